@@ -3,12 +3,15 @@ package agent
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"reflect"
+	"sort"
 	"strings"
 	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/devalexandre/agno-golang/agno/knowledge"
 	"github.com/devalexandre/agno-golang/agno/memory"
@@ -40,6 +43,9 @@ type AgentConfig struct {
 	ShowToolsCall  bool
 	ShowSkillCall  bool
 	Debug          bool
+	// Logger receives the debug and trace output of the agent as structured
+	// events instead of text, e.g. NewJSONLogger(os.Stderr). See Logger.
+	Logger Logger
 	//--- ChainTool Configuration ---
 	// Enable ChainTool mode: Agent calls 1 tool, result propagates through all others
 	EnableChainTool bool
@@ -76,6 +82,7 @@ type AgentConfig struct {
 	EnableReadChatHistoryTool     bool // Enable read_chat_history default tool
 	EnableUpdateKnowledgeTool     bool // Enable update_knowledge default tool
 	EnableReadToolCallHistoryTool bool // Enable read_tool_call_history default tool
+	EnableMemoryRecallTool        bool // Enable recall_memory default tool (requires Memory)
 
 	//knowledge
 	Knowledge             knowledge.Knowledge
@@ -147,6 +154,9 @@ type AgentConfig struct {
 
 	// ParseResponse controls whether to parse the response into the OutputSchema
 	ParseResponse bool
+	// JSONRepair repairs malformed JSON (code fences, trailing commas, single
+	// quotes, truncation) before parsing it into the OutputSchema. See RepairJSON.
+	JSONRepair bool
 
 	// --- Hooks ---
 	// Functions called before processing starts (for validation, logging, etc.)
@@ -157,6 +167,8 @@ type AgentConfig struct {
 	ToolBeforeHooks []func(ctx context.Context, toolName string, args map[string]interface{}) error
 	// ToolAfterHooks are called after a tool is executed
 	ToolAfterHooks []func(ctx context.Context, toolName string, args map[string]interface{}, result interface{}) error
+	// ToolAuditSink records every tool call (name, args, result, duration, error)
+	ToolAuditSink ToolAuditSink
 
 	// --- Guardrails ---
 	// InputGuardrails validate input before processing
@@ -165,12 +177,47 @@ type AgentConfig struct {
 	OutputGuardrails []Guardrail
 	// ToolGuardrails validate tool calls
 	ToolGuardrails []Guardrail
+	// StreamGuardrailMode applies OutputGuardrails to RunStream and
+	// RunStreamEvents by buffering output until it passes (default: off)
+	StreamGuardrailMode StreamGuardrailMode
+
+	// --- Output Transformation ---
+	// OutputTransform rewrites RunResponse.TextContent after output guardrails pass
+	// (e.g. masking emails). Unlike guardrails, which only block, it can change
+	// the final answer. Returning an error aborts the run.
+	OutputTransform func(string) (string, error)
 
 	// --- Tool Management ---
 	// Maximum number of tool calls allowed per run
 	ToolCallLimit int
-	// Controls which tool is called: "none", "auto", or specific tool name
+	// Controls which tool is called: "none", "auto", "required", or a tool
+	// method name to force. WithToolChoice and WithForcedTool override it per run.
 	ToolChoice string
+	// ToolConcurrency caps the concurrent calls of a tool, by tool name, in
+	// ExecuteToolCallsParallel, e.g. 1 for a rate-limited API. See WithToolConcurrency.
+	ToolConcurrency map[string]int
+	// ToolTimeout bounds every tool call. A call that takes longer is
+	// abandoned and the model gets a timeout error as the tool result, so it
+	// can retry or try something else. Tools implementing
+	// toolkit.ContextTool are cancelled at the deadline; others finish in
+	// the background and their result is discarded. Zero means no limit.
+	ToolTimeout time.Duration
+	// ToolTimeouts overrides ToolTimeout by tool name, e.g. a longer limit
+	// for a shell tool. Zero disables the limit for that tool. See WithToolTimeout.
+	ToolTimeouts map[string]time.Duration
+	// Timeout bounds every run, all model calls and tool loops included. A run
+	// that takes longer fails with an error matching context.DeadlineExceeded,
+	// e.g. to enforce HTTP request timeouts server-side. Zero means no limit;
+	// WithTimeout overrides it per run.
+	Timeout time.Duration
+	// MaxTokens caps the length of every model response, sent as the model's
+	// max tokens parameter (num_predict for Ollama). Zero keeps the model
+	// default; WithMaxTokens overrides it per run.
+	MaxTokens int
+	// MaxToolOutputBytes truncates tool results longer than this many bytes
+	// before they are sent to the model, with a "[truncated N bytes]" marker.
+	// Zero means no limit.
+	MaxToolOutputBytes int
 
 	// --- Context Building ---
 	// If True, add the agent name to the system message
@@ -227,7 +274,8 @@ type Agent struct {
 	showToolsCall          bool
 	showSkillCall          bool
 	debug                  bool
-	enableChainTool        bool // If true, Agent calls 1 tool and propagates result
+	logger                 Logger // nil prints debug output as text
+	enableChainTool        bool   // If true, Agent calls 1 tool and propagates result
 	chainToolErrorConfig   *ChainToolErrorConfig
 	chainToolErrorHandler  ChainToolErrorHandler
 	chainToolCache         ChainToolCache
@@ -303,21 +351,39 @@ type Agent struct {
 	lastLearningRetrievedIDsByUser map[string][]string
 
 	parseResponse bool
+	jsonRepair    bool
 
 	// Hooks
 	preHooks        []func(ctx context.Context, input interface{}) error
 	postHooks       []func(ctx context.Context, output *models.RunResponse) error
 	toolBeforeHooks []func(ctx context.Context, toolName string, args map[string]interface{}) error
 	toolAfterHooks  []func(ctx context.Context, toolName string, args map[string]interface{}, result interface{}) error
+	toolAuditSink   ToolAuditSink
 
 	// Guardrails
 	inputGuardrails  []Guardrail
 	outputGuardrails []Guardrail
 	toolGuardrails   []Guardrail
 
+	streamGuardrailMode StreamGuardrailMode
+
+	// Output Transformation
+	outputTransform func(string) (string, error)
+
 	// Tool Management
 	toolCallLimit int
 	toolChoice    string
+	// toolSemaphores limit concurrent calls per tool name (see ToolConcurrency)
+	toolSemaphores map[string]chan struct{}
+	// toolTimeout bounds each tool call, toolTimeouts by tool name (0 = no limit)
+	toolTimeout  time.Duration
+	toolTimeouts map[string]time.Duration
+	// maxToolOutputBytes limits the tool result sent to the model (0 = no limit)
+	maxToolOutputBytes int
+	// timeout bounds each run (0 = no limit)
+	timeout time.Duration
+	// maxTokens caps each model response (0 = model default)
+	maxTokens int
 
 	// Context Building
 	addNameToContext     bool
@@ -345,6 +411,10 @@ type Agent struct {
 	enableReadChatHistoryTool     bool // Enable read_chat_history default tool
 	enableUpdateKnowledgeTool     bool // Enable update_knowledge default tool
 	enableReadToolCallHistoryTool bool // Enable read_tool_call_history default tool
+	enableMemoryRecallTool        bool // Enable recall_memory default tool
+
+	// config is the configuration the agent was created with, used by Clone
+	config AgentConfig
 }
 
 // Ensure Agent implements models.AgentInterface
@@ -355,6 +425,7 @@ func NewAgent(config AgentConfig) (*Agent, error) {
 	if config.Context == nil {
 		config.Context = context.Background()
 	}
+	baseConfig := config
 
 	config.Context = context.WithValue(config.Context, models.DebugKey, config.Debug)
 	config.Context = context.WithValue(config.Context, models.ShowToolsCallKey, config.ShowToolsCall)
@@ -405,6 +476,7 @@ func NewAgent(config AgentConfig) (*Agent, error) {
 		showToolsCall:         config.ShowToolsCall,
 		showSkillCall:         config.ShowSkillCall,
 		debug:                 config.Debug,
+		logger:                config.Logger,
 		enableChainTool:       config.EnableChainTool,
 		chainToolErrorConfig:  config.ChainToolErrorConfig,
 		chainToolErrorHandler: config.ChainToolErrorHandler,
@@ -473,21 +545,35 @@ func NewAgent(config AgentConfig) (*Agent, error) {
 		lastLearningRetrievedIDsByUser: make(map[string][]string),
 
 		parseResponse: config.ParseResponse,
+		jsonRepair:    config.JSONRepair,
 
 		// Hooks
 		preHooks:        config.PreHooks,
 		postHooks:       config.PostHooks,
 		toolBeforeHooks: config.ToolBeforeHooks,
 		toolAfterHooks:  config.ToolAfterHooks,
+		toolAuditSink:   config.ToolAuditSink,
 
 		// Guardrails
 		inputGuardrails:  config.InputGuardrails,
 		outputGuardrails: config.OutputGuardrails,
 		toolGuardrails:   config.ToolGuardrails,
 
+		streamGuardrailMode: config.StreamGuardrailMode,
+
+		// Output Transformation
+		outputTransform: config.OutputTransform,
+
 		// Tool Management
-		toolCallLimit: config.ToolCallLimit,
-		toolChoice:    config.ToolChoice,
+		toolCallLimit:  config.ToolCallLimit,
+		toolChoice:     config.ToolChoice,
+		toolSemaphores: newToolSemaphores(config.ToolConcurrency),
+		toolTimeout:    config.ToolTimeout,
+		toolTimeouts:   config.ToolTimeouts,
+
+		maxToolOutputBytes: config.MaxToolOutputBytes,
+		timeout:            config.Timeout,
+		maxTokens:          config.MaxTokens,
 
 		// Context Building
 		addNameToContext:     config.AddNameToContext,
@@ -515,18 +601,20 @@ func NewAgent(config AgentConfig) (*Agent, error) {
 		enableReadChatHistoryTool:     config.EnableReadChatHistoryTool,
 		enableUpdateKnowledgeTool:     config.EnableUpdateKnowledgeTool,
 		enableReadToolCallHistoryTool: config.EnableReadToolCallHistoryTool,
-	}
+		enableMemoryRecallTool:        config.EnableMemoryRecallTool,
 
-	// Wrap tools with hooks if configured
-	if len(config.ToolBeforeHooks) > 0 || len(config.ToolAfterHooks) > 0 || len(config.ToolGuardrails) > 0 || config.EnableChainTool {
-		agent.tools = agent.WrapToolsWithHooks(agent.tools)
+		config: baseConfig,
 	}
 
+	// Wrap tools with hooks if configured, and tools that take the run context
+	agent.tools = agent.WrapToolsWithHooks(agent.tools)
+
 	// Add default tools if enabled
 	defaultTools := CreateDefaultTools(agent, DefaultToolsConfig{
 		EnableReadChatHistory:     config.EnableReadChatHistoryTool,
 		EnableUpdateKnowledge:     config.EnableUpdateKnowledgeTool,
 		EnableReadToolCallHistory: config.EnableReadToolCallHistoryTool,
+		EnableMemoryRecall:        config.EnableMemoryRecallTool,
 	})
 	if len(defaultTools) > 0 {
 		agent.tools = append(agent.tools, defaultTools...)
@@ -597,6 +685,10 @@ func NewAgent(config AgentConfig) (*Agent, error) {
 		return nil, fmt.Errorf("semantic compression is enabled but no semantic model or agent provided")
 	}
 
+	if err := agent.checkToolChoice(ToolChoice(agent.toolChoice)); err != nil {
+		return nil, err
+	}
+
 	// Load existing session if storage is provided
 	if agent.db != nil {
 		agent.loadSession()
@@ -621,6 +713,11 @@ func (a *Agent) GetRole() string {
 	return "Assistant"
 }
 
+// GetDescription returns the agent's description
+func (a *Agent) GetDescription() string {
+	return a.description
+}
+
 // GetModel returns the agent's model
 func (a *Agent) GetModel() models.AgnoModelInterface {
 	return a.model
@@ -897,52 +994,239 @@ func (a *Agent) ExecuteToolAfterHooks(ctx context.Context, toolName string, args
 	return nil
 }
 
+// applyOutputTransform rewrites the response text with the run-level transform,
+// falling back to the agent-level OutputTransform when none is set for the run.
+func (a *Agent) applyOutputTransform(options *RunOptions, runResponse *models.RunResponse) error {
+	transform := a.outputTransform
+	if options != nil && options.OutputTransform != nil {
+		transform = options.OutputTransform
+	}
+	if transform == nil {
+		return nil
+	}
+
+	transformed, err := transform(runResponse.TextContent)
+	if err != nil {
+		return fmt.Errorf("output transform failed: %w", err)
+	}
+	runResponse.TextContent = transformed
+	return nil
+}
+
 // ToolWrapper wraps a tool with before/after hooks
 type ToolWrapper struct {
 	toolkit.Tool
 	agent *Agent
+	run   *runState // the run the tool is bound to, nil outside runs
 }
 
-// Execute wraps the original Execute method with hooks and guardrails
-func (tw *ToolWrapper) Execute(methodName string, input json.RawMessage) (interface{}, error) {
+// Execute wraps the original Execute method with hooks, guardrails and auditing
+func (tw *ToolWrapper) Execute(methodName string, input json.RawMessage) (result interface{}, err error) {
+	ctx := tw.agent.ctx
+	if tw.run != nil {
+		ctx = tw.run.ctx
+		if err := tw.run.beginToolCall(tw.GetName() + "." + methodName); err != nil {
+			return nil, err
+		}
+		defer func() { tw.run.endToolCall(tw.GetName()+"."+methodName, toolCallError(result, err)) }()
+	}
+
 	// Parse input to map for hooks
 	var inputMap map[string]interface{}
 	if err := json.Unmarshal(input, &inputMap); err != nil {
 		inputMap = make(map[string]interface{})
-	} // Execute tool guardrails
+	}
+
+	if tw.agent.toolAuditSink != nil {
+		start := time.Now()
+		defer func() {
+			execution := ToolExecution{
+				AgentName: tw.agent.name,
+				ToolName:  tw.GetName() + "." + methodName,
+				Args:      inputMap,
+				Result:    result,
+				Duration:  time.Since(start),
+				Timestamp: start,
+			}
+			if callErr := toolCallError(result, err); callErr != nil {
+				execution.Error = callErr.Error()
+			}
+			tw.agent.toolAuditSink.Record(execution)
+		}()
+	}
+
+	if tw.agent.logger != nil {
+		start := time.Now()
+		defer func() {
+			fields := map[string]interface{}{
+				"tool":        tw.GetName() + "." + methodName,
+				"duration_ms": time.Since(start).Milliseconds(),
+			}
+			level := LogLevelInfo
+			if callErr := toolCallError(result, err); callErr != nil {
+				level = LogLevelError
+				fields["error"] = callErr
+			}
+			tw.agent.logEvent(level, "tool_call", fields)
+		}()
+	}
+
+	// Execute tool guardrails
 	if len(tw.agent.toolGuardrails) > 0 {
 		toolCallData := map[string]interface{}{
 			"tool_name":   tw.GetName() + "." + methodName,
 			"method_name": methodName,
 			"arguments":   inputMap,
 		}
-		if err := RunGuardrails(tw.agent.ctx, tw.agent.toolGuardrails, toolCallData); err != nil {
+		if err := tw.agent.runGuardrails(ctx, GuardrailStageTool, tw.agent.toolGuardrails, toolCallData); err != nil {
 			return nil, fmt.Errorf("tool guardrail validation failed: %w", err)
 		}
 	}
 
 	// Execute before hooks
-	if err := tw.agent.ExecuteToolBeforeHooks(tw.agent.ctx, tw.GetName()+"."+methodName, inputMap); err != nil {
+	if err := tw.agent.ExecuteToolBeforeHooks(ctx, tw.GetName()+"."+methodName, inputMap); err != nil {
 		return nil, err
 	}
 
-	// Execute original tool
-	result, err := tw.Tool.Execute(methodName, input)
+	// Execute original tool, passing the run context to tools that accept it
+	if timeout := tw.agent.toolTimeoutFor(tw.GetName()); timeout > 0 {
+		result, err = tw.executeWithTimeout(ctx, timeout, methodName, input)
+	} else {
+		result, err = tw.execute(ctx, methodName, input)
+	}
+	var timeoutErr *ToolTimeoutError
+	if errors.As(err, &timeoutErr) {
+		// Give the model the timeout as the tool result so the run goes on.
+		// The audit sink still records it as an error.
+		result = toolkit.NewToolResult(fmt.Sprintf("Error: %v. Try again with a smaller request or use another approach.", timeoutErr), timeoutErr, "")
+		if hookErr := tw.agent.ExecuteToolAfterHooks(ctx, tw.GetName()+"."+methodName, inputMap, result); hookErr != nil {
+			return result, hookErr
+		}
+		return result, nil
+	}
 	if err != nil {
-		return result, err
+		return result, &ToolExecutionError{Tool: tw.GetName(), Method: methodName, Err: err}
 	}
 
 	// Execute after hooks
-	if err := tw.agent.ExecuteToolAfterHooks(tw.agent.ctx, tw.GetName()+"."+methodName, inputMap, result); err != nil {
+	if err := tw.agent.ExecuteToolAfterHooks(ctx, tw.GetName()+"."+methodName, inputMap, result); err != nil {
 		return result, err
 	}
 
-	return result, nil
+	return tw.agent.limitToolOutput(tw.GetName()+"."+methodName, result), nil
+}
+
+// toolCallError returns the error of a tool call, including a timeout that
+// Execute turned into a result for the model
+func toolCallError(result interface{}, err error) error {
+	if err != nil {
+		return err
+	}
+	if r, ok := toolkit.AsToolResult(result); ok {
+		if timeoutErr, ok := r.Data.(*ToolTimeoutError); ok {
+			return timeoutErr
+		}
+	}
+	return nil
+}
+
+// execute runs the wrapped tool, passing ctx to tools that accept it
+func (tw *ToolWrapper) execute(ctx context.Context, methodName string, input json.RawMessage) (interface{}, error) {
+	if contextTool, ok := tw.Tool.(toolkit.ContextTool); ok {
+		return contextTool.ExecuteContext(ctx, methodName, input)
+	}
+	return tw.Tool.Execute(methodName, input)
+}
+
+// executeWithTimeout runs the wrapped tool under a deadline and returns a
+// *ToolTimeoutError when it passes. The call runs in its own goroutine, which
+// exits as soon as the tool returns, even when nobody waits for the result.
+func (tw *ToolWrapper) executeWithTimeout(ctx context.Context, timeout time.Duration, methodName string, input json.RawMessage) (interface{}, error) {
+	callCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type outcome struct {
+		result interface{}
+		err    error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			// A panic in this goroutine would crash the program, not the call
+			if r := recover(); r != nil {
+				done <- outcome{err: fmt.Errorf("tool panicked: %v", r)}
+			}
+		}()
+		result, err := tw.execute(callCtx, methodName, input)
+		done <- outcome{result, err}
+	}()
+
+	select {
+	case out := <-done:
+		// A context tool stopped by the deadline failed because of it
+		if out.err != nil && callCtx.Err() == context.DeadlineExceeded && ctx.Err() == nil {
+			return nil, &ToolTimeoutError{Tool: tw.GetName(), Method: methodName, Timeout: timeout}
+		}
+		return out.result, out.err
+	case <-callCtx.Done():
+		if ctx.Err() != nil {
+			// The run itself was cancelled or timed out
+			return nil, ctx.Err()
+		}
+		return nil, &ToolTimeoutError{Tool: tw.GetName(), Method: methodName, Timeout: timeout}
+	}
+}
+
+// toolTimeoutFor returns the time limit of a tool call (0 = no limit)
+func (a *Agent) toolTimeoutFor(toolName string) time.Duration {
+	if timeout, ok := a.toolTimeouts[toolName]; ok {
+		return timeout
+	}
+	return a.toolTimeout
 }
 
-// WrapToolsWithHooks wraps tools with before/after hooks and guardrails if configured
+// limitToolOutput truncates the model-facing content of a tool result to
+// MaxToolOutputBytes. The Data of a toolkit.ToolResult is kept whole for
+// downstream code; only its Content is truncated.
+func (a *Agent) limitToolOutput(toolName string, result interface{}) interface{} {
+	if a.maxToolOutputBytes <= 0 {
+		return result
+	}
+
+	var content string
+	switch v := toolkit.ModelContent(result).(type) {
+	case string:
+		content = v
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return result
+		}
+		content = string(data)
+	}
+	if len(content) <= a.maxToolOutputBytes {
+		return result
+	}
+
+	// Cut at a rune boundary so the model never sees broken UTF-8
+	cut := a.maxToolOutputBytes
+	for cut > 0 && !utf8.RuneStart(content[cut]) {
+		cut--
+	}
+	truncated := fmt.Sprintf("%s\n[truncated %d bytes]", content[:cut], len(content)-cut)
+	log.Printf("Warning: output of tool %s truncated from %d to %d bytes (MaxToolOutputBytes)", toolName, len(content), cut)
+
+	if r, ok := toolkit.AsToolResult(result); ok {
+		r.Content = truncated
+		return r
+	}
+	return truncated
+}
+
+// WrapToolsWithHooks wraps tools with before/after hooks and guardrails if
+// configured, and tools that take the run context
 func (a *Agent) WrapToolsWithHooks(tools []toolkit.Tool) []toolkit.Tool {
-	if len(a.toolBeforeHooks) == 0 && len(a.toolAfterHooks) == 0 && len(a.toolGuardrails) == 0 && !a.enableChainTool {
+	if len(a.toolBeforeHooks) == 0 && len(a.toolAfterHooks) == 0 && len(a.toolGuardrails) == 0 && a.toolAuditSink == nil && a.logger == nil && !a.enableChainTool && a.maxToolOutputBytes <= 0 && !hasContextTools(tools) {
 		return tools
 	}
 
@@ -957,6 +1241,16 @@ func (a *Agent) WrapToolsWithHooks(tools []toolkit.Tool) []toolkit.Tool {
 	return wrappedTools
 }
 
+// hasContextTools reports whether any tool accepts the run context
+func hasContextTools(tools []toolkit.Tool) bool {
+	for _, tool := range tools {
+		if _, ok := tool.(toolkit.ContextTool); ok {
+			return true
+		}
+	}
+	return false
+}
+
 // truncateString truncates a string to maxLen characters
 func truncateString(s string, maxLen int) string {
 	if len(s) <= maxLen {
@@ -1181,9 +1475,12 @@ func (a *Agent) parseOutputWithSchema(response string) (interface{}, error) {
 	}
 
 	cleaned = strings.TrimSpace(cleaned)
+	if a.jsonRepair {
+		cleaned = RepairJSON(cleaned)
+	}
 
 	// If debug mode, show what we're trying to parse
-	if a.debug {
+	if a.debugText() {
 		fmt.Printf("\n=== DEBUG: Output Parsing ===\n")
 		fmt.Printf("Original response length: %d\n", len(originalResponse))
 		fmt.Printf("Cleaned response length: %d\n", len(cleaned))
@@ -1191,6 +1488,10 @@ func (a *Agent) parseOutputWithSchema(response string) (interface{}, error) {
 		fmt.Printf("Cleaned response preview (first 200 chars):\n%s\n", truncateString(cleaned, 200))
 		fmt.Printf("===========================\n\n")
 	}
+	a.logEvent(LogLevelDebug, "output_parsing", map[string]interface{}{
+		"response_length": len(originalResponse),
+		"cleaned_length":  len(cleaned),
+	})
 
 	// Get schema type
 	schemaType := reflect.TypeOf(a.outputSchema)
@@ -1207,16 +1508,14 @@ func (a *Agent) parseOutputWithSchema(response string) (interface{}, error) {
 		if isPointer {
 			// If outputSchema is a pointer, unmarshal directly into it
 			if err := json.Unmarshal([]byte(cleaned), a.outputSchema); err != nil {
-				preview := truncateString(cleaned, 500)
-				return nil, fmt.Errorf("failed to parse response into output schema (slice): %w\nResponse preview: %s", err, preview)
+				return nil, newParseError(schemaType, cleaned, err)
 			}
 			result = a.outputSchema
 		} else {
 			// For slices without pointer, create a new slice
 			result = reflect.New(schemaType).Interface()
 			if err := json.Unmarshal([]byte(cleaned), result); err != nil {
-				preview := truncateString(cleaned, 500)
-				return nil, fmt.Errorf("failed to parse response into output schema (slice): %w\nResponse preview: %s", err, preview)
+				return nil, newParseError(schemaType, cleaned, err)
 			}
 		}
 
@@ -1229,16 +1528,14 @@ func (a *Agent) parseOutputWithSchema(response string) (interface{}, error) {
 	if isPointer {
 		// If outputSchema is a pointer, unmarshal directly into it
 		if err := json.Unmarshal([]byte(cleaned), a.outputSchema); err != nil {
-			preview := truncateString(cleaned, 500)
-			return nil, fmt.Errorf("failed to parse response into output schema: %w\nResponse preview: %s", err, preview)
+			return nil, newParseError(schemaType, cleaned, err)
 		}
 		result = a.outputSchema
 	} else {
 		// For structs without pointer, create a new instance
 		result = reflect.New(schemaType).Interface()
 		if err := json.Unmarshal([]byte(cleaned), result); err != nil {
-			preview := truncateString(cleaned, 500)
-			return nil, fmt.Errorf("failed to parse response into output schema: %w\nResponse preview: %s", err, preview)
+			return nil, newParseError(schemaType, cleaned, err)
 		}
 	}
 
@@ -1249,22 +1546,67 @@ func (a *Agent) parseOutputWithSchema(response string) (interface{}, error) {
 // Similar to ApplySemanticCompression, this method handles the logic of using
 // a separate model for JSON formatting or falling back to direct parsing
 func (a *Agent) ApplyOutputFormatting(response string) (interface{}, error) {
+	return a.applyOutputFormatting(a.ctx, response)
+}
+
+// applyOutputFormatting is ApplyOutputFormatting within the run context ctx
+func (a *Agent) applyOutputFormatting(ctx context.Context, response string) (interface{}, error) {
 	if a.outputSchema == nil || !a.parseResponse {
 		return response, nil
 	}
 
 	// If OutputModel is configured, use it for JSON formatting
 	if a.outputModel != nil {
-		return a.formatWithOutputModel(response)
+		return a.formatWithOutputModel(ctx, response)
 	}
 
 	// Otherwise, parse directly from the response
 	return a.parseOutputWithSchema(response)
 }
 
+// parseRunOutput parses resp into the output schema, through the ParserModel
+// when one is configured. A response that does not parse is sent back to the
+// model with the parse error, up to retries times, and each of those retries
+// is charged to the run's retry budget. It returns the response that was parsed.
+func (a *Agent) parseRunOutput(run *runState, messages []models.Message, resp *models.MessageResponse, retries int) (*models.MessageResponse, interface{}, error) {
+	for attempt := 0; ; attempt++ {
+		responseContent := resp.Content
+		if a.parserModel != nil {
+			parsed, err := a.parseResponseWithParserModel(run.ctx, resp.Content)
+			if err != nil {
+				log.Printf("Warning: ParserModel failed, using original response: %v", err)
+			} else {
+				responseContent = parsed
+			}
+		}
+
+		parsedContent, err := a.applyOutputFormatting(run.ctx, responseContent)
+		var parseErr *ParseError
+		if err == nil || attempt >= retries || !errors.As(err, &parseErr) {
+			return resp, parsedContent, err
+		}
+		if budgetErr := run.retryBudget.consume("parse", err); budgetErr != nil {
+			return resp, nil, budgetErr
+		}
+		a.logRetry(attempt+1, retries, err)
+
+		messages = append(messages,
+			models.Message{Role: models.TypeAssistantRole, Content: resp.Content},
+			models.Message{Role: models.TypeUserRole, Content: fmt.Sprintf("Your response could not be parsed (%v). Answer again, following the required output format.", parseErr.Err)},
+		)
+		callStart := time.Now()
+		next, err := a.activeModel(run).Invoke(run.ctx, messages, a.modelCallOptions(run, models.WithTools(run.tools))...)
+		a.logModelCall(a.activeModel(run), callStart, attempt+1, len(messages), err)
+		if err != nil {
+			return resp, nil, a.newModelError(run, err)
+		}
+		resp = next
+	}
+}
+
 // formatWithOutputModel uses the OutputModel to convert response to structured JSON
-func (a *Agent) formatWithOutputModel(response string) (interface{}, error) {
-	if a.debug {
+func (a *Agent) formatWithOutputModel(ctx context.Context, response string) (interface{}, error) {
+	if a.debugText() {
 		fmt.Printf("\n=== DEBUG: Using OutputModel for JSON formatting ===\n")
 		fmt.Printf("Original response length: %d\n", len(response))
 		fmt.Printf("OutputModel: %T\n", a.outputModel)
@@ -1314,7 +1656,9 @@ CRITICAL RULES:
 	}
 
 	// Invoke the output model
-	resp, err := a.outputModel.Invoke(a.ctx, messages)
+	callStart := time.Now()
+	resp, err := a.outputModel.Invoke(ctx, messages)
+	a.logModelCall(a.outputModel, callStart, 0, len(messages), err)
 	if err != nil {
 		return nil, fmt.Errorf("output model invocation failed: %w", err)
 	}
@@ -1338,8 +1682,11 @@ CRITICAL RULES:
 	}
 
 	cleaned = strings.TrimSpace(cleaned)
+	if a.jsonRepair {
+		cleaned = RepairJSON(cleaned)
+	}
 
-	if a.debug {
+	if a.debugText() {
 		fmt.Printf("\n=== DEBUG: OutputModel Response ===\n")
 		fmt.Printf("Cleaned JSON length: %d\n", len(cleaned))
 		fmt.Printf("JSON preview (first 500 chars):\n%s\n", truncateString(cleaned, 500))
@@ -1353,8 +1700,8 @@ CRITICAL RULES:
 // parseResponseWithParserModel uses the ParserModel to parse and structure unstructured responses
 // This is different from OutputModel - ParserModel is used when the main model returns free-form text
 // that needs to be converted to structured data, while OutputModel is used for JSON formatting
-func (a *Agent) parseResponseWithParserModel(response string) (string, error) {
-	if a.debug {
+func (a *Agent) parseResponseWithParserModel(ctx context.Context, response string) (string, error) {
+	if a.debugText() {
 		fmt.Printf("\n=== DEBUG: Using ParserModel for response parsing ===\n")
 		fmt.Printf("Original response length: %d\n", len(response))
 		fmt.Printf("ParserModel: %T\n", a.parserModel)
@@ -1390,14 +1737,16 @@ CRITICAL RULES:
 	}
 
 	// Invoke the parser model
-	resp, err := a.parserModel.Invoke(a.ctx, messages)
+	callStart := time.Now()
+	resp, err := a.parserModel.Invoke(ctx, messages)
+	a.logModelCall(a.parserModel, callStart, 0, len(messages), err)
 	if err != nil {
 		return "", fmt.Errorf("parser model invocation failed: %w", err)
 	}
 
 	parsed := strings.TrimSpace(resp.Content)
 
-	if a.debug {
+	if a.debugText() {
 		fmt.Printf("\n=== DEBUG: ParserModel Response ===\n")
 		fmt.Printf("Parsed response length: %d\n", len(parsed))
 		fmt.Printf("Response preview (first 500 chars):\n%s\n", truncateString(parsed, 500))
@@ -1423,15 +1772,13 @@ func (a *Agent) unmarshalIntoSchema(jsonStr string) (interface{}, error) {
 
 		if isPointer {
 			if err := json.Unmarshal([]byte(jsonStr), a.outputSchema); err != nil {
-				preview := truncateString(jsonStr, 500)
-				return nil, fmt.Errorf("failed to parse output model response (slice): %w\nResponse preview: %s", err, preview)
+				return nil, newParseError(schemaType, jsonStr, err)
 			}
 			result = a.outputSchema
 		} else {
 			result = reflect.New(schemaType).Interface()
 			if err := json.Unmarshal([]byte(jsonStr), result); err != nil {
-				preview := truncateString(jsonStr, 500)
-				return nil, fmt.Errorf("failed to parse output model response (slice): %w\nResponse preview: %s", err, preview)
+				return nil, newParseError(schemaType, jsonStr, err)
 			}
 		}
 
@@ -1443,15 +1790,13 @@ func (a *Agent) unmarshalIntoSchema(jsonStr string) (interface{}, error) {
 
 	if isPointer {
 		if err := json.Unmarshal([]byte(jsonStr), a.outputSchema); err != nil {
-			preview := truncateString(jsonStr, 500)
-			return nil, fmt.Errorf("failed to parse output model response: %w\nResponse preview: %s", err, preview)
+			return nil, newParseError(schemaType, jsonStr, err)
 		}
 		result = a.outputSchema
 	} else {
 		result = reflect.New(schemaType).Interface()
 		if err := json.Unmarshal([]byte(jsonStr), result); err != nil {
-			preview := truncateString(jsonStr, 500)
-			return nil, fmt.Errorf("failed to parse output model response: %w\nResponse preview: %s", err, preview)
+			return nil, newParseError(schemaType, jsonStr, err)
 		}
 	}
 
@@ -1459,15 +1804,21 @@ func (a *Agent) unmarshalIntoSchema(jsonStr string) (interface{}, error) {
 }
 
 // RunWithOptions is the new method with full options support
-func (a *Agent) RunWithOptions(input interface{}, opts ...interface{}) (models.RunResponse, error) {
+func (a *Agent) RunWithOptions(input interface{}, opts ...interface{}) (_ models.RunResponse, err error) {
 	// Apply options
-	options := &RunOptions{}
-	for _, opt := range opts {
-		if runOpt, ok := opt.(func(*RunOptions)); ok {
-			runOpt(options)
-		}
+	options := applyRunOptions(opts)
+	if err := options.validate(); err != nil {
+		return models.RunResponse{}, err
+	}
+	if err := a.checkToolChoice(options.ToolChoice); err != nil {
+		return models.RunResponse{}, err
 	}
 
+	// Bound the whole run, model calls and tool loops included, by the timeout.
+	// The run state holds the run options, so overlapping runs do not mix them.
+	run := a.startRun(options.runContext, options)
+	defer func() { err = run.finish(err) }()
+
 	// Override agent settings with run options if provided
 	if options.SessionID != nil {
 		a.sessionID = *options.SessionID
@@ -1544,7 +1895,7 @@ func (a *Agent) RunWithOptions(input interface{}, opts ...interface{}) (models.R
 	}
 
 	// Add system message and history normally
-	baseMessages := a.prepareMessages(prompt, options.KnowledgeFilters)
+	baseMessages := a.prepareMessages(run.ctx, prompt, options.KnowledgeFilters)
 	for _, msg := range baseMessages {
 		if msg.Role == models.TypeUserRole {
 			messages = append(messages, msg)
@@ -1575,7 +1926,7 @@ func (a *Agent) RunWithOptions(input interface{}, opts ...interface{}) (models.R
 	if a.reasoning && a.reasoningModel != nil {
 		// use default reasoning agent
 		if a.reasoningAgent == nil {
-			reasoningAgent := NewReasoningAgent(a.ctx, a.reasoningModel, a.tools, a.reasoningMinSteps, a.reasoningMaxSteps)
+			reasoningAgent := NewReasoningAgent(run.ctx, a.reasoningModel, run.tools, a.reasoningMinSteps, a.reasoningMaxSteps)
 			// Use the reasoning agent directly without assigning to interface
 			reasoningSteps, err := reasoningAgent.Reason(prompt)
 			if err == nil && len(reasoningSteps) > 0 {
@@ -1640,35 +1991,40 @@ func (a *Agent) RunWithOptions(input interface{}, opts ...interface{}) (models.R
 	var lastErr error
 
 	for attempt := 0; attempt <= retries; attempt++ {
-		if a.debug && attempt > 0 {
-			fmt.Printf("Retry attempt %d/%d\n", attempt, retries)
+		if attempt > 0 {
+			a.logRetry(attempt, retries, lastErr)
 		}
 
-		resp, lastErr = a.model.Invoke(a.ctx, messages, models.WithTools(a.tools))
+		callStart := time.Now()
+		resp, lastErr = a.activeModel(run).Invoke(run.ctx, messages, a.modelCallOptions(run, models.WithTools(run.tools))...)
+		a.logModelCall(a.activeModel(run), callStart, attempt, len(messages), lastErr)
 		if lastErr == nil {
 			break
 		}
 
 		if attempt < retries {
+			if err := run.retryBudget.consume("model", lastErr); err != nil {
+				return models.RunResponse{}, err
+			}
 			time.Sleep(time.Second * time.Duration(attempt+1))
 		}
 	}
 
 	if lastErr != nil {
-		return models.RunResponse{}, lastErr
+		return models.RunResponse{}, a.newModelError(run, lastErr)
 	}
 
 	// Save run to storage if enabled
 	if a.db != nil {
-		if err := a.saveRun(prompt, resp.Content, messages); err != nil && a.debug {
-			fmt.Printf("Warning: Failed to save run: %v\n", err)
+		if err := a.saveRun(run.ctx, prompt, resp.Content, messages); err != nil {
+			a.logWarning("save_run_failed", "Failed to save run", err)
 		}
 	}
 
 	// Process memories if enabled
 	if a.memory != nil {
-		if err := a.processMemories(prompt, resp.Content); err != nil && a.debug {
-			fmt.Printf("Warning: Failed to process memories: %v\n", err)
+		if err := a.processMemories(run.ctx, prompt, resp.Content); err != nil {
+			a.logWarning("process_memories_failed", "Failed to process memories", err)
 		}
 	}
 
@@ -1690,7 +2046,7 @@ func (a *Agent) RunWithOptions(input interface{}, opts ...interface{}) (models.R
 			if options.KnowledgeFilters != nil {
 				meta["knowledge_filters"] = options.KnowledgeFilters
 			}
-			if err := lm.ObserveAndLearn(a.ctx, a.userID, prompt, resp.Content, meta); err != nil {
+			if err := lm.ObserveAndLearn(run.ctx, a.userID, prompt, resp.Content, meta); err != nil {
 				log.Printf("Warning: Learning observe failed: %v", err)
 			}
 			delete(a.lastLearningRetrievedIDsByUser, a.userID)
@@ -1726,19 +2082,8 @@ func (a *Agent) RunWithOptions(input interface{}, opts ...interface{}) (models.R
 		}
 	}
 
-	// Step 1: Parse response with ParserModel if configured
-	responseContent := resp.Content
-	if a.parserModel != nil {
-		parsed, err := a.parseResponseWithParserModel(resp.Content)
-		if err != nil {
-			log.Printf("Warning: ParserModel failed, using original response: %v", err)
-		} else {
-			responseContent = parsed
-		}
-	}
-
-	// Step 2: Parse output using ApplyOutputFormatting method
-	parsedContent, err := a.ApplyOutputFormatting(responseContent)
+	// Parse the response into the output schema, asking the model again when it does not parse
+	resp, parsedContent, err := a.parseRunOutput(run, messages, resp, retries)
 	if err != nil {
 		return models.RunResponse{}, err
 	}
@@ -1749,7 +2094,7 @@ func (a *Agent) RunWithOptions(input interface{}, opts ...interface{}) (models.R
 		outputContent = parsedContent
 	}
 
-	return models.RunResponse{
+	runResponse := models.RunResponse{
 		TextContent:  resp.Content, // Original response from main model
 		ContentType:  "text",
 		Event:        "RunResponse",
@@ -1764,25 +2109,39 @@ func (a *Agent) RunWithOptions(input interface{}, opts ...interface{}) (models.R
 			},
 		},
 		Model:     resp.Model,
+		Metrics:   run.metrics(),
 		CreatedAt: time.Now().Unix(),
-	}, nil
+	}
+
+	// Apply output transformation
+	if err := a.applyOutputTransform(options, &runResponse); err != nil {
+		return models.RunResponse{}, err
+	}
+
+	return runResponse, nil
 }
 
 // Run executes the agent with the given input and options
 // This method accepts optional RunOptions using the functional options pattern
-func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse, error) {
+func (a *Agent) Run(input interface{}, opts ...interface{}) (_ models.RunResponse, err error) {
 	// Apply options
-	options := &RunOptions{}
-	for _, opt := range opts {
-		if runOpt, ok := opt.(func(*RunOptions)); ok {
-			runOpt(options)
-		}
+	options := applyRunOptions(opts)
+	if err := options.validate(); err != nil {
+		return models.RunResponse{}, err
+	}
+	if err := a.checkToolChoice(options.ToolChoice); err != nil {
+		return models.RunResponse{}, err
 	}
 
+	// Bound the whole run, model calls and tool loops included, by the timeout.
+	// The run state holds the run options, so overlapping runs do not mix them.
+	run := a.startRun(options.runContext, options)
+	defer func() { err = run.finish(err) }()
+
 	// Execute pre-hooks for validation and preprocessing
 	if len(a.preHooks) > 0 {
 		for i, hook := range a.preHooks {
-			if err := hook(a.ctx, input); err != nil {
+			if err := hook(run.ctx, input); err != nil {
 				return models.RunResponse{}, fmt.Errorf("pre-hook %d failed: %w", i, err)
 			}
 		}
@@ -1790,7 +2149,7 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 
 	// Execute input guardrails
 	if len(a.inputGuardrails) > 0 {
-		if err := RunGuardrails(a.ctx, a.inputGuardrails, input); err != nil {
+		if err := a.runGuardrails(run.ctx, GuardrailStageInput, a.inputGuardrails, input); err != nil {
 			return models.RunResponse{}, fmt.Errorf("input validation failed: %w", err)
 		}
 	}
@@ -1871,7 +2230,7 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 	}
 
 	// Add system message and history normally
-	baseMessages := a.prepareMessages(prompt, options.KnowledgeFilters)
+	baseMessages := a.prepareMessages(run.ctx, prompt, options.KnowledgeFilters)
 	for _, msg := range baseMessages {
 		if msg.Role == models.TypeUserRole {
 			messages = append(messages, msg)
@@ -1902,7 +2261,7 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 	if a.reasoning && a.reasoningModel != nil {
 		// use default reasoning agent
 		if a.reasoningAgent == nil {
-			reasoningAgent := NewReasoningAgent(a.ctx, a.reasoningModel, a.tools, a.reasoningMinSteps, a.reasoningMaxSteps)
+			reasoningAgent := NewReasoningAgent(run.ctx, a.reasoningModel, run.tools, a.reasoningMinSteps, a.reasoningMaxSteps)
 			// Use the reasoning agent directly without assigning to interface
 			reasoningSteps, err := reasoningAgent.Reason(prompt)
 			if err == nil && len(reasoningSteps) > 0 {
@@ -1968,43 +2327,45 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 	var resp *models.MessageResponse
 	var lastErr error
 
-	if a.model == nil {
+	if a.activeModel(run) == nil {
 		return models.RunResponse{}, fmt.Errorf("agent model is not initialized")
 	}
 
 	// Prepare model options - if ChainTool is enabled, only send the first tool
 	var toolsToSend []toolkit.Tool
-	if a.enableChainTool && len(a.tools) > 1 {
+	if a.enableChainTool && len(run.tools) > 1 {
 		// ChainTool mode: Send only the first tool to the model
-		toolsToSend = []toolkit.Tool{a.tools[0]}
-		if a.debug {
-			utils.DebugPanel(fmt.Sprintf("ChainTool: Sending only first tool '%s' to model (hiding %d other tools)", a.tools[0].GetName(), len(a.tools)-1))
+		toolsToSend = []toolkit.Tool{run.tools[0]}
+		if a.debugText() {
+			utils.DebugPanel(fmt.Sprintf("ChainTool: Sending only first tool '%s' to model (hiding %d other tools)", run.tools[0].GetName(), len(run.tools)-1))
 		}
 	} else {
 		// Normal mode: Send all tools
-		toolsToSend = a.tools
+		toolsToSend = run.tools
 	}
 
-	modelOptions := []models.Option{models.WithTools(toolsToSend)}
-	if len(a.modelOptions) > 0 {
-		modelOptions = append(modelOptions, a.modelOptions...)
-	}
+	modelOptions := a.modelCallOptions(run, models.WithTools(toolsToSend))
 
 	// Check if streaming is enabled
 	if options.Stream != nil && *options.Stream {
-		resp, lastErr = a.runWithStreaming(prompt, messages)
+		resp, lastErr = a.runWithStreaming(run, prompt, messages)
 	} else {
 		for attempt := 0; attempt <= retries; attempt++ {
-			if a.debug && attempt > 0 {
-				fmt.Printf("Retry attempt %d/%d\n", attempt, retries)
+			if attempt > 0 {
+				a.logRetry(attempt, retries, lastErr)
 			}
 
-			resp, lastErr = a.model.Invoke(a.ctx, messages, modelOptions...)
-			if lastErr == nil {
+			callStart := time.Now()
+			resp, lastErr = a.activeModel(run).Invoke(run.ctx, messages, modelOptions...)
+			a.logModelCall(a.activeModel(run), callStart, attempt, len(messages), lastErr)
+			if lastErr == nil || run.ctx.Err() != nil {
 				break
 			}
 
 			if attempt < retries {
+				if err := run.retryBudget.consume("model", lastErr); err != nil {
+					return models.RunResponse{}, err
+				}
 				// Apply exponential backoff if enabled
 				delay := time.Duration(a.delayBetweenRetries) * time.Second
 				if a.exponentialBackoff && attempt > 0 {
@@ -2016,23 +2377,28 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 	}
 
 	if lastErr != nil {
+		lastErr = a.newModelError(run, lastErr)
+		// A streamed run that failed midway, e.g. on timeout, returns the text received so far
+		if resp != nil {
+			return models.RunResponse{TextContent: resp.Content, Model: resp.Model}, lastErr
+		}
 		return models.RunResponse{}, lastErr
 	}
 
 	// Debug: print response in json
-	if a.debug {
+	if a.debugText() {
 		utils.ToolCallPanelWithArgs("resp", resp)
 	}
 
 	// Process tool results if present (tools were executed by the model client)
-	if len(resp.ToolResults) > 0 && a.enableChainTool && len(a.tools) > 1 {
+	if len(resp.ToolResults) > 0 && a.enableChainTool && len(run.tools) > 1 {
 
 		// Get the first tool's result
 		firstToolResult := resp.ToolResults[0]
 
 		// Get the first tool's result as string (this is what we'll replace in model response)
 		var firstToolResultStr string
-		if str, ok := firstToolResult.Result.(string); ok {
+		if str, ok := toolkit.ModelContent(firstToolResult.Result).(string); ok {
 			firstToolResultStr = str
 		} else {
 			resultJSON, _ := json.Marshal(firstToolResult.Result)
@@ -2043,8 +2409,8 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 		currentResult := firstToolResult.Result
 
 		// Execute remaining tools in sequence (tools[1], tools[2], ...)
-		for i := 1; i < len(a.tools); i++ {
-			tool := a.tools[i]
+		for i := 1; i < len(run.tools); i++ {
+			tool := run.tools[i]
 
 			// Prepare arguments for the tool
 			args := a.prepareToolArgumentsForChain(tool, currentResult)
@@ -2064,7 +2430,7 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 				break
 			}
 
-			if a.debug {
+			if a.debugText() {
 				utils.ToolCallPanelWithArgs(fmt.Sprintf("ChainTool: Executing tool[%d] %s", i, tool.GetName()), args)
 			}
 
@@ -2075,7 +2441,7 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 				continue
 			}
 
-			if a.debug {
+			if a.debugText() {
 				utils.ToolCallPanelWithArgs(fmt.Sprintf("ChainTool: Result from tool[%d] %s", i, tool.GetName()), toolResult)
 			}
 
@@ -2099,14 +2465,14 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 			idx := strings.Index(modelResponse, firstToolResultStr)
 			if idx != -1 {
 				modelResponse = modelResponse[:idx] + finalResult + modelResponse[idx+len(firstToolResultStr):]
-				if a.debug {
+				if a.debugText() {
 					utils.InfoPanel(fmt.Sprintf("ChainTool: Substituting '%s' → '%s' in model response", firstToolResultStr, finalResult))
 				}
 			}
 		}
 
 		// Build response with substituted model response
-		parsedContent, err := a.ApplyOutputFormatting(modelResponse)
+		parsedContent, err := a.applyOutputFormatting(run.ctx, modelResponse)
 		if err != nil {
 			return models.RunResponse{}, err
 		}
@@ -2128,20 +2494,26 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 					Content: modelResponse,
 				},
 			},
+			Metrics:   run.metrics(),
 			CreatedAt: time.Now().Unix(),
 		}
 
 		// Execute output guardrails
 		if len(a.outputGuardrails) > 0 {
-			if err := RunGuardrails(a.ctx, a.outputGuardrails, runResponse); err != nil {
+			if err := a.runGuardrails(run.ctx, GuardrailStageOutput, a.outputGuardrails, runResponse); err != nil {
 				return models.RunResponse{}, fmt.Errorf("output validation failed: %w", err)
 			}
 		}
 
+		// Apply output transformation
+		if err := a.applyOutputTransform(options, &runResponse); err != nil {
+			return models.RunResponse{}, err
+		}
+
 		// Execute post-hooks
 		if len(a.postHooks) > 0 {
 			for i, hook := range a.postHooks {
-				if err := hook(a.ctx, &runResponse); err != nil {
+				if err := hook(run.ctx, &runResponse); err != nil {
 					return models.RunResponse{}, fmt.Errorf("post-hook %d failed: %w", i, err)
 				}
 			}
@@ -2155,7 +2527,7 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 		utils.InfoPanel(fmt.Sprintf("Processing %d tool calls", len(resp.ToolCalls)))
 
 		// Execute tool calls and get final result
-		finalResult, toolMessages, _, _, err := a.processToolCallsFromResponse(resp)
+		finalResult, toolMessages, _, _, err := a.processToolCallsFromResponse(run, resp)
 		if err != nil {
 			return models.RunResponse{}, fmt.Errorf("tool call processing failed: %w", err)
 		}
@@ -2169,15 +2541,15 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 
 	// Save run to storage if enabled
 	if a.db != nil {
-		if err := a.saveRun(prompt, resp.Content, messages); err != nil && a.debug {
-			fmt.Printf("Warning: Failed to save run: %v\n", err)
+		if err := a.saveRun(run.ctx, prompt, resp.Content, messages); err != nil {
+			a.logWarning("save_run_failed", "Failed to save run", err)
 		}
 	}
 
 	// Process memories if enabled
 	if a.memory != nil {
-		if err := a.processMemories(prompt, resp.Content); err != nil && a.debug {
-			fmt.Printf("Warning: Failed to process memories: %v\n", err)
+		if err := a.processMemories(run.ctx, prompt, resp.Content); err != nil {
+			a.logWarning("process_memories_failed", "Failed to process memories", err)
 		}
 	}
 
@@ -2199,7 +2571,7 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 			if options.KnowledgeFilters != nil {
 				meta["knowledge_filters"] = options.KnowledgeFilters
 			}
-			if err := lm.ObserveAndLearn(a.ctx, a.userID, prompt, resp.Content, meta); err != nil {
+			if err := lm.ObserveAndLearn(run.ctx, a.userID, prompt, resp.Content, meta); err != nil {
 				log.Printf("Warning: Learning observe failed: %v", err)
 			}
 			delete(a.lastLearningRetrievedIDsByUser, a.userID)
@@ -2235,19 +2607,8 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 		}
 	}
 
-	// Step 1: Parse response with ParserModel if configured
-	responseContent := resp.Content
-	if a.parserModel != nil {
-		parsed, err := a.parseResponseWithParserModel(resp.Content)
-		if err != nil {
-			log.Printf("Warning: ParserModel failed, using original response: %v", err)
-		} else {
-			responseContent = parsed
-		}
-	}
-
-	// Step 2: Parse output using ApplyOutputFormatting method
-	parsedContent, err := a.ApplyOutputFormatting(responseContent)
+	// Parse the response into the output schema, asking the model again when it does not parse
+	resp, parsedContent, err := a.parseRunOutput(run, messages, resp, retries)
 	if err != nil {
 		return models.RunResponse{}, err
 	}
@@ -2273,20 +2634,26 @@ func (a *Agent) Run(input interface{}, opts ...interface{}) (models.RunResponse,
 			},
 		},
 		Model:     resp.Model,
+		Metrics:   run.metrics(),
 		CreatedAt: time.Now().Unix(),
 	}
 
 	// Execute output guardrails
 	if len(a.outputGuardrails) > 0 {
-		if err := RunGuardrails(a.ctx, a.outputGuardrails, runResponse); err != nil {
+		if err := a.runGuardrails(run.ctx, GuardrailStageOutput, a.outputGuardrails, runResponse); err != nil {
 			return models.RunResponse{}, fmt.Errorf("output validation failed: %w", err)
 		}
 	}
 
+	// Apply output transformation
+	if err := a.applyOutputTransform(options, &runResponse); err != nil {
+		return models.RunResponse{}, err
+	}
+
 	// Execute post-hooks for validation and post-processing
 	if len(a.postHooks) > 0 {
 		for i, hook := range a.postHooks {
-			if err := hook(a.ctx, &runResponse); err != nil {
+			if err := hook(run.ctx, &runResponse); err != nil {
 				return models.RunResponse{}, fmt.Errorf("post-hook %d failed: %w", i, err)
 			}
 		}
@@ -2308,10 +2675,13 @@ func (a *Agent) PrintResponse(prompt string, stream bool, markdown bool) {
 }
 
 func (a *Agent) print_response(prompt string, markdown bool) {
+	run := a.startRun(nil, nil)
+	defer run.finish(nil)
+
 	start := time.Now()
-	messages := a.prepareMessages(prompt, nil)
+	messages := a.prepareMessages(run.ctx, prompt, nil)
 
-	if a.debug {
+	if a.debugText() {
 		fmt.Printf("DEBUG: Prepared %d messages for model\n", len(messages))
 		for i, msg := range messages {
 			fmt.Printf("DEBUG: Message %d - Role: %s, Content length: %d\n", i, msg.Role, len(msg.Content))
@@ -2319,22 +2689,21 @@ func (a *Agent) print_response(prompt string, markdown bool) {
 		fmt.Printf("DEBUG: Using %d tools\n", len(a.tools))
 	}
 
-	if a.debug {
+	if a.debugText() {
 		fmt.Println("DEBUG: Calling model.Invoke...")
 	}
 
-	callOptions := []models.Option{models.WithTools(a.tools)}
-	if len(a.modelOptions) > 0 {
-		callOptions = append(callOptions, a.modelOptions...)
-	}
+	callOptions := a.modelCallOptions(run, models.WithTools(run.tools))
 
-	resp, err := a.model.Invoke(a.ctx, messages, callOptions...)
+	callStart := time.Now()
+	resp, err := a.activeModel(run).Invoke(run.ctx, messages, callOptions...)
+	a.logModelCall(a.activeModel(run), callStart, 0, len(messages), err)
 	if err != nil {
 		fmt.Printf("ERROR: Model invoke failed: %v\n", err)
 		return
 	}
 
-	if a.debug {
+	if a.debugText() {
 		fmt.Printf("DEBUG: Model response received - Content length: %d\n", len(resp.Content))
 		fmt.Printf("DEBUG: Response content preview: %.100s...\n", resp.Content)
 		fmt.Printf("DEBUG: Response type: %T\n", resp)
@@ -2344,12 +2713,12 @@ func (a *Agent) print_response(prompt string, markdown bool) {
 
 	// Process tool calls if present
 	if len(resp.ToolCalls) > 0 {
-		if a.debug {
+		if a.debugText() {
 			utils.InfoPanel(fmt.Sprintf("Processing %d tool calls", len(resp.ToolCalls)))
 		}
 
 		// Execute tool calls and get tool messages
-		_, toolMessages, _, _, err := a.processToolCallsFromResponse(resp)
+		_, toolMessages, _, _, err := a.processToolCallsFromResponse(run, resp)
 		if err != nil {
 			fmt.Printf("ERROR: Tool call processing failed: %v\n", err)
 			return
@@ -2363,34 +2732,41 @@ func (a *Agent) print_response(prompt string, markdown bool) {
 		})
 		messages = append(messages, toolMessages...)
 
-		if a.debug {
+		if a.debugText() {
 			fmt.Printf("DEBUG: Making follow-up request with %d messages\n", len(messages))
 		}
 
-		// Make follow-up request to get final response
-		resp, err = a.model.Invoke(a.ctx, messages, callOptions...)
+		// Make follow-up request to get final response; a forced tool was called
+		// already, so the model may answer now
+		callOptions = append(callOptions, models.WithToolChoice(string(ToolChoiceAuto)))
+		callStart = time.Now()
+		resp, err = a.activeModel(run).Invoke(run.ctx, messages, callOptions...)
+		a.logModelCall(a.activeModel(run), callStart, 0, len(messages), err)
 		if err != nil {
 			fmt.Printf("ERROR: Follow-up model invoke failed: %v\n", err)
 			return
 		}
 
-		if a.debug {
+		if a.debugText() {
 			fmt.Printf("DEBUG: Follow-up response content length: %d\n", len(resp.Content))
 		}
 	}
 
 	utils.ResponsePanel(resp.Content, nil, start, markdown)
 
-	if a.debug {
+	if a.debugText() {
 		fmt.Println("DEBUG: ResponsePanel called")
 		fmt.Printf("DEBUG: Final response content:\n%s\n", resp.Content)
 	}
 }
 
 func (a *Agent) print_stream_response(prompt string, markdown bool) {
+	run := a.startRun(nil, nil)
+	defer run.finish(nil)
+
 	start := time.Now()
 
-	messages := a.prepareMessages(prompt, nil)
+	messages := a.prepareMessages(run.ctx, prompt, nil)
 	contentChan := utils.StartSimplePanel(nil, start, markdown)
 
 	// Response
@@ -2429,7 +2805,7 @@ func (a *Agent) print_stream_response(prompt string, markdown bool) {
 	}
 
 	callOptions := []models.Option{
-		models.WithTools(a.tools),
+		models.WithTools(run.tools),
 		models.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
 			if !showResponse {
 				showResponse = true
@@ -2526,11 +2902,11 @@ func (a *Agent) print_stream_response(prompt string, markdown bool) {
 			return nil
 		}),
 	}
-	if len(a.modelOptions) > 0 {
-		callOptions = append(callOptions, a.modelOptions...)
-	}
+	callOptions = a.modelCallOptions(run, callOptions...)
 
-	err := a.model.InvokeStream(a.ctx, messages, callOptions...)
+	callStart := time.Now()
+	err := a.activeModel(run).InvokeStream(run.ctx, messages, callOptions...)
+	a.logModelCall(a.activeModel(run), callStart, 0, len(messages), err)
 	if err != nil {
 		fmt.Println(err)
 		return
@@ -2627,7 +3003,7 @@ func (a *Agent) filterToolCallsFromHistory(messages []models.Message) []models.M
 	return filteredMessages
 }
 
-func (a *Agent) prepareMessages(prompt string, knowledgeFilters map[string]interface{}) []models.Message {
+func (a *Agent) prepareMessages(ctx context.Context, prompt string, knowledgeFilters map[string]interface{}) []models.Message {
 	// If custom system message is provided and buildContext is false, use it directly
 	if a.systemMessage != "" && !a.buildContext {
 		messages := []models.Message{
@@ -2704,11 +3080,19 @@ func (a *Agent) prepareMessages(prompt string, knowledgeFilters map[string]inter
 
 	// Add user memories if enabled and available
 	if a.enableUserMemories && a.memory != nil && a.userID != "" {
-		userMemories, err := a.memory.GetUserMemories(a.ctx, a.userID)
+		// Limit to the 10 memories most relevant to the prompt, or the 10 most
+		// recent when the memory manager can't search or nothing matches
+		maxMemories := 10
+		var userMemories []*memory.UserMemory
+		var err error
+		if searcher, ok := a.memory.(userMemorySearcher); ok && strings.TrimSpace(prompt) != "" {
+			userMemories, err = searcher.SearchUserMemories(ctx, a.userID, prompt, maxMemories)
+		}
+		if err != nil || len(userMemories) == 0 {
+			userMemories, err = a.memory.GetUserMemories(ctx, a.userID)
+		}
 		if err == nil && len(userMemories) > 0 {
 			memoryContent := ""
-			// Limit to recent memories (last 10)
-			maxMemories := 10
 			if len(userMemories) > maxMemories {
 				userMemories = userMemories[len(userMemories)-maxMemories:]
 			}
@@ -2720,12 +3104,22 @@ func (a *Agent) prepareMessages(prompt string, knowledgeFilters map[string]inter
 		}
 	}
 
+	// Add the automatically refreshed session summary
+	if a.memory != nil && a.userID != "" && a.sessionID != "" && a.autoSummarizeEvery() > 0 {
+		summary, err := a.memory.GetSessionSummary(ctx, a.userID, a.sessionID)
+		if err == nil && summary != nil && summary.Summary != "" {
+			summaryContent := fmt.Sprintf("<session_summary>\nSummary of this conversation so far:\n%s\n</session_summary>\n", summary.Summary)
+			systemMessage += summaryContent
+			originalSystemMessage += summaryContent
+		}
+	}
+
 	// Add learning memories (continuous learning) if configured
 	if a.learningManager != nil && a.userID != "" {
 		if lm, ok := a.learningManager.(interface {
 			RetrieveContextWithMeta(ctx context.Context, userID, query string, filters map[string]interface{}) (string, []string, error)
 		}); ok {
-			learningCtx, ids, err := lm.RetrieveContextWithMeta(a.ctx, a.userID, prompt, knowledgeFilters)
+			learningCtx, ids, err := lm.RetrieveContextWithMeta(ctx, a.userID, prompt, knowledgeFilters)
 			if err != nil {
 				log.Printf("Warning: Failed to retrieve learning context: %v", err)
 			} else if learningCtx != "" {
@@ -2740,7 +3134,7 @@ func (a *Agent) prepareMessages(prompt string, knowledgeFilters map[string]inter
 		} else if lm, ok := a.learningManager.(interface {
 			RetrieveContextWithFilters(ctx context.Context, userID, query string, filters map[string]interface{}) (string, error)
 		}); ok {
-			learningCtx, err := lm.RetrieveContextWithFilters(a.ctx, a.userID, prompt, knowledgeFilters)
+			learningCtx, err := lm.RetrieveContextWithFilters(ctx, a.userID, prompt, knowledgeFilters)
 			if err != nil {
 				log.Printf("Warning: Failed to retrieve learning context: %v", err)
 			} else if learningCtx != "" {
@@ -2751,7 +3145,7 @@ func (a *Agent) prepareMessages(prompt string, knowledgeFilters map[string]inter
 		} else if lm, ok := a.learningManager.(interface {
 			RetrieveContext(ctx context.Context, userID, query string) (string, error)
 		}); ok {
-			learningCtx, err := lm.RetrieveContext(a.ctx, a.userID, prompt)
+			learningCtx, err := lm.RetrieveContext(ctx, a.userID, prompt)
 			if err != nil {
 				log.Printf("Warning: Failed to retrieve learning context: %v", err)
 			} else if learningCtx != "" {
@@ -2773,9 +3167,9 @@ func (a *Agent) prepareMessages(prompt string, knowledgeFilters map[string]inter
 		if s, ok := a.knowledge.(interface {
 			SearchWithFilters(ctx context.Context, query string, numDocuments int, filters map[string]interface{}) ([]*knowledge.SearchResult, error)
 		}); ok && knowledgeFilters != nil {
-			relevantDocs, err = s.SearchWithFilters(a.ctx, prompt, a.knowledgeMaxDocuments, knowledgeFilters)
+			relevantDocs, err = s.SearchWithFilters(ctx, prompt, a.knowledgeMaxDocuments, knowledgeFilters)
 		} else {
-			relevantDocs, err = a.knowledge.Search(a.ctx, prompt, a.knowledgeMaxDocuments)
+			relevantDocs, err = a.knowledge.Search(ctx, prompt, a.knowledgeMaxDocuments)
 		}
 		if err == nil && len(relevantDocs) > 0 {
 			docContent := ""
@@ -2852,7 +3246,7 @@ func (a *Agent) prepareMessages(prompt string, knowledgeFilters map[string]inter
 				userID = "default_user"
 			}
 
-			culturalContext, err := cm.AddCultureToContext(a.ctx, userID)
+			culturalContext, err := cm.AddCultureToContext(ctx, userID)
 			if err != nil {
 				log.Printf("Warning: Failed to add cultural context: %v", err)
 			} else if culturalContext != "" {
@@ -2862,7 +3256,7 @@ func (a *Agent) prepareMessages(prompt string, knowledgeFilters map[string]inter
 		}
 	}
 
-	if a.debug {
+	if a.debugText() {
 		utils.DebugPanel(systemMessage)
 	}
 
@@ -2883,7 +3277,7 @@ func (a *Agent) prepareMessages(prompt string, knowledgeFilters map[string]inter
 
 	compressedPrompt := a.ApplySemanticCompression(prompt)
 
-	if a.debug && a.enableSemanticCompression {
+	if a.debugText() && a.enableSemanticCompression {
 		encoder, _ := gpt3encoder.NewEncoder()
 		// Check token length
 		tokensSemantic, err := encoder.Encode(systemMessage)
@@ -3012,7 +3406,7 @@ func (a *Agent) buildMessageHistoryFromRuns() {
 }
 
 // saveRun saves a completed run to storage
-func (a *Agent) saveRun(userMessage, agentResponse string, messages []models.Message) error {
+func (a *Agent) saveRun(ctx context.Context, userMessage, agentResponse string, messages []models.Message) error {
 	if a.db == nil {
 		return nil
 	}
@@ -3040,7 +3434,7 @@ func (a *Agent) saveRun(userMessage, agentResponse string, messages []models.Mes
 		UpdatedAt:    time.Now(),
 	}
 
-	if err := a.db.CreateRun(a.ctx, run); err != nil {
+	if err := a.db.CreateRun(ctx, run); err != nil {
 		return fmt.Errorf("failed to save run: %w", err)
 	}
 
@@ -3056,50 +3450,48 @@ func (a *Agent) saveRun(userMessage, agentResponse string, messages []models.Mes
 }
 
 // processMemories handles memory extraction and session summarization
-func (a *Agent) processMemories(userMessage, agentResponse string) error {
+func (a *Agent) processMemories(ctx context.Context, userMessage, agentResponse string) error {
 	if a.memory == nil {
 		return nil
 	}
 
 	// Extract and save user memories if enabled
 	if a.enableAgenticMemory && a.userID != "" {
-		_, err := a.memory.CreateMemory(a.ctx, a.userID, userMessage, agentResponse)
+		_, err := a.memory.CreateMemory(ctx, a.userID, userMessage, agentResponse)
 		if err != nil {
 			// Log error but don't fail the whole operation
-			if a.debug {
-				fmt.Printf("Warning: Failed to create memory: %v\n", err)
-			}
+			a.logWarning("create_memory_failed", "Failed to create memory", err)
+		}
+	}
+
+	if a.userID == "" || a.sessionID == "" {
+		return nil
+	}
+
+	// Count the turn; memory managers with automatic summaries refresh the
+	// session summary on their own
+	if recorder, ok := a.memory.(sessionTurnRecorder); ok {
+		conversation := a.sessionConversation(recorder.AutoSummarizeEvery(), userMessage, agentResponse)
+		if _, err := recorder.RecordSessionTurn(ctx, a.userID, a.sessionID, conversation); err != nil {
+			a.logWarning("record_session_turn_failed", "Failed to record session turn", err)
+		}
+		if recorder.AutoSummarizeEvery() > 0 {
+			return nil
 		}
 	}
 
 	// Generate session summary if enabled
-	if a.enableSessionSummaries && a.userID != "" && a.sessionID != "" {
+	if a.enableSessionSummaries {
 		// Check if we need to create/update session summary
 		// This could be done periodically or based on number of interactions
 		runCount := len(a.runs)
 		if runCount > 0 && runCount%5 == 0 { // Summarize every 5 interactions
-			conversation := []map[string]interface{}{}
-			for _, run := range a.runs {
-				if run.UserMessage != "" {
-					conversation = append(conversation, map[string]interface{}{
-						"role":    "user",
-						"content": run.UserMessage,
-					})
-				}
-				if run.AgentMessage != "" {
-					conversation = append(conversation, map[string]interface{}{
-						"role":    "assistant",
-						"content": run.AgentMessage,
-					})
-				}
-			}
+			conversation := a.sessionConversation(0, userMessage, agentResponse)
 
-			_, err := a.memory.CreateSessionSummary(a.ctx, a.userID, a.sessionID, conversation)
+			_, err := a.memory.CreateSessionSummary(ctx, a.userID, a.sessionID, conversation)
 			if err != nil {
 				// Log error but don't fail the whole operation
-				if a.debug {
-					fmt.Printf("Warning: Failed to create session summary: %v\n", err)
-				}
+				a.logWarning("create_session_summary_failed", "Failed to create session summary", err)
 			}
 		}
 	}
@@ -3107,42 +3499,166 @@ func (a *Agent) processMemories(userMessage, agentResponse string) error {
 	return nil
 }
 
-func (a *Agent) RunStream(prompt string, fn func([]byte) error) error {
-	messages := a.prepareMessages(prompt, nil)
+// sessionTurnRecorder is implemented by memory managers that count session
+// turns and can refresh the session summary every N turns
+type sessionTurnRecorder interface {
+	RecordSessionTurn(ctx context.Context, userID, sessionID string, messages []map[string]interface{}) (*memory.SessionSummary, error)
+	AutoSummarizeEvery() int
+}
+
+// autoSummarizeEvery returns the session summary interval of the memory
+// manager, or zero if it doesn't summarize sessions on its own
+func (a *Agent) autoSummarizeEvery() int {
+	if recorder, ok := a.memory.(sessionTurnRecorder); ok {
+		return recorder.AutoSummarizeEvery()
+	}
+	return 0
+}
+
+// sessionConversation returns the last turns of the session (all kept runs
+// when turns <= 0) as messages, ending with the current turn
+func (a *Agent) sessionConversation(turns int, userMessage, agentResponse string) []map[string]interface{} {
+	runs := a.runs
+	if n := len(runs); n == 0 || runs[n-1].UserMessage != userMessage || runs[n-1].AgentMessage != agentResponse {
+		runs = append(runs[:n:n], &storage.AgentRun{UserMessage: userMessage, AgentMessage: agentResponse})
+	}
+	if turns > 0 && len(runs) > turns {
+		runs = runs[len(runs)-turns:]
+	}
+
+	conversation := []map[string]interface{}{}
+	for _, run := range runs {
+		if run.UserMessage != "" {
+			conversation = append(conversation, map[string]interface{}{
+				"role":    "user",
+				"content": run.UserMessage,
+			})
+		}
+		if run.AgentMessage != "" {
+			conversation = append(conversation, map[string]interface{}{
+				"role":    "assistant",
+				"content": run.AgentMessage,
+			})
+		}
+	}
+	return conversation
+}
+
+// ErrStopStream can be returned by a RunStream or RunStreamEvents callback to
+// stop generation early, e.g. when the user clicks stop. The model request is
+// cancelled and the run ends without error; the text delivered so far is kept
+// as the response in storage, memory and history.
+var ErrStopStream = errors.New("stream stopped")
+
+// RunStream streams the response to fn chunk by chunk. Returning ErrStopStream
+// from fn stops the generation; any other error aborts the run and is returned.
+func (a *Agent) RunStream(prompt string, fn func([]byte) error) (err error) {
+	run := a.startRun(nil, nil)
+	defer func() { err = run.finish(err) }()
+	_, err = a.runStream(run, prompt, fn)
+	return err
+}
+
+// RunStreamWithToolOutput streams a run like RunStream and passes the output
+// tools report while they run, such as the lines printed by ShellTool
+// commands, to onToolOutput
+func (a *Agent) RunStreamWithToolOutput(prompt string, fn func([]byte) error, onToolOutput toolkit.ToolOutputFunc) (err error) {
+	run := a.startRun(toolkit.ContextWithToolOutput(a.ctx, onToolOutput), nil)
+	defer func() { err = run.finish(err) }()
+	_, err = a.runStream(run, prompt, fn)
+	return err
+}
+
+// runStream streams run with its tools and returns the full response text, or
+// the text delivered to fn when it stopped the stream. The chunks already
+// delivered to fn are the partial result when the run times out.
+func (a *Agent) runStream(run *runState, prompt string, fn func([]byte) error) (_ string, err error) {
+	messages := a.prepareMessages(run.ctx, prompt, nil)
+
+	// Cancelled when fn stops the stream, so the model connection is closed
+	// even by clients that do not abort on a callback error
+	ctx, cancel := context.WithCancel(run.ctx)
+	defer cancel()
 
 	// Collect streaming content for memory processing
 	var fullResponse strings.Builder
 
+	// Text handed to fn, which is the response when the stream is stopped
+	var delivered strings.Builder
+	stopped := false
+	var callbackErr error
+	deliver := func(chunk []byte) error {
+		err := fn(chunk)
+		if err == nil || errors.Is(err, ErrStopStream) {
+			delivered.Write(chunk)
+		}
+		if errors.Is(err, ErrStopStream) {
+			stopped = true
+			cancel()
+		} else if err != nil {
+			callbackErr = err
+		}
+		return err
+	}
+
+	// Output guardrails hold chunks back until the text passes
+	guard := a.newStreamGuard(run.ctx, deliver)
+	var blocked error
+
 	opts := []models.Option{
-		models.WithTools(a.tools),
+		models.WithTools(run.tools),
 		models.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			if stopped {
+				return ErrStopStream
+			}
+
 			// Collect content for memory processing
 			fullResponse.Write(chunk)
 
-			return fn(chunk)
+			if guard != nil {
+				blocked = guard.write(chunk)
+				return blocked
+			}
+			return deliver(chunk)
 		}),
 	}
-	if len(a.modelOptions) > 0 {
-		opts = append(opts, a.modelOptions...)
+	opts = a.modelCallOptions(run, opts...)
+
+	callStart := time.Now()
+	err = a.activeModel(run).InvokeStream(ctx, messages, opts...)
+	a.logModelCall(a.activeModel(run), callStart, 0, len(messages), err)
+	if stopped || errors.Is(err, ErrStopStream) {
+		// Stopping is not a failure, whatever error the model client returned
+		stopped = true
+		err = nil
+	} else if blocked != nil {
+		// Report the guardrail error rather than however the model client wrapped it
+		err = blocked
+	} else if err != nil && callbackErr == nil {
+		err = a.newModelError(run, err)
+	} else if err == nil && guard != nil {
+		err = guard.close()
 	}
 
-	err := a.model.InvokeStream(a.ctx, messages, opts...)
+	responseContent := fullResponse.String()
+	if stopped {
+		responseContent = delivered.String()
+	}
 
 	// After streaming is complete, process memory and storage
 	if err == nil {
-		responseContent := fullResponse.String()
 
 		// Save run to storage if enabled
 		if a.db != nil {
-			if saveErr := a.saveRun(prompt, responseContent, messages); saveErr != nil && a.debug {
-				fmt.Printf("Warning: Failed to save run: %v\n", saveErr)
+			if saveErr := a.saveRun(run.ctx, prompt, responseContent, messages); saveErr != nil {
+				a.logWarning("save_run_failed", "Failed to save run", saveErr)
 			}
 		}
 
 		// Process memories if enabled
 		if a.memory != nil {
-			if memErr := a.processMemories(prompt, responseContent); memErr != nil && a.debug {
-				fmt.Printf("Warning: Failed to process memories: %v\n", memErr)
+			if memErr := a.processMemories(run.ctx, prompt, responseContent); memErr != nil {
+				a.logWarning("process_memories_failed", "Failed to process memories", memErr)
 			}
 		}
 
@@ -3167,7 +3683,7 @@ func (a *Agent) RunStream(prompt string, fn func([]byte) error) error {
 		}
 	}
 
-	return err
+	return responseContent, err
 
 }
 
@@ -3193,9 +3709,13 @@ func (a *Agent) ApplySemanticCompression(message string) string {
 	encoder, _ := gpt3encoder.NewEncoder()
 	// Check token length
 	tokens, _ := encoder.Encode(message)
-	if a.debug {
+	if a.debugText() {
 		fmt.Printf("DEBUG: Applying semantic compression to %d tokens\n", tokens)
 	}
+	a.logEvent(LogLevelDebug, "semantic_compression", map[string]interface{}{
+		"tokens":     len(tokens),
+		"max_tokens": a.semanticMaxTokens,
+	})
 	if a.semanticMaxTokens == 0 || len(tokens) < a.semanticMaxTokens {
 		// No need to compress
 		return message
@@ -3225,9 +3745,7 @@ func (a *Agent) ApplySemanticCompression(message string) string {
 
 		newmsg, err := a.semanticAgent.Run(message)
 		if err != nil {
-			if a.debug {
-				fmt.Printf("Warning: Semantic compression failed for message: %v\n", err)
-			}
+			a.logWarning("semantic_compression_failed", "Semantic compression failed for message", err)
 
 		}
 		msgcompressed = newmsg.Messages[0].Content
@@ -3237,9 +3755,7 @@ func (a *Agent) ApplySemanticCompression(message string) string {
 
 		newmsg, err := semanticAgent.Run(message)
 		if err != nil {
-			if a.debug {
-				fmt.Printf("Warning: Semantic compression failed for message: %v\n", err)
-			}
+			a.logWarning("semantic_compression_failed", "Semantic compression failed for message", err)
 
 		}
 		msgcompressed = newmsg.Messages[0].Content
@@ -3311,8 +3827,8 @@ func (a *Agent) prepareToolArgumentsForChain(tool toolkit.Tool, input interface{
 // If the result is a JSON string, it will be pretty-printed with indentation.
 // Otherwise, returns the original value as string.
 func formatToolResult(result interface{}) string {
-	// Convert result to string first
-	resultStr := fmt.Sprintf("%v", result)
+	// Convert result to string first (ToolResult shows its model-facing content)
+	resultStr := fmt.Sprintf("%v", toolkit.ModelContent(result))
 
 	// Try to parse and pretty-print JSON
 	var jsonData interface{}
@@ -3330,7 +3846,7 @@ func formatToolResult(result interface{}) string {
 
 // processToolCallsFromResponse processes tool calls from model response and returns final result
 // Returns: (finalResult, toolMessages, chainToolExecuted, firstToolInput, error)
-func (a *Agent) processToolCallsFromResponse(resp *models.MessageResponse) (string, []models.Message, bool, string, error) {
+func (a *Agent) processToolCallsFromResponse(run *runState, resp *models.MessageResponse) (string, []models.Message, bool, string, error) {
 	utils.InfoPanel("Processing tool calls from model response")
 
 	var toolMessages []models.Message
@@ -3350,7 +3866,7 @@ func (a *Agent) processToolCallsFromResponse(resp *models.MessageResponse) (stri
 		}
 
 		var tool toolkit.Tool
-		for _, t := range a.tools {
+		for _, t := range run.tools {
 			// Check if it's a ToolWrapper
 			if wrapper, ok := t.(*ToolWrapper); ok {
 				if wrapper.GetName() == toolName {
@@ -3413,21 +3929,21 @@ func (a *Agent) processToolCallsFromResponse(resp *models.MessageResponse) (stri
 		// Add tool message to history
 		toolMessages = append(toolMessages, models.Message{
 			Role:       "tool",
-			Content:    fmt.Sprintf("%v", result),
+			Content:    fmt.Sprintf("%v", toolkit.ModelContent(result)),
 			ToolCallID: &toolCall.ID,
 		})
 
 		// DEBUG: Check ChainTool state
-		utils.InfoPanel(fmt.Sprintf("DEBUG: enableChainTool=%v, len(tools)=%d, callIndex=%d", a.enableChainTool, len(a.tools), callIndex))
+		utils.InfoPanel(fmt.Sprintf("DEBUG: enableChainTool=%v, len(tools)=%d, callIndex=%d", a.enableChainTool, len(run.tools), callIndex))
 
 		// In ChainTool mode, check if this was the final result from the chain
-		if a.enableChainTool && len(a.tools) > 1 {
+		if a.enableChainTool && len(run.tools) > 1 {
 			utils.InfoPanel(fmt.Sprintf("ChainTool: Tool execution completed with result: %v", result))
 			chainToolWasExecuted = true
 		}
 
 		// In ChainTool mode, execute remaining tools in sequence
-		if a.enableChainTool && len(a.tools) > 1 && callIndex == 0 {
+		if a.enableChainTool && len(run.tools) > 1 && callIndex == 0 {
 			// After first tool executes, run the chain for remaining tools
 			// Extract the unwrapped tool if it's a wrapper
 			var unwrappedTool toolkit.Tool = tool
@@ -3435,7 +3951,7 @@ func (a *Agent) processToolCallsFromResponse(resp *models.MessageResponse) (stri
 				unwrappedTool = wrapper.Tool
 			}
 
-			chainResult, err := a.executeChainFromTool(unwrappedTool, result)
+			chainResult, err := a.executeChainFromTool(run, unwrappedTool, result)
 			if err != nil {
 				utils.InfoPanel(fmt.Sprintf("ChainTool: Chain execution warning: %v", err))
 			} else if chainResult != nil {
@@ -3444,7 +3960,7 @@ func (a *Agent) processToolCallsFromResponse(resp *models.MessageResponse) (stri
 		}
 
 		// Convert result to string for final response
-		if str, ok := result.(string); ok {
+		if str, ok := toolkit.ModelContent(result).(string); ok {
 			finalResult = str
 		} else {
 			resultJSON, err := json.Marshal(result)
@@ -3461,13 +3977,13 @@ func (a *Agent) processToolCallsFromResponse(resp *models.MessageResponse) (stri
 
 // executeChainFromTool executes remaining tools in sequence after the given tool was called by the model
 // This implements the ChainTool behavior where one tool call triggers the execution of all subsequent tools
-func (a *Agent) executeChainFromTool(executedTool toolkit.Tool, result interface{}) (interface{}, error) {
-	if a.debug {
+func (a *Agent) executeChainFromTool(run *runState, executedTool toolkit.Tool, result interface{}) (interface{}, error) {
+	if a.debugText() {
 		utils.ToolCallPanel("Executing Chain Tools")
 	}
 	// Find the index of the executed tool
 	executedIndex := -1
-	for i, tool := range a.tools {
+	for i, tool := range run.tools {
 		// Compare both wrapped and unwrapped tools
 		var toolToCompare toolkit.Tool
 		if wrapper, ok := tool.(*ToolWrapper); ok {
@@ -3484,14 +4000,14 @@ func (a *Agent) executeChainFromTool(executedTool toolkit.Tool, result interface
 	}
 
 	if executedIndex == -1 {
-		if a.debug {
+		if a.debugText() {
 			utils.InfoPanel("ChainTool: Executed tool not found in agent tools list")
 		}
 		return result, nil // Tool not found, return original result
 	}
 
 	// If this was the last tool, no chaining needed
-	if executedIndex >= len(a.tools)-1 {
+	if executedIndex >= len(run.tools)-1 {
 		return result, nil
 	}
 
@@ -3499,14 +4015,14 @@ func (a *Agent) executeChainFromTool(executedTool toolkit.Tool, result interface
 	var finalResult interface{} = result
 
 	// Execute remaining tools in sequence
-	for i := executedIndex + 1; i < len(a.tools); i++ {
-		tool := a.tools[i]
+	for i := executedIndex + 1; i < len(run.tools); i++ {
+		tool := run.tools[i]
 
 		// Prepare arguments for the tool
 		args := a.prepareToolArgumentsForChain(tool, currentInput)
 
 		// Execute before hooks
-		if err := a.ExecuteToolBeforeHooks(a.ctx, tool.GetName(), args); err != nil {
+		if err := a.ExecuteToolBeforeHooks(run.ctx, tool.GetName(), args); err != nil {
 			utils.ErrorPanel(fmt.Errorf("ChainTool: Before hook failed for %s: %v", tool.GetName(), err))
 			continue // Continue with chain even if hook fails
 		}
@@ -3526,7 +4042,7 @@ func (a *Agent) executeChainFromTool(executedTool toolkit.Tool, result interface
 			break
 		}
 
-		if a.debug {
+		if a.debugText() {
 			utils.ToolCallPanelWithArgs(fmt.Sprintf("Executing Tool Chain: %v", tool.GetName()), args)
 		}
 
@@ -3536,12 +4052,12 @@ func (a *Agent) executeChainFromTool(executedTool toolkit.Tool, result interface
 			continue // Continue with chain even if tool fails
 		}
 
-		if a.debug {
+		if a.debugText() {
 			utils.ToolCallPanelWithArgs(fmt.Sprintf("Result  Tool Chain: %v", tool.GetName()), toolResult)
 		}
 
 		// Execute after hooks
-		if err := a.ExecuteToolAfterHooks(a.ctx, tool.GetName(), args, toolResult); err != nil {
+		if err := a.ExecuteToolAfterHooks(run.ctx, tool.GetName(), args, toolResult); err != nil {
 			utils.ErrorPanel(fmt.Errorf("chainTool: After hook failed for %v: %v", tool.GetName(), err.Error()))
 			continue
 		}
@@ -3569,7 +4085,7 @@ func (a *Agent) AddTool(tool toolkit.Tool) error {
 		return fmt.Errorf("tool with name '%s' already exists", tool.GetName())
 	}
 
-	a.tools = append(a.tools, tool)
+	a.tools = append(a.tools, a.WrapToolsWithHooks([]toolkit.Tool{tool})...)
 	return nil
 }
 
@@ -3608,3 +4124,40 @@ func (a *Agent) GetToolByName(name string) toolkit.Tool {
 
 	return nil
 }
+
+// ToolSchema describes a tool method as it is offered to the model
+type ToolSchema struct {
+	Name        string                 `json:"name"`        // Function name the model calls, e.g. "WebTool_HttpRequest"
+	Tool        string                 `json:"tool"`        // Name of the tool the method belongs to
+	Description string                 `json:"description"` // Method description, or the tool's when the method has none
+	Parameters  map[string]interface{} `json:"parameters"`  // JSON schema of the arguments
+}
+
+// GetToolSchemas returns the schema of every method of the agent's tools,
+// as sent to the model in each request. Use it to debug tool calls, e.g. to
+// check how NewToolFromFunction typed a struct field.
+func (a *Agent) GetToolSchemas() []ToolSchema {
+	var schemas []ToolSchema
+	for _, tool := range a.tools {
+		methods := tool.GetMethods()
+		names := make([]string, 0, len(methods))
+		for name := range methods {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			description := tool.GetDescriptionOfMethod(name)
+			if description == "" {
+				description = tool.GetDescription()
+			}
+			schemas = append(schemas, ToolSchema{
+				Name:        name,
+				Tool:        tool.GetName(),
+				Description: description,
+				Parameters:  tool.GetParameterStruct(name),
+			})
+		}
+	}
+	return schemas
+}
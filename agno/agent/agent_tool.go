@@ -0,0 +1,89 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// AgentTool exposes an agent as a tool, so a parent agent can consult a
+// specialist mid-run without a Team. Create it with Agent.AsTool.
+//
+// When the parent runs the tool, the child runs with the parent's run context
+// (so cancelling the parent cancels the child, and the child's tools see the
+// parent's run metadata) and shares the parent run's retry budget set with
+// WithMaxTotalRetries. Calls to the same child are serialized.
+type AgentTool struct {
+	toolkit.Toolkit
+	agent *Agent
+	mu    *sync.Mutex
+}
+
+// AgentToolParams represents the parameters of a delegated call
+type AgentToolParams struct {
+	Prompt string `json:"prompt" description:"The task or question for the specialist, with all the context it needs" required:"true"`
+}
+
+// AsTool wraps the agent as a tool named name, to be added to another agent's
+// Tools. The parent model calls it as "<name>_ask" with a prompt and gets the
+// agent's answer as the tool result.
+//
+//	researcher, _ := agent.NewAgent(agent.AgentConfig{Model: model, Instructions: "Research topics in depth."})
+//	writer, _ := agent.NewAgent(agent.AgentConfig{
+//		Model: model,
+//		Tools: []toolkit.Tool{researcher.AsTool("researcher", "Ask the research specialist")},
+//	})
+func (a *Agent) AsTool(name, description string) *AgentTool {
+	t := &AgentTool{agent: a, mu: &sync.Mutex{}}
+
+	tk := toolkit.NewToolkit()
+	tk.Name = name
+	tk.Description = description
+
+	t.Toolkit = tk
+	t.Toolkit.Register("ask", description, t, func(params AgentToolParams) (interface{}, error) {
+		return t.ask(nil, params)
+	}, AgentToolParams{})
+
+	return t
+}
+
+// Execute runs the wrapped agent with the prompt from the tool call
+func (t *AgentTool) Execute(methodName string, input json.RawMessage) (interface{}, error) {
+	return t.ExecuteContext(nil, methodName, input)
+}
+
+// ExecuteContext runs the wrapped agent within ctx, the parent's run context
+func (t *AgentTool) ExecuteContext(ctx context.Context, methodName string, input json.RawMessage) (interface{}, error) {
+	var params AgentToolParams
+	if err := json.Unmarshal(input, &params); err != nil {
+		return nil, fmt.Errorf("invalid arguments for %s: %w", methodName, err)
+	}
+	return t.ask(ctx, params)
+}
+
+// ask runs the wrapped agent within the parent run context ctx, which carries
+// the parent's cancellation, metadata and retry budget; a nil ctx runs it in
+// its own context
+func (t *AgentTool) ask(ctx context.Context, params AgentToolParams) (interface{}, error) {
+	if params.Prompt == "" {
+		return nil, fmt.Errorf("prompt is required")
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var opts []interface{}
+	if ctx != nil {
+		opts = append(opts, withRunContext(ctx))
+	}
+
+	resp, err := t.agent.Run(params.Prompt, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("agent %s failed: %w", t.GetName(), err)
+	}
+	return resp.TextContent, nil
+}
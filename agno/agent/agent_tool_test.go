@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func TestAgentAsTool(t *testing.T) {
+	specialist, err := NewAgent(AgentConfig{Model: &stubModel{content: "specialist answer"}})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	researcher := specialist.AsTool("researcher", "Ask the research specialist")
+	parent, err := NewAgent(AgentConfig{
+		Model: &stubModel{content: "done"},
+		Tools: []toolkit.Tool{researcher},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	tools := parent.GetTools()
+	if len(tools) != 1 {
+		t.Fatalf("Expected 1 tool, got %d", len(tools))
+	}
+	if _, ok := tools[0].GetMethods()["researcher_ask"]; !ok {
+		t.Fatalf("Expected researcher_ask method, got %v", tools[0].GetMethods())
+	}
+
+	result, err := tools[0].Execute("researcher_ask", json.RawMessage(`{"prompt":"What is new?"}`))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result != "specialist answer" {
+		t.Errorf("Expected specialist answer, got %v", result)
+	}
+
+	if _, err := tools[0].Execute("researcher_ask", json.RawMessage(`{}`)); err == nil {
+		t.Error("Expected an error for an empty prompt")
+	}
+}
+
+func TestAgentToolSharesRetryBudget(t *testing.T) {
+	model := &failingModel{}
+	child, err := NewAgent(AgentConfig{Model: model})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	// The parent run's context carries its budget
+	max := 0
+	parentCtx := context.WithValue(context.Background(), retryBudgetKey{}, newRetryBudget(&max))
+	_, err = child.Run("hi", WithRetries(3), withRunContext(parentCtx))
+	var exhausted *RetryBudgetExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("Expected RetryBudgetExhaustedError, got %v", err)
+	}
+	if model.calls != 1 {
+		t.Errorf("Expected 1 model call, got %d", model.calls)
+	}
+}
@@ -0,0 +1,64 @@
+package agent
+
+import "time"
+
+// Clone creates a new agent from the configuration this agent was built with,
+// after applying overrides. Slices and maps in the configuration are copied, so
+// overrides never affect the original agent, while the model, memory, storage,
+// knowledge and tool instances are shared by reference.
+//
+// The clone starts its own session unless an override sets SessionID, and does
+// not inherit the original agent's messages, runs or session state.
+//
+//	reviewer, err := coder.Clone(func(cfg *AgentConfig) {
+//		cfg.Name = "Reviewer"
+//		cfg.Instructions = "Review the code for bugs."
+//	})
+func (a *Agent) Clone(overrides ...AgentOption) (*Agent, error) {
+	config := a.config.copy()
+	config.SessionID = ""
+	return NewAgentWithOptions(config, overrides...)
+}
+
+// copy returns a copy of the configuration with its own slices and maps
+func (c AgentConfig) copy() AgentConfig {
+	c.ModelOptions = append(c.ModelOptions[:0:0], c.ModelOptions...)
+	c.Tools = append(c.Tools[:0:0], c.Tools...)
+	c.SkillsToUse = append(c.SkillsToUse[:0:0], c.SkillsToUse...)
+	c.PreHooks = append(c.PreHooks[:0:0], c.PreHooks...)
+	c.PostHooks = append(c.PostHooks[:0:0], c.PostHooks...)
+	c.ToolBeforeHooks = append(c.ToolBeforeHooks[:0:0], c.ToolBeforeHooks...)
+	c.ToolAfterHooks = append(c.ToolAfterHooks[:0:0], c.ToolAfterHooks...)
+	c.InputGuardrails = append(c.InputGuardrails[:0:0], c.InputGuardrails...)
+	c.OutputGuardrails = append(c.OutputGuardrails[:0:0], c.OutputGuardrails...)
+	c.ToolGuardrails = append(c.ToolGuardrails[:0:0], c.ToolGuardrails...)
+	if c.ToolTimeouts != nil {
+		timeouts := make(map[string]time.Duration, len(c.ToolTimeouts))
+		for name, timeout := range c.ToolTimeouts {
+			timeouts[name] = timeout
+		}
+		c.ToolTimeouts = timeouts
+	}
+	if c.ToolConcurrency != nil {
+		limits := make(map[string]int, len(c.ToolConcurrency))
+		for name, limit := range c.ToolConcurrency {
+			limits[name] = limit
+		}
+		c.ToolConcurrency = limits
+	}
+	c.ContextData = copyMap(c.ContextData)
+	c.Dependencies = copyMap(c.Dependencies)
+	return c
+}
+
+// copyMap returns a shallow copy of m, preserving nil
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	if m == nil {
+		return nil
+	}
+	copied := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		copied[k] = v
+	}
+	return copied
+}
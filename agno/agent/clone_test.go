@@ -0,0 +1,62 @@
+package agent
+
+import (
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func TestAgentClone(t *testing.T) {
+	model := &stubModel{content: "ok"}
+	mockTool := createMockTool()
+
+	base, err := NewAgent(AgentConfig{
+		Model:           model,
+		Name:            "Coder",
+		Instructions:    "Write code.",
+		Tools:           []toolkit.Tool{mockTool},
+		ContextData:     map[string]interface{}{"repo": "agno"},
+		ToolTimeouts:    map[string]time.Duration{"search": time.Second},
+		ToolConcurrency: map[string]int{"search": 1},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	clone, err := base.Clone(func(cfg *AgentConfig) {
+		cfg.Name = "Reviewer"
+		cfg.Instructions = "Review the code."
+		cfg.ContextData["repo"] = "other"
+		cfg.ToolTimeouts["search"] = time.Minute
+		cfg.ToolConcurrency["search"] = 4
+	})
+	if err != nil {
+		t.Fatalf("Clone failed: %v", err)
+	}
+
+	if clone.GetName() != "Reviewer" || clone.instructions != "Review the code." {
+		t.Errorf("Overrides not applied: name=%s instructions=%s", clone.GetName(), clone.instructions)
+	}
+	if base.GetName() != "Coder" || base.instructions != "Write code." {
+		t.Errorf("Original agent changed: name=%s instructions=%s", base.GetName(), base.instructions)
+	}
+	if base.contextData["repo"] != "agno" {
+		t.Errorf("Original context data changed: %v", base.contextData)
+	}
+	if base.config.ToolTimeouts["search"] != time.Second || base.config.ToolConcurrency["search"] != 1 {
+		t.Errorf("Original tool limits changed: timeouts=%v concurrency=%v", base.config.ToolTimeouts, base.config.ToolConcurrency)
+	}
+	if clone.config.ToolTimeouts["search"] != time.Minute || clone.config.ToolConcurrency["search"] != 4 {
+		t.Errorf("Tool limit overrides not applied: timeouts=%v concurrency=%v", clone.config.ToolTimeouts, clone.config.ToolConcurrency)
+	}
+	if clone.GetModel() != base.GetModel() {
+		t.Error("Expected clone to share the model")
+	}
+	if len(clone.GetTools()) == 0 || clone.GetTools()[0] != toolkit.Tool(mockTool) {
+		t.Error("Expected clone to share the tool instances")
+	}
+	if clone.GetID() == base.GetID() {
+		t.Error("Expected clone to start its own session")
+	}
+}
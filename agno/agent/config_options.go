@@ -1,5 +1,11 @@
 package agent
 
+import (
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
 // AgentOption applies configuration to AgentConfig before creating an Agent.
 type AgentOption func(*AgentConfig)
 
@@ -21,3 +27,43 @@ func WithLearningLoop(manager interface{}) AgentOption {
 	}
 }
 
+// WithSeed passes a fixed sampling seed to the model on every run.
+// Providers that do not support seeding ignore it (see models.WithSeed).
+func WithSeed(seed int) AgentOption {
+	return func(cfg *AgentConfig) {
+		cfg.ModelOptions = append(cfg.ModelOptions, models.WithSeed(seed))
+	}
+}
+
+// WithJSONRepair repairs malformed JSON from the model before it is parsed
+// into the OutputSchema, for models that wrap JSON in prose or code fences or
+// emit trailing commas and single quotes.
+func WithJSONRepair(enabled bool) AgentOption {
+	return func(cfg *AgentConfig) {
+		cfg.JSONRepair = enabled
+	}
+}
+
+// WithToolConcurrency caps the concurrent calls of the named tool in
+// ExecuteToolCallsParallel, independently of MaxParallelCalls, e.g. 1 to call a
+// rate-limited API serially while other tools run in parallel.
+func WithToolConcurrency(toolName string, n int) AgentOption {
+	return func(cfg *AgentConfig) {
+		if cfg.ToolConcurrency == nil {
+			cfg.ToolConcurrency = make(map[string]int)
+		}
+		cfg.ToolConcurrency[toolName] = n
+	}
+}
+
+// WithToolTimeout sets the time limit of the named tool's calls, overriding
+// ToolTimeout, e.g. a longer limit for a slow shell tool. Zero disables the
+// limit for that tool.
+func WithToolTimeout(toolName string, timeout time.Duration) AgentOption {
+	return func(cfg *AgentConfig) {
+		if cfg.ToolTimeouts == nil {
+			cfg.ToolTimeouts = make(map[string]time.Duration)
+		}
+		cfg.ToolTimeouts[toolName] = timeout
+	}
+}
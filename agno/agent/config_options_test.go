@@ -0,0 +1,27 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+func TestWithSeed(t *testing.T) {
+	model := &stubModel{content: "ok"}
+	ag, err := NewAgentWithOptions(AgentConfig{Model: model}, WithSeed(42))
+	if err != nil {
+		t.Fatalf("NewAgentWithOptions failed: %v", err)
+	}
+
+	if _, err := ag.Run("hi"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	callOpts := models.DefaultCallOptions()
+	for _, opt := range model.options {
+		opt(callOpts)
+	}
+	if callOpts.Seed == nil || *callOpts.Seed != 42 {
+		t.Errorf("Expected seed 42 to reach the model, got %v", callOpts.Seed)
+	}
+}
@@ -1,12 +1,15 @@
 package agent
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
 
 	"github.com/devalexandre/agno-golang/agno/document"
+	"github.com/devalexandre/agno-golang/agno/knowledge"
+	"github.com/devalexandre/agno-golang/agno/memory"
 	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
 )
 
@@ -15,6 +18,7 @@ type DefaultToolsConfig struct {
 	EnableReadChatHistory     bool
 	EnableUpdateKnowledge     bool
 	EnableReadToolCallHistory bool
+	EnableMemoryRecall        bool
 }
 
 // CreateDefaultTools creates the default tools based on config
@@ -39,6 +43,12 @@ func CreateDefaultTools(agent *Agent, config DefaultToolsConfig) []toolkit.Tool
 		}
 	}
 
+	if config.EnableMemoryRecall {
+		if tool := NewMemoryRecallTool(agent); tool != nil {
+			tools = append(tools, tool)
+		}
+	}
+
 	return tools
 }
 
@@ -182,6 +192,8 @@ func NewUpdateKnowledgeTool(agent *Agent) toolkit.Tool {
 
 	tk.Register("AddKnowledge", "Add a new piece of information to the knowledge base", ukt, ukt.AddKnowledge, AddKnowledgeParams{})
 	tk.Register("SearchKnowledge", "Search the knowledge base for relevant information", ukt, ukt.SearchKnowledge, SearchKnowledgeParams{})
+	tk.Register("DeleteKnowledge", "Delete outdated or incorrect entries from the knowledge base by ID or exact content", ukt, ukt.DeleteKnowledge, DeleteKnowledgeParams{})
+	tk.Register("UpdateKnowledgeEntry", "Replace the content of an existing knowledge base entry to correct it", ukt, ukt.UpdateKnowledgeEntry, UpdateKnowledgeEntryParams{})
 
 	ukt.Toolkit = tk
 	return &tk
@@ -244,6 +256,139 @@ func (ukt *UpdateKnowledgeToolkit) SearchKnowledge(params SearchKnowledgeParams)
 	return output.String(), nil
 }
 
+// knowledgeEditor is implemented by knowledge bases that support deleting and inspecting documents
+type knowledgeEditor interface {
+	ListDocuments(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]knowledge.DocumentInfo, error)
+	DeleteDocuments(ctx context.Context, ids []string) (int, error)
+	GetDocument(ctx context.Context, id string) (*document.Document, error)
+	UpsertDocument(ctx context.Context, doc document.Document) error
+}
+
+// editor returns the agent knowledge base as a knowledgeEditor if supported
+func (ukt *UpdateKnowledgeToolkit) editor() (knowledgeEditor, error) {
+	editor, ok := ukt.agent.knowledge.(knowledgeEditor)
+	if !ok {
+		return nil, fmt.Errorf("knowledge base does not support editing entries")
+	}
+	return editor, nil
+}
+
+// DeleteKnowledgeParams defines parameters for deleting knowledge
+type DeleteKnowledgeParams struct {
+	IDs     []string `json:"ids" jsonschema:"description=IDs of the entries to delete"`
+	Content string   `json:"content" jsonschema:"description=Exact content of the entries to delete (used when IDs are not known)"`
+}
+
+// DeleteKnowledge removes entries from the knowledge base by ID or exact content match
+func (ukt *UpdateKnowledgeToolkit) DeleteKnowledge(params DeleteKnowledgeParams) (string, error) {
+	if len(params.IDs) == 0 && params.Content == "" {
+		return "", fmt.Errorf("either ids or content must be provided")
+	}
+
+	editor, err := ukt.editor()
+	if err != nil {
+		return "", err
+	}
+
+	ids := params.IDs
+	if len(ids) == 0 {
+		ids, err = ukt.findExactMatches(editor, params.Content)
+		if err != nil {
+			return "", err
+		}
+		if len(ids) == 0 {
+			return "No knowledge entries matched the given content (0 entries deleted). Entries stored before content hashes were recorded are only matched among the closest search results; delete those by ID.", nil
+		}
+	}
+
+	deleted, err := editor.DeleteDocuments(ukt.agent.ctx, ids)
+	if err != nil {
+		return "", fmt.Errorf("failed to delete knowledge: %w", err)
+	}
+
+	return fmt.Sprintf("Deleted %d knowledge entries", deleted), nil
+}
+
+// findExactMatches returns the IDs of documents whose content equals content,
+// with or without surrounding whitespace. Documents are looked up by the
+// content hash the knowledge base stores in their metadata, so every stored
+// match is found, not only the closest search results. Documents stored before
+// content hashes were recorded have no hash; they are matched on content among
+// the closest search results instead, so only those can be found.
+func (ukt *UpdateKnowledgeToolkit) findExactMatches(editor knowledgeEditor, content string) ([]string, error) {
+	hashes := []string{knowledge.ContentHash(content)}
+	if trimmed := strings.TrimSpace(content); trimmed != content {
+		hashes = append(hashes, knowledge.ContentHash(trimmed))
+	}
+
+	const pageSize = 100
+	var ids []string
+	for _, hash := range hashes {
+		filters := map[string]interface{}{knowledge.ContentHashKey: hash}
+		for offset := 0; ; offset += pageSize {
+			docs, err := editor.ListDocuments(ukt.agent.ctx, filters, pageSize, offset)
+			if err != nil {
+				return nil, fmt.Errorf("failed to look up knowledge: %w", err)
+			}
+			for _, doc := range docs {
+				ids = append(ids, doc.ID)
+			}
+			if len(docs) < pageSize {
+				break
+			}
+		}
+	}
+
+	results, err := ukt.agent.knowledge.Search(ukt.agent.ctx, content, 20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search knowledge: %w", err)
+	}
+	target := strings.TrimSpace(content)
+	for _, result := range results {
+		doc := result.Document
+		if doc == nil || doc.Metadata[knowledge.ContentHashKey] != nil {
+			continue
+		}
+		if strings.TrimSpace(doc.Content) == target {
+			ids = append(ids, doc.ID)
+		}
+	}
+	return ids, nil
+}
+
+// UpdateKnowledgeEntryParams defines parameters for updating a knowledge entry
+type UpdateKnowledgeEntryParams struct {
+	ID       string                 `json:"id" jsonschema:"required,description=ID of the entry to update"`
+	Content  string                 `json:"content" jsonschema:"required,description=Corrected content for the entry"`
+	Metadata map[string]interface{} `json:"metadata" jsonschema:"description=Optional metadata; existing metadata is kept when omitted"`
+}
+
+// UpdateKnowledgeEntry replaces the content of an existing knowledge entry
+func (ukt *UpdateKnowledgeToolkit) UpdateKnowledgeEntry(params UpdateKnowledgeEntryParams) (string, error) {
+	editor, err := ukt.editor()
+	if err != nil {
+		return "", err
+	}
+
+	existing, err := editor.GetDocument(ukt.agent.ctx, params.ID)
+	if err != nil {
+		return "", fmt.Errorf("failed to find knowledge entry %s: %w", params.ID, err)
+	}
+
+	existing.Content = params.Content
+	existing.Embeddings = nil
+	existing.UpdatedAt = time.Now()
+	if params.Metadata != nil {
+		existing.Metadata = params.Metadata
+	}
+
+	if err := editor.UpsertDocument(ukt.agent.ctx, *existing); err != nil {
+		return "", fmt.Errorf("failed to update knowledge: %w", err)
+	}
+
+	return fmt.Sprintf("Updated 1 knowledge entry (ID: %s)", params.ID), nil
+}
+
 // ==================== ReadToolCallHistory Tool ====================
 
 // ReadToolCallHistoryToolkit provides tools to read tool call history
@@ -368,3 +513,102 @@ func (rth *ReadToolCallHistoryToolkit) GetToolStats(params GetToolStatsParams) (
 
 	return output.String(), nil
 }
+
+// ==================== MemoryRecall Tool ====================
+
+// MemoryRecallToolkit lets the model search the user's stored memories on demand
+type MemoryRecallToolkit struct {
+	toolkit.Toolkit
+	agent *Agent
+}
+
+// memorySearcher is implemented by memory managers that support semantic search
+type memorySearcher interface {
+	SearchMemoriesSemantic(ctx context.Context, userID, query string, limit int) ([]*memory.UserMemory, error)
+}
+
+// keywordMemorySearcher is implemented by memory managers that support keyword search
+type keywordMemorySearcher interface {
+	SearchMemoriesKeyword(ctx context.Context, userID, query string, limit int) ([]*memory.UserMemory, error)
+}
+
+// userMemorySearcher is implemented by memory managers that can rank memories
+// by relevance, used to pick which memories go into the system message
+type userMemorySearcher interface {
+	SearchUserMemories(ctx context.Context, userID, query string, limit int) ([]*memory.UserMemory, error)
+}
+
+// NewMemoryRecallTool creates a new memory recall tool
+func NewMemoryRecallTool(agent *Agent) toolkit.Tool {
+	if agent.memory == nil {
+		return nil
+	}
+
+	mrt := &MemoryRecallToolkit{
+		agent: agent,
+	}
+
+	tk := toolkit.NewToolkit()
+	tk.Name = "memory"
+	tk.Description = "Recall stored memories about the current user"
+
+	tk.Register("RecallMemory", "Search the user's stored memories for information relevant to a query", mrt, mrt.RecallMemory, RecallMemoryParams{})
+
+	mrt.Toolkit = tk
+	return &tk
+}
+
+// RecallMemoryParams defines parameters for recalling memories
+type RecallMemoryParams struct {
+	Query string `json:"query" jsonschema:"required,description=What to recall about the user"`
+	Limit int    `json:"limit" jsonschema:"description=Maximum number of memories to return (default 5)"`
+}
+
+// RecallMemory searches the user's memories, using semantic search when the memory
+// manager supports it and keyword search when it doesn't or semantic search
+// fails, e.g. without an embedder. Semantic search failures are logged.
+func (mrt *MemoryRecallToolkit) RecallMemory(params RecallMemoryParams) (string, error) {
+	if mrt.agent.userID == "" {
+		return "", fmt.Errorf("no user ID set for this run")
+	}
+	if params.Limit <= 0 {
+		params.Limit = 5
+	}
+
+	semantic, canSearchSemantic := mrt.agent.memory.(memorySearcher)
+	keyword, canSearchKeyword := mrt.agent.memory.(keywordMemorySearcher)
+	if !canSearchSemantic && !canSearchKeyword {
+		return "", fmt.Errorf("memory manager does not support searching memories")
+	}
+
+	var memories []*memory.UserMemory
+	var semanticErr error
+	if canSearchSemantic {
+		memories, semanticErr = semantic.SearchMemoriesSemantic(mrt.agent.ctx, mrt.agent.userID, params.Query, params.Limit)
+		if semanticErr != nil && !canSearchKeyword {
+			return "", fmt.Errorf("failed to search memories: %w", semanticErr)
+		}
+		if semanticErr != nil {
+			mrt.agent.logWarning("memory_semantic_search_failed", "Semantic memory search failed, using keyword search", semanticErr)
+		}
+	}
+	if !canSearchSemantic || semanticErr != nil {
+		var err error
+		memories, err = keyword.SearchMemoriesKeyword(mrt.agent.ctx, mrt.agent.userID, params.Query, params.Limit)
+		if err != nil {
+			return "", fmt.Errorf("failed to search memories: %w", err)
+		}
+	}
+
+	if len(memories) == 0 {
+		return "No matching memories found", nil
+	}
+
+	var output strings.Builder
+	output.WriteString(fmt.Sprintf("Found %d memories:\n\n", len(memories)))
+	for _, m := range memories {
+		output.WriteString(fmt.Sprintf("- [%s] %s\n", m.ID, m.Memory))
+	}
+
+	return output.String(), nil
+}
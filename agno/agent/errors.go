@@ -0,0 +1,208 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// The errors below describe why a run failed. They are returned wrapped, so
+// use errors.As to branch on them instead of matching error text:
+//
+//	resp, err := ag.Run(prompt)
+//	var guardErr *agent.GuardrailError
+//	var toolErr *agent.ToolExecutionError
+//	var modelErr *agent.ModelError
+//	switch {
+//	case errors.As(err, &guardErr):
+//		fmt.Printf("blocked by %s (%s)\n", guardErr.Guardrail, guardErr.Stage)
+//	case errors.As(err, &toolErr):
+//		fmt.Printf("tool %s failed\n", toolErr.Tool)
+//	case errors.As(err, &modelErr) && modelErr.StatusCode == http.StatusTooManyRequests:
+//		// back off and retry later
+//	}
+
+// GuardrailStage is the point of a run where a guardrail rejected the data
+type GuardrailStage string
+
+const (
+	GuardrailStageInput  GuardrailStage = "input"
+	GuardrailStageOutput GuardrailStage = "output"
+	GuardrailStageTool   GuardrailStage = "tool"
+)
+
+// GuardrailError is returned when a guardrail rejects the input, the output
+// or a tool call
+type GuardrailError struct {
+	Guardrail string         // Name of the guardrail that failed
+	Stage     GuardrailStage // Empty when returned by RunGuardrails outside a run
+	Err       error
+}
+
+func (e *GuardrailError) Error() string {
+	return fmt.Sprintf("guardrail '%s' failed: %v", e.Guardrail, e.Err)
+}
+
+func (e *GuardrailError) Unwrap() error {
+	return e.Err
+}
+
+// ToolExecutionError is returned when a tool called by the model fails
+type ToolExecutionError struct {
+	Tool   string // Toolkit name, e.g. "math"
+	Method string // Method called by the model, e.g. "math_add"
+	Err    error
+}
+
+func (e *ToolExecutionError) Error() string {
+	return fmt.Sprintf("tool %s failed: %v", e.Method, e.Err)
+}
+
+func (e *ToolExecutionError) Unwrap() error {
+	return e.Err
+}
+
+// ToolTimeoutError describes a tool call that exceeded its ToolTimeout. The
+// model gets it as the tool result, and tool audit records and after hooks
+// see it in the toolkit.ToolResult Data. It matches context.DeadlineExceeded
+// with errors.Is.
+type ToolTimeoutError struct {
+	Tool    string // Toolkit name
+	Method  string // Method called by the model
+	Timeout time.Duration
+}
+
+func (e *ToolTimeoutError) Error() string {
+	return fmt.Sprintf("tool %s timed out after %s", e.Method, e.Timeout)
+}
+
+func (e *ToolTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// ModelError is returned when the model call of a run fails
+type ModelError struct {
+	Model      string // Model ID
+	StatusCode int    // HTTP status of the provider response, 0 if unknown
+	Err        error
+}
+
+func (e *ModelError) Error() string {
+	msg := fmt.Sprintf("model %s call failed", e.Model)
+	if e.StatusCode != 0 {
+		msg += fmt.Sprintf(" (status %d)", e.StatusCode)
+	}
+	return msg + ": " + e.Err.Error()
+}
+
+func (e *ModelError) Unwrap() error {
+	return e.Err
+}
+
+// ParseError is returned when the response cannot be parsed into the output schema
+type ParseError struct {
+	Schema   string // Output schema type, e.g. "main.MovieScript"
+	Response string // Text that failed to parse
+	Err      error
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("failed to parse response into output schema %s: %v\nResponse preview: %s", e.Schema, e.Err, truncateString(e.Response, 500))
+}
+
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// BudgetExceededError is returned when a run exhausts its retry budget
+// (see WithMaxTotalRetries)
+type BudgetExceededError = RetryBudgetExhaustedError
+
+// TimeoutError is returned when a run exceeds its timeout (see WithTimeout).
+// It matches context.DeadlineExceeded with errors.Is.
+type TimeoutError struct {
+	Timeout time.Duration
+	Err     error // Error the run failed with when the deadline passed
+}
+
+func (e *TimeoutError) Error() string {
+	if errors.Is(e.Err, context.DeadlineExceeded) {
+		return fmt.Sprintf("agent run timed out after %s: %v", e.Timeout, e.Err)
+	}
+	return fmt.Sprintf("agent run timed out after %s: %v (%v)", e.Timeout, context.DeadlineExceeded, e.Err)
+}
+
+func (e *TimeoutError) Unwrap() []error {
+	return []error{context.DeadlineExceeded, e.Err}
+}
+
+// runGuardrails runs guardrails like RunGuardrails, records stage in the
+// returned GuardrailError and logs the decision
+func (a *Agent) runGuardrails(ctx context.Context, stage GuardrailStage, guardrails []Guardrail, data interface{}) error {
+	start := time.Now()
+	err := RunGuardrails(ctx, guardrails, data)
+	fields := map[string]interface{}{
+		"stage":       string(stage),
+		"guardrails":  len(guardrails),
+		"decision":    "passed",
+		"duration_ms": time.Since(start).Milliseconds(),
+	}
+	level := LogLevelDebug
+	var guardErr *GuardrailError
+	if errors.As(err, &guardErr) {
+		guardErr.Stage = stage
+		level = LogLevelWarn
+		fields["decision"] = "blocked"
+		fields["guardrail"] = guardErr.Guardrail
+		fields["error"] = guardErr.Err
+	}
+	a.logEvent(level, "guardrail", fields)
+	return err
+}
+
+// newModelError wraps an error returned by a model call of run in a
+// ModelError. Errors raised by tools, guardrails or the caller inside the call
+// are returned as they are.
+func (a *Agent) newModelError(run *runState, err error) error {
+	var toolErr *ToolExecutionError
+	var guardErr *GuardrailError
+	var modelErr *ModelError
+	if err == nil || errors.As(err, &toolErr) || errors.As(err, &guardErr) || errors.As(err, &modelErr) || errors.Is(err, ErrStopStream) {
+		return err
+	}
+
+	modelID := ""
+	if model := a.activeModel(run); model != nil {
+		modelID = model.GetID()
+	}
+	return &ModelError{Model: modelID, StatusCode: errorStatusCode(err), Err: err}
+}
+
+// errorStatusCode finds the HTTP status code of a provider error. SDK errors
+// such as *openai.Error and ollama's api.StatusError carry it in a StatusCode
+// field rather than a method.
+func errorStatusCode(err error) int {
+	for ; err != nil; err = errors.Unwrap(err) {
+		v := reflect.ValueOf(err)
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				break
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct {
+			continue
+		}
+		if field := v.FieldByName("StatusCode"); field.IsValid() && field.CanInt() {
+			return int(field.Int())
+		}
+	}
+	return 0
+}
+
+// newParseError describes a failure to parse response into the output schema type
+func newParseError(schemaType reflect.Type, response string, err error) error {
+	return &ParseError{Schema: schemaType.String(), Response: response, Err: err}
+}
@@ -0,0 +1,113 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// apiError mimics provider SDK errors, which expose the HTTP status as a field
+type apiError struct {
+	StatusCode int
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("provider returned status %d", e.StatusCode)
+}
+
+// errModel fails every call with err
+type errModel struct {
+	stubModel
+	err error
+}
+
+func (m *errModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	m.calls++
+	return nil, m.err
+}
+
+func TestRunErrorTypes(t *testing.T) {
+	t.Run("guardrail", func(t *testing.T) {
+		ag, _ := NewAgent(AgentConfig{
+			Context: context.Background(),
+			Model:   &stubModel{content: "ok"},
+			InputGuardrails: []Guardrail{&GuardrailFunc{
+				Name:      "NoSecrets",
+				CheckFunc: func(ctx context.Context, data interface{}) error { return errors.New("secret detected") },
+			}},
+		})
+		_, err := ag.Run("my password is hunter2")
+
+		var guardErr *GuardrailError
+		if !errors.As(err, &guardErr) || guardErr.Guardrail != "NoSecrets" || guardErr.Stage != GuardrailStageInput {
+			t.Fatalf("Expected an input GuardrailError from NoSecrets, got %#v", err)
+		}
+	})
+
+	t.Run("model", func(t *testing.T) {
+		ag, _ := NewAgent(AgentConfig{
+			Context: context.Background(),
+			Model:   &errModel{err: fmt.Errorf("request failed: %w", &apiError{StatusCode: http.StatusTooManyRequests})},
+		})
+		_, err := ag.Run("hello")
+
+		var modelErr *ModelError
+		if !errors.As(err, &modelErr) || modelErr.Model != "stub" || modelErr.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("Expected a ModelError with status 429, got %#v", err)
+		}
+	})
+
+	t.Run("parse", func(t *testing.T) {
+		ag, _ := NewAgent(AgentConfig{
+			Context:       context.Background(),
+			Model:         &stubModel{content: "not json"},
+			OutputSchema:  &struct{ Title string }{},
+			ParseResponse: true,
+		})
+		_, err := ag.Run("hello")
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) || parseErr.Response != "not json" {
+			t.Fatalf("Expected a ParseError, got %#v", err)
+		}
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		ag, _ := NewAgent(AgentConfig{Context: context.Background(), Model: &slowModel{}})
+		_, err := ag.Run("hello", WithTimeout(10*time.Millisecond))
+
+		var timeoutErr *TimeoutError
+		if !errors.As(err, &timeoutErr) || timeoutErr.Timeout != 10*time.Millisecond || !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("Expected a TimeoutError, got %#v", err)
+		}
+	})
+}
+
+func TestToolWrapperReturnsToolExecutionError(t *testing.T) {
+	tk := toolkit.NewToolkit()
+	tk.Name = "files"
+	tk.Register("read", "Fails to read", &tk, func(params bigOutputParams) (interface{}, error) {
+		return nil, errors.New("permission denied")
+	}, bigOutputParams{})
+
+	wrapper := &ToolWrapper{Tool: &tk, agent: &Agent{ctx: context.Background()}}
+	_, err := wrapper.Execute("files_read", json.RawMessage(`{}`))
+
+	var toolErr *ToolExecutionError
+	if !errors.As(err, &toolErr) || toolErr.Tool != "files" || toolErr.Method != "files_read" {
+		t.Fatalf("Expected a ToolExecutionError for files_read, got %#v", err)
+	}
+
+	// Model clients that run tools return their errors from the model call
+	var modelErr *ModelError
+	if errors.As((&Agent{}).newModelError(nil, fmt.Errorf("error executing tool: %w", err)), &modelErr) {
+		t.Errorf("Expected a tool error raised inside a model call not to become a ModelError")
+	}
+}
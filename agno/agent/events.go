@@ -0,0 +1,258 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// RunEventType identifies the kind of event emitted during a streamed run
+type RunEventType string
+
+const (
+	RunEventContent           RunEventType = "RunContent"
+	RunEventToolCallStarted   RunEventType = "ToolCallStarted"
+	RunEventToolCallCompleted RunEventType = "ToolCallCompleted"
+	RunEventCompleted         RunEventType = "RunCompleted"
+	// RunEventToolOutput carries a line of output of a running tool, such as a
+	// ShellTool command, in Content
+	RunEventToolOutput RunEventType = "ToolOutput"
+	// RunEventContentRetracted tells the caller to discard the content streamed so
+	// far because an output guardrail blocked the response
+	RunEventContentRetracted RunEventType = "RunContentRetracted"
+	// RunEventError is the last event sent by RunChan when the run fails
+	RunEventError RunEventType = "RunError"
+)
+
+// RunEvent is a single event emitted while an agent run is streaming
+type RunEvent struct {
+	Event      RunEventType           `json:"event"`
+	Content    string                 `json:"content,omitempty"`
+	ToolName   string                 `json:"tool_name,omitempty"`
+	ToolArgs   map[string]interface{} `json:"tool_args,omitempty"`
+	ToolResult interface{}            `json:"tool_result,omitempty"`
+	Stream     string                 `json:"stream,omitempty"` // "stdout" or "stderr" for ToolOutput events
+	Error      string                 `json:"error,omitempty"`
+	// Metrics of a RunCompleted event: the input_tokens and output_tokens
+	// reported by the model for the run
+	Metrics   map[string]interface{} `json:"metrics,omitempty"`
+	CreatedAt time.Time              `json:"created_at"`
+	// Err is the error of a RunEventError event, for errors.Is and errors.As
+	Err error `json:"-"`
+}
+
+// eventToolWrapper reports tool calls as RunEvents
+type eventToolWrapper struct {
+	toolkit.Tool
+	emit func(RunEvent) error
+}
+
+// Execute emits ToolCallStarted and ToolCallCompleted around the wrapped tool call
+func (tw *eventToolWrapper) Execute(methodName string, input json.RawMessage) (interface{}, error) {
+	var args map[string]interface{}
+	if err := json.Unmarshal(input, &args); err != nil {
+		args = nil
+	}
+	toolName := tw.GetName() + "." + methodName
+
+	if err := tw.emit(RunEvent{
+		Event:     RunEventToolCallStarted,
+		ToolName:  toolName,
+		ToolArgs:  args,
+		CreatedAt: time.Now(),
+	}); err != nil {
+		return nil, err
+	}
+
+	result, err := tw.Tool.Execute(methodName, input)
+
+	completed := RunEvent{
+		Event:      RunEventToolCallCompleted,
+		ToolName:   toolName,
+		ToolArgs:   args,
+		ToolResult: result,
+		CreatedAt:  time.Now(),
+	}
+	if err != nil {
+		completed.Error = err.Error()
+	}
+	if emitErr := tw.emit(completed); emitErr != nil {
+		return result, emitErr
+	}
+
+	return result, err
+}
+
+// RunStreamEvents streams a run like RunStream, reporting text chunks, tool calls,
+// the output of running tools and completion to fn as RunEvents. Calls to fn
+// are serialized, so fn may write to a connection that does not support
+// concurrent writers.
+// Returning ErrStopStream from fn stops the generation and completes the run
+// with the text streamed so far; returning any other error aborts the run.
+// Options select the session and user of the run and its model settings as in
+// RunWithOptions; media inputs are not supported when streaming.
+func (a *Agent) RunStreamEvents(prompt string, fn func(RunEvent) error, opts ...RunOption) error {
+	options := &RunOptions{}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(options)
+		}
+	}
+	if err := options.validate(); err != nil {
+		return err
+	}
+	if err := a.checkToolChoice(options.ToolChoice); err != nil {
+		return err
+	}
+	if options.SessionID != nil {
+		a.sessionID = *options.SessionID
+	}
+	if options.UserID != nil {
+		a.userID = *options.UserID
+	}
+
+	parent := options.runContext
+	if parent == nil {
+		parent = a.ctx
+	}
+
+	var mu sync.Mutex
+	emit := func(event RunEvent) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return fn(event)
+	}
+
+	run := a.startRun(toolkit.ContextWithToolOutput(parent, func(output toolkit.ToolOutput) {
+		emit(RunEvent{
+			Event:     RunEventToolOutput,
+			Content:   output.Text,
+			ToolName:  output.Tool,
+			Stream:    output.Stream,
+			CreatedAt: time.Now(),
+		})
+	}), options)
+	for i, tool := range run.tools {
+		run.tools[i] = &eventToolWrapper{Tool: tool, emit: emit}
+	}
+
+	content, err := a.runStream(run, prompt, func(chunk []byte) error {
+		return emit(RunEvent{
+			Event:     RunEventContent,
+			Content:   string(chunk),
+			CreatedAt: time.Now(),
+		})
+	})
+	err = run.finish(err)
+	var blocked *OutputBlockedError
+	if errors.As(err, &blocked) {
+		if emitErr := emit(RunEvent{
+			Event:     RunEventContentRetracted,
+			Content:   blocked.Flushed,
+			Error:     blocked.Err.Error(),
+			CreatedAt: time.Now(),
+		}); emitErr != nil {
+			return emitErr
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	return emit(RunEvent{
+		Event:     RunEventCompleted,
+		Content:   content,
+		Metrics:   run.metrics(),
+		CreatedAt: time.Now(),
+	})
+}
+
+// defaultRunChanBuffer is the number of events RunChan buffers by default
+const defaultRunChanBuffer = 16
+
+// RunChanOption configures RunChan
+type RunChanOption func(*runChanOptions)
+
+type runChanOptions struct {
+	ctx        context.Context
+	buffer     int
+	runOptions []RunOption
+}
+
+// WithChanContext stops the run when ctx is done. The generation stops like
+// with ErrStopStream and the channel is closed without further events, so a
+// consumer that goes away, such as a disconnected HTTP client, does not leave
+// the run blocked on the channel.
+func WithChanContext(ctx context.Context) RunChanOption {
+	return func(o *runChanOptions) {
+		o.ctx = ctx
+	}
+}
+
+// WithChanBuffer configures how many events are buffered before the run waits
+// for the consumer (default 16, 0 for an unbuffered channel)
+func WithChanBuffer(size int) RunChanOption {
+	return func(o *runChanOptions) {
+		o.buffer = size
+	}
+}
+
+// WithChanRunOptions passes RunOptions, such as WithSessionID, to the run
+func WithChanRunOptions(opts ...RunOption) RunChanOption {
+	return func(o *runChanOptions) {
+		o.runOptions = append(o.runOptions, opts...)
+	}
+}
+
+// RunChan streams a run like RunStreamEvents and delivers the events on the
+// returned channel: RunContent deltas, ToolCallStarted and ToolCallCompleted
+// around tool calls, ToolOutput lines of running tools, and RunCompleted with
+// the full response. If the run fails, the last event is a RunError carrying
+// the error. The channel is closed when the run ends; the agent must not be
+// run again before then.
+func (a *Agent) RunChan(prompt string, opts ...RunChanOption) (<-chan RunEvent, error) {
+	options := runChanOptions{
+		ctx:    context.Background(),
+		buffer: defaultRunChanBuffer,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.ctx == nil {
+		return nil, fmt.Errorf("run channel context cannot be nil")
+	}
+	if options.buffer < 0 {
+		return nil, fmt.Errorf("run channel buffer cannot be negative: %d", options.buffer)
+	}
+
+	events := make(chan RunEvent, options.buffer)
+	go func() {
+		defer close(events)
+
+		send := func(event RunEvent) error {
+			select {
+			case events <- event:
+				return nil
+			case <-options.ctx.Done():
+				return ErrStopStream
+			}
+		}
+
+		err := a.RunStreamEvents(prompt, send, options.runOptions...)
+		if err != nil && options.ctx.Err() == nil {
+			send(RunEvent{
+				Event:     RunEventError,
+				Error:     err.Error(),
+				Err:       err,
+				CreatedAt: time.Now(),
+			})
+		}
+	}()
+
+	return events, nil
+}
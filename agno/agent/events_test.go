@@ -0,0 +1,229 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func TestRunStreamEvents(t *testing.T) {
+	ag, err := NewAgent(AgentConfig{
+		Model: &stubModel{content: "hello"},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	var events []RunEvent
+	err = ag.RunStreamEvents("hi", func(event RunEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunStreamEvents failed: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 events, got %d", len(events))
+	}
+	if events[0].Event != RunEventContent || events[0].Content != "hello" {
+		t.Errorf("Unexpected content event: %+v", events[0])
+	}
+	if events[1].Event != RunEventCompleted || events[1].Content != "hello" {
+		t.Errorf("Unexpected completed event: %+v", events[1])
+	}
+}
+
+// streamToolModel calls every method of the first tool before streaming its content
+type streamToolModel struct {
+	stubModel
+}
+
+func (m *streamToolModel) InvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) error {
+	callOpts := models.DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOpts)
+	}
+	tool := callOpts.ToolCall[0]
+	for name := range tool.GetMethods() {
+		if _, err := tool.Execute(name, json.RawMessage(`{}`)); err != nil {
+			return err
+		}
+	}
+	return m.stubModel.InvokeStream(ctx, messages, options...)
+}
+
+// printerTool reports two lines of output while it runs
+type printerTool struct {
+	toolkit.Toolkit
+}
+
+func (t *printerTool) ExecuteContext(ctx context.Context, methodName string, input json.RawMessage) (interface{}, error) {
+	if output := toolkit.ToolOutputFromContext(ctx); output != nil {
+		output(toolkit.ToolOutput{Tool: methodName, Stream: "stdout", Text: "building"})
+		output(toolkit.ToolOutput{Tool: methodName, Stream: "stderr", Text: "warning"})
+	}
+	return "done", nil
+}
+
+func newPrinterTool() *printerTool {
+	tool := &printerTool{Toolkit: toolkit.NewToolkit()}
+	tool.Name = "printer"
+	tool.Register("run", "Prints", tool, func(params struct{}) (interface{}, error) { return "done", nil }, struct{}{})
+	return tool
+}
+
+func TestRunStreamEventsToolOutput(t *testing.T) {
+	ag, err := NewAgent(AgentConfig{
+		Model: &streamToolModel{stubModel{content: "ok"}},
+		Tools: []toolkit.Tool{newPrinterTool()},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	var got []RunEventType
+	var outputs []RunEvent
+	err = ag.RunStreamEvents("build", func(event RunEvent) error {
+		got = append(got, event.Event)
+		if event.Event == RunEventToolOutput {
+			outputs = append(outputs, event)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunStreamEvents failed: %v", err)
+	}
+
+	want := []RunEventType{RunEventToolCallStarted, RunEventToolOutput, RunEventToolOutput, RunEventToolCallCompleted, RunEventContent, RunEventCompleted}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected events %v, got %v", want, got)
+	}
+	if outputs[0].Content != "building" || outputs[0].Stream != "stdout" || outputs[0].ToolName != "printer_run" {
+		t.Errorf("Unexpected tool output event: %+v", outputs[0])
+	}
+	if outputs[1].Content != "warning" || outputs[1].Stream != "stderr" {
+		t.Errorf("Unexpected tool output event: %+v", outputs[1])
+	}
+
+	// Tools stop reporting once the streamed run is over
+	var lines []string
+	if err := ag.RunStreamWithToolOutput("build", func([]byte) error { return nil }, func(out toolkit.ToolOutput) {
+		lines = append(lines, out.Text)
+	}); err != nil {
+		t.Fatalf("RunStreamWithToolOutput failed: %v", err)
+	}
+	if !reflect.DeepEqual(lines, []string{"building", "warning"}) {
+		t.Errorf("Expected the tool output lines, got %v", lines)
+	}
+}
+
+// streamErrModel fails every streamed call with err
+type streamErrModel struct {
+	stubModel
+	err error
+}
+
+func (m *streamErrModel) InvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) error {
+	return m.err
+}
+
+func TestRunChan(t *testing.T) {
+	ag, err := NewAgent(AgentConfig{
+		Model: &chunkedModel{chunks: []string{"hel", "lo"}},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	events, err := ag.RunChan("hi", WithChanBuffer(0))
+	if err != nil {
+		t.Fatalf("RunChan failed: %v", err)
+	}
+	var types []RunEventType
+	var last RunEvent
+	for event := range events {
+		types = append(types, event.Event)
+		last = event
+	}
+
+	want := []RunEventType{RunEventContent, RunEventContent, RunEventCompleted}
+	if !reflect.DeepEqual(types, want) {
+		t.Fatalf("Expected events %v, got %v", want, types)
+	}
+	if last.Content != "hello" {
+		t.Errorf("Expected final content %q, got %q", "hello", last.Content)
+	}
+
+	if _, err := ag.RunChan("hi", WithChanBuffer(-1)); err == nil {
+		t.Error("Expected an error for a negative buffer")
+	}
+}
+
+func TestRunChanError(t *testing.T) {
+	modelErr := errors.New("connection reset")
+	ag, err := NewAgent(AgentConfig{
+		Model: &streamErrModel{err: modelErr},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	events, err := ag.RunChan("hi")
+	if err != nil {
+		t.Fatalf("RunChan failed: %v", err)
+	}
+	var received []RunEvent
+	for event := range events {
+		received = append(received, event)
+	}
+
+	if len(received) != 1 || received[0].Event != RunEventError {
+		t.Fatalf("Expected a single error event, got %+v", received)
+	}
+	if !errors.Is(received[0].Err, modelErr) || received[0].Error == "" {
+		t.Errorf("Error event does not carry the model error: %+v", received[0])
+	}
+}
+
+func TestRunChanContextCancel(t *testing.T) {
+	ag, err := NewAgent(AgentConfig{
+		Model: &chunkedModel{chunks: []string{"one ", "two ", "three"}},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := ag.RunChan("hi", WithChanContext(ctx), WithChanBuffer(0))
+	if err != nil {
+		t.Fatalf("RunChan failed: %v", err)
+	}
+
+	if event := <-events; event.Content != "one " {
+		t.Fatalf("Unexpected first event: %+v", event)
+	}
+	cancel()
+
+	// The run must end and close the channel without a consumer draining it
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for event := range events {
+			if event.Event == RunEventError {
+				t.Errorf("Unexpected error event after cancel: %+v", event)
+			}
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunChan did not close the channel after cancel")
+	}
+}
@@ -2,11 +2,17 @@ package agent
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"math"
 	"regexp"
 	"strings"
 	"sync"
 	"time"
+
+	"github.com/devalexandre/agno-golang/agno/embedder"
+	"github.com/devalexandre/agno-golang/agno/models"
 )
 
 // Guardrail represents a reusable validation/policy rule
@@ -62,16 +68,139 @@ func (gc *GuardrailChain) GetDescription() string {
 	return fmt.Sprintf("Chain of %d guardrails", len(gc.Guardrails))
 }
 
-// RunGuardrails executes a list of guardrails on data
+// guardrailText extracts the text checked by text guardrails: the input string,
+// or the response text for output guardrails. Responses are checked too, so a
+// text guardrail listed in OutputGuardrails applies to the response text.
+func guardrailText(data interface{}) (string, bool) {
+	switch v := data.(type) {
+	case string:
+		return v, true
+	case models.RunResponse:
+		return v.TextContent, true
+	case *models.RunResponse:
+		if v == nil {
+			return "", false
+		}
+		return v.TextContent, true
+	default:
+		return "", false
+	}
+}
+
+// RunGuardrails executes a list of guardrails on data. It stops at the first
+// failure and returns it as a *GuardrailError.
 func RunGuardrails(ctx context.Context, guardrails []Guardrail, data interface{}) error {
 	for _, gr := range guardrails {
 		if err := gr.Check(ctx, data); err != nil {
-			return fmt.Errorf("guardrail '%s' failed: %w", gr.GetName(), err)
+			return &GuardrailError{Guardrail: gr.GetName(), Err: err}
 		}
 	}
 	return nil
 }
 
+// ===== COMPOSITE GUARDRAILS =====
+
+// CompositeOp is the logical operator applied by a CompositeGuardrail
+type CompositeOp string
+
+const (
+	// CompositeAll passes when every guardrail passes (stops at the first failure)
+	CompositeAll CompositeOp = "all"
+	// CompositeAny passes when at least one guardrail passes (stops at the first pass)
+	CompositeAny CompositeOp = "any"
+	// CompositeNot passes when its single guardrail fails
+	CompositeNot CompositeOp = "not"
+)
+
+// CompositeGuardrail combines guardrails with AND/OR/NOT logic. Composites are
+// guardrails themselves, so they can be nested to express policies such as
+// "block prompt injection or long input, unless the user is an admin":
+//
+//	policy := agent.NewCompositeGuardrail("AdminBypass", agent.CompositeAny,
+//		isAdmin,
+//		agent.All(agent.NewPromptInjectionGuardrail(), agent.NewInputLengthGuardrail(5000)),
+//	)
+type CompositeGuardrail struct {
+	Name       string
+	Op         CompositeOp
+	Guardrails []Guardrail
+}
+
+// NewCompositeGuardrail creates a named composite guardrail
+func NewCompositeGuardrail(name string, op CompositeOp, guardrails ...Guardrail) *CompositeGuardrail {
+	return &CompositeGuardrail{
+		Name:       name,
+		Op:         op,
+		Guardrails: guardrails,
+	}
+}
+
+// All passes only when every guardrail passes
+func All(guardrails ...Guardrail) *CompositeGuardrail {
+	return NewCompositeGuardrail("All", CompositeAll, guardrails...)
+}
+
+// Any passes when at least one guardrail passes
+func Any(guardrails ...Guardrail) *CompositeGuardrail {
+	return NewCompositeGuardrail("Any", CompositeAny, guardrails...)
+}
+
+// Not inverts a guardrail: it passes when the guardrail fails
+func Not(guardrail Guardrail) *CompositeGuardrail {
+	return NewCompositeGuardrail("Not", CompositeNot, guardrail)
+}
+
+func (c *CompositeGuardrail) Check(ctx context.Context, data interface{}) error {
+	switch c.Op {
+	case CompositeAll:
+		for _, guardrail := range c.Guardrails {
+			if err := guardrail.Check(ctx, data); err != nil {
+				return fmt.Errorf("%s failed: %w", guardrail.GetName(), err)
+			}
+		}
+		return nil
+
+	case CompositeAny:
+		errs := make([]error, 0, len(c.Guardrails))
+		for _, guardrail := range c.Guardrails {
+			err := guardrail.Check(ctx, data)
+			if err == nil {
+				return nil
+			}
+			errs = append(errs, fmt.Errorf("%s failed: %w", guardrail.GetName(), err))
+		}
+		return fmt.Errorf("no guardrail passed: %w", errors.Join(errs...))
+
+	case CompositeNot:
+		if len(c.Guardrails) != 1 {
+			return fmt.Errorf("not guardrail requires exactly one guardrail, got %d", len(c.Guardrails))
+		}
+		// A cancelled context must not be inverted into a pass
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if c.Guardrails[0].Check(ctx, data) == nil {
+			return fmt.Errorf("%s passed but must not", c.Guardrails[0].GetName())
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unknown composite operator: %s", c.Op)
+	}
+}
+
+func (c *CompositeGuardrail) GetName() string {
+	return c.Name
+}
+
+func (c *CompositeGuardrail) GetDescription() string {
+	names := make([]string, len(c.Guardrails))
+	for i, guardrail := range c.Guardrails {
+		names[i] = guardrail.GetName()
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(string(c.Op)), strings.Join(names, ", "))
+}
+
 // ===== INPUT VALIDATION GUARDRAILS =====
 
 // PromptInjectionGuardrail detects common prompt injection patterns
@@ -99,7 +228,7 @@ func NewPromptInjectionGuardrail() *PromptInjectionGuardrail {
 }
 
 func (p *PromptInjectionGuardrail) Check(ctx context.Context, data interface{}) error {
-	text, ok := data.(string)
+	text, ok := guardrailText(data)
 	if !ok {
 		return nil
 	}
@@ -131,7 +260,7 @@ func NewInputLengthGuardrail(maxLength int) *InputLengthGuardrail {
 }
 
 func (i *InputLengthGuardrail) Check(ctx context.Context, data interface{}) error {
-	text, ok := data.(string)
+	text, ok := guardrailText(data)
 	if !ok {
 		return nil
 	}
@@ -173,7 +302,7 @@ func NewOutputContentGuardrail() *OutputContentGuardrail {
 }
 
 func (o *OutputContentGuardrail) Check(ctx context.Context, data interface{}) error {
-	text, ok := data.(string)
+	text, ok := guardrailText(data)
 	if !ok {
 		return nil
 	}
@@ -196,10 +325,14 @@ func (o *OutputContentGuardrail) GetDescription() string {
 
 // ===== RATE LIMITING GUARDRAILS =====
 
-// RateLimitGuardrail enforces rate limiting per user
+// RateLimitGuardrail enforces rate limiting per user, or per any key derived
+// from the context (organization, plan, ...), with optional per-tier limits
 type RateLimitGuardrail struct {
 	maxRequests int
 	windowSize  time.Duration
+	keyFn       func(ctx context.Context) string
+	tierFn      func(ctx context.Context) string
+	tierLimits  map[string]int
 	userLimits  map[string]*userRateLimit
 	mu          sync.RWMutex
 }
@@ -209,32 +342,88 @@ type userRateLimit struct {
 	lastClean time.Time
 }
 
+// metadataContextKey carries the run metadata in the context passed to guardrails
+type metadataContextKey struct{}
+
+// ContextWithMetadata returns a context carrying run metadata. Agent.Run uses it
+// for input guardrails so they can read values passed with WithMetadata.
+func ContextWithMetadata(ctx context.Context, metadata map[string]interface{}) context.Context {
+	return context.WithValue(ctx, metadataContextKey{}, metadata)
+}
+
+// MetadataFromContext returns the run metadata stored by ContextWithMetadata
+func MetadataFromContext(ctx context.Context) map[string]interface{} {
+	metadata, _ := ctx.Value(metadataContextKey{}).(map[string]interface{})
+	return metadata
+}
+
+// MetadataKey returns a key function reading a run metadata value, for use
+// with NewRateLimitGuardrailWithKey and WithTierLimits
+func MetadataKey(key string) func(ctx context.Context) string {
+	return func(ctx context.Context) string {
+		value, ok := MetadataFromContext(ctx)[key]
+		if !ok || value == nil {
+			return ""
+		}
+		return fmt.Sprint(value)
+	}
+}
+
+// userIDKey is the default rate limit key: the "user_id" context value
+func userIDKey(ctx context.Context) string {
+	userID, _ := ctx.Value("user_id").(string)
+	return userID
+}
+
 // NewRateLimitGuardrail creates a rate limiting guardrail
 func NewRateLimitGuardrail(maxRequests int, windowSize time.Duration) *RateLimitGuardrail {
+	return NewRateLimitGuardrailWithKey(userIDKey, maxRequests, windowSize)
+}
+
+// NewRateLimitGuardrailWithKey creates a rate limiting guardrail that counts
+// requests per key, e.g. agent.MetadataKey("organization")
+func NewRateLimitGuardrailWithKey(keyFn func(ctx context.Context) string, maxRequests int, windowSize time.Duration) *RateLimitGuardrail {
 	return &RateLimitGuardrail{
 		maxRequests: maxRequests,
 		windowSize:  windowSize,
+		keyFn:       keyFn,
 		userLimits:  make(map[string]*userRateLimit),
 	}
 }
 
+// WithTierLimits sets per-tier request limits, e.g. a higher limit for the
+// "enterprise" plan. tierFn resolves the tier of a request; unknown tiers use
+// the default limit.
+func (r *RateLimitGuardrail) WithTierLimits(tierFn func(ctx context.Context) string, limits map[string]int) *RateLimitGuardrail {
+	r.tierFn = tierFn
+	r.tierLimits = limits
+	return r
+}
+
 func (r *RateLimitGuardrail) Check(ctx context.Context, data interface{}) error {
-	userID, ok := ctx.Value("user_id").(string)
-	if !ok {
-		userID = "anonymous"
+	key := r.keyFn(ctx)
+	if key == "" {
+		key = "anonymous"
+	}
+
+	maxRequests := r.maxRequests
+	if r.tierFn != nil {
+		if limit, ok := r.tierLimits[r.tierFn(ctx)]; ok {
+			maxRequests = limit
+		}
 	}
 
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
 	now := time.Now()
-	limit, exists := r.userLimits[userID]
+	limit, exists := r.userLimits[key]
 	if !exists {
 		limit = &userRateLimit{
 			requests:  []time.Time{},
 			lastClean: now,
 		}
-		r.userLimits[userID] = limit
+		r.userLimits[key] = limit
 	}
 
 	// Clean old requests
@@ -254,8 +443,8 @@ func (r *RateLimitGuardrail) Check(ctx context.Context, data interface{}) error
 	limit.requests = validRequests
 
 	// Check limit
-	if len(limit.requests) >= r.maxRequests {
-		return fmt.Errorf("rate limit exceeded for user %s: %d requests in %v", userID, len(limit.requests), r.windowSize)
+	if len(limit.requests) >= maxRequests {
+		return fmt.Errorf("rate limit exceeded for %s: %d requests in %v", key, len(limit.requests), r.windowSize)
 	}
 
 	// Add current request
@@ -268,6 +457,9 @@ func (r *RateLimitGuardrail) GetName() string {
 }
 
 func (r *RateLimitGuardrail) GetDescription() string {
+	if len(r.tierLimits) > 0 {
+		return fmt.Sprintf("Rate limit: %d requests per %v (%d tiers)", r.maxRequests, r.windowSize, len(r.tierLimits))
+	}
 	return fmt.Sprintf("Rate limit: %d requests per %v", r.maxRequests, r.windowSize)
 }
 
@@ -326,24 +518,51 @@ func (l *LoopDetectionGuardrail) ResetLoopCounter(runID string) {
 
 // ===== SEMANTIC GUARDRAILS =====
 
-// SemanticSimilarityGuardrail detects repetitive outputs
+// SemanticSimilarityGuardrail detects repetitive outputs by comparing each
+// output with the previous outputs of the same run (the "run_id" context value).
+// With an Embedder it compares cosine similarity of embeddings; without one it
+// falls back to a character overlap score.
 type SemanticSimilarityGuardrail struct {
 	maxSimilarity float64
-	history       map[string][]string
+	window        int
+	embedder      embedder.Embedder
+	history       map[string][]similarityEntry
 	mu            sync.RWMutex
 }
 
+// similarityEntry is a previous output and, when an embedder is set, its embedding
+type similarityEntry struct {
+	text      string
+	embedding []float64
+}
+
+// defaultSimilarityWindow is the number of previous outputs compared by default
+const defaultSimilarityWindow = 5
+
 // NewSemanticSimilarityGuardrail creates a guardrail to detect repetitive outputs
+// using character overlap against the last 5 outputs
 func NewSemanticSimilarityGuardrail(maxSimilarity float64) *SemanticSimilarityGuardrail {
+	return NewSemanticSimilarityGuardrailWithEmbedder(nil, maxSimilarity, defaultSimilarityWindow)
+}
+
+// NewSemanticSimilarityGuardrailWithEmbedder creates a guardrail that blocks an
+// output whose embedding has a cosine similarity above threshold with any of the
+// last window outputs. A nil embedder uses character overlap instead.
+func NewSemanticSimilarityGuardrailWithEmbedder(emb embedder.Embedder, threshold float64, window int) *SemanticSimilarityGuardrail {
+	if window <= 0 {
+		window = defaultSimilarityWindow
+	}
 	return &SemanticSimilarityGuardrail{
-		maxSimilarity: maxSimilarity,
-		history:       make(map[string][]string),
+		maxSimilarity: threshold,
+		window:        window,
+		embedder:      emb,
+		history:       make(map[string][]similarityEntry),
 	}
 }
 
 func (s *SemanticSimilarityGuardrail) Check(ctx context.Context, data interface{}) error {
-	text, ok := data.(string)
-	if !ok {
+	text, ok := guardrailText(data)
+	if !ok || text == "" {
 		return nil
 	}
 
@@ -352,26 +571,33 @@ func (s *SemanticSimilarityGuardrail) Check(ctx context.Context, data interface{
 		runID = "default"
 	}
 
+	entry := similarityEntry{text: text}
+	if s.embedder != nil {
+		embedding, err := s.embedder.GetEmbedding(text)
+		if err != nil {
+			log.Printf("Warning: SemanticSimilarityGuardrail failed to embed output, using character overlap: %v", err)
+		} else {
+			entry.embedding = embedding
+		}
+	}
+
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	history, exists := s.history[runID]
-	if !exists {
-		history = []string{}
-	}
+	history := s.history[runID]
 
 	// Check similarity with recent outputs
 	for _, prev := range history {
-		similarity := calculateStringSimilarity(text, prev)
+		similarity := entrySimilarity(entry, prev)
 		if similarity > s.maxSimilarity {
 			return fmt.Errorf("output too similar to previous output: %.2f > %.2f", similarity, s.maxSimilarity)
 		}
 	}
 
-	// Keep last 5 outputs
-	history = append(history, text)
-	if len(history) > 5 {
-		history = history[len(history)-5:]
+	// Keep the last window outputs
+	history = append(history, entry)
+	if len(history) > s.window {
+		history = history[len(history)-s.window:]
 	}
 	s.history[runID] = history
 
@@ -383,7 +609,36 @@ func (s *SemanticSimilarityGuardrail) GetName() string {
 }
 
 func (s *SemanticSimilarityGuardrail) GetDescription() string {
-	return fmt.Sprintf("Detects repetitive outputs with similarity > %.2f", s.maxSimilarity)
+	method := "character overlap"
+	if s.embedder != nil {
+		method = "embedding cosine similarity"
+	}
+	return fmt.Sprintf("Detects repetitive outputs with %s > %.2f over the last %d outputs", method, s.maxSimilarity, s.window)
+}
+
+// entrySimilarity compares embeddings when both outputs have one
+func entrySimilarity(a, b similarityEntry) float64 {
+	if a.embedding != nil && b.embedding != nil {
+		return cosineSimilarity(a.embedding, b.embedding)
+	}
+	return calculateStringSimilarity(a.text, b.text)
+}
+
+// cosineSimilarity calculates the cosine similarity between two vectors
+func cosineSimilarity(a, b []float64) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var dot, normA, normB float64
+	for i := range a {
+		dot += a[i] * b[i]
+		normA += a[i] * a[i]
+		normB += b[i] * b[i]
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
 }
 
 // calculateStringSimilarity calculates Levenshtein distance-based similarity
@@ -0,0 +1,262 @@
+package agent
+
+import (
+	"encoding/json"
+	"strings"
+	"unicode"
+)
+
+// RepairJSON makes a best-effort attempt to turn model output into valid JSON:
+// it strips markdown code fences and surrounding prose, extracts the first
+// balanced JSON object or array, and fixes common mistakes of local models:
+// trailing commas, comments, single-quoted strings, unquoted keys, Python
+// literals (True, False, None), raw newlines in strings and missing closing
+// brackets of truncated output. Valid JSON is returned unchanged, and text
+// that cannot be repaired is returned as is, so unmarshaling reports the error.
+func RepairJSON(text string) string {
+	s := strings.TrimSpace(text)
+	if json.Valid([]byte(s)) {
+		return s
+	}
+
+	s = stripCodeFence(s)
+	if json.Valid([]byte(s)) {
+		return s
+	}
+
+	value := extractJSONValue(s)
+	if value == "" {
+		return s
+	}
+	if json.Valid([]byte(value)) {
+		return value
+	}
+
+	repaired := normalizeJSON(value)
+	if json.Valid([]byte(repaired)) {
+		return repaired
+	}
+	return value
+}
+
+// stripCodeFence returns the content of the first ``` fenced block, if any
+func stripCodeFence(s string) string {
+	start := strings.Index(s, "```")
+	if start == -1 {
+		return s
+	}
+	body := s[start+3:]
+	// Skip the info string, e.g. "json"
+	if nl := strings.IndexByte(body, '\n'); nl != -1 && !strings.ContainsAny(body[:nl], "{[") {
+		body = body[nl+1:]
+	}
+	if end := strings.Index(body, "```"); end != -1 {
+		body = body[:end]
+	}
+	return strings.TrimSpace(body)
+}
+
+// extractJSONValue returns the first object or array in s, up to its balanced
+// end or the end of s when it is truncated, or "" when there is none
+func extractJSONValue(s string) string {
+	start := strings.IndexAny(s, "{[")
+	if start == -1 {
+		return ""
+	}
+
+	depth := 0
+	var quote byte
+	escaped := false
+	for i := start; i < len(s); i++ {
+		c := s[i]
+		if quote != 0 {
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == quote:
+				quote = 0
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			quote = c
+		case '\'':
+			// Only a string delimiter where a value or key can start, not in "it's"
+			if i > start && isJSONWordChar(rune(s[i-1])) {
+				continue
+			}
+			quote = c
+		case '{', '[':
+			depth++
+		case '}', ']':
+			depth--
+			if depth == 0 {
+				return s[start : i+1]
+			}
+		}
+	}
+	return strings.TrimSpace(s[start:])
+}
+
+// normalizeJSON rewrites a JSON-like value into valid JSON in a single pass
+func normalizeJSON(s string) string {
+	var out strings.Builder
+	var stack []rune
+	runes := []rune(s)
+
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '"' || c == '\'':
+			i = writeJSONString(&out, runes, i)
+
+		case c == '/' && i+1 < len(runes) && (runes[i+1] == '/' || runes[i+1] == '*'):
+			i = skipJSONComment(runes, i)
+
+		case c == ',':
+			// Drop trailing commas before a closing bracket or the end
+			next := skipJSONSpace(runes, i+1)
+			if next < len(runes) && runes[next] != '}' && runes[next] != ']' {
+				out.WriteRune(c)
+			}
+
+		case c == '{' || c == '[':
+			stack = append(stack, c)
+			out.WriteRune(c)
+
+		case c == '}' || c == ']':
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+			out.WriteRune(c)
+
+		case c == '-' || unicode.IsDigit(c):
+			j := i
+			for j < len(runes) && strings.ContainsRune("+-.eE0123456789", runes[j]) {
+				j++
+			}
+			out.WriteString(string(runes[i:j]))
+			i = j - 1
+
+		case unicode.IsLetter(c) || c == '_' || c == '$':
+			j := i
+			for j < len(runes) && isJSONWordChar(runes[j]) {
+				j++
+			}
+			word := string(runes[i:j])
+			next := skipJSONSpace(runes, j)
+			switch {
+			case next < len(runes) && runes[next] == ':':
+				// Unquoted key
+				out.WriteString(quoteJSON(word))
+			case word == "true" || word == "True":
+				out.WriteString("true")
+			case word == "false" || word == "False":
+				out.WriteString("false")
+			case word == "null" || word == "None" || word == "NaN" || word == "undefined":
+				out.WriteString("null")
+			default:
+				out.WriteString(quoteJSON(word))
+			}
+			i = j - 1
+
+		default:
+			out.WriteRune(c)
+		}
+	}
+
+	// Close what a truncated response left open
+	repaired := strings.TrimRight(out.String(), " \t\r\n")
+	repaired = strings.TrimSuffix(repaired, ",")
+	for i := len(stack) - 1; i >= 0; i-- {
+		if stack[i] == '{' {
+			repaired += "}"
+		} else {
+			repaired += "]"
+		}
+	}
+	return repaired
+}
+
+// writeJSONString writes the string starting at runes[start] as a double-quoted
+// JSON string and returns the index of its closing quote
+func writeJSONString(out *strings.Builder, runes []rune, start int) int {
+	quote := runes[start]
+	out.WriteByte('"')
+	for i := start + 1; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case c == '\\' && i+1 < len(runes):
+			i++
+			if runes[i] == '\'' {
+				out.WriteRune('\'')
+			} else {
+				out.WriteRune(c)
+				out.WriteRune(runes[i])
+			}
+		case c == quote:
+			out.WriteByte('"')
+			return i
+		case c == '"':
+			out.WriteString(`\"`)
+		case c == '\n':
+			out.WriteString(`\n`)
+		case c == '\r':
+			out.WriteString(`\r`)
+		case c == '\t':
+			out.WriteString(`\t`)
+		default:
+			out.WriteRune(c)
+		}
+	}
+	// Unterminated string in truncated output
+	out.WriteByte('"')
+	return len(runes)
+}
+
+// skipJSONComment returns the index of the last rune of the comment at runes[start]
+func skipJSONComment(runes []rune, start int) int {
+	if runes[start+1] == '/' {
+		for i := start + 2; i < len(runes); i++ {
+			if runes[i] == '\n' {
+				return i - 1
+			}
+		}
+		return len(runes) - 1
+	}
+	for i := start + 2; i+1 < len(runes); i++ {
+		if runes[i] == '*' && runes[i+1] == '/' {
+			return i + 1
+		}
+	}
+	return len(runes) - 1
+}
+
+// skipJSONSpace returns the index of the next rune that is not whitespace or a comment
+func skipJSONSpace(runes []rune, i int) int {
+	for i < len(runes) {
+		switch {
+		case unicode.IsSpace(runes[i]):
+			i++
+		case runes[i] == '/' && i+1 < len(runes) && (runes[i+1] == '/' || runes[i+1] == '*'):
+			i = skipJSONComment(runes, i) + 1
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// isJSONWordChar reports whether c can be part of an unquoted key or literal
+func isJSONWordChar(c rune) bool {
+	return unicode.IsLetter(c) || unicode.IsDigit(c) || c == '_' || c == '$' || c == '-'
+}
+
+// quoteJSON returns s as a JSON string literal
+func quoteJSON(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
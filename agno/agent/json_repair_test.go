@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRepairJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"valid", `{"a": 1}`, `{"a": 1}`},
+		{"code fence", "```json\n{\"a\": 1}\n```", `{"a": 1}`},
+		{"prose around", "Sure! Here it is:\n{\"a\": 1}\nLet me know if you need more.", `{"a": 1}`},
+		{"trailing commas", `{"a": [1, 2,], "b": 3,}`, `{"a": [1, 2], "b": 3}`},
+		{"single quotes", `{'name': 'Bob "B" Smith', 'ok': True}`, `{"name": "Bob \"B\" Smith", "ok": true}`},
+		{"unquoted keys and None", `{name: "x", age: None}`, `{"name": "x", "age": null}`},
+		{"comments", "{\n  \"a\": 1, // first\n  /* second */ \"b\": 2\n}", "{\n  \"a\": 1, \n   \"b\": 2\n}"},
+		{"newline in string", "{\"a\": \"line1\nline2\"}", `{"a": "line1\nline2"}`},
+		{"truncated", `{"items": [{"a": 1}, {"a": 2`, `{"items": [{"a": 1}, {"a": 2}]}`},
+		{"array", "```\n[1, 2, 3,]\n```", `[1, 2, 3]`},
+		{"apostrophe in prose", "Here's the JSON: {\"a\": \"it's\"}", `{"a": "it's"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := RepairJSON(tt.input)
+			if got != tt.want {
+				t.Errorf("RepairJSON(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+			if !json.Valid([]byte(got)) {
+				t.Errorf("RepairJSON(%q) is not valid JSON: %q", tt.input, got)
+			}
+		})
+	}
+
+	// Text without JSON is returned for unmarshaling to report
+	if got := RepairJSON("no json here"); got != "no json here" {
+		t.Errorf("Expected text without JSON unchanged, got %q", got)
+	}
+}
+
+func TestWithJSONRepair(t *testing.T) {
+	type movie struct {
+		Name       string   `json:"name"`
+		Characters []string `json:"characters"`
+	}
+
+	content := "Here is your movie:\n```json\n{'name': 'Arrival', 'characters': ['Louise', 'Ian',],}\n```"
+	ag, err := NewAgentWithOptions(AgentConfig{
+		Model:        &stubModel{content: content},
+		OutputSchema: &movie{},
+	}, WithJSONRepair(true))
+	if err != nil {
+		t.Fatalf("NewAgentWithOptions failed: %v", err)
+	}
+
+	resp, err := ag.Run("Create a movie")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	got, ok := resp.Output.(*movie)
+	if !ok {
+		t.Fatalf("Expected *movie output, got %T", resp.Output)
+	}
+	if got.Name != "Arrival" || len(got.Characters) != 2 {
+		t.Errorf("Unexpected output: %+v", got)
+	}
+}
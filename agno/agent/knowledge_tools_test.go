@@ -0,0 +1,141 @@
+package agent
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+	"github.com/devalexandre/agno-golang/agno/embedder"
+	"github.com/devalexandre/agno-golang/agno/knowledge"
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+)
+
+// listVectorDB keeps documents in memory. Its search only finds documents
+// without a content hash, so tools must look the others up by metadata.
+type listVectorDB struct {
+	vectordb.VectorDB
+	docs map[string]*document.Document
+}
+
+func (l *listVectorDB) Create(ctx context.Context) error { return nil }
+func (l *listVectorDB) GetEmbedder() embedder.Embedder   { return nil }
+func (l *listVectorDB) Insert(ctx context.Context, docs []*document.Document, filters map[string]interface{}) error {
+	return l.Upsert(ctx, docs, filters)
+}
+
+func (l *listVectorDB) Upsert(ctx context.Context, docs []*document.Document, filters map[string]interface{}) error {
+	for _, doc := range docs {
+		stored := *doc
+		l.docs[doc.ID] = &stored
+	}
+	return nil
+}
+
+func (l *listVectorDB) Search(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	var results []*vectordb.SearchResult
+	for _, doc := range l.docs {
+		if doc.Metadata[knowledge.ContentHashKey] == nil && len(results) < limit {
+			results = append(results, &vectordb.SearchResult{Document: doc})
+		}
+	}
+	return results, nil
+}
+
+func (l *listVectorDB) ListDocuments(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*document.Document, error) {
+	var docs []*document.Document
+	for _, doc := range l.docs {
+		matches := true
+		for key, value := range filters {
+			if fmt.Sprint(doc.Metadata[key]) != fmt.Sprint(value) {
+				matches = false
+			}
+		}
+		if matches {
+			docs = append(docs, doc)
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].ID < docs[j].ID })
+	if offset > len(docs) {
+		offset = len(docs)
+	}
+	docs = docs[offset:]
+	if len(docs) > limit {
+		docs = docs[:limit]
+	}
+	return docs, nil
+}
+
+func (l *listVectorDB) GetDocument(ctx context.Context, id string) (*document.Document, error) {
+	return l.docs[id], nil
+}
+
+func (l *listVectorDB) DeleteByIDs(ctx context.Context, ids []string) (int, error) {
+	deleted := 0
+	for _, id := range ids {
+		if _, ok := l.docs[id]; ok {
+			delete(l.docs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestUpdateKnowledgeToolDeletesByContent(t *testing.T) {
+	db := &listVectorDB{docs: make(map[string]*document.Document)}
+	ag, err := NewAgent(AgentConfig{
+		Model:     &stubModel{content: "ok"},
+		Knowledge: &knowledge.BaseKnowledge{VectorDB: db},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+	ukt := &UpdateKnowledgeToolkit{agent: ag}
+
+	// More entries than a page of lookups, all with the same content
+	var docs []document.Document
+	for i := 0; i < 150; i++ {
+		docs = append(docs, document.Document{ID: fmt.Sprintf("old-%03d", i), Content: "The office is in Lisbon"})
+	}
+	docs = append(docs, document.Document{ID: "new", Content: "The office is in Porto"})
+	if err := ag.knowledge.(*knowledge.BaseKnowledge).LoadDocuments(context.Background(), docs, false); err != nil {
+		t.Fatalf("LoadDocuments failed: %v", err)
+	}
+
+	result, err := ukt.DeleteKnowledge(DeleteKnowledgeParams{Content: "  The office is in Lisbon\n"})
+	if err != nil {
+		t.Fatalf("DeleteKnowledge failed: %v", err)
+	}
+	if result != "Deleted 150 knowledge entries" {
+		t.Errorf("Unexpected result %q", result)
+	}
+	if len(db.docs) != 1 || db.docs["new"] == nil {
+		t.Errorf("Expected only the other entry to remain, got %d entries", len(db.docs))
+	}
+
+	result, err = ukt.DeleteKnowledge(DeleteKnowledgeParams{Content: "The office is in Lisbon"})
+	if err != nil || !strings.HasPrefix(result, "No knowledge entries matched the given content (0 entries deleted)") {
+		t.Errorf("Unexpected result %q, %v", result, err)
+	}
+
+	// Corrected entries can be found by their new content
+	if _, err := ukt.UpdateKnowledgeEntry(UpdateKnowledgeEntryParams{ID: "new", Content: "The office is in Faro"}); err != nil {
+		t.Fatalf("UpdateKnowledgeEntry failed: %v", err)
+	}
+	result, err = ukt.DeleteKnowledge(DeleteKnowledgeParams{Content: "The office is in Faro"})
+	if err != nil || result != "Deleted 1 knowledge entries" {
+		t.Errorf("Unexpected result %q, %v", result, err)
+	}
+
+	// Entries stored before content hashes were recorded are matched on content
+	db.docs["legacy"] = &document.Document{ID: "legacy", Content: "The office is in Braga"}
+	result, err = ukt.DeleteKnowledge(DeleteKnowledgeParams{Content: "The office is in Braga "})
+	if err != nil || result != "Deleted 1 knowledge entries" {
+		t.Errorf("Unexpected result %q, %v", result, err)
+	}
+	if db.docs["legacy"] != nil {
+		t.Error("Expected the legacy entry to be deleted")
+	}
+}
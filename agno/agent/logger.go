@@ -0,0 +1,161 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// LogLevel is the severity of a log event
+type LogLevel string
+
+const (
+	LogLevelDebug LogLevel = "debug"
+	LogLevelInfo  LogLevel = "info"
+	LogLevelWarn  LogLevel = "warn"
+	LogLevelError LogLevel = "error"
+)
+
+// Logger receives the structured debug and trace output of an agent: model
+// calls, tool calls, guardrail decisions, retries and warnings. Each event has
+// a name, such as "model_call", and fields such as agent_name, tool and
+// duration_ms. Debug events are only sent when AgentConfig.Debug is set.
+type Logger interface {
+	Log(level LogLevel, event string, fields map[string]interface{})
+}
+
+// NopLogger discards every event. It is the default logger; an agent without
+// AgentConfig.Logger prints its debug output as text instead.
+type NopLogger struct{}
+
+func (NopLogger) Log(level LogLevel, event string, fields map[string]interface{}) {}
+
+// JSONLogger writes each event as a JSON object on its own line, e.g.
+//
+//	{"time":"...","level":"info","event":"tool_call","agent_name":"researcher","duration_ms":12,"tool":"web.Search"}
+//
+// It is safe for concurrent use.
+type JSONLogger struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewJSONLogger creates a logger writing JSON lines to w, for log aggregators
+func NewJSONLogger(w io.Writer) *JSONLogger {
+	return &JSONLogger{w: w}
+}
+
+// Log writes the event with its time, level and fields. Fields that cannot be
+// encoded as JSON, like errors, are written as text.
+func (l *JSONLogger) Log(level LogLevel, event string, fields map[string]interface{}) {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		if key != "time" && key != "level" && key != "event" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	// time, level and event come first, then the fields by name
+	line := make([]byte, 0, 128)
+	line = appendJSONField(line, "time", time.Now().UTC().Format(time.RFC3339Nano))
+	line = appendJSONField(line, "level", string(level))
+	line = appendJSONField(line, "event", event)
+	for _, key := range keys {
+		line = appendJSONField(line, key, fields[key])
+	}
+	line[0] = '{'
+	line = append(line, '}', '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.w.Write(line)
+}
+
+// appendJSONField appends ,"key":value to line
+func appendJSONField(line []byte, key string, value interface{}) []byte {
+	if err, ok := value.(error); ok {
+		value = err.Error()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		data, _ = json.Marshal(fmt.Sprint(value))
+	}
+	name, _ := json.Marshal(key)
+	line = append(line, ',')
+	line = append(line, name...)
+	line = append(line, ':')
+	return append(line, data...)
+}
+
+// logEvent sends an event to the agent's logger, tagged with the agent name.
+// Debug events are dropped unless the agent is in debug mode.
+func (a *Agent) logEvent(level LogLevel, event string, fields map[string]interface{}) {
+	if a.logger == nil || (level == LogLevelDebug && !a.debug) {
+		return
+	}
+	if fields == nil {
+		fields = make(map[string]interface{}, 1)
+	}
+	fields["agent_name"] = a.name
+	a.logger.Log(level, event, fields)
+}
+
+// debugText reports whether debug output is printed as text, i.e. the agent
+// is in debug mode and has no Logger to send it to
+func (a *Agent) debugText() bool {
+	return a.debug && a.logger == nil
+}
+
+// logWarning reports a non-fatal failure: a warn event when the agent has a
+// Logger, otherwise a text warning in debug mode
+func (a *Agent) logWarning(event, message string, err error) {
+	if a.logger != nil {
+		a.logEvent(LogLevelWarn, event, map[string]interface{}{"error": err})
+		return
+	}
+	if a.debug {
+		fmt.Printf("Warning: %s: %v\n", message, err)
+	}
+}
+
+// logModelCall reports a finished call of model
+func (a *Agent) logModelCall(model models.AgnoModelInterface, start time.Time, attempt, messages int, err error) {
+	if a.logger == nil {
+		return
+	}
+	fields := map[string]interface{}{
+		"duration_ms": time.Since(start).Milliseconds(),
+		"messages":    messages,
+		"attempt":     attempt,
+	}
+	if model != nil {
+		fields["model"] = model.GetID()
+	}
+	level := LogLevelInfo
+	if err != nil {
+		level = LogLevelError
+		fields["error"] = err
+	}
+	a.logEvent(level, "model_call", fields)
+}
+
+// logRetry reports that a failed model call is retried
+func (a *Agent) logRetry(attempt, retries int, lastErr error) {
+	if a.logger != nil {
+		a.logEvent(LogLevelWarn, "retry", map[string]interface{}{
+			"attempt":     attempt,
+			"max_retries": retries,
+			"error":       lastErr,
+		})
+		return
+	}
+	if a.debug {
+		fmt.Printf("Retry attempt %d/%d\n", attempt, retries)
+	}
+}
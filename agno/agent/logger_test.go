@@ -0,0 +1,133 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/tools"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// logEvents decodes the JSON lines written by a JSONLogger
+func logEvents(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var events []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var event map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("Invalid JSON line %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// findEvent returns the first event with the given name, or nil
+func findEvent(events []map[string]interface{}, name string) map[string]interface{} {
+	for _, event := range events {
+		if event["event"] == name {
+			return event
+		}
+	}
+	return nil
+}
+
+func TestJSONLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewJSONLogger(&buf)
+	logger.Log(LogLevelWarn, "tool_call", map[string]interface{}{
+		"tool":        "web.Search",
+		"duration_ms": int64(12),
+		"error":       errors.New("timeout"),
+	})
+
+	line := buf.String()
+	if !strings.HasPrefix(line, `{"time":`) || !strings.HasSuffix(line, "}\n") {
+		t.Fatalf("Expected one JSON line starting with time, got %q", line)
+	}
+	if !strings.Contains(line, `"level":"warn","event":"tool_call","duration_ms":12,"error":"timeout","tool":"web.Search"}`) {
+		t.Errorf("Unexpected field order or encoding: %q", line)
+	}
+}
+
+func TestAgentLogger(t *testing.T) {
+	t.Run("model and tool calls", func(t *testing.T) {
+		var buf bytes.Buffer
+		lookup := tools.NewToolFromFunction(func(ctx context.Context, id string) (string, error) {
+			return "order " + id, nil
+		}, "Look up an order")
+		ag, err := NewAgent(AgentConfig{
+			Name:   "support",
+			Model:  &toolCallingModel{args: `{"arg0":"A-1"}`},
+			Tools:  []toolkit.Tool{lookup},
+			Logger: NewJSONLogger(&buf),
+		})
+		if err != nil {
+			t.Fatalf("NewAgent failed: %v", err)
+		}
+		if _, err := ag.Run("Where is my order?"); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+
+		events := logEvents(t, &buf)
+		toolCall := findEvent(events, "tool_call")
+		if toolCall == nil || toolCall["agent_name"] != "support" || !strings.HasSuffix(toolCall["tool"].(string), ".lookUpAnOrder") {
+			t.Fatalf("Expected a tool_call event for lookUpAnOrder, got %v", events)
+		}
+		if _, ok := toolCall["duration_ms"]; !ok {
+			t.Errorf("Expected duration_ms on tool_call, got %v", toolCall)
+		}
+		modelCall := findEvent(events, "model_call")
+		if modelCall == nil || modelCall["model"] != "stub" || modelCall["level"] != "info" {
+			t.Errorf("Expected an info model_call event for stub, got %v", events)
+		}
+		if findEvent(events, "output_parsing") != nil {
+			t.Error("Expected debug events to be dropped outside debug mode")
+		}
+	})
+
+	t.Run("guardrail", func(t *testing.T) {
+		var buf bytes.Buffer
+		ag, _ := NewAgent(AgentConfig{
+			Name:  "support",
+			Model: &stubModel{content: "ok"},
+			InputGuardrails: []Guardrail{&GuardrailFunc{
+				Name:      "NoSecrets",
+				CheckFunc: func(ctx context.Context, data interface{}) error { return errors.New("secret detected") },
+			}},
+			Logger: NewJSONLogger(&buf),
+		})
+		ag.Run("my password is hunter2")
+
+		event := findEvent(logEvents(t, &buf), "guardrail")
+		if event == nil || event["decision"] != "blocked" || event["guardrail"] != "NoSecrets" || event["stage"] != "input" {
+			t.Fatalf("Expected a blocked input guardrail event, got %v", event)
+		}
+	})
+
+	t.Run("retry", func(t *testing.T) {
+		var buf bytes.Buffer
+		ag, _ := NewAgent(AgentConfig{
+			Name:   "support",
+			Model:  &failingModel{},
+			Logger: NewJSONLogger(&buf),
+		})
+		ag.Run("hi", WithRetries(1))
+
+		events := logEvents(t, &buf)
+		retry := findEvent(events, "retry")
+		if retry == nil || retry["attempt"] != float64(1) || retry["error"] != "provider unavailable" {
+			t.Fatalf("Expected a retry event, got %v", events)
+		}
+		if modelCall := findEvent(events, "model_call"); modelCall == nil || modelCall["level"] != "error" {
+			t.Errorf("Expected an error model_call event, got %v", events)
+		}
+	})
+}
@@ -0,0 +1,61 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+func TestMaxTokens(t *testing.T) {
+	sentMaxTokens := func(options []models.Option) *int {
+		callOpts := models.DefaultCallOptions()
+		for _, opt := range options {
+			opt(callOpts)
+		}
+		return callOpts.MaxTokens
+	}
+
+	model := &stubModel{content: "ok"}
+	ag, err := NewAgent(AgentConfig{Model: model})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+	if _, err := ag.Run("hi"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := sentMaxTokens(model.options); got != nil {
+		t.Errorf("Expected no max tokens by default, got %d", *got)
+	}
+
+	model = &stubModel{content: "ok"}
+	ag, err = NewAgent(AgentConfig{
+		Model:        model,
+		MaxTokens:    256,
+		ModelOptions: []models.Option{models.WithMaxTokens(1000)},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	if _, err := ag.Run("hi"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := sentMaxTokens(model.options); got == nil || *got != 256 {
+		t.Errorf("Expected the agent max tokens 256, got %v", got)
+	}
+
+	if _, err := ag.Run("hi", WithMaxTokens(32)); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := sentMaxTokens(model.options); got == nil || *got != 32 {
+		t.Errorf("Expected the run max tokens 32, got %v", got)
+	}
+
+	// The override only applies to the run it was given to
+	if err := ag.RunStream("hi", func([]byte) error { return nil }); err != nil {
+		t.Fatalf("RunStream failed: %v", err)
+	}
+	if got := sentMaxTokens(model.options); got == nil || *got != 256 {
+		t.Errorf("Expected the agent max tokens 256 after the run, got %v", got)
+	}
+}
@@ -0,0 +1,104 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/memory"
+)
+
+// searchMemory answers searches with fixed results and records which ran
+type searchMemory struct {
+	memory.MemoryManager
+	semanticErr error
+	searches    []string
+}
+
+func (m *searchMemory) SearchMemoriesSemantic(ctx context.Context, userID, query string, limit int) ([]*memory.UserMemory, error) {
+	m.searches = append(m.searches, "semantic")
+	if m.semanticErr != nil {
+		return nil, m.semanticErr
+	}
+	return []*memory.UserMemory{{ID: "m1", Memory: "Likes green tea"}}, nil
+}
+
+func (m *searchMemory) SearchMemoriesKeyword(ctx context.Context, userID, query string, limit int) ([]*memory.UserMemory, error) {
+	m.searches = append(m.searches, "keyword:"+query)
+	return []*memory.UserMemory{{ID: "m2", Memory: "Drinks tea every morning"}}, nil
+}
+
+// semanticMemory only supports semantic search
+type semanticMemory struct {
+	memory.MemoryManager
+	search *searchMemory
+}
+
+func (m semanticMemory) SearchMemoriesSemantic(ctx context.Context, userID, query string, limit int) ([]*memory.UserMemory, error) {
+	return m.search.SearchMemoriesSemantic(ctx, userID, query, limit)
+}
+
+// keywordMemory only supports keyword search
+type keywordMemory struct {
+	memory.MemoryManager
+	search *searchMemory
+}
+
+func (m keywordMemory) SearchMemoriesKeyword(ctx context.Context, userID, query string, limit int) ([]*memory.UserMemory, error) {
+	return m.search.SearchMemoriesKeyword(ctx, userID, query, limit)
+}
+
+func TestRecallMemory(t *testing.T) {
+	newTool := func(t *testing.T, mem memory.MemoryManager, logger Logger) *MemoryRecallToolkit {
+		ag, err := NewAgent(AgentConfig{Model: &stubModel{content: "ok"}, Memory: mem, UserID: "u1", Logger: logger})
+		if err != nil {
+			t.Fatalf("NewAgent failed: %v", err)
+		}
+		return &MemoryRecallToolkit{agent: ag}
+	}
+
+	t.Run("semantic search", func(t *testing.T) {
+		mem := &searchMemory{}
+		result, err := newTool(t, mem, nil).RecallMemory(RecallMemoryParams{Query: "tea"})
+		if err != nil || !strings.Contains(result, "[m1] Likes green tea") {
+			t.Errorf("Unexpected result %q, %v", result, err)
+		}
+		if strings.Join(mem.searches, ",") != "semantic" {
+			t.Errorf("Expected only a semantic search, got %v", mem.searches)
+		}
+	})
+
+	t.Run("keyword search when semantic search fails", func(t *testing.T) {
+		mem := &searchMemory{semanticErr: errors.New("embedder not configured for semantic search")}
+		var buf bytes.Buffer
+		result, err := newTool(t, mem, NewJSONLogger(&buf)).RecallMemory(RecallMemoryParams{Query: "tea"})
+		if err != nil || !strings.Contains(result, "[m2] Drinks tea every morning") {
+			t.Errorf("Unexpected result %q, %v", result, err)
+		}
+		if strings.Join(mem.searches, ",") != "semantic,keyword:tea" {
+			t.Errorf("Expected a keyword search after the semantic one, got %v", mem.searches)
+		}
+		event := findEvent(logEvents(t, &buf), "memory_semantic_search_failed")
+		if event == nil || !strings.Contains(event["error"].(string), "embedder not configured") {
+			t.Errorf("Expected the semantic search error to be logged, got %q", buf.String())
+		}
+	})
+
+	t.Run("keyword search only", func(t *testing.T) {
+		search := &searchMemory{}
+		result, err := newTool(t, keywordMemory{search: search}, nil).RecallMemory(RecallMemoryParams{Query: "tea"})
+		if err != nil || !strings.Contains(result, "[m2]") || strings.Join(search.searches, ",") != "keyword:tea" {
+			t.Errorf("Unexpected result %q, %v, searches %v", result, err, search.searches)
+		}
+	})
+
+	t.Run("semantic search error without keyword search", func(t *testing.T) {
+		mem := semanticMemory{search: &searchMemory{semanticErr: errors.New("embedding service down")}}
+		if _, err := newTool(t, mem, nil).RecallMemory(RecallMemoryParams{Query: "tea"}); err == nil ||
+			!strings.Contains(err.Error(), "embedding service down") {
+			t.Errorf("Expected the semantic search error, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func TestWithModelOverride(t *testing.T) {
+	cheap := &stubModel{content: "cheap answer"}
+	big := &stubModel{content: "big answer"}
+
+	ag, err := NewAgent(AgentConfig{
+		Model: cheap,
+		Tools: []toolkit.Tool{createMockTool()},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	resp, err := ag.Run("hard question", WithModelOverride(big))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if resp.TextContent != "big answer" || big.calls != 1 || cheap.calls != 0 {
+		t.Fatalf("Expected the override model to answer, got %q (big=%d cheap=%d)", resp.TextContent, big.calls, cheap.calls)
+	}
+
+	callOpts := models.DefaultCallOptions()
+	for _, opt := range big.options {
+		opt(callOpts)
+	}
+	if len(callOpts.Tools) == 0 {
+		t.Error("Expected tools to be sent to the override model")
+	}
+
+	// The override only applies to the run it was given to
+	resp, err = ag.Run("easy question")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if resp.TextContent != "cheap answer" || cheap.calls != 1 {
+		t.Errorf("Expected the default model after the override run, got %q", resp.TextContent)
+	}
+	if ag.GetModel() != cheap {
+		t.Error("GetModel should still return the agent model")
+	}
+}
+
+// gateModel answers once every run sharing the gate has called it, so the
+// runs overlap
+type gateModel struct {
+	stubModel
+	gate *sync.WaitGroup
+}
+
+func (m *gateModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	m.gate.Done()
+	m.gate.Wait()
+	return m.stubModel.Invoke(ctx, messages, options...)
+}
+
+func TestOverlappingRunsKeepTheirOptions(t *testing.T) {
+	ag, err := NewAgent(AgentConfig{Model: &stubModel{content: "default"}})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	var gate sync.WaitGroup
+	gate.Add(2)
+	runModels := []*gateModel{
+		{stubModel: stubModel{content: "first"}, gate: &gate},
+		{stubModel: stubModel{content: "second"}, gate: &gate},
+	}
+
+	answers := make([]string, len(runModels))
+	var wg sync.WaitGroup
+	for i, model := range runModels {
+		wg.Add(1)
+		go func(i int, model *gateModel) {
+			defer wg.Done()
+			resp, err := ag.Run("hi", WithModelOverride(model), WithMaxTokens(100*(i+1)))
+			if err != nil {
+				t.Errorf("Run %d failed: %v", i, err)
+			}
+			answers[i] = resp.TextContent
+		}(i, model)
+	}
+	wg.Wait()
+
+	for i, model := range runModels {
+		if answers[i] != model.content {
+			t.Errorf("Run %d: expected its override model to answer %q, got %q", i, model.content, answers[i])
+		}
+		callOpts := models.DefaultCallOptions()
+		for _, opt := range model.options {
+			opt(callOpts)
+		}
+		if callOpts.MaxTokens == nil || *callOpts.MaxTokens != 100*(i+1) {
+			t.Errorf("Run %d: expected max tokens %d, got %v", i, 100*(i+1), callOpts.MaxTokens)
+		}
+	}
+}
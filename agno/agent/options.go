@@ -1,7 +1,12 @@
 package agent
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
 )
 
 // RunOption is a function type for configuring agent runs
@@ -29,8 +34,11 @@ type RunOptions struct {
 	Videos []Video
 	// Files inputs
 	Files []File
-	// Retries number of retry attempts
+	// Retries number of retry attempts, for failed model calls and responses
+	// that do not parse into the output schema
 	Retries *int
+	// MaxTotalRetries caps the sum of model, tool and parse retries within the run
+	MaxTotalRetries *int
 	// KnowledgeFilters for filtering knowledge base queries
 	KnowledgeFilters map[string]interface{}
 	// AddHistoryToContext includes conversation history in context
@@ -47,6 +55,43 @@ type RunOptions struct {
 	DebugMode *bool
 	// SmartMemoryManager configuration for this run
 	SmartMemoryManager *SmartMemoryManagerOptions
+	// OutputTransform overrides AgentConfig.OutputTransform for this run
+	OutputTransform func(string) (string, error) `json:"-"`
+	// ModelOverride replaces the agent model for this run
+	ModelOverride models.AgnoModelInterface `json:"-"`
+	// Timeout overrides AgentConfig.Timeout for this run
+	Timeout time.Duration
+	// MaxTokens overrides AgentConfig.MaxTokens for this run (0 keeps the agent default)
+	MaxTokens int
+	// Temperature sets the sampling temperature for this run (nil keeps the model default)
+	Temperature *float64
+	// TopP sets the nucleus sampling probability for this run (nil keeps the model default)
+	TopP *float64
+	// ToolChoice overrides AgentConfig.ToolChoice for this run
+	ToolChoice ToolChoice
+
+	// runContext is the parent run's context when running as an AgentTool. The
+	// run inherits its cancellation, metadata and retry budget.
+	runContext context.Context
+}
+
+// applyRunOptions builds RunOptions from the variadic options accepted by Run.
+// Both RunOption values and plain func(*RunOptions) closures are accepted.
+func applyRunOptions(opts []interface{}) *RunOptions {
+	options := &RunOptions{}
+	for _, opt := range opts {
+		switch runOpt := opt.(type) {
+		case RunOption:
+			if runOpt != nil {
+				runOpt(options)
+			}
+		case func(*RunOptions):
+			if runOpt != nil {
+				runOpt(options)
+			}
+		}
+	}
+	return options
 }
 
 // WithStream enables streaming response
@@ -112,13 +157,24 @@ func WithFiles(files ...File) RunOption {
 	}
 }
 
-// WithRetries sets number of retry attempts
+// WithRetries sets number of retry attempts, for failed model calls and
+// responses that do not parse into the output schema
 func WithRetries(retries int) RunOption {
 	return func(o *RunOptions) {
 		o.Retries = &retries
 	}
 }
 
+// WithMaxTotalRetries caps the sum of all retry attempts (model calls, tools and
+// output parsing) within the run. Calling a tool method again after it failed
+// in the same run counts as a tool retry. When exhausted the run fails with a
+// RetryBudgetExhaustedError.
+func WithMaxTotalRetries(n int) RunOption {
+	return func(o *RunOptions) {
+		o.MaxTotalRetries = &n
+	}
+}
+
 // WithKnowledgeFilters sets knowledge filters for this run
 func WithKnowledgeFilters(knowledgeFilters map[string]interface{}) RunOption {
 	return func(o *RunOptions) {
@@ -175,6 +231,84 @@ func WithSmartMemoryManager(opts SmartMemoryManagerOptions) RunOption {
 	}
 }
 
+// WithOutputTransform sets a transform applied to the final response text for this run
+func WithOutputTransform(transform func(string) (string, error)) RunOption {
+	return func(o *RunOptions) {
+		o.OutputTransform = transform
+	}
+}
+
+// WithModelOverride runs this request with model instead of the agent's default model,
+// e.g. to route complex questions to a bigger model. Tools are sent to the override
+// model on every call, so their schemas are serialized in its own format.
+func WithModelOverride(model models.AgnoModelInterface) RunOption {
+	return func(o *RunOptions) {
+		o.ModelOverride = model
+	}
+}
+
+// WithTimeout bounds this run, all model calls and tool loops included, by d.
+// It overrides AgentConfig.Timeout; the run fails with an error matching
+// context.DeadlineExceeded when the deadline passes.
+func WithTimeout(d time.Duration) RunOption {
+	return func(o *RunOptions) {
+		o.Timeout = d
+	}
+}
+
+// WithMaxTokens caps the length of the model responses in this run. It
+// overrides AgentConfig.MaxTokens; zero keeps the agent default.
+func WithMaxTokens(n int) RunOption {
+	return func(o *RunOptions) {
+		o.MaxTokens = n
+	}
+}
+
+// WithTemperature sets the sampling temperature of the model calls in this
+// run, e.g. 0 for deterministic answers or 1.2 for creative ones. Run fails
+// before calling the model when it is outside [0, 2].
+func WithTemperature(temperature float64) RunOption {
+	return func(o *RunOptions) {
+		o.Temperature = &temperature
+	}
+}
+
+// WithTopP sets the nucleus sampling probability of the model calls in this
+// run. Run fails before calling the model when it is outside [0, 1].
+func WithTopP(topP float64) RunOption {
+	return func(o *RunOptions) {
+		o.TopP = &topP
+	}
+}
+
+// WithToolChoice controls whether the model may, must or must not call tools
+// in this run. It overrides AgentConfig.ToolChoice.
+func WithToolChoice(choice ToolChoice) RunOption {
+	return func(o *RunOptions) {
+		o.ToolChoice = choice
+	}
+}
+
+// WithForcedTool makes the model call the tool method name in this run, e.g.
+// "Calculator_add". Run fails before calling the model when the agent has no
+// such method.
+func WithForcedTool(name string) RunOption {
+	return func(o *RunOptions) {
+		o.ToolChoice = ToolChoice(name)
+	}
+}
+
+// validate checks the sampling options before any model call is made
+func (o *RunOptions) validate() error {
+	if o.Temperature != nil && (*o.Temperature < 0 || *o.Temperature > 2) {
+		return fmt.Errorf("temperature must be between 0 and 2, got %v", *o.Temperature)
+	}
+	if o.TopP != nil && (*o.TopP < 0 || *o.TopP > 1) {
+		return fmt.Errorf("top_p must be between 0 and 1, got %v", *o.TopP)
+	}
+	return nil
+}
+
 // Media types for agent inputs
 
 // Audio represents an audio input
@@ -234,3 +368,10 @@ func (o *RunOptions) MarshalJSON() ([]byte, error) {
 		Alias: (*Alias)(o),
 	})
 }
+
+// withRunContext runs within ctx, the context of a parent run
+func withRunContext(ctx context.Context) RunOption {
+	return func(o *RunOptions) {
+		o.runContext = ctx
+	}
+}
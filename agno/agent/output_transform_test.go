@@ -0,0 +1,123 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// stubModel returns a fixed response without calling any provider
+type stubModel struct {
+	content string
+	calls   int
+	options []models.Option
+}
+
+func (m *stubModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	m.calls++
+	m.options = options
+	return &models.MessageResponse{Role: models.TypeAssistantRole, Content: m.content, Model: "stub"}, nil
+}
+
+func (m *stubModel) AInvoke(ctx context.Context, messages []models.Message, options ...models.Option) (<-chan *models.MessageResponse, <-chan error) {
+	respCh := make(chan *models.MessageResponse, 1)
+	errCh := make(chan error, 1)
+	resp, err := m.Invoke(ctx, messages, options...)
+	if err != nil {
+		errCh <- err
+	} else {
+		respCh <- resp
+	}
+	close(respCh)
+	close(errCh)
+	return respCh, errCh
+}
+
+func (m *stubModel) InvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) error {
+	m.calls++
+	m.options = options
+	callOpts := models.DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOpts)
+	}
+	if callOpts.StreamingFunc != nil {
+		return callOpts.StreamingFunc(ctx, []byte(m.content))
+	}
+	return nil
+}
+
+func (m *stubModel) AInvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) (<-chan *models.MessageResponse, <-chan error) {
+	return m.AInvoke(ctx, messages, options...)
+}
+
+func (m *stubModel) GetID() string {
+	return "stub"
+}
+
+func TestOutputTransform(t *testing.T) {
+	ag, err := NewAgent(AgentConfig{
+		Model: &stubModel{content: "contact me at john@example.com"},
+		OutputTransform: func(s string) (string, error) {
+			return strings.ReplaceAll(s, "john@example.com", "[email]"), nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	resp, err := ag.Run("hi")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if resp.TextContent != "contact me at [email]" {
+		t.Errorf("Expected masked output, got %q", resp.TextContent)
+	}
+
+	// Run option overrides the agent-level transform
+	resp, err = ag.Run("hi", WithOutputTransform(func(s string) (string, error) {
+		return strings.ToUpper(s), nil
+	}))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if resp.TextContent != "CONTACT ME AT JOHN@EXAMPLE.COM" {
+		t.Errorf("Expected run-level transform, got %q", resp.TextContent)
+	}
+
+	// RunWithOptions applies the transforms too
+	resp, err = ag.RunWithOptions("hi")
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if resp.TextContent != "contact me at [email]" {
+		t.Errorf("Expected masked output from RunWithOptions, got %q", resp.TextContent)
+	}
+	resp, err = ag.RunWithOptions("hi", WithOutputTransform(func(s string) (string, error) {
+		return strings.ToUpper(s), nil
+	}))
+	if err != nil {
+		t.Fatalf("RunWithOptions failed: %v", err)
+	}
+	if resp.TextContent != "CONTACT ME AT JOHN@EXAMPLE.COM" {
+		t.Errorf("Expected run-level transform from RunWithOptions, got %q", resp.TextContent)
+	}
+}
+
+func TestOutputTransformErrorAbortsRun(t *testing.T) {
+	ag, err := NewAgent(AgentConfig{
+		Model: &stubModel{content: "answer"},
+		OutputTransform: func(s string) (string, error) {
+			return "", errors.New("boom")
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	if _, err := ag.Run("hi"); err == nil {
+		t.Error("Expected error from output transform")
+	}
+}
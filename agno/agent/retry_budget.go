@@ -0,0 +1,79 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// RetryBudgetExhaustedError is returned when a run uses up the retry budget set
+// with WithMaxTotalRetries. It reports how many retries each layer consumed.
+type RetryBudgetExhaustedError struct {
+	MaxRetries int
+	Retries    map[string]int
+	LastErr    error
+}
+
+func (e *RetryBudgetExhaustedError) Error() string {
+	layers := make([]string, 0, len(e.Retries))
+	for layer := range e.Retries {
+		layers = append(layers, layer)
+	}
+	sort.Strings(layers)
+
+	parts := make([]string, 0, len(layers))
+	for _, layer := range layers {
+		parts = append(parts, fmt.Sprintf("%s: %d", layer, e.Retries[layer]))
+	}
+
+	msg := fmt.Sprintf("retry budget of %d exhausted (%s)", e.MaxRetries, strings.Join(parts, ", "))
+	if e.LastErr != nil {
+		msg += fmt.Sprintf(": %v", e.LastErr)
+	}
+	return msg
+}
+
+func (e *RetryBudgetExhaustedError) Unwrap() error {
+	return e.LastErr
+}
+
+// retryBudget caps the total number of retries across all retry layers of a run.
+// A nil budget is unlimited.
+type retryBudget struct {
+	mu      sync.Mutex
+	max     int
+	total   int
+	retries map[string]int
+}
+
+// newRetryBudget returns nil when max is not set, leaving retries unlimited
+func newRetryBudget(max *int) *retryBudget {
+	if max == nil || *max < 0 {
+		return nil
+	}
+	return &retryBudget{max: *max, retries: make(map[string]int)}
+}
+
+// consume takes one retry for layer. It returns a RetryBudgetExhaustedError,
+// wrapping lastErr, when no retries are left.
+func (b *retryBudget) consume(layer string, lastErr error) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.total >= b.max {
+		retries := make(map[string]int, len(b.retries))
+		for l, n := range b.retries {
+			retries[l] = n
+		}
+		return &RetryBudgetExhaustedError{MaxRetries: b.max, Retries: retries, LastErr: lastErr}
+	}
+
+	b.total++
+	b.retries[layer]++
+	return nil
+}
@@ -0,0 +1,185 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// failingModel always fails to invoke
+type failingModel struct {
+	stubModel
+}
+
+func (m *failingModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	m.calls++
+	return nil, errors.New("provider unavailable")
+}
+
+func TestRetryBudgetConsume(t *testing.T) {
+	max := 2
+	budget := newRetryBudget(&max)
+
+	if err := budget.consume("model", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := budget.consume("tool", nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	lastErr := errors.New("timeout")
+	err := budget.consume("tool", lastErr)
+	var exhausted *RetryBudgetExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("Expected RetryBudgetExhaustedError, got %v", err)
+	}
+	if exhausted.Retries["model"] != 1 || exhausted.Retries["tool"] != 1 {
+		t.Errorf("Unexpected per-layer retries: %v", exhausted.Retries)
+	}
+	if !errors.Is(err, lastErr) {
+		t.Error("Expected budget error to wrap the last error")
+	}
+
+	// A nil budget never runs out
+	var unlimited *retryBudget
+	if err := unlimited.consume("model", nil); err != nil {
+		t.Errorf("Unexpected error from nil budget: %v", err)
+	}
+}
+
+func TestRunFailsWhenRetryBudgetExhausted(t *testing.T) {
+	model := &failingModel{}
+	ag, err := NewAgent(AgentConfig{Model: model})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	_, err = ag.Run("hi", WithRetries(5), WithMaxTotalRetries(1))
+	var exhausted *RetryBudgetExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("Expected RetryBudgetExhaustedError, got %v", err)
+	}
+	if model.calls != 2 {
+		t.Errorf("Expected 2 model calls, got %d", model.calls)
+	}
+}
+
+// retryingToolModel calls the first tool again after each failure, up to
+// attempts times, like a model client handing the tool error back to the model
+type retryingToolModel struct {
+	stubModel
+	attempts int
+}
+
+func (m *retryingToolModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	callOpts := models.DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOpts)
+	}
+	tool := callOpts.ToolCall[0]
+	for name := range tool.GetMethods() {
+		for i := 0; i < m.attempts; i++ {
+			if _, err := tool.Execute(name, json.RawMessage(`{"arg0":"A-1"}`)); err == nil {
+				break
+			}
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+		}
+	}
+	return m.stubModel.Invoke(ctx, messages, options...)
+}
+
+func TestRunChargesToolRetriesToBudget(t *testing.T) {
+	calls := 0
+	lookup := tools.NewToolFromFunction(func(ctx context.Context, id string) (string, error) {
+		calls++
+		return "", errors.New("backend down")
+	}, "Look up an order")
+
+	ag, err := NewAgent(AgentConfig{
+		Model: &retryingToolModel{attempts: 10},
+		Tools: []toolkit.Tool{lookup},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	_, err = ag.Run("Where is my order?", WithMaxTotalRetries(2))
+	var exhausted *RetryBudgetExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("Expected RetryBudgetExhaustedError, got %v", err)
+	}
+	if exhausted.Retries["tool"] != 2 {
+		t.Errorf("Expected 2 tool retries, got %v", exhausted.Retries)
+	}
+	if calls != 3 {
+		t.Errorf("Expected the tool to stop after 3 calls, got %d", calls)
+	}
+
+	// Without a budget the model may retry the tool as often as it likes
+	calls = 0
+	if _, err := ag.Run("Where is my order?"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if calls != 10 {
+		t.Errorf("Expected 10 tool calls, got %d", calls)
+	}
+}
+
+// sequenceModel answers with each of its responses in turn, repeating the last
+type sequenceModel struct {
+	stubModel
+	responses []string
+}
+
+func (m *sequenceModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	m.content = m.responses[0]
+	if len(m.responses) > 1 {
+		m.responses = m.responses[1:]
+	}
+	return m.stubModel.Invoke(ctx, messages, options...)
+}
+
+func TestRunRetriesOutputParsing(t *testing.T) {
+	type answer struct{ Title string }
+
+	t.Run("parses a retried response", func(t *testing.T) {
+		model := &sequenceModel{responses: []string{"not json", `{"Title":"ok"}`}}
+		ag, _ := NewAgent(AgentConfig{Model: model, OutputSchema: &answer{}, ParseResponse: true})
+
+		resp, err := ag.Run("hi", WithRetries(1))
+		if err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		if out, ok := resp.Output.(*answer); !ok || out.Title != "ok" {
+			t.Errorf("Expected the retried response to be parsed, got %#v", resp.Output)
+		}
+		if model.calls != 2 {
+			t.Errorf("Expected 2 model calls, got %d", model.calls)
+		}
+	})
+
+	t.Run("charges the budget", func(t *testing.T) {
+		model := &stubModel{content: "not json"}
+		ag, _ := NewAgent(AgentConfig{Model: model, OutputSchema: &answer{}, ParseResponse: true})
+
+		_, err := ag.Run("hi", WithRetries(5), WithMaxTotalRetries(2))
+		var exhausted *RetryBudgetExhaustedError
+		if !errors.As(err, &exhausted) || exhausted.Retries["parse"] != 2 {
+			t.Fatalf("Expected the budget to be used up by parse retries, got %v", err)
+		}
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Errorf("Expected the budget error to wrap the ParseError, got %v", err)
+		}
+		if model.calls != 3 {
+			t.Errorf("Expected 3 model calls, got %d", model.calls)
+		}
+	})
+}
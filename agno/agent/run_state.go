@@ -0,0 +1,228 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// runState is the state of a single run: its context, which carries the
+// deadline, metadata and retry budget, the run options that override the
+// agent configuration, and the agent tools bound to the run. Runs can overlap
+// on the same Agent, so it is passed down the call chain instead of being
+// stored on the Agent.
+type runState struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	stop   func(error) error // ends the run timeout, nil without one
+
+	model       models.AgnoModelInterface // WithModelOverride, nil uses the agent model
+	maxTokens   int                       // WithMaxTokens, 0 uses AgentConfig.MaxTokens
+	temperature *float64                  // WithTemperature, nil uses the model default
+	topP        *float64                  // WithTopP, nil uses the model default
+	toolChoice  ToolChoice                // WithToolChoice, "" uses AgentConfig.ToolChoice
+	retryBudget *retryBudget              // WithMaxTotalRetries, nil is unlimited
+	tools       []toolkit.Tool            // the agent tools, bound to this run
+
+	mu          sync.Mutex
+	failedTools map[string]error // last error of each tool method that failed
+	abortErr    error            // why the run was aborted, e.g. an exhausted retry budget
+	usage       models.Usage     // tokens reported by the model requests of the run
+}
+
+// retryBudgetKey is the context key of the retry budget of a run
+type retryBudgetKey struct{}
+
+// retryBudgetFromContext returns the retry budget of the run ctx belongs to,
+// or nil
+func retryBudgetFromContext(ctx context.Context) *retryBudget {
+	if ctx == nil {
+		return nil
+	}
+	budget, _ := ctx.Value(retryBudgetKey{}).(*retryBudget)
+	return budget
+}
+
+// startRun starts a run in ctx, the agent context when nil, with options (nil
+// for none). The run is bounded by its timeout and shares the retry budget of
+// a parent run found in ctx. The caller must end it with finish.
+func (a *Agent) startRun(ctx context.Context, options *RunOptions) *runState {
+	if ctx == nil {
+		ctx = a.ctx
+	}
+	if options == nil {
+		options = &RunOptions{}
+	}
+
+	run := &runState{
+		model:       options.ModelOverride,
+		maxTokens:   options.MaxTokens,
+		temperature: options.Temperature,
+		topP:        options.TopP,
+		toolChoice:  options.ToolChoice,
+	}
+
+	ctx, run.stop = a.startRunTimeout(ctx, options.Timeout)
+	ctx, run.cancel = context.WithCancel(ctx)
+	if options.Metadata != nil {
+		ctx = ContextWithMetadata(ctx, options.Metadata)
+	}
+	// Count the tokens of every model request of the run. A parent run, e.g.
+	// of an agent using this one as a tool, counts them too.
+	parent := ctx
+	ctx = models.ContextWithUsageReporter(ctx, func(usage models.Usage) {
+		run.mu.Lock()
+		run.usage.InputTokens += usage.InputTokens
+		run.usage.OutputTokens += usage.OutputTokens
+		run.mu.Unlock()
+		models.ReportUsage(parent, usage)
+	})
+	run.retryBudget = retryBudgetFromContext(ctx)
+	if run.retryBudget == nil {
+		run.retryBudget = newRetryBudget(options.MaxTotalRetries)
+		if run.retryBudget != nil {
+			ctx = context.WithValue(ctx, retryBudgetKey{}, run.retryBudget)
+		}
+	}
+	run.ctx = ctx
+	run.tools = a.bindTools(run)
+	return run
+}
+
+// finish ends the run with its error. A run aborted by an exhausted retry
+// budget fails with the budget error, and a run cut short by its deadline with
+// a TimeoutError.
+func (r *runState) finish(err error) error {
+	r.mu.Lock()
+	if r.abortErr != nil {
+		err = r.abortErr
+	}
+	r.mu.Unlock()
+
+	if r.stop != nil {
+		err = r.stop(err)
+	}
+	r.cancel()
+	return err
+}
+
+// metrics returns the metrics of the run: the input_tokens and output_tokens
+// reported by the model so far
+func (r *runState) metrics() map[string]interface{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return map[string]interface{}{
+		"input_tokens":  r.usage.InputTokens,
+		"output_tokens": r.usage.OutputTokens,
+	}
+}
+
+// abort cancels the run, which then fails with err
+func (r *runState) abort(err error) {
+	r.mu.Lock()
+	if r.abortErr == nil {
+		r.abortErr = err
+	}
+	r.mu.Unlock()
+	r.cancel()
+}
+
+// beginToolCall charges a tool retry to the run's retry budget when the tool
+// method already failed in this run, and aborts the run when the budget is
+// exhausted
+func (r *runState) beginToolCall(tool string) error {
+	if r.retryBudget == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	lastErr, failed := r.failedTools[tool]
+	r.mu.Unlock()
+	if !failed {
+		return nil
+	}
+
+	if err := r.retryBudget.consume("tool", lastErr); err != nil {
+		r.abort(err)
+		return err
+	}
+	return nil
+}
+
+// endToolCall records the outcome of a tool call, so that calling a failed
+// tool method again counts as a retry
+func (r *runState) endToolCall(tool string, err error) {
+	var exhausted *RetryBudgetExhaustedError
+	if errors.As(err, &exhausted) {
+		// An agent used as a tool used up the budget it shares with this run
+		r.abort(err)
+		return
+	}
+	if r.retryBudget == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err == nil {
+		delete(r.failedTools, tool)
+		return
+	}
+	if r.failedTools == nil {
+		r.failedTools = make(map[string]error)
+	}
+	r.failedTools[tool] = err
+}
+
+// bindTools returns the agent tools bound to run, so tool calls see the run
+// context and count their retries against the run's retry budget
+func (a *Agent) bindTools(run *runState) []toolkit.Tool {
+	tools := make([]toolkit.Tool, len(a.tools))
+	for i, tool := range a.tools {
+		bound := ToolWrapper{Tool: tool, agent: a}
+		if tw, ok := tool.(*ToolWrapper); ok {
+			bound = *tw
+		}
+		bound.run = run
+		tools[i] = &bound
+	}
+	return tools
+}
+
+// activeModel returns the model of the run: the WithModelOverride model when
+// one was given, otherwise the agent's model
+func (a *Agent) activeModel(run *runState) models.AgnoModelInterface {
+	if run != nil && run.model != nil {
+		return run.model
+	}
+	return a.model
+}
+
+// modelCallOptions returns opts followed by the AgentConfig.ModelOptions and
+// the max tokens, sampling and tool choice of the run, so the run options win
+func (a *Agent) modelCallOptions(run *runState, opts ...models.Option) []models.Option {
+	opts = append(opts, a.modelOptions...)
+
+	maxTokens := a.maxTokens
+	if run != nil {
+		if run.temperature != nil {
+			opts = append(opts, models.WithTemperature(float32(*run.temperature)))
+		}
+		if run.topP != nil {
+			opts = append(opts, models.WithTopP(float32(*run.topP)))
+		}
+		if run.maxTokens > 0 {
+			maxTokens = run.maxTokens
+		}
+	}
+	if choice := a.activeToolChoice(run); choice != "" {
+		opts = append(opts, models.WithToolChoice(string(choice)))
+	}
+	if maxTokens > 0 {
+		opts = append(opts, models.WithMaxTokens(maxTokens))
+	}
+	return opts
+}
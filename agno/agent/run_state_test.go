@@ -0,0 +1,75 @@
+package agent
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// usageModel answers like toolCallingModel when it has args, otherwise like
+// stubModel, and reports usage for each request like a model client
+type usageModel struct {
+	toolCallingModel
+	usage models.Usage
+}
+
+func (m *usageModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	models.ReportUsage(ctx, m.usage)
+	if m.args != "" {
+		return m.toolCallingModel.Invoke(ctx, messages, options...)
+	}
+	return m.stubModel.Invoke(ctx, messages, options...)
+}
+
+func (m *usageModel) InvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) error {
+	models.ReportUsage(ctx, m.usage)
+	return m.stubModel.InvokeStream(ctx, messages, options...)
+}
+
+func TestRunReportsTokenUsage(t *testing.T) {
+	model := &usageModel{toolCallingModel: toolCallingModel{stubModel: stubModel{content: "ok"}}, usage: models.Usage{InputTokens: 12, OutputTokens: 5}}
+	ag, err := NewAgent(AgentConfig{Model: model})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	resp, err := ag.Run("hi")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if resp.Metrics["input_tokens"] != 12 || resp.Metrics["output_tokens"] != 5 {
+		t.Errorf("Expected the reported usage in the run metrics, got %v", resp.Metrics)
+	}
+
+	var completed RunEvent
+	err = ag.RunStreamEvents("hi", func(event RunEvent) error {
+		if event.Event == RunEventCompleted {
+			completed = event
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("RunStreamEvents failed: %v", err)
+	}
+	if completed.Metrics["input_tokens"] != 12 || completed.Metrics["output_tokens"] != 5 {
+		t.Errorf("Expected the reported usage in the RunCompleted event, got %v", completed.Metrics)
+	}
+
+	// A parent run counts the tokens of the agents it uses as tools
+	parent, err := NewAgent(AgentConfig{
+		Model: &usageModel{toolCallingModel: toolCallingModel{args: `{"prompt":"hi"}`}, usage: models.Usage{InputTokens: 100, OutputTokens: 50}},
+		Tools: []toolkit.Tool{ag.AsTool("helper", "Ask the helper")},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+	resp, err = parent.Run("hi")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if resp.Metrics["input_tokens"] != 112 || resp.Metrics["output_tokens"] != 55 {
+		t.Errorf("Expected the parent and child usage in the run metrics, got %v", resp.Metrics)
+	}
+}
@@ -0,0 +1,34 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// startRunTimeout bounds a run in ctx by timeout, or by AgentConfig.Timeout
+// when it is zero, and returns the run context with the deadline. Without a
+// timeout it returns ctx and a nil function; otherwise the run must call the
+// returned function with its error when it ends, to release the deadline and
+// report a passed deadline as a TimeoutError.
+func (a *Agent) startRunTimeout(ctx context.Context, timeout time.Duration) (context.Context, func(error) error) {
+	if timeout <= 0 {
+		timeout = a.timeout
+	}
+	if timeout <= 0 {
+		return ctx, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return ctx, func(err error) error {
+		expired := errors.Is(ctx.Err(), context.DeadlineExceeded)
+		cancel()
+
+		// Model clients do not all wrap the context error, so make sure a run
+		// cut short by the deadline reports it
+		if err == nil || !expired {
+			return err
+		}
+		return &TimeoutError{Timeout: timeout, Err: err}
+	}
+}
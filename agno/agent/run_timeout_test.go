@@ -0,0 +1,96 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// slowModel blocks until the context is done, then fails with an error that
+// does not wrap the context error, like some HTTP clients
+type slowModel struct {
+	stubModel
+}
+
+func (m *slowModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	m.calls++
+	<-ctx.Done()
+	return nil, errors.New("request aborted")
+}
+
+func TestRunTimeout(t *testing.T) {
+	model := &slowModel{}
+	ag, err := NewAgent(AgentConfig{
+		Context: context.Background(),
+		Model:   model,
+		Timeout: 20 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	start := time.Now()
+	_, err = ag.Run("hello", WithRetries(3))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the run to stop at the deadline, took %s", elapsed)
+	}
+	if model.calls != 1 {
+		t.Errorf("Expected no retries after the deadline, got %d calls", model.calls)
+	}
+	if ag.ctx.Err() != nil {
+		t.Errorf("Expected the agent context to be untouched, got %v", ag.ctx.Err())
+	}
+
+	// A run that finishes before the deadline is unaffected
+	ag.model = &stubModel{content: "ok"}
+	resp, err := ag.Run("hello", WithTimeout(time.Second))
+	if err != nil || resp.TextContent != "ok" {
+		t.Errorf("Expected the run to finish within its timeout, got %q, %v", resp.TextContent, err)
+	}
+}
+
+// waitModel answers once release is closed, unless its context is done first
+type waitModel struct {
+	stubModel
+	release chan struct{}
+}
+
+func (m *waitModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	select {
+	case <-m.release:
+		return &models.MessageResponse{Role: models.TypeAssistantRole, Content: m.content, Model: "stub"}, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func TestRunTimeoutDoesNotLeakIntoOverlappingRuns(t *testing.T) {
+	ag, err := NewAgent(AgentConfig{Context: context.Background(), Model: &stubModel{content: "ok"}})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	// The first run times out while the second one, without a timeout, is
+	// still waiting for its model
+	release := make(chan struct{})
+	done := make(chan error, 1)
+	go func() {
+		_, err := ag.Run("slow", WithModelOverride(&slowModel{}), WithTimeout(20*time.Millisecond))
+		close(release)
+		done <- err
+	}()
+
+	resp, err := ag.Run("patient", WithModelOverride(&waitModel{stubModel: stubModel{content: "done"}, release: release}))
+	if err != nil || resp.TextContent != "done" {
+		t.Errorf("Expected the run without a timeout to finish, got %v", err)
+	}
+	if err := <-done; !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the first run to time out, got %v", err)
+	}
+}
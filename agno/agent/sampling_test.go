@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+func TestSamplingOptions(t *testing.T) {
+	model := &stubModel{content: "ok"}
+	ag, err := NewAgent(AgentConfig{Model: model})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+	sent := func() *models.CallOptions {
+		callOpts := models.DefaultCallOptions()
+		for _, opt := range model.options {
+			opt(callOpts)
+		}
+		return callOpts
+	}
+
+	if _, err := ag.Run("hi", WithTemperature(0), WithTopP(0.9)); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := sent(); got.Temperature == nil || *got.Temperature != 0 || got.TopP == nil || *got.TopP != 0.9 {
+		t.Errorf("Expected temperature 0 and top_p 0.9, got %v and %v", got.Temperature, got.TopP)
+	}
+
+	// Unset options keep the model default
+	if _, err := ag.Run("hi"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	defaults := models.DefaultCallOptions()
+	if got := sent(); *got.Temperature != *defaults.Temperature || *got.TopP != *defaults.TopP {
+		t.Errorf("Expected the default sampling options, got %v and %v", *got.Temperature, *got.TopP)
+	}
+
+	calls := model.calls
+	for _, opt := range []RunOption{WithTemperature(2.5), WithTemperature(-0.1), WithTopP(1.5)} {
+		if _, err := ag.Run("hi", opt); err == nil {
+			t.Error("Expected an error for an out of range option")
+		}
+	}
+	if model.calls != calls {
+		t.Errorf("Expected no model call for invalid options, got %d", model.calls-calls)
+	}
+}
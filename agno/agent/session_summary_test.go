@@ -0,0 +1,67 @@
+package agent
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/memory"
+	memorysqlite "github.com/devalexandre/agno-golang/agno/memory/sqlite"
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// recordingModel is a stubModel that keeps the messages of its last call
+type recordingModel struct {
+	stubModel
+	messages []models.Message
+}
+
+func (m *recordingModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	m.messages = messages
+	return m.stubModel.Invoke(ctx, messages, options...)
+}
+
+func TestAgentAutoSummarize(t *testing.T) {
+	db, err := memorysqlite.NewSqliteMemoryDb("memories", filepath.Join(t.TempDir(), "memory.db"))
+	if err != nil {
+		t.Fatalf("Failed to create memory db: %v", err)
+	}
+	summarizer := &stubModel{content: "The user is planning a trip to Japan."}
+	mem := memory.NewMemory(summarizer, db, memory.WithAutoSummarize(2))
+
+	model := &recordingModel{stubModel: stubModel{content: "Sounds great!"}}
+	ag, err := NewAgent(AgentConfig{
+		Context:   context.Background(),
+		Model:     model,
+		Memory:    mem,
+		UserID:    "u1",
+		SessionID: "s1",
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	for _, prompt := range []string{"I want to visit Japan", "In April"} {
+		if _, err := ag.Run(prompt); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+	}
+
+	turns, err := mem.GetSessionTurnCount(context.Background(), "u1", "s1")
+	if err != nil || turns != 2 {
+		t.Fatalf("Expected 2 turns, got %d (%v)", turns, err)
+	}
+	summary, err := mem.GetSessionSummary(context.Background(), "u1", "s1")
+	if err != nil || summary.Summary != "The user is planning a trip to Japan." {
+		t.Fatalf("Expected the session summary after 2 turns, got %+v (%v)", summary, err)
+	}
+
+	if _, err := ag.Run("What should I pack?"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if len(model.messages) == 0 || !strings.Contains(model.messages[0].Content, "<session_summary>") ||
+		!strings.Contains(model.messages[0].Content, "trip to Japan") {
+		t.Errorf("Expected the session summary in the system message, got %+v", model.messages)
+	}
+}
@@ -0,0 +1,125 @@
+package agent
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// StreamGuardrailMode controls how OutputGuardrails apply to RunStream and RunStreamEvents
+type StreamGuardrailMode string
+
+const (
+	// StreamGuardrailsOff streams chunks as they arrive without output guardrails (default)
+	StreamGuardrailsOff StreamGuardrailMode = ""
+	// StreamGuardrailsBuffered holds the whole response until the output
+	// guardrails pass, so blocked text never reaches the caller
+	StreamGuardrailsBuffered StreamGuardrailMode = "buffered"
+	// StreamGuardrailsBoundary runs the output guardrails on the text so far at
+	// each sentence or line boundary and flushes it when they pass. Latency is
+	// lower, but a later block retracts text the caller already received.
+	StreamGuardrailsBoundary StreamGuardrailMode = "boundary"
+)
+
+// OutputBlockedError is returned by a streamed run when an output guardrail
+// blocks the response
+type OutputBlockedError struct {
+	Err error
+	// Flushed is the text already delivered to the caller, which must be retracted
+	Flushed string
+}
+
+func (e *OutputBlockedError) Error() string {
+	return "output validation failed: " + e.Err.Error()
+}
+
+func (e *OutputBlockedError) Unwrap() error {
+	return e.Err
+}
+
+// streamGuard buffers streamed chunks and only flushes text that passed the output guardrails
+type streamGuard struct {
+	agent      *Agent
+	ctx        context.Context
+	guardrails []Guardrail
+	boundary   bool
+	flush      func([]byte) error
+
+	full    strings.Builder
+	flushed int
+}
+
+// newStreamGuard wraps fn with the agent's output guardrails, run in the run
+// context ctx, or returns nil when streamed output is not guarded
+func (a *Agent) newStreamGuard(ctx context.Context, fn func([]byte) error) *streamGuard {
+	if a.streamGuardrailMode == StreamGuardrailsOff || len(a.outputGuardrails) == 0 {
+		return nil
+	}
+	return &streamGuard{
+		agent:      a,
+		ctx:        ctx,
+		guardrails: a.outputGuardrails,
+		boundary:   a.streamGuardrailMode == StreamGuardrailsBoundary,
+		flush:      fn,
+	}
+}
+
+// write buffers a chunk, flushing up to the last boundary in boundary mode
+func (g *streamGuard) write(chunk []byte) error {
+	g.full.Write(chunk)
+	if !g.boundary {
+		return nil
+	}
+	text := g.full.String()
+	if end := lastStreamBoundary(text, g.flushed); end > g.flushed {
+		return g.flushTo(text, end)
+	}
+	return nil
+}
+
+// close validates and flushes the remaining text once the model finished
+func (g *streamGuard) close() error {
+	text := g.full.String()
+	if len(text) == g.flushed && g.flushed > 0 {
+		return nil
+	}
+	return g.flushTo(text, len(text))
+}
+
+// flushTo runs the guardrails on text[:end] and delivers the unflushed part
+func (g *streamGuard) flushTo(text string, end int) error {
+	response := models.RunResponse{
+		TextContent: text[:end],
+		ContentType: "text",
+		Event:       "RunResponse",
+		CreatedAt:   time.Now().Unix(),
+	}
+	if err := g.agent.runGuardrails(g.ctx, GuardrailStageOutput, g.guardrails, response); err != nil {
+		return &OutputBlockedError{Err: err, Flushed: text[:g.flushed]}
+	}
+	if end == g.flushed {
+		return nil
+	}
+	pending := text[g.flushed:end]
+	g.flushed = end
+	return g.flush([]byte(pending))
+}
+
+// lastStreamBoundary returns the offset just past the last sentence end or
+// newline in text after from, or from when there is none
+func lastStreamBoundary(text string, from int) int {
+	for i := len(text) - 1; i >= from; i-- {
+		switch text[i] {
+		case '\n':
+			return i + 1
+		case '.', '!', '?':
+			// Only a sentence end when followed by whitespace, not "3.14" or "e.g"
+			if i+1 < len(text) && (text[i+1] == ' ' || text[i+1] == '\n') {
+				return i + 1
+			}
+		}
+	}
+	return from
+}
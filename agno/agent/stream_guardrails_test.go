@@ -0,0 +1,84 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// chunkedModel streams its response in several chunks
+type chunkedModel struct {
+	stubModel
+	chunks []string
+}
+
+func (m *chunkedModel) InvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) error {
+	callOpts := models.DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOpts)
+	}
+	for _, chunk := range m.chunks {
+		if err := callOpts.StreamingFunc(ctx, []byte(chunk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestStreamGuardrailModes(t *testing.T) {
+	run := func(mode StreamGuardrailMode, chunks ...string) ([]RunEvent, error) {
+		ag, err := NewAgent(AgentConfig{
+			Model:               &chunkedModel{chunks: chunks},
+			OutputGuardrails:    []Guardrail{NewOutputContentGuardrail()},
+			StreamGuardrailMode: mode,
+		})
+		if err != nil {
+			t.Fatalf("NewAgent failed: %v", err)
+		}
+		var events []RunEvent
+		err = ag.RunStreamEvents("hi", func(event RunEvent) error {
+			events = append(events, event)
+			return nil
+		})
+		return events, err
+	}
+	content := func(events []RunEvent) string {
+		var sb strings.Builder
+		for _, event := range events {
+			if event.Event == RunEventContent {
+				sb.WriteString(event.Content)
+			}
+		}
+		return sb.String()
+	}
+	leak := []string{"Hello there. ", "Your password: hunter2\n"}
+
+	// Without a mode, the blocked text reaches the caller
+	events, err := run(StreamGuardrailsOff, leak...)
+	if err != nil || !strings.Contains(content(events), "hunter2") {
+		t.Fatalf("Expected unguarded streaming, got %q (%v)", content(events), err)
+	}
+
+	events, err = run(StreamGuardrailsBuffered, leak...)
+	var blocked *OutputBlockedError
+	if !errors.As(err, &blocked) || content(events) != "" {
+		t.Fatalf("Expected the buffered stream to be blocked before any content, got %q (%v)", content(events), err)
+	}
+
+	events, err = run(StreamGuardrailsBoundary, leak...)
+	if !errors.As(err, &blocked) || content(events) != "Hello there." {
+		t.Fatalf("Expected only the first sentence before the block, got %q (%v)", content(events), err)
+	}
+	last := events[len(events)-1]
+	if last.Event != RunEventContentRetracted || last.Content != "Hello there." {
+		t.Errorf("Expected a retraction of the flushed text, got %+v", last)
+	}
+
+	events, err = run(StreamGuardrailsBoundary, "Safe. ", "Still ", "safe.")
+	if err != nil || content(events) != "Safe. Still safe." {
+		t.Errorf("Expected safe text to stream through, got %q (%v)", content(events), err)
+	}
+}
@@ -0,0 +1,72 @@
+package agent
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/models/ollama"
+)
+
+func TestRunStreamStopsOllamaStream(t *testing.T) {
+	disconnected := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		flusher := w.(http.Flusher)
+		enc := json.NewEncoder(w)
+		for i := 0; i < 200; i++ {
+			select {
+			case <-r.Context().Done():
+				close(disconnected)
+				return
+			case <-time.After(5 * time.Millisecond):
+			}
+			enc.Encode(map[string]interface{}{
+				"model":   "test",
+				"message": map[string]string{"role": "assistant", "content": "word "},
+				"done":    false,
+			})
+			flusher.Flush()
+		}
+		enc.Encode(map[string]interface{}{"model": "test", "done": true})
+	}))
+	defer server.Close()
+
+	model, err := ollama.NewOllamaChat(models.WithID("test"), models.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("NewOllamaChat failed: %v", err)
+	}
+	ag, err := NewAgent(AgentConfig{Model: model, AddHistoryToMessages: true})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	chunks := 0
+	err = ag.RunStream("Tell me a long story", func(chunk []byte) error {
+		chunks++
+		if chunks == 3 {
+			return ErrStopStream
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Expected a stopped stream to succeed, got %v", err)
+	}
+	if chunks != 3 {
+		t.Errorf("Expected no chunks after stopping, got %d", chunks)
+	}
+
+	select {
+	case <-disconnected:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected the model connection to be closed after stopping")
+	}
+
+	history := ag.messages
+	if len(history) != 2 || history[1].Content != "word word word " {
+		t.Errorf("Expected the partial response in history, got %+v", history)
+	}
+}
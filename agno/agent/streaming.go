@@ -9,8 +9,8 @@ import (
 	"github.com/devalexandre/agno-golang/agno/utils"
 )
 
-// runWithStreaming executes the agent with streaming UI and returns the response
-func (a *Agent) runWithStreaming(prompt string, messages []models.Message) (*models.MessageResponse, error) {
+// runWithStreaming executes run with streaming UI and returns the response
+func (a *Agent) runWithStreaming(run *runState, prompt string, messages []models.Message) (*models.MessageResponse, error) {
 	start := time.Now()
 
 	// Show prompt
@@ -28,7 +28,7 @@ func (a *Agent) runWithStreaming(prompt string, messages []models.Message) (*mod
 	inThinkingTag := false
 
 	callOptions := []models.Option{
-		models.WithTools(a.tools),
+		models.WithTools(run.tools),
 		models.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
 			if !showResponse {
 				showResponse = true
@@ -135,11 +135,11 @@ func (a *Agent) runWithStreaming(prompt string, messages []models.Message) (*mod
 			return nil
 		}),
 	}
-	if len(a.modelOptions) > 0 {
-		callOptions = append(callOptions, a.modelOptions...)
-	}
+	callOptions = a.modelCallOptions(run, callOptions...)
 
-	err := a.model.InvokeStream(a.ctx, messages, callOptions...)
+	callStart := time.Now()
+	err := a.activeModel(run).InvokeStream(run.ctx, messages, callOptions...)
+	a.logModelCall(a.activeModel(run), callStart, 0, len(messages), err)
 
 	// Flush any remaining content in buffer
 	if streamBuffer != "" {
@@ -167,12 +167,16 @@ func (a *Agent) runWithStreaming(prompt string, messages []models.Message) (*mod
 	time.Sleep(100 * time.Millisecond)
 
 	if err != nil {
+		// Keep the text received before the failure as a partial response
+		if fullResponse != "" {
+			return &models.MessageResponse{Model: a.activeModel(run).GetID(), Role: "assistant", Content: fullResponse}, err
+		}
 		return nil, err
 	}
 
 	// Construct response object
 	return &models.MessageResponse{
-		Model:   a.model.GetID(), // Assuming GetID exists or we use a.model.ID if available
+		Model:   a.activeModel(run).GetID(), // Assuming GetID exists or we use a.model.ID if available
 		Role:    "assistant",
 		Content: fullResponse,
 		// Note: We might miss some metrics here as InvokeStream doesn't return them directly
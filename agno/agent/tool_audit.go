@@ -0,0 +1,134 @@
+package agent
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+)
+
+// ToolExecution describes a single tool call for auditing
+type ToolExecution struct {
+	AgentName string                 `json:"agent_name,omitempty"`
+	ToolName  string                 `json:"tool_name"`
+	Args      map[string]interface{} `json:"args,omitempty"`
+	Result    interface{}            `json:"result,omitempty"`
+	Duration  time.Duration          `json:"duration"`
+	Error     string                 `json:"error,omitempty"`
+	Timestamp time.Time              `json:"timestamp"`
+}
+
+// ToolAuditSink receives a record of every tool call made by an agent.
+// Record is called synchronously after each call, so implementations should be fast
+// and must be safe for concurrent use.
+type ToolAuditSink interface {
+	Record(execution ToolExecution)
+}
+
+// JSONFileToolAuditSink appends tool executions to a file as JSON lines
+type JSONFileToolAuditSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewJSONFileToolAuditSink opens (or creates) path for appending tool executions
+func NewJSONFileToolAuditSink(path string) (*JSONFileToolAuditSink, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit file: %w", err)
+	}
+	return &JSONFileToolAuditSink{file: file}, nil
+}
+
+// Record writes the execution as a single JSON line
+func (s *JSONFileToolAuditSink) Record(execution ToolExecution) {
+	line, err := json.Marshal(execution)
+	if err != nil {
+		log.Printf("Warning: Failed to encode tool execution: %v", err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		log.Printf("Warning: Failed to write tool execution: %v", err)
+	}
+}
+
+// Close closes the underlying file
+func (s *JSONFileToolAuditSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file.Close()
+}
+
+var auditTableNamePattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+
+// SQLToolAuditSink stores tool executions in a SQL table. Pass the same *sql.DB
+// used by tools.NewDatabaseTool to keep the audit trail alongside the agent's data.
+type SQLToolAuditSink struct {
+	db     *sql.DB
+	dbType string
+	table  string
+}
+
+// NewSQLToolAuditSink creates the audit table if needed. dbType is postgres, mysql
+// or sqlite3 (default postgres) and table defaults to tool_audit_log.
+func NewSQLToolAuditSink(db *sql.DB, dbType, table string) (*SQLToolAuditSink, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+	if dbType == "" {
+		dbType = "postgres"
+	}
+	if table == "" {
+		table = "tool_audit_log"
+	}
+	if !auditTableNamePattern.MatchString(table) {
+		return nil, fmt.Errorf("invalid audit table name: %s", table)
+	}
+
+	createQuery := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+		agent_name VARCHAR(255),
+		tool_name VARCHAR(255) NOT NULL,
+		args TEXT,
+		result TEXT,
+		error TEXT,
+		duration_ms BIGINT,
+		created_at TIMESTAMP NOT NULL
+	)`, table)
+	if _, err := db.Exec(createQuery); err != nil {
+		return nil, fmt.Errorf("failed to create audit table: %w", err)
+	}
+
+	return &SQLToolAuditSink{db: db, dbType: dbType, table: table}, nil
+}
+
+// Record inserts the execution into the audit table
+func (s *SQLToolAuditSink) Record(execution ToolExecution) {
+	args, _ := json.Marshal(execution.Args)
+	result, _ := json.Marshal(execution.Result)
+
+	placeholders := "?, ?, ?, ?, ?, ?, ?"
+	if s.dbType == "postgres" {
+		placeholders = "$1, $2, $3, $4, $5, $6, $7"
+	}
+	query := fmt.Sprintf("INSERT INTO %s (agent_name, tool_name, args, result, error, duration_ms, created_at) VALUES (%s)", s.table, placeholders)
+
+	_, err := s.db.Exec(query,
+		execution.AgentName,
+		execution.ToolName,
+		string(args),
+		string(result),
+		execution.Error,
+		execution.Duration.Milliseconds(),
+		execution.Timestamp,
+	)
+	if err != nil {
+		log.Printf("Warning: Failed to record tool execution: %v", err)
+	}
+}
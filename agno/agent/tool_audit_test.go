@@ -0,0 +1,65 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func TestToolAuditSinkRecordsCalls(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	sink, err := NewJSONFileToolAuditSink(path)
+	if err != nil {
+		t.Fatalf("NewJSONFileToolAuditSink failed: %v", err)
+	}
+	defer sink.Close()
+
+	ag, err := NewAgent(AgentConfig{
+		Name:          "auditor",
+		Model:         &stubModel{content: "ok"},
+		Tools:         []toolkit.Tool{createMockTool()},
+		ToolAuditSink: sink,
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	result, err := ag.tools[0].Execute("mock_test_method", json.RawMessage(`{"value": 4}`))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result != 8 {
+		t.Fatalf("Expected 8, got %v", result)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open audit file: %v", err)
+	}
+	defer file.Close()
+
+	var records []ToolExecution
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record ToolExecution
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Invalid audit line: %v", err)
+		}
+		records = append(records, record)
+	}
+
+	if len(records) != 1 {
+		t.Fatalf("Expected 1 audit record, got %d", len(records))
+	}
+	record := records[0]
+	if record.AgentName != "auditor" || record.ToolName != "mock.mock_test_method" {
+		t.Errorf("Unexpected record: %+v", record)
+	}
+	if record.Args["value"] != float64(4) || record.Result != float64(8) || record.Error != "" {
+		t.Errorf("Unexpected record values: %+v", record)
+	}
+}
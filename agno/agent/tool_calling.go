@@ -106,6 +106,11 @@ func (v *DefaultToolArgumentValidator) ValidateArguments(toolName, methodName st
 		}
 	}
 
+	// Validar enum, limites numéricos e tamanho de strings
+	if err := toolkit.CheckConstraints(schema, args); err != nil {
+		return fmt.Errorf("%w for %s.%s", err, toolName, methodName)
+	}
+
 	return nil
 }
 
@@ -154,6 +159,19 @@ func (a *Agent) ExecuteToolCallsParallel(ctx context.Context, requests []ToolCal
 		go func(index int, request ToolCallRequest) {
 			defer wg.Done()
 
+			// Respect the tool's own concurrency limit before taking a global
+			// slot, so calls waiting on a serial tool don't block the others
+			release, err := a.acquireToolSlot(ctx, request.ToolName)
+			if err != nil {
+				results[index] = ToolCallResult{
+					ToolName:   request.ToolName,
+					MethodName: request.MethodName,
+					Error:      err,
+				}
+				return
+			}
+			defer release()
+
 			// Adquirir slot do semáforo
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
@@ -175,6 +193,35 @@ func (a *Agent) ExecuteToolCallsParallel(ctx context.Context, requests []ToolCal
 	return results
 }
 
+// newToolSemaphores creates a semaphore for each tool with a concurrency limit
+func newToolSemaphores(limits map[string]int) map[string]chan struct{} {
+	if len(limits) == 0 {
+		return nil
+	}
+	semaphores := make(map[string]chan struct{}, len(limits))
+	for name, n := range limits {
+		if n > 0 {
+			semaphores[name] = make(chan struct{}, n)
+		}
+	}
+	return semaphores
+}
+
+// acquireToolSlot waits for a free slot of a tool with a concurrency limit.
+// The returned function releases it; tools without a limit return at once.
+func (a *Agent) acquireToolSlot(ctx context.Context, toolName string) (func(), error) {
+	semaphore, ok := a.toolSemaphores[toolName]
+	if !ok {
+		return func() {}, nil
+	}
+	select {
+	case semaphore <- struct{}{}:
+		return func() { <-semaphore }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // executeToolCallWithRetry executa uma chamada de ferramenta com retry automático
 func (a *Agent) executeToolCallWithRetry(ctx context.Context, req ToolCallRequest, config ToolCallConfig) ToolCallResult {
 	result := ToolCallResult{
@@ -236,6 +283,12 @@ func (a *Agent) executeToolCallWithRetry(ctx context.Context, req ToolCallReques
 			return result
 		}
 
+		// Respeitar o orçamento de retries da execução
+		if budgetErr := retryBudgetFromContext(ctx).consume("tool", err); budgetErr != nil {
+			result.Error = budgetErr
+			return result
+		}
+
 		// Calcular delay para próxima tentativa
 		delay := calculateRetryDelay(attempt, config.RetryDelay, config.UseExponentialBackoff)
 
@@ -3,6 +3,7 @@ package agent
 import (
 	"context"
 	"encoding/json"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -79,6 +80,67 @@ func TestExecuteToolCallsParallel(t *testing.T) {
 	}
 }
 
+// concurrencyTool records the maximum number of concurrent calls
+type concurrencyTool struct {
+	toolkit.Toolkit
+	active int32
+	max    int32
+}
+
+func (ct *concurrencyTool) TestMethod(params MockParams) (int, error) {
+	n := atomic.AddInt32(&ct.active, 1)
+	defer atomic.AddInt32(&ct.active, -1)
+	for {
+		max := atomic.LoadInt32(&ct.max)
+		if n <= max || atomic.CompareAndSwapInt32(&ct.max, max, n) {
+			break
+		}
+	}
+	time.Sleep(20 * time.Millisecond)
+	return params.Value, nil
+}
+
+func TestExecuteToolCallsParallelToolConcurrency(t *testing.T) {
+	ctx := context.Background()
+	limited := &concurrencyTool{Toolkit: toolkit.NewToolkit()}
+	limited.Name = "limited"
+	limited.Register("test_method", "Limited tool", limited, limited.TestMethod, MockParams{})
+	free := &concurrencyTool{Toolkit: toolkit.NewToolkit()}
+	free.Name = "free"
+	free.Register("test_method", "Free tool", free, free.TestMethod, MockParams{})
+
+	agent := &Agent{
+		ctx:            ctx,
+		tools:          []toolkit.Tool{limited, free},
+		toolSemaphores: newToolSemaphores(map[string]int{"limited": 1}),
+	}
+
+	var requests []ToolCallRequest
+	for i := 0; i < 4; i++ {
+		for _, name := range []string{"limited", "free"} {
+			requests = append(requests, ToolCallRequest{
+				ToolName:   name,
+				MethodName: "test_method",
+				Arguments:  json.RawMessage(`{"value": 1}`),
+			})
+		}
+	}
+
+	results := agent.ExecuteToolCallsParallel(ctx, requests, ToolCallConfig{MaxParallelCalls: 4})
+
+	for i, result := range results {
+		if !result.Success {
+			t.Errorf("Result %d failed: %v", i, result.Error)
+		}
+	}
+	if got := atomic.LoadInt32(&limited.max); got != 1 {
+		t.Errorf("Expected limited tool to run one call at a time, got %d concurrent calls", got)
+	}
+	if got := atomic.LoadInt32(&free.max); got < 2 {
+		t.Errorf("Expected free tool to run calls in parallel, got %d concurrent calls", got)
+	}
+}
+
 func TestRetryWithBackoff(t *testing.T) {
 	ctx := context.Background()
 	mockTool := createMockTool()
@@ -161,6 +223,33 @@ func TestArgumentValidation(t *testing.T) {
 	}
 }
 
+type roomParams struct {
+	RoomType string `json:"room_type" jsonschema:"required,enum=standard|deluxe|suite"`
+	Nights   int    `json:"nights" jsonschema:"min=1,max=14"`
+}
+
+func TestArgumentValidationConstraints(t *testing.T) {
+	tk := toolkit.NewToolkit()
+	tk.Name = "hotel"
+	tk.Register("book", "Reserva um quarto", &tk, func(params roomParams) (string, error) { return "ok", nil }, roomParams{})
+	validator := NewDefaultToolArgumentValidator([]toolkit.Tool{&tk})
+
+	if err := validator.ValidateArguments("hotel", "book", map[string]interface{}{"room_type": "suite", "nights": 3.0}); err != nil {
+		t.Errorf("Expected valid arguments, got %v", err)
+	}
+
+	invalid := []map[string]interface{}{
+		{"room_type": "penthouse"},
+		{"room_type": "suite", "nights": 0.0},
+		{"room_type": "suite", "nights": 15.0},
+	}
+	for _, args := range invalid {
+		if err := validator.ValidateArguments("hotel", "book", args); err == nil {
+			t.Errorf("Expected %v to be rejected", args)
+		}
+	}
+}
+
 func TestToolCallStats(t *testing.T) {
 	results := []ToolCallResult{
 		{
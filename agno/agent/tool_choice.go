@@ -0,0 +1,50 @@
+package agent
+
+import (
+	"fmt"
+	"sort"
+)
+
+// ToolChoice controls whether the model calls tools: ToolChoiceAuto,
+// ToolChoiceNone, ToolChoiceRequired, or the name of a tool method to force
+// (see WithForcedTool)
+type ToolChoice string
+
+const (
+	// ToolChoiceAuto lets the model decide whether to call tools (default)
+	ToolChoiceAuto ToolChoice = "auto"
+	// ToolChoiceNone forbids tool calls
+	ToolChoiceNone ToolChoice = "none"
+	// ToolChoiceRequired makes the model call at least one tool
+	ToolChoiceRequired ToolChoice = "required"
+)
+
+// checkToolChoice returns an error when choice forces a tool method the agent
+// does not have
+func (a *Agent) checkToolChoice(choice ToolChoice) error {
+	switch choice {
+	case "", ToolChoiceAuto, ToolChoiceNone, ToolChoiceRequired:
+		return nil
+	}
+
+	var names []string
+	for _, tool := range a.tools {
+		for name := range tool.GetMethods() {
+			if name == string(choice) {
+				return nil
+			}
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return fmt.Errorf("tool choice %q is not a tool of the agent (available: %v)", choice, names)
+}
+
+// activeToolChoice returns the tool choice of run: the WithToolChoice or
+// WithForcedTool value when one was given, otherwise AgentConfig.ToolChoice
+func (a *Agent) activeToolChoice(run *runState) ToolChoice {
+	if run != nil && run.toolChoice != "" {
+		return run.toolChoice
+	}
+	return ToolChoice(a.toolChoice)
+}
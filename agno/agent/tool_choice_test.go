@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func TestToolChoice(t *testing.T) {
+	model := &stubModel{content: "ok"}
+	ag, err := NewAgent(AgentConfig{
+		Model:      model,
+		Tools:      []toolkit.Tool{createMockTool()},
+		ToolChoice: string(ToolChoiceNone),
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+	sentChoice := func() string {
+		callOpts := models.DefaultCallOptions()
+		for _, opt := range model.options {
+			opt(callOpts)
+		}
+		return callOpts.ToolChoice
+	}
+
+	if _, err := ag.Run("hi"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := sentChoice(); got != "none" {
+		t.Errorf("Expected the agent tool choice none, got %q", got)
+	}
+
+	if _, err := ag.Run("hi", WithToolChoice(ToolChoiceRequired)); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := sentChoice(); got != "required" {
+		t.Errorf("Expected the run tool choice required, got %q", got)
+	}
+
+	if _, err := ag.Run("hi", WithForcedTool("mock_test_method")); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := sentChoice(); got != "mock_test_method" {
+		t.Errorf("Expected the forced tool, got %q", got)
+	}
+
+	calls := model.calls
+	if _, err := ag.Run("hi", WithForcedTool("missing")); err == nil {
+		t.Error("Expected an error when forcing an unknown tool")
+	}
+	if model.calls != calls {
+		t.Error("Expected no model call when forcing an unknown tool")
+	}
+
+	if _, err := NewAgent(AgentConfig{Model: model, ToolChoice: "missing"}); err == nil {
+		t.Error("Expected NewAgent to reject an unknown forced tool")
+	}
+}
@@ -0,0 +1,42 @@
+package agent
+
+import (
+	"encoding/json"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// CircuitBreakerTool wraps a tool with a circuit breaker shared by all its methods
+type CircuitBreakerTool struct {
+	toolkit.Tool
+	breaker *models.CircuitBreaker
+}
+
+// NewCircuitBreakerTool wraps tool so that repeated failures open the circuit and
+// later calls fail fast with models.ErrCircuitOpen until the cooldown elapses.
+// The model sees the rejection as a tool error and can choose another tool.
+func NewCircuitBreakerTool(tool toolkit.Tool, config models.CBConfig) *CircuitBreakerTool {
+	if config.Name == "" {
+		config.Name = tool.GetName()
+	}
+	return &CircuitBreakerTool{
+		Tool:    tool,
+		breaker: models.NewBreaker(config),
+	}
+}
+
+// Breaker returns the underlying circuit breaker
+func (t *CircuitBreakerTool) Breaker() *models.CircuitBreaker {
+	return t.breaker
+}
+
+// Execute calls the wrapped tool if the circuit allows it
+func (t *CircuitBreakerTool) Execute(methodName string, input json.RawMessage) (interface{}, error) {
+	if err := t.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	result, err := t.Tool.Execute(methodName, input)
+	t.breaker.Record(err)
+	return result, err
+}
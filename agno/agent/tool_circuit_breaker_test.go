@@ -0,0 +1,60 @@
+package agent
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+func TestCircuitBreakerTool(t *testing.T) {
+	var transitions []models.CircuitState
+	tool := NewCircuitBreakerTool(createMockTool(), models.CBConfig{
+		FailureThreshold: 2,
+		Cooldown:         20 * time.Millisecond,
+		OnStateChange: func(name string, from, to models.CircuitState) {
+			if name != "mock" {
+				t.Errorf("Expected breaker name mock, got %s", name)
+			}
+			transitions = append(transitions, to)
+		},
+	})
+
+	bad := json.RawMessage(`not json`)
+	good := json.RawMessage(`{"value": 2}`)
+
+	for i := 0; i < 2; i++ {
+		if _, err := tool.Execute("mock_test_method", bad); err == nil {
+			t.Fatal("Expected invalid input to fail")
+		}
+	}
+	if tool.Breaker().State() != models.CircuitOpen {
+		t.Fatalf("Expected open circuit, got %s", tool.Breaker().State())
+	}
+
+	if _, err := tool.Execute("mock_test_method", good); !errors.Is(err, models.ErrCircuitOpen) {
+		t.Fatalf("Expected ErrCircuitOpen, got %v", err)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	result, err := tool.Execute("mock_test_method", good)
+	if err != nil {
+		t.Fatalf("Expected probe to succeed, got %v", err)
+	}
+	if result != 4 {
+		t.Errorf("Expected result 4, got %v", result)
+	}
+
+	expected := []models.CircuitState{models.CircuitOpen, models.CircuitHalfOpen, models.CircuitClosed}
+	if len(transitions) != len(expected) {
+		t.Fatalf("Expected transitions %v, got %v", expected, transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("Expected transitions %v, got %v", expected, transitions)
+			break
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// toolCallingModel calls the first tool once with args before answering, like a model client
+type toolCallingModel struct {
+	stubModel
+	args string
+}
+
+func (m *toolCallingModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	callOpts := models.DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOpts)
+	}
+	tool := callOpts.ToolCall[0]
+	for name := range tool.GetMethods() {
+		result, err := tool.Execute(name, json.RawMessage(m.args))
+		if err != nil {
+			return nil, err
+		}
+		m.content = result.(string)
+	}
+	return m.stubModel.Invoke(ctx, messages, options...)
+}
+
+func TestToolsReceiveRunMetadata(t *testing.T) {
+	var tenant interface{}
+	lookup := tools.NewToolFromFunction(func(ctx context.Context, id string) (string, error) {
+		tenant = MetadataFromContext(ctx)["tenant_id"]
+		return "order " + id, nil
+	}, "Look up an order")
+
+	ag, err := NewAgent(AgentConfig{
+		Model: &toolCallingModel{args: `{"arg0":"A-1"}`},
+		Tools: []toolkit.Tool{lookup},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	resp, err := ag.Run("Where is my order?", WithMetadata(map[string]interface{}{"tenant_id": "acme"}))
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if resp.TextContent != "order A-1" {
+		t.Errorf("Expected the tool result, got %q", resp.TextContent)
+	}
+	if tenant != "acme" {
+		t.Errorf("Expected the tool to see tenant_id acme, got %v", tenant)
+	}
+
+	// Outside a run, tools see no metadata
+	tenant = nil
+	if _, err := ag.tools[0].Execute("lookUpAnOrder", json.RawMessage(`{"arg0":"A-2"}`)); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if tenant != nil {
+		t.Errorf("Expected no metadata outside a run, got %v", tenant)
+	}
+}
@@ -0,0 +1,66 @@
+package agent
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+type bigOutputParams struct {
+	Size int `json:"size"`
+}
+
+func newBigOutputTool() toolkit.Tool {
+	tk := toolkit.NewToolkit()
+	tk.Name = "big"
+	tk.Register("read", "Returns size bytes", &tk, func(params bigOutputParams) (interface{}, error) {
+		return strings.Repeat("x", params.Size), nil
+	}, bigOutputParams{})
+	return &tk
+}
+
+func TestMaxToolOutputBytesTruncatesResults(t *testing.T) {
+	ag, err := NewAgent(AgentConfig{
+		Model:              &stubModel{content: "ok"},
+		Tools:              []toolkit.Tool{newBigOutputTool()},
+		MaxToolOutputBytes: 10,
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	result, err := ag.tools[0].Execute("big_read", json.RawMessage(`{"size": 25}`))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if want := "xxxxxxxxxx\n[truncated 15 bytes]"; result != want {
+		t.Errorf("Expected %q, got %q", want, result)
+	}
+
+	result, err = ag.tools[0].Execute("big_read", json.RawMessage(`{"size": 10}`))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if result != strings.Repeat("x", 10) {
+		t.Errorf("Expected a result within the limit to be unchanged, got %q", result)
+	}
+}
+
+func TestLimitToolOutputKeepsToolResultData(t *testing.T) {
+	ag := &Agent{maxToolOutputBytes: 2}
+
+	result := ag.limitToolOutput("files.read", toolkit.NewToolResult("héllo world", []byte("raw"), "text/plain"))
+	r, ok := result.(toolkit.ToolResult)
+	if !ok {
+		t.Fatalf("Expected a ToolResult, got %T", result)
+	}
+	// The limit falls inside the two bytes of "é", so the cut moves back before it
+	if r.Content != "h\n[truncated 11 bytes]" {
+		t.Errorf("Unexpected content: %q", r.Content)
+	}
+	if string(r.Data.([]byte)) != "raw" {
+		t.Errorf("Expected Data to be kept, got %v", r.Data)
+	}
+}
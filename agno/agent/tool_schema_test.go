@@ -0,0 +1,48 @@
+package agent
+
+import (
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/tools"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func TestGetToolSchemas(t *testing.T) {
+	place := tools.NewToolFromFunction(func(id string, quantity int) (string, error) {
+		return "placed", nil
+	}, "Place an order")
+
+	ag, err := NewAgent(AgentConfig{
+		Model: &stubModel{content: "ok"},
+		Tools: []toolkit.Tool{newSleepTool(), place},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	schemas := ag.GetToolSchemas()
+	if len(schemas) != 2 {
+		t.Fatalf("Expected 2 schemas, got %+v", schemas)
+	}
+
+	sleep := schemas[0]
+	if sleep.Name != "slow_sleep" || sleep.Tool != "slow" || sleep.Description != "Sleeps" {
+		t.Errorf("Unexpected schema for slow_sleep: %+v", sleep)
+	}
+	if want := ag.tools[0].GetParameterStruct("slow_sleep"); sleep.Parameters["properties"] == nil ||
+		len(sleep.Parameters["properties"].(map[string]interface{})) != len(want["properties"].(map[string]interface{})) {
+		t.Errorf("Expected the schema sent to the model, got %v", sleep.Parameters)
+	}
+
+	order := schemas[1]
+	if order.Name != place.GetName() || order.Description != "Place an order" {
+		t.Errorf("Unexpected schema for the function tool: %+v", order)
+	}
+	properties, _ := order.Parameters["properties"].(map[string]interface{})
+	for arg, want := range map[string]string{"arg0": "string", "arg1": "integer"} {
+		schema, _ := properties[arg].(map[string]interface{})
+		if schema["type"] != want {
+			t.Errorf("Expected %s to be typed %s, got %v", arg, want, properties[arg])
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package agent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+type sleepParams struct {
+	Millis int `json:"millis"`
+}
+
+// contextSleepTool sleeps until its context is done and reports how it ended
+type contextSleepTool struct {
+	toolkit.Toolkit
+	stopped chan error
+}
+
+func (t *contextSleepTool) ExecuteContext(ctx context.Context, methodName string, input json.RawMessage) (interface{}, error) {
+	<-ctx.Done()
+	t.stopped <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+type recordingAuditSink struct {
+	executions []ToolExecution
+}
+
+func (s *recordingAuditSink) Record(execution ToolExecution) {
+	s.executions = append(s.executions, execution)
+}
+
+func newSleepTool() toolkit.Tool {
+	tk := toolkit.NewToolkit()
+	tk.Name = "slow"
+	tk.Register("sleep", "Sleeps", &tk, func(params sleepParams) (interface{}, error) {
+		time.Sleep(time.Duration(params.Millis) * time.Millisecond)
+		return "awake", nil
+	}, sleepParams{})
+	return &tk
+}
+
+func TestToolTimeoutReturnsErrorToModel(t *testing.T) {
+	sink := &recordingAuditSink{}
+	var hookResult interface{}
+	ag, err := NewAgent(AgentConfig{
+		Model:         &stubModel{content: "ok"},
+		Tools:         []toolkit.Tool{newSleepTool()},
+		ToolTimeout:   20 * time.Millisecond,
+		ToolAuditSink: sink,
+		ToolAfterHooks: []func(ctx context.Context, toolName string, args map[string]interface{}, result interface{}) error{
+			func(ctx context.Context, toolName string, args map[string]interface{}, result interface{}) error {
+				hookResult = result
+				return nil
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	start := time.Now()
+	result, err := ag.tools[0].Execute("slow_sleep", json.RawMessage(`{"millis": 500}`))
+	if err != nil {
+		t.Fatalf("Expected the timeout as the tool result, got error %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 300*time.Millisecond {
+		t.Errorf("Expected the call to return at the deadline, took %s", elapsed)
+	}
+
+	toolResult, ok := toolkit.AsToolResult(result)
+	if !ok {
+		t.Fatalf("Expected a ToolResult, got %#v", result)
+	}
+	timeoutErr, ok := toolResult.Data.(*ToolTimeoutError)
+	if !ok || timeoutErr.Method != "slow_sleep" || timeoutErr.Timeout != 20*time.Millisecond {
+		t.Fatalf("Expected a ToolTimeoutError in the result data, got %#v", toolResult.Data)
+	}
+	if !errors.Is(timeoutErr, context.DeadlineExceeded) {
+		t.Error("Expected ToolTimeoutError to match context.DeadlineExceeded")
+	}
+	if toolResult.Content == "" {
+		t.Error("Expected model-facing content describing the timeout")
+	}
+	if hookResult == nil {
+		t.Error("Expected after hooks to see the timeout result")
+	}
+	if len(sink.executions) != 1 || sink.executions[0].Error != timeoutErr.Error() {
+		t.Errorf("Expected the audit record to hold the timeout, got %+v", sink.executions)
+	}
+
+	// Calls that finish in time are unaffected
+	result, err = ag.tools[0].Execute("slow_sleep", json.RawMessage(`{"millis": 1}`))
+	if err != nil || result != "awake" {
+		t.Errorf("Expected a normal result, got %v, %v", result, err)
+	}
+}
+
+func TestToolTimeoutOverrides(t *testing.T) {
+	stopped := make(chan error, 1)
+	ctxTool := &contextSleepTool{Toolkit: toolkit.NewToolkit(), stopped: stopped}
+	ctxTool.Name = "waiter"
+	ctxTool.Register("wait", "Waits", ctxTool, func(params sleepParams) (interface{}, error) { return nil, nil }, sleepParams{})
+
+	ag, err := NewAgentWithOptions(AgentConfig{
+		Model:       &stubModel{content: "ok"},
+		Tools:       []toolkit.Tool{newSleepTool(), ctxTool},
+		ToolTimeout: time.Hour,
+	}, WithToolTimeout("slow", 0), WithToolTimeout("waiter", 20*time.Millisecond))
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	// Zero disables the limit for the tool
+	if result, err := ag.tools[0].Execute("slow_sleep", json.RawMessage(`{"millis": 1}`)); err != nil || result != "awake" {
+		t.Errorf("Expected a normal result, got %v, %v", result, err)
+	}
+
+	result, err := ag.tools[1].Execute("waiter_wait", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("Expected the timeout as the tool result, got error %v", err)
+	}
+	if toolResult, ok := toolkit.AsToolResult(result); !ok {
+		t.Errorf("Expected a ToolResult, got %#v", result)
+	} else if _, ok := toolResult.Data.(*ToolTimeoutError); !ok {
+		t.Errorf("Expected a ToolTimeoutError, got %#v", toolResult.Data)
+	}
+
+	// Context tools are cancelled at the deadline rather than left running
+	select {
+	case err := <-stopped:
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("Expected the tool context to hit its deadline, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the context tool to be cancelled")
+	}
+}
+
+func TestToolTimeoutRecoversPanics(t *testing.T) {
+	tk := toolkit.NewToolkit()
+	tk.Name = "broken"
+	tk.Register("run", "Panics", &tk, func(params sleepParams) (interface{}, error) {
+		panic("boom")
+	}, sleepParams{})
+
+	wrapper := &ToolWrapper{Tool: &tk, agent: &Agent{ctx: context.Background(), toolTimeout: time.Second}}
+	_, err := wrapper.Execute("broken_run", json.RawMessage(`{}`))
+
+	var toolErr *ToolExecutionError
+	if !errors.As(err, &toolErr) {
+		t.Fatalf("Expected a ToolExecutionError, got %#v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package agent
+
+import (
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// RecordRun runs prompt like Run and returns, along with the response, a
+// transcript of the run: every request to the model with its response and the
+// tools it ran. Save it with RunTranscript.Save and play it back with
+// models.NewReplayModel to test the agent without a real model:
+//
+//	transcript, _, _ := ag.RecordRun("What is the weather in Paris?")
+//	_ = transcript.Save("testdata/weather.json")
+//
+//	// later, in a test
+//	transcript, _ := models.LoadRunTranscript("testdata/weather.json")
+//	resp, _ := ag.Run(transcript.Input, agent.WithModelOverride(models.NewReplayModel(transcript)))
+//
+// Only calls to the run's model are recorded, not those to the output or parser model.
+func (a *Agent) RecordRun(prompt string, opts ...interface{}) (*models.RunTranscript, models.RunResponse, error) {
+	model := applyRunOptions(opts).ModelOverride
+	if model == nil {
+		model = a.model
+	}
+	recorder := models.NewRecordingModel(model)
+
+	createdAt := time.Now()
+	resp, err := a.Run(prompt, append(append([]interface{}(nil), opts...), WithModelOverride(recorder))...)
+
+	transcript := &models.RunTranscript{
+		Agent:     a.name,
+		Model:     model.GetID(),
+		Input:     prompt,
+		Calls:     recorder.Calls(),
+		Output:    resp.TextContent,
+		CreatedAt: createdAt,
+	}
+	if err != nil {
+		transcript.Error = err.Error()
+	}
+	return transcript, resp, err
+}
@@ -0,0 +1,71 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func TestRecordRunReplaysWithoutTheModel(t *testing.T) {
+	lookup := tools.NewToolFromFunction(func(ctx context.Context, id string) (string, error) {
+		return "order " + id + " shipped", nil
+	}, "Look up an order")
+
+	model := &toolCallingModel{args: `{"arg0":"A-1"}`}
+	ag, err := NewAgent(AgentConfig{
+		Name:  "support",
+		Model: model,
+		Tools: []toolkit.Tool{lookup},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	transcript, resp, err := ag.RecordRun("Where is my order?")
+	if err != nil {
+		t.Fatalf("RecordRun failed: %v", err)
+	}
+	if transcript.Output != resp.TextContent || transcript.Input != "Where is my order?" {
+		t.Errorf("Unexpected transcript: %+v", transcript)
+	}
+	if len(transcript.Calls) != 1 {
+		t.Fatalf("Expected 1 model call, got %d", len(transcript.Calls))
+	}
+	call := transcript.Calls[0]
+	if len(call.Messages) == 0 || call.Response == nil || call.Response.Content != "order A-1 shipped" {
+		t.Errorf("Unexpected call: %+v", call)
+	}
+	if len(call.ToolCalls) != 1 || call.ToolCalls[0].Result != "order A-1 shipped" {
+		t.Errorf("Expected the tool call to be recorded, got %+v", call.ToolCalls)
+	}
+
+	path := filepath.Join(t.TempDir(), "run.json")
+	if err := transcript.Save(path); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	loaded, err := models.LoadRunTranscript(path)
+	if err != nil {
+		t.Fatalf("LoadRunTranscript failed: %v", err)
+	}
+
+	replay := models.NewReplayModel(loaded)
+	replayed, err := ag.Run(loaded.Input, WithModelOverride(replay))
+	if err != nil {
+		t.Fatalf("Replayed run failed: %v", err)
+	}
+	if replayed.TextContent != transcript.Output {
+		t.Errorf("Expected replayed output %q, got %q", transcript.Output, replayed.TextContent)
+	}
+	if model.calls != 1 {
+		t.Errorf("Expected the replay not to call the real model, got %d calls", model.calls)
+	}
+
+	if _, err := ag.Run(loaded.Input, WithModelOverride(replay)); !errors.Is(err, models.ErrReplayExhausted) {
+		t.Errorf("Expected ErrReplayExhausted, got %v", err)
+	}
+}
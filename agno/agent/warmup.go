@@ -0,0 +1,49 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// pinger is implemented by models and storages that can check they are
+// reachable, e.g. OllamaChat, OpenAIChat and SqliteStorage
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// WarmUp makes a cheap call (a heartbeat or model listing) to the agent's
+// models and storage, so the connections, DNS lookups and TLS handshakes are
+// done before real traffic. Call it once at startup, e.g. before an AgentOS
+// starts serving. Dependencies that cannot be pinged are skipped; the errors
+// of those that fail are joined.
+func (a *Agent) WarmUp(ctx context.Context) error {
+	var errs []error
+	seen := make(map[interface{}]bool)
+	for _, dep := range []struct {
+		name      string
+		component interface{}
+	}{
+		{"model", a.model},
+		{"output model", a.outputModel},
+		{"parser model", a.parserModel},
+		{"storage", a.db},
+	} {
+		p, ok := dep.component.(pinger)
+		if !ok {
+			continue
+		}
+		// The output or parser model is often the main model
+		if reflect.TypeOf(p).Comparable() {
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+		}
+		if err := p.Ping(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("warm up %s: %w", dep.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
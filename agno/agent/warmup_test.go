@@ -0,0 +1,50 @@
+package agent
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// pingModel counts pings
+type pingModel struct {
+	stubModel
+	pings int
+	err   error
+}
+
+func (m *pingModel) Ping(ctx context.Context) error {
+	m.pings++
+	return m.err
+}
+
+func TestWarmUp(t *testing.T) {
+	model := &pingModel{}
+	ag, err := NewAgent(AgentConfig{Model: model, OutputModel: model})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	if err := ag.WarmUp(context.Background()); err != nil {
+		t.Fatalf("WarmUp failed: %v", err)
+	}
+	if model.pings != 1 {
+		t.Errorf("Expected a shared model to be pinged once, got %d", model.pings)
+	}
+
+	model.err = errors.New("connection refused")
+	err = ag.WarmUp(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "warm up model") {
+		t.Errorf("Expected a model warm up error, got %v", err)
+	}
+
+	// Models that cannot be pinged are skipped
+	plain, err := NewAgent(AgentConfig{Model: &stubModel{}})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+	if err := plain.WarmUp(context.Background()); err != nil {
+		t.Errorf("Expected no error without pingable dependencies, got %v", err)
+	}
+}
@@ -0,0 +1,144 @@
+// Package htmltext extracts the readable text of HTML pages. It is shared by
+// the web knowledge loader and WebFetchTool.
+package htmltext
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Extract returns the title and the main text of an HTML page. The text
+// comes from <main>, <article> or role="main" when present, else <body>,
+// with one paragraph per block element. Scripts, styles, navigation, headers
+// and footers are dropped.
+func Extract(root *html.Node) (string, string) {
+	title := strings.TrimSpace(nodeText(findNode(root, func(n *html.Node) bool { return n.Data == "title" })))
+
+	content := findNode(root, func(n *html.Node) bool {
+		return n.Data == "main" || attr(n, "role") == "main"
+	})
+	if content == nil {
+		content = findNode(root, func(n *html.Node) bool { return n.Data == "article" })
+	}
+	if content == nil {
+		content = findNode(root, func(n *html.Node) bool { return n.Data == "body" })
+	}
+	if content == nil {
+		content = root
+	}
+
+	if title == "" {
+		title = strings.TrimSpace(nodeText(findNode(content, func(n *html.Node) bool { return n.Data == "h1" })))
+	}
+
+	var sb strings.Builder
+	writeText(content, &sb, false)
+
+	// Collapse the blank lines left by nested blocks into paragraph breaks
+	var paragraphs []string
+	for _, paragraph := range strings.Split(sb.String(), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			paragraphs = append(paragraphs, paragraph)
+		}
+	}
+	return title, strings.Join(paragraphs, "\n\n")
+}
+
+// skippedElements never hold page content
+var skippedElements = map[string]bool{
+	"script": true, "style": true, "noscript": true, "template": true, "svg": true, "iframe": true,
+	"nav": true, "header": true, "footer": true, "aside": true, "form": true, "button": true,
+}
+
+// blockElements start a new paragraph
+var blockElements = map[string]bool{
+	"p": true, "div": true, "section": true, "article": true, "main": true, "blockquote": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+	"ul": true, "ol": true, "li": true, "dl": true, "dt": true, "dd": true,
+	"table": true, "tr": true, "pre": true, "figure": true, "figcaption": true, "hr": true,
+}
+
+// writeText writes the visible text below n, with a blank line around
+// block elements and whitespace preserved inside <pre>
+func writeText(n *html.Node, sb *strings.Builder, pre bool) {
+	switch n.Type {
+	case html.TextNode:
+		if pre {
+			sb.WriteString(n.Data)
+			return
+		}
+		if text := strings.Join(strings.Fields(n.Data), " "); text != "" {
+			// Keep a space between adjacent inline texts
+			if s := sb.String(); s != "" && !strings.HasSuffix(s, "\n") && !strings.HasSuffix(s, " ") &&
+				(n.Data[0] == ' ' || n.Data[0] == '\n' || n.Data[0] == '\t') {
+				sb.WriteString(" ")
+			}
+			sb.WriteString(text)
+			if last := n.Data[len(n.Data)-1]; last == ' ' || last == '\n' || last == '\t' {
+				sb.WriteString(" ")
+			}
+		}
+		return
+	case html.ElementNode:
+		if skippedElements[n.Data] || attr(n, "aria-hidden") == "true" || attr(n, "role") == "navigation" {
+			return
+		}
+		if n.Data == "br" {
+			sb.WriteString("\n")
+			return
+		}
+	}
+
+	block := n.Type == html.ElementNode && blockElements[n.Data]
+	if block {
+		sb.WriteString("\n\n")
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		writeText(c, sb, pre || n.Data == "pre")
+	}
+	if block {
+		sb.WriteString("\n\n")
+	}
+}
+
+// findNode returns the first element below n matching match
+func findNode(n *html.Node, match func(*html.Node) bool) *html.Node {
+	if n == nil {
+		return nil
+	}
+	if n.Type == html.ElementNode && match(n) {
+		return n
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if found := findNode(c, match); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// nodeText returns the text below n
+func nodeText(n *html.Node) string {
+	if n == nil {
+		return ""
+	}
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+	var sb strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		sb.WriteString(nodeText(c))
+	}
+	return strings.Join(strings.Fields(sb.String()), " ")
+}
+
+// attr returns the value of an attribute of n
+func attr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
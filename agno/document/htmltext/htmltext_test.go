@@ -0,0 +1,39 @@
+package htmltext
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/net/html"
+)
+
+const docsPage = `<html><head><title>Install Guide</title><style>body{}</style></head>
+<body>
+<nav><a href="/">Home</a> | <a href="/docs">Docs</a></nav>
+<main>
+<h1>Installing</h1>
+<p>Run the <code>installer</code> and
+follow the prompts.</p>
+<pre>make install
+make test</pre>
+<script>track()</script>
+<ul><li>Linux</li><li>macOS</li></ul>
+</main>
+<footer>Copyright</footer>
+</body></html>`
+
+func TestExtract(t *testing.T) {
+	root, err := html.Parse(strings.NewReader(docsPage))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	title, text := Extract(root)
+	if title != "Install Guide" {
+		t.Errorf("title = %q", title)
+	}
+	want := "Installing\n\nRun the installer and follow the prompts.\n\nmake install\nmake test\n\nLinux\n\nmacOS"
+	if text != want {
+		t.Errorf("text = %q, want %q", text, want)
+	}
+}
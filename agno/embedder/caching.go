@@ -0,0 +1,173 @@
+package embedder
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// CachingEmbedder wraps an Embedder and caches embeddings in memory, so
+// repeated texts (e.g. the same search query across agent runs) are only
+// sent to the provider once. It is safe for concurrent use.
+type CachingEmbedder struct {
+	Embedder
+	MaxSize int           // Maximum cached embeddings; the least recently used is evicted (default 1000)
+	TTL     time.Duration // How long an embedding stays cached; 0 keeps it until evicted
+
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // Front is the most recently used
+	hits    int64
+	misses  int64
+	now     func() time.Time
+}
+
+// CacheStats reports how effective the cache of a CachingEmbedder is
+type CacheStats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+	Size   int   `json:"size"`
+}
+
+type cacheEntry struct {
+	key       string
+	embedding []float64
+	expiresAt time.Time
+}
+
+// NewCachingEmbedder creates a caching wrapper around inner
+func NewCachingEmbedder(inner Embedder, options ...func(*CachingEmbedder)) *CachingEmbedder {
+	embedder := &CachingEmbedder{
+		Embedder: inner,
+		MaxSize:  1000,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		now:      time.Now,
+	}
+
+	// Apply options
+	for _, option := range options {
+		option(embedder)
+	}
+
+	return embedder
+}
+
+// WithCacheSize configures the maximum number of cached embeddings
+func WithCacheSize(size int) func(*CachingEmbedder) {
+	return func(e *CachingEmbedder) {
+		e.MaxSize = size
+	}
+}
+
+// WithCacheTTL configures how long an embedding stays cached
+func WithCacheTTL(ttl time.Duration) func(*CachingEmbedder) {
+	return func(e *CachingEmbedder) {
+		e.TTL = ttl
+	}
+}
+
+// GetEmbedding returns the cached embedding for text, calling the wrapped
+// embedder only on a miss
+func (c *CachingEmbedder) GetEmbedding(text string) ([]float64, error) {
+	key := cacheKey(text)
+	if embedding, ok := c.lookup(key); ok {
+		return embedding, nil
+	}
+
+	embedding, err := c.Embedder.GetEmbedding(text)
+	if err != nil {
+		return nil, err
+	}
+	c.store(key, embedding)
+	return embedding, nil
+}
+
+// GetEmbeddingAndUsage returns the cached embedding for text with usage
+// marked as cached, calling the wrapped embedder only on a miss
+func (c *CachingEmbedder) GetEmbeddingAndUsage(text string) ([]float64, map[string]interface{}, error) {
+	key := cacheKey(text)
+	if embedding, ok := c.lookup(key); ok {
+		return embedding, map[string]interface{}{"model": c.GetID(), "cached": true}, nil
+	}
+
+	embedding, usage, err := c.Embedder.GetEmbeddingAndUsage(text)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.store(key, embedding)
+	return embedding, usage, nil
+}
+
+// CacheStats returns the hits and misses since the embedder was created
+func (c *CachingEmbedder) CacheStats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return CacheStats{Hits: c.hits, Misses: c.misses, Size: c.order.Len()}
+}
+
+// ClearCache removes all cached embeddings. Stats are kept.
+func (c *CachingEmbedder) ClearCache() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]*list.Element)
+	c.order.Init()
+}
+
+// lookup returns a copy of the cached embedding for key and records a hit or miss
+func (c *CachingEmbedder) lookup(key string) ([]float64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if ok {
+		entry := elem.Value.(*cacheEntry)
+		if entry.expiresAt.IsZero() || c.now().Before(entry.expiresAt) {
+			c.order.MoveToFront(elem)
+			c.hits++
+			return append([]float64(nil), entry.embedding...), true
+		}
+		c.remove(elem)
+	}
+	c.misses++
+	return nil, false
+}
+
+// store caches a copy of embedding under key, evicting the least recently used entries
+func (c *CachingEmbedder) store(key string, embedding []float64) {
+	if c.MaxSize <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := &cacheEntry{key: key, embedding: append([]float64(nil), embedding...)}
+	if c.TTL > 0 {
+		entry.expiresAt = c.now().Add(c.TTL)
+	}
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+
+	for c.order.Len() > c.MaxSize {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *CachingEmbedder) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*cacheEntry).key)
+}
+
+// cacheKey hashes text so long documents are not kept as map keys
+func cacheKey(text string) string {
+	sum := sha256.Sum256([]byte(text))
+	return hex.EncodeToString(sum[:])
+}
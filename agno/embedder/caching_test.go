@@ -0,0 +1,87 @@
+package embedder
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// countingEmbedder counts the calls that reach the provider
+type countingEmbedder struct {
+	*MockEmbedder
+	mu    sync.Mutex
+	calls int
+}
+
+func (e *countingEmbedder) GetEmbedding(text string) ([]float64, error) {
+	e.mu.Lock()
+	e.calls++
+	e.mu.Unlock()
+	return e.MockEmbedder.GetEmbedding(text)
+}
+
+func TestCachingEmbedder(t *testing.T) {
+	inner := &countingEmbedder{MockEmbedder: NewMockEmbedder(8)}
+	cache := NewCachingEmbedder(inner, WithCacheSize(2))
+
+	first, _ := cache.GetEmbedding("query")
+	first[0] = 42 // callers must not be able to corrupt the cache
+	second, err := cache.GetEmbedding("query")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if inner.calls != 1 || second[0] == 42 {
+		t.Fatalf("Expected one provider call and an unmodified cached copy, got %d calls", inner.calls)
+	}
+
+	cache.GetEmbedding("b")
+	cache.GetEmbedding("c") // evicts "query"
+	cache.GetEmbedding("query")
+	if inner.calls != 4 {
+		t.Fatalf("Expected the least recently used entry to be evicted, got %d calls", inner.calls)
+	}
+
+	stats := cache.CacheStats()
+	if stats.Hits != 1 || stats.Misses != 4 || stats.Size != 2 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+	if cache.GetDimensions() != 8 || cache.GetID() != "mock-embedder" {
+		t.Fatalf("Expected metadata of the wrapped embedder, got %d %s", cache.GetDimensions(), cache.GetID())
+	}
+}
+
+func TestCachingEmbedderTTL(t *testing.T) {
+	inner := &countingEmbedder{MockEmbedder: NewMockEmbedder(8)}
+	cache := NewCachingEmbedder(inner, WithCacheTTL(time.Minute))
+	now := time.Now()
+	cache.now = func() time.Time { return now }
+
+	cache.GetEmbedding("query")
+	now = now.Add(30 * time.Second)
+	cache.GetEmbedding("query")
+	now = now.Add(time.Minute)
+	cache.GetEmbedding("query")
+
+	if inner.calls != 2 {
+		t.Fatalf("Expected the entry to expire after the TTL, got %d calls", inner.calls)
+	}
+}
+
+func TestCachingEmbedderConcurrent(t *testing.T) {
+	inner := &countingEmbedder{MockEmbedder: NewMockEmbedder(8)}
+	cache := NewCachingEmbedder(inner, WithCacheSize(5))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cache.GetEmbedding(string(rune('a' + i%10)))
+		}(i)
+	}
+	wg.Wait()
+
+	if stats := cache.CacheStats(); stats.Hits+stats.Misses != 50 || stats.Size > 5 {
+		t.Fatalf("Unexpected stats: %+v", stats)
+	}
+}
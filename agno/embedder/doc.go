@@ -5,7 +5,9 @@ package embedder
 //
 // - OpenAIEmbedder: Utiliza a API da OpenAI para gerar embeddings
 // - OllamaEmbedder: Utiliza Ollama (local) para gerar embeddings
+// - GeminiEmbedder: Uses the Google Generative Language API to generate embeddings
 // - MockEmbedder: Embedder mock para testes
+// - CachingEmbedder: Caches the embeddings of any other embedder in memory
 //
 // Exemplo de uso:
 //
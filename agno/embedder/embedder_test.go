@@ -1,7 +1,13 @@
 package embedder
 
 import (
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 )
 
@@ -152,3 +158,145 @@ func TestOpenAIEmbedderErrors(t *testing.T) {
 		t.Fatalf("Expected ErrEmptyText, got: %v", err)
 	}
 }
+
+func TestOpenAIEmbedderDimensions(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []func(*OpenAIEmbedder)
+		want    int
+	}{
+		{"default", nil, 1536},
+		{"large", []func(*OpenAIEmbedder){WithModel("text-embedding-3-large")}, 3072},
+		{"override after model", []func(*OpenAIEmbedder){WithModel("text-embedding-3-large"), WithDimensions(256)}, 256},
+		{"override before model", []func(*OpenAIEmbedder){WithDimensions(256), WithModel("text-embedding-3-large")}, 256},
+	}
+
+	for _, tt := range tests {
+		if got := NewOpenAIEmbedder(tt.options...).GetDimensions(); got != tt.want {
+			t.Errorf("%s: expected %d dimensions, got: %d", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestOpenAIEmbedderTruncation(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req OpenAIEmbeddingRequest
+		json.NewDecoder(r.Body).Decode(&req)
+		received = req.Input
+		w.Write([]byte(`{"data":[{"embedding":[0.1,0.2]}]}`))
+	}))
+	defer server.Close()
+
+	text := "alpha beta gamma delta, epsilon"
+	if n := EstimateTokens(text); n != 10 {
+		t.Fatalf("Expected 10 estimated tokens, got: %d", n)
+	}
+
+	e := NewOpenAIEmbedder(WithAPIKey("fake-key"), WithBaseURL(server.URL), WithMaxTokens(3), WithTruncation(TruncateEnd))
+	if _, err := e.GetEmbedding(text); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if received != "alpha beta" {
+		t.Fatalf("Expected the beginning of the text, got: %q", received)
+	}
+
+	e.Truncation = TruncateStart
+	if _, err := e.GetEmbedding(text); err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if received != ", epsilon" {
+		t.Fatalf("Expected the end of the text, got: %q", received)
+	}
+
+	e.Truncation = TruncateError
+	received = ""
+	if _, err := e.GetEmbedding(text); !errors.Is(err, ErrInputTooLong) || received != "" {
+		t.Fatalf("Expected ErrInputTooLong without a request, got: %v", err)
+	}
+	if _, err := e.GetEmbedding("short text"); err != nil {
+		t.Fatalf("Expected text within the limit to pass, got: %v", err)
+	}
+}
+
+func TestEmbedderNormalize(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/embed" {
+			w.Write([]byte(`{"embeddings":[[3,4]]}`))
+			return
+		}
+		w.Write([]byte(`{"data":[{"embedding":[3,4]}]}`))
+	}))
+	defer server.Close()
+
+	openai := NewOpenAIEmbedder(WithAPIKey("fake-key"), WithBaseURL(server.URL), WithNormalize(true))
+	ollama := NewOllamaEmbedder(WithOllamaHost(server.URL), WithOllamaModel("test", 2), WithOllamaNormalize(true))
+	embedders := map[string]func(string) ([]float64, error){
+		"openai": openai.GetEmbedding,
+		"openai usage": func(text string) ([]float64, error) {
+			embedding, _, err := openai.GetEmbeddingAndUsage(text)
+			return embedding, err
+		},
+		"ollama": ollama.GetEmbedding,
+	}
+
+	for name, getEmbedding := range embedders {
+		embedding, err := getEmbedding("test")
+		if err != nil {
+			t.Fatalf("%s: expected no error, got: %v", name, err)
+		}
+		magnitude := math.Sqrt(embedding[0]*embedding[0] + embedding[1]*embedding[1])
+		if math.Abs(magnitude-1) > 1e-9 || math.Abs(embedding[0]-0.6) > 1e-9 {
+			t.Fatalf("%s: expected a unit vector, got %v (magnitude %f)", name, embedding, magnitude)
+		}
+	}
+
+	openai.Normalize = false
+	if embedding, _ := openai.GetEmbedding("test"); embedding[0] != 3 {
+		t.Fatalf("Expected the raw vector without normalization, got %v", embedding)
+	}
+}
+
+func TestGeminiEmbedder(t *testing.T) {
+	var received GeminiEmbeddingRequest
+	var path, apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, apiKey = r.URL.Path, r.Header.Get("x-goog-api-key")
+		json.NewDecoder(r.Body).Decode(&received)
+		if received.Content.Parts[0].Text == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"message":"invalid taskType"}}`))
+			return
+		}
+		w.Write([]byte(`{"embedding":{"values":[0.1,0.2,0.3]}}`))
+	}))
+	defer server.Close()
+
+	e := NewGeminiEmbedder(
+		WithGeminiAPIKey("fake-key"),
+		WithGeminiBaseURL(server.URL),
+		WithGeminiModel("models/text-embedding-004"),
+		WithGeminiTaskType(GeminiTaskRetrievalQuery),
+		WithGeminiDimensions(3),
+	)
+
+	embedding, err := e.GetEmbedding("hello")
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if len(embedding) != 3 || e.GetDimensions() != 3 || e.GetID() != "text-embedding-004" {
+		t.Fatalf("Unexpected embedding %v (dimensions %d, id %s)", embedding, e.GetDimensions(), e.GetID())
+	}
+	if path != "/models/text-embedding-004:embedContent" || apiKey != "fake-key" {
+		t.Fatalf("Unexpected request to %s with key %q", path, apiKey)
+	}
+	if received.Model != "models/text-embedding-004" || received.TaskType != GeminiTaskRetrievalQuery ||
+		received.OutputDimensionality == nil || *received.OutputDimensionality != 3 {
+		t.Fatalf("Unexpected request body: %+v", received)
+	}
+
+	_, err = e.GetEmbedding("fail")
+	if err == nil || !strings.Contains(err.Error(), "status 400") || !strings.Contains(err.Error(), "invalid taskType") {
+		t.Fatalf("Expected the API status and body in the error, got: %v", err)
+	}
+}
@@ -9,4 +9,5 @@ var (
 	ErrEmptyText        = errors.New("text cannot be empty")
 	ErrAPIKeyMissing    = errors.New("API key is required")
 	ErrInvalidResponse  = errors.New("invalid response from embedding service")
+	ErrInputTooLong     = errors.New("input exceeds the model's max tokens")
 )
@@ -0,0 +1,222 @@
+package embedder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// GeminiTaskType tells Gemini what the embedding will be used for, so it can
+// optimize the vector for that task
+type GeminiTaskType string
+
+const (
+	GeminiTaskRetrievalDocument  GeminiTaskType = "RETRIEVAL_DOCUMENT" // Documents stored in a vector DB
+	GeminiTaskRetrievalQuery     GeminiTaskType = "RETRIEVAL_QUERY"    // Search queries
+	GeminiTaskSemanticSimilarity GeminiTaskType = "SEMANTIC_SIMILARITY"
+	GeminiTaskClassification     GeminiTaskType = "CLASSIFICATION"
+	GeminiTaskClustering         GeminiTaskType = "CLUSTERING"
+)
+
+// GeminiEmbedder embedder using the Google Generative Language API
+type GeminiEmbedder struct {
+	BaseEmbedder
+	APIKey     string
+	BaseURL    string
+	Model      string
+	TaskType   GeminiTaskType
+	HTTPClient *http.Client
+	Timeout    time.Duration
+	Normalize  bool // L2-normalize returned embeddings
+
+	customDimensions bool // Set by WithGeminiDimensions, sent as outputDimensionality
+}
+
+// GeminiEmbeddingRequest request structure for Gemini
+type GeminiEmbeddingRequest struct {
+	Model                string             `json:"model"`
+	Content              GeminiEmbedContent `json:"content"`
+	TaskType             GeminiTaskType     `json:"taskType,omitempty"`
+	OutputDimensionality *int               `json:"outputDimensionality,omitempty"`
+}
+
+// GeminiEmbedContent is the text to embed
+type GeminiEmbedContent struct {
+	Parts []GeminiEmbedPart `json:"parts"`
+}
+
+// GeminiEmbedPart is a piece of the text to embed
+type GeminiEmbedPart struct {
+	Text string `json:"text"`
+}
+
+// GeminiEmbeddingResponse Gemini response structure
+type GeminiEmbeddingResponse struct {
+	Embedding struct {
+		Values []float64 `json:"values"`
+	} `json:"embedding"`
+}
+
+// NewGeminiEmbedder creates a new Gemini embedder
+func NewGeminiEmbedder(options ...func(*GeminiEmbedder)) *GeminiEmbedder {
+	embedder := &GeminiEmbedder{
+		BaseEmbedder: BaseEmbedder{
+			ID:         "text-embedding-004",
+			Dimensions: 768,
+		},
+		APIKey:     os.Getenv("GEMINI_API_KEY"),
+		BaseURL:    "https://generativelanguage.googleapis.com/v1beta",
+		Model:      "text-embedding-004",
+		HTTPClient: &http.Client{},
+		Timeout:    30 * time.Second,
+	}
+
+	// Apply options
+	for _, option := range options {
+		option(embedder)
+	}
+
+	// Configure timeout on HTTP client
+	embedder.HTTPClient.Timeout = embedder.Timeout
+
+	return embedder
+}
+
+// WithGeminiAPIKey configures the API key (default: GEMINI_API_KEY)
+func WithGeminiAPIKey(apiKey string) func(*GeminiEmbedder) {
+	return func(e *GeminiEmbedder) {
+		e.APIKey = apiKey
+	}
+}
+
+// WithGeminiModel configures the model
+func WithGeminiModel(model string) func(*GeminiEmbedder) {
+	return func(e *GeminiEmbedder) {
+		e.Model = strings.TrimPrefix(model, "models/")
+		e.ID = e.Model
+	}
+}
+
+// WithGeminiTaskType configures the task the embeddings are optimized for
+func WithGeminiTaskType(taskType GeminiTaskType) func(*GeminiEmbedder) {
+	return func(e *GeminiEmbedder) {
+		e.TaskType = taskType
+	}
+}
+
+// WithGeminiDimensions configures the output dimensionality, truncating the
+// model's default 768 dimensions
+func WithGeminiDimensions(dimensions int) func(*GeminiEmbedder) {
+	return func(e *GeminiEmbedder) {
+		e.Dimensions = dimensions
+		e.customDimensions = true
+	}
+}
+
+// WithGeminiBaseURL configures the base URL
+func WithGeminiBaseURL(baseURL string) func(*GeminiEmbedder) {
+	return func(e *GeminiEmbedder) {
+		e.BaseURL = baseURL
+	}
+}
+
+// WithGeminiTimeout configures the timeout
+func WithGeminiTimeout(timeout time.Duration) func(*GeminiEmbedder) {
+	return func(e *GeminiEmbedder) {
+		e.Timeout = timeout
+	}
+}
+
+// WithGeminiNormalize configures whether returned embeddings are L2-normalized
+func WithGeminiNormalize(normalize bool) func(*GeminiEmbedder) {
+	return func(e *GeminiEmbedder) {
+		e.Normalize = normalize
+	}
+}
+
+// GetEmbedding gets embedding for a text
+func (e *GeminiEmbedder) GetEmbedding(text string) ([]float64, error) {
+	if text == "" {
+		return nil, ErrEmptyText
+	}
+
+	if e.APIKey == "" {
+		return nil, ErrAPIKeyMissing
+	}
+
+	request := GeminiEmbeddingRequest{
+		Model:    "models/" + e.Model,
+		Content:  GeminiEmbedContent{Parts: []GeminiEmbedPart{{Text: text}}},
+		TaskType: e.TaskType,
+	}
+	if e.customDimensions {
+		request.OutputDimensionality = &e.Dimensions
+	}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/models/%s:embedContent", e.BaseURL, e.Model)
+	req, err := http.NewRequestWithContext(context.Background(), "POST", url, bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-goog-api-key", e.APIKey)
+
+	resp, err := e.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response GeminiEmbeddingResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	embedding := response.Embedding.Values
+	if len(embedding) == 0 {
+		return nil, ErrInvalidResponse
+	}
+
+	if e.Normalize {
+		embedding = NormalizeL2(embedding)
+	}
+
+	return embedding, nil
+}
+
+// GetEmbeddingAndUsage gets embedding and usage information
+func (e *GeminiEmbedder) GetEmbeddingAndUsage(text string) ([]float64, map[string]interface{}, error) {
+	embedding, err := e.GetEmbedding(text)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Gemini doesn't report token usage for embeddings
+	usage := map[string]interface{}{
+		"model":      e.Model,
+		"dimensions": len(embedding),
+	}
+
+	return embedding, usage, nil
+}
@@ -0,0 +1,21 @@
+package embedder
+
+import "math"
+
+// NormalizeL2 scales vector in place to unit length, so dot product and
+// cosine similarity rank results the same way. Zero vectors are left unchanged.
+func NormalizeL2(vector []float64) []float64 {
+	var sum float64
+	for _, v := range vector {
+		sum += v * v
+	}
+	if sum == 0 {
+		return vector
+	}
+
+	norm := math.Sqrt(sum)
+	for i := range vector {
+		vector[i] /= norm
+	}
+	return vector
+}
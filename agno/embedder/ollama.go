@@ -18,6 +18,9 @@ type OllamaEmbedder struct {
 	HTTPClient *http.Client
 	Timeout    time.Duration
 	Options    map[string]interface{}
+	MaxTokens  int
+	Truncation TruncationStrategy
+	Normalize  bool // L2-normalize returned embeddings
 }
 
 // OllamaEmbeddingRequest request structure for Ollama
@@ -44,6 +47,7 @@ func NewOllamaEmbedder(options ...func(*OllamaEmbedder)) *OllamaEmbedder {
 		Model:      "nomic-embed-text",
 		HTTPClient: &http.Client{},
 		Timeout:    60 * time.Second, // Embeddings can take longer
+		MaxTokens:  2048,             // Ollama's default context length
 	}
 
 	// Apply options
@@ -87,12 +91,38 @@ func WithOllamaOptions(options map[string]interface{}) func(*OllamaEmbedder) {
 	}
 }
 
+// WithOllamaMaxTokens configures the input limit used by truncation (default 2048)
+func WithOllamaMaxTokens(maxTokens int) func(*OllamaEmbedder) {
+	return func(e *OllamaEmbedder) {
+		e.MaxTokens = maxTokens
+	}
+}
+
+// WithOllamaTruncation configures how over-length input is handled
+func WithOllamaTruncation(strategy TruncationStrategy) func(*OllamaEmbedder) {
+	return func(e *OllamaEmbedder) {
+		e.Truncation = strategy
+	}
+}
+
+// WithOllamaNormalize configures whether returned embeddings are L2-normalized
+func WithOllamaNormalize(normalize bool) func(*OllamaEmbedder) {
+	return func(e *OllamaEmbedder) {
+		e.Normalize = normalize
+	}
+}
+
 // GetEmbedding gets embedding for a text
 func (e *OllamaEmbedder) GetEmbedding(text string) ([]float64, error) {
 	if text == "" {
 		return nil, ErrEmptyText
 	}
 
+	text, err := truncateText(text, e.MaxTokens, e.Truncation)
+	if err != nil {
+		return nil, err
+	}
+
 	request := OllamaEmbeddingRequest{
 		Model:   e.Model,
 		Input:   text,
@@ -146,6 +176,10 @@ func (e *OllamaEmbedder) GetEmbedding(text string) ([]float64, error) {
 		return nil, fmt.Errorf("%w: expected %d, got %d", ErrInvalidDimension, e.Dimensions, len(embedding))
 	}
 
+	if e.Normalize {
+		embedding = NormalizeL2(embedding)
+	}
+
 	return embedding, nil
 }
 
@@ -22,6 +22,18 @@ type OpenAIEmbedder struct {
 	User         string
 	HTTPClient   *http.Client
 	Timeout      time.Duration
+	MaxTokens    int
+	Truncation   TruncationStrategy
+	Normalize    bool // L2-normalize returned embeddings
+
+	customDimensions bool // Set by WithDimensions, overrides the model default
+}
+
+// openAIModelDimensions holds the default output dimensions of the OpenAI embedding models
+var openAIModelDimensions = map[string]int{
+	"text-embedding-3-small": 1536,
+	"text-embedding-3-large": 3072,
+	"text-embedding-ada-002": 1536,
 }
 
 // OpenAIEmbeddingRequest request structure for OpenAI
@@ -60,6 +72,7 @@ func NewOpenAIEmbedder(options ...func(*OpenAIEmbedder)) *OpenAIEmbedder {
 		Model:      "text-embedding-3-small",
 		HTTPClient: &http.Client{},
 		Timeout:    30 * time.Second,
+		MaxTokens:  8191,
 	}
 
 	// Apply options
@@ -70,9 +83,11 @@ func NewOpenAIEmbedder(options ...func(*OpenAIEmbedder)) *OpenAIEmbedder {
 	// Configurar timeout no client HTTP
 	embedder.HTTPClient.Timeout = embedder.Timeout
 
-	// Adjust dimensions based on model
-	if embedder.Model == "text-embedding-3-large" {
-		embedder.Dimensions = 3072
+	// Size from the model unless WithDimensions asked for shortened embeddings
+	if !embedder.customDimensions {
+		if dimensions, ok := openAIModelDimensions[embedder.Model]; ok {
+			embedder.Dimensions = dimensions
+		}
 	}
 
 	return embedder
@@ -90,19 +105,15 @@ func WithModel(model string) func(*OpenAIEmbedder) {
 	return func(e *OpenAIEmbedder) {
 		e.Model = model
 		e.ID = model
-		// Adjust dimensions
-		if model == "text-embedding-3-large" {
-			e.Dimensions = 3072
-		} else {
-			e.Dimensions = 1536
-		}
 	}
 }
 
-// WithDimensions configures dimensions (only for text-embedding-3 models)
+// WithDimensions configures dimensions (only for text-embedding-3 models).
+// It takes precedence over the model default regardless of option order.
 func WithDimensions(dimensions int) func(*OpenAIEmbedder) {
 	return func(e *OpenAIEmbedder) {
 		e.Dimensions = dimensions
+		e.customDimensions = true
 	}
 }
 
@@ -127,6 +138,27 @@ func WithTimeout(timeout time.Duration) func(*OpenAIEmbedder) {
 	}
 }
 
+// WithMaxTokens configures the input limit used by truncation (default 8191)
+func WithMaxTokens(maxTokens int) func(*OpenAIEmbedder) {
+	return func(e *OpenAIEmbedder) {
+		e.MaxTokens = maxTokens
+	}
+}
+
+// WithTruncation configures how over-length input is handled
+func WithTruncation(strategy TruncationStrategy) func(*OpenAIEmbedder) {
+	return func(e *OpenAIEmbedder) {
+		e.Truncation = strategy
+	}
+}
+
+// WithNormalize configures whether returned embeddings are L2-normalized
+func WithNormalize(normalize bool) func(*OpenAIEmbedder) {
+	return func(e *OpenAIEmbedder) {
+		e.Normalize = normalize
+	}
+}
+
 // GetEmbedding gets embedding for a text
 func (e *OpenAIEmbedder) GetEmbedding(text string) ([]float64, error) {
 	if text == "" {
@@ -137,6 +169,11 @@ func (e *OpenAIEmbedder) GetEmbedding(text string) ([]float64, error) {
 		return nil, ErrAPIKeyMissing
 	}
 
+	text, err := truncateText(text, e.MaxTokens, e.Truncation)
+	if err != nil {
+		return nil, err
+	}
+
 	request := OpenAIEmbeddingRequest{
 		Input:          text,
 		Model:          e.Model,
@@ -190,11 +227,16 @@ func (e *OpenAIEmbedder) GetEmbedding(text string) ([]float64, error) {
 		return nil, ErrInvalidResponse
 	}
 
-	return response.Data[0].Embedding, nil
+	return e.normalize(response.Data[0].Embedding), nil
 }
 
 // GetEmbeddingAndUsage gets embedding and usage information
 func (e *OpenAIEmbedder) GetEmbeddingAndUsage(text string) ([]float64, map[string]interface{}, error) {
+	text, err := truncateText(text, e.MaxTokens, e.Truncation)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	request := OpenAIEmbeddingRequest{
 		Input:          text,
 		Model:          e.Model,
@@ -254,5 +296,12 @@ func (e *OpenAIEmbedder) GetEmbeddingAndUsage(text string) ([]float64, map[strin
 		"model":         response.Model,
 	}
 
-	return response.Data[0].Embedding, usage, nil
+	return e.normalize(response.Data[0].Embedding), usage, nil
+}
+
+func (e *OpenAIEmbedder) normalize(embedding []float64) []float64 {
+	if e.Normalize {
+		return NormalizeL2(embedding)
+	}
+	return embedding
 }
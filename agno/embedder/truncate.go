@@ -0,0 +1,76 @@
+package embedder
+
+import (
+	"fmt"
+	"regexp"
+	"unicode/utf8"
+)
+
+// TruncationStrategy defines what an embedder does with input longer than the model's max tokens
+type TruncationStrategy int
+
+const (
+	// TruncateNone sends the text unchanged and lets the embedding service decide (default)
+	TruncateNone TruncationStrategy = iota
+	// TruncateEnd keeps the beginning of the text and drops the tokens past the limit
+	TruncateEnd
+	// TruncateStart keeps the end of the text and drops the tokens before it
+	TruncateStart
+	// TruncateError returns ErrInputTooLong instead of calling the embedding service
+	TruncateError
+)
+
+// approxCharsPerToken is the average length of a BPE token for English text
+const approxCharsPerToken = 4
+
+// approxTokenPattern splits text into words, numbers and single punctuation marks
+var approxTokenPattern = regexp.MustCompile(`\p{L}+|\p{N}+|[^\s\p{L}\p{N}]`)
+
+// EstimateTokens approximates the number of tokens in text without a model
+// tokenizer: punctuation counts as one token and words count one token per
+// four characters, which slightly overestimates BPE tokenizers on English text.
+func EstimateTokens(text string) int {
+	return len(approxTokenSpans(text))
+}
+
+// approxTokenSpans returns the byte offsets of the approximate tokens in text
+func approxTokenSpans(text string) [][2]int {
+	var spans [][2]int
+	for _, match := range approxTokenPattern.FindAllStringIndex(text, -1) {
+		start, end := match[0], match[1]
+		for start < end {
+			next, runes := start, 0
+			for next < end && runes < approxCharsPerToken {
+				_, size := utf8.DecodeRuneInString(text[next:])
+				next += size
+				runes++
+			}
+			spans = append(spans, [2]int{start, next})
+			start = next
+		}
+	}
+	return spans
+}
+
+// truncateText applies strategy to text when it exceeds maxTokens
+func truncateText(text string, maxTokens int, strategy TruncationStrategy) (string, error) {
+	if strategy == TruncateNone || maxTokens <= 0 {
+		return text, nil
+	}
+
+	spans := approxTokenSpans(text)
+	if len(spans) <= maxTokens {
+		return text, nil
+	}
+
+	switch strategy {
+	case TruncateEnd:
+		return text[:spans[maxTokens-1][1]], nil
+	case TruncateStart:
+		return text[spans[len(spans)-maxTokens][0]:], nil
+	case TruncateError:
+		return "", fmt.Errorf("%w: ~%d tokens, max %d", ErrInputTooLong, len(spans), maxTokens)
+	default:
+		return "", fmt.Errorf("unknown truncation strategy: %d", strategy)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/devalexandre/agno-golang/agno/document"
@@ -15,7 +16,12 @@ import (
 	"github.com/devalexandre/agno-golang/agno/vectordb"
 )
 
-// Knowledge is the base interface for knowledge bases
+// Knowledge is the base interface for knowledge bases.
+//
+// BaseKnowledge has further methods, such as SearchWithFilters, Upsert,
+// ListDocuments, GetDocument, DeleteDocuments and CopyTo. They are
+// intentionally not part of the Knowledge interface to keep backwards
+// compatibility; callers check for them with a type assertion.
 type Knowledge interface {
 	// Load loads documents into the knowledge base
 	Load(ctx context.Context, recreate bool) error
@@ -35,6 +41,12 @@ type Knowledge interface {
 	// GetCount returns the number of documents in the base
 	GetCount(ctx context.Context) (int64, error)
 
+	// DeleteDocument removes a single document by ID
+	DeleteDocument(ctx context.Context, id string) error
+
+	// DeleteByMetadata removes the documents whose metadata matches filters
+	DeleteByMetadata(ctx context.Context, filters map[string]interface{}) error
+
 	// GetInfo returns information about the knowledge base
 	GetInfo() KnowledgeInfo
 
@@ -66,18 +78,25 @@ type SearchFilters struct {
 
 // BaseKnowledge base implementation for knowledge bases
 type BaseKnowledge struct {
-	Name         string
-	VectorDB     VectorDB
-	ContentsDB   storage.Storage // Database for storing knowledge content metadata
+	Name       string
+	VectorDB   VectorDB
+	ContentsDB storage.Storage // Database for storing knowledge content metadata
+	// Embedder overrides the vector database embedder for this knowledge base.
+	// Documents and queries are both embedded with it.
 	Embedder     embedder.Embedder
+	Chunker      Chunker    // Splits loaded documents; nil keeps each loader's default chunking
+	UpsertMode   UpsertMode // How stored documents are handled on reload (default: insert every document)
 	NumDocuments int
 	Filters      *SearchFilters
 	Recreate     bool
 	Metadata     map[string]interface{}
+
+	// embedderValidated caches a successful ValidateEmbedder check
+	embedderValidated atomic.Bool
 }
 
 // NewBaseKnowledge creates a new BaseKnowledge instance
-func NewBaseKnowledge(name string, vectorDB VectorDB) *BaseKnowledge {
+func NewBaseKnowledge(name string, vectorDB VectorDB, options ...KnowledgeOption) *BaseKnowledge {
 	kb := &BaseKnowledge{
 		Name:         name,
 		VectorDB:     vectorDB,
@@ -105,6 +124,10 @@ func NewBaseKnowledge(name string, vectorDB VectorDB) *BaseKnowledge {
 	// If there's an error creating the DB, ContentsDB remains nil
 	// and knowledge endpoints will return 404 (which is fine)
 
+	for _, option := range options {
+		option(kb)
+	}
+
 	return kb
 }
 
@@ -347,7 +370,7 @@ func (k *BaseKnowledge) SearchDocuments(ctx context.Context, query string, numDo
 		return nil, fmt.Errorf("vector database not configured")
 	}
 
-	results, err := k.VectorDB.Search(ctx, query, numDocuments, filters)
+	results, err := k.search(ctx, query, numDocuments, filters)
 	if err != nil {
 		return nil, err
 	}
@@ -361,7 +384,9 @@ func (k *BaseKnowledge) SearchDocuments(ctx context.Context, query string, numDo
 	return docs, nil
 }
 
-// LoadDocuments loads documents into the knowledge base
+// LoadDocuments loads documents into the knowledge base. Each document's
+// content hash is stored under ContentHashKey, and unless recreate is set,
+// documents that are already stored are skipped according to the UpsertMode.
 func (k *BaseKnowledge) LoadDocuments(ctx context.Context, docs []document.Document, recreate bool) error {
 	if k.VectorDB == nil {
 		return fmt.Errorf("vector database not configured")
@@ -373,6 +398,7 @@ func (k *BaseKnowledge) LoadDocuments(ctx context.Context, docs []document.Docum
 			// Ignore error if doesn't exist
 			fmt.Printf("[KNOWLEDGE] Warning: Failed to drop VectorDB (may not exist): %v\n", err)
 		}
+		k.embedderValidated.Store(false)
 	}
 
 	// Create table if doesn't exist
@@ -381,6 +407,11 @@ func (k *BaseKnowledge) LoadDocuments(ctx context.Context, docs []document.Docum
 		// Continue - table might already exist
 	}
 
+	// Refuse to mix embedders in one collection
+	if err := k.recordEmbedder(ctx); err != nil {
+		return err
+	}
+
 	// Insert documents
 	if len(docs) > 0 {
 		fmt.Printf("[KNOWLEDGE] Inserting %d documents into VectorDB...\n", len(docs))
@@ -391,6 +422,26 @@ func (k *BaseKnowledge) LoadDocuments(ctx context.Context, docs []document.Docum
 			docPtrs[i] = &docs[i]
 		}
 
+		// Only re-embed what changed since the last load
+		setContentHashes(docPtrs)
+		if !recreate {
+			pending, err := k.pendingDocuments(ctx, docPtrs)
+			if err != nil {
+				return err
+			}
+			if skipped := len(docPtrs) - len(pending); skipped > 0 {
+				fmt.Printf("[KNOWLEDGE] Skipping %d unchanged documents\n", skipped)
+			}
+			if len(pending) == 0 {
+				return nil
+			}
+			docPtrs = pending
+		}
+
+		if err := k.embedDocuments(docPtrs); err != nil {
+			return err
+		}
+
 		// Optimize batch size based on dataset size
 		batchSize := 100 // Larger batches for better throughput
 		numWorkers := 10 // More workers for faster processing
@@ -427,7 +478,7 @@ func (k *BaseKnowledge) insertDocumentsSequential(ctx context.Context, docPtrs [
 		batch := docPtrs[i:end]
 		batchNum := (i / batchSize) + 1
 
-		if err := k.VectorDB.Insert(ctx, batch, nil); err != nil {
+		if err := k.writeDocuments(ctx, batch); err != nil {
 			return fmt.Errorf("failed to insert batch %d: %w", batchNum, err)
 		}
 
@@ -471,7 +522,7 @@ func (k *BaseKnowledge) insertDocumentsParallel(ctx context.Context, docPtrs []*
 	for range numWorkers {
 		wg.Go(func() {
 			for b := range batchChan {
-				if err := k.VectorDB.Insert(ctx, b.docs, nil); err != nil {
+				if err := k.writeDocuments(ctx, b.docs); err != nil {
 					select {
 					case errChan <- fmt.Errorf("batch %d failed: %w", b.num, err):
 					default:
@@ -551,11 +602,10 @@ func (k *BaseKnowledge) Search(ctx context.Context, query string, numDocuments i
 		return nil, fmt.Errorf("vector database not configured")
 	}
 
-	return k.VectorDB.Search(ctx, query, numDocuments, filters)
+	return k.search(ctx, query, numDocuments, filters)
 }
 
 // SearchWithFilters searches the knowledge base with additional per-query filters.
-// This is intentionally not part of the Knowledge interface to keep backwards compatibility.
 func (k *BaseKnowledge) SearchWithFilters(ctx context.Context, query string, numDocuments int, filters map[string]interface{}) ([]*SearchResult, error) {
 	if numDocuments <= 0 {
 		numDocuments = k.NumDocuments
@@ -578,7 +628,7 @@ func (k *BaseKnowledge) SearchWithFilters(ctx context.Context, query string, num
 		merged = nil
 	}
 
-	return k.VectorDB.Search(ctx, query, numDocuments, merged)
+	return k.search(ctx, query, numDocuments, merged)
 }
 
 // Add adds documents to the knowledge base
@@ -593,11 +643,18 @@ func (k *BaseKnowledge) Add(ctx context.Context, documents []document.Document)
 		docPtrs[i] = &documents[i]
 	}
 
+	if err := k.ValidateEmbedder(ctx); err != nil {
+		return err
+	}
+	setContentHashes(docPtrs)
+	if err := k.embedDocuments(docPtrs); err != nil {
+		return err
+	}
+
 	return k.VectorDB.Insert(ctx, docPtrs, nil)
 }
 
 // Upsert upserts documents into the knowledge base.
-// This is intentionally not part of the Knowledge interface to keep backwards compatibility.
 func (k *BaseKnowledge) Upsert(ctx context.Context, documents []document.Document) error {
 	if k.VectorDB == nil {
 		return fmt.Errorf("vector database not configured")
@@ -608,11 +665,18 @@ func (k *BaseKnowledge) Upsert(ctx context.Context, documents []document.Documen
 		docPtrs[i] = &documents[i]
 	}
 
+	if err := k.ValidateEmbedder(ctx); err != nil {
+		return err
+	}
+	setContentHashes(docPtrs)
+	if err := k.embedDocuments(docPtrs); err != nil {
+		return err
+	}
+
 	return k.VectorDB.Upsert(ctx, docPtrs, nil)
 }
 
 // UpsertDocument upserts a single document into the knowledge base.
-// This is intentionally not part of the Knowledge interface to keep backwards compatibility.
 func (k *BaseKnowledge) UpsertDocument(ctx context.Context, doc document.Document) error {
 	return k.Upsert(ctx, []document.Document{doc})
 }
@@ -659,10 +723,11 @@ func (k *BaseKnowledge) GetCount(ctx context.Context) (int64, error) {
 	return k.VectorDB.GetCount(ctx)
 }
 
-// SetEmbedder configures the embedder
+// SetEmbedder configures the embedder used by this knowledge base, overriding the
+// vector database embedder for both indexing and queries
 func (k *BaseKnowledge) SetEmbedder(emb embedder.Embedder) {
 	k.Embedder = emb
-	// Note: VectorDB implementation should handle embedder internally
+	k.embedderValidated.Store(false)
 }
 
 // GetEmbedder returns the configured embedder
@@ -0,0 +1,302 @@
+package knowledge
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+)
+
+// Chunk is a piece of a document produced by a Chunker
+type Chunk struct {
+	Content string `json:"content"`
+	Index   int    `json:"index"`
+}
+
+// Chunker splits document text into chunks before they are embedded
+type Chunker interface {
+	Chunk(text string) []Chunk
+}
+
+// KnowledgeOption configures the BaseKnowledge of a knowledge base
+type KnowledgeOption func(*BaseKnowledge)
+
+// WithChunker configures how loaded documents are split. Without it every
+// loader keeps its default chunking.
+func WithChunker(c Chunker) KnowledgeOption {
+	return func(k *BaseKnowledge) {
+		k.Chunker = c
+	}
+}
+
+// FixedSizeChunker packs words into chunks of at most Size characters. Each
+// chunk after the first repeats up to Overlap characters of trailing words
+// from the previous one. Whitespace is collapsed to single spaces.
+type FixedSizeChunker struct {
+	Size    int
+	Overlap int
+}
+
+// NewFixedSizeChunker creates a FixedSizeChunker
+func NewFixedSizeChunker(size, overlap int) *FixedSizeChunker {
+	return &FixedSizeChunker{Size: size, Overlap: overlap}
+}
+
+// Chunk splits text into fixed size chunks
+func (c *FixedSizeChunker) Chunk(text string) []Chunk {
+	words := strings.Fields(text)
+	if len(words) == 0 {
+		return nil
+	}
+	if c.Size <= 0 {
+		return newChunks([]string{strings.Join(words, " ")})
+	}
+
+	var contents []string
+	var current []string
+	length := 0
+	for _, word := range words {
+		if length > 0 && length+1+len(word) > c.Size {
+			contents = append(contents, strings.Join(current, " "))
+			current, length = overlapWords(current, c.Overlap, c.Size-len(word)-1)
+		}
+		if length > 0 {
+			length++
+		}
+		current = append(current, word)
+		length += len(word)
+	}
+	contents = append(contents, strings.Join(current, " "))
+
+	return newChunks(contents)
+}
+
+// overlapWords returns the trailing words of words that fit in overlap
+// characters, and leave room for limit characters in total
+func overlapWords(words []string, overlap, limit int) ([]string, int) {
+	if overlap > limit {
+		overlap = limit
+	}
+	length := 0
+	start := len(words)
+	for start > 0 {
+		next := length + len(words[start-1])
+		if length > 0 {
+			next++
+		}
+		if next > overlap {
+			break
+		}
+		length = next
+		start--
+	}
+	return append([]string(nil), words[start:]...), length
+}
+
+// SentenceChunker packs whole sentences into chunks of at most MaxSize
+// characters. Sentences longer than MaxSize are split on words.
+type SentenceChunker struct {
+	MaxSize int
+}
+
+// NewSentenceChunker creates a SentenceChunker
+func NewSentenceChunker(maxSize int) *SentenceChunker {
+	return &SentenceChunker{MaxSize: maxSize}
+}
+
+// Chunk splits text into chunks of whole sentences
+func (c *SentenceChunker) Chunk(text string) []Chunk {
+	var contents []string
+	var current strings.Builder
+	flush := func() {
+		if current.Len() > 0 {
+			contents = append(contents, current.String())
+			current.Reset()
+		}
+	}
+
+	for _, sentence := range splitSentences(text) {
+		if c.MaxSize > 0 && len(sentence) > c.MaxSize {
+			flush()
+			for _, chunk := range NewFixedSizeChunker(c.MaxSize, 0).Chunk(sentence) {
+				contents = append(contents, chunk.Content)
+			}
+			continue
+		}
+		if c.MaxSize > 0 && current.Len() > 0 && current.Len()+1+len(sentence) > c.MaxSize {
+			flush()
+		}
+		if current.Len() > 0 {
+			current.WriteString(" ")
+		}
+		current.WriteString(sentence)
+	}
+	flush()
+
+	return newChunks(contents)
+}
+
+// splitSentences splits text after '.', '!' or '?' followed by whitespace,
+// and at blank lines
+func splitSentences(text string) []string {
+	var sentences []string
+	add := func(s string) {
+		if s = strings.Join(strings.Fields(s), " "); s != "" {
+			sentences = append(sentences, s)
+		}
+	}
+
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		start := 0
+		for i := 0; i < len(paragraph); i++ {
+			switch paragraph[i] {
+			case '.', '!', '?':
+				if i+1 == len(paragraph) || unicode.IsSpace(rune(paragraph[i+1])) {
+					add(paragraph[start : i+1])
+					start = i + 1
+				}
+			}
+		}
+		add(paragraph[start:])
+	}
+	return sentences
+}
+
+// RecursiveChunker splits text on the first separator that occurs in it
+// (paragraphs, then lines, sentences and words by default), splitting pieces
+// that are still longer than Size with the next separator, and merges the
+// pieces back into chunks of at most Size characters. Each chunk after the
+// first repeats up to Overlap characters of pieces from the previous one.
+type RecursiveChunker struct {
+	Size       int
+	Overlap    int
+	Separators []string
+}
+
+// NewRecursiveChunker creates a RecursiveChunker with the default separators
+func NewRecursiveChunker(size, overlap int) *RecursiveChunker {
+	return &RecursiveChunker{
+		Size:       size,
+		Overlap:    overlap,
+		Separators: []string{"\n\n", "\n", ". ", " "},
+	}
+}
+
+// Chunk splits text recursively
+func (c *RecursiveChunker) Chunk(text string) []Chunk {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	if c.Size <= 0 {
+		return newChunks([]string{strings.TrimSpace(text)})
+	}
+
+	var contents []string
+	var current []string
+	length := 0
+	for _, piece := range c.split(text, c.Separators) {
+		if length > 0 && length+len(piece) > c.Size {
+			contents = append(contents, strings.Join(current, ""))
+
+			// Keep trailing pieces as overlap while the next piece still fits
+			for length > 0 && (length > c.Overlap || length+len(piece) > c.Size) {
+				length -= len(current[0])
+				current = current[1:]
+			}
+		}
+		current = append(current, piece)
+		length += len(piece)
+	}
+	contents = append(contents, strings.Join(current, ""))
+
+	return newChunks(contents)
+}
+
+// split breaks text into pieces of at most Size characters, keeping separators
+func (c *RecursiveChunker) split(text string, separators []string) []string {
+	if len(text) <= c.Size {
+		return []string{text}
+	}
+
+	for i, sep := range separators {
+		if sep == "" || !strings.Contains(text, sep) {
+			continue
+		}
+		var pieces []string
+		for _, part := range strings.SplitAfter(text, sep) {
+			if part == "" {
+				continue
+			}
+			pieces = append(pieces, c.split(part, separators[i+1:])...)
+		}
+		return pieces
+	}
+
+	// No separator left: cut on rune boundaries
+	var pieces []string
+	for len(text) > c.Size {
+		end := c.Size
+		for end > 1 && !utf8.RuneStart(text[end]) {
+			end--
+		}
+		pieces = append(pieces, text[:end])
+		text = text[end:]
+	}
+	return append(pieces, text)
+}
+
+// newChunks numbers non-empty contents as chunks
+func newChunks(contents []string) []Chunk {
+	chunks := make([]Chunk, 0, len(contents))
+	for _, content := range contents {
+		if content = strings.TrimSpace(content); content != "" {
+			chunks = append(chunks, Chunk{Content: content, Index: len(chunks)})
+		}
+	}
+	return chunks
+}
+
+// chunkContents splits text with the configured Chunker
+func (k *BaseKnowledge) chunkContents(text string) []string {
+	chunks := k.Chunker.Chunk(text)
+	contents := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		contents[i] = chunk.Content
+	}
+	return contents
+}
+
+// splitDocument splits doc with the configured Chunker. Without a Chunker,
+// or when the text is a single chunk, doc is returned unchanged.
+func (k *BaseKnowledge) splitDocument(doc *document.Document) []*document.Document {
+	if k.Chunker == nil {
+		return []*document.Document{doc}
+	}
+
+	contents := k.chunkContents(doc.Content)
+	if len(contents) <= 1 {
+		return []*document.Document{doc}
+	}
+
+	documents := make([]*document.Document, len(contents))
+	for i, content := range contents {
+		chunkMetadata := make(map[string]interface{}, len(doc.Metadata)+3)
+		for k, v := range doc.Metadata {
+			chunkMetadata[k] = v
+		}
+		chunkMetadata["chunk_index"] = i
+		chunkMetadata["total_chunks"] = len(contents)
+		chunkMetadata["chunk_size"] = len(content)
+
+		chunk := *doc
+		chunk.ID = generateUUID()
+		chunk.Content = content
+		chunk.Metadata = chunkMetadata
+		chunk.ChunkIndex = i
+		chunk.ChunkTotal = len(contents)
+		chunk.ParentID = doc.ID
+		documents[i] = &chunk
+	}
+	return documents
+}
@@ -0,0 +1,88 @@
+package knowledge
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+)
+
+func chunkContents(chunks []Chunk) []string {
+	contents := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		if chunk.Index != i {
+			panic("chunks are not numbered in order")
+		}
+		contents[i] = chunk.Content
+	}
+	return contents
+}
+
+func TestFixedSizeChunker(t *testing.T) {
+	got := chunkContents(NewFixedSizeChunker(11, 5).Chunk("one two  three\nfour five"))
+	want := []string{"one two", "two three", "three four", "four five"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	if got := NewFixedSizeChunker(100, 10).Chunk("   "); len(got) != 0 {
+		t.Errorf("blank text produced %q", got)
+	}
+}
+
+func TestSentenceChunker(t *testing.T) {
+	text := "First sentence. Second one! Third?\n\nNew paragraph without a stop"
+	got := chunkContents(NewSentenceChunker(30).Chunk(text))
+	want := []string{"First sentence. Second one!", "Third?", "New paragraph without a stop"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	// Version numbers are not sentence ends
+	if got := splitSentences("Use v1.2 now. Done."); !reflect.DeepEqual(got, []string{"Use v1.2 now.", "Done."}) {
+		t.Errorf("got sentences %q", got)
+	}
+}
+
+func TestRecursiveChunker(t *testing.T) {
+	text := "Intro paragraph.\n\nA longer paragraph. It has two sentences.\n\nEnd."
+	got := chunkContents(NewRecursiveChunker(40, 0).Chunk(text))
+	want := []string{"Intro paragraph.\n\nA longer paragraph.", "It has two sentences.\n\nEnd."}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	for _, chunk := range NewRecursiveChunker(10, 4).Chunk(strings.Repeat("abcdefghij", 5) + " tail words here") {
+		if len(chunk.Content) > 10 {
+			t.Errorf("chunk %q is longer than 10 characters", chunk.Content)
+		}
+	}
+}
+
+func TestWithChunker(t *testing.T) {
+	kb := &BaseKnowledge{}
+	doc := &document.Document{ID: "doc", Content: "alpha beta gamma", Metadata: map[string]interface{}{"source": "test"}}
+
+	if got := kb.splitDocument(doc); len(got) != 1 || got[0] != doc {
+		t.Errorf("without a chunker the document was split into %d", len(got))
+	}
+
+	WithChunker(NewFixedSizeChunker(10, 0))(kb)
+	got := kb.splitDocument(doc)
+	if len(got) != 2 || got[0].Content != "alpha beta" || got[1].Content != "gamma" {
+		t.Fatalf("unexpected chunks %+v", got)
+	}
+	if got[1].ParentID != "doc" || got[1].ChunkTotal != 2 || got[1].Metadata["chunk_index"] != 1 || got[1].Metadata["source"] != "test" {
+		t.Errorf("unexpected chunk fields %+v", got[1])
+	}
+
+	pdf := &PDFKnowledgeBase{BaseKnowledge: &BaseKnowledge{}, ChunkSize: 10, ChunkOverlap: 1}
+	if got := pdf.chunkContent("alpha beta gamma"); !reflect.DeepEqual(got, pdf.chunkText("alpha beta gamma")) {
+		t.Errorf("default PDF chunking changed: %q", got)
+	}
+	pdf.Chunker = NewSentenceChunker(100)
+	if got := pdf.chunkContent("alpha beta gamma"); len(got) != 1 {
+		t.Errorf("PDF ignored the chunker: %q", got)
+	}
+}
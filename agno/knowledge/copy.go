@@ -0,0 +1,120 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devalexandre/agno-golang/agno/embedder"
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+)
+
+// CopyTo copies every document of the knowledge base, with its embeddings, IDs and
+// metadata, into dst without re-embedding (e.g. staging -> prod). Documents are read
+// and upserted batchSize at a time. Both collections must use the same vector size.
+// It returns the number of documents copied.
+func (k *BaseKnowledge) CopyTo(ctx context.Context, dst vectordb.VectorDB, batchSize int) (int, error) {
+	if k.VectorDB == nil {
+		return 0, fmt.Errorf("vector database not configured")
+	}
+	if dst == nil {
+		return 0, fmt.Errorf("destination vector database is required")
+	}
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	scroller, ok := k.VectorDB.(vectordb.DocumentScroller)
+	if !ok {
+		return 0, fmt.Errorf("vector database does not support scrolling documents")
+	}
+
+	srcInfo, err := collectionEmbedderInfo(ctx, k.VectorDB, k.GetEmbedder())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get source dimensions: %w", err)
+	}
+	dstInfo, err := collectionEmbedderInfo(ctx, dst, dst.GetEmbedder())
+	if err != nil {
+		return 0, fmt.Errorf("failed to get destination dimensions: %w", err)
+	}
+	if srcInfo.Dimensions > 0 && dstInfo.Dimensions > 0 && srcInfo.Dimensions != dstInfo.Dimensions {
+		return 0, fmt.Errorf("%w: source has %d dimensions, destination has %d", vectordb.ErrEmbedderMismatch, srcInfo.Dimensions, dstInfo.Dimensions)
+	}
+
+	dimensions := srcInfo.Dimensions
+	if dimensions == 0 {
+		dimensions = dstInfo.Dimensions
+	}
+
+	copied := 0
+	cursor := ""
+	for {
+		docs, next, err := scroller.ScrollDocuments(ctx, cursor, batchSize)
+		if err != nil {
+			return copied, err
+		}
+
+		for _, doc := range docs {
+			// Missing vectors would make the destination re-embed the document
+			if len(doc.Embeddings) == 0 {
+				return copied, fmt.Errorf("document %s has no embeddings", doc.ID)
+			}
+			if dimensions == 0 {
+				dimensions = len(doc.Embeddings)
+			}
+			if len(doc.Embeddings) != dimensions {
+				return copied, fmt.Errorf("%w: document %s has %d dimensions, expected %d", vectordb.ErrEmbedderMismatch, doc.ID, len(doc.Embeddings), dimensions)
+			}
+		}
+
+		if len(docs) > 0 {
+			if err := dst.Upsert(ctx, docs, nil); err != nil {
+				return copied, fmt.Errorf("failed to upsert documents: %w", err)
+			}
+			copied += len(docs)
+		}
+
+		if next == "" || len(docs) == 0 {
+			break
+		}
+		cursor = next
+	}
+
+	// Record the source embedder on the destination so later queries are validated
+	if store, ok := dst.(vectordb.EmbedderInfoStore); ok && copied > 0 && srcInfo.Model != "" {
+		info, err := store.GetEmbedderInfo(ctx)
+		if err != nil {
+			return copied, fmt.Errorf("failed to get destination embedder: %w", err)
+		}
+		if info == nil || info.Model == "" {
+			if err := store.SetEmbedderInfo(ctx, vectordb.EmbedderInfo{Model: srcInfo.Model, Dimensions: dimensions}); err != nil {
+				return copied, fmt.Errorf("failed to record destination embedder: %w", err)
+			}
+		}
+	}
+
+	return copied, nil
+}
+
+// collectionEmbedderInfo returns the embedder recorded for db, falling back to emb
+// for fields that were not recorded. Unknown fields are left empty.
+func collectionEmbedderInfo(ctx context.Context, db vectordb.VectorDB, emb embedder.Embedder) (vectordb.EmbedderInfo, error) {
+	var info vectordb.EmbedderInfo
+	if store, ok := db.(vectordb.EmbedderInfoStore); ok {
+		recorded, err := store.GetEmbedderInfo(ctx)
+		if err != nil {
+			return info, err
+		}
+		if recorded != nil {
+			info = *recorded
+		}
+	}
+	if emb != nil {
+		if info.Model == "" {
+			info.Model = emb.GetID()
+		}
+		if info.Dimensions == 0 {
+			info.Dimensions = emb.GetDimensions()
+		}
+	}
+	return info, nil
+}
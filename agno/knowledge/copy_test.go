@@ -0,0 +1,68 @@
+package knowledge
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+)
+
+// scrollVectorDB is an in-memory store that can be scrolled and upserted into
+type scrollVectorDB struct {
+	fakeVectorDB
+	docs    []*document.Document
+	upserts int
+}
+
+func (s *scrollVectorDB) ScrollDocuments(ctx context.Context, cursor string, limit int) ([]*document.Document, string, error) {
+	start, _ := strconv.Atoi(cursor)
+	end := start + limit
+	if end >= len(s.docs) {
+		return s.docs[start:], "", nil
+	}
+	return s.docs[start:end], strconv.Itoa(end), nil
+}
+
+func (s *scrollVectorDB) Upsert(ctx context.Context, docs []*document.Document, filters map[string]interface{}) error {
+	s.upserts++
+	s.docs = append(s.docs, docs...)
+	return nil
+}
+
+func TestKnowledgeCopyTo(t *testing.T) {
+	src := &scrollVectorDB{fakeVectorDB: fakeVectorDB{info: &vectordb.EmbedderInfo{Model: "mistral-embed", Dimensions: 2}}}
+	for i := 0; i < 5; i++ {
+		src.docs = append(src.docs, &document.Document{
+			ID:         "doc-" + strconv.Itoa(i),
+			Metadata:   map[string]interface{}{"page": i},
+			Embeddings: []float64{float64(i), 1},
+		})
+	}
+	kb := &BaseKnowledge{VectorDB: src}
+
+	dst := &scrollVectorDB{}
+	copied, err := kb.CopyTo(context.Background(), dst, 2)
+	if err != nil {
+		t.Fatalf("CopyTo failed: %v", err)
+	}
+	if copied != 5 || len(dst.docs) != 5 || dst.upserts != 3 {
+		t.Fatalf("Expected 5 docs in 3 batches, got copied=%d docs=%d upserts=%d", copied, len(dst.docs), dst.upserts)
+	}
+	if dst.docs[4].ID != "doc-4" || dst.docs[4].Metadata["page"] != 4 || dst.docs[4].Embeddings[0] != 4 {
+		t.Errorf("Document not preserved: %+v", dst.docs[4])
+	}
+	if dst.info == nil || dst.info.Model != "mistral-embed" || dst.info.Dimensions != 2 {
+		t.Errorf("Expected source embedder recorded on destination, got %+v", dst.info)
+	}
+
+	mismatched := &scrollVectorDB{fakeVectorDB: fakeVectorDB{info: &vectordb.EmbedderInfo{Dimensions: 768}}}
+	if _, err := kb.CopyTo(context.Background(), mismatched, 2); !errors.Is(err, vectordb.ErrEmbedderMismatch) {
+		t.Fatalf("Expected ErrEmbedderMismatch, got %v", err)
+	}
+	if len(mismatched.docs) != 0 {
+		t.Error("Nothing should be copied when dimensions differ")
+	}
+}
@@ -0,0 +1,318 @@
+package knowledge
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/csv"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+)
+
+// CSVKnowledgeBase handles CSV knowledge bases. Every row becomes a document
+// whose content is built from the text columns, while the other columns are
+// stored as metadata so searches can filter on them.
+type CSVKnowledgeBase struct {
+	*BaseKnowledge
+	Paths           []string `json:"paths,omitempty"`            // Files loaded by Load
+	ContentColumns  []string `json:"content_columns,omitempty"`  // Default text columns
+	MetadataColumns []string `json:"metadata_columns,omitempty"` // Default metadata columns
+	Delimiter       rune     `json:"delimiter,omitempty"`        // Field delimiter (default ',')
+}
+
+// CSVLoadOptions configures how rows are mapped to documents. Without
+// ContentColumns every column that is not a metadata column is text; without
+// MetadataColumns every column that is not text is metadata.
+type CSVLoadOptions struct {
+	ContentColumns  []string
+	MetadataColumns []string
+	Delimiter       rune
+	Metadata        map[string]interface{} // Added to every row
+}
+
+// CSVOption configures a CSV load
+type CSVOption func(*CSVLoadOptions)
+
+// WithContentColumns configures the columns joined into the document content
+func WithContentColumns(columns []string) CSVOption {
+	return func(o *CSVLoadOptions) {
+		o.ContentColumns = columns
+	}
+}
+
+// WithMetadataColumns configures the columns stored as metadata
+func WithMetadataColumns(columns []string) CSVOption {
+	return func(o *CSVLoadOptions) {
+		o.MetadataColumns = columns
+	}
+}
+
+// WithCSVDelimiter configures the field delimiter, e.g. ';' or '\t'
+func WithCSVDelimiter(delimiter rune) CSVOption {
+	return func(o *CSVLoadOptions) {
+		o.Delimiter = delimiter
+	}
+}
+
+// WithCSVMetadata configures metadata added to every row
+func WithCSVMetadata(metadata map[string]interface{}) CSVOption {
+	return func(o *CSVLoadOptions) {
+		o.Metadata = metadata
+	}
+}
+
+// CSVLoadResult reports what a CSV load did
+type CSVLoadResult struct {
+	Rows    int `json:"rows"`    // Data rows read
+	Loaded  int `json:"loaded"`  // Rows stored as documents
+	Skipped int `json:"skipped"` // Rows skipped because their content was empty
+}
+
+// NewCSVKnowledgeBase creates a new CSV knowledge base
+func NewCSVKnowledgeBase(name string, vectorDB VectorDB, options ...KnowledgeOption) *CSVKnowledgeBase {
+	base := NewBaseKnowledge(name, vectorDB, options...)
+	base.Metadata["description"] = "CSV knowledge base"
+	base.Metadata["type"] = "csv"
+
+	return &CSVKnowledgeBase{
+		BaseKnowledge: base,
+		Delimiter:     ',',
+	}
+}
+
+// Load loads all configured CSV files
+func (c *CSVKnowledgeBase) Load(ctx context.Context, recreate bool) error {
+	if recreate && c.VectorDB != nil {
+		if err := c.VectorDB.Drop(ctx); err != nil {
+			return fmt.Errorf("failed to drop existing database: %w", err)
+		}
+	}
+
+	for _, path := range c.Paths {
+		if _, err := c.LoadDocumentFromPath(ctx, path); err != nil {
+			return fmt.Errorf("failed to load from path %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// LoadAsync loads documents asynchronously
+func (c *CSVKnowledgeBase) LoadAsync(ctx context.Context, recreate bool) error {
+	return c.Load(ctx, recreate)
+}
+
+// LoadDocumentFromPath loads every row of a CSV file as a document. Options
+// override the columns configured on the knowledge base.
+func (c *CSVKnowledgeBase) LoadDocumentFromPath(ctx context.Context, path string, options ...CSVOption) (CSVLoadResult, error) {
+	opts := CSVLoadOptions{
+		ContentColumns:  c.ContentColumns,
+		MetadataColumns: c.MetadataColumns,
+		Delimiter:       c.Delimiter,
+	}
+	for _, option := range options {
+		option(&opts)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return CSVLoadResult{}, fmt.Errorf("failed to open CSV file: %w", err)
+	}
+	defer file.Close()
+
+	docs, result, err := c.readCSV(file, path, opts)
+	if err != nil {
+		return result, err
+	}
+	if len(docs) == 0 {
+		return result, nil
+	}
+
+	docChan := make(chan document.Document)
+	go func() {
+		defer close(docChan)
+		for i, doc := range docs {
+			select {
+			case docChan <- *doc:
+				docs[i] = nil // release the row once it is handed off
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	if _, err := c.LoadDocumentStream(ctx, docChan); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// GetInfo returns information about the CSV knowledge base
+func (c *CSVKnowledgeBase) GetInfo() KnowledgeInfo {
+	info := c.BaseKnowledge.GetInfo()
+	info.Type = "csv"
+	info.Description = "CSV knowledge base with one document per row"
+	info.Metadata["sources"] = c.Paths
+	return info
+}
+
+// readCSV converts the rows of r into documents
+func (c *CSVKnowledgeBase) readCSV(r io.Reader, path string, opts CSVLoadOptions) ([]*document.Document, CSVLoadResult, error) {
+	var result CSVLoadResult
+
+	reader := csv.NewReader(r)
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, result, fmt.Errorf("CSV file has no header row: %s", path)
+	}
+	if err != nil {
+		return nil, result, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	for i := range header {
+		header[i] = strings.TrimSpace(header[i])
+	}
+	header[0] = strings.TrimPrefix(header[0], "\ufeff") // Excel adds a byte order mark
+
+	contentColumns, metadataColumns, err := csvColumns(header, opts)
+	if err != nil {
+		return nil, result, err
+	}
+
+	var docs []*document.Document
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, result, fmt.Errorf("failed to read CSV row: %w", err)
+		}
+		result.Rows++
+
+		var parts []string
+		for _, i := range contentColumns {
+			value := strings.TrimSpace(record[i])
+			if value == "" {
+				continue
+			}
+			if len(contentColumns) > 1 {
+				value = header[i] + ": " + value
+			}
+			parts = append(parts, value)
+		}
+		if len(parts) == 0 {
+			result.Skipped++
+			continue
+		}
+		content := strings.Join(parts, "\n")
+
+		metadata := map[string]interface{}{
+			"source":    path,
+			"file_path": path,
+			"type":      "csv",
+			"row":       result.Rows,
+		}
+		for k, v := range opts.Metadata {
+			metadata[k] = v
+		}
+		for _, i := range metadataColumns {
+			if value := csvValue(record[i]); value != nil {
+				metadata[header[i]] = value
+			}
+		}
+
+		// Rows keep their ID when the file is reloaded
+		hash := sha1.New()
+		fmt.Fprintf(hash, "%s\x00%d", path, result.Rows)
+		id := hex.EncodeToString(hash.Sum(nil))
+
+		doc := document.NewDocumentWithMetadata(content, metadata)
+		doc.ID = id
+		doc.Name = fmt.Sprintf("%s#%d", filepath.Base(path), result.Rows)
+		doc.Source = path
+		doc.ContentType = "text/csv"
+
+		docs = append(docs, c.splitDocument(doc)...)
+		result.Loaded++
+	}
+
+	return docs, result, nil
+}
+
+// csvColumns resolves the content and metadata column indexes
+func csvColumns(header []string, opts CSVLoadOptions) ([]int, []int, error) {
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[name] = i
+	}
+	lookup := func(names []string) ([]int, error) {
+		indexes := make([]int, 0, len(names))
+		for _, name := range names {
+			i, ok := index[name]
+			if !ok {
+				return nil, fmt.Errorf("CSV column %q not found in header %v", name, header)
+			}
+			indexes = append(indexes, i)
+		}
+		return indexes, nil
+	}
+	others := func(exclude []int) []int {
+		excluded := make(map[int]bool, len(exclude))
+		for _, i := range exclude {
+			excluded[i] = true
+		}
+		var indexes []int
+		for i := range header {
+			if !excluded[i] {
+				indexes = append(indexes, i)
+			}
+		}
+		return indexes
+	}
+
+	content, err := lookup(opts.ContentColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+	metadata, err := lookup(opts.MetadataColumns)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(content) == 0 {
+		content = others(metadata)
+	} else if len(metadata) == 0 {
+		metadata = others(content)
+	}
+	return content, metadata, nil
+}
+
+// csvValue converts a cell to float64 when it is a number, or else a
+// string. Codes with leading zeros such as "00123" stay strings. Empty
+// cells return nil.
+func csvValue(cell string) interface{} {
+	cell = strings.TrimSpace(cell)
+	if cell == "" {
+		return nil
+	}
+	digits := strings.TrimLeft(cell, "+-")
+	if len(digits) > 1 && digits[0] == '0' && digits[1] >= '0' && digits[1] <= '9' {
+		return cell
+	}
+	if number, err := strconv.ParseFloat(cell, 64); err == nil && !math.IsNaN(number) && !math.IsInf(number, 0) {
+		return number
+	}
+	return cell
+}
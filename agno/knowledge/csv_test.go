@@ -0,0 +1,83 @@
+package knowledge
+
+import (
+	"strings"
+	"testing"
+)
+
+const productsCSV = "\ufeffsku,name,description,price,category\n" +
+	"00123,Widget,A small widget,9.99,tools\n" +
+	"00124,,,5,tools\n" +
+	"00125,Gadget,\"Multi-line,\nwith commas\",12,gadgets\n"
+
+func TestReadCSV(t *testing.T) {
+	c := &CSVKnowledgeBase{BaseKnowledge: &BaseKnowledge{}}
+	opts := CSVLoadOptions{}
+	WithContentColumns([]string{"name", "description"})(&opts)
+
+	docs, result, err := c.readCSV(strings.NewReader(productsCSV), "products.csv", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != (CSVLoadResult{Rows: 3, Loaded: 2, Skipped: 1}) {
+		t.Errorf("result = %+v", result)
+	}
+	if len(docs) != 2 {
+		t.Fatalf("got %d documents, want 2", len(docs))
+	}
+
+	widget := docs[0]
+	if widget.Content != "name: Widget\ndescription: A small widget" {
+		t.Errorf("content = %q", widget.Content)
+	}
+	if widget.Metadata["price"] != 9.99 || widget.Metadata["category"] != "tools" || widget.Metadata["sku"] != "00123" {
+		t.Errorf("metadata = %v", widget.Metadata)
+	}
+	if _, ok := widget.Metadata["name"]; ok {
+		t.Errorf("content column stored as metadata: %v", widget.Metadata)
+	}
+	if widget.Metadata["file_path"] != "products.csv" || widget.Metadata["row"] != 1 {
+		t.Errorf("file metadata = %v", widget.Metadata)
+	}
+	if docs[1].Metadata["price"] != 12.0 || !strings.Contains(docs[1].Content, "with commas") {
+		t.Errorf("quoted row = %q %v", docs[1].Content, docs[1].Metadata)
+	}
+
+	// Reloading keeps the row IDs
+	again, _, _ := c.readCSV(strings.NewReader(productsCSV), "products.csv", opts)
+	if again[0].ID != widget.ID || again[1].ID == widget.ID {
+		t.Errorf("row IDs are not stable and unique")
+	}
+}
+
+func TestReadCSVColumns(t *testing.T) {
+	c := &CSVKnowledgeBase{BaseKnowledge: &BaseKnowledge{}}
+
+	opts := CSVLoadOptions{}
+	WithMetadataColumns([]string{"price"})(&opts)
+	docs, _, err := c.readCSV(strings.NewReader(productsCSV), "products.csv", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(docs[0].Content, "category: tools") || docs[0].Metadata["price"] != 9.99 {
+		t.Errorf("content = %q, metadata = %v", docs[0].Content, docs[0].Metadata)
+	}
+	if _, ok := docs[0].Metadata["category"]; ok {
+		t.Errorf("text column stored as metadata: %v", docs[0].Metadata)
+	}
+
+	WithContentColumns([]string{"title"})(&opts)
+	if _, _, err := c.readCSV(strings.NewReader(productsCSV), "products.csv", opts); err == nil || !strings.Contains(err.Error(), `"title"`) {
+		t.Errorf("unknown column error = %v", err)
+	}
+
+	semicolons := CSVLoadOptions{ContentColumns: []string{"name"}}
+	WithCSVDelimiter(';')(&semicolons)
+	docs, _, err = c.readCSV(strings.NewReader("name;qty\nbolt;3\n"), "parts.csv", semicolons)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if docs[0].Content != "bolt" || docs[0].Metadata["qty"] != 3.0 {
+		t.Errorf("content = %q, metadata = %v", docs[0].Content, docs[0].Metadata)
+	}
+}
@@ -0,0 +1,160 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+)
+
+// DocumentInfo describes a stored document without its content vectors
+type DocumentInfo struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Source      string                 `json:"source,omitempty"`
+	ContentType string                 `json:"content_type,omitempty"`
+	Metadata    map[string]interface{} `json:"metadata,omitempty"`
+	ChunkIndex  int                    `json:"chunk_index,omitempty"`
+	ChunkTotal  int                    `json:"chunk_total,omitempty"`
+	ParentID    string                 `json:"parent_id,omitempty"`
+	CreatedAt   time.Time              `json:"created_at"`
+	UpdatedAt   time.Time              `json:"updated_at"`
+}
+
+// NewDocumentInfo builds a DocumentInfo from a stored document
+func NewDocumentInfo(doc *document.Document) DocumentInfo {
+	return DocumentInfo{
+		ID:          doc.ID,
+		Name:        doc.Name,
+		Source:      doc.Source,
+		ContentType: doc.ContentType,
+		Metadata:    doc.Metadata,
+		ChunkIndex:  doc.ChunkIndex,
+		ChunkTotal:  doc.ChunkTotal,
+		ParentID:    doc.ParentID,
+		CreatedAt:   doc.CreatedAt,
+		UpdatedAt:   doc.UpdatedAt,
+	}
+}
+
+// documentLister returns the VectorDB as a vectordb.DocumentLister if supported
+func (k *BaseKnowledge) documentLister() (vectordb.DocumentLister, error) {
+	if k.VectorDB == nil {
+		return nil, fmt.Errorf("vector database not configured")
+	}
+
+	lister, ok := k.VectorDB.(vectordb.DocumentLister)
+	if !ok {
+		return nil, fmt.Errorf("vector database does not support listing documents")
+	}
+	return lister, nil
+}
+
+// scopeFilters merges filters with the knowledge base include filters. It
+// returns nil when there is nothing to filter on.
+func (k *BaseKnowledge) scopeFilters(filters map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	if k.Filters != nil && k.Filters.Include != nil {
+		for key, value := range k.Filters.Include {
+			merged[key] = value
+		}
+	}
+	for key, value := range filters {
+		merged[key] = value
+	}
+	if len(merged) == 0 {
+		return nil
+	}
+	return merged
+}
+
+// ListDocuments returns the IDs, names and metadata of stored documents matching filters.
+// Filters are merged with the knowledge base include filters. Vectors are never returned.
+func (k *BaseKnowledge) ListDocuments(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]DocumentInfo, error) {
+	lister, err := k.documentLister()
+	if err != nil {
+		return nil, err
+	}
+
+	docs, err := lister.ListDocuments(ctx, k.scopeFilters(filters), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]DocumentInfo, 0, len(docs))
+	for _, doc := range docs {
+		infos = append(infos, NewDocumentInfo(doc))
+	}
+	return infos, nil
+}
+
+// GetDocument returns a stored document, including its content but not its vectors.
+func (k *BaseKnowledge) GetDocument(ctx context.Context, id string) (*document.Document, error) {
+	lister, err := k.documentLister()
+	if err != nil {
+		return nil, err
+	}
+
+	doc, err := lister.GetDocument(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if doc == nil {
+		return nil, fmt.Errorf("document not found: %s", id)
+	}
+
+	doc.Embeddings = nil
+	return doc, nil
+}
+
+// DeleteDocuments removes the documents with the given IDs and returns how many were deleted.
+func (k *BaseKnowledge) DeleteDocuments(ctx context.Context, ids []string) (int, error) {
+	if k.VectorDB == nil {
+		return 0, fmt.Errorf("vector database not configured")
+	}
+
+	deleter, ok := k.VectorDB.(vectordb.DocumentDeleter)
+	if !ok {
+		return 0, fmt.Errorf("vector database does not support deleting documents")
+	}
+
+	return deleter.DeleteByIDs(ctx, ids)
+}
+
+// DeleteDocument removes the document with the given ID. It returns an error
+// when the document does not exist.
+func (k *BaseKnowledge) DeleteDocument(ctx context.Context, id string) error {
+	deleted, err := k.DeleteDocuments(ctx, []string{id})
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return fmt.Errorf("document not found: %s", id)
+	}
+	return nil
+}
+
+// DeleteByMetadata removes the documents whose metadata matches every filter,
+// e.g. all chunks of a source file or of an expired import batch. Filters are
+// merged with the knowledge base include filters, and empty filters are
+// rejected so the whole collection is never deleted by accident.
+func (k *BaseKnowledge) DeleteByMetadata(ctx context.Context, filters map[string]interface{}) error {
+	if len(filters) == 0 {
+		return fmt.Errorf("filters cannot be empty for delete operation")
+	}
+	if k.VectorDB == nil {
+		return fmt.Errorf("vector database not configured")
+	}
+
+	deleter, ok := k.VectorDB.(vectordb.FilterDeleter)
+	if !ok {
+		return fmt.Errorf("vector database does not support deleting documents by filter")
+	}
+
+	if err := deleter.DeleteByFilter(ctx, k.scopeFilters(filters)); err != nil {
+		return fmt.Errorf("failed to delete documents: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+)
+
+// deleteVectorDB records the deletes it receives
+type deleteVectorDB struct {
+	fakeVectorDB
+	ids     map[string]bool
+	filters map[string]interface{}
+}
+
+func (d *deleteVectorDB) DeleteByIDs(ctx context.Context, ids []string) (int, error) {
+	deleted := 0
+	for _, id := range ids {
+		if d.ids[id] {
+			delete(d.ids, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (d *deleteVectorDB) DeleteByFilter(ctx context.Context, filters map[string]interface{}) error {
+	d.filters = filters
+	return nil
+}
+
+func TestDeleteDocument(t *testing.T) {
+	ctx := context.Background()
+	db := &deleteVectorDB{ids: map[string]bool{"a": true}}
+	kb := &BaseKnowledge{VectorDB: db}
+
+	if err := kb.DeleteDocument(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if err := kb.DeleteDocument(ctx, "a"); err == nil {
+		t.Error("deleting a missing document succeeded")
+	}
+
+	kb = &BaseKnowledge{VectorDB: &fakeVectorDB{}}
+	if err := kb.DeleteDocument(ctx, "a"); err == nil {
+		t.Error("expected an error for a vector database without deletes")
+	}
+}
+
+func TestDeleteByMetadata(t *testing.T) {
+	ctx := context.Background()
+	db := &deleteVectorDB{}
+	kb := &BaseKnowledge{
+		VectorDB: db,
+		Filters:  &SearchFilters{Include: map[string]interface{}{"tenant": "acme"}},
+	}
+
+	if err := kb.DeleteByMetadata(ctx, map[string]interface{}{"batch": "2024-01"}); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]interface{}{"tenant": "acme", "batch": "2024-01"}
+	if !reflect.DeepEqual(db.filters, want) {
+		t.Errorf("deleted with filters %v, want %v", db.filters, want)
+	}
+
+	// Include filters alone must not turn an empty delete into a tenant wipe
+	db.filters = nil
+	if err := kb.DeleteByMetadata(ctx, nil); err == nil || db.filters != nil {
+		t.Errorf("empty filters were not rejected: %v", err)
+	}
+
+	kb = &BaseKnowledge{VectorDB: &fakeVectorDB{}}
+	if err := kb.DeleteByMetadata(ctx, map[string]interface{}{"batch": "2024-01"}); err == nil {
+		t.Error("expected an error for a vector database without filter deletes")
+	}
+}
+
+func (s *storeVectorDB) ListDocuments(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*document.Document, error) {
+	var docs []*document.Document
+	for _, doc := range s.docs {
+		matches := true
+		for key, value := range filters {
+			if fmt.Sprint(doc.Metadata[key]) != fmt.Sprint(value) {
+				matches = false
+			}
+		}
+		if matches {
+			docs = append(docs, doc)
+		}
+	}
+	sort.Slice(docs, func(i, j int) bool { return docs[i].ID < docs[j].ID })
+	if offset > len(docs) {
+		offset = len(docs)
+	}
+	docs = docs[offset:]
+	if len(docs) > limit {
+		docs = docs[:limit]
+	}
+	return docs, nil
+}
+
+func (s *storeVectorDB) GetDocument(ctx context.Context, id string) (*document.Document, error) {
+	return s.docs[id], nil
+}
+
+func (s *storeVectorDB) DeleteByIDs(ctx context.Context, ids []string) (int, error) {
+	deleted := 0
+	for _, id := range ids {
+		if _, ok := s.docs[id]; ok {
+			delete(s.docs, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func TestDocumentInspection(t *testing.T) {
+	ctx := context.Background()
+	db := &storeVectorDB{docs: make(map[string]*document.Document)}
+	kb := &BaseKnowledge{VectorDB: db}
+
+	docs := []document.Document{
+		{ID: "a", Name: "A", Content: "alpha", Metadata: map[string]interface{}{"topic": "greek"}},
+		{ID: "b", Name: "B", Content: "beta", Metadata: map[string]interface{}{"topic": "greek"}},
+		{ID: "c", Name: "C", Content: "gamma", Metadata: map[string]interface{}{"topic": "other"}, Embeddings: []float64{1}},
+	}
+	if err := kb.LoadDocuments(ctx, docs, false); err != nil {
+		t.Fatal(err)
+	}
+
+	infos, err := kb.ListDocuments(ctx, map[string]interface{}{"topic": "greek"}, 10, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(infos) != 2 || infos[0].ID != "a" || infos[0].Name != "A" || infos[1].ID != "b" {
+		t.Errorf("unexpected documents %+v", infos)
+	}
+
+	// The knowledge base include filters apply to every listing
+	kb.Filters = &SearchFilters{Include: map[string]interface{}{"topic": "other"}}
+	if infos, _ := kb.ListDocuments(ctx, nil, 10, 0); len(infos) != 1 || infos[0].ID != "c" {
+		t.Errorf("include filters not applied: %+v", infos)
+	}
+	kb.Filters = nil
+
+	// Documents can be found by content through the stored hash
+	infos, err = kb.ListDocuments(ctx, map[string]interface{}{ContentHashKey: ContentHash("beta")}, 10, 0)
+	if err != nil || len(infos) != 1 || infos[0].ID != "b" {
+		t.Errorf("lookup by content hash = %+v, %v", infos, err)
+	}
+
+	doc, err := kb.GetDocument(ctx, "c")
+	if err != nil || doc.Content != "gamma" || doc.Embeddings != nil {
+		t.Errorf("GetDocument = %+v, %v", doc, err)
+	}
+	if _, err := kb.GetDocument(ctx, "missing"); err == nil {
+		t.Error("expected an error for a missing document")
+	}
+
+	deleted, err := kb.DeleteDocuments(ctx, []string{"a", "missing"})
+	if err != nil || deleted != 1 {
+		t.Errorf("DeleteDocuments = %d, %v", deleted, err)
+	}
+}
+
+func TestUpsertStoresContentHash(t *testing.T) {
+	ctx := context.Background()
+	db := &storeVectorDB{docs: make(map[string]*document.Document)}
+	kb := &BaseKnowledge{VectorDB: db}
+
+	if err := kb.Add(ctx, []document.Document{{ID: "a", Content: "alpha"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := kb.UpsertDocument(ctx, document.Document{ID: "b", Content: "beta"}); err != nil {
+		t.Fatal(err)
+	}
+	if db.docs["a"].Metadata[ContentHashKey] != ContentHash("alpha") || db.docs["b"].Metadata[ContentHashKey] != ContentHash("beta") {
+		t.Errorf("content hashes not stored: %v, %v", db.docs["a"].Metadata, db.docs["b"].Metadata)
+	}
+}
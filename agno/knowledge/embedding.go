@@ -0,0 +1,105 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+)
+
+// embedDocuments embeds documents with the knowledge base's own embedder, if set,
+// so the vectors stored match the embedder used for queries. Documents that
+// already have embeddings are left untouched.
+func (k *BaseKnowledge) embedDocuments(docs []*document.Document) error {
+	if k.Embedder == nil {
+		return nil
+	}
+
+	for _, doc := range docs {
+		if len(doc.Embeddings) > 0 {
+			continue
+		}
+		embedding, err := k.Embedder.GetEmbedding(doc.Content)
+		if err != nil {
+			return fmt.Errorf("failed to generate embedding for doc ID %s: %w", doc.ID, err)
+		}
+		if len(embedding) == 0 {
+			return fmt.Errorf("empty embedding generated for doc ID %s", doc.ID)
+		}
+		doc.Embeddings = embedding
+	}
+
+	return nil
+}
+
+// ValidateEmbedder checks that the knowledge base embedder matches the embedder
+// recorded for the collection and returns an error wrapping vectordb.ErrEmbedderMismatch
+// if it does not. Vector databases that cannot record embedders are not checked.
+func (k *BaseKnowledge) ValidateEmbedder(ctx context.Context) error {
+	if k.embedderValidated.Load() {
+		return nil
+	}
+
+	store, ok := k.VectorDB.(vectordb.EmbedderInfoStore)
+	if !ok {
+		return nil
+	}
+
+	info, err := store.GetEmbedderInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get collection embedder: %w", err)
+	}
+	if err := vectordb.ValidateEmbedder(info, k.GetEmbedder()); err != nil {
+		return err
+	}
+
+	if info != nil {
+		k.embedderValidated.Store(true)
+	}
+	return nil
+}
+
+// recordEmbedder validates the embedder against the collection and records it
+// when the collection has none yet
+func (k *BaseKnowledge) recordEmbedder(ctx context.Context) error {
+	emb := k.GetEmbedder()
+	store, ok := k.VectorDB.(vectordb.EmbedderInfoStore)
+	if !ok || emb == nil {
+		return nil
+	}
+
+	info, err := store.GetEmbedderInfo(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get collection embedder: %w", err)
+	}
+	if info != nil {
+		return vectordb.ValidateEmbedder(info, emb)
+	}
+
+	if err := store.SetEmbedderInfo(ctx, vectordb.NewEmbedderInfo(emb)); err != nil {
+		return err
+	}
+	k.embedderValidated.Store(true)
+	return nil
+}
+
+// search runs a query against the vector database. When the knowledge base has its
+// own embedder the query is embedded with it, so it matches the indexed vectors.
+func (k *BaseKnowledge) search(ctx context.Context, query string, numDocuments int, filters map[string]interface{}) ([]*SearchResult, error) {
+	if err := k.ValidateEmbedder(ctx); err != nil {
+		return nil, err
+	}
+
+	if k.Embedder != nil {
+		if searcher, ok := k.VectorDB.(vectordb.VectorSearcher); ok {
+			embedding, err := k.Embedder.GetEmbedding(query)
+			if err != nil {
+				return nil, fmt.Errorf("failed to embed query: %w", err)
+			}
+			return searcher.SearchByVector(ctx, embedding, numDocuments, filters)
+		}
+	}
+
+	return k.VectorDB.Search(ctx, query, numDocuments, filters)
+}
@@ -0,0 +1,71 @@
+package knowledge
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/embedder"
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+)
+
+// fakeVectorDB records the embedder info and the query vector it was searched with
+type fakeVectorDB struct {
+	vectordb.VectorDB
+	info        *vectordb.EmbedderInfo
+	queryVector []float64
+}
+
+func (f *fakeVectorDB) GetEmbedder() embedder.Embedder { return nil }
+
+func (f *fakeVectorDB) GetEmbedderInfo(ctx context.Context) (*vectordb.EmbedderInfo, error) {
+	return f.info, nil
+}
+
+func (f *fakeVectorDB) SetEmbedderInfo(ctx context.Context, info vectordb.EmbedderInfo) error {
+	f.info = &info
+	return nil
+}
+
+func (f *fakeVectorDB) SearchByVector(ctx context.Context, embedding []float64, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	f.queryVector = embedding
+	return nil, nil
+}
+
+func TestKnowledgeEmbedderIsUsedForQueries(t *testing.T) {
+	db := &fakeVectorDB{}
+	kb := &BaseKnowledge{
+		VectorDB: db,
+		Embedder: embedder.NewMockEmbedder(3).WithFixedEmbedding([]float64{1, 2, 3}),
+	}
+
+	if err := kb.recordEmbedder(context.Background()); err != nil {
+		t.Fatalf("recordEmbedder failed: %v", err)
+	}
+	if db.info == nil || db.info.Model != "mock-embedder" || db.info.Dimensions != 3 {
+		t.Fatalf("Unexpected recorded embedder: %+v", db.info)
+	}
+
+	if _, err := kb.Search(context.Background(), "query", 5); err != nil {
+		t.Fatalf("Search failed: %v", err)
+	}
+	if len(db.queryVector) != 3 || db.queryVector[0] != 1 {
+		t.Errorf("Expected query embedded with the knowledge embedder, got %v", db.queryVector)
+	}
+}
+
+func TestKnowledgeEmbedderMismatch(t *testing.T) {
+	db := &fakeVectorDB{info: &vectordb.EmbedderInfo{Model: "other-model", Dimensions: 768}}
+	kb := &BaseKnowledge{
+		VectorDB: db,
+		Embedder: embedder.NewMockEmbedder(3).WithFixedEmbedding([]float64{1, 2, 3}),
+	}
+
+	_, err := kb.Search(context.Background(), "query", 5)
+	if !errors.Is(err, vectordb.ErrEmbedderMismatch) {
+		t.Fatalf("Expected ErrEmbedderMismatch, got %v", err)
+	}
+	if db.queryVector != nil {
+		t.Error("Search should not run with a mismatched embedder")
+	}
+}
@@ -0,0 +1,135 @@
+package knowledge
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+)
+
+// UpsertMode controls how loading treats documents whose ID is already stored.
+// The zero value inserts every document, as loading did before upsert modes.
+type UpsertMode string
+
+const (
+	// UpsertModeHashCompare re-embeds and upserts only documents whose content
+	// hash differs from the stored one. Vector databases that cannot fetch
+	// stored documents (vectordb.DocumentBatchGetter) get every document.
+	UpsertModeHashCompare UpsertMode = "hash_compare"
+	// UpsertModeSkip inserts only documents whose ID is not stored yet
+	UpsertModeSkip UpsertMode = "skip"
+	// UpsertModeOverwrite re-embeds and upserts every document
+	UpsertModeOverwrite UpsertMode = "overwrite"
+)
+
+// ContentHashKey is the metadata key holding the content hash of a document.
+// It is set on every document written by LoadDocuments, Add and Upsert, so
+// documents can be looked up by content with a metadata filter.
+const ContentHashKey = "content_hash"
+
+// WithUpsertMode configures how already stored documents are handled on reload
+func WithUpsertMode(mode UpsertMode) KnowledgeOption {
+	return func(k *BaseKnowledge) {
+		k.UpsertMode = mode
+	}
+}
+
+// ContentHash returns the hash stored under ContentHashKey for content
+func ContentHash(content string) string {
+	sum := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(sum[:])
+}
+
+// setContentHashes records the content hash of docs in their metadata
+func setContentHashes(docs []*document.Document) {
+	for _, doc := range docs {
+		if doc.Metadata == nil {
+			doc.Metadata = make(map[string]interface{})
+		}
+		doc.Metadata[ContentHashKey] = ContentHash(doc.Content)
+	}
+}
+
+// pendingDocuments returns the docs that must be written under the upsert
+// mode, comparing the hashes set by setContentHashes. Documents without an
+// ID cannot be matched and are always written.
+func (k *BaseKnowledge) pendingDocuments(ctx context.Context, docs []*document.Document) ([]*document.Document, error) {
+	mode := k.UpsertMode
+	switch mode {
+	case "", UpsertModeOverwrite:
+		return docs, nil
+	case UpsertModeSkip, UpsertModeHashCompare:
+	default:
+		return nil, fmt.Errorf("unknown upsert mode %q", mode)
+	}
+
+	hashes, batched, err := k.storedHashes(ctx, docs)
+	if err != nil {
+		return nil, err
+	}
+	if !batched && mode == UpsertModeHashCompare {
+		return docs, nil
+	}
+
+	pending := make([]*document.Document, 0, len(docs))
+	for _, doc := range docs {
+		if doc.ID == "" {
+			pending = append(pending, doc)
+			continue
+		}
+
+		if !batched {
+			exists, err := k.VectorDB.IDExists(ctx, doc.ID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check document %s: %w", doc.ID, err)
+			}
+			if exists {
+				continue
+			}
+		} else if hash, exists := hashes[doc.ID]; exists && (mode == UpsertModeSkip || hash == doc.Metadata[ContentHashKey]) {
+			continue
+		}
+		pending = append(pending, doc)
+	}
+	return pending, nil
+}
+
+// storedHashes returns the stored content hash of each of docs that is already
+// stored, keyed by ID, fetched in one request. batched is false when the vector
+// database cannot fetch documents by ID.
+func (k *BaseKnowledge) storedHashes(ctx context.Context, docs []*document.Document) (hashes map[string]interface{}, batched bool, err error) {
+	getter, ok := k.VectorDB.(vectordb.DocumentBatchGetter)
+	if !ok {
+		return nil, false, nil
+	}
+
+	ids := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		if doc.ID != "" {
+			ids = append(ids, doc.ID)
+		}
+	}
+	stored, err := getter.GetDocuments(ctx, ids)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to get stored documents: %w", err)
+	}
+
+	hashes = make(map[string]interface{}, len(stored))
+	for _, doc := range stored {
+		hashes[doc.ID] = doc.Metadata[ContentHashKey]
+	}
+	return hashes, true, nil
+}
+
+// writeDocuments stores a batch. Without an upsert mode, and in UpsertModeSkip
+// where only new documents are left, they are inserted; the other modes
+// replace stored documents.
+func (k *BaseKnowledge) writeDocuments(ctx context.Context, docs []*document.Document) error {
+	if k.UpsertMode == "" || k.UpsertMode == UpsertModeSkip {
+		return k.VectorDB.Insert(ctx, docs, nil)
+	}
+	return k.VectorDB.Upsert(ctx, docs, nil)
+}
@@ -0,0 +1,163 @@
+package knowledge
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+)
+
+// storeVectorDB keeps documents by ID and counts the documents written and
+// the requests for stored documents
+type storeVectorDB struct {
+	fakeVectorDB
+	docs     map[string]*document.Document
+	inserted int
+	upserted int
+	gets     int
+}
+
+func (s *storeVectorDB) Create(ctx context.Context) error { return nil }
+
+func (s *storeVectorDB) Insert(ctx context.Context, docs []*document.Document, filters map[string]interface{}) error {
+	s.inserted += len(docs)
+	return s.store(docs)
+}
+
+func (s *storeVectorDB) Upsert(ctx context.Context, docs []*document.Document, filters map[string]interface{}) error {
+	s.upserted += len(docs)
+	return s.store(docs)
+}
+
+func (s *storeVectorDB) store(docs []*document.Document) error {
+	for _, doc := range docs {
+		stored := *doc
+		s.docs[doc.ID] = &stored
+	}
+	return nil
+}
+
+func (s *storeVectorDB) IDExists(ctx context.Context, id string) (bool, error) {
+	_, ok := s.docs[id]
+	return ok, nil
+}
+
+func (s *storeVectorDB) GetDocuments(ctx context.Context, ids []string) ([]*document.Document, error) {
+	s.gets++
+	var docs []*document.Document
+	for _, id := range ids {
+		if doc, ok := s.docs[id]; ok {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+// unbatchedVectorDB hides everything but the VectorDB methods of a store
+type unbatchedVectorDB struct {
+	vectordb.VectorDB
+}
+
+func loadDocs() []document.Document {
+	return []document.Document{
+		{ID: "a", Content: "alpha"},
+		{ID: "b", Content: "beta"},
+	}
+}
+
+func TestLoadDocumentsUpsertModes(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		mode               UpsertMode
+		inserted, upserted int
+		content            string
+	}{
+		{"", 4, 0, "beta v2"}, // Every document is inserted, as before upsert modes
+		{UpsertModeHashCompare, 0, 3, "beta v2"},
+		{UpsertModeSkip, 2, 0, "beta"},
+		{UpsertModeOverwrite, 0, 4, "beta v2"},
+	}
+
+	for _, tt := range tests {
+		db := &storeVectorDB{docs: make(map[string]*document.Document)}
+		kb := &BaseKnowledge{VectorDB: db}
+		WithUpsertMode(tt.mode)(kb)
+
+		if err := kb.LoadDocuments(ctx, loadDocs(), false); err != nil {
+			t.Fatal(err)
+		}
+		if db.docs["a"].Metadata[ContentHashKey] != ContentHash("alpha") {
+			t.Errorf("%q: content hash not stored: %v", tt.mode, db.docs["a"].Metadata)
+		}
+
+		changed := loadDocs()
+		changed[1].Content = "beta v2"
+		if err := kb.LoadDocuments(ctx, changed, false); err != nil {
+			t.Fatal(err)
+		}
+
+		if db.inserted != tt.inserted || db.upserted != tt.upserted {
+			t.Errorf("%q: inserted %d, upserted %d, want %d and %d", tt.mode, db.inserted, db.upserted, tt.inserted, tt.upserted)
+		}
+		if db.docs["b"].Content != tt.content {
+			t.Errorf("%q: stored content %q, want %q", tt.mode, db.docs["b"].Content, tt.content)
+		}
+	}
+}
+
+func TestLoadDocumentStreamSkipsUnchanged(t *testing.T) {
+	ctx := context.Background()
+	db := &storeVectorDB{docs: make(map[string]*document.Document)}
+	kb := &BaseKnowledge{VectorDB: db, UpsertMode: UpsertModeHashCompare}
+
+	load := func() int {
+		docChan := make(chan document.Document)
+		go func() {
+			defer close(docChan)
+			for _, doc := range loadDocs() {
+				docChan <- doc
+			}
+		}()
+		stored, err := kb.LoadDocumentStream(ctx, docChan)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return stored
+	}
+
+	if stored := load(); stored != 2 {
+		t.Errorf("first load stored %d, want 2", stored)
+	}
+	if stored := load(); stored != 0 {
+		t.Errorf("reload stored %d unchanged documents", stored)
+	}
+}
+
+func TestLoadDocumentsHashCompareBatchesLookups(t *testing.T) {
+	ctx := context.Background()
+	db := &storeVectorDB{docs: make(map[string]*document.Document)}
+	kb := &BaseKnowledge{VectorDB: db, UpsertMode: UpsertModeHashCompare}
+
+	for i := 0; i < 2; i++ {
+		if err := kb.LoadDocuments(ctx, loadDocs(), false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if db.gets != 2 || db.upserted != 2 {
+		t.Errorf("expected one lookup per load and 2 documents written, got %d lookups and %d written", db.gets, db.upserted)
+	}
+
+	// Backends that cannot fetch documents by ID get every document
+	db = &storeVectorDB{docs: make(map[string]*document.Document)}
+	kb = &BaseKnowledge{VectorDB: unbatchedVectorDB{db}, UpsertMode: UpsertModeHashCompare}
+	for i := 0; i < 2; i++ {
+		if err := kb.LoadDocuments(ctx, loadDocs(), false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if db.upserted != 4 {
+		t.Errorf("expected every document to be written, got %d", db.upserted)
+	}
+}
@@ -0,0 +1,394 @@
+package knowledge
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+)
+
+// MarkdownKnowledgeBase handles Markdown knowledge bases. Files are split on
+// H1/H2/H3 headings so every chunk belongs to a single section, and the
+// heading path is stored in the chunk metadata.
+type MarkdownKnowledgeBase struct {
+	*BaseKnowledge
+	Paths        []string `json:"paths,omitempty"`         // Files or directories loaded by Load
+	ExcludeFiles []string `json:"exclude_files,omitempty"` // Files to exclude
+	Formats      []string `json:"formats"`                 // Supported formats
+	ChunkSize    int      `json:"chunk_size"`              // Maximum chunk size in characters
+	ChunkOverlap int      `json:"chunk_overlap"`           // Characters repeated from the previous chunk of a section
+}
+
+// MarkdownLoadOptions configures LoadDirectory
+type MarkdownLoadOptions struct {
+	Recursive bool                   // Also load subdirectories
+	Exclude   []string               // Skip files whose path contains any of these
+	Metadata  map[string]interface{} // Added to every chunk
+}
+
+// markdownSection is the text under a heading
+type markdownSection struct {
+	headings [3]string // H1, H2 and H3 in effect for the section
+	level    int       // Level of the section heading, 0 before the first heading
+	text     string
+}
+
+// NewMarkdownKnowledgeBase creates a new Markdown knowledge base
+func NewMarkdownKnowledgeBase(name string, vectorDB VectorDB, options ...KnowledgeOption) *MarkdownKnowledgeBase {
+	base := NewBaseKnowledge(name, vectorDB, options...)
+	base.Metadata["description"] = "Markdown knowledge base"
+	base.Metadata["type"] = "markdown"
+
+	return &MarkdownKnowledgeBase{
+		BaseKnowledge: base,
+		Formats:       []string{".md", ".markdown"},
+		ChunkSize:     1000,
+		ChunkOverlap:  100,
+	}
+}
+
+// Load loads all configured Markdown paths
+func (m *MarkdownKnowledgeBase) Load(ctx context.Context, recreate bool) error {
+	var documents []*document.Document
+	for _, path := range m.Paths {
+		docs, err := m.loadFromPath(path, MarkdownLoadOptions{Recursive: true, Exclude: m.ExcludeFiles})
+		if err != nil {
+			return fmt.Errorf("failed to load from path %s: %w", path, err)
+		}
+		documents = append(documents, docs...)
+	}
+
+	if len(documents) == 0 {
+		return fmt.Errorf("no Markdown documents found or configured")
+	}
+
+	return m.LoadDocuments(ctx, ConvertDocumentPointers(documents), recreate)
+}
+
+// LoadAsync loads documents asynchronously
+func (m *MarkdownKnowledgeBase) LoadAsync(ctx context.Context, recreate bool) error {
+	return m.Load(ctx, recreate)
+}
+
+// LoadDocumentFromPath loads a Markdown file, or every Markdown file in a
+// directory tree, adding metadata to every chunk
+func (m *MarkdownKnowledgeBase) LoadDocumentFromPath(ctx context.Context, path string, metadata map[string]interface{}) error {
+	docs, err := m.loadFromPath(path, MarkdownLoadOptions{Recursive: true, Exclude: m.ExcludeFiles, Metadata: metadata})
+	if err != nil {
+		return err
+	}
+	return m.LoadDocuments(ctx, ConvertDocumentPointers(docs), false)
+}
+
+// LoadDirectory loads the Markdown files in dir
+func (m *MarkdownKnowledgeBase) LoadDirectory(ctx context.Context, dir string, opts MarkdownLoadOptions) error {
+	info, err := os.Stat(dir)
+	if err != nil {
+		return fmt.Errorf("path not found: %w", err)
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("not a directory: %s", dir)
+	}
+
+	docs, err := m.loadFromPath(dir, opts)
+	if err != nil {
+		return err
+	}
+	if len(docs) == 0 {
+		return fmt.Errorf("no Markdown documents found in %s", dir)
+	}
+	return m.LoadDocuments(ctx, ConvertDocumentPointers(docs), false)
+}
+
+// LoadDocument splits a Markdown document into section chunks and loads them
+func (m *MarkdownKnowledgeBase) LoadDocument(ctx context.Context, doc document.Document) error {
+	docs := m.chunkMarkdown(doc.Source, doc.Content, doc.Metadata)
+	return m.LoadDocuments(ctx, ConvertDocumentPointers(docs), false)
+}
+
+// GetInfo returns information about the Markdown knowledge base
+func (m *MarkdownKnowledgeBase) GetInfo() KnowledgeInfo {
+	info := m.BaseKnowledge.GetInfo()
+	info.Type = "markdown"
+	info.Description = "Markdown knowledge base chunked by heading"
+	info.Metadata["sources"] = m.Paths
+	info.Metadata["formats"] = m.Formats
+	return info
+}
+
+// loadFromPath reads and chunks a Markdown file or the Markdown files in a directory
+func (m *MarkdownKnowledgeBase) loadFromPath(path string, opts MarkdownLoadOptions) ([]*document.Document, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("path not found: %w", err)
+	}
+
+	var files []string
+	if info.IsDir() {
+		err := filepath.WalkDir(path, func(filePath string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if filePath != path && !opts.Recursive {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !IsValidFileFormat(filePath, m.Formats) {
+				return nil
+			}
+			for _, exclude := range opts.Exclude {
+				if strings.Contains(filePath, exclude) {
+					return nil
+				}
+			}
+			files = append(files, filePath)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan directory: %w", err)
+		}
+	} else {
+		if !IsValidFileFormat(path, m.Formats) {
+			return nil, fmt.Errorf("file is not Markdown: %s", path)
+		}
+		files = []string{path}
+	}
+
+	var documents []*document.Document
+	for _, filePath := range files {
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", filePath, err)
+		}
+		documents = append(documents, m.chunkMarkdown(filePath, string(content), opts.Metadata)...)
+	}
+	return documents, nil
+}
+
+// chunkMarkdown splits content into one or more chunks per section, with
+// the heading path and file path in the metadata of every chunk
+func (m *MarkdownKnowledgeBase) chunkMarkdown(filePath, content string, metadata map[string]interface{}) []*document.Document {
+	type chunk struct {
+		section *markdownSection
+		text    string
+	}
+
+	var chunks []chunk
+	for _, section := range splitMarkdownSections(content) {
+		for _, text := range m.chunkSection(section.text) {
+			chunks = append(chunks, chunk{section: section, text: text})
+		}
+	}
+
+	documents := make([]*document.Document, 0, len(chunks))
+	for i, c := range chunks {
+		docMetadata := make(map[string]interface{}, len(metadata)+10)
+		for k, v := range metadata {
+			docMetadata[k] = v
+		}
+		docMetadata["source"] = filePath
+		docMetadata["file_path"] = filePath
+		docMetadata["type"] = "markdown"
+		docMetadata["chunk_index"] = i
+		docMetadata["total_chunks"] = len(chunks)
+
+		// Heading path, e.g. title "Guide", section "Install", subsection "Linux"
+		var path []string
+		for level, key := range []string{"title", "section", "subsection"} {
+			if heading := c.section.headings[level]; heading != "" {
+				docMetadata[key] = heading
+				path = append(path, heading)
+			}
+		}
+		if len(path) > 0 {
+			docMetadata["heading_path"] = strings.Join(path, " > ")
+			docMetadata["heading_level"] = c.section.level
+		}
+
+		// IDs depend on the file so identical sections in two files do not collide
+		hash := sha1.New()
+		hash.Write([]byte(filePath))
+		hash.Write([]byte{0})
+		hash.Write([]byte(c.text))
+		id := hex.EncodeToString(hash.Sum(nil))
+		docMetadata["id"] = id
+
+		var name string
+		if filePath != "" {
+			name = filepath.Base(filePath)
+		}
+
+		documents = append(documents, &document.Document{
+			ID:          id,
+			Name:        name,
+			Content:     c.text,
+			ContentType: "text/markdown",
+			Source:      filePath,
+			Metadata:    docMetadata,
+			ChunkIndex:  i,
+			ChunkTotal:  len(chunks),
+		})
+	}
+	return documents
+}
+
+// chunkSection splits the text of a section with the configured Chunker,
+// or on paragraph, line and word boundaries by default
+func (m *MarkdownKnowledgeBase) chunkSection(text string) []string {
+	if m.BaseKnowledge != nil && m.Chunker != nil {
+		return m.chunkContents(text)
+	}
+	return splitWithOverlap(text, m.ChunkSize, m.ChunkOverlap)
+}
+
+// splitMarkdownSections splits content on ATX headings up to H3, ignoring
+// headings inside fenced code blocks. Headings without text of their own
+// (e.g. a title directly followed by a subheading) only name later sections.
+func splitMarkdownSections(content string) []*markdownSection {
+	var sections []*markdownSection
+	current := &markdownSection{}
+	var body strings.Builder
+	hasText := false // Whether the section has more than its heading
+	inFence := false
+	fence := ""
+
+	flush := func() {
+		if hasText {
+			current.text = strings.TrimSpace(body.String())
+			sections = append(sections, current)
+		}
+		body.Reset()
+		hasText = false
+	}
+
+	for _, line := range strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		if marker := fenceMarker(trimmed); marker != "" {
+			if !inFence {
+				inFence, fence = true, marker
+			} else if strings.HasPrefix(trimmed, fence) && strings.TrimLeft(trimmed, fence[:1]) == "" {
+				inFence = false
+			}
+		}
+
+		if level, heading := markdownHeading(line); !inFence && level > 0 {
+			flush()
+			next := &markdownSection{headings: current.headings, level: level}
+			next.headings[level-1] = heading
+			for i := level; i < len(next.headings); i++ {
+				next.headings[i] = ""
+			}
+			current = next
+		} else if trimmed != "" {
+			hasText = true
+		}
+
+		body.WriteString(line)
+		body.WriteString("\n")
+	}
+	flush()
+
+	return sections
+}
+
+// fenceMarker returns the ``` or ~~~ run that opens or closes a code block
+func fenceMarker(line string) string {
+	for _, c := range []string{"`", "~"} {
+		if strings.HasPrefix(line, c+c+c) {
+			return line[:len(line)-len(strings.TrimLeft(line, c))]
+		}
+	}
+	return ""
+}
+
+// markdownHeading returns the level and text of an H1-H3 ATX heading line
+func markdownHeading(line string) (int, string) {
+	// Up to three spaces of indentation are allowed
+	trimmed := strings.TrimLeft(line, " ")
+	if len(line)-len(trimmed) > 3 {
+		return 0, ""
+	}
+
+	level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	if level == 0 || level > 3 {
+		return 0, ""
+	}
+	rest := trimmed[level:]
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return 0, "" // "#hashtag" is not a heading
+	}
+
+	// Drop an optional closing sequence of #s
+	text := strings.TrimSpace(rest)
+	if stripped := strings.TrimRight(text, "#"); stripped == "" || strings.HasSuffix(stripped, " ") {
+		text = strings.TrimSpace(stripped)
+	}
+	return level, text
+}
+
+// splitWithOverlap splits text into chunks of at most size characters,
+// preferring paragraph, line and word boundaries. Each chunk after the first
+// starts with up to overlap characters from the end of the previous one.
+func splitWithOverlap(text string, size, overlap int) []string {
+	if size <= 0 || len(text) <= size {
+		return []string{text}
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	var chunks []string
+	start := 0
+	for start < len(text) {
+		end := start + size
+		if end >= len(text) {
+			chunks = append(chunks, strings.TrimSpace(text[start:]))
+			break
+		}
+		end = breakPoint(text, start, end)
+		chunks = append(chunks, strings.TrimSpace(text[start:end]))
+
+		// Begin the next chunk on a word boundary inside the overlap
+		next := end - overlap
+		if next <= start {
+			next = end
+		} else if i := strings.IndexAny(text[next:end], " \n\t"); i >= 0 {
+			next += i + 1
+		} else {
+			next = end
+		}
+		start = next
+	}
+
+	result := chunks[:0]
+	for _, chunk := range chunks {
+		if chunk != "" {
+			result = append(result, chunk)
+		}
+	}
+	return result
+}
+
+// breakPoint returns the last paragraph, line or word break in text[start:end],
+// or the last rune boundary when the span has none
+func breakPoint(text string, start, end int) int {
+	span := text[start:end]
+	for _, sep := range []string{"\n\n", "\n", " "} {
+		if i := strings.LastIndex(span, sep); i > 0 {
+			return start + i + len(sep)
+		}
+	}
+	for end > start+1 && !utf8.RuneStart(text[end]) {
+		end--
+	}
+	return end
+}
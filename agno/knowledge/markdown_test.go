@@ -0,0 +1,124 @@
+package knowledge
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const guideMarkdown = `# Guide
+
+Intro text.
+
+## Install
+
+Run the installer.
+
+` + "```sh\n# not a heading\nmake install\n```" + `
+
+### Linux
+
+Use the package manager.
+
+## Usage ##
+
+Call the API.
+`
+
+func TestChunkMarkdownSections(t *testing.T) {
+	m := &MarkdownKnowledgeBase{Formats: []string{".md"}, ChunkSize: 1000}
+	docs := m.chunkMarkdown("docs/guide.md", guideMarkdown, map[string]interface{}{"team": "core"})
+
+	want := []struct {
+		heading  string
+		contains string
+	}{
+		{"Guide", "Intro text."},
+		{"Guide > Install", "# not a heading"},
+		{"Guide > Install > Linux", "package manager"},
+		{"Guide > Usage", "Call the API."},
+	}
+	if len(docs) != len(want) {
+		t.Fatalf("got %d chunks, want %d", len(docs), len(want))
+	}
+	for i, w := range want {
+		doc := docs[i]
+		if doc.Metadata["heading_path"] != w.heading || !strings.Contains(doc.Content, w.contains) {
+			t.Errorf("chunk %d = %q under %v, want %q under %q", i, doc.Content, doc.Metadata["heading_path"], w.contains, w.heading)
+		}
+		if doc.Metadata["file_path"] != "docs/guide.md" || doc.Metadata["team"] != "core" || doc.Name != "guide.md" {
+			t.Errorf("chunk %d metadata = %v", i, doc.Metadata)
+		}
+	}
+
+	linux := docs[2].Metadata
+	if linux["title"] != "Guide" || linux["section"] != "Install" || linux["subsection"] != "Linux" || linux["heading_level"] != 3 {
+		t.Errorf("unexpected heading metadata %v", linux)
+	}
+	if _, ok := docs[3].Metadata["subsection"]; ok {
+		t.Errorf("subsection leaked into the next section: %v", docs[3].Metadata)
+	}
+}
+
+func TestSplitWithOverlap(t *testing.T) {
+	text := strings.Repeat("word ", 100) // 500 characters
+	chunks := splitWithOverlap(text, 100, 20)
+	if len(chunks) < 5 {
+		t.Fatalf("got %d chunks, want at least 5", len(chunks))
+	}
+	for i, chunk := range chunks {
+		if len(chunk) > 100 {
+			t.Errorf("chunk %d has %d characters", i, len(chunk))
+		}
+	}
+	if total := len(strings.Fields(strings.Join(chunks, " "))); total <= 100 {
+		t.Errorf("chunks hold %d words, want overlap beyond the 100 in the text", total)
+	}
+
+	if chunks := splitWithOverlap("short", 100, 20); len(chunks) != 1 || chunks[0] != "short" {
+		t.Errorf("short text split into %q", chunks)
+	}
+}
+
+func TestMarkdownLoadFromPath(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.md":         "# A\n\nalpha",
+		"notes.txt":    "not markdown",
+		"sub/b.md":     "# B\n\nbeta",
+		"draft/c.md":   "# C\n\ngamma",
+		"sub/deep.txt": "ignored",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	m := &MarkdownKnowledgeBase{Formats: []string{".md"}, ChunkSize: 1000}
+
+	top, err := m.loadFromPath(dir, MarkdownLoadOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(top) != 1 || top[0].Metadata["title"] != "A" {
+		t.Errorf("non-recursive load returned %d chunks", len(top))
+	}
+
+	all, err := m.loadFromPath(dir, MarkdownLoadOptions{Recursive: true, Exclude: []string{"draft"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, doc := range all {
+		titles = append(titles, doc.Metadata["title"].(string))
+	}
+	if strings.Join(titles, ",") != "A,B" {
+		t.Errorf("recursive load returned %v, want [A B]", titles)
+	}
+}
@@ -47,7 +47,7 @@ func (p *PDFKnowledgeBase) Search(ctx context.Context, query string, numDocument
 		return nil, fmt.Errorf("vector database not configured")
 	}
 
-	return p.VectorDB.Search(ctx, query, numDocuments, nil)
+	return p.search(ctx, query, numDocuments, nil)
 
 }
 
@@ -59,8 +59,8 @@ func (p *PDFKnowledgeBase) GetCount(ctx context.Context) (int64, error) {
 }
 
 // NewPDFKnowledgeBase creates a new PDF knowledge base
-func NewPDFKnowledgeBase(name string, vectorDB VectorDB) *PDFKnowledgeBase {
-	base := NewBaseKnowledge(name, vectorDB)
+func NewPDFKnowledgeBase(name string, vectorDB VectorDB, options ...KnowledgeOption) *PDFKnowledgeBase {
+	base := NewBaseKnowledge(name, vectorDB, options...)
 	base.Metadata["description"] = "PDF knowledge base"
 	base.Metadata["type"] = "pdf"
 
@@ -494,11 +494,32 @@ func (p *PDFKnowledgeBase) LoadDocumentFromPath(ctx context.Context, pathOrURL s
 		return err
 	}
 
-	// Convert pointers to values
-	convertedDocs := ConvertDocumentPointers(docs)
+	// Stream chunks into the vector database so only a few batches of
+	// embeddings are held in memory, even for very large PDFs
+	docChan := make(chan document.Document)
+	go func() {
+		defer close(docChan)
+		for i, doc := range docs {
+			select {
+			case docChan <- *doc:
+				docs[i] = nil // release the chunk once it is handed off
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	stored, err := p.LoadDocumentStream(ctx, docChan,
+		WithStreamProgress(func(progress StreamProgress) {
+			p.showInsertProgress(progress.Documents, len(docs), fmt.Sprintf("%d documents stored", progress.Documents))
+		}),
+	)
+	if err != nil {
+		return err
+	}
 
-	// Load documents into vector database
-	return p.LoadDocuments(ctx, convertedDocs, false)
+	fmt.Printf("\n✅ %d documents stored\n", stored)
+	return nil
 }
 
 // LoadDocument loads a single document into the vector database
@@ -665,7 +686,7 @@ func (p *PDFKnowledgeBase) extractPDFContent(filePath, sourceURL string, metadat
 	}
 
 	// Chunk the content
-	chunks := p.chunkText(content)
+	chunks := p.chunkContent(content)
 
 	var docs []*document.Document
 
@@ -854,14 +875,25 @@ func (p *PDFKnowledgeBase) chunkText(text string) []string {
 	return chunks
 }
 
+// chunkContent splits text with the configured Chunker, or chunkText by default
+func (p *PDFKnowledgeBase) chunkContent(text string) []string {
+	if p.Chunker != nil {
+		return p.chunkContents(text)
+	}
+	return p.chunkText(text)
+}
+
 // chunkDocument creates smaller documents from a large document
 func (p *PDFKnowledgeBase) chunkDocument(doc document.Document) []document.Document {
 	content := doc.Content
-	if len(content) <= p.ChunkSize {
+	if p.Chunker == nil && len(content) <= p.ChunkSize {
 		return []document.Document{doc}
 	}
 
-	chunks := p.chunkText(content)
+	chunks := p.chunkContent(content)
+	if p.Chunker != nil && len(chunks) <= 1 {
+		return []document.Document{doc}
+	}
 	var documents []document.Document
 
 	for i, chunk := range chunks {
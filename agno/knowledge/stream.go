@@ -0,0 +1,184 @@
+package knowledge
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+)
+
+// StreamProgress reports the documents stored so far by LoadDocumentStream
+type StreamProgress struct {
+	Batches   int
+	Documents int
+}
+
+// streamConfig holds the LoadDocumentStream settings
+type streamConfig struct {
+	batchSize   int
+	concurrency int
+	onProgress  func(StreamProgress)
+}
+
+// StreamOption configures LoadDocumentStream
+type StreamOption func(*streamConfig)
+
+// WithStreamBatchSize sets how many documents are embedded and upserted together (default 100)
+func WithStreamBatchSize(size int) StreamOption {
+	return func(c *streamConfig) {
+		c.batchSize = size
+	}
+}
+
+// WithStreamConcurrency sets how many batches are processed in parallel (default 4)
+func WithStreamConcurrency(workers int) StreamOption {
+	return func(c *streamConfig) {
+		c.concurrency = workers
+	}
+}
+
+// WithStreamProgress sets a callback invoked after each stored batch.
+// Calls are serialized, so the callback does not need to be thread-safe.
+func WithStreamProgress(fn func(StreamProgress)) StreamOption {
+	return func(c *streamConfig) {
+		c.onProgress = fn
+	}
+}
+
+// LoadDocumentStream embeds and upserts documents as they arrive on docChan, in
+// batches, until docChan is closed. At most concurrency+1 batches are held in
+// memory at any time, so memory use does not grow with the corpus size.
+// On error the remaining documents are drained and discarded so producers never
+// block. Like LoadDocuments it skips stored documents according to the
+// UpsertMode. It returns the number of documents stored.
+func (k *BaseKnowledge) LoadDocumentStream(ctx context.Context, docChan <-chan document.Document, options ...StreamOption) (int, error) {
+	if k.VectorDB == nil {
+		return 0, fmt.Errorf("vector database not configured")
+	}
+
+	cfg := streamConfig{batchSize: 100, concurrency: 4}
+	for _, opt := range options {
+		opt(&cfg)
+	}
+	if cfg.batchSize <= 0 {
+		cfg.batchSize = 100
+	}
+	if cfg.concurrency <= 0 {
+		cfg.concurrency = 1
+	}
+
+	if err := k.VectorDB.Create(ctx); err != nil {
+		fmt.Printf("[KNOWLEDGE] Warning: Failed to create VectorDB table (may already exist): %v\n", err)
+	}
+	if err := k.recordEmbedder(ctx); err != nil {
+		go drainDocuments(docChan)
+		return 0, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		progress StreamProgress
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		if firstErr == nil {
+			firstErr = err
+		}
+		mu.Unlock()
+		cancel()
+	}
+
+	// Unbuffered: the reader blocks until a worker is free (backpressure)
+	batches := make(chan []*document.Document)
+	for range cfg.concurrency {
+		wg.Go(func() {
+			for batch := range batches {
+				setContentHashes(batch)
+				batch, err := k.pendingDocuments(ctx, batch)
+				if err != nil {
+					fail(err)
+					continue
+				}
+				if len(batch) == 0 {
+					continue // Nothing changed since the last load
+				}
+				if err := k.embedDocuments(batch); err != nil {
+					fail(err)
+					continue
+				}
+				if err := k.VectorDB.Upsert(ctx, batch, nil); err != nil {
+					fail(fmt.Errorf("failed to upsert batch: %w", err))
+					continue
+				}
+
+				mu.Lock()
+				progress.Batches++
+				progress.Documents += len(batch)
+				if cfg.onProgress != nil {
+					cfg.onProgress(progress)
+				}
+				mu.Unlock()
+			}
+		})
+	}
+
+	send := func(batch []*document.Document) bool {
+		select {
+		case batches <- batch:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	batch := make([]*document.Document, 0, cfg.batchSize)
+	reading := true
+	for reading {
+		select {
+		case doc, ok := <-docChan:
+			if !ok {
+				reading = false
+				break
+			}
+			batch = append(batch, &doc)
+			if len(batch) == cfg.batchSize {
+				if !send(batch) {
+					reading = false
+					break
+				}
+				batch = make([]*document.Document, 0, cfg.batchSize)
+			}
+		case <-ctx.Done():
+			reading = false
+		}
+	}
+	if len(batch) > 0 && ctx.Err() == nil {
+		send(batch)
+	}
+	close(batches)
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		go drainDocuments(docChan)
+	}
+
+	if firstErr != nil {
+		return progress.Documents, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return progress.Documents, err
+	}
+	return progress.Documents, nil
+}
+
+// drainDocuments discards the rest of docChan so its producer can finish
+func drainDocuments(docChan <-chan document.Document) {
+	for range docChan {
+	}
+}
@@ -0,0 +1,90 @@
+package knowledge
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+)
+
+// streamVectorDB counts stored documents and the peak number of concurrent upserts
+type streamVectorDB struct {
+	fakeVectorDB
+	mu       sync.Mutex
+	stored   int
+	inFlight atomic.Int32
+	peak     atomic.Int32
+	failAt   int
+}
+
+func (s *streamVectorDB) Create(ctx context.Context) error { return nil }
+
+func (s *streamVectorDB) Upsert(ctx context.Context, docs []*document.Document, filters map[string]interface{}) error {
+	n := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		peak := s.peak.Load()
+		if n <= peak || s.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failAt > 0 && s.stored+len(docs) >= s.failAt {
+		return errors.New("disk full")
+	}
+	s.stored += len(docs)
+	return nil
+}
+
+func produce(n int) <-chan document.Document {
+	docChan := make(chan document.Document)
+	go func() {
+		defer close(docChan)
+		for i := 0; i < n; i++ {
+			docChan <- document.Document{ID: "doc", Content: "chunk", Embeddings: []float64{1}}
+		}
+	}()
+	return docChan
+}
+
+func TestKnowledgeLoadDocumentStream(t *testing.T) {
+	db := &streamVectorDB{}
+	kb := &BaseKnowledge{VectorDB: db}
+
+	var last StreamProgress
+	stored, err := kb.LoadDocumentStream(context.Background(), produce(95),
+		WithStreamBatchSize(10),
+		WithStreamConcurrency(3),
+		WithStreamProgress(func(p StreamProgress) { last = p }),
+	)
+	if err != nil {
+		t.Fatalf("LoadDocumentStream failed: %v", err)
+	}
+	if stored != 95 || db.stored != 95 {
+		t.Fatalf("Expected 95 documents stored, got %d (db %d)", stored, db.stored)
+	}
+	if last.Batches != 10 || last.Documents != 95 {
+		t.Errorf("Unexpected final progress: %+v", last)
+	}
+	if peak := db.peak.Load(); peak > 3 {
+		t.Errorf("Expected at most 3 concurrent upserts, got %d", peak)
+	}
+}
+
+func TestKnowledgeLoadDocumentStreamError(t *testing.T) {
+	db := &streamVectorDB{failAt: 30}
+	kb := &BaseKnowledge{VectorDB: db}
+
+	docChan := produce(1000)
+	_, err := kb.LoadDocumentStream(context.Background(), docChan, WithStreamBatchSize(10), WithStreamConcurrency(2))
+	if err == nil || db.stored >= 1000 {
+		t.Fatalf("Expected the stream to stop on upsert error, got err=%v stored=%d", err, db.stored)
+	}
+}
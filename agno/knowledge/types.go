@@ -19,8 +19,8 @@ type TextKnowledgeBase struct {
 }
 
 // NewTextKnowledgeBase creates a new text knowledge base
-func NewTextKnowledgeBase(name, path string, vectorDB VectorDB) *TextKnowledgeBase {
-	base := NewBaseKnowledge(name, vectorDB)
+func NewTextKnowledgeBase(name, path string, vectorDB VectorDB, options ...KnowledgeOption) *TextKnowledgeBase {
+	base := NewBaseKnowledge(name, vectorDB, options...)
 	base.Metadata["description"] = "Text file knowledge base"
 	base.Metadata["path"] = path
 
@@ -96,7 +96,7 @@ func (t *TextKnowledgeBase) loadTextDocuments() ([]*document.Document, error) {
 				if err != nil {
 					return fmt.Errorf("failed to load file %s: %w", path, err)
 				}
-				documents = append(documents, doc)
+				documents = append(documents, t.splitDocument(doc)...)
 			}
 			return nil
 		})
@@ -112,7 +112,7 @@ func (t *TextKnowledgeBase) loadTextDocuments() ([]*document.Document, error) {
 		if err != nil {
 			return nil, err
 		}
-		documents = append(documents, doc)
+		documents = append(documents, t.splitDocument(doc)...)
 	}
 
 	return documents, nil
@@ -152,8 +152,8 @@ type JSONKnowledgeBase struct {
 }
 
 // NewJSONKnowledgeBase creates a new JSON knowledge base
-func NewJSONKnowledgeBase(name, path string, vectorDB VectorDB) *JSONKnowledgeBase {
-	base := NewBaseKnowledge(name, vectorDB)
+func NewJSONKnowledgeBase(name, path string, vectorDB VectorDB, options ...KnowledgeOption) *JSONKnowledgeBase {
+	base := NewBaseKnowledge(name, vectorDB, options...)
 	base.Metadata["description"] = "JSON file knowledge base"
 	base.Metadata["path"] = path
 
@@ -275,7 +275,7 @@ func (j *JSONKnowledgeBase) loadJSONFile(filePath string) ([]*document.Document,
 	doc.AddMetadata("file_extension", GetFileExtension(filePath))
 	doc.AddMetadata("file_size", len(content))
 
-	return []*document.Document{doc}, nil
+	return j.splitDocument(doc), nil
 }
 
 // DocumentKnowledgeBase handles direct document input
@@ -285,8 +285,8 @@ type DocumentKnowledgeBase struct {
 }
 
 // NewDocumentKnowledgeBase creates a new document knowledge base
-func NewDocumentKnowledgeBase(name string, vectorDB VectorDB) *DocumentKnowledgeBase {
-	base := NewBaseKnowledge(name, vectorDB)
+func NewDocumentKnowledgeBase(name string, vectorDB VectorDB, options ...KnowledgeOption) *DocumentKnowledgeBase {
+	base := NewBaseKnowledge(name, vectorDB, options...)
 	base.Metadata["description"] = "Direct document knowledge base"
 
 	return &DocumentKnowledgeBase{
@@ -313,8 +313,13 @@ func (d *DocumentKnowledgeBase) Load(ctx context.Context, recreate bool) error {
 		return fmt.Errorf("no documents provided")
 	}
 
+	var documents []*document.Document
+	for _, doc := range d.Documents {
+		documents = append(documents, d.splitDocument(doc)...)
+	}
+
 	// Convert and load documents
-	convertedDocs := ConvertDocumentPointers(d.Documents)
+	convertedDocs := ConvertDocumentPointers(documents)
 	return d.LoadDocuments(ctx, convertedDocs, recreate)
 }
 
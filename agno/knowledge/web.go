@@ -0,0 +1,246 @@
+package knowledge
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+	"github.com/devalexandre/agno-golang/agno/document/htmltext"
+)
+
+// maxWebPageSize limits how much of a page is read
+const maxWebPageSize = 10 << 20
+
+// WebKnowledgeBase loads web pages, keeping the main text of each page and
+// dropping scripts, styles and navigation
+type WebKnowledgeBase struct {
+	*BaseKnowledge
+	URLs         []string      `json:"urls,omitempty"` // Pages loaded by Load
+	UserAgent    string        `json:"user_agent"`
+	Timeout      time.Duration `json:"timeout"`       // Per page
+	ChunkSize    int           `json:"chunk_size"`    // Maximum chunk size in characters
+	ChunkOverlap int           `json:"chunk_overlap"` // Characters repeated from the previous chunk
+	HTTPClient   *http.Client  `json:"-"`
+}
+
+// WebLoadOptions configures how pages are fetched
+type WebLoadOptions struct {
+	UserAgent string
+	Timeout   time.Duration
+	Metadata  map[string]interface{} // Added to every chunk
+}
+
+// WebOption configures a web page load
+type WebOption func(*WebLoadOptions)
+
+// WithUserAgent configures the User-Agent header sent with requests
+func WithUserAgent(userAgent string) WebOption {
+	return func(o *WebLoadOptions) {
+		o.UserAgent = userAgent
+	}
+}
+
+// WithTimeout configures how long fetching a single page may take
+func WithTimeout(timeout time.Duration) WebOption {
+	return func(o *WebLoadOptions) {
+		o.Timeout = timeout
+	}
+}
+
+// WithWebMetadata configures metadata added to every chunk
+func WithWebMetadata(metadata map[string]interface{}) WebOption {
+	return func(o *WebLoadOptions) {
+		o.Metadata = metadata
+	}
+}
+
+// NewWebKnowledgeBase creates a new web knowledge base
+func NewWebKnowledgeBase(name string, vectorDB VectorDB, options ...KnowledgeOption) *WebKnowledgeBase {
+	base := NewBaseKnowledge(name, vectorDB, options...)
+	base.Metadata["description"] = "Web page knowledge base"
+	base.Metadata["type"] = "web"
+
+	return &WebKnowledgeBase{
+		BaseKnowledge: base,
+		UserAgent:     "Agno-Framework/1.0 (Knowledge)",
+		Timeout:       30 * time.Second,
+		ChunkSize:     1000,
+		ChunkOverlap:  100,
+		HTTPClient:    &http.Client{},
+	}
+}
+
+// Load loads all configured URLs, failing if any page could not be loaded
+func (w *WebKnowledgeBase) Load(ctx context.Context, recreate bool) error {
+	if recreate && w.VectorDB != nil {
+		if err := w.VectorDB.Drop(ctx); err != nil {
+			return fmt.Errorf("failed to drop existing database: %w", err)
+		}
+	}
+
+	failed := w.LoadURLs(ctx, w.URLs)
+	for _, url := range w.URLs {
+		if err, ok := failed[url]; ok {
+			return fmt.Errorf("failed to load %d of %d pages, first %s: %w", len(failed), len(w.URLs), url, err)
+		}
+	}
+	return nil
+}
+
+// LoadAsync loads documents asynchronously
+func (w *WebKnowledgeBase) LoadAsync(ctx context.Context, recreate bool) error {
+	return w.Load(ctx, recreate)
+}
+
+// LoadURL fetches a page, extracts its main text and loads it in chunks
+func (w *WebKnowledgeBase) LoadURL(ctx context.Context, url string, options ...WebOption) error {
+	docs, err := w.fetchDocuments(ctx, url, w.loadOptions(options))
+	if err != nil {
+		return err
+	}
+	return w.LoadDocuments(ctx, ConvertDocumentPointers(docs), false)
+}
+
+// LoadURLs loads each URL in turn. A page that fails does not stop the
+// others; the returned map holds the error of every failed URL and is empty
+// when all pages were loaded.
+func (w *WebKnowledgeBase) LoadURLs(ctx context.Context, urls []string, options ...WebOption) map[string]error {
+	opts := w.loadOptions(options)
+	failed := make(map[string]error)
+
+	for _, url := range urls {
+		if err := ctx.Err(); err != nil {
+			failed[url] = err
+			continue
+		}
+
+		docs, err := w.fetchDocuments(ctx, url, opts)
+		if err == nil {
+			err = w.LoadDocuments(ctx, ConvertDocumentPointers(docs), false)
+		}
+		if err != nil {
+			failed[url] = err
+		}
+	}
+	return failed
+}
+
+// GetInfo returns information about the web knowledge base
+func (w *WebKnowledgeBase) GetInfo() KnowledgeInfo {
+	info := w.BaseKnowledge.GetInfo()
+	info.Type = "web"
+	info.Description = "Web page knowledge base"
+	info.Metadata["sources"] = w.URLs
+	return info
+}
+
+// loadOptions applies options over the knowledge base defaults
+func (w *WebKnowledgeBase) loadOptions(options []WebOption) WebLoadOptions {
+	opts := WebLoadOptions{UserAgent: w.UserAgent, Timeout: w.Timeout}
+	for _, option := range options {
+		option(&opts)
+	}
+	return opts
+}
+
+// fetchDocuments downloads a page and converts its main text into chunk documents
+func (w *WebKnowledgeBase) fetchDocuments(ctx context.Context, url string, opts WebLoadOptions) ([]*document.Document, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if opts.UserAgent != "" {
+		req.Header.Set("User-Agent", opts.UserAgent)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain;q=0.9")
+
+	client := w.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch page: status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxWebPageSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+
+	var title, text string
+	if strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") {
+		text = strings.TrimSpace(string(body))
+	} else {
+		root, err := html.Parse(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		}
+		title, text = htmltext.Extract(root)
+	}
+	if text == "" {
+		return nil, fmt.Errorf("no text content found at %s", url)
+	}
+
+	var chunks []string
+	if w.Chunker != nil {
+		chunks = w.chunkContents(text)
+	} else {
+		chunks = splitWithOverlap(text, w.ChunkSize, w.ChunkOverlap)
+	}
+
+	docs := make([]*document.Document, 0, len(chunks))
+	for i, chunk := range chunks {
+		metadata := make(map[string]interface{}, len(opts.Metadata)+6)
+		for k, v := range opts.Metadata {
+			metadata[k] = v
+		}
+		metadata["source"] = url
+		metadata["url"] = url
+		metadata["type"] = "web"
+		metadata["chunk_index"] = i
+		metadata["total_chunks"] = len(chunks)
+		if title != "" {
+			metadata["title"] = title
+		}
+
+		// Reloading a page replaces its chunks
+		hash := sha1.New()
+		fmt.Fprintf(hash, "%s\x00%d", url, i)
+		id := hex.EncodeToString(hash.Sum(nil))
+
+		docs = append(docs, &document.Document{
+			ID:          id,
+			Name:        title,
+			Content:     chunk,
+			ContentType: "text/plain",
+			Source:      url,
+			Metadata:    metadata,
+			ChunkIndex:  i,
+			ChunkTotal:  len(chunks),
+			CreatedAt:   time.Now(),
+			UpdatedAt:   time.Now(),
+		})
+	}
+	return docs, nil
+}
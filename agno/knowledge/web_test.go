@@ -0,0 +1,63 @@
+package knowledge
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const docsPage = `<html><head><title>Install Guide</title><style>body{}</style></head>
+<body>
+<nav><a href="/">Home</a> | <a href="/docs">Docs</a></nav>
+<main>
+<h1>Installing</h1>
+<p>Run the <code>installer</code> and
+follow the prompts.</p>
+<pre>make install
+make test</pre>
+<script>track()</script>
+<ul><li>Linux</li><li>macOS</li></ul>
+</main>
+<footer>Copyright</footer>
+</body></html>`
+
+func TestWebFetchDocuments(t *testing.T) {
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgent = r.Header.Get("User-Agent")
+		if r.URL.Path == "/missing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(docsPage))
+	}))
+	defer server.Close()
+
+	kb := &WebKnowledgeBase{BaseKnowledge: &BaseKnowledge{}, ChunkSize: 1000}
+	opts := kb.loadOptions([]WebOption{WithUserAgent("docs-bot"), WithWebMetadata(map[string]interface{}{"site": "docs"})})
+
+	docs, err := kb.fetchDocuments(context.Background(), server.URL+"/install", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if userAgent != "docs-bot" {
+		t.Errorf("User-Agent = %q", userAgent)
+	}
+	if len(docs) != 1 || docs[0].Metadata["title"] != "Install Guide" || docs[0].Metadata["url"] != server.URL+"/install" || docs[0].Metadata["site"] != "docs" {
+		t.Errorf("unexpected documents %+v", docs)
+	}
+
+	if _, err := kb.fetchDocuments(context.Background(), server.URL+"/missing", opts); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("missing page error = %v", err)
+	}
+
+	// A failed page does not stop the rest
+	failed := kb.LoadURLs(context.Background(), []string{server.URL + "/missing", server.URL + "/install"})
+	if len(failed) != 2 || !strings.Contains(failed[server.URL+"/missing"].Error(), "404") ||
+		!strings.Contains(failed[server.URL+"/install"].Error(), "vector database not configured") {
+		t.Errorf("failed = %v", failed)
+	}
+}
@@ -14,6 +14,10 @@ type UserMemory struct {
 	Summary   string    `json:"summary,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Duplicate is set by Memory.CreateMemory when the memory was matched to
+	// an existing one instead of being stored. It is not persisted.
+	Duplicate bool `json:"-"`
 }
 
 // SessionSummary represents a summary of a session
@@ -24,6 +28,11 @@ type SessionSummary struct {
 	Summary   string    `json:"summary"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	// Usage is the token usage of the call that generated the summary. It is
+	// set by Memory.CreateSessionSummary and CreateSessionSummaryStream and is
+	// not persisted.
+	Usage *SummaryUsage `json:"usage,omitempty"`
 }
 
 // MemoryDatabase defines the interface for memory database operations
@@ -31,8 +40,10 @@ type MemoryDatabase interface {
 	// User Memory operations
 	CreateUserMemory(ctx context.Context, memory *UserMemory) error
 	GetUserMemories(ctx context.Context, userID string) ([]*UserMemory, error)
+	// UpdateUserMemory and DeleteUserMemory only touch the memory if it
+	// belongs to the given user, and fail if it is not found
 	UpdateUserMemory(ctx context.Context, memory *UserMemory) error
-	DeleteUserMemory(ctx context.Context, memoryID string) error
+	DeleteUserMemory(ctx context.Context, userID, memoryID string) error
 	ClearUserMemories(ctx context.Context, userID string) error
 
 	// Session Summary operations
@@ -41,6 +52,10 @@ type MemoryDatabase interface {
 	UpdateSessionSummary(ctx context.Context, summary *SessionSummary) error
 	DeleteSessionSummary(ctx context.Context, userID, sessionID string) error
 
+	// Session turn counts
+	IncrementSessionTurnCount(ctx context.Context, userID, sessionID string) (int, error)
+	GetSessionTurnCount(ctx context.Context, userID, sessionID string) (int, error)
+
 	// Database management
 	CreateTables(ctx context.Context) error
 	UpgradeSchema(ctx context.Context) error
@@ -55,11 +70,11 @@ type MemoryManager interface {
 	// Get all memories for a user
 	GetUserMemories(ctx context.Context, userID string) ([]*UserMemory, error)
 
-	// Update an existing memory
-	UpdateMemory(ctx context.Context, memoryID, newContent string) (*UserMemory, error)
+	// Update the content of one of the user's memories
+	UpdateMemory(ctx context.Context, userID, memoryID, newContent string) error
 
-	// Delete a specific memory
-	DeleteMemory(ctx context.Context, memoryID string) error
+	// Delete one of the user's memories
+	DeleteMemory(ctx context.Context, userID, memoryID string) error
 
 	// Clear all memories for a user
 	ClearUserMemories(ctx context.Context, userID string) error
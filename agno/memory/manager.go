@@ -87,13 +87,13 @@ func (emm *EnhancedMemoryManager) AddMemory(ctx context.Context, userID, memoryC
 	return memory, nil
 }
 
-// DeleteMemory deletes a memory from the database
-func (emm *EnhancedMemoryManager) DeleteMemory(ctx context.Context, memoryID string) error {
+// DeleteMemory deletes one of the user's memories from the database
+func (emm *EnhancedMemoryManager) DeleteMemory(ctx context.Context, userID, memoryID string) error {
 	if emm.DB == nil {
 		return fmt.Errorf("memory database not provided")
 	}
 
-	err := emm.DB.DeleteUserMemory(ctx, memoryID)
+	err := emm.DB.DeleteUserMemory(ctx, userID, memoryID)
 	if err != nil {
 		return fmt.Errorf("failed to delete memory from db: %w", err)
 	}
@@ -2,10 +2,13 @@ package memory
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"math"
 	"sort"
 	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/devalexandre/agno-golang/agno/embedder"
 	"github.com/devalexandre/agno-golang/agno/models"
@@ -16,23 +19,63 @@ type Memory struct {
 	Model    models.AgnoModelInterface
 	DB       MemoryDatabase
 	Embedder embedder.Embedder // Optional: for semantic search
+
+	// DedupThreshold is the similarity (0-1) at or above which a new memory
+	// is considered a duplicate of an existing one. Zero disables dedup.
+	DedupThreshold float64
+
+	autoSummarizeEvery int
+
+	// embeddings caches the embeddings of memories by content hash, so a
+	// search only embeds its query
+	embeddingsMu sync.Mutex
+	embeddings   map[[sha256.Size]byte][]float64
+}
+
+// Option configures a Memory
+type Option func(*Memory)
+
+// WithDedupThreshold makes CreateMemory skip memories whose similarity to an
+// existing memory of the user is at or above threshold. Similarity is the
+// cosine similarity of the embeddings when an embedder is set, and word
+// overlap otherwise.
+func WithDedupThreshold(threshold float64) Option {
+	return func(m *Memory) {
+		m.DedupThreshold = threshold
+	}
+}
+
+// WithAutoSummarize regenerates and stores the session summary every
+// everyNTurns turns recorded with RecordSessionTurn
+func WithAutoSummarize(everyNTurns int) Option {
+	return func(m *Memory) {
+		m.autoSummarizeEvery = everyNTurns
+	}
+}
+
+// AutoSummarizeEvery returns the turn interval set by WithAutoSummarize, or
+// zero if automatic summaries are disabled
+func (m *Memory) AutoSummarizeEvery() int {
+	return m.autoSummarizeEvery
 }
 
 // NewMemory creates a new Memory instance
-func NewMemory(model models.AgnoModelInterface, db MemoryDatabase) *Memory {
-	return &Memory{
+func NewMemory(model models.AgnoModelInterface, db MemoryDatabase, opts ...Option) *Memory {
+	m := &Memory{
 		Model: model,
 		DB:    db,
 	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 // NewMemoryWithEmbedder creates a new Memory instance with embedder for semantic search
-func NewMemoryWithEmbedder(model models.AgnoModelInterface, db MemoryDatabase, emb embedder.Embedder) *Memory {
-	return &Memory{
-		Model:    model,
-		DB:       db,
-		Embedder: emb,
-	}
+func NewMemoryWithEmbedder(model models.AgnoModelInterface, db MemoryDatabase, emb embedder.Embedder, opts ...Option) *Memory {
+	m := NewMemory(model, db, opts...)
+	m.Embedder = emb
+	return m
 }
 
 // CreateMemory creates a memory from user input and AI response
@@ -48,6 +91,17 @@ func (m *Memory) CreateMemory(ctx context.Context, userID, input, response strin
 		return nil, nil
 	}
 
+	if m.DedupThreshold > 0 {
+		existing, err := m.findDuplicateMemory(ctx, userID, memoryContent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for duplicate memories: %w", err)
+		}
+		if existing != nil {
+			existing.Duplicate = true
+			return existing, nil
+		}
+	}
+
 	memory := &UserMemory{
 		UserID:  userID,
 		Memory:  memoryContent,
@@ -63,37 +117,77 @@ func (m *Memory) CreateMemory(ctx context.Context, userID, input, response strin
 	return memory, nil
 }
 
+// findDuplicateMemory returns the user's memory most similar to content if
+// its similarity reaches DedupThreshold
+func (m *Memory) findDuplicateMemory(ctx context.Context, userID, content string) (*UserMemory, error) {
+	memories, err := m.DB.GetUserMemories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(memories) == 0 {
+		return nil, nil
+	}
+
+	similarity := func(text string) float64 {
+		return textSimilarity(content, text)
+	}
+	if m.Embedder != nil {
+		if contentEmbedding, err := m.memoryEmbedding(content); err == nil {
+			similarity = func(text string) float64 {
+				embedding, err := m.memoryEmbedding(text)
+				if err != nil {
+					return textSimilarity(content, text)
+				}
+				return cosineSimilarity(contentEmbedding, embedding)
+			}
+		}
+	}
+
+	var best *UserMemory
+	bestScore := 0.0
+	for _, memory := range memories {
+		if score := similarity(memory.Memory); score > bestScore {
+			best, bestScore = memory, score
+		}
+	}
+	if bestScore >= m.DedupThreshold {
+		return best, nil
+	}
+	return nil, nil
+}
+
 // GetUserMemories gets all memories for a user
 func (m *Memory) GetUserMemories(ctx context.Context, userID string) ([]*UserMemory, error) {
 	return m.DB.GetUserMemories(ctx, userID)
 }
 
-// UpdateMemory updates an existing memory
-func (m *Memory) UpdateMemory(ctx context.Context, memoryID, newContent string) (*UserMemory, error) {
-	// We need to implement a way to get memory by ID first
-	// This is a simplified implementation
-	memories, err := m.DB.GetUserMemories(ctx, "")
+// UpdateMemory replaces the content of one of the user's memories
+func (m *Memory) UpdateMemory(ctx context.Context, userID, memoryID, newContent string) error {
+	if strings.TrimSpace(newContent) == "" {
+		return fmt.Errorf("memory content cannot be empty")
+	}
+
+	memories, err := m.DB.GetUserMemories(ctx, userID)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("failed to get user memories: %w", err)
 	}
 
 	for _, memory := range memories {
 		if memory.ID == memoryID {
 			memory.Memory = newContent
-			err = m.DB.UpdateUserMemory(ctx, memory)
-			if err != nil {
-				return nil, fmt.Errorf("failed to update memory: %w", err)
+			if err := m.DB.UpdateUserMemory(ctx, memory); err != nil {
+				return fmt.Errorf("failed to update memory: %w", err)
 			}
-			return memory, nil
+			return nil
 		}
 	}
 
-	return nil, fmt.Errorf("memory not found: %s", memoryID)
+	return fmt.Errorf("memory not found: %s", memoryID)
 }
 
-// DeleteMemory deletes a specific memory
-func (m *Memory) DeleteMemory(ctx context.Context, memoryID string) error {
-	return m.DB.DeleteUserMemory(ctx, memoryID)
+// DeleteMemory deletes one of the user's memories
+func (m *Memory) DeleteMemory(ctx context.Context, userID, memoryID string) error {
+	return m.DB.DeleteUserMemory(ctx, userID, memoryID)
 }
 
 // ClearUserMemories clears all memories for a user
@@ -104,7 +198,7 @@ func (m *Memory) ClearUserMemories(ctx context.Context, userID string) error {
 // CreateSessionSummary creates a session summary
 func (m *Memory) CreateSessionSummary(ctx context.Context, userID, sessionID string, messages []map[string]interface{}) (*SessionSummary, error) {
 	// Generate summary using AI
-	summaryContent, err := m.generateSessionSummary(ctx, messages)
+	summaryContent, usage, err := m.generateSessionSummary(ctx, messages)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate session summary: %w", err)
 	}
@@ -113,6 +207,7 @@ func (m *Memory) CreateSessionSummary(ctx context.Context, userID, sessionID str
 		UserID:    userID,
 		SessionID: sessionID,
 		Summary:   summaryContent,
+		Usage:     &usage,
 	}
 
 	err = m.DB.CreateSessionSummary(ctx, summary)
@@ -128,6 +223,37 @@ func (m *Memory) GetSessionSummary(ctx context.Context, userID, sessionID string
 	return m.DB.GetSessionSummary(ctx, userID, sessionID)
 }
 
+// GetSessionTurnCount returns the number of turns recorded for a session
+func (m *Memory) GetSessionTurnCount(ctx context.Context, userID, sessionID string) (int, error) {
+	return m.DB.GetSessionTurnCount(ctx, userID, sessionID)
+}
+
+// RecordSessionTurn counts one conversation turn in the session. When
+// WithAutoSummarize is set and the turn count reaches a multiple of N, the
+// session summary is regenerated from the previous summary plus messages,
+// which should hold the turns since the last summary, and returned. Otherwise
+// the returned summary is nil.
+func (m *Memory) RecordSessionTurn(ctx context.Context, userID, sessionID string, messages []map[string]interface{}) (*SessionSummary, error) {
+	turns, err := m.DB.IncrementSessionTurnCount(ctx, userID, sessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record session turn: %w", err)
+	}
+
+	if m.autoSummarizeEvery <= 0 || turns%m.autoSummarizeEvery != 0 {
+		return nil, nil
+	}
+
+	// Fold the previous summary in so the new one covers the whole session
+	if previous, err := m.DB.GetSessionSummary(ctx, userID, sessionID); err == nil && previous.Summary != "" {
+		messages = append([]map[string]interface{}{{
+			"role":    "system",
+			"content": "Summary of the conversation so far: " + previous.Summary,
+		}}, messages...)
+	}
+
+	return m.CreateSessionSummary(ctx, userID, sessionID, messages)
+}
+
 // extractMemoryFromConversation uses AI to extract meaningful information
 func (m *Memory) extractMemoryFromConversation(ctx context.Context, input, response string) (string, error) {
 	prompt := fmt.Sprintf(`Analyze the following conversation between a user and an AI assistant.
@@ -175,8 +301,21 @@ Important information to remember about the user:`, input, response)
 	return memory, nil
 }
 
-// generateSessionSummary creates a summary of the session
-func (m *Memory) generateSessionSummary(ctx context.Context, messages []map[string]interface{}) (string, error) {
+// generateSessionSummary creates a summary of the session and estimates the
+// token usage of the call
+func (m *Memory) generateSessionSummary(ctx context.Context, messages []map[string]interface{}) (string, SummaryUsage, error) {
+	aiMessages := sessionSummaryMessages(messages)
+
+	response, err := m.Model.Invoke(ctx, aiMessages)
+	if err != nil {
+		return "", SummaryUsage{}, err
+	}
+
+	return strings.TrimSpace(response.Content), summaryUsage(estimateMessageTokens(aiMessages), response.Content), nil
+}
+
+// sessionSummaryMessages builds the messages asking the model to summarize a conversation
+func sessionSummaryMessages(messages []map[string]interface{}) []models.Message {
 	// Convert messages to a readable format
 	var conversation strings.Builder
 	for _, msg := range messages {
@@ -206,7 +345,7 @@ Conversation:
 
 Summary:`, conversation.String())
 
-	aiMessages := []models.Message{
+	return []models.Message{
 		{
 			Role:    models.TypeSystemRole,
 			Content: "You are a conversation summarization assistant. Create concise, informative summaries.",
@@ -216,13 +355,6 @@ Summary:`, conversation.String())
 			Content: prompt,
 		},
 	}
-
-	response, err := m.Model.Invoke(ctx, aiMessages)
-	if err != nil {
-		return "", err
-	}
-
-	return strings.TrimSpace(response.Content), nil
 }
 
 // GetMemoriesAsContext returns user memories formatted for AI context
@@ -246,6 +378,40 @@ func (m *Memory) GetMemoriesAsContext(ctx context.Context, userID string) (strin
 	return context.String(), nil
 }
 
+// memoryEmbedding returns the embedding of a memory's content, embedding it
+// only the first time the content is seen
+func (m *Memory) memoryEmbedding(content string) ([]float64, error) {
+	key := sha256.Sum256([]byte(content))
+	m.embeddingsMu.Lock()
+	embedding, ok := m.embeddings[key]
+	m.embeddingsMu.Unlock()
+	if ok {
+		return embedding, nil
+	}
+
+	embedding, err := m.Embedder.GetEmbedding(content)
+	if err != nil {
+		return nil, err
+	}
+	m.embeddingsMu.Lock()
+	if m.embeddings == nil {
+		m.embeddings = make(map[[sha256.Size]byte][]float64)
+	}
+	m.embeddings[key] = embedding
+	m.embeddingsMu.Unlock()
+	return embedding, nil
+}
+
+// SearchUserMemories returns the memories most relevant to the query, ranked
+// by semantic similarity when an embedder is configured and by keyword
+// matching otherwise
+func (m *Memory) SearchUserMemories(ctx context.Context, userID, query string, limit int) ([]*UserMemory, error) {
+	if m.Embedder == nil {
+		return m.SearchMemoriesKeyword(ctx, userID, query, limit)
+	}
+	return m.SearchMemoriesSemantic(ctx, userID, query, limit)
+}
+
 // SearchMemoriesSemantic performs semantic search on user memories
 // Returns memories ranked by relevance to the query
 func (m *Memory) SearchMemoriesSemantic(ctx context.Context, userID, query string, limit int) ([]*UserMemory, error) {
@@ -279,7 +445,7 @@ func (m *Memory) SearchMemoriesSemantic(ctx context.Context, userID, query strin
 
 	for _, memory := range allMemories {
 		// Generate embedding for the memory
-		memoryEmbedding, err := m.Embedder.GetEmbedding(memory.Memory)
+		memoryEmbedding, err := m.memoryEmbedding(memory.Memory)
 		if err != nil {
 			// Skip memories that fail to embed
 			continue
@@ -356,7 +522,7 @@ func (m *Memory) SearchMemoriesHybrid(ctx context.Context, userID, query string,
 	for _, memory := range allMemories {
 		// Semantic score
 		var semanticScore float64
-		memoryEmbedding, err := m.Embedder.GetEmbedding(memory.Memory)
+		memoryEmbedding, err := m.memoryEmbedding(memory.Memory)
 		if err == nil {
 			semanticScore = cosineSimilarity(queryEmbedding, memoryEmbedding)
 		}
@@ -462,6 +628,34 @@ func cosineSimilarity(a, b []float64) float64 {
 	return dotProduct / (math.Sqrt(normA) * math.Sqrt(normB))
 }
 
+// textSimilarity returns the Jaccard similarity of the word sets of a and b,
+// ignoring case and punctuation
+func textSimilarity(a, b string) float64 {
+	wordsA, wordsB := wordSet(a), wordSet(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(wordsA)+len(wordsB)-shared)
+}
+
+func wordSet(text string) map[string]bool {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
+
 // calculateKeywordScore calculates a simple keyword matching score
 func calculateKeywordScore(query, text string) float64 {
 	queryWords := strings.Fields(query)
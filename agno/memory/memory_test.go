@@ -0,0 +1,180 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/embedder"
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// topicEmbedder embeds text as a vector of topic hits, so texts about the same
+// topic are similar without sharing words with the query
+type topicEmbedder struct {
+	embedder.Embedder
+	topics [][]string
+}
+
+func (e *topicEmbedder) GetEmbedding(text string) ([]float64, error) {
+	text = strings.ToLower(text)
+	vector := make([]float64, len(e.topics))
+	for i, words := range e.topics {
+		for _, word := range words {
+			if strings.Contains(text, word) {
+				vector[i]++
+			}
+		}
+	}
+	return vector, nil
+}
+
+// countingEmbedder counts the texts it embeds
+type countingEmbedder struct {
+	topicEmbedder
+	calls int
+}
+
+func (e *countingEmbedder) GetEmbedding(text string) ([]float64, error) {
+	e.calls++
+	return e.topicEmbedder.GetEmbedding(text)
+}
+
+// memoriesDB returns a fixed set of user memories
+type memoriesDB struct {
+	MemoryDatabase
+	memories []*UserMemory
+}
+
+func (db *memoriesDB) GetUserMemories(ctx context.Context, userID string) ([]*UserMemory, error) {
+	return db.memories, nil
+}
+
+func (db *memoriesDB) CreateUserMemory(ctx context.Context, memory *UserMemory) error {
+	memory.ID = fmt.Sprintf("%d", len(db.memories)+1)
+	db.memories = append(db.memories, memory)
+	return nil
+}
+
+// replyModel answers every Invoke with the same content
+type replyModel struct {
+	models.AgnoModelInterface
+	reply string
+}
+
+func (m *replyModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	return &models.MessageResponse{Content: m.reply}, nil
+}
+
+func TestSearchUserMemories(t *testing.T) {
+	db := &memoriesDB{memories: []*UserMemory{
+		{ID: "1", Memory: "The user has a golden retriever named Max"},
+		{ID: "2", Memory: "The user is vegetarian and loves pasta"},
+		{ID: "3", Memory: "The user works as a Go developer"},
+	}}
+	ctx := context.Background()
+
+	t.Run("Semantic", func(t *testing.T) {
+		emb := &topicEmbedder{topics: [][]string{
+			{"dog", "retriever", "pet"},
+			{"food", "pasta", "vegetarian", "dinner"},
+			{"developer", "programming", "code"},
+		}}
+		m := NewMemoryWithEmbedder(nil, db, emb)
+
+		results, err := m.SearchUserMemories(ctx, "u1", "What should I cook for dinner? Any food ideas?", 1)
+		if err != nil {
+			t.Fatalf("SearchUserMemories: %v", err)
+		}
+		if len(results) != 1 || results[0].ID != "2" {
+			t.Fatalf("expected the food memory, got %+v", results)
+		}
+	})
+
+	t.Run("Memory embeddings are cached", func(t *testing.T) {
+		emb := &countingEmbedder{topicEmbedder: topicEmbedder{topics: [][]string{{"dog"}, {"pasta"}, {"developer"}}}}
+		m := NewMemoryWithEmbedder(nil, db, emb)
+
+		for _, query := range []string{"pasta", "dog", "pasta"} {
+			if _, err := m.SearchUserMemories(ctx, "u1", query, 1); err != nil {
+				t.Fatalf("SearchUserMemories: %v", err)
+			}
+		}
+		// Each memory once, then only the queries
+		if want := len(db.memories) + 3; emb.calls != want {
+			t.Errorf("expected %d embedding calls, got %d", want, emb.calls)
+		}
+	})
+
+	t.Run("Substring fallback", func(t *testing.T) {
+		m := NewMemory(nil, db)
+
+		results, err := m.SearchUserMemories(ctx, "u1", "pasta", 5)
+		if err != nil {
+			t.Fatalf("SearchUserMemories: %v", err)
+		}
+		if len(results) != 1 || results[0].ID != "2" {
+			t.Fatalf("expected only the pasta memory, got %+v", results)
+		}
+	})
+}
+
+func TestCreateMemoryDedup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Textual", func(t *testing.T) {
+		db := &memoriesDB{memories: []*UserMemory{
+			{ID: "1", UserID: "u1", Memory: "The user lives in Lisbon and works as a nurse."},
+		}}
+		m := NewMemory(&replyModel{reply: "The user works as a nurse and lives in Lisbon"}, db, WithDedupThreshold(0.8))
+
+		mem, err := m.CreateMemory(ctx, "u1", "hi", "hello")
+		if err != nil {
+			t.Fatalf("CreateMemory: %v", err)
+		}
+		if mem.ID != "1" || !mem.Duplicate || len(db.memories) != 1 {
+			t.Fatalf("expected the existing memory to be returned, got %+v (%d stored)", mem, len(db.memories))
+		}
+
+		m.Model = &replyModel{reply: "The user has two cats named Tom and Jerry"}
+		mem, err = m.CreateMemory(ctx, "u1", "hi", "hello")
+		if err != nil {
+			t.Fatalf("CreateMemory: %v", err)
+		}
+		if mem.Duplicate || len(db.memories) != 2 {
+			t.Fatalf("expected a new memory, got %+v (%d stored)", mem, len(db.memories))
+		}
+	})
+
+	t.Run("Semantic", func(t *testing.T) {
+		db := &memoriesDB{memories: []*UserMemory{
+			{ID: "1", UserID: "u1", Memory: "The user owns a retriever"},
+		}}
+		emb := &topicEmbedder{topics: [][]string{{"dog", "retriever"}, {"pasta", "food"}}}
+		m := NewMemoryWithEmbedder(&replyModel{reply: "User has a dog at home"}, db, emb, WithDedupThreshold(0.9))
+
+		mem, err := m.CreateMemory(ctx, "u1", "hi", "hello")
+		if err != nil {
+			t.Fatalf("CreateMemory: %v", err)
+		}
+		if mem.ID != "1" || !mem.Duplicate {
+			t.Fatalf("expected the semantic duplicate to be matched, got %+v", mem)
+		}
+	})
+
+	t.Run("Disabled", func(t *testing.T) {
+		db := &memoriesDB{memories: []*UserMemory{
+			{ID: "1", UserID: "u1", Memory: "The user lives in Lisbon"},
+		}}
+		m := NewMemory(&replyModel{reply: "The user lives in Lisbon"}, db)
+
+		mem, err := m.CreateMemory(ctx, "u1", "hi", "hello")
+		if err != nil {
+			t.Fatalf("CreateMemory: %v", err)
+		}
+		if mem.Duplicate || len(db.memories) != 2 {
+			t.Fatalf("expected dedup to be off by default, got %+v", mem)
+		}
+	})
+}
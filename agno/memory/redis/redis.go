@@ -0,0 +1,354 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/memory"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// RedisMemoryDb implements MemoryDatabase interface backed by Redis, so
+// several agent workers can share the same memories.
+//
+// Keys are laid out as:
+//
+//	<prefix>:memory:<memory_id>                     memory JSON
+//	<prefix>:user:<user_id>:memories                sorted set of the user's memory IDs
+//	<prefix>:summary:<user_id>:<session_id>         session summary JSON
+//	<prefix>:turns:<user_id>:<session_id>           session turn count
+//
+// IDs are escaped so a ":" in one cannot make two keys collide.
+type RedisMemoryDb struct {
+	client    *goredis.Client
+	keyPrefix string
+	ttl       time.Duration
+}
+
+// Option configures a RedisMemoryDb
+type Option func(*RedisMemoryDb)
+
+// WithTTL makes memories and session summaries expire after ttl. Writing a
+// memory or summary resets its TTL. A ttl <= 0 disables expiration.
+func WithTTL(ttl time.Duration) Option {
+	return func(db *RedisMemoryDb) {
+		db.ttl = ttl
+	}
+}
+
+// NewRedisMemoryDb creates a new Redis memory database instance
+func NewRedisMemoryDb(addr, password, keyPrefix string, opts ...Option) (*RedisMemoryDb, error) {
+	if keyPrefix == "" {
+		keyPrefix = "agno"
+	}
+
+	db := &RedisMemoryDb{
+		client: goredis.NewClient(&goredis.Options{
+			Addr:     addr,
+			Password: password,
+		}),
+		keyPrefix: keyPrefix,
+	}
+	for _, opt := range opts {
+		opt(db)
+	}
+
+	if err := db.client.Ping(context.Background()).Err(); err != nil {
+		db.client.Close()
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+
+	return db, nil
+}
+
+// keyEscaper percent-encodes the key separator in IDs
+var keyEscaper = strings.NewReplacer("%", "%25", ":", "%3A")
+
+func (db *RedisMemoryDb) memoryKey(memoryID string) string {
+	return fmt.Sprintf("%s:memory:%s", db.keyPrefix, keyEscaper.Replace(memoryID))
+}
+
+func (db *RedisMemoryDb) userMemoriesKey(userID string) string {
+	return fmt.Sprintf("%s:user:%s:memories", db.keyPrefix, keyEscaper.Replace(userID))
+}
+
+func (db *RedisMemoryDb) summaryKey(userID, sessionID string) string {
+	return fmt.Sprintf("%s:summary:%s:%s", db.keyPrefix, keyEscaper.Replace(userID), keyEscaper.Replace(sessionID))
+}
+
+func (db *RedisMemoryDb) turnsKey(userID, sessionID string) string {
+	return fmt.Sprintf("%s:turns:%s:%s", db.keyPrefix, keyEscaper.Replace(userID), keyEscaper.Replace(sessionID))
+}
+
+// saveMemory writes the memory and indexes it under its user
+func (db *RedisMemoryDb) saveMemory(ctx context.Context, mem *memory.UserMemory) error {
+	data, err := json.Marshal(mem)
+	if err != nil {
+		return fmt.Errorf("failed to marshal memory: %w", err)
+	}
+
+	userKey := db.userMemoriesKey(mem.UserID)
+	_, err = db.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Set(ctx, db.memoryKey(mem.ID), data, db.ttl)
+		pipe.ZAdd(ctx, userKey, goredis.Z{Score: float64(mem.CreatedAt.UnixNano()), Member: mem.ID})
+		if db.ttl > 0 {
+			pipe.Expire(ctx, userKey, db.ttl)
+		}
+		return nil
+	})
+	return err
+}
+
+// getMemory loads a memory by ID, returning nil if it does not exist
+func (db *RedisMemoryDb) getMemory(ctx context.Context, memoryID string) (*memory.UserMemory, error) {
+	data, err := db.client.Get(ctx, db.memoryKey(memoryID)).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var mem memory.UserMemory
+	if err := json.Unmarshal(data, &mem); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal memory: %w", err)
+	}
+	return &mem, nil
+}
+
+// CreateUserMemory creates a new user memory
+func (db *RedisMemoryDb) CreateUserMemory(ctx context.Context, mem *memory.UserMemory) error {
+	if mem.ID == "" {
+		mem.ID = uuid.New().String()
+	}
+	mem.CreatedAt = time.Now()
+	mem.UpdatedAt = time.Now()
+
+	return db.saveMemory(ctx, mem)
+}
+
+// GetUserMemories retrieves all memories for a user, newest first
+func (db *RedisMemoryDb) GetUserMemories(ctx context.Context, userID string) ([]*memory.UserMemory, error) {
+	userKey := db.userMemoriesKey(userID)
+	ids, err := db.client.ZRevRange(ctx, userKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return []*memory.UserMemory{}, nil
+	}
+
+	keys := make([]string, len(ids))
+	for i, id := range ids {
+		keys[i] = db.memoryKey(id)
+	}
+	values, err := db.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	memories := make([]*memory.UserMemory, 0, len(values))
+	var expired []interface{}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			// The memory expired; drop it from the user's index
+			expired = append(expired, ids[i])
+			continue
+		}
+		var mem memory.UserMemory
+		if err := json.Unmarshal([]byte(data), &mem); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal memory: %w", err)
+		}
+		memories = append(memories, &mem)
+	}
+
+	if len(expired) > 0 {
+		if err := db.client.ZRem(ctx, userKey, expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+
+	sort.SliceStable(memories, func(i, j int) bool {
+		return memories[i].CreatedAt.After(memories[j].CreatedAt)
+	})
+
+	return memories, nil
+}
+
+// UpdateUserMemory updates an existing user memory
+func (db *RedisMemoryDb) UpdateUserMemory(ctx context.Context, mem *memory.UserMemory) error {
+	existing, err := db.getMemory(ctx, mem.ID)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.UserID != mem.UserID {
+		return fmt.Errorf("memory not found: %s", mem.ID)
+	}
+
+	mem.CreatedAt = existing.CreatedAt
+	mem.UpdatedAt = time.Now()
+
+	return db.saveMemory(ctx, mem)
+}
+
+// DeleteUserMemory deletes a specific user memory
+func (db *RedisMemoryDb) DeleteUserMemory(ctx context.Context, userID, memoryID string) error {
+	existing, err := db.getMemory(ctx, memoryID)
+	if err != nil {
+		return err
+	}
+	if existing == nil || existing.UserID != userID {
+		return fmt.Errorf("memory not found: %s", memoryID)
+	}
+
+	_, err = db.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		pipe.Del(ctx, db.memoryKey(memoryID))
+		pipe.ZRem(ctx, db.userMemoriesKey(userID), memoryID)
+		return nil
+	})
+	return err
+}
+
+// ClearUserMemories deletes all memories for a user
+func (db *RedisMemoryDb) ClearUserMemories(ctx context.Context, userID string) error {
+	userKey := db.userMemoriesKey(userID)
+	ids, err := db.client.ZRange(ctx, userKey, 0, -1).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(ids)+1)
+	for _, id := range ids {
+		keys = append(keys, db.memoryKey(id))
+	}
+	keys = append(keys, userKey)
+
+	return db.client.Del(ctx, keys...).Err()
+}
+
+// CreateSessionSummary creates a new session summary, replacing any existing
+// summary of the same session
+func (db *RedisMemoryDb) CreateSessionSummary(ctx context.Context, summary *memory.SessionSummary) error {
+	if summary.ID == "" {
+		summary.ID = uuid.New().String()
+	}
+	summary.CreatedAt = time.Now()
+	summary.UpdatedAt = time.Now()
+
+	return db.saveSummary(ctx, summary)
+}
+
+func (db *RedisMemoryDb) saveSummary(ctx context.Context, summary *memory.SessionSummary) error {
+	// Usage is not persisted, matching the other backends
+	record := *summary
+	record.Usage = nil
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal session summary: %w", err)
+	}
+
+	return db.client.Set(ctx, db.summaryKey(summary.UserID, summary.SessionID), data, db.ttl).Err()
+}
+
+// GetSessionSummary retrieves a session summary
+func (db *RedisMemoryDb) GetSessionSummary(ctx context.Context, userID, sessionID string) (*memory.SessionSummary, error) {
+	data, err := db.client.Get(ctx, db.summaryKey(userID, sessionID)).Bytes()
+	if errors.Is(err, goredis.Nil) {
+		return nil, fmt.Errorf("session summary not found for user %s, session %s", userID, sessionID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var summary memory.SessionSummary
+	if err := json.Unmarshal(data, &summary); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal session summary: %w", err)
+	}
+	return &summary, nil
+}
+
+// UpdateSessionSummary updates an existing session summary
+func (db *RedisMemoryDb) UpdateSessionSummary(ctx context.Context, summary *memory.SessionSummary) error {
+	existing, err := db.GetSessionSummary(ctx, summary.UserID, summary.SessionID)
+	if err != nil {
+		return err
+	}
+
+	existing.Summary = summary.Summary
+	existing.UpdatedAt = time.Now()
+	summary.UpdatedAt = existing.UpdatedAt
+
+	return db.saveSummary(ctx, existing)
+}
+
+// DeleteSessionSummary deletes a session summary
+func (db *RedisMemoryDb) DeleteSessionSummary(ctx context.Context, userID, sessionID string) error {
+	return db.client.Del(ctx, db.summaryKey(userID, sessionID)).Err()
+}
+
+// IncrementSessionTurnCount adds one turn to a session and returns the new count
+func (db *RedisMemoryDb) IncrementSessionTurnCount(ctx context.Context, userID, sessionID string) (int, error) {
+	key := db.turnsKey(userID, sessionID)
+
+	var incr *goredis.IntCmd
+	_, err := db.client.TxPipelined(ctx, func(pipe goredis.Pipeliner) error {
+		incr = pipe.Incr(ctx, key)
+		if db.ttl > 0 {
+			pipe.Expire(ctx, key, db.ttl)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(incr.Val()), nil
+}
+
+// GetSessionTurnCount returns the number of turns recorded for a session
+func (db *RedisMemoryDb) GetSessionTurnCount(ctx context.Context, userID, sessionID string) (int, error) {
+	count, err := db.client.Get(ctx, db.turnsKey(userID, sessionID)).Int()
+	if errors.Is(err, goredis.Nil) {
+		return 0, nil
+	}
+	return count, err
+}
+
+// CreateTables is a no-op; Redis needs no schema
+func (db *RedisMemoryDb) CreateTables(ctx context.Context) error {
+	return nil
+}
+
+// UpgradeSchema is a no-op; Redis needs no schema
+func (db *RedisMemoryDb) UpgradeSchema(ctx context.Context) error {
+	return nil
+}
+
+// DropTables deletes every key under the key prefix
+func (db *RedisMemoryDb) DropTables(ctx context.Context) error {
+	iter := db.client.Scan(ctx, 0, db.keyPrefix+":*", 100).Iterator()
+	var keys []string
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return db.client.Del(ctx, keys...).Err()
+}
+
+// Close closes the Redis client
+func (db *RedisMemoryDb) Close() error {
+	return db.client.Close()
+}
@@ -0,0 +1,182 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/memory"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func setupRedisContainer(t *testing.T) (string, func()) {
+	ctx := context.Background()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "redis:7-alpine",
+			ExposedPorts: []string{"6379/tcp"},
+			WaitingFor:   wait.ForLog("Ready to accept connections").WithStartupTimeout(60 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		t.Fatalf("Failed to start Redis container: %v", err)
+	}
+
+	endpoint, err := container.Endpoint(ctx, "")
+	if err != nil {
+		t.Fatalf("Failed to get container endpoint: %v", err)
+	}
+
+	cleanup := func() {
+		if err := container.Terminate(ctx); err != nil {
+			t.Logf("Failed to terminate container: %v", err)
+		}
+	}
+
+	return endpoint, cleanup
+}
+
+func TestRedisKeysEscapeIDs(t *testing.T) {
+	db := &RedisMemoryDb{keyPrefix: "agno"}
+
+	// Without escaping both would be "agno:summary:a:b:c"
+	if db.summaryKey("a:b", "c") == db.summaryKey("a", "b:c") {
+		t.Errorf("summary keys collide: %q", db.summaryKey("a", "b:c"))
+	}
+	if db.turnsKey("a:b", "c") == db.turnsKey("a", "b:c") {
+		t.Errorf("turns keys collide: %q", db.turnsKey("a", "b:c"))
+	}
+	if got := db.userMemoriesKey("a:b%"); got != "agno:user:a%3Ab%25:memories" {
+		t.Errorf("unexpected user key %q", got)
+	}
+	if got := db.summaryKey("u1", "s1"); got != "agno:summary:u1:s1" {
+		t.Errorf("expected plain IDs to be unchanged, got %q", got)
+	}
+}
+
+func TestRedisMemoryDb(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	addr, cleanup := setupRedisContainer(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	db, err := NewRedisMemoryDb(addr, "", "test")
+	if err != nil {
+		t.Fatalf("Failed to create Redis memory db: %v", err)
+	}
+	defer db.Close()
+
+	t.Run("User Memories", func(t *testing.T) {
+		first := &memory.UserMemory{UserID: "u1", Memory: "Likes hiking"}
+		second := &memory.UserMemory{UserID: "u1", Memory: "Lives in Lisbon"}
+		other := &memory.UserMemory{UserID: "u2", Memory: "Plays chess"}
+		for _, mem := range []*memory.UserMemory{first, second, other} {
+			if err := db.CreateUserMemory(ctx, mem); err != nil {
+				t.Fatalf("Failed to create memory: %v", err)
+			}
+		}
+
+		memories, err := db.GetUserMemories(ctx, "u1")
+		if err != nil {
+			t.Fatalf("Failed to get memories: %v", err)
+		}
+		if len(memories) != 2 || memories[0].ID != second.ID {
+			t.Fatalf("Expected the user's 2 memories newest first, got %+v", memories)
+		}
+
+		first.Memory = "Likes hiking and climbing"
+		if err := db.UpdateUserMemory(ctx, first); err != nil {
+			t.Fatalf("Failed to update memory: %v", err)
+		}
+		if err := db.DeleteUserMemory(ctx, "u2", second.ID); err == nil {
+			t.Fatal("Expected deleting another user's memory to fail")
+		}
+		if err := db.DeleteUserMemory(ctx, "u1", second.ID); err != nil {
+			t.Fatalf("Failed to delete memory: %v", err)
+		}
+
+		memories, _ = db.GetUserMemories(ctx, "u1")
+		if len(memories) != 1 || memories[0].Memory != "Likes hiking and climbing" {
+			t.Fatalf("Unexpected memories after update and delete: %+v", memories)
+		}
+
+		if err := db.ClearUserMemories(ctx, "u1"); err != nil {
+			t.Fatalf("Failed to clear memories: %v", err)
+		}
+		if memories, _ = db.GetUserMemories(ctx, "u1"); len(memories) != 0 {
+			t.Errorf("Expected no memories, got %d", len(memories))
+		}
+		if memories, _ = db.GetUserMemories(ctx, "u2"); len(memories) != 1 {
+			t.Errorf("Expected other users' memories to be kept, got %d", len(memories))
+		}
+	})
+
+	t.Run("Session Summaries", func(t *testing.T) {
+		summary := &memory.SessionSummary{UserID: "u1", SessionID: "s1", Summary: "Planned a trip"}
+		if err := db.CreateSessionSummary(ctx, summary); err != nil {
+			t.Fatalf("Failed to create summary: %v", err)
+		}
+
+		summary.Summary = "Planned a trip to Japan"
+		if err := db.UpdateSessionSummary(ctx, summary); err != nil {
+			t.Fatalf("Failed to update summary: %v", err)
+		}
+
+		got, err := db.GetSessionSummary(ctx, "u1", "s1")
+		if err != nil {
+			t.Fatalf("Failed to get summary: %v", err)
+		}
+		if got.Summary != "Planned a trip to Japan" || got.ID != summary.ID {
+			t.Errorf("Unexpected summary: %+v", got)
+		}
+
+		for i := 1; i <= 2; i++ {
+			if count, err := db.IncrementSessionTurnCount(ctx, "u1", "s1"); err != nil || count != i {
+				t.Fatalf("Expected turn %d, got %d (%v)", i, count, err)
+			}
+		}
+		if count, err := db.GetSessionTurnCount(ctx, "u1", "s1"); err != nil || count != 2 {
+			t.Errorf("Expected 2 turns, got %d (%v)", count, err)
+		}
+		if count, err := db.GetSessionTurnCount(ctx, "u1", "s2"); err != nil || count != 0 {
+			t.Errorf("Expected no turns for another session, got %d (%v)", count, err)
+		}
+
+		if err := db.DeleteSessionSummary(ctx, "u1", "s1"); err != nil {
+			t.Fatalf("Failed to delete summary: %v", err)
+		}
+		if _, err := db.GetSessionSummary(ctx, "u1", "s1"); err == nil {
+			t.Error("Expected the summary to be deleted")
+		}
+	})
+
+	t.Run("TTL", func(t *testing.T) {
+		expiring, err := NewRedisMemoryDb(addr, "", "ttl", WithTTL(time.Second))
+		if err != nil {
+			t.Fatalf("Failed to create Redis memory db: %v", err)
+		}
+		defer expiring.Close()
+
+		if err := expiring.CreateUserMemory(ctx, &memory.UserMemory{UserID: "u1", Memory: "Short-lived"}); err != nil {
+			t.Fatalf("Failed to create memory: %v", err)
+		}
+		if memories, _ := expiring.GetUserMemories(ctx, "u1"); len(memories) != 1 {
+			t.Fatalf("Expected the memory before it expires, got %d", len(memories))
+		}
+
+		time.Sleep(1500 * time.Millisecond)
+		if memories, _ := expiring.GetUserMemories(ctx, "u1"); len(memories) != 0 {
+			t.Errorf("Expected the memory to expire, got %d", len(memories))
+		}
+	})
+
+	if err := db.DropTables(ctx); err != nil {
+		t.Fatalf("Failed to drop keys: %v", err)
+	}
+}
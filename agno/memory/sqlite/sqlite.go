@@ -88,6 +88,17 @@ func (db *SqliteMemoryDb) CreateTables(ctx context.Context) error {
 		)
 	`, db.tableName)
 
+	// Session turn counts table
+	turnsTable := fmt.Sprintf(`
+		CREATE TABLE IF NOT EXISTS %s_session_turns (
+			user_id TEXT NOT NULL,
+			session_id TEXT NOT NULL,
+			turn_count INTEGER NOT NULL DEFAULT 0,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (user_id, session_id)
+		)
+	`, db.tableName)
+
 	// Create indexes
 	memoryIndexes := fmt.Sprintf(`
 		CREATE INDEX IF NOT EXISTS idx_%s_user_id ON %s(user_id);
@@ -106,6 +117,10 @@ func (db *SqliteMemoryDb) CreateTables(ctx context.Context) error {
 		return fmt.Errorf("failed to create summaries table: %w", err)
 	}
 
+	if _, err := db.db.Exec(ctx, turnsTable); err != nil {
+		return fmt.Errorf("failed to create session turns table: %w", err)
+	}
+
 	if _, err := db.db.Exec(ctx, memoryIndexes); err != nil {
 		return fmt.Errorf("failed to create memory indexes: %w", err)
 	}
@@ -179,16 +194,42 @@ func (db *SqliteMemoryDb) GetUserMemories(ctx context.Context, userID string) ([
 func (db *SqliteMemoryDb) UpdateUserMemory(ctx context.Context, mem *memory.UserMemory) error {
 	mem.UpdatedAt = time.Now()
 
-	record := db.memoryToRecord(mem)
-	table := ksql.NewTable(db.tableName, "id")
+	query := fmt.Sprintf(`
+		UPDATE %s
+		SET memory = ?, input = ?, summary = ?, updated_at = ?
+		WHERE id = ? AND user_id = ?
+	`, db.tableName)
+
+	result, err := db.db.Exec(ctx, query,
+		mem.Memory, mem.Input, mem.Summary, mem.UpdatedAt, mem.ID, mem.UserID)
+	if err != nil {
+		return err
+	}
 
-	return db.db.Patch(ctx, table, record)
+	return requireAffected(result, mem.ID)
 }
 
 // DeleteUserMemory deletes a specific user memory
-func (db *SqliteMemoryDb) DeleteUserMemory(ctx context.Context, memoryID string) error {
-	table := ksql.NewTable(db.tableName, "id")
-	return db.db.Delete(ctx, table, memoryID)
+func (db *SqliteMemoryDb) DeleteUserMemory(ctx context.Context, userID, memoryID string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = ? AND user_id = ?", db.tableName)
+	result, err := db.db.Exec(ctx, query, memoryID, userID)
+	if err != nil {
+		return err
+	}
+
+	return requireAffected(result, memoryID)
+}
+
+// requireAffected returns a not found error if the statement changed no rows
+func requireAffected(result ksql.Result, memoryID string) error {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("memory not found: %s", memoryID)
+	}
+	return nil
 }
 
 // ClearUserMemories deletes all memories for a user
@@ -284,6 +325,40 @@ func (db *SqliteMemoryDb) DeleteSessionSummary(ctx context.Context, userID, sess
 	return err
 }
 
+// IncrementSessionTurnCount adds one turn to a session and returns the new count
+func (db *SqliteMemoryDb) IncrementSessionTurnCount(ctx context.Context, userID, sessionID string) (int, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO %s_session_turns (user_id, session_id, turn_count, updated_at)
+		VALUES (?, ?, 1, ?)
+		ON CONFLICT (user_id, session_id)
+		DO UPDATE SET turn_count = turn_count + 1, updated_at = excluded.updated_at
+	`, db.tableName)
+
+	if _, err := db.db.Exec(ctx, query, userID, sessionID, time.Now()); err != nil {
+		return 0, err
+	}
+
+	return db.GetSessionTurnCount(ctx, userID, sessionID)
+}
+
+// GetSessionTurnCount returns the number of turns recorded for a session
+func (db *SqliteMemoryDb) GetSessionTurnCount(ctx context.Context, userID, sessionID string) (int, error) {
+	var row struct {
+		TurnCount int `ksql:"turn_count"`
+	}
+
+	query := fmt.Sprintf("SELECT turn_count FROM %s_session_turns WHERE user_id = ? AND session_id = ?", db.tableName)
+	err := db.db.QueryOne(ctx, &row, query, userID, sessionID)
+	if err == ksql.ErrRecordNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return row.TurnCount, nil
+}
+
 // UpgradeSchema upgrades the database schema
 func (db *SqliteMemoryDb) UpgradeSchema(ctx context.Context) error {
 	// For now, just recreate tables - in production you'd want proper migrations
@@ -295,6 +370,7 @@ func (db *SqliteMemoryDb) DropTables(ctx context.Context) error {
 	queries := []string{
 		fmt.Sprintf("DROP TABLE IF EXISTS %s", db.tableName),
 		fmt.Sprintf("DROP TABLE IF EXISTS %s_summaries", db.tableName),
+		fmt.Sprintf("DROP TABLE IF EXISTS %s_session_turns", db.tableName),
 	}
 
 	for _, query := range queries {
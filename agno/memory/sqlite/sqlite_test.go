@@ -0,0 +1,87 @@
+package sqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/memory"
+)
+
+func TestUpdateAndDeleteUserMemory(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewSqliteMemoryDb("memories", filepath.Join(t.TempDir(), "memory.db"))
+	if err != nil {
+		t.Fatalf("Failed to create db: %v", err)
+	}
+
+	mine := &memory.UserMemory{UserID: "u1", Memory: "Lives in Porto", Input: "I live in Porto"}
+	theirs := &memory.UserMemory{UserID: "u2", Memory: "Lives in Madrid"}
+	for _, mem := range []*memory.UserMemory{mine, theirs} {
+		if err := db.CreateUserMemory(ctx, mem); err != nil {
+			t.Fatalf("Failed to create memory: %v", err)
+		}
+	}
+
+	manager := memory.NewMemory(nil, db)
+
+	if err := manager.UpdateMemory(ctx, "u1", mine.ID, "Lives in Lisbon"); err != nil {
+		t.Fatalf("UpdateMemory: %v", err)
+	}
+	if err := manager.UpdateMemory(ctx, "u1", theirs.ID, "Lives in Lisbon"); err == nil {
+		t.Error("Expected updating another user's memory to fail")
+	}
+
+	memories, err := manager.GetUserMemories(ctx, "u1")
+	if err != nil {
+		t.Fatalf("GetUserMemories: %v", err)
+	}
+	if len(memories) != 1 || memories[0].Memory != "Lives in Lisbon" || memories[0].Input != "I live in Porto" {
+		t.Fatalf("Unexpected memories after update: %+v", memories)
+	}
+
+	if err := manager.DeleteMemory(ctx, "u1", theirs.ID); err == nil {
+		t.Error("Expected deleting another user's memory to fail")
+	}
+	if err := manager.DeleteMemory(ctx, "u1", mine.ID); err != nil {
+		t.Fatalf("DeleteMemory: %v", err)
+	}
+	if err := manager.DeleteMemory(ctx, "u1", mine.ID); err == nil {
+		t.Error("Expected deleting a missing memory to fail")
+	}
+
+	if memories, _ := manager.GetUserMemories(ctx, "u1"); len(memories) != 0 {
+		t.Errorf("Expected no memories for u1, got %d", len(memories))
+	}
+	if memories, _ := manager.GetUserMemories(ctx, "u2"); len(memories) != 1 || memories[0].Memory != "Lives in Madrid" {
+		t.Errorf("Expected u2's memory to be untouched, got %+v", memories)
+	}
+}
+
+func TestSessionTurnCount(t *testing.T) {
+	ctx := context.Background()
+	db, err := NewSqliteMemoryDb("memories", filepath.Join(t.TempDir(), "memory.db"))
+	if err != nil {
+		t.Fatalf("Failed to create db: %v", err)
+	}
+
+	if count, err := db.GetSessionTurnCount(ctx, "u1", "s1"); err != nil || count != 0 {
+		t.Fatalf("Expected no turns for a new session, got %d (%v)", count, err)
+	}
+	for i := 1; i <= 3; i++ {
+		count, err := db.IncrementSessionTurnCount(ctx, "u1", "s1")
+		if err != nil || count != i {
+			t.Fatalf("Expected turn %d, got %d (%v)", i, count, err)
+		}
+	}
+	if _, err := db.IncrementSessionTurnCount(ctx, "u1", "s2"); err != nil {
+		t.Fatalf("IncrementSessionTurnCount: %v", err)
+	}
+
+	if count, _ := db.GetSessionTurnCount(ctx, "u1", "s1"); count != 3 {
+		t.Errorf("Expected 3 turns, got %d", count)
+	}
+	if count, _ := db.GetSessionTurnCount(ctx, "u1", "s2"); count != 1 {
+		t.Errorf("Expected sessions to be counted separately, got %d", count)
+	}
+}
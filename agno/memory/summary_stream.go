@@ -0,0 +1,124 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/devalexandre/agno-golang/agno/embedder"
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// SummaryUsage is the token usage of a summarization call. Models do not
+// report usage yet, so the counts are estimated from the prompt and the
+// generated text with embedder.EstimateTokens.
+type SummaryUsage struct {
+	InputTokens  int  `json:"input_tokens"`
+	OutputTokens int  `json:"output_tokens"`
+	TotalTokens  int  `json:"total_tokens"`
+	Estimated    bool `json:"estimated"`
+}
+
+// SummaryEventType identifies the stage of a streamed summarization
+type SummaryEventType string
+
+const (
+	// SummaryEventStarted is sent once the prompt is built, before the model is called
+	SummaryEventStarted SummaryEventType = "started"
+	// SummaryEventChunk is sent for every piece of summary text generated
+	SummaryEventChunk SummaryEventType = "chunk"
+	// SummaryEventCompleted is sent once the summary is complete and saved
+	SummaryEventCompleted SummaryEventType = "completed"
+)
+
+// SummaryEvent reports the progress of CreateSessionSummaryStream
+type SummaryEvent struct {
+	Type     SummaryEventType `json:"type"`
+	Delta    string           `json:"delta,omitempty"`   // Text generated since the previous event
+	Content  string           `json:"content,omitempty"` // Summary generated so far
+	Messages int              `json:"messages"`          // Conversation messages being summarized
+	Usage    SummaryUsage     `json:"usage"`             // Usage so far; final on SummaryEventCompleted
+	Summary  *SessionSummary  `json:"summary,omitempty"` // Saved summary, on SummaryEventCompleted
+}
+
+// SummaryStreamFunc receives the events of a streamed summarization.
+// Returning an error stops the summarization.
+type SummaryStreamFunc func(event SummaryEvent) error
+
+// CreateSessionSummaryStream creates a session summary like
+// CreateSessionSummary, but streams the summary to fn as the model generates
+// it, so long sessions can show progress instead of blocking until the end.
+// The returned summary carries the token usage of the call in Usage.
+func (m *Memory) CreateSessionSummaryStream(ctx context.Context, userID, sessionID string, messages []map[string]interface{}, fn SummaryStreamFunc) (*SessionSummary, error) {
+	if fn == nil {
+		fn = func(SummaryEvent) error { return nil }
+	}
+
+	aiMessages := sessionSummaryMessages(messages)
+	usage := SummaryUsage{InputTokens: estimateMessageTokens(aiMessages), Estimated: true}
+	usage.TotalTokens = usage.InputTokens
+
+	event := SummaryEvent{Type: SummaryEventStarted, Messages: len(messages), Usage: usage}
+	if err := fn(event); err != nil {
+		return nil, err
+	}
+
+	var content strings.Builder
+	err := m.Model.InvokeStream(ctx, aiMessages, models.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		content.Write(chunk)
+		event.Type = SummaryEventChunk
+		event.Delta = string(chunk)
+		event.Content = content.String()
+		event.Usage = summaryUsage(usage.InputTokens, event.Content)
+		return fn(event)
+	}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session summary: %w", err)
+	}
+
+	summary := &SessionSummary{
+		UserID:    userID,
+		SessionID: sessionID,
+		Summary:   strings.TrimSpace(content.String()),
+	}
+	finalUsage := summaryUsage(usage.InputTokens, content.String())
+	summary.Usage = &finalUsage
+
+	if err := m.DB.CreateSessionSummary(ctx, summary); err != nil {
+		return nil, fmt.Errorf("failed to save session summary: %w", err)
+	}
+
+	event.Type = SummaryEventCompleted
+	event.Delta = ""
+	event.Content = summary.Summary
+	event.Usage = finalUsage
+	event.Summary = summary
+	if err := fn(event); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// summaryUsage estimates the usage of a summarization call that generated output
+func summaryUsage(inputTokens int, output string) SummaryUsage {
+	outputTokens := embedder.EstimateTokens(output)
+	return SummaryUsage{
+		InputTokens:  inputTokens,
+		OutputTokens: outputTokens,
+		TotalTokens:  inputTokens + outputTokens,
+		Estimated:    true,
+	}
+}
+
+// estimateMessageTokens estimates the tokens of the messages sent to the model
+func estimateMessageTokens(messages []models.Message) int {
+	tokens := 0
+	for _, msg := range messages {
+		tokens += embedder.EstimateTokens(msg.Content)
+	}
+	return tokens
+}
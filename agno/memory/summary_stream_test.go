@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// chunkModel streams its chunks, or returns them joined from Invoke
+type chunkModel struct {
+	models.AgnoModelInterface
+	chunks []string
+}
+
+func (m *chunkModel) InvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) error {
+	callOptions := models.DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOptions)
+	}
+	for _, chunk := range m.chunks {
+		if err := callOptions.StreamingFunc(ctx, []byte(chunk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// summaryDB keeps the last saved session summary
+type summaryDB struct {
+	MemoryDatabase
+	saved *SessionSummary
+}
+
+func (db *summaryDB) CreateSessionSummary(ctx context.Context, summary *SessionSummary) error {
+	db.saved = summary
+	return nil
+}
+
+func TestCreateSessionSummaryStream(t *testing.T) {
+	db := &summaryDB{}
+	m := NewMemory(&chunkModel{chunks: []string{"The user ", "plans a trip ", "to Japan."}}, db)
+	messages := []map[string]interface{}{
+		{"role": "user", "content": "I want to visit Tokyo and Kyoto in April."},
+		{"role": "assistant", "content": "April is cherry blossom season."},
+	}
+
+	var events []SummaryEvent
+	summary, err := m.CreateSessionSummaryStream(context.Background(), "u1", "s1", messages, func(event SummaryEvent) error {
+		events = append(events, event)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("CreateSessionSummaryStream: %v", err)
+	}
+
+	if summary.Summary != "The user plans a trip to Japan." || db.saved != summary {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+	if len(events) != 5 || events[0].Type != SummaryEventStarted || events[4].Type != SummaryEventCompleted {
+		t.Fatalf("unexpected events: %+v", events)
+	}
+	if events[2].Delta != "plans a trip " || events[2].Content != "The user plans a trip " {
+		t.Errorf("unexpected chunk event: %+v", events[2])
+	}
+	if events[0].Messages != 2 || events[0].Usage.InputTokens == 0 || events[0].Usage.OutputTokens != 0 {
+		t.Errorf("unexpected started event: %+v", events[0])
+	}
+	if events[1].Usage.OutputTokens >= events[3].Usage.OutputTokens {
+		t.Errorf("expected output tokens to grow, got %d then %d", events[1].Usage.OutputTokens, events[3].Usage.OutputTokens)
+	}
+
+	usage := summary.Usage
+	if usage == nil || !usage.Estimated || usage.TotalTokens != usage.InputTokens+usage.OutputTokens || *usage != events[4].Usage {
+		t.Errorf("unexpected usage: %+v", usage)
+	}
+}
+
+func TestCreateSessionSummaryStreamCallbackError(t *testing.T) {
+	db := &summaryDB{}
+	m := NewMemory(&chunkModel{chunks: []string{"a", "b"}}, db)
+	stop := errors.New("stop")
+
+	_, err := m.CreateSessionSummaryStream(context.Background(), "u1", "s1", nil, func(event SummaryEvent) error {
+		if event.Type == SummaryEventChunk {
+			return stop
+		}
+		return nil
+	})
+	if !errors.Is(err, stop) || db.saved != nil {
+		t.Fatalf("expected the callback error to stop the summary, got %v (saved %v)", err, db.saved)
+	}
+}
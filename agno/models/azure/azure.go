@@ -61,6 +61,7 @@ func (a *AzureOpenAI) Invoke(ctx context.Context, messages []models.Message, opt
 		return nil, err
 	}
 
+	usage := resp.Usage.ModelUsage()
 	return &models.MessageResponse{
 		Role:             resp.Choices[0].Message.Role,
 		Content:          resp.Choices[0].Message.Content,
@@ -68,6 +69,7 @@ func (a *AzureOpenAI) Invoke(ctx context.Context, messages []models.Message, opt
 		ToolCalls:        resp.Choices[0].Message.ToolCalls,
 		ToolResults:      resp.Choices[0].Message.ToolResults,
 		ReasoningContent: resp.Choices[0].Message.ReasoningContent,
+		Usage:            &usage,
 	}, nil
 }
 
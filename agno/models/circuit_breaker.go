@@ -0,0 +1,341 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// CircuitState is the state of a circuit breaker
+type CircuitState string
+
+const (
+	// CircuitClosed lets calls through and counts consecutive failures
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen fast-fails every call until the cooldown elapses
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single probe call through to test recovery
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// ErrCircuitOpen is returned when a call is rejected by an open circuit breaker
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CBConfig configures a circuit breaker
+type CBConfig struct {
+	// Name identifies the breaker in OnStateChange; defaults to the model ID or tool name
+	Name string
+	// FailureThreshold is the number of consecutive failures that opens the circuit (default 5)
+	FailureThreshold int
+	// Window bounds how far apart those failures may be: a failure more than
+	// Window after the first one of the streak starts a new streak (default 0,
+	// no limit)
+	Window time.Duration
+	// Cooldown is how long the circuit stays open before a probe is allowed (default 30s)
+	Cooldown time.Duration
+	// OnStateChange is called on every state transition, e.g. to export metrics.
+	// It is called synchronously and must not call back into the breaker.
+	OnStateChange func(name string, from, to CircuitState)
+}
+
+// CircuitBreaker tracks consecutive failures of a dependency. After FailureThreshold
+// failures it opens and rejects calls with ErrCircuitOpen; once Cooldown has passed
+// it half-opens and lets one probe through, closing on success or reopening on failure.
+type CircuitBreaker struct {
+	config CBConfig
+
+	mu             sync.Mutex
+	state          CircuitState
+	failures       int
+	firstFailureAt time.Time
+	openedAt       time.Time
+	probing        bool
+	now            func() time.Time
+}
+
+// NewBreaker creates a standalone circuit breaker, for guarding
+// dependencies other than models and tools
+func NewBreaker(config CBConfig) *CircuitBreaker {
+	if config.FailureThreshold <= 0 {
+		config.FailureThreshold = 5
+	}
+	if config.Cooldown <= 0 {
+		config.Cooldown = 30 * time.Second
+	}
+	return &CircuitBreaker{
+		config: config,
+		state:  CircuitClosed,
+		now:    time.Now,
+	}
+}
+
+// State returns the current state, moving an expired open circuit to half-open
+func (cb *CircuitBreaker) State() CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state == CircuitOpen && cb.now().Sub(cb.openedAt) >= cb.config.Cooldown {
+		cb.transition(CircuitHalfOpen)
+	}
+	return cb.state
+}
+
+// Allow reports whether a call may proceed. Every allowed call must be followed
+// by exactly one Record with its result.
+func (cb *CircuitBreaker) Allow() error {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	switch cb.state {
+	case CircuitOpen:
+		if cb.now().Sub(cb.openedAt) < cb.config.Cooldown {
+			return fmt.Errorf("%s: %w", cb.config.Name, ErrCircuitOpen)
+		}
+		cb.transition(CircuitHalfOpen)
+		fallthrough
+	case CircuitHalfOpen:
+		if cb.probing {
+			return fmt.Errorf("%s: %w", cb.config.Name, ErrCircuitOpen)
+		}
+		cb.probing = true
+	}
+	return nil
+}
+
+// Record reports the result of an allowed call. Context cancellation is not
+// counted as a failure of the dependency.
+func (cb *CircuitBreaker) Record(err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	wasProbe := cb.probing
+	cb.probing = false
+
+	if err != nil && (errors.Is(err, context.Canceled) || errors.Is(err, ErrCircuitOpen)) {
+		return
+	}
+
+	if err == nil {
+		cb.failures = 0
+		if cb.state != CircuitClosed {
+			cb.transition(CircuitClosed)
+		}
+		return
+	}
+
+	now := cb.now()
+	if cb.config.Window > 0 && cb.failures > 0 && now.Sub(cb.firstFailureAt) > cb.config.Window {
+		cb.failures = 0
+	}
+	if cb.failures == 0 {
+		cb.firstFailureAt = now
+	}
+	cb.failures++
+	if (wasProbe && cb.state == CircuitHalfOpen) || cb.failures >= cb.config.FailureThreshold {
+		cb.openedAt = now
+		if cb.state != CircuitOpen {
+			cb.transition(CircuitOpen)
+		}
+	}
+}
+
+// Do calls fn if the circuit allows it and records its result. A nil breaker
+// calls fn directly, so model clients can guard calls whether or not
+// WithCircuitBreaker was given.
+func (cb *CircuitBreaker) Do(fn func() error) error {
+	if cb == nil {
+		return fn()
+	}
+	if err := cb.Allow(); err != nil {
+		return err
+	}
+	err := fn()
+	cb.Record(err)
+	return err
+}
+
+// DoStream is Do for streaming calls: fn is called with options whose
+// StreamingFunc is wrapped, so an error returned by the consumer, e.g. to stop
+// the stream, is not counted as a failure of the model
+func (cb *CircuitBreaker) DoStream(options []Option, fn func(options []Option) error) error {
+	if cb == nil {
+		return fn(options)
+	}
+	if err := cb.Allow(); err != nil {
+		return err
+	}
+
+	callOpts := &CallOptions{}
+	for _, opt := range options {
+		opt(callOpts)
+	}
+	var consumerErr error
+	if streamingFunc := callOpts.StreamingFunc; streamingFunc != nil {
+		options = append(options[:len(options):len(options)], WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			err := streamingFunc(ctx, chunk)
+			if err != nil {
+				consumerErr = err
+			}
+			return err
+		}))
+	}
+
+	err := fn(options)
+	if consumerErr != nil {
+		cb.Record(nil)
+	} else {
+		cb.Record(err)
+	}
+	return err
+}
+
+// ClientBreaker returns a circuit breaker for a model client created with
+// opts, named after the model ID, or nil without WithCircuitBreaker
+func ClientBreaker(opts *ClientOptions) *CircuitBreaker {
+	if opts.CircuitBreaker == nil {
+		return nil
+	}
+	config := *opts.CircuitBreaker
+	if config.Name == "" {
+		config.Name = opts.ID
+	}
+	return NewBreaker(config)
+}
+
+// WithCircuitBreaker guards every call of the model client with a circuit
+// breaker shared by all its callers: after threshold consecutive failures
+// within window the model fails fast with ErrCircuitOpen for cooldown, then
+// lets one probe call through. A zero window counts failures however far apart.
+func WithCircuitBreaker(threshold int, window, cooldown time.Duration) func(*ClientOptions) {
+	return func(o *ClientOptions) {
+		o.CircuitBreaker = &CBConfig{
+			FailureThreshold: threshold,
+			Window:           window,
+			Cooldown:         cooldown,
+		}
+	}
+}
+
+// transition changes state and notifies OnStateChange; callers hold cb.mu
+func (cb *CircuitBreaker) transition(to CircuitState) {
+	from := cb.state
+	cb.state = to
+	if to == CircuitClosed {
+		cb.failures = 0
+	}
+	if cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(cb.config.Name, from, to)
+	}
+}
+
+// CircuitBreakerModel wraps a model with a circuit breaker
+type CircuitBreakerModel struct {
+	model   AgnoModelInterface
+	breaker *CircuitBreaker
+}
+
+// NewCircuitBreaker wraps model so that repeated failures open the circuit and
+// later calls fail fast with ErrCircuitOpen until the cooldown elapses
+func NewCircuitBreaker(model AgnoModelInterface, config CBConfig) *CircuitBreakerModel {
+	if config.Name == "" {
+		config.Name = model.GetID()
+	}
+	return &CircuitBreakerModel{
+		model:   model,
+		breaker: NewBreaker(config),
+	}
+}
+
+// Breaker returns the underlying circuit breaker
+func (m *CircuitBreakerModel) Breaker() *CircuitBreaker {
+	return m.breaker
+}
+
+// Invoke calls the wrapped model if the circuit allows it
+func (m *CircuitBreakerModel) Invoke(ctx context.Context, messages []Message, options ...Option) (*MessageResponse, error) {
+	if err := m.breaker.Allow(); err != nil {
+		return nil, err
+	}
+	resp, err := m.model.Invoke(ctx, messages, options...)
+	m.breaker.Record(err)
+	return resp, err
+}
+
+// AInvoke calls the wrapped model asynchronously if the circuit allows it
+func (m *CircuitBreakerModel) AInvoke(ctx context.Context, messages []Message, options ...Option) (<-chan *MessageResponse, <-chan error) {
+	if err := m.breaker.Allow(); err != nil {
+		return rejectedAsync(err)
+	}
+	respChan, errChan := m.model.AInvoke(ctx, messages, options...)
+	return m.forwardAsync(respChan, errChan)
+}
+
+// InvokeStream streams from the wrapped model if the circuit allows it
+func (m *CircuitBreakerModel) InvokeStream(ctx context.Context, messages []Message, options ...Option) error {
+	if err := m.breaker.Allow(); err != nil {
+		return err
+	}
+	err := m.model.InvokeStream(ctx, messages, options...)
+	m.breaker.Record(err)
+	return err
+}
+
+// AInvokeStream streams from the wrapped model asynchronously if the circuit allows it
+func (m *CircuitBreakerModel) AInvokeStream(ctx context.Context, messages []Message, options ...Option) (<-chan *MessageResponse, <-chan error) {
+	if err := m.breaker.Allow(); err != nil {
+		return rejectedAsync(err)
+	}
+	respChan, errChan := m.model.AInvokeStream(ctx, messages, options...)
+	return m.forwardAsync(respChan, errChan)
+}
+
+// GetID returns the wrapped model ID
+func (m *CircuitBreakerModel) GetID() string {
+	return m.model.GetID()
+}
+
+// forwardAsync relays the wrapped channels and records the outcome once both close
+func (m *CircuitBreakerModel) forwardAsync(respIn <-chan *MessageResponse, errIn <-chan error) (<-chan *MessageResponse, <-chan error) {
+	respOut := make(chan *MessageResponse, 1)
+	errOut := make(chan error, 1)
+
+	go func() {
+		defer close(respOut)
+		defer close(errOut)
+
+		var firstErr error
+		for respIn != nil || errIn != nil {
+			select {
+			case resp, ok := <-respIn:
+				if !ok {
+					respIn = nil
+					continue
+				}
+				respOut <- resp
+			case err, ok := <-errIn:
+				if !ok {
+					errIn = nil
+					continue
+				}
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				errOut <- err
+			}
+		}
+		m.breaker.Record(firstErr)
+	}()
+
+	return respOut, errOut
+}
+
+// rejectedAsync returns closed channels carrying a single error
+func rejectedAsync(err error) (<-chan *MessageResponse, <-chan error) {
+	respChan := make(chan *MessageResponse)
+	errChan := make(chan error, 1)
+	errChan <- err
+	close(respChan)
+	close(errChan)
+	return respChan, errChan
+}
@@ -98,6 +98,10 @@ func (c *Client) CreateChatCompletion(ctx context.Context, messages []models.Mes
 		FrequencyPenalty:  callOptions.FrequencyPenalty,
 		PresencePenalty:   callOptions.PresencePenalty,
 	}
+	if callOptions.Seed != nil {
+		seed := int32(*callOptions.Seed)
+		config.Seed = &seed
+	}
 
 	// Add tools if declared
 	if len(functionDeclarations) > 0 {
@@ -210,6 +214,10 @@ func (c *Client) StreamChatCompletion(ctx context.Context, messages []models.Mes
 		FrequencyPenalty:  callOptions.FrequencyPenalty,
 		PresencePenalty:   callOptions.PresencePenalty,
 	}
+	if callOptions.Seed != nil {
+		seed := int32(*callOptions.Seed)
+		config.Seed = &seed
+	}
 
 	// Add tools if declared
 	if len(functionDeclarations) > 0 {
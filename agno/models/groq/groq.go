@@ -68,6 +68,7 @@ func (g *Groq) Invoke(ctx context.Context, messages []models.Message, options ..
 		return nil, errors.New("no choices in response")
 	}
 
+	usage := resp.Usage.ModelUsage()
 	return &models.MessageResponse{
 		Role:             resp.Choices[0].Message.Role,
 		Content:          resp.Choices[0].Message.Content,
@@ -75,6 +76,7 @@ func (g *Groq) Invoke(ctx context.Context, messages []models.Message, options ..
 		ToolCalls:        resp.Choices[0].Message.ToolCalls,
 		ToolResults:      resp.Choices[0].Message.ToolResults,
 		ReasoningContent: resp.Choices[0].Message.ReasoningContent,
+		Usage:            &usage,
 	}, nil
 }
 
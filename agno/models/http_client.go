@@ -0,0 +1,69 @@
+package models
+
+import (
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HTTPPoolConfig configures the connection pool of an HTTP client created with NewHTTPClient
+type HTTPPoolConfig struct {
+	// MaxIdleConns caps idle connections across all hosts (default 100)
+	MaxIdleConns int
+	// MaxIdleConnsPerHost caps idle connections kept per host (default 100).
+	// net/http keeps only 2, so concurrent model calls to the same provider
+	// keep opening new connections and paying the TLS handshake again.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout closes idle connections after this long (default 90s)
+	IdleConnTimeout time.Duration
+	// DisableKeepAlives opens a new connection for every request
+	DisableKeepAlives bool
+	// Timeout bounds each request including reading the body (default none,
+	// since streamed responses can take minutes)
+	Timeout time.Duration
+}
+
+var (
+	defaultHTTPClientOnce sync.Once
+	defaultHTTPClient     *http.Client
+)
+
+// NewHTTPClient creates an HTTP client with a pooled transport, for model
+// clients that should share connections (see WithHTTPClient)
+func NewHTTPClient(config HTTPPoolConfig) *http.Client {
+	if config.MaxIdleConns <= 0 {
+		config.MaxIdleConns = 100
+	}
+	if config.MaxIdleConnsPerHost <= 0 {
+		config.MaxIdleConnsPerHost = 100
+	}
+	if config.IdleConnTimeout <= 0 {
+		config.IdleConnTimeout = 90 * time.Second
+	}
+
+	transport := &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		ForceAttemptHTTP2:     true,
+		MaxIdleConns:          config.MaxIdleConns,
+		MaxIdleConnsPerHost:   config.MaxIdleConnsPerHost,
+		IdleConnTimeout:       config.IdleConnTimeout,
+		DisableKeepAlives:     config.DisableKeepAlives,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+	return &http.Client{Transport: transport, Timeout: config.Timeout}
+}
+
+// DefaultHTTPClient returns the pooled HTTP client shared by model clients
+// created without WithHTTPClient
+func DefaultHTTPClient() *http.Client {
+	defaultHTTPClientOnce.Do(func() {
+		defaultHTTPClient = NewHTTPClient(HTTPPoolConfig{})
+	})
+	return defaultHTTPClient
+}
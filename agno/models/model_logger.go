@@ -0,0 +1,181 @@
+package models
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httputil"
+	"strings"
+	"sync"
+)
+
+// ModelLogger receives the raw HTTP traffic of a model call: the request and
+// the response, each with its headers and body. Streamed responses are passed
+// once the stream ends, with all chunks assembled. resp is nil when the
+// request failed before a response arrived. Credentials are redacted.
+type ModelLogger func(req, resp []byte)
+
+// redactedHeaders are header names whose values are never logged
+var redactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Api-Key",
+	"X-Api-Key",
+	"X-Goog-Api-Key",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// redactedQueryParams are query parameters whose values are never logged
+var redactedQueryParams = []string{"key", "api_key", "apikey", "access_token"}
+
+const redacted = "[REDACTED]"
+
+// WithModelLogger logs every request and response of the model client, e.g.
+// to capture the raw traffic to a file while debugging a model's output.
+func WithModelLogger(fn func(req, resp []byte)) func(*ClientOptions) {
+	return func(o *ClientOptions) {
+		o.ModelLogger = fn
+	}
+}
+
+// LoggingHTTPClient returns a copy of client whose requests and responses are
+// passed to logger, or client itself when logger is nil
+func LoggingHTTPClient(client *http.Client, logger ModelLogger) *http.Client {
+	if logger == nil {
+		return client
+	}
+	logging := *client
+	logging.Transport = &LoggingTransport{Transport: client.Transport, Logger: logger}
+	return &logging
+}
+
+// LoggingTransport is an http.RoundTripper that passes each request and its
+// response to Logger, with credentials redacted
+type LoggingTransport struct {
+	Transport http.RoundTripper // Underlying transport (default http.DefaultTransport)
+	Logger    ModelLogger
+}
+
+// RoundTrip sends req and arranges for the exchange to be logged once the
+// response body has been read or closed
+func (t *LoggingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	reqDump := dumpRequest(req, body)
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Logger(reqDump, nil)
+		return nil, err
+	}
+
+	resp.Body = &loggedBody{
+		ReadCloser: resp.Body,
+		head:       dumpResponseHead(resp),
+		log: func(respDump []byte) {
+			t.Logger(reqDump, respDump)
+		},
+	}
+	return resp, nil
+}
+
+// dumpRequest serializes req with credentials redacted
+func dumpRequest(req *http.Request, body []byte) []byte {
+	logged := req.Clone(req.Context())
+	logged.Header = redactHeader(req.Header)
+	logged.URL.RawQuery = redactQuery(req.URL.RawQuery)
+	logged.Body = io.NopCloser(bytes.NewReader(body))
+	logged.ContentLength = int64(len(body))
+
+	dump, err := httputil.DumpRequestOut(logged, true)
+	if err != nil {
+		return body
+	}
+	return dump
+}
+
+// dumpResponseHead serializes the status line and headers of resp
+func dumpResponseHead(resp *http.Response) []byte {
+	logged := *resp
+	logged.Header = redactHeader(resp.Header)
+	logged.Body = nil
+
+	dump, err := httputil.DumpResponse(&logged, false)
+	if err != nil {
+		return nil
+	}
+	return dump
+}
+
+// redactHeader returns a copy of header with credential values replaced
+func redactHeader(header http.Header) http.Header {
+	redactedHeader := header.Clone()
+	for _, name := range redactedHeaders {
+		if _, ok := redactedHeader[name]; ok {
+			redactedHeader.Set(name, redacted)
+		}
+	}
+	return redactedHeader
+}
+
+// redactQuery replaces the values of credential query parameters. It works
+// on the raw query so the logged URL keeps its original encoding.
+func redactQuery(rawQuery string) string {
+	if rawQuery == "" {
+		return rawQuery
+	}
+	params := strings.Split(rawQuery, "&")
+	for i, param := range params {
+		name, _, _ := strings.Cut(param, "=")
+		for _, secret := range redactedQueryParams {
+			if strings.EqualFold(name, secret) {
+				params[i] = name + "=" + redacted
+			}
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// loggedBody records a response body as it is read and logs the exchange at
+// EOF or Close, whichever comes first
+type loggedBody struct {
+	io.ReadCloser
+	head []byte
+	buf  bytes.Buffer
+	once sync.Once
+	log  func([]byte)
+}
+
+func (b *loggedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.buf.Write(p[:n])
+	if err == io.EOF {
+		b.flush()
+	}
+	return n, err
+}
+
+func (b *loggedBody) Close() error {
+	b.flush()
+	return b.ReadCloser.Close()
+}
+
+func (b *loggedBody) flush() {
+	b.once.Do(func() {
+		b.log(append(b.head, b.buf.Bytes()...))
+	})
+}
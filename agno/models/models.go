@@ -45,6 +45,9 @@ type MessageResponse struct {
 	ToolCalls        []tools.ToolCall `json:"tool_calls,omitempty"`
 	ToolResults      []ToolResult     `json:"tool_results,omitempty"` // Results from tool executions
 	ReasoningContent string           `json:"reasoning_content,omitempty"`
+	// Usage is the token usage the provider reported for the call, including
+	// the requests of a tool loop run by the client; nil when not reported
+	Usage *Usage `json:"usage,omitempty"`
 }
 
 func (r Role) IsValid() bool {
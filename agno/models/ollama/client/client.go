@@ -34,6 +34,11 @@ func NewClient(model, baseURL string, client *http.Client) *Client {
 	}
 }
 
+// Ping checks that the Ollama server is reachable
+func (c *Client) Ping(ctx context.Context) error {
+	return c.api.Heartbeat(ctx)
+}
+
 func (c *Client) CreateChatCompletion(ctx context.Context, messages []models.Message, options ...models.Option) (*CompletionResponse, error) {
 	// Get debug and tools flags from context
 	debugmod := ctx.Value(models.DebugKey)
@@ -73,11 +78,13 @@ func (c *Client) CreateChatCompletion(ctx context.Context, messages []models.Mes
 		opts["num_predict"] = val
 		delete(opts, "max_tokens")
 	}
+	// Ollama has no tool_choice; it is applied to the tools sent instead
+	delete(opts, "tool_choice")
 
 	req.Options = opts
 
 	_tools, maptools, _ := c.prepareTools(callOptions.ToolCall)
-	req.Tools = _tools
+	req.Tools = applyToolChoice(_tools, callOptions.ToolChoice)
 
 	if showToolsCall != nil && showToolsCall.(bool) {
 		toolsJosn, _ := json.MarshalIndent(_tools, "", "  ")
@@ -150,7 +157,7 @@ func (c *Client) CreateChatCompletion(ctx context.Context, messages []models.Mes
 
 				// Convert tool result to string
 				var toolResultStr string
-				switch result := resTool.(type) {
+				switch result := toolkit.ModelContent(resTool).(type) {
 				case string:
 					toolResultStr = result
 				case map[string]interface{}:
@@ -300,7 +307,7 @@ func (c *Client) StreamChatCompletion(ctx context.Context, messages []models.Mes
 
 	_tools, maptools, _ := c.prepareTools(callOptions.ToolCall)
 	callOptions.Tools = nil
-	req.Tools = _tools
+	req.Tools = applyToolChoice(_tools, callOptions.ToolChoice)
 	opts, err := utils.StructToMap(callOptions)
 	if err != nil {
 		return err
@@ -311,6 +318,8 @@ func (c *Client) StreamChatCompletion(ctx context.Context, messages []models.Mes
 		opts["num_predict"] = val
 		delete(opts, "max_tokens")
 	}
+	// Ollama has no tool_choice; it is applied to the tools sent instead
+	delete(opts, "tool_choice")
 
 	//remove ToolCall from options
 	opts["ToolCall"] = nil
@@ -356,7 +365,7 @@ func (c *Client) StreamChatCompletion(ctx context.Context, messages []models.Mes
 
 					// Convert tool result to string
 					var toolResultStr string
-					switch result := resTool.(type) {
+					switch result := toolkit.ModelContent(resTool).(type) {
 					case string:
 						toolResultStr = result
 					case map[string]interface{}:
@@ -411,6 +420,24 @@ func stopSentence(text string) bool {
 	return strings.HasSuffix(text, ".") || strings.HasSuffix(text, "?") || strings.HasSuffix(text, "!") || strings.HasSuffix(text, "\n") || strings.HasSuffix(text, ":")
 }
 
+// applyToolChoice emulates tool_choice, which Ollama does not support: "none"
+// sends no tools and a function name sends only that function. "required"
+// cannot be enforced, so the model still decides.
+func applyToolChoice(apiTools []api.Tool, choice string) []api.Tool {
+	switch choice {
+	case "", "auto", "required":
+		return apiTools
+	case "none":
+		return nil
+	}
+	for _, tool := range apiTools {
+		if tool.Function.Name == choice {
+			return []api.Tool{tool}
+		}
+	}
+	return apiTools
+}
+
 func (c *Client) prepareTools(toolsCall []toolkit.Tool) ([]api.Tool, map[string]toolkit.Tool, []string) {
 	var apiTools []api.Tool
 	maptools := make(map[string]toolkit.Tool)
@@ -17,6 +17,7 @@ type OllamaChat struct {
 	baseURL string
 	client  *client.Client
 	opts    *models.ClientOptions
+	breaker *models.CircuitBreaker // nil without WithCircuitBreaker
 }
 
 // authTransport wraps an http.RoundTripper to add Authorization header
@@ -45,14 +46,23 @@ func NewOllamaChat(options ...models.OptionClient) (models.AgnoModelInterface, e
 		opts.BaseURL = "http://localhost:11434"
 	}
 
-	// Create HTTP client with custom transport for authorization
-	httpClient := http.DefaultClient
+	// Reuse pooled connections, adding authorization to the transport if needed
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = models.DefaultHTTPClient()
+	}
+	httpClient = models.LoggingHTTPClient(httpClient, opts.ModelLogger)
 	if opts.APIKey != "" {
+		transport := httpClient.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
 		httpClient = &http.Client{
 			Transport: &authTransport{
-				transport: http.DefaultTransport,
+				transport: transport,
 				apiKey:    opts.APIKey,
 			},
+			Timeout: httpClient.Timeout,
 		}
 	}
 
@@ -62,12 +72,23 @@ func NewOllamaChat(options ...models.OptionClient) (models.AgnoModelInterface, e
 		baseURL: opts.BaseURL,
 		client:  cli,
 		opts:    opts,
+		breaker: models.ClientBreaker(opts),
 	}, nil
 }
 func (o *OllamaChat) GetID() string {
 	return o.id
 }
 
+// Ping checks that the Ollama server is reachable
+func (o *OllamaChat) Ping(ctx context.Context) error {
+	return o.client.Ping(ctx)
+}
+
+// Breaker returns the circuit breaker configured with models.WithCircuitBreaker, or nil
+func (o *OllamaChat) Breaker() *models.CircuitBreaker {
+	return o.breaker
+}
+
 // GetClientOptions returns the client options for this Ollama model
 func (o *OllamaChat) GetClientOptions() *models.ClientOptions {
 	return o.opts
@@ -82,7 +103,12 @@ func (o *OllamaChat) Invoke(ctx context.Context, messages []models.Message, opti
 		options = append([]models.Option{models.WithMaxTokens(*o.opts.MaxTokens)}, options...)
 	}
 
-	resp, err := o.client.CreateChatCompletion(ctx, messages, options...)
+	var resp *client.CompletionResponse
+	err := o.breaker.Do(func() error {
+		var err error
+		resp, err = o.client.CreateChatCompletion(ctx, messages, options...)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -145,11 +171,9 @@ func (o *OllamaChat) InvokeStream(ctx context.Context, messages []models.Message
 		options = append([]models.Option{models.WithMaxTokens(*o.opts.MaxTokens)}, options...)
 	}
 
-	err := o.client.StreamChatCompletion(ctx, messages, options...)
-	if err != nil {
-		return err
-	}
-	return nil
+	return o.breaker.DoStream(options, func(options []models.Option) error {
+		return o.client.StreamChatCompletion(ctx, messages, options...)
+	})
 }
 
 // AInvokeStream is the asynchronous version of InvokeStream
@@ -2,9 +2,14 @@ package ollama
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/devalexandre/agno-golang/agno/models"
 	"github.com/devalexandre/agno-golang/agno/models/ollama/client"
@@ -261,3 +266,233 @@ func TestOllamaChat_InvokeStreamWithTools(t *testing.T) {
 	}
 
 }
+
+// countingTransport counts requests and checks the authorization header
+type countingTransport struct {
+	requests int
+	auth     string
+}
+
+func (c *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	c.requests++
+	c.auth = req.Header.Get("Authorization")
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func TestOllamaChat_WithHTTPClient(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	transport := &countingTransport{}
+	ollamaChat, err := NewOllamaChat(
+		models.WithBaseURL(server.URL),
+		models.WithAPIKey("secret"),
+		models.WithHTTPClient(&http.Client{Transport: transport}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create OllamaChat: %v", err)
+	}
+
+	if err := ollamaChat.(*OllamaChat).Ping(context.Background()); err != nil {
+		t.Fatalf("Ping failed: %v", err)
+	}
+	if transport.requests != 1 {
+		t.Errorf("Expected the custom client to be used, got %d requests", transport.requests)
+	}
+	if transport.auth != "Bearer secret" {
+		t.Errorf("Expected the API key on the custom transport, got %q", transport.auth)
+	}
+}
+
+// newOptionsServer serves a fixed chat response and records the request options
+func newOptionsServer(t *testing.T, options *map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Options map[string]interface{} `json:"options"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		*options = body.Options
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"model":"test","message":{"role":"assistant","content":"ok"},"done":true}`))
+	}))
+}
+
+func TestOllamaChat_MaxTokens(t *testing.T) {
+	var options map[string]interface{}
+	server := newOptionsServer(t, &options)
+	defer server.Close()
+	ollamaChat, err := NewOllamaChat(models.WithID("test"), models.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("Failed to create OllamaChat: %v", err)
+	}
+	messages := []models.Message{{Role: models.TypeUserRole, Content: "hi"}}
+
+	if _, err := ollamaChat.Invoke(context.Background(), messages, models.WithMaxTokens(64)); err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if options["num_predict"] != float64(64) {
+		t.Errorf("Expected num_predict 64 in the request, got %v", options)
+	}
+	if _, ok := options["max_tokens"]; ok {
+		t.Errorf("Expected max_tokens to be sent as num_predict, got %v", options)
+	}
+
+	// Without max tokens the model default applies
+	if _, err := ollamaChat.Invoke(context.Background(), messages); err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if _, ok := options["num_predict"]; ok {
+		t.Errorf("Expected no num_predict by default, got %v", options)
+	}
+}
+
+func TestOllamaChat_SamplingOptions(t *testing.T) {
+	var options map[string]interface{}
+	server := newOptionsServer(t, &options)
+	defer server.Close()
+
+	ollamaChat, err := NewOllamaChat(models.WithID("test"), models.WithBaseURL(server.URL))
+	if err != nil {
+		t.Fatalf("Failed to create OllamaChat: %v", err)
+	}
+	messages := []models.Message{{Role: models.TypeUserRole, Content: "hi"}}
+
+	if _, err := ollamaChat.Invoke(context.Background(), messages, models.WithTemperature(0), models.WithTopP(0.5)); err != nil {
+		t.Fatalf("Invoke failed: %v", err)
+	}
+	if options["temperature"] != float64(0) || options["top_p"] != 0.5 {
+		t.Errorf("Expected temperature 0 and top_p 0.5 in the request, got %v", options)
+	}
+}
+
+func TestOllamaChat_WithModelLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Write([]byte(`{"model":"test","message":{"role":"assistant","content":"hel"},"done":false}` + "\n"))
+		w.(http.Flusher).Flush()
+		w.Write([]byte(`{"model":"test","message":{"role":"assistant","content":"lo"},"done":true}` + "\n"))
+	}))
+	defer server.Close()
+
+	var logged [][2][]byte
+	ollamaChat, err := NewOllamaChat(
+		models.WithID("test"),
+		models.WithBaseURL(server.URL),
+		models.WithAPIKey("secret-key"),
+		models.WithModelLogger(func(req, resp []byte) {
+			logged = append(logged, [2][]byte{req, resp})
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create OllamaChat: %v", err)
+	}
+
+	var streamed strings.Builder
+	err = ollamaChat.InvokeStream(context.Background(), []models.Message{{Role: models.TypeUserRole, Content: "say hello"}},
+		models.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			streamed.Write(chunk)
+			return nil
+		}))
+	if err != nil {
+		t.Fatalf("InvokeStream failed: %v", err)
+	}
+	if streamed.String() != "hello" {
+		t.Errorf("Expected the stream to be unchanged, got %q", streamed.String())
+	}
+
+	if len(logged) != 1 {
+		t.Fatalf("Expected 1 logged call, got %d", len(logged))
+	}
+	req, resp := string(logged[0][0]), string(logged[0][1])
+	if !strings.Contains(req, "POST /api/chat") || !strings.Contains(req, "say hello") {
+		t.Errorf("Expected the request line and body to be logged, got %q", req)
+	}
+	if strings.Contains(req, "secret-key") || !strings.Contains(req, "Authorization: [REDACTED]") {
+		t.Errorf("Expected the API key to be redacted, got %q", req)
+	}
+	if !strings.Contains(resp, "200 OK") || !strings.Contains(resp, `"content":"hel"`) || !strings.Contains(resp, `"content":"lo"`) {
+		t.Errorf("Expected the status and every streamed chunk to be logged, got %q", resp)
+	}
+}
+
+func TestOllamaChat_WithCircuitBreaker(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.Error(w, `{"error":"service unavailable"}`, http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ollamaChat, err := NewOllamaChat(
+		models.WithID("test"),
+		models.WithBaseURL(server.URL),
+		models.WithCircuitBreaker(2, 50*time.Millisecond, time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create OllamaChat: %v", err)
+	}
+	ctx := context.Background()
+	messages := []models.Message{{Role: models.TypeUserRole, Content: "hi"}}
+
+	// Failures further apart than the window do not add up
+	if _, err := ollamaChat.Invoke(ctx, messages); err == nil {
+		t.Fatal("Expected the call to fail")
+	}
+	time.Sleep(80 * time.Millisecond)
+	if _, err := ollamaChat.Invoke(ctx, messages); err == nil {
+		t.Fatal("Expected the call to fail")
+	}
+	breaker := ollamaChat.(*OllamaChat).Breaker()
+	if breaker.State() != models.CircuitClosed {
+		t.Fatalf("Expected a closed circuit, got %s", breaker.State())
+	}
+
+	if _, err := ollamaChat.Invoke(ctx, messages); err == nil {
+		t.Fatal("Expected the call to fail")
+	}
+	if breaker.State() != models.CircuitOpen {
+		t.Fatalf("Expected an open circuit, got %s", breaker.State())
+	}
+
+	// Open: both call styles fail fast without reaching the server
+	before := requests
+	if _, err := ollamaChat.Invoke(ctx, messages); !errors.Is(err, models.ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+	if err := ollamaChat.InvokeStream(ctx, messages); !errors.Is(err, models.ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen from InvokeStream, got %v", err)
+	}
+	if requests != before {
+		t.Errorf("Expected no requests while open, got %d", requests-before)
+	}
+}
+
+func TestOllamaChat_CircuitBreakerIgnoresConsumerErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"model":"test","message":{"role":"assistant","content":"hi"},"done":true}` + "\n"))
+	}))
+	defer server.Close()
+
+	ollamaChat, err := NewOllamaChat(
+		models.WithID("test"),
+		models.WithBaseURL(server.URL),
+		models.WithCircuitBreaker(1, 0, time.Hour),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create OllamaChat: %v", err)
+	}
+
+	stop := errors.New("stop")
+	err = ollamaChat.InvokeStream(context.Background(), []models.Message{{Role: models.TypeUserRole, Content: "hi"}},
+		models.WithStreamingFunc(func(ctx context.Context, chunk []byte) error { return stop }))
+	if !errors.Is(err, stop) {
+		t.Fatalf("Expected the consumer error, got %v", err)
+	}
+	if state := ollamaChat.(*OllamaChat).Breaker().State(); state != models.CircuitClosed {
+		t.Errorf("Expected a consumer error to keep the circuit closed, got %s", state)
+	}
+}
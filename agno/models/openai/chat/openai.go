@@ -11,8 +11,9 @@ import (
 
 // OpenAIChat represents the integration with the OpenAIChat API.
 type OpenAIChat struct {
-	client client.ClientInterface
-	opts   *models.ClientOptions
+	client  client.ClientInterface
+	opts    *models.ClientOptions
+	breaker *models.CircuitBreaker // nil without WithCircuitBreaker
 }
 
 // NewOpenAIChat creates a new instance of the integration with the OpenAIChat API.
@@ -32,8 +33,9 @@ func NewOpenAIChat(options ...models.OptionClient) (models.AgnoModelInterface, e
 	}
 
 	return &OpenAIChat{
-		client: cli,
-		opts:   opts,
+		client:  cli,
+		opts:    opts,
+		breaker: models.ClientBreaker(opts),
 	}, nil
 }
 
@@ -42,6 +44,22 @@ func (o *OpenAIChat) GetID() string {
 	return o.opts.ID
 }
 
+// Ping checks that the API is reachable, which also opens a pooled connection
+func (o *OpenAIChat) Ping(ctx context.Context) error {
+	pinger, ok := o.client.(interface {
+		Ping(ctx context.Context) error
+	})
+	if !ok {
+		return nil
+	}
+	return pinger.Ping(ctx)
+}
+
+// Breaker returns the circuit breaker configured with models.WithCircuitBreaker, or nil
+func (o *OpenAIChat) Breaker() *models.CircuitBreaker {
+	return o.breaker
+}
+
 // GetClientOptions returns the client options for this OpenAI model
 func (o *OpenAIChat) GetClientOptions() *models.ClientOptions {
 	return o.opts
@@ -61,7 +79,12 @@ func (o *OpenAIChat) Invoke(ctx context.Context, messages []models.Message, opti
 		options = append([]models.Option{models.WithMaxTokens(*o.opts.MaxTokens)}, options...)
 	}
 
-	resp, err := o.ChatCompletion(ctx, messages, options...)
+	var resp *client.ChatCompletionResponse
+	err := o.breaker.Do(func() error {
+		var err error
+		resp, err = o.ChatCompletion(ctx, messages, options...)
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -82,6 +105,7 @@ func (o *OpenAIChat) Invoke(ctx context.Context, messages []models.Message, opti
 		}
 	}
 
+	usage := resp.Usage.ModelUsage()
 	return &models.MessageResponse{
 		Role:             resp.Choices[0].Message.Role,
 		Content:          resp.Choices[0].Message.Content,
@@ -89,6 +113,7 @@ func (o *OpenAIChat) Invoke(ctx context.Context, messages []models.Message, opti
 		ToolCalls:        resp.Choices[0].Message.ToolCalls,
 		ToolResults:      resp.Choices[0].Message.ToolResults,
 		ReasoningContent: resp.Choices[0].Message.ReasoningContent,
+		Usage:            &usage,
 	}, nil
 }
 
@@ -119,11 +144,9 @@ func (o *OpenAIChat) InvokeStream(ctx context.Context, messages []models.Message
 		options = append([]models.Option{models.WithMaxTokens(*o.opts.MaxTokens)}, options...)
 	}
 
-	err := o.client.StreamChatCompletion(ctx, messages, options...)
-	if err != nil {
-		return err
-	}
-	return nil
+	return o.breaker.DoStream(options, func(options []models.Option) error {
+		return o.client.StreamChatCompletion(ctx, messages, options...)
+	})
 }
 
 // AInvokeStream is the asynchronous version of InvokeStream. It delegates to InvokeStream.
@@ -55,6 +55,12 @@ func NewClient(options ...models.OptionClient) (*Client, error) {
 	}, nil
 }
 
+// Ping checks that the API is reachable and the key is accepted by listing models
+func (c *Client) Ping(ctx context.Context) error {
+	_, err := c.client.Models.List(ctx)
+	return err
+}
+
 // CreateChatCompletion creates a chat completion request using the official client.
 func (c *Client) CreateChatCompletion(ctx context.Context, messages []models.Message, options ...models.Option) (*CompletionResponse, error) {
 	// Process options to get tools and other parameters
@@ -257,9 +263,7 @@ func (c *Client) CreateChatCompletion(ctx context.Context, messages []models.Mes
 			return nil, fmt.Errorf("failed to build OpenAI tools: %w", err)
 		}
 		params.Tools = openaiTools
-		params.ToolChoice = openai.ChatCompletionToolChoiceOptionUnionParam{
-			OfAuto: openai.String("auto"),
-		}
+		params.ToolChoice = openAIToolChoice(callOptions.ToolChoice)
 		maptools = toolMap
 	}
 
@@ -285,7 +289,9 @@ func (c *Client) CreateChatCompletion(ctx context.Context, messages []models.Mes
 		Object:  string(resp.Object),
 		Created: resp.Created,
 		Model:   string(resp.Model),
+		Usage:   newUsage(resp.Usage),
 	}
+	models.ReportUsage(ctx, result.Usage.ModelUsage())
 
 	if len(resp.Choices) > 0 {
 		choices := make([]Choices, len(resp.Choices))
@@ -362,7 +368,7 @@ func (c *Client) CreateChatCompletion(ctx context.Context, messages []models.Mes
 				if err != nil {
 					toolResponse = fmt.Sprintf("Error executing tool: %v", err)
 				} else {
-					switch v := resTool.(type) {
+					switch v := toolkit.ModelContent(resTool).(type) {
 					case string:
 						toolResponse = v
 					default:
@@ -400,6 +406,15 @@ func (c *Client) CreateChatCompletion(ctx context.Context, messages []models.Mes
 	return result, nil
 }
 
+// newUsage converts the usage of an OpenAI response
+func newUsage(usage openai.CompletionUsage) Usage {
+	return Usage{
+		PromptTokens:     int(usage.PromptTokens),
+		CompletionTokens: int(usage.CompletionTokens),
+		TotalTokens:      int(usage.TotalTokens),
+	}
+}
+
 func extractReasoningFields(raw string) (thinking string, reasoningContent string) {
 	if raw == "" {
 		return "", ""
@@ -455,7 +470,9 @@ func (c *Client) createStreamingCompletion(ctx context.Context, params openai.Ch
 		},
 	}
 
-	// Enable streaming using the official client streaming method
+	// Enable streaming using the official client streaming method, asking for
+	// the token usage in the last chunk
+	params.StreamOptions = openai.ChatCompletionStreamOptionsParam{IncludeUsage: openai.Bool(true)}
 	stream := c.client.Chat.Completions.NewStreaming(ctx, params)
 	defer stream.Close()
 
@@ -496,6 +513,8 @@ func (c *Client) createStreamingCompletion(ctx context.Context, params openai.Ch
 	if err := stream.Err(); err != nil {
 		return nil, err
 	}
+	usage := newUsage(acc.Usage)
+	models.ReportUsage(ctx, usage.ModelUsage())
 
 	// If we have tool calls, process them after streaming is complete
 	result := &CompletionResponse{
@@ -503,6 +522,7 @@ func (c *Client) createStreamingCompletion(ctx context.Context, params openai.Ch
 		Object:  "chat.completion",
 		Created: 0,
 		Model:   c.model,
+		Usage:   usage,
 		Choices: []Choices{
 			{
 				Message: models.MessageResponse{
@@ -525,6 +545,19 @@ func (c *Client) createStreamingCompletion(ctx context.Context, params openai.Ch
 	return result, nil
 }
 
+// openAIToolChoice converts a models.WithToolChoice value to the OpenAI tool_choice parameter
+func openAIToolChoice(choice string) openai.ChatCompletionToolChoiceOptionUnionParam {
+	switch choice {
+	case "":
+		return openai.ChatCompletionToolChoiceOptionUnionParam{OfAuto: openai.String("auto")}
+	case "auto", "none", "required":
+		return openai.ChatCompletionToolChoiceOptionUnionParam{OfAuto: openai.String(choice)}
+	default:
+		return openai.ChatCompletionToolChoiceOptionParamOfChatCompletionNamedToolChoice(
+			openai.ChatCompletionNamedToolChoiceFunctionParam{Name: choice})
+	}
+}
+
 func (c *Client) buildOpenAITools(toolkits []toolkit.Tool) ([]openai.ChatCompletionToolParam, map[string]toolkit.Tool, error) {
 	var result []openai.ChatCompletionToolParam
 	maptools := make(map[string]toolkit.Tool)
@@ -591,7 +624,7 @@ func (c *Client) processToolCalls(ctx context.Context, originalMessages []models
 					fmt.Printf("DEBUG: Tool execution error: %v\n", err)
 				}
 			} else {
-				switch v := resTool.(type) {
+				switch v := toolkit.ModelContent(resTool).(type) {
 				case string:
 					toolResponse = v
 				default:
@@ -678,6 +711,11 @@ func (c *Client) processToolCalls(ctx context.Context, originalMessages []models
 	// We'll merge the final response content with the original tool calls
 	finalResponse.Choices[0].Message.ToolCalls = originalToolCalls
 
+	// The response accounts for every request of the tool loop
+	finalResponse.Usage.PromptTokens += resp.Usage.PromptTokens
+	finalResponse.Usage.CompletionTokens += resp.Usage.CompletionTokens
+	finalResponse.Usage.TotalTokens += resp.Usage.TotalTokens
+
 	return finalResponse, nil
 }
 
@@ -693,6 +731,14 @@ func toRequestOptions(opts models.ClientOptions) []option.RequestOption {
 		reqOpts = append(reqOpts, option.WithBaseURL(opts.BaseURL))
 	}
 
+	// Reuse pooled connections instead of opening new ones under concurrent load
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = models.DefaultHTTPClient()
+	}
+	httpClient = models.LoggingHTTPClient(httpClient, opts.ModelLogger)
+	reqOpts = append(reqOpts, option.WithHTTPClient(httpClient))
+
 	return reqOpts
 }
 
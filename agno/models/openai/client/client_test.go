@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 	"testing"
@@ -337,3 +338,21 @@ func TestStreamChatCompletionWithExaTool(t *testing.T) {
 
 	t.Logf("Test completed successfully with actual streaming")
 }
+
+func TestOpenAIToolChoice(t *testing.T) {
+	tests := map[string]string{
+		"":         `"auto"`,
+		"none":     `"none"`,
+		"required": `"required"`,
+		"mock_add": `{"function":{"name":"mock_add"},"type":"function"}`,
+	}
+	for choice, want := range tests {
+		got, err := json.Marshal(openAIToolChoice(choice))
+		if err != nil {
+			t.Fatalf("Marshal failed: %v", err)
+		}
+		if string(got) != want {
+			t.Errorf("openAIToolChoice(%q) = %s, want %s", choice, got, want)
+		}
+	}
+}
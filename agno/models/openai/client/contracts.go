@@ -60,6 +60,11 @@ type Usage struct {
 	TotalTokens      int `json:"total_tokens"`
 }
 
+// ModelUsage returns the usage as models.Usage
+func (u Usage) ModelUsage() models.Usage {
+	return models.Usage{InputTokens: u.PromptTokens, OutputTokens: u.CompletionTokens}
+}
+
 type CompletionResponse struct {
 	ID      string    `json:"id"`
 	Object  string    `json:"object"`
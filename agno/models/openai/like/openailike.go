@@ -46,6 +46,17 @@ func (o *OpenAIChat) GetID() string {
 	return o.opts.ID
 }
 
+// Ping checks that the API is reachable, which also opens a pooled connection
+func (o *OpenAIChat) Ping(ctx context.Context) error {
+	pinger, ok := o.client.(interface {
+		Ping(ctx context.Context) error
+	})
+	if !ok {
+		return nil
+	}
+	return pinger.Ping(ctx)
+}
+
 // ChatCompletion performs a chat completion request.
 func (o *OpenAIChat) ChatCompletion(ctx context.Context, messages []models.Message, options ...models.Option) (*client.ChatCompletionResponse, error) {
 	return o.client.CreateChatCompletion(ctx, messages, options...)
@@ -60,6 +71,7 @@ func (o *OpenAIChat) Invoke(ctx context.Context, messages []models.Message, opti
 	if len(resp.Choices) == 0 {
 		return nil, errors.New("no choices in response")
 	}
+	usage := resp.Usage.ModelUsage()
 	return &models.MessageResponse{
 		Role:             resp.Choices[0].Message.Role,
 		Content:          resp.Choices[0].Message.Content,
@@ -67,6 +79,7 @@ func (o *OpenAIChat) Invoke(ctx context.Context, messages []models.Message, opti
 		ToolCalls:        resp.Choices[0].Message.ToolCalls,
 		ToolResults:      resp.Choices[0].Message.ToolResults,
 		ReasoningContent: resp.Choices[0].Message.ReasoningContent,
+		Usage:            &usage,
 	}, nil
 }
 
@@ -37,6 +37,7 @@ type CallOptions struct {
 	RequestParams       map[string]interface{}              `json:"request_params,omitempty"`        // Additional request parameters.
 	StreamingFunc       func(context.Context, []byte) error `json:"-"`                               // Callback function for streaming.
 	Tools               []tools.Tools                       `json:"tools,omitempty"`                 // Tools for function calls.
+	ToolChoice          string                              `json:"tool_choice,omitempty"`           // "auto", "none", "required" or a function name.
 	ToolCall            []toolkit.Tool                      `json:"-"`                               // Tools for function calls.
 }
 
@@ -55,6 +56,14 @@ func WithTools(tool []toolkit.Tool) Option {
 	}
 }
 
+// WithToolChoice controls whether the model calls tools: "auto" (default),
+// "none", "required", or the name of the function it must call.
+func WithToolChoice(choice string) Option {
+	return func(o *CallOptions) {
+		o.ToolChoice = choice
+	}
+}
+
 // WithStreamingFunc adds a callback function for processing streaming chunks.
 // Setting this option will make the request be performed in streaming mode.
 func WithStreamingFunc(f func(context.Context, []byte) error) Option {
@@ -77,6 +86,8 @@ type ClientOptions struct {
 	DefaultHeaders http.Header            `json:"-"`                       // Default headers.
 	DefaultQuery   map[string]string      `json:"-"`                       // Default query parameters.
 	HTTPClient     *http.Client           `json:"-"`                       // Custom HTTP client.
+	ModelLogger    ModelLogger            `json:"-"`                       // Receives raw requests and responses.
+	CircuitBreaker *CBConfig              `json:"-"`                       // Circuit breaker for model calls.
 	ClientParams   map[string]interface{} `json:"client_params,omitempty"` // Additional client parameters.
 	// Additional fields for chat requests.
 	ID               string   // Model to be used.
@@ -210,6 +221,8 @@ func WithResponseFormat(format interface{}) Option {
 }
 
 // WithSeed sets the seed for reproducibility.
+// Honored by OpenAI (and OpenAI-compatible clients), Ollama and Gemini; providers
+// without seed support ignore it. Combine with a fixed temperature for repeatable output.
 func WithSeed(seed int) Option {
 	return func(o *CallOptions) {
 		o.Seed = intPtr(seed)
@@ -258,6 +271,15 @@ func WithBaseURL(url string) func(*ClientOptions) {
 	}
 }
 
+// WithHTTPClient sets the HTTP client used by the model client, e.g. one
+// created with NewHTTPClient to tune the connection pool. Without it, model
+// clients share DefaultHTTPClient.
+func WithHTTPClient(client *http.Client) func(*ClientOptions) {
+	return func(o *ClientOptions) {
+		o.HTTPClient = client
+	}
+}
+
 // WithRequestParams sets additional request parameters.
 func WithRequestParams(params map[string]interface{}) Option {
 	return func(o *CallOptions) {
@@ -0,0 +1,371 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// ErrReplayExhausted is returned by a ReplayModel called more times than the
+// transcript recorded
+var ErrReplayExhausted = errors.New("replay: no more recorded model calls")
+
+// RunTranscript is the record of a run: its input, every model call with the
+// tools it ran, and the final output. It is plain JSON, so it can be saved as
+// a golden file and replayed with NewReplayModel.
+type RunTranscript struct {
+	Agent     string      `json:"agent,omitempty"`
+	Model     string      `json:"model"`
+	Input     string      `json:"input"`
+	Calls     []ModelCall `json:"calls"`
+	Output    string      `json:"output"`
+	Error     string      `json:"error,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// ModelCall is one recorded request to the model and its response
+type ModelCall struct {
+	Messages  []Message        `json:"messages"`
+	Response  *MessageResponse `json:"response,omitempty"`
+	Chunks    []string         `json:"chunks,omitempty"`     // Streamed chunks, in order
+	ToolCalls []ToolResult     `json:"tool_calls,omitempty"` // Tools run by the model client during the call
+	Error     string           `json:"error,omitempty"`
+}
+
+// LoadRunTranscript reads a transcript saved with RunTranscript.Save
+func LoadRunTranscript(path string) (*RunTranscript, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read transcript: %w", err)
+	}
+	var transcript RunTranscript
+	if err := json.Unmarshal(data, &transcript); err != nil {
+		return nil, fmt.Errorf("failed to parse transcript: %w", err)
+	}
+	return &transcript, nil
+}
+
+// Save writes the transcript to path as indented JSON
+func (t *RunTranscript) Save(path string) error {
+	data, err := json.MarshalIndent(t, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode transcript: %w", err)
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// RecordingModel wraps a model and records every call made through it
+type RecordingModel struct {
+	model AgnoModelInterface
+
+	mu    sync.Mutex
+	calls []ModelCall
+}
+
+// NewRecordingModel wraps model so its calls can be read back with Calls
+func NewRecordingModel(model AgnoModelInterface) *RecordingModel {
+	return &RecordingModel{model: model}
+}
+
+// Calls returns the calls recorded so far
+func (m *RecordingModel) Calls() []ModelCall {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]ModelCall(nil), m.calls...)
+}
+
+// Invoke calls the wrapped model and records the exchange
+func (m *RecordingModel) Invoke(ctx context.Context, messages []Message, options ...Option) (*MessageResponse, error) {
+	call := &ModelCall{Messages: append([]Message(nil), messages...)}
+	resp, err := m.model.Invoke(ctx, messages, m.recordTools(call, options)...)
+	m.record(call, resp, err)
+	return resp, err
+}
+
+// AInvoke calls the wrapped model asynchronously and records the exchange
+func (m *RecordingModel) AInvoke(ctx context.Context, messages []Message, options ...Option) (<-chan *MessageResponse, <-chan error) {
+	call := &ModelCall{Messages: append([]Message(nil), messages...)}
+	respChan, errChan := m.model.AInvoke(ctx, messages, m.recordTools(call, options)...)
+	return m.forwardAsync(call, respChan, errChan, false)
+}
+
+// InvokeStream streams from the wrapped model and records the chunks
+func (m *RecordingModel) InvokeStream(ctx context.Context, messages []Message, options ...Option) error {
+	call := &ModelCall{Messages: append([]Message(nil), messages...)}
+	options = m.recordTools(call, options)
+
+	callOptions := DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOptions)
+	}
+	if streamingFunc := callOptions.StreamingFunc; streamingFunc != nil {
+		options = append(options, WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+			m.mu.Lock()
+			call.Chunks = append(call.Chunks, string(chunk))
+			m.mu.Unlock()
+			return streamingFunc(ctx, chunk)
+		}))
+	}
+
+	err := m.model.InvokeStream(ctx, messages, options...)
+	m.record(call, nil, err)
+	return err
+}
+
+// AInvokeStream streams from the wrapped model asynchronously and records the chunks
+func (m *RecordingModel) AInvokeStream(ctx context.Context, messages []Message, options ...Option) (<-chan *MessageResponse, <-chan error) {
+	call := &ModelCall{Messages: append([]Message(nil), messages...)}
+	respChan, errChan := m.model.AInvokeStream(ctx, messages, m.recordTools(call, options)...)
+	return m.forwardAsync(call, respChan, errChan, true)
+}
+
+// GetID returns the wrapped model ID
+func (m *RecordingModel) GetID() string {
+	return m.model.GetID()
+}
+
+// recordTools wraps the tools of the call options so their executions are
+// added to call
+func (m *RecordingModel) recordTools(call *ModelCall, options []Option) []Option {
+	callOptions := DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOptions)
+	}
+	if len(callOptions.ToolCall) == 0 {
+		return options
+	}
+
+	wrapped := make([]toolkit.Tool, len(callOptions.ToolCall))
+	for i, tool := range callOptions.ToolCall {
+		wrapped[i] = &recordingTool{Tool: tool, model: m, call: call}
+	}
+	return append(append([]Option(nil), options...), func(o *CallOptions) {
+		o.ToolCall = wrapped
+	})
+}
+
+// record stores a finished call. For streamed calls the response is rebuilt
+// from the chunks.
+func (m *RecordingModel) record(call *ModelCall, resp *MessageResponse, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if resp != nil {
+		copied := *resp
+		call.Response = &copied
+	} else if len(call.Chunks) > 0 {
+		call.Response = &MessageResponse{
+			Model:   m.model.GetID(),
+			Role:    TypeAssistantRole,
+			Content: strings.Join(call.Chunks, ""),
+		}
+	}
+	if err != nil {
+		call.Error = err.Error()
+	}
+	m.calls = append(m.calls, *call)
+}
+
+// forwardAsync relays the wrapped channels and records the call once both close
+func (m *RecordingModel) forwardAsync(call *ModelCall, respIn <-chan *MessageResponse, errIn <-chan error, stream bool) (<-chan *MessageResponse, <-chan error) {
+	respOut := make(chan *MessageResponse, 1)
+	errOut := make(chan error, 1)
+
+	go func() {
+		defer close(respOut)
+		defer close(errOut)
+
+		var last *MessageResponse
+		var firstErr error
+		for respIn != nil || errIn != nil {
+			select {
+			case resp, ok := <-respIn:
+				if !ok {
+					respIn = nil
+					continue
+				}
+				if resp != nil {
+					if stream {
+						m.mu.Lock()
+						call.Chunks = append(call.Chunks, resp.Content)
+						m.mu.Unlock()
+					} else {
+						last = resp
+					}
+				}
+				respOut <- resp
+			case err, ok := <-errIn:
+				if !ok {
+					errIn = nil
+					continue
+				}
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
+				errOut <- err
+			}
+		}
+		m.record(call, last, firstErr)
+	}()
+
+	return respOut, errOut
+}
+
+// recordingTool records the executions of a tool into a model call
+type recordingTool struct {
+	toolkit.Tool
+	model *RecordingModel
+	call  *ModelCall
+}
+
+// Execute runs the tool and records its input and result
+func (t *recordingTool) Execute(methodName string, input json.RawMessage) (interface{}, error) {
+	result, err := t.Tool.Execute(methodName, input)
+
+	toolResult := ToolResult{
+		ToolName:  methodName,
+		ToolInput: string(input),
+		Result:    result,
+	}
+	if err != nil {
+		toolResult.Error = err.Error()
+	}
+
+	t.model.mu.Lock()
+	t.call.ToolCalls = append(t.call.ToolCalls, toolResult)
+	t.model.mu.Unlock()
+
+	return result, err
+}
+
+// ReplayModel plays back the model calls of a transcript in order, so a run
+// can be repeated deterministically without a real model. Recorded tool calls
+// are not executed again: the model responses already account for them.
+type ReplayModel struct {
+	transcript *RunTranscript
+
+	mu   sync.Mutex
+	next int
+}
+
+// NewReplayModel creates a model that returns the responses recorded in transcript
+func NewReplayModel(transcript *RunTranscript) *ReplayModel {
+	return &ReplayModel{transcript: transcript}
+}
+
+// Remaining returns the number of recorded calls not replayed yet
+func (m *ReplayModel) Remaining() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.transcript.Calls) - m.next
+}
+
+// Invoke returns the next recorded response
+func (m *ReplayModel) Invoke(ctx context.Context, messages []Message, options ...Option) (*MessageResponse, error) {
+	call, err := m.nextCall(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if call.Response == nil {
+		return &MessageResponse{Model: m.GetID(), Role: TypeAssistantRole}, nil
+	}
+	resp := *call.Response
+	return &resp, nil
+}
+
+// AInvoke returns the next recorded response asynchronously
+func (m *ReplayModel) AInvoke(ctx context.Context, messages []Message, options ...Option) (<-chan *MessageResponse, <-chan error) {
+	respChan := make(chan *MessageResponse, 1)
+	errChan := make(chan error, 1)
+	resp, err := m.Invoke(ctx, messages, options...)
+	if err != nil {
+		errChan <- err
+	} else {
+		respChan <- resp
+	}
+	close(respChan)
+	close(errChan)
+	return respChan, errChan
+}
+
+// InvokeStream sends the next recorded chunks to the streaming function, or
+// the whole recorded response when the call was not streamed
+func (m *ReplayModel) InvokeStream(ctx context.Context, messages []Message, options ...Option) error {
+	call, err := m.nextCall(ctx)
+	if err != nil {
+		return err
+	}
+
+	callOptions := DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOptions)
+	}
+	if callOptions.StreamingFunc == nil {
+		return nil
+	}
+	for _, chunk := range replayChunks(call) {
+		if err := callOptions.StreamingFunc(ctx, []byte(chunk)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AInvokeStream sends the next recorded chunks asynchronously
+func (m *ReplayModel) AInvokeStream(ctx context.Context, messages []Message, options ...Option) (<-chan *MessageResponse, <-chan error) {
+	call, err := m.nextCall(ctx)
+	chunks := replayChunks(call)
+	respChan := make(chan *MessageResponse, len(chunks))
+	errChan := make(chan error, 1)
+	if err != nil {
+		errChan <- err
+	}
+	for _, chunk := range chunks {
+		respChan <- &MessageResponse{Model: m.GetID(), Role: TypeAssistantRole, Content: chunk}
+	}
+	close(respChan)
+	close(errChan)
+	return respChan, errChan
+}
+
+// GetID returns the ID of the recorded model
+func (m *ReplayModel) GetID() string {
+	return m.transcript.Model
+}
+
+// nextCall returns the next recorded call, or its recorded error
+func (m *ReplayModel) nextCall(ctx context.Context) (ModelCall, error) {
+	if err := ctx.Err(); err != nil {
+		return ModelCall{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.next >= len(m.transcript.Calls) {
+		return ModelCall{}, ErrReplayExhausted
+	}
+	call := m.transcript.Calls[m.next]
+	m.next++
+	if call.Error != "" {
+		return call, errors.New(call.Error)
+	}
+	return call, nil
+}
+
+// replayChunks returns the chunks of a recorded call, falling back to its
+// whole response content
+func replayChunks(call ModelCall) []string {
+	if len(call.Chunks) > 0 {
+		return call.Chunks
+	}
+	if call.Response != nil && call.Response.Content != "" {
+		return []string{call.Response.Content}
+	}
+	return nil
+}
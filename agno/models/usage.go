@@ -0,0 +1,30 @@
+package models
+
+import "context"
+
+// Usage is the number of tokens a model call consumed, as reported by the provider
+type Usage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// usageReporterKey is the context key of the usage reporter
+type usageReporterKey struct{}
+
+// ContextWithUsageReporter returns a context whose model requests report their
+// token usage to fn. Model clients call ReportUsage once for each request they
+// send, so a run can count the tokens of tool loops and streamed responses.
+func ContextWithUsageReporter(ctx context.Context, fn func(Usage)) context.Context {
+	return context.WithValue(ctx, usageReporterKey{}, fn)
+}
+
+// ReportUsage reports the usage of a model request sent with ctx to the
+// reporter of ctx, if any
+func ReportUsage(ctx context.Context, usage Usage) {
+	if ctx == nil || usage.InputTokens == 0 && usage.OutputTokens == 0 {
+		return
+	}
+	if fn, ok := ctx.Value(usageReporterKey{}).(func(Usage)); ok {
+		fn(usage)
+	}
+}
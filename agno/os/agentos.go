@@ -18,6 +18,7 @@ import (
 
 	"github.com/devalexandre/agno-golang/agno/agent"
 	"github.com/devalexandre/agno-golang/agno/knowledge"
+	"github.com/devalexandre/agno-golang/agno/storage"
 	"github.com/devalexandre/agno-golang/agno/team"
 	"github.com/devalexandre/agno-golang/agno/utils/telemetry"
 	v2 "github.com/devalexandre/agno-golang/agno/workflow/v2"
@@ -45,6 +46,8 @@ type AgentOS struct {
 	server     *http.Server
 	router     *gin.Engine
 	sessions   map[string]*Session
+	storage    storage.Storage // Optional backend the sessions are persisted to
+	runMetrics *runMetrics     // Prometheus metrics of the agent runs, nil when disabled
 	events     []Event
 	interfaces []AgentOSInterface
 	templates  *template.Template
@@ -139,6 +142,7 @@ func NewAgentOS(options AgentOSOptions) (*AgentOS, error) {
 		config:             config,
 		settings:           settings,
 		sessions:           make(map[string]*Session),
+		storage:            options.Storage,
 		events:             make([]Event, 0),
 		interfaces:         options.Interfaces,
 		ctx:                ctx,
@@ -158,8 +162,13 @@ func NewAgentOS(options AgentOSOptions) (*AgentOS, error) {
 		},
 	}
 
+	if settings.EnableMetrics {
+		os.runMetrics = newRunMetrics()
+	}
+
 	// Initialize components
 	if err := os.initialize(); err != nil {
+		cancel()
 		return nil, fmt.Errorf("failed to initialize AgentOS: %w", err)
 	}
 
@@ -207,6 +216,13 @@ func (os *AgentOS) initialize() error {
 		}
 	}
 
+	// Restore the sessions persisted by previous runs
+	if os.storage != nil {
+		if err := os.loadSessions(); err != nil {
+			return fmt.Errorf("failed to load sessions: %w", err)
+		}
+	}
+
 	// Load HTML templates
 	templatePath := filepath.Join("agno", "os", "web", "templates", "*.html")
 	templates, err := template.ParseGlob(templatePath)
@@ -443,6 +459,11 @@ func (os *AgentOS) GetApp() *gin.Engine {
 	}
 
 	router := gin.New()
+	// Only trust X-Forwarded-For from configured proxies, so clients can't
+	// pick their own IP for rate limiting and logs
+	if err := router.SetTrustedProxies(os.settings.TrustedProxies); err != nil {
+		log.Printf("Warning: invalid trusted proxies: %v", err)
+	}
 
 	// Add telemetry middleware if enabled
 	if os.telemetry {
@@ -466,7 +487,7 @@ func (os *AgentOS) GetApp() *gin.Engine {
 
 	// Add middleware - only enable logging in debug mode
 	if os.settings.Debug {
-		router.Use(gin.Logger())
+		router.Use(gin.LoggerWithFormatter(requestLogFormatter))
 	}
 	router.Use(gin.Recovery())
 
@@ -475,6 +496,11 @@ func (os *AgentOS) GetApp() *gin.Engine {
 		router.Use(os.corsMiddleware())
 	}
 
+	// Rate limit each client if configured
+	if os.settings.RateLimit.RequestsPerMinute > 0 {
+		router.Use(os.rateLimitMiddleware())
+	}
+
 	// Add custom middleware if provided
 	for _, middleware := range os.middleware {
 		if mw, ok := middleware.(gin.HandlerFunc); ok {
@@ -498,6 +524,12 @@ func (os *AgentOS) setupRoutes(router *gin.Engine) {
 	router.GET("/health", os.healthHandler)
 	router.HEAD("/health", os.healthHandler)
 
+	// Kubernetes-style liveness and readiness probes - public
+	router.GET("/healthz", os.healthzHandler)
+	router.HEAD("/healthz", os.healthzHandler)
+	router.GET("/readyz", os.readyzHandler)
+	router.HEAD("/readyz", os.readyzHandler)
+
 	// AgentOS discovery endpoint - critical for cloud detection
 	router.GET("/ping", os.pingHandler)
 	router.HEAD("/ping", os.pingHandler)
@@ -529,24 +561,29 @@ func (os *AgentOS) setupRoutes(router *gin.Engine) {
 	router.HEAD("/models", os.modelsHandler)
 
 	// Agent and Team operations - needed by UI (compatible with Python API)
-	router.POST("/agents/:id/runs", os.agentRunsHandler)
-	router.POST("/agents/:id/runs/:run_id/cancel", os.cancelAgentRunHandler)
-	router.POST("/agents/:id/runs/:run_id/continue", os.continueAgentRunHandler)
-	router.POST("/teams/:id/runs", os.teamRunsHandler)
-	router.POST("/teams/:id/runs/:run_id/cancel", os.cancelTeamRunHandler)
-	router.POST("/workflows/:id/runs", os.workflowRunsHandler)
-	router.POST("/workflows/:id/runs/:run_id/cancel", os.cancelWorkflowRunHandler)
-
-	// Sessions - needed by UI and Python compatibility
-	router.GET("/sessions", os.sessionsHandler)
-	router.GET("/sessions/:session_id", os.getSessionHandler) // Get individual session
-	router.POST("/sessions/:session_id/runs", os.sessionRunsHandler)
-	router.GET("/sessions/:session_id/runs", os.getSessionRunsHandler) // Get session runs
+	// When auth is enabled, agent runs need a key with access to the agent
+	router.POST("/agents/:id/runs", os.agentAuthMiddleware("id"), os.agentRunsHandler)
+	router.POST("/agents/:id/runs/:run_id/cancel", os.agentAuthMiddleware("id"), os.cancelAgentRunHandler)
+	router.POST("/agents/:id/runs/:run_id/continue", os.agentAuthMiddleware("id"), os.continueAgentRunHandler)
+	// Team runs need access to every member, workflow runs an unrestricted key
+	router.POST("/teams/:id/runs", os.teamAuthMiddleware("id"), os.teamRunsHandler)
+	router.POST("/teams/:id/runs/:run_id/cancel", os.teamAuthMiddleware("id"), os.cancelTeamRunHandler)
+	router.POST("/workflows/:id/runs", os.workflowAuthMiddleware(), os.workflowRunsHandler)
+	router.POST("/workflows/:id/runs/:run_id/cancel", os.workflowAuthMiddleware(), os.cancelWorkflowRunHandler)
+
 	protected := router.Group("/")
 	protected.Use(os.authMiddleware())
 	{
 		// Version endpoint
 		protected.GET("/version", os.versionHandler)
+
+		// Sessions - needed by UI and Python compatibility. They hold the
+		// conversations, so keys only see the sessions of their agents.
+		protected.GET("/sessions", os.sessionsHandler)
+		protected.GET("/sessions/:session_id", os.getSessionHandler) // Get individual session
+		protected.DELETE("/sessions/:session_id", os.deleteSessionHandler)
+		protected.POST("/sessions/:session_id/runs", os.sessionRunsHandler)
+		protected.GET("/sessions/:session_id/runs", os.getSessionRunsHandler) // Get session runs
 	}
 
 	// Knowledge routes - compatible with Python API (uses /content not /documents)
@@ -580,7 +617,11 @@ func (os *AgentOS) setupRoutes(router *gin.Engine) {
 	router.GET("/memory/run_ids/:run_id", os.getMemoriesByRunIDHandler)
 
 	// Metrics routes - compatible with Python API
-	router.GET("/metrics", os.getMetricsHandler)
+	if os.runMetrics != nil {
+		router.GET("/metrics", os.prometheusMetricsHandler)
+	} else {
+		router.GET("/metrics", os.getMetricsHandler)
+	}
 	router.POST("/metrics", os.createMetricsHandler)
 
 	// Evals routes - compatible with Python API
@@ -592,6 +633,7 @@ func (os *AgentOS) setupRoutes(router *gin.Engine) {
 	// WebSocket endpoints
 	router.GET("/ws", os.websocketHandler)
 	router.GET("/workflows/ws", os.websocketHandler) // Frontend compatibility
+	router.GET("/agents/:agent_id/runs/ws", os.agentAuthMiddleware("agent_id"), os.agentRunsWebSocketHandler)
 }
 
 // Serve starts the AgentOS server
@@ -622,6 +664,9 @@ func (os *AgentOS) Serve() error {
 	log.Printf("⚙️  Configuration: %s://%s:%d/config", protocol, os.settings.Host, os.settings.Port)
 	log.Printf("☁️  Cloud Platform: %s", cloudEndpoint)
 	log.Printf("🔑 Security Key: %s", func() string {
+		if len(os.settings.APIKeys) > 0 {
+			return fmt.Sprintf("configured (%d API keys)", len(os.settings.APIKeys))
+		}
 		if os.settings.SecurityKey != "" {
 			return "configured"
 		}
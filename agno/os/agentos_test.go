@@ -279,3 +279,54 @@ func TestAgentOSSettings_DefaultValues(t *testing.T) {
 }
 
 // Helper function is now in conversions.go
+
+// pingModel is a model whose reachability can be controlled by tests
+type pingModel struct {
+	models.AgnoModelInterface
+	err error
+}
+
+func (m *pingModel) GetID() string                  { return "ping-model" }
+func (m *pingModel) Ping(ctx context.Context) error { return m.err }
+
+func TestAgentOS_ReadinessProbes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	model := &pingModel{}
+	testAgent, err := agent.NewAgent(agent.AgentConfig{
+		Context: context.Background(),
+		Name:    "TestAgent",
+		Model:   model,
+	})
+	require.NoError(t, err)
+
+	os, err := NewAgentOS(AgentOSOptions{
+		OSID:   "test-os",
+		Agents: []*agent.Agent{testAgent},
+	})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/healthz", os.healthzHandler)
+	router.GET("/readyz", os.readyzHandler)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusOK, get("/healthz").Code)
+	assert.NoError(t, os.Ready())
+	assert.Equal(t, http.StatusOK, get("/readyz").Code)
+
+	model.err = assert.AnError
+	assert.ErrorIs(t, os.Ready(), assert.AnError)
+	w := get("/readyz")
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Contains(t, w.Body.String(), `agent \"TestAgent\" model`)
+
+	// The liveness probe does not depend on dependencies
+	assert.Equal(t, http.StatusOK, get("/healthz").Code)
+}
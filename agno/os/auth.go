@@ -0,0 +1,222 @@
+package os
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+)
+
+// apiKeyContextKey is the gin context key of the APIKey that authenticated the request
+const apiKeyContextKey = "agentos_api_key"
+
+// securityKeyName is the name AgentOSSettings.SecurityKey is logged under
+const securityKeyName = "security_key"
+
+// authEnabled reports whether requests must carry a key
+func (os *AgentOS) authEnabled() bool {
+	return os.settings != nil && (os.settings.SecurityKey != "" || len(os.settings.APIKeys) > 0)
+}
+
+// authenticate returns the key matching token, or nil. The SecurityKey gives
+// access to every agent.
+func (os *AgentOS) authenticate(token string) *APIKey {
+	if token == "" || os.settings == nil {
+		return nil
+	}
+	if os.settings.SecurityKey != "" && keysEqual(token, os.settings.SecurityKey) {
+		return &APIKey{Key: os.settings.SecurityKey, Name: securityKeyName}
+	}
+	for i := range os.settings.APIKeys {
+		if keysEqual(token, os.settings.APIKeys[i].Key) {
+			return &os.settings.APIKeys[i]
+		}
+	}
+	return nil
+}
+
+// keysEqual compares keys in constant time
+func keysEqual(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+// requestToken returns the key of a request: the Bearer token, or the "token"
+// query parameter, since browsers cannot set headers on WebSocket requests
+func requestToken(c *gin.Context) string {
+	if authHeader := c.GetHeader("Authorization"); strings.HasPrefix(authHeader, "Bearer ") {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+	return c.Query("token")
+}
+
+// canAccessAgent reports whether the key may use the agent requested as id.
+// Allowed agents can be listed by name or by ID.
+func (k *APIKey) canAccessAgent(id string, ag *agent.Agent) bool {
+	if len(k.AllowedAgents) == 0 {
+		return true
+	}
+	for _, allowed := range k.AllowedAgents {
+		if allowed == id {
+			return true
+		}
+	}
+	return ag != nil && k.canAccessAgentNamed(ag.GetName())
+}
+
+// canAccessAgentNamed reports whether the key may use the agent with the given
+// name, allowed by name or by ID
+func (k *APIKey) canAccessAgentNamed(name string) bool {
+	if len(k.AllowedAgents) == 0 {
+		return true
+	}
+	for _, allowed := range k.AllowedAgents {
+		if allowed == name || allowed == generateDeterministicID("agent", name) {
+			return true
+		}
+	}
+	return false
+}
+
+// requireKey authenticates the request when auth is enabled. It returns false,
+// having answered 401, when the request has no valid key.
+func (os *AgentOS) requireKey(c *gin.Context) (*APIKey, bool) {
+	key := os.authenticate(requestToken(c))
+	if key == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication token"})
+		c.Abort()
+		return nil, false
+	}
+	c.Set(apiKeyContextKey, key)
+	return key, true
+}
+
+// agentAuthMiddleware protects the routes of an agent, named by the param
+// route parameter: when auth is enabled the request needs a valid key (401)
+// that has access to the agent (403)
+func (os *AgentOS) agentAuthMiddleware(param string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if !os.authEnabled() {
+			c.Next()
+			return
+		}
+
+		key, ok := os.requireKey(c)
+		if !ok {
+			return
+		}
+
+		agentID := c.Param(param)
+		if !key.canAccessAgent(agentID, os.findAgent(agentID)) {
+			c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("API key %q has no access to agent %s", key.Name, agentID)})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	})
+}
+
+// teamAuthMiddleware protects the routes of a team, named by the param route
+// parameter. Team runs run the member agents, so a key needs access to every
+// member (403).
+func (os *AgentOS) teamAuthMiddleware(param string) gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if !os.authEnabled() {
+			c.Next()
+			return
+		}
+
+		key, ok := os.requireKey(c)
+		if !ok {
+			return
+		}
+
+		teamID := c.Param(param)
+		for _, t := range os.teams {
+			if generateDeterministicID("team", t.GetName()) != teamID && t.GetName() != teamID {
+				continue
+			}
+			for _, member := range t.GetMembers() {
+				if !key.canAccessAgentNamed(member.GetName()) {
+					c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("API key %q has no access to agent %s of team %s", key.Name, member.GetName(), teamID)})
+					c.Abort()
+					return
+				}
+			}
+		}
+
+		c.Next()
+	})
+}
+
+// workflowAuthMiddleware protects the routes of workflows. Their steps can run
+// any agent, so keys restricted to some agents are refused (403).
+func (os *AgentOS) workflowAuthMiddleware() gin.HandlerFunc {
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if !os.authEnabled() {
+			c.Next()
+			return
+		}
+
+		key, ok := os.requireKey(c)
+		if !ok {
+			return
+		}
+		if len(key.AllowedAgents) > 0 {
+			c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("API key %q is restricted to some agents and cannot run workflows", key.Name)})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	})
+}
+
+// findAgent returns the agent with the given ID or name, or nil
+func (os *AgentOS) findAgent(id string) *agent.Agent {
+	for _, ag := range os.agents {
+		if generateDeterministicID("agent", ag.GetName()) == id || ag.GetName() == id {
+			return ag
+		}
+	}
+	return nil
+}
+
+// canAccessSession reports whether the key that authenticated the request may
+// see the session: keys restricted to some agents only see those agents'
+// sessions. Without auth every session is visible.
+func (os *AgentOS) canAccessSession(c *gin.Context, session *Session) bool {
+	value, _ := c.Get(apiKeyContextKey)
+	key, ok := value.(*APIKey)
+	if !ok || len(key.AllowedAgents) == 0 {
+		return true
+	}
+	return session.AgentID != nil && key.canAccessAgent(*session.AgentID, os.findAgent(*session.AgentID))
+}
+
+// requestLogFormatter is gin's request log line plus the name of the key that
+// authenticated the request, "-" for anonymous requests
+func requestLogFormatter(param gin.LogFormatterParams) string {
+	keyName := "-"
+	if key, ok := param.Keys[apiKeyContextKey].(*APIKey); ok {
+		keyName = key.Name
+	}
+	if param.Latency > time.Minute {
+		param.Latency = param.Latency.Truncate(time.Second)
+	}
+	return fmt.Sprintf("[GIN] %v | %3d | %13v | %15s | key=%s | %-7s %#v\n%s",
+		param.TimeStamp.Format("2006/01/02 - 15:04:05"),
+		param.StatusCode,
+		param.Latency,
+		param.ClientIP,
+		keyName,
+		param.Method,
+		param.Path,
+		param.ErrorMessage,
+	)
+}
@@ -0,0 +1,202 @@
+package os
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+	"github.com/devalexandre/agno-golang/agno/team"
+	v2 "github.com/devalexandre/agno-golang/agno/workflow/v2"
+)
+
+func TestAPIKeyAuth(t *testing.T) {
+	newAgent := func(name string) *agent.Agent {
+		ag, err := agent.NewAgent(agent.AgentConfig{
+			Context: context.Background(),
+			Name:    name,
+			Model:   &slowStreamModel{chunks: []string{"ok"}},
+		})
+		require.NoError(t, err)
+		return ag
+	}
+
+	os, err := NewAgentOS(AgentOSOptions{
+		OSID:   "test-os",
+		Agents: []*agent.Agent{newAgent("support"), newAgent("billing")},
+		Settings: &AgentOSSettings{
+			SecurityKey: "legacy-key",
+			APIKeys: []APIKey{
+				{Key: "support-key", Name: "support-team", AllowedAgents: []string{"support"}},
+				{Key: "billing-key", Name: "billing-team", AllowedAgents: []string{generateDeterministicID("agent", "billing")}},
+				{Key: "admin-key", Name: "admin"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	router := os.GetApp()
+
+	run := func(agentID, token string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/agents/"+agentID+"/runs", strings.NewReader(`{"message": "hi"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	tests := []struct {
+		name  string
+		agent string
+		token string
+		want  int
+	}{
+		{"no key", "support", "", http.StatusUnauthorized},
+		{"unknown key", "support", "nope", http.StatusUnauthorized},
+		{"allowed by name", "support", "support-key", http.StatusOK},
+		{"not allowed", "billing", "support-key", http.StatusForbidden},
+		{"allowed by id", "billing", "billing-key", http.StatusOK},
+		{"allowed by id, requested by id", generateDeterministicID("agent", "billing"), "billing-key", http.StatusOK},
+		{"unrestricted key", "billing", "admin-key", http.StatusOK},
+		{"security key", "billing", "legacy-key", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, run(tt.agent, tt.token))
+		})
+	}
+
+	// Every configured key passes the protected routes
+	for _, token := range []string{"legacy-key", "support-key"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/version", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		router.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, token)
+	}
+}
+
+func TestTeamAndWorkflowRunsAuth(t *testing.T) {
+	newAgent := func(name string) *agent.Agent {
+		ag, err := agent.NewAgent(agent.AgentConfig{
+			Context: context.Background(),
+			Name:    name,
+			Model:   &slowStreamModel{chunks: []string{"ok"}},
+		})
+		require.NoError(t, err)
+		return ag
+	}
+	support, billing := newAgent("support"), newAgent("billing")
+
+	os, err := NewAgentOS(AgentOSOptions{
+		OSID:   "test-os",
+		Agents: []*agent.Agent{support, billing},
+		Teams: []*team.Team{
+			team.NewTeam(team.TeamConfig{Context: context.Background(), Name: "helpdesk", Members: []*agent.Agent{support}}),
+			team.NewTeam(team.TeamConfig{Context: context.Background(), Name: "everyone", Members: []*agent.Agent{support, billing}}),
+		},
+		Workflows: []*v2.Workflow{{WorkflowID: "onboarding", Name: "onboarding"}},
+		Settings: &AgentOSSettings{
+			APIKeys: []APIKey{
+				{Key: "support-key", Name: "support-team", AllowedAgents: []string{"support"}},
+				{Key: "admin-key", Name: "admin"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	router := os.GetApp()
+
+	request := func(path, token string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", path, strings.NewReader(`{"message": "hi"}`))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	for _, path := range []string{"/teams/everyone/runs", "/teams/everyone/runs/r1/cancel", "/workflows/onboarding/runs", "/workflows/onboarding/runs/r1/cancel"} {
+		assert.Equal(t, http.StatusUnauthorized, request(path, ""), path)
+	}
+
+	// The team contains an agent the key has no access to
+	assert.Equal(t, http.StatusForbidden, request("/teams/everyone/runs", "support-key"))
+	assert.Equal(t, http.StatusForbidden, request("/teams/"+generateDeterministicID("team", "everyone")+"/runs/r1/cancel", "support-key"))
+	assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, request("/teams/helpdesk/runs/r1/cancel", "support-key"))
+	assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, request("/teams/everyone/runs/r1/cancel", "admin-key"))
+
+	// Workflow steps can run any agent
+	assert.Equal(t, http.StatusForbidden, request("/workflows/onboarding/runs", "support-key"))
+	assert.NotContains(t, []int{http.StatusUnauthorized, http.StatusForbidden}, request("/workflows/onboarding/runs/r1/cancel", "admin-key"))
+}
+
+func TestSessionRoutesAuth(t *testing.T) {
+	os, err := NewAgentOS(AgentOSOptions{
+		OSID: "test-os",
+		Settings: &AgentOSSettings{
+			APIKeys: []APIKey{
+				{Key: "support-key", Name: "support-team", AllowedAgents: []string{"support"}},
+				{Key: "admin-key", Name: "admin"},
+			},
+		},
+	})
+	require.NoError(t, err)
+	support, billing := "support", "billing"
+	os.sessions["s-support"] = &Session{ID: "s-support", AgentID: &support}
+	os.sessions["s-billing"] = &Session{ID: "s-billing", AgentID: &billing}
+	router := os.GetApp()
+
+	request := func(method, path, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, nil)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for _, route := range [][2]string{{"GET", "/sessions"}, {"GET", "/sessions/s-support"}, {"GET", "/sessions/s-support/runs"}, {"DELETE", "/sessions/s-support"}} {
+		assert.Equal(t, http.StatusUnauthorized, request(route[0], route[1], "").Code, route[1])
+	}
+
+	// A restricted key only sees the sessions of its agents
+	w := request("GET", "/sessions", "support-key")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "s-support")
+	assert.NotContains(t, w.Body.String(), "s-billing")
+	assert.Equal(t, http.StatusOK, request("GET", "/sessions/s-support", "support-key").Code)
+	assert.Equal(t, http.StatusForbidden, request("GET", "/sessions/s-billing", "support-key").Code)
+	assert.Equal(t, http.StatusForbidden, request("GET", "/sessions/s-billing/runs", "support-key").Code)
+	assert.Equal(t, http.StatusForbidden, request("DELETE", "/sessions/s-billing", "support-key").Code)
+	assert.Contains(t, os.sessions, "s-billing")
+
+	w = request("GET", "/sessions", "admin-key")
+	assert.Contains(t, w.Body.String(), "s-billing")
+	assert.Equal(t, http.StatusOK, request("DELETE", "/sessions/s-billing", "admin-key").Code)
+	assert.NotContains(t, os.sessions, "s-billing")
+}
+
+func TestRequestLogFormatter(t *testing.T) {
+	line := requestLogFormatter(gin.LogFormatterParams{
+		StatusCode: 200,
+		Method:     "POST",
+		Path:       "/agents/support/runs",
+		Keys:       map[string]any{apiKeyContextKey: &APIKey{Name: "support-team"}},
+	})
+	assert.Contains(t, line, "key=support-team")
+	assert.Contains(t, line, `"/agents/support/runs"`)
+
+	line = requestLogFormatter(gin.LogFormatterParams{StatusCode: 200, Method: "GET", Path: "/health"})
+	assert.Contains(t, line, "key=-")
+}
@@ -5,8 +5,10 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
@@ -50,6 +52,18 @@ type RunCompletedMetrics struct {
 	Duration         float64 `json:"duration"`
 }
 
+// runCompletedMetrics returns the metrics of a run that started at start,
+// with the token counts of the agent's run metrics
+func runCompletedMetrics(metrics map[string]interface{}, start time.Time) RunCompletedMetrics {
+	input, output := tokenCount(metrics["input_tokens"]), tokenCount(metrics["output_tokens"])
+	return RunCompletedMetrics{
+		InputTokens:  input,
+		OutputTokens: output,
+		TotalTokens:  input + output,
+		Duration:     time.Since(start).Seconds(),
+	}
+}
+
 type RunCompletedEvent struct {
 	CreatedAt   int64               `json:"created_at"`
 	Event       string              `json:"event"`
@@ -62,6 +76,40 @@ type RunCompletedEvent struct {
 	Metrics     RunCompletedMetrics `json:"metrics"`
 }
 
+// sseKeepAliveInterval is how often a comment is written to an idle SSE stream
+// so proxies don't close it while the agent is thinking or running tools
+var sseKeepAliveInterval = 15 * time.Second
+
+// writeSSE writes one Server-Sent Event with data encoded as JSON and flushes it
+func writeSSE(c *gin.Context, event string, data interface{}) {
+	payload, _ := json.Marshal(data)
+	c.Writer.Write([]byte(fmt.Sprintf("event: %s\ndata: %s\n\n", event, payload)))
+	c.Writer.Flush()
+}
+
+// runEventTool returns the tool payload of a tool call or tool output event,
+// shared by the SSE and WebSocket run streams
+func runEventTool(event agent.RunEvent) gin.H {
+	if event.Event == agent.RunEventToolOutput {
+		return gin.H{
+			"tool_name": event.ToolName,
+			"stream":    event.Stream,
+		}
+	}
+	tool := gin.H{
+		"tool_name": event.ToolName,
+		"tool_args": event.ToolArgs,
+	}
+	if event.Event == agent.RunEventToolCallCompleted {
+		tool["result"] = event.ToolResult
+		if event.Error != "" {
+			tool["tool_call_error"] = true
+			tool["error"] = event.Error
+		}
+	}
+	return tool
+}
+
 // corsMiddleware adds CORS headers
 func (os *AgentOS) corsMiddleware() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
@@ -119,8 +167,8 @@ func (os *AgentOS) corsMiddleware() gin.HandlerFunc {
 // authMiddleware provides optional authentication
 func (os *AgentOS) authMiddleware() gin.HandlerFunc {
 	return gin.HandlerFunc(func(c *gin.Context) {
-		// If no security key is set, skip authentication
-		if os.settings == nil || os.settings.SecurityKey == "" {
+		// If no key is set, skip authentication
+		if !os.authEnabled() {
 			c.Next()
 			return
 		}
@@ -139,12 +187,13 @@ func (os *AgentOS) authMiddleware() gin.HandlerFunc {
 			return
 		}
 
-		token := strings.TrimPrefix(authHeader, "Bearer ")
-		if token != os.settings.SecurityKey {
+		key := os.authenticate(strings.TrimPrefix(authHeader, "Bearer "))
+		if key == nil {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authentication token"})
 			c.Abort()
 			return
 		}
+		c.Set(apiKeyContextKey, key)
 
 		c.Next()
 	})
@@ -387,7 +436,7 @@ func (os *AgentOS) infoHandler(c *gin.Context) {
 		"capabilities": gin.H{
 			"streaming":      true,
 			"websockets":     true,
-			"authentication": os.settings.SecurityKey != "",
+			"authentication": os.authEnabled(),
 			"cors":           os.settings.EnableCORS,
 		},
 	})
@@ -509,7 +558,7 @@ func (os *AgentOS) websocketHandler(c *gin.Context) {
 	// Connection tracking
 	connectionID := generateID("ws_conn")
 	isAuthenticated := false
-	requiresAuth := os.settings.SecurityKey != "" // Require auth if security key is set
+	requiresAuth := os.authEnabled() // Require auth if a key is set
 
 	// Send connection confirmation
 	conn.WriteJSON(gin.H{
@@ -551,8 +600,8 @@ func (os *AgentOS) websocketHandler(c *gin.Context) {
 				continue
 			}
 
-			// Validate token against security key
-			if token == os.settings.SecurityKey || !requiresAuth {
+			// Validate token against the configured keys
+			if os.authenticate(token) != nil || !requiresAuth {
 				isAuthenticated = true
 				conn.WriteJSON(gin.H{
 					"event":   "authenticated",
@@ -699,6 +748,102 @@ func (os *AgentOS) websocketHandler(c *gin.Context) {
 	}
 }
 
+// agentRunsWebSocketHandler streams agent runs over a WebSocket.
+// Each client message {"message": "...", "session_id": "..."} starts a run whose
+// events (RunStarted, RunContent, ToolCallStarted, ToolOutput, ToolCallCompleted,
+// RunCompleted, RunError) are sent back as JSON messages. When a security key is configured the
+// upgrade request must carry it as a Bearer token or as the "token" query parameter,
+// since browsers cannot set headers on WebSocket requests (see agentAuthMiddleware).
+func (os *AgentOS) agentRunsWebSocketHandler(c *gin.Context) {
+	agentID := c.Param("agent_id")
+	var targetAgent *agent.Agent
+	for _, ag := range os.agents {
+		if generateDeterministicID("agent", ag.GetName()) == agentID || ag.GetName() == agentID {
+			targetAgent = ag
+			break
+		}
+	}
+	if targetAgent == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Agent not found"})
+		return
+	}
+
+	conn, err := os.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	for {
+		var req struct {
+			Message   string `json:"message"`
+			SessionID string `json:"session_id,omitempty"`
+		}
+		if err := conn.ReadJSON(&req); err != nil {
+			break
+		}
+
+		if req.Message == "" {
+			conn.WriteJSON(gin.H{"event": "RunError", "content": "Message is required"})
+			continue
+		}
+
+		runID := generateID("run")
+		sessionID := req.SessionID
+		if sessionID == "" {
+			sessionID = generateID("session")
+		}
+
+		baseEvent := func(event string) gin.H {
+			return gin.H{
+				"created_at": time.Now().Unix(),
+				"event":      event,
+				"agent_id":   agentID,
+				"agent_name": targetAgent.GetName(),
+				"run_id":     runID,
+				"session_id": sessionID,
+			}
+		}
+
+		if err := conn.WriteJSON(baseEvent("RunStarted")); err != nil {
+			break
+		}
+
+		startTime := time.Now()
+		observer := os.runMetrics.startRun(targetAgent.GetName())
+		err := targetAgent.RunStreamEvents(req.Message, func(event agent.RunEvent) error {
+			observer.event(event)
+			data := baseEvent(string(event.Event))
+			data["created_at"] = event.CreatedAt.Unix()
+			switch event.Event {
+			case agent.RunEventContent:
+				data["content"] = event.Content
+				data["content_type"] = "str"
+			case agent.RunEventToolCallStarted, agent.RunEventToolCallCompleted:
+				data["tool"] = runEventTool(event)
+			case agent.RunEventToolOutput:
+				data["content"] = event.Content
+				data["tool"] = runEventTool(event)
+			case agent.RunEventCompleted:
+				data["content"] = event.Content
+				data["content_type"] = "str"
+				data["metrics"] = runCompletedMetrics(event.Metrics, startTime)
+			}
+			return conn.WriteJSON(data)
+		}, agent.WithSessionID(sessionID))
+		if err != nil {
+			observer.finish("error")
+			errorEvent := baseEvent("RunError")
+			errorEvent["content"] = err.Error()
+			if writeErr := conn.WriteJSON(errorEvent); writeErr != nil {
+				break
+			}
+			continue
+		}
+		observer.finish("completed")
+	}
+}
+
 // setupAgentRoutes configures routes for agent management
 func (os *AgentOS) setupAgentRoutes(router *gin.RouterGroup) {
 	router.GET("/", os.listAgentsHandler)
@@ -995,6 +1140,7 @@ func (os *AgentOS) createSessionHandler(c *gin.Context) {
 	os.mu.Lock()
 	os.sessions[session.ID] = session
 	os.mu.Unlock()
+	os.persistSession(session.ID)
 
 	c.JSON(http.StatusCreated, gin.H{"session": session})
 }
@@ -1013,6 +1159,7 @@ func (os *AgentOS) getSessionHandler(c *gin.Context) {
 
 	os.mu.Lock()
 	session, exists := os.sessions[sessionID]
+	created := false
 
 	// Create session if it doesn't exist and we have the required parameters
 	if !exists && sessionType != "" && userID != "" {
@@ -1034,6 +1181,7 @@ func (os *AgentOS) getSessionHandler(c *gin.Context) {
 		}
 		os.sessions[sessionID] = session
 		exists = true
+		created = true
 	}
 	os.mu.Unlock()
 
@@ -1041,26 +1189,58 @@ func (os *AgentOS) getSessionHandler(c *gin.Context) {
 		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
 		return
 	}
+	if !os.canAccessSession(c, session) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "API key has no access to this session"})
+		return
+	}
+	if created {
+		os.persistSession(sessionID)
+	}
 
+	os.mu.RLock()
+	defer os.mu.RUnlock()
 	c.JSON(http.StatusOK, gin.H{
-		"id":         sessionID,
-		"user_id":    session.UserID,
-		"type":       sessionType,
-		"db_id":      dbID,
-		"created_at": session.CreatedAt,
-		"updated_at": session.UpdatedAt,
-		"metadata":   session.Metadata,
-		"active":     session.Active,
+		"id":           sessionID,
+		"user_id":      session.UserID,
+		"agent_id":     session.AgentID,
+		"team_id":      session.TeamID,
+		"type":         sessionType,
+		"db_id":        dbID,
+		"created_at":   session.CreatedAt,
+		"updated_at":   session.UpdatedAt,
+		"metadata":     session.Metadata,
+		"active":       session.Active,
+		"runs":         len(session.Runs),
+		"chat_history": sessionChatHistory(session),
 	})
 }
 
 func (os *AgentOS) deleteSessionHandler(c *gin.Context) {
-	id := c.Param("id")
+	id := c.Param("session_id")
+	// Fallback for API routes that use "id"
+	if id == "" {
+		id = c.Param("id")
+	}
 
 	os.mu.Lock()
+	session, exists := os.sessions[id]
+	if exists && !os.canAccessSession(c, session) {
+		os.mu.Unlock()
+		c.JSON(http.StatusForbidden, gin.H{"error": "API key has no access to this session"})
+		return
+	}
 	delete(os.sessions, id)
 	os.mu.Unlock()
 
+	if err := os.deleteStoredSession(c.Request.Context(), id); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("failed to delete session: %v", err)})
+		return
+	}
+	if !exists {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+
 	c.JSON(http.StatusOK, gin.H{"message": "Session deleted"})
 }
 
@@ -1093,6 +1273,7 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 
 	var message string
 	var sessionID string
+	var streamRequested bool
 
 	// Check content type and parse accordingly
 	contentType := c.GetHeader("Content-Type")
@@ -1114,6 +1295,7 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 		}
 		message = req.Message
 		sessionID = req.SessionID
+		streamRequested = req.Stream
 
 	} else if strings.Contains(contentType, "multipart/form-data") || strings.Contains(contentType, "application/x-www-form-urlencoded") {
 		// Parse form data before accessing fields
@@ -1140,6 +1322,7 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 			if err := c.ShouldBindJSON(&req); err == nil {
 				message = req.Message
 				sessionID = req.SessionID
+				streamRequested = req.Stream
 			}
 		}
 	}
@@ -1232,8 +1415,8 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 	// Get user_id for run options
 	userID := c.PostForm("user_id")
 
-	// Check if streaming is requested
-	stream := c.PostForm("stream") == "true"
+	// Check if streaming is requested, as a form field or in the JSON body
+	stream := streamRequested || c.PostForm("stream") == "true"
 
 	if stream {
 		// Track timing for metrics (Python compatible)
@@ -1316,6 +1499,7 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 			os.sessions[sessionID] = &Session{
 				ID:        sessionID,
 				UserID:    &userID,
+				AgentID:   &agentID,
 				Active:    true,
 				CreatedAt: time.Now(),
 				UpdatedAt: time.Now(),
@@ -1324,9 +1508,11 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 			}
 		}
 		os.mu.Unlock()
+		os.persistSession(sessionID)
 
 		// Run the agent with streaming - following Python implementation
 		var finalmessage string
+		var usage RunCompletedMetrics
 
 		// Prepare run options with media uploads and user context
 		runOpts := []agent.RunOption{
@@ -1382,95 +1568,149 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 			runOpts = append(runOpts, agent.WithFiles(fileValues...))
 		}
 
-		// TODO: Update RunStream to accept RunOptions
-		// For now, we'll call Run instead when media is present
+		// Events of the run: the agent's streaming path, or a single content
+		// event when media is present since streamed runs do not take media
+		ctx := c.Request.Context()
+		observer := os.runMetrics.startRun(targetAgent.GetName())
+		var events <-chan agent.RunEvent
 		if len(images) > 0 || len(audio) > 0 || len(videos) > 0 || len(files) > 0 {
-			// Use Run with options and manually stream the response
 			// Convert runOpts to interface{} slice
 			interfaceOpts := make([]interface{}, len(runOpts))
 			for i, opt := range runOpts {
 				interfaceOpts[i] = opt
 			}
-			response, err := targetAgent.Run(message, interfaceOpts...)
-			if err != nil {
-				// Send error event
-				errorEvent := map[string]interface{}{
-					"created_at": time.Now().Unix(),
-					"event":      "RunError",
-					"agent_id":   agentID,
-					"agent_name": targetAgent.GetName(),
-					"run_id":     runID,
-					"session_id": sessionID,
-					"error":      err.Error(),
+			runEvents := make(chan agent.RunEvent, 2)
+			go func() {
+				defer close(runEvents)
+				response, err := targetAgent.Run(message, interfaceOpts...)
+				if err != nil {
+					runEvents <- agent.RunEvent{Event: agent.RunEventError, Error: err.Error(), Err: err, CreatedAt: time.Now()}
+					return
 				}
-				errorJSON, _ := json.Marshal(errorEvent)
-				c.Writer.Write([]byte(fmt.Sprintf("event: RunError\ndata: %s\n\n", errorJSON)))
-				c.Writer.Flush()
+				runEvents <- agent.RunEvent{Event: agent.RunEventContent, Content: response.TextContent, CreatedAt: time.Now()}
+				runEvents <- agent.RunEvent{Event: agent.RunEventCompleted, Content: response.TextContent, Metrics: response.Metrics, CreatedAt: time.Now()}
+			}()
+			events = runEvents
+		} else {
+			runEvents, err := targetAgent.RunChan(message, agent.WithChanContext(ctx), agent.WithChanRunOptions(runOpts...))
+			if err != nil {
+				writeSSE(c, "RunError", gin.H{"error": err.Error()})
+				c.Abort()
 				return
 			}
+			events = runEvents
+		}
 
-			// Manually stream the content
-			finalmessage = response.TextContent
-
-			// Send RunContent event
-			contentEvent := RunContentEvent{
-				CreatedAt:        time.Now().Unix(),
-				Event:            "RunContent",
-				AgentID:          agentID,
-				AgentName:        targetAgent.GetName(),
-				RunID:            runID,
-				SessionID:        sessionID,
-				Content:          response.TextContent,
-				ContentType:      "str",
-				ReasoningContent: "",
+		baseEvent := func(event agent.RunEvent) gin.H {
+			return gin.H{
+				"created_at": event.CreatedAt.Unix(),
+				"event":      string(event.Event),
+				"agent_id":   agentID,
+				"agent_name": targetAgent.GetName(),
+				"run_id":     runID,
+				"session_id": sessionID,
 			}
-			contentEventJSON, _ := json.Marshal(contentEvent)
-			c.Writer.Write([]byte(fmt.Sprintf("event: RunContent\ndata: %s\n\n", contentEventJSON)))
-			c.Writer.Flush()
-		} else {
-			// No media - use normal streaming
-			_ = targetAgent.RunStream(message, func(chunk []byte) error {
-				// Accumulate content in finalmessage (single source of truth)
-				finalmessage += string(chunk)
-
-				// Send RunContent event for each chunk (following Python format)
-				// Python order: created_at, event, agent_id, agent_name, run_id, session_id, content, content_type, reasoning_content
-				contentEvent := RunContentEvent{
-					CreatedAt:        time.Now().Unix(),
-					Event:            "RunContent",
-					AgentID:          agentID,
-					AgentName:        targetAgent.GetName(),
-					RunID:            runID,
-					SessionID:        sessionID,
-					Content:          string(chunk),
-					ContentType:      "str",
-					ReasoningContent: "", // Python always includes this, even if empty
-				}
+		}
 
-				contentEventJSON, _ := json.Marshal(contentEvent)
-				c.Writer.Write([]byte(fmt.Sprintf("event: RunContent\ndata: %s\n\n", contentEventJSON)))
+		// Forward the events as they come, with a keep-alive comment while idle
+		keepAlive := time.NewTicker(sseKeepAliveInterval)
+		defer keepAlive.Stop()
+		var runErr error
+	forward:
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					break forward
+				}
+				observer.event(event)
+				switch event.Event {
+				case agent.RunEventContent:
+					// Send RunContent event for each chunk (following Python format)
+					// Python order: created_at, event, agent_id, agent_name, run_id, session_id, content, content_type, reasoning_content
+					finalmessage += event.Content
+					writeSSE(c, "RunContent", RunContentEvent{
+						CreatedAt:        event.CreatedAt.Unix(),
+						Event:            "RunContent",
+						AgentID:          agentID,
+						AgentName:        targetAgent.GetName(),
+						RunID:            runID,
+						SessionID:        sessionID,
+						Content:          event.Content,
+						ContentType:      "str",
+						ReasoningContent: "", // Python always includes this, even if empty
+					})
+				case agent.RunEventToolCallStarted, agent.RunEventToolCallCompleted:
+					data := baseEvent(event)
+					data["tool"] = runEventTool(event)
+					writeSSE(c, string(event.Event), data)
+				case agent.RunEventToolOutput, agent.RunEventContentRetracted:
+					data := baseEvent(event)
+					data["content"] = event.Content
+					if event.Event == agent.RunEventToolOutput {
+						data["tool"] = runEventTool(event)
+					} else {
+						data["error"] = event.Error
+					}
+					writeSSE(c, string(event.Event), data)
+				case agent.RunEventCompleted:
+					// The completed content is final, e.g. after output transforms
+					finalmessage = event.Content
+					usage = runCompletedMetrics(event.Metrics, startTime)
+				case agent.RunEventError:
+					runErr = event.Err
+					if runErr == nil {
+						runErr = errors.New(event.Error)
+					}
+				}
+				keepAlive.Reset(sseKeepAliveInterval)
+			case <-keepAlive.C:
+				c.Writer.Write([]byte(": keep-alive\n\n"))
 				c.Writer.Flush()
+			case <-ctx.Done():
+				break forward
+			}
+		}
+
+		// The client went away, nobody is left to read the rest of the stream
+		if ctx.Err() != nil {
+			observer.finish("cancelled")
+			os.setRunStatus(sessionID, runID, "cancelled", finalmessage)
+			c.Abort()
+			return
+		}
 
-				return nil
+		if runErr != nil {
+			writeSSE(c, "RunError", map[string]interface{}{
+				"created_at": time.Now().Unix(),
+				"event":      "RunError",
+				"agent_id":   agentID,
+				"agent_name": targetAgent.GetName(),
+				"run_id":     runID,
+				"session_id": sessionID,
+				"error":      runErr.Error(),
 			})
+			observer.finish("error")
+			os.setRunStatus(sessionID, runID, "error", finalmessage)
+			writeSSE(c, "done", gin.H{"run_id": runID, "session_id": sessionID, "status": "error"})
+			c.Abort()
+			return
 		}
 
+		observer.finish("completed")
+
 		// Send RunContentCompleted event EXACTLY like Python
-		contentCompletedEvent := RunContentCompletedEvent{
+		writeSSE(c, "RunContentCompleted", RunContentCompletedEvent{
 			CreatedAt: time.Now().Unix(),
 			Event:     "RunContentCompleted",
 			AgentID:   agentID,
 			AgentName: targetAgent.GetName(),
 			RunID:     runID,
 			SessionID: sessionID,
-		}
-
-		contentCompletedJSON, _ := json.Marshal(contentCompletedEvent)
-		c.Writer.Write([]byte(fmt.Sprintf("event: RunContentCompleted\ndata: %s\n\n", contentCompletedJSON)))
-		c.Writer.Flush()
+		})
 
 		// Send RunCompleted event EXACTLY like Python using struct for field order
-		runCompletedEvent := RunCompletedEvent{
+		writeSSE(c, "RunCompleted", RunCompletedEvent{
 			CreatedAt:   time.Now().Unix(),
 			Event:       "RunCompleted",
 			AgentID:     agentID,
@@ -1479,18 +1719,8 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 			SessionID:   sessionID,
 			Content:     finalmessage,
 			ContentType: "str",
-			Metrics: RunCompletedMetrics{
-				InputTokens:      56,
-				OutputTokens:     262,
-				TotalTokens:      318,
-				TimeToFirstToken: 0.0013077390030957758,
-				Duration:         time.Since(startTime).Seconds(),
-			},
-		}
-
-		completedJSON, _ := json.Marshal(runCompletedEvent)
-		c.Writer.Write([]byte(fmt.Sprintf("event: RunCompleted\ndata: %s\n\n", completedJSON)))
-		c.Writer.Flush()
+			Metrics:     usage,
+		})
 
 		// Update run status to completed with full content and metrics
 		os.mu.Lock()
@@ -1501,11 +1731,10 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 					session.Runs[i].Status = "completed"
 					session.Runs[i].Content = finalmessage
 					session.Runs[i].Metrics = map[string]interface{}{
-						"input_tokens":        56,
-						"output_tokens":       262,
-						"total_tokens":        318,
-						"time_to_first_token": 0.0013077390030957758,
-						"duration":            time.Since(startTime).Seconds(),
+						"input_tokens":  usage.InputTokens,
+						"output_tokens": usage.OutputTokens,
+						"total_tokens":  usage.TotalTokens,
+						"duration":      usage.Duration,
 					}
 					session.Runs[i].UpdatedAt = time.Now()
 					break
@@ -1514,6 +1743,10 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 			session.UpdatedAt = time.Now()
 		}
 		os.mu.Unlock()
+		os.persistSession(sessionID)
+
+		// The done event is always the last one of the stream
+		writeSSE(c, "done", gin.H{"run_id": runID, "session_id": sessionID, "status": "completed"})
 
 		// CRITICAL: Abort to prevent ANY additional data after stream
 		c.Abort()
@@ -1528,6 +1761,27 @@ func (os *AgentOS) agentRunsHandler(c *gin.Context) {
 	})
 }
 
+// setRunStatus updates the status and content of a session run
+func (os *AgentOS) setRunStatus(sessionID, runID, status, content string) {
+	os.mu.Lock()
+	session, exists := os.sessions[sessionID]
+	if !exists {
+		os.mu.Unlock()
+		return
+	}
+	for _, run := range session.Runs {
+		if run.ID == runID {
+			run.Status = status
+			run.Content = content
+			run.UpdatedAt = time.Now()
+			break
+		}
+	}
+	session.UpdatedAt = time.Now()
+	os.mu.Unlock()
+	os.persistSession(sessionID)
+}
+
 func (os *AgentOS) teamRunsHandler(c *gin.Context) {
 	teamID := c.Param("id")
 
@@ -1678,33 +1932,54 @@ func (os *AgentOS) sessionsHandler(c *gin.Context) {
 	// Handle query parameters for filtering
 	sessionType := c.Query("type")         // "agent" or "team"
 	componentID := c.Query("component_id") // agent_id or team_id
-	_ = c.Query("db_id")                   // database id (not used yet)
+	userID := c.Query("user_id")
+	_ = c.Query("db_id") // database id (not used yet)
 
 	os.mu.RLock()
-	defer os.mu.RUnlock()
-
-	sessions := make([]map[string]interface{}, 0)
+	matched := make([]*Session, 0, len(os.sessions))
 	for _, session := range os.sessions {
+		if !os.canAccessSession(c, session) {
+			continue
+		}
+		if userID != "" && (session.UserID == nil || *session.UserID != userID) {
+			continue
+		}
 		// Filter by type and component if specified
-		if sessionType != "" && componentID != "" {
-			if sessionType == "agent" && session.AgentID != nil && *session.AgentID == componentID {
-				sessions = append(sessions, map[string]interface{}{
-					"session_id":   session.ID,
-					"session_name": fmt.Sprintf("Session with %s", componentID),
-					"created_at":   session.CreatedAt.Unix(),
-					"updated_at":   session.UpdatedAt.Unix(),
-				})
-			} else if sessionType == "team" && session.TeamID != nil && *session.TeamID == componentID {
-				sessions = append(sessions, map[string]interface{}{
-					"session_id":   session.ID,
-					"session_name": fmt.Sprintf("Session with team %s", componentID),
-					"created_at":   session.CreatedAt.Unix(),
-					"updated_at":   session.UpdatedAt.Unix(),
-				})
-			}
+		if sessionType == "agent" && componentID != "" && (session.AgentID == nil || *session.AgentID != componentID) {
+			continue
+		}
+		if sessionType == "team" && componentID != "" && (session.TeamID == nil || *session.TeamID != componentID) {
+			continue
 		}
+		matched = append(matched, session)
 	}
 
+	// Most recently updated first
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].UpdatedAt.After(matched[j].UpdatedAt)
+	})
+
+	sessions := make([]map[string]interface{}, 0, len(matched))
+	for _, session := range matched {
+		sessionName := fmt.Sprintf("Session %s", session.ID)
+		if session.TeamID != nil {
+			sessionName = fmt.Sprintf("Session with team %s", *session.TeamID)
+		} else if session.AgentID != nil {
+			sessionName = fmt.Sprintf("Session with %s", *session.AgentID)
+		}
+		sessions = append(sessions, map[string]interface{}{
+			"session_id":   session.ID,
+			"session_name": sessionName,
+			"user_id":      session.UserID,
+			"agent_id":     session.AgentID,
+			"team_id":      session.TeamID,
+			"runs":         len(session.Runs),
+			"created_at":   session.CreatedAt.Unix(),
+			"updated_at":   session.UpdatedAt.Unix(),
+		})
+	}
+	os.mu.RUnlock()
+
 	// Return in the format expected by the UI
 	response := map[string]interface{}{
 		"data": sessions,
@@ -1737,6 +2012,11 @@ func (os *AgentOS) sessionRunsHandler(c *gin.Context) {
 
 	os.mu.Lock()
 	session, exists := os.sessions[sessionID]
+	if exists && !os.canAccessSession(c, session) {
+		os.mu.Unlock()
+		c.JSON(http.StatusForbidden, gin.H{"error": "API key has no access to this session"})
+		return
+	}
 
 	// Create session if it doesn't exist
 	if !exists {
@@ -1821,6 +2101,7 @@ func (os *AgentOS) sessionRunsHandler(c *gin.Context) {
 		session.UpdatedAt = time.Now()
 	}
 	os.mu.Unlock()
+	os.persistSession(sessionID)
 
 	// RunCompleted event
 	completedEvent := map[string]interface{}{
@@ -2267,6 +2548,10 @@ func (os *AgentOS) getSessionRunsHandler(c *gin.Context) {
 		})
 		return
 	}
+	if !os.canAccessSession(c, session) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "API key has no access to this session"})
+		return
+	}
 
 	// Return runs for this session com TODOS os campos que o cliente espera
 	runs := make([]map[string]interface{}, 0)
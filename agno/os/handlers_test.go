@@ -10,11 +10,15 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/devalexandre/agno-golang/agno/agent"
+	"github.com/devalexandre/agno-golang/agno/models"
 	"github.com/devalexandre/agno-golang/agno/team"
 	v2 "github.com/devalexandre/agno-golang/agno/workflow/v2"
 )
@@ -1023,3 +1027,145 @@ func TestWebSocketUpgrade(t *testing.T) {
 	// Should return 400 because it's not a valid WebSocket upgrade request
 	assert.Equal(t, 400, w.Code)
 }
+
+// slowStreamModel streams its chunks with a pause before each one, reporting
+// usage for each request when set
+type slowStreamModel struct {
+	chunks []string
+	delay  time.Duration
+	usage  *models.Usage
+}
+
+func (m *slowStreamModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	if m.usage != nil {
+		models.ReportUsage(ctx, *m.usage)
+	}
+	return &models.MessageResponse{Role: models.TypeAssistantRole, Content: strings.Join(m.chunks, ""), Usage: m.usage}, nil
+}
+
+func (m *slowStreamModel) AInvoke(ctx context.Context, messages []models.Message, options ...models.Option) (<-chan *models.MessageResponse, <-chan error) {
+	respCh := make(chan *models.MessageResponse, 1)
+	errCh := make(chan error, 1)
+	resp, _ := m.Invoke(ctx, messages, options...)
+	respCh <- resp
+	close(respCh)
+	close(errCh)
+	return respCh, errCh
+}
+
+func (m *slowStreamModel) InvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) error {
+	callOpts := models.DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOpts)
+	}
+	for _, chunk := range m.chunks {
+		time.Sleep(m.delay)
+		if err := callOpts.StreamingFunc(ctx, []byte(chunk)); err != nil {
+			return err
+		}
+	}
+	if m.usage != nil {
+		models.ReportUsage(ctx, *m.usage)
+	}
+	return nil
+}
+
+func (m *slowStreamModel) AInvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) (<-chan *models.MessageResponse, <-chan error) {
+	return m.AInvoke(ctx, messages, options...)
+}
+
+func (m *slowStreamModel) GetID() string { return "slow-stream" }
+
+// TestAgentRunsSSEStream tests that stream=true in a JSON body streams the run as SSE
+func TestAgentRunsSSEStream(t *testing.T) {
+	keepAlive := sseKeepAliveInterval
+	sseKeepAliveInterval = 10 * time.Millisecond
+	defer func() { sseKeepAliveInterval = keepAlive }()
+
+	streamAgent, err := agent.NewAgent(agent.AgentConfig{
+		Context: context.Background(),
+		Name:    "stream-agent",
+		Model:   &slowStreamModel{chunks: []string{"Hello", ", world"}, delay: 50 * time.Millisecond},
+	})
+	require.NoError(t, err)
+
+	os, err := NewAgentOS(AgentOSOptions{
+		OSID:   "test-os",
+		Agents: []*agent.Agent{streamAgent},
+	})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.POST("/agents/:id/runs", os.agentRunsHandler)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/agents/stream-agent/runs", strings.NewReader(`{"message": "hi", "stream": true}`))
+	req.Header.Set("Content-Type", "application/json")
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+
+	// Collect the event names in order, skipping the keep-alive comments
+	var events []string
+	var contents []string
+	keepAlives := 0
+	for _, block := range strings.Split(strings.TrimSpace(w.Body.String()), "\n\n") {
+		if block == ": keep-alive" {
+			keepAlives++
+			continue
+		}
+		lines := strings.SplitN(block, "\n", 2)
+		require.Len(t, lines, 2, "malformed event %q", block)
+		event := strings.TrimPrefix(lines[0], "event: ")
+		events = append(events, event)
+		if event == "RunContent" {
+			var data RunContentEvent
+			require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(lines[1], "data: ")), &data))
+			contents = append(contents, data.Content)
+		}
+	}
+
+	assert.Equal(t, []string{"RunStarted", "RunContent", "RunContent", "RunContentCompleted", "RunCompleted", "done"}, events)
+	assert.Equal(t, []string{"Hello", ", world"}, contents)
+	assert.Greater(t, keepAlives, 0, "expected keep-alive comments while the model was idle")
+	assert.Contains(t, w.Body.String(), `"content":"Hello, world"`)
+}
+
+func TestAgentRunsWebSocketSession(t *testing.T) {
+	wsAgent, err := agent.NewAgent(agent.AgentConfig{
+		Context: context.Background(),
+		Name:    "ws-agent",
+		Model:   &slowStreamModel{chunks: []string{"Hello"}},
+	})
+	require.NoError(t, err)
+
+	os, err := NewAgentOS(AgentOSOptions{
+		OSID:   "test-os",
+		Agents: []*agent.Agent{wsAgent},
+	})
+	require.NoError(t, err)
+
+	router := gin.New()
+	router.GET("/agents/:agent_id/runs/ws", os.agentRunsWebSocketHandler)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http")+"/agents/ws-agent/runs/ws", nil)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	require.NoError(t, conn.WriteJSON(map[string]string{"message": "hi", "session_id": "s-123"}))
+	for {
+		var event map[string]interface{}
+		require.NoError(t, conn.ReadJSON(&event))
+		assert.Equal(t, "s-123", event["session_id"])
+		if event["event"] == string(agent.RunEventCompleted) || event["event"] == "RunError" {
+			assert.Equal(t, string(agent.RunEventCompleted), event["event"], "run failed: %v", event["content"])
+			break
+		}
+	}
+
+	// The run itself, not only its events, belongs to the requested session
+	assert.Equal(t, "s-123", wsAgent.GetID())
+}
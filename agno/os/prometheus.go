@@ -0,0 +1,203 @@
+package os
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+)
+
+// runDurationBuckets are the upper bounds, in seconds, of the run duration histogram
+var runDurationBuckets = []float64{0.1, 0.5, 1, 2.5, 5, 10, 30, 60, 120, 300}
+
+// runMetrics aggregates the agent runs served by AgentOS and renders them in
+// the Prometheus text format. It is only created when
+// AgentOSSettings.EnableMetrics is set; a nil *runMetrics records nothing.
+type runMetrics struct {
+	mu        sync.Mutex
+	runs      map[[2]string]int     // {agent, status}
+	durations map[string]*histogram // agent
+	toolCalls map[[2]string]int     // {agent, tool}
+	tokens    map[[2]string]int     // {agent, "input" or "output"}
+}
+
+type histogram struct {
+	buckets []int // Cumulative counts, one per runDurationBuckets bound
+	count   int
+	sum     float64
+}
+
+func newRunMetrics() *runMetrics {
+	return &runMetrics{
+		runs:      make(map[[2]string]int),
+		durations: make(map[string]*histogram),
+		toolCalls: make(map[[2]string]int),
+		tokens:    make(map[[2]string]int),
+	}
+}
+
+// runObserver records the metrics of one run
+type runObserver struct {
+	m     *runMetrics
+	agent string
+	start time.Time
+}
+
+// startRun starts observing a run of the named agent
+func (m *runMetrics) startRun(agentName string) *runObserver {
+	if m == nil {
+		return nil
+	}
+	return &runObserver{m: m, agent: agentName, start: time.Now()}
+}
+
+// event records a run event: completed tool calls are counted, and the tokens
+// of the run are taken from the metrics of its RunCompleted event
+func (o *runObserver) event(event agent.RunEvent) {
+	if o == nil {
+		return
+	}
+	switch event.Event {
+	case agent.RunEventToolCallCompleted:
+		o.m.mu.Lock()
+		defer o.m.mu.Unlock()
+		o.m.toolCalls[[2]string{o.agent, event.ToolName}]++
+	case agent.RunEventCompleted:
+		o.usage(event.Metrics)
+	}
+}
+
+// usage records the tokens a run reports in its metrics, under the
+// input_tokens and output_tokens keys
+func (o *runObserver) usage(metrics map[string]interface{}) {
+	if o == nil {
+		return
+	}
+	o.m.mu.Lock()
+	defer o.m.mu.Unlock()
+	for _, kind := range []string{"input", "output"} {
+		if n := tokenCount(metrics[kind+"_tokens"]); n > 0 {
+			o.m.tokens[[2]string{o.agent, kind}] += n
+		}
+	}
+}
+
+// finish records the end of the run with its status: completed, error or cancelled
+func (o *runObserver) finish(status string) {
+	if o == nil {
+		return
+	}
+	seconds := time.Since(o.start).Seconds()
+
+	o.m.mu.Lock()
+	defer o.m.mu.Unlock()
+	o.m.runs[[2]string{o.agent, status}]++
+	h, exists := o.m.durations[o.agent]
+	if !exists {
+		h = &histogram{buckets: make([]int, len(runDurationBuckets))}
+		o.m.durations[o.agent] = h
+	}
+	for i, bound := range runDurationBuckets {
+		if seconds <= bound {
+			h.buckets[i]++
+		}
+	}
+	h.count++
+	h.sum += seconds
+}
+
+// tokenCount reads a token count from run metrics, which may hold any number type
+func tokenCount(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// write renders the metrics in the Prometheus text exposition format
+func (m *runMetrics) write(b *strings.Builder) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b.WriteString("# HELP agno_agent_runs_total Agent runs served by AgentOS, by final status.\n")
+	b.WriteString("# TYPE agno_agent_runs_total counter\n")
+	for _, key := range sortedKeys(m.runs) {
+		fmt.Fprintf(b, "agno_agent_runs_total{agent=%s,status=%s} %d\n", labelValue(key[0]), labelValue(key[1]), m.runs[key])
+	}
+
+	b.WriteString("# HELP agno_agent_run_duration_seconds Duration of agent runs.\n")
+	b.WriteString("# TYPE agno_agent_run_duration_seconds histogram\n")
+	agents := make([]string, 0, len(m.durations))
+	for name := range m.durations {
+		agents = append(agents, name)
+	}
+	sort.Strings(agents)
+	for _, name := range agents {
+		h := m.durations[name]
+		for i, bound := range runDurationBuckets {
+			fmt.Fprintf(b, "agno_agent_run_duration_seconds_bucket{agent=%s,le=\"%s\"} %d\n", labelValue(name), strconv.FormatFloat(bound, 'g', -1, 64), h.buckets[i])
+		}
+		fmt.Fprintf(b, "agno_agent_run_duration_seconds_bucket{agent=%s,le=\"+Inf\"} %d\n", labelValue(name), h.count)
+		fmt.Fprintf(b, "agno_agent_run_duration_seconds_sum{agent=%s} %s\n", labelValue(name), strconv.FormatFloat(h.sum, 'g', -1, 64))
+		fmt.Fprintf(b, "agno_agent_run_duration_seconds_count{agent=%s} %d\n", labelValue(name), h.count)
+	}
+
+	b.WriteString("# HELP agno_agent_tool_calls_total Tool calls made during agent runs.\n")
+	b.WriteString("# TYPE agno_agent_tool_calls_total counter\n")
+	for _, key := range sortedKeys(m.toolCalls) {
+		fmt.Fprintf(b, "agno_agent_tool_calls_total{agent=%s,tool=%s} %d\n", labelValue(key[0]), labelValue(key[1]), m.toolCalls[key])
+	}
+
+	b.WriteString("# HELP agno_model_tokens_total Model tokens consumed by agent runs, as reported by the model.\n")
+	b.WriteString("# TYPE agno_model_tokens_total counter\n")
+	for _, key := range sortedKeys(m.tokens) {
+		fmt.Fprintf(b, "agno_model_tokens_total{agent=%s,type=%s} %d\n", labelValue(key[0]), labelValue(key[1]), m.tokens[key])
+	}
+}
+
+// sortedKeys returns the label pairs of a counter in a stable order
+func sortedKeys(counter map[[2]string]int) [][2]string {
+	keys := make([][2]string, 0, len(counter))
+	for key := range counter {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i][0] != keys[j][0] {
+			return keys[i][0] < keys[j][0]
+		}
+		return keys[i][1] < keys[j][1]
+	})
+	return keys
+}
+
+// labelValue quotes a label value, escaping backslashes, quotes and newlines
+func labelValue(value string) string {
+	value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+	return `"` + value + `"`
+}
+
+// prometheusMetricsHandler serves the run metrics in the Prometheus text
+// format. Clients asking for JSON, like the AgentOS UI, get the metrics of
+// getMetricsHandler instead.
+func (os *AgentOS) prometheusMetricsHandler(c *gin.Context) {
+	if strings.Contains(c.GetHeader("Accept"), "application/json") {
+		os.getMetricsHandler(c)
+		return
+	}
+
+	var b strings.Builder
+	os.runMetrics.write(&b)
+	c.Data(http.StatusOK, "text/plain; version=0.0.4; charset=utf-8", []byte(b.String()))
+}
@@ -0,0 +1,92 @@
+package os
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+func TestPrometheusMetricsEndpoint(t *testing.T) {
+	chatAgent, err := agent.NewAgent(agent.AgentConfig{
+		Context: context.Background(),
+		Name:    "chat-agent",
+		Model:   &slowStreamModel{chunks: []string{"ok"}, usage: &models.Usage{InputTokens: 12, OutputTokens: 5}},
+	})
+	require.NoError(t, err)
+
+	os, err := NewAgentOS(AgentOSOptions{
+		OSID:     "test-os",
+		Agents:   []*agent.Agent{chatAgent},
+		Settings: &AgentOSSettings{EnableMetrics: true},
+	})
+	require.NoError(t, err)
+	router := os.GetApp()
+
+	for i := 0; i < 2; i++ {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/agents/chat-agent/runs", strings.NewReader(`{"message": "hi", "stream": true}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		require.Equal(t, 200, w.Code)
+		assert.Contains(t, w.Body.String(), `"input_tokens":12,"output_tokens":5,"total_tokens":17`)
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/metrics", nil)
+	router.ServeHTTP(w, req)
+	require.Equal(t, 200, w.Code)
+	assert.Contains(t, w.Header().Get("Content-Type"), "text/plain")
+	body := w.Body.String()
+	assert.Contains(t, body, `agno_agent_runs_total{agent="chat-agent",status="completed"} 2`)
+	assert.Contains(t, body, `agno_agent_run_duration_seconds_bucket{agent="chat-agent",le="+Inf"} 2`)
+	assert.Contains(t, body, `agno_agent_run_duration_seconds_count{agent="chat-agent"} 2`)
+	assert.Contains(t, body, `agno_model_tokens_total{agent="chat-agent",type="input"} 24`)
+	assert.Contains(t, body, `agno_model_tokens_total{agent="chat-agent",type="output"} 10`)
+
+	// The UI still gets JSON
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/metrics", nil)
+	req.Header.Set("Accept", "application/json")
+	router.ServeHTTP(w, req)
+	assert.Contains(t, w.Body.String(), `"metrics"`)
+}
+
+func TestRunMetrics(t *testing.T) {
+	m := newRunMetrics()
+
+	run := m.startRun("research")
+	run.event(agent.RunEvent{Event: agent.RunEventToolCallStarted, ToolName: "web.Search"})
+	run.event(agent.RunEvent{Event: agent.RunEventToolCallCompleted, ToolName: "web.Search"})
+	run.event(agent.RunEvent{Event: agent.RunEventToolCallCompleted, ToolName: "web.Search"})
+	run.event(agent.RunEvent{Event: agent.RunEventCompleted, Metrics: map[string]interface{}{"input_tokens": 120, "output_tokens": float64(30)}})
+	run.finish("completed")
+	m.startRun("research").finish("error")
+
+	var b strings.Builder
+	m.write(&b)
+	out := b.String()
+	for _, line := range []string{
+		`agno_agent_runs_total{agent="research",status="completed"} 1`,
+		`agno_agent_runs_total{agent="research",status="error"} 1`,
+		`agno_agent_run_duration_seconds_bucket{agent="research",le="0.1"} 2`,
+		`agno_agent_tool_calls_total{agent="research",tool="web.Search"} 2`,
+		`agno_model_tokens_total{agent="research",type="input"} 120`,
+		`agno_model_tokens_total{agent="research",type="output"} 30`,
+	} {
+		assert.Contains(t, out, line)
+	}
+
+	// Disabled metrics record nothing
+	var disabled *runMetrics
+	disabled.startRun("research").finish("completed")
+
+	assert.Equal(t, `"a\"b\\c\nd"`, labelValue("a\"b\\c\nd"))
+}
@@ -0,0 +1,121 @@
+package os
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitIdleTTL is how long the bucket of an idle client is kept
+const rateLimitIdleTTL = 10 * time.Minute
+
+// rateLimiter is a token bucket per client: each bucket holds up to burst
+// tokens and refills at RequestsPerMinute, and every request takes a token
+type rateLimiter struct {
+	rate      float64 // Tokens per second
+	burst     float64
+	buckets   map[string]*rateBucket
+	lastSweep time.Time
+	mu        sync.Mutex
+}
+
+type rateBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// newRateLimiter creates a limiter for the config, or nil when rate limiting
+// is disabled
+func newRateLimiter(config RateLimitConfig) *rateLimiter {
+	if config.RequestsPerMinute <= 0 {
+		return nil
+	}
+	burst := config.Burst
+	if burst <= 0 {
+		burst = config.RequestsPerMinute
+	}
+	return &rateLimiter{
+		rate:      float64(config.RequestsPerMinute) / 60,
+		burst:     float64(burst),
+		buckets:   make(map[string]*rateBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+// allow takes a token from the bucket of key. When the bucket is empty it
+// returns false and how long until the next token.
+func (l *rateLimiter) allow(key string, now time.Time) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// Drop the buckets of idle clients, which are full again anyway
+	if now.Sub(l.lastSweep) > rateLimitIdleTTL {
+		for k, b := range l.buckets {
+			if now.Sub(b.last) > rateLimitIdleTTL {
+				delete(l.buckets, k)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	b, exists := l.buckets[key]
+	if !exists {
+		b = &rateBucket{tokens: l.burst, last: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens = math.Min(l.burst, b.tokens+now.Sub(b.last).Seconds()*l.rate)
+	b.last = now
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / l.rate * float64(time.Second))
+		return false, wait
+	}
+	b.tokens--
+	return true, 0
+}
+
+// rateLimitMiddleware limits the requests of each client as configured by
+// AgentOSSettings.RateLimit. Clients presenting a valid key share the bucket
+// of that key; other clients are limited by IP. Health probes are
+// never limited. Rejected requests get 429 with a Retry-After header.
+func (os *AgentOS) rateLimitMiddleware() gin.HandlerFunc {
+	limiter := newRateLimiter(os.settings.RateLimit)
+	return gin.HandlerFunc(func(c *gin.Context) {
+		if limiter == nil {
+			c.Next()
+			return
+		}
+		switch c.Request.URL.Path {
+		case "/health", "/healthz", "/readyz":
+			c.Next()
+			return
+		}
+
+		allowed, wait := limiter.allow(os.rateLimitKey(c), time.Now())
+		if !allowed {
+			retryAfter := int(math.Ceil(wait.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Header("Retry-After", strconv.Itoa(retryAfter))
+			c.JSON(http.StatusTooManyRequests, gin.H{"error": "Rate limit exceeded"})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	})
+}
+
+// rateLimitKey identifies the client of a request: the key it authenticates
+// with, otherwise the client IP
+func (os *AgentOS) rateLimitKey(c *gin.Context) string {
+	if key := os.authenticate(requestToken(c)); key != nil {
+		return "key:" + key.Key
+	}
+	return "ip:" + c.ClientIP()
+}
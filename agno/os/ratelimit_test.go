@@ -0,0 +1,110 @@
+package os
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	os, err := NewAgentOS(AgentOSOptions{
+		OSID: "test-os",
+		Settings: &AgentOSSettings{
+			SecurityKey: "secret",
+			RateLimit:   RateLimitConfig{RequestsPerMinute: 60, Burst: 2},
+		},
+	})
+	require.NoError(t, err)
+	router := os.GetApp()
+
+	get := func(path, remoteAddr, token string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", path, nil)
+		req.RemoteAddr = remoteAddr
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	// The burst passes, then the client is limited
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, 200, get("/ping", "10.0.0.1:1234", "").Code)
+	}
+	w := get("/ping", "10.0.0.1:1234", "")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	// Other IPs and the security key have their own buckets
+	assert.Equal(t, 200, get("/ping", "10.0.0.2:1234", "").Code)
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, 200, get("/ping", "10.0.0.1:1234", "secret").Code)
+	}
+	assert.Equal(t, http.StatusTooManyRequests, get("/ping", "10.0.0.3:1234", "secret").Code)
+
+	// A wrong key is limited by IP
+	assert.Equal(t, http.StatusTooManyRequests, get("/ping", "10.0.0.1:1234", "wrong").Code)
+
+	// Health probes are never limited
+	assert.Equal(t, 200, get("/healthz", "10.0.0.1:1234", "").Code)
+}
+
+func TestRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	newRouter := func(trustedProxies []string) *gin.Engine {
+		os, err := NewAgentOS(AgentOSOptions{
+			OSID: "test-os",
+			Settings: &AgentOSSettings{
+				RateLimit:      RateLimitConfig{RequestsPerMinute: 60, Burst: 1},
+				TrustedProxies: trustedProxies,
+			},
+		})
+		require.NoError(t, err)
+		return os.GetApp()
+	}
+	get := func(router *gin.Engine, remoteAddr, forwardedFor string) int {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", "/ping", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// A direct client can't get a fresh bucket by sending a new X-Forwarded-For
+	router := newRouter(nil)
+	assert.Equal(t, 200, get(router, "10.0.0.1:1234", "1.1.1.1"))
+	assert.Equal(t, http.StatusTooManyRequests, get(router, "10.0.0.1:1234", "2.2.2.2"))
+
+	// Behind a trusted proxy, clients are told apart by X-Forwarded-For
+	router = newRouter([]string{"10.0.0.0/8"})
+	assert.Equal(t, 200, get(router, "10.0.0.1:1234", "1.1.1.1"))
+	assert.Equal(t, 200, get(router, "10.0.0.1:1234", "2.2.2.2"))
+	assert.Equal(t, http.StatusTooManyRequests, get(router, "10.0.0.1:1234", "2.2.2.2"))
+}
+
+func TestRateLimiterRefill(t *testing.T) {
+	limiter := newRateLimiter(RateLimitConfig{RequestsPerMinute: 30})
+	now := time.Now()
+
+	for i := 0; i < 30; i++ {
+		allowed, _ := limiter.allow("client", now)
+		require.True(t, allowed, "request %d should pass the burst", i)
+	}
+	allowed, wait := limiter.allow("client", now)
+	assert.False(t, allowed)
+	assert.Equal(t, 2*time.Second, wait)
+
+	// One token every 2 seconds
+	allowed, _ = limiter.allow("client", now.Add(2*time.Second))
+	assert.True(t, allowed)
+	allowed, _ = limiter.allow("client", now.Add(3*time.Second))
+	assert.False(t, allowed)
+
+	assert.Nil(t, newRateLimiter(RateLimitConfig{}))
+}
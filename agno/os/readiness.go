@@ -0,0 +1,124 @@
+package os
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// defaultReadinessTimeout bounds readiness checks when settings do not set one
+const defaultReadinessTimeout = 3 * time.Second
+
+// Pinger is implemented by models and databases that can report whether they are
+// reachable (e.g. OllamaChat, OpenAIChat, SqliteStorage, PostgresStorage). Components that do
+// not implement it are assumed ready.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// readinessCheck is a named dependency checked by Ready
+type readinessCheck struct {
+	name   string
+	pinger Pinger
+}
+
+// readinessChecks collects the distinct models and databases used by the
+// agents, teams and knowledge bases served by this AgentOS
+func (os *AgentOS) readinessChecks() []readinessCheck {
+	var checks []readinessCheck
+	seen := make(map[interface{}]bool)
+	add := func(name string, component interface{}) {
+		pinger, ok := component.(Pinger)
+		if !ok {
+			return
+		}
+		// Agents often share a model or database: check each one once
+		if reflect.TypeOf(pinger).Comparable() {
+			if seen[pinger] {
+				return
+			}
+			seen[pinger] = true
+		}
+		checks = append(checks, readinessCheck{name: name, pinger: pinger})
+	}
+
+	for _, ag := range os.agents {
+		if ag == nil {
+			continue
+		}
+		add(fmt.Sprintf("agent %q model", ag.GetName()), ag.GetModel())
+		add(fmt.Sprintf("agent %q storage", ag.GetName()), ag.GetStorage())
+	}
+	for _, tm := range os.teams {
+		if tm == nil {
+			continue
+		}
+		add(fmt.Sprintf("team %q model", tm.GetName()), tm.GetModel())
+		add(fmt.Sprintf("team %q storage", tm.GetName()), tm.GetStorage())
+	}
+
+	os.mu.RLock()
+	defer os.mu.RUnlock()
+	for id, db := range os.dbs {
+		add(fmt.Sprintf("database %q", id), db)
+	}
+	for id, db := range os.knowledgeDbs {
+		add(fmt.Sprintf("knowledge database %q", id), db)
+	}
+
+	return checks
+}
+
+// Ready pings every model and database the AgentOS depends on, in parallel and
+// within the readiness timeout, and returns an error describing each dependency
+// that is not reachable. It returns nil when all dependencies are ready.
+func (os *AgentOS) Ready() error {
+	timeout := os.settings.ReadinessTimeout
+	if timeout <= 0 {
+		timeout = defaultReadinessTimeout
+	}
+	ctx, cancel := context.WithTimeout(os.ctx, timeout)
+	defer cancel()
+
+	checks := os.readinessChecks()
+	errs := make([]error, len(checks))
+	var wg sync.WaitGroup
+	for i, check := range checks {
+		wg.Go(func() {
+			if err := check.pinger.Ping(ctx); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", check.name, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// healthzHandler is the liveness probe: the process is up and serving requests
+func (os *AgentOS) healthzHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{
+		"status": "ok",
+	})
+}
+
+// readyzHandler is the readiness probe: 200 when all dependencies respond,
+// 503 with the failing dependencies otherwise
+func (os *AgentOS) readyzHandler(c *gin.Context) {
+	if err := os.Ready(); err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{
+			"status": "not_ready",
+			"error":  err.Error(),
+		})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"status": "ready",
+	})
+}
@@ -0,0 +1,181 @@
+package os
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/storage"
+)
+
+// Sessions are kept in memory and, when AgentOSOptions.Storage is set, also
+// persisted as storage.AgentSession rows so they survive restarts. Like Python
+// agno, the runs of a session go in memory["runs"]; the rest of the session
+// state goes in session_data.
+
+// sessionToStorage converts a session to its stored form
+func sessionToStorage(session *Session) (*storage.AgentSession, error) {
+	runsJSON, err := json.Marshal(session.Runs)
+	if err != nil {
+		return nil, err
+	}
+	var runs []interface{}
+	if err := json.Unmarshal(runsJSON, &runs); err != nil {
+		return nil, err
+	}
+
+	stored := &storage.AgentSession{
+		Session: storage.Session{
+			SessionID: session.ID,
+			Memory:    map[string]interface{}{"runs": runs},
+			SessionData: map[string]interface{}{
+				"metadata": session.Metadata,
+				"state":    session.State,
+				"active":   session.Active,
+			},
+			ExtraData: map[string]interface{}{},
+			CreatedAt: session.CreatedAt.Unix(),
+			UpdatedAt: session.UpdatedAt.Unix(),
+		},
+	}
+	if session.UserID != nil {
+		stored.UserID = *session.UserID
+	}
+	if session.AgentID != nil {
+		stored.AgentID = *session.AgentID
+	}
+	if session.TeamID != nil {
+		stored.SessionData["team_id"] = *session.TeamID
+	}
+	return stored, nil
+}
+
+// sessionFromStorage restores a session from its stored form
+func sessionFromStorage(stored *storage.AgentSession) (*Session, error) {
+	session := &Session{
+		ID:        stored.SessionID,
+		CreatedAt: time.Unix(stored.CreatedAt, 0),
+		UpdatedAt: time.Unix(stored.UpdatedAt, 0),
+		Active:    true,
+		Runs:      []*SessionRun{},
+	}
+	if stored.UserID != "" {
+		userID := stored.UserID
+		session.UserID = &userID
+	}
+	if stored.AgentID != "" {
+		agentID := stored.AgentID
+		session.AgentID = &agentID
+	}
+
+	if data := stored.SessionData; data != nil {
+		if metadata, ok := data["metadata"].(map[string]interface{}); ok {
+			session.Metadata = metadata
+		}
+		if state, ok := data["state"].(map[string]interface{}); ok {
+			session.State = state
+		}
+		if active, ok := data["active"].(bool); ok {
+			session.Active = active
+		}
+		if teamID, ok := data["team_id"].(string); ok && teamID != "" {
+			session.TeamID = &teamID
+		}
+	}
+
+	if runs, ok := stored.Memory["runs"]; ok && runs != nil {
+		runsJSON, err := json.Marshal(runs)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(runsJSON, &session.Runs); err != nil {
+			return nil, fmt.Errorf("invalid runs in session %s: %w", stored.SessionID, err)
+		}
+	}
+	return session, nil
+}
+
+// loadSessions restores the sessions saved in storage
+func (os *AgentOS) loadSessions() error {
+	rows, err := os.storage.GetRecentSessions(nil, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	os.mu.Lock()
+	defer os.mu.Unlock()
+	for _, row := range rows {
+		stored, ok := row.(*storage.AgentSession)
+		if !ok || stored == nil {
+			continue
+		}
+		session, err := sessionFromStorage(stored)
+		if err != nil {
+			log.Printf("Warning: skipping stored session: %v", err)
+			continue
+		}
+		os.sessions[session.ID] = session
+	}
+	return nil
+}
+
+// persistSession saves the current state of a session to storage, if any.
+// Failures are logged: the session stays available in memory.
+func (os *AgentOS) persistSession(sessionID string) {
+	if os.storage == nil {
+		return
+	}
+
+	os.mu.RLock()
+	session, exists := os.sessions[sessionID]
+	var stored *storage.AgentSession
+	var err error
+	if exists {
+		stored, err = sessionToStorage(session)
+	}
+	os.mu.RUnlock()
+	if !exists {
+		return
+	}
+	if err == nil {
+		_, err = os.storage.Upsert(stored)
+	}
+	if err != nil {
+		log.Printf("Warning: failed to persist session %s: %v", sessionID, err)
+	}
+}
+
+// deleteStoredSession removes a session from storage, if any
+func (os *AgentOS) deleteStoredSession(ctx context.Context, sessionID string) error {
+	if os.storage == nil {
+		return nil
+	}
+	return os.storage.DeleteSession(ctx, sessionID)
+}
+
+// sessionChatHistory returns the messages of a session, a user message and an
+// assistant reply per run
+func sessionChatHistory(session *Session) []map[string]interface{} {
+	history := make([]map[string]interface{}, 0, 2*len(session.Runs))
+	for _, run := range session.Runs {
+		if run.RunInput != "" {
+			history = append(history, map[string]interface{}{
+				"role":       "user",
+				"content":    run.RunInput,
+				"run_id":     run.ID,
+				"created_at": run.CreatedAt.Unix(),
+			})
+		}
+		if run.Content != "" {
+			history = append(history, map[string]interface{}{
+				"role":       "assistant",
+				"content":    run.Content,
+				"run_id":     run.ID,
+				"created_at": run.UpdatedAt.Unix(),
+			})
+		}
+	}
+	return history
+}
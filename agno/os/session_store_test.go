@@ -0,0 +1,103 @@
+package os
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+	"github.com/devalexandre/agno-golang/agno/storage/sqlite"
+)
+
+func TestSessionsPersistAcrossRestarts(t *testing.T) {
+	dbFile := filepath.Join(t.TempDir(), "sessions.db")
+
+	// newServer starts an AgentOS on the shared database, like a process restart
+	newServer := func() *gin.Engine {
+		store, err := sqlite.NewSqliteStorage(sqlite.SqliteStorageConfig{
+			TableName: "agentos_sessions",
+			DBFile:    &dbFile,
+		})
+		require.NoError(t, err)
+
+		chatAgent, err := agent.NewAgent(agent.AgentConfig{
+			Context: context.Background(),
+			Name:    "chat-agent",
+			Model:   &slowStreamModel{chunks: []string{"Hi ", "there"}},
+		})
+		require.NoError(t, err)
+
+		os, err := NewAgentOS(AgentOSOptions{
+			OSID:    "test-os",
+			Agents:  []*agent.Agent{chatAgent},
+			Storage: store,
+		})
+		require.NoError(t, err)
+
+		router := gin.New()
+		router.POST("/agents/:id/runs", os.agentRunsHandler)
+		router.GET("/sessions", os.sessionsHandler)
+		router.GET("/sessions/:session_id", os.getSessionHandler)
+		router.DELETE("/sessions/:session_id", os.deleteSessionHandler)
+		return router
+	}
+
+	do := func(router *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	router := newServer()
+	w := do(router, "POST", "/agents/chat-agent/runs", `{"message": "hello", "stream": true, "session_id": "s1"}`)
+	require.Equal(t, 200, w.Code)
+
+	// A new AgentOS on the same database sees the session
+	router = newServer()
+
+	w = do(router, "GET", "/sessions", "")
+	require.Equal(t, 200, w.Code)
+	var list struct {
+		Data []map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &list))
+	require.Len(t, list.Data, 1)
+	assert.Equal(t, "s1", list.Data[0]["session_id"])
+	assert.Equal(t, "chat-agent", list.Data[0]["agent_id"])
+
+	w = do(router, "GET", "/sessions/s1", "")
+	require.Equal(t, 200, w.Code)
+	var session struct {
+		ID          string                   `json:"id"`
+		AgentID     string                   `json:"agent_id"`
+		ChatHistory []map[string]interface{} `json:"chat_history"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &session))
+	assert.Equal(t, "s1", session.ID)
+	assert.Equal(t, "chat-agent", session.AgentID)
+	require.Len(t, session.ChatHistory, 2)
+	assert.Equal(t, "user", session.ChatHistory[0]["role"])
+	assert.Equal(t, "hello", session.ChatHistory[0]["content"])
+	assert.Equal(t, "assistant", session.ChatHistory[1]["role"])
+	assert.Equal(t, "Hi there", session.ChatHistory[1]["content"])
+
+	// Deleting removes the session from storage too
+	w = do(router, "DELETE", "/sessions/s1", "")
+	assert.Equal(t, 200, w.Code)
+
+	router = newServer()
+	w = do(router, "GET", "/sessions/s1", "")
+	assert.Equal(t, 404, w.Code)
+	w = do(router, "DELETE", "/sessions/s1", "")
+	assert.Equal(t, 404, w.Code)
+}
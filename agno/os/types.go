@@ -113,12 +113,38 @@ type AgentOSSettings struct {
 	EnableMCP   bool          `json:"enable_mcp" yaml:"enable_mcp" default:"false"`
 	Telemetry   bool          `json:"telemetry" yaml:"telemetry" default:"false"`
 	SecurityKey string        `json:"security_key,omitempty" yaml:"security_key,omitempty"`
+	// APIKeys are additional keys, each optionally restricted to some agents
+	APIKeys []APIKey `json:"api_keys,omitempty" yaml:"api_keys,omitempty"`
+	// ReadinessTimeout bounds the dependency checks of /readyz (default 3s)
+	ReadinessTimeout time.Duration `json:"readiness_timeout,omitempty" yaml:"readiness_timeout,omitempty"`
+	// EnableMetrics serves Prometheus metrics of the agent runs on GET /metrics
+	EnableMetrics bool `json:"enable_metrics" yaml:"enable_metrics" default:"false"`
+	// RateLimit limits the requests of each client (disabled by default)
+	RateLimit RateLimitConfig `json:"rate_limit,omitempty" yaml:"rate_limit,omitempty"`
+	// TrustedProxies are the IPs or CIDRs of proxies whose X-Forwarded-For
+	// header is trusted for the client IP (none by default)
+	TrustedProxies []string `json:"trusted_proxies,omitempty" yaml:"trusted_proxies,omitempty"`
 	// TLS Configuration
 	EnableTLS bool   `json:"enable_tls" yaml:"enable_tls" default:"false"`
 	CertFile  string `json:"cert_file,omitempty" yaml:"cert_file,omitempty"`
 	KeyFile   string `json:"key_file,omitempty" yaml:"key_file,omitempty"`
 }
 
+// APIKey is a key clients authenticate with, as a Bearer token or as the
+// "token" query parameter of WebSocket requests
+type APIKey struct {
+	Key           string   `json:"key" yaml:"key"`
+	Name          string   `json:"name" yaml:"name"`                                         // Shown in request logs
+	AllowedAgents []string `json:"allowed_agents,omitempty" yaml:"allowed_agents,omitempty"` // Agent IDs or names, empty allows all agents
+}
+
+// RateLimitConfig configures per-client rate limiting. Clients are identified
+// by the security key they authenticate with, or by IP when they don't.
+type RateLimitConfig struct {
+	RequestsPerMinute int `json:"requests_per_minute" yaml:"requests_per_minute"` // Sustained rate, 0 disables rate limiting
+	Burst             int `json:"burst" yaml:"burst"`                             // Requests allowed at once (default RequestsPerMinute)
+}
+
 // AgentOSOptions represents all options for creating an AgentOS instance
 type AgentOSOptions struct {
 	OSID         string                `json:"os_id" yaml:"os_id"`
@@ -136,6 +162,7 @@ type AgentOSOptions struct {
 	Telemetry    bool                  `json:"telemetry" yaml:"telemetry" default:"false"`
 	Middleware   []interface{}         `json:"middleware,omitempty" yaml:"middleware,omitempty"`
 	CustomRoutes []interface{}         `json:"custom_routes,omitempty" yaml:"custom_routes,omitempty"`
+	Storage      storage.Storage       `json:"-" yaml:"-"` // Persists sessions across restarts (e.g. sqlite.NewSqliteStorage)
 }
 
 // Event represents an event in the AgentOS system
@@ -0,0 +1,106 @@
+package reasoning
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ExportFormat define o formato de exportação do histórico de reasoning
+type ExportFormat string
+
+const (
+	// FormatJSON exporta o histórico como JSON indentado
+	FormatJSON ExportFormat = "json"
+	// FormatMarkdown exporta o histórico como um documento Markdown legível
+	FormatMarkdown ExportFormat = "markdown"
+)
+
+// ExportReasoningHistory exporta o histórico de uma execução
+func (srp *SQLiteReasoningPersistence) ExportReasoningHistory(ctx context.Context, runID string, format ExportFormat) ([]byte, error) {
+	return exportReasoningHistory(ctx, srp, runID, format)
+}
+
+// ExportReasoningHistory exporta o histórico de uma execução
+func (mrp *MySQLReasoningPersistence) ExportReasoningHistory(ctx context.Context, runID string, format ExportFormat) ([]byte, error) {
+	return exportReasoningHistory(ctx, mrp, runID, format)
+}
+
+// ExportReasoningHistory exporta o histórico de uma execução
+func (krp *KsqlReasoningPersistence) ExportReasoningHistory(ctx context.Context, runID string, format ExportFormat) ([]byte, error) {
+	return exportReasoningHistory(ctx, krp, runID, format)
+}
+
+func exportReasoningHistory(ctx context.Context, p ReasoningPersistence, runID string, format ExportFormat) ([]byte, error) {
+	if format != FormatJSON && format != FormatMarkdown {
+		return nil, fmt.Errorf("unsupported export format: %q", format)
+	}
+
+	history, err := p.GetReasoningHistory(ctx, runID)
+	if err != nil {
+		return nil, err
+	}
+
+	if format == FormatJSON {
+		return json.MarshalIndent(history, "", "  ")
+	}
+	return renderReasoningMarkdown(history), nil
+}
+
+// renderReasoningMarkdown gera um relatório com um cabeçalho de resumo e uma
+// seção por step
+func renderReasoningMarkdown(history *ReasoningHistory) []byte {
+	totalTokens := history.TotalTokens
+	totalDuration := history.TotalDuration
+	// Históricos ainda em execução podem não ter os totais consolidados
+	if totalTokens == 0 && totalDuration == 0 {
+		for _, step := range history.Steps {
+			totalTokens += step.ReasoningTokens + step.InputTokens + step.OutputTokens
+			totalDuration += step.Duration
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Reasoning History: %s\n\n", history.RunID)
+	if history.AgentID != "" {
+		fmt.Fprintf(&b, "- **Agent:** %s\n", history.AgentID)
+	}
+	if history.Status != "" {
+		fmt.Fprintf(&b, "- **Status:** %s\n", history.Status)
+	}
+	if !history.StartTime.IsZero() {
+		fmt.Fprintf(&b, "- **Started:** %s\n", history.StartTime.Format(time.RFC3339))
+	}
+	if !history.EndTime.IsZero() {
+		fmt.Fprintf(&b, "- **Ended:** %s\n", history.EndTime.Format(time.RFC3339))
+	}
+	fmt.Fprintf(&b, "- **Steps:** %d\n", len(history.Steps))
+	fmt.Fprintf(&b, "- **Total tokens:** %d\n", totalTokens)
+	fmt.Fprintf(&b, "- **Total duration:** %dms\n", totalDuration)
+	if history.Error != "" {
+		fmt.Fprintf(&b, "- **Error:** %s\n", history.Error)
+	}
+
+	for _, step := range history.Steps {
+		title := step.Title
+		if title == "" {
+			title = "Untitled"
+		}
+		fmt.Fprintf(&b, "\n## Step %d: %s\n\n", step.StepNumber, title)
+		writeMarkdownSection(&b, "Reasoning", step.Reasoning)
+		writeMarkdownSection(&b, "Action", step.Action)
+		writeMarkdownSection(&b, "Result", step.Result)
+		fmt.Fprintf(&b, "**Confidence:** %.2f\n", step.Confidence)
+	}
+
+	return []byte(b.String())
+}
+
+func writeMarkdownSection(b *strings.Builder, heading, content string) {
+	if content == "" {
+		return
+	}
+	fmt.Fprintf(b, "### %s\n\n%s\n\n", heading, strings.TrimSpace(content))
+}
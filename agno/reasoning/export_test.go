@@ -0,0 +1,92 @@
+package reasoning
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExportReasoningHistory(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	persistence, err := NewSQLiteReasoningPersistence(db)
+	if err != nil {
+		t.Fatalf("Failed to create persistence: %v", err)
+	}
+
+	ctx := context.Background()
+	runID := "run-export"
+	steps := []ReasoningStepRecord{
+		{RunID: runID, AgentID: "agent-1", StepNumber: 1, Title: "Understand", Reasoning: "Read the question", Action: "parse", Result: "parsed", Confidence: 0.8},
+		{RunID: runID, AgentID: "agent-1", StepNumber: 2, Title: "Answer", Reasoning: "Compute the total", Result: "42", Confidence: 0.95},
+	}
+	for _, step := range steps {
+		if err := persistence.SaveReasoningStep(ctx, step); err != nil {
+			t.Fatalf("Failed to save step: %v", err)
+		}
+	}
+	err = persistence.UpdateReasoningHistory(ctx, ReasoningHistory{
+		ID:            "history-export",
+		RunID:         runID,
+		AgentID:       "agent-1",
+		TotalTokens:   320,
+		TotalDuration: 1500,
+		StartTime:     time.Now().Add(-time.Minute),
+		EndTime:       time.Now(),
+		Status:        "completed",
+	})
+	if err != nil {
+		t.Fatalf("Failed to save history: %v", err)
+	}
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := persistence.ExportReasoningHistory(ctx, runID, FormatJSON)
+		if err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		var history ReasoningHistory
+		if err := json.Unmarshal(data, &history); err != nil {
+			t.Fatalf("Invalid JSON: %v", err)
+		}
+		if history.RunID != runID || history.TotalTokens != 320 || len(history.Steps) != 2 {
+			t.Errorf("Unexpected exported history: %s", data)
+		}
+	})
+
+	t.Run("Markdown", func(t *testing.T) {
+		data, err := persistence.ExportReasoningHistory(ctx, runID, FormatMarkdown)
+		if err != nil {
+			t.Fatalf("Export failed: %v", err)
+		}
+		md := string(data)
+		for _, want := range []string{
+			"# Reasoning History: run-export",
+			"**Total tokens:** 320",
+			"**Total duration:** 1500ms",
+			"## Step 1: Understand",
+			"### Reasoning\n\nRead the question",
+			"### Action\n\nparse",
+			"### Result\n\n42",
+			"**Confidence:** 0.95",
+		} {
+			if !strings.Contains(md, want) {
+				t.Errorf("Markdown missing %q:\n%s", want, md)
+			}
+		}
+		if strings.Index(md, "## Step 1") > strings.Index(md, "## Step 2") {
+			t.Error("Expected steps in order")
+		}
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		if _, err := persistence.ExportReasoningHistory(ctx, runID, "pdf"); err == nil {
+			t.Error("Expected an error for an unsupported format")
+		}
+		if _, err := persistence.ExportReasoningHistory(ctx, "missing", FormatJSON); err == nil {
+			t.Error("Expected an error for an unknown run")
+		}
+	})
+}
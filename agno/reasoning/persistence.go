@@ -10,39 +10,39 @@ import (
 
 // ReasoningStepRecord representa um reasoning step armazenado no banco de dados
 type ReasoningStepRecord struct {
-	ID              int64
-	RunID           string
-	AgentID         string
-	StepNumber      int
-	Title           string
-	Reasoning       string
-	Action          string
-	Result          string
-	Confidence      float64
-	NextAction      string
-	ReasoningTokens int
-	InputTokens     int
-	OutputTokens    int
-	Duration        int64 // em millisegundos
-	Timestamp       time.Time
-	Metadata        map[string]interface{}
+	ID              int64                  `json:"id"`
+	RunID           string                 `json:"run_id"`
+	AgentID         string                 `json:"agent_id"`
+	StepNumber      int                    `json:"step_number"`
+	Title           string                 `json:"title"`
+	Reasoning       string                 `json:"reasoning"`
+	Action          string                 `json:"action"`
+	Result          string                 `json:"result"`
+	Confidence      float64                `json:"confidence"`
+	NextAction      string                 `json:"next_action"`
+	ReasoningTokens int                    `json:"reasoning_tokens"`
+	InputTokens     int                    `json:"input_tokens"`
+	OutputTokens    int                    `json:"output_tokens"`
+	Duration        int64                  `json:"duration_ms"` // em millisegundos
+	Timestamp       time.Time              `json:"timestamp"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
 }
 
 // ReasoningHistory representa o histórico completo de reasoning de uma execução
 type ReasoningHistory struct {
-	ID              string
-	RunID           string
-	AgentID         string
-	Steps           []ReasoningStepRecord
-	TotalTokens     int
-	ReasoningTokens int
-	InputTokens     int
-	OutputTokens    int
-	TotalDuration   int64 // em millisegundos
-	StartTime       time.Time
-	EndTime         time.Time
-	Status          string // "running", "completed", "failed"
-	Error           string
+	ID              string                `json:"id"`
+	RunID           string                `json:"run_id"`
+	AgentID         string                `json:"agent_id"`
+	Steps           []ReasoningStepRecord `json:"steps"`
+	TotalTokens     int                   `json:"total_tokens"`
+	ReasoningTokens int                   `json:"reasoning_tokens"`
+	InputTokens     int                   `json:"input_tokens"`
+	OutputTokens    int                   `json:"output_tokens"`
+	TotalDuration   int64                 `json:"total_duration_ms"` // em millisegundos
+	StartTime       time.Time             `json:"start_time"`
+	EndTime         time.Time             `json:"end_time"`
+	Status          string                `json:"status"` // "running", "completed", "failed"
+	Error           string                `json:"error,omitempty"`
 }
 
 // ReasoningPersistence interface para persistência de reasoning steps
@@ -67,6 +67,16 @@ type ReasoningPersistence interface {
 
 	// GetReasoningStats obtém estatísticas de reasoning
 	GetReasoningStats(ctx context.Context, runID string) (map[string]interface{}, error)
+
+	// ListRuns lista os históricos de um agente, do mais recente ao mais antigo,
+	// sem os steps. limit <= 0 retorna todos.
+	ListRuns(ctx context.Context, agentID string, limit, offset int) ([]ReasoningHistory, error)
+
+	// CountRuns conta os históricos de um agente
+	CountRuns(ctx context.Context, agentID string) (int, error)
+
+	// ExportReasoningHistory exporta o histórico de uma execução em JSON ou Markdown
+	ExportReasoningHistory(ctx context.Context, runID string, format ExportFormat) ([]byte, error)
 }
 
 // SQLiteReasoningPersistence implementação SQLite de ReasoningPersistence
@@ -403,3 +413,85 @@ func (srp *SQLiteReasoningPersistence) GetReasoningStats(ctx context.Context, ru
 
 	return stats, nil
 }
+
+// ListRuns lista os históricos de um agente, ordenados por start_time decrescente
+func (srp *SQLiteReasoningPersistence) ListRuns(ctx context.Context, agentID string, limit, offset int) ([]ReasoningHistory, error) {
+	query, args := listRunsQuery(agentID, limit, offset)
+
+	rows, err := srp.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reasoning runs: %w", err)
+	}
+	defer rows.Close()
+
+	return scanReasoningRuns(rows)
+}
+
+// CountRuns conta os históricos de um agente
+func (srp *SQLiteReasoningPersistence) CountRuns(ctx context.Context, agentID string) (int, error) {
+	var count int
+	err := srp.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM reasoning_history WHERE agent_id = ?", agentID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reasoning runs: %w", err)
+	}
+	return count, nil
+}
+
+// listRunsQuery monta a consulta paginada de ListRuns, comum a SQLite e MySQL
+func listRunsQuery(agentID string, limit, offset int) (string, []interface{}) {
+	query := `
+	SELECT id, run_id, agent_id, total_tokens, reasoning_tokens, input_tokens,
+	       output_tokens, total_duration, start_time, end_time, status, error
+	FROM reasoning_history
+	WHERE agent_id = ?
+	ORDER BY start_time DESC, created_at DESC
+	`
+	args := []interface{}{agentID}
+
+	if offset < 0 {
+		offset = 0
+	}
+	if limit > 0 {
+		query += "LIMIT ? OFFSET ?"
+		args = append(args, limit, offset)
+	} else if offset > 0 {
+		// Sem limite: o maior valor aceito por SQLite e MySQL
+		query += "LIMIT 9223372036854775807 OFFSET ?"
+		args = append(args, offset)
+	}
+
+	return query, args
+}
+
+// scanReasoningRuns lê as linhas de ListRuns, comum a SQLite e MySQL
+func scanReasoningRuns(rows *sql.Rows) ([]ReasoningHistory, error) {
+	var runs []ReasoningHistory
+
+	for rows.Next() {
+		history := ReasoningHistory{}
+		var startTime, endTime sql.NullTime
+		var status, errorText sql.NullString
+
+		err := rows.Scan(
+			&history.ID, &history.RunID, &history.AgentID, &history.TotalTokens,
+			&history.ReasoningTokens, &history.InputTokens, &history.OutputTokens,
+			&history.TotalDuration, &startTime, &endTime,
+			&status, &errorText,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reasoning run: %w", err)
+		}
+
+		history.StartTime = startTime.Time
+		history.EndTime = endTime.Time
+		history.Status = status.String
+		history.Error = errorText.String
+		runs = append(runs, history)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reasoning runs: %w", err)
+	}
+
+	return runs, nil
+}
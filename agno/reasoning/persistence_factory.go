@@ -2,6 +2,8 @@ package reasoning
 
 import (
 	"fmt"
+
+	"github.com/devalexandre/agno-golang/agno/db"
 )
 
 // DatabaseType define o tipo de banco de dados suportado
@@ -165,35 +167,33 @@ func newPostgreSQLPersistence(config *DatabaseConfig) (ReasoningPersistence, err
 	return nil, fmt.Errorf("PostgreSQL persistence requires agno/db package")
 }
 
-// newMySQLPersistence cria uma nova instância de MySQL persistence usando agno/db
+// newMySQLPersistence cria uma nova instância de MySQLReasoningPersistence usando agno/db
 func newMySQLPersistence(config *DatabaseConfig) (ReasoningPersistence, error) {
 	if config.Host == "" || config.Port == 0 || config.Database == "" {
 		return nil, fmt.Errorf("host, port and database are required for MySQL")
 	}
 
-	// Implementação usando agno/db
-	// import "github.com/devalexandre/agno-golang/agno/db"
-
-	// database, err := db.New(db.Config{
-	//     Type:     db.MySQL,
-	//     Host:     config.Host,
-	//     Port:     config.Port,
-	//     User:     config.User,
-	//     Password: config.Password,
-	//     Database: config.Database,
-	// })
-	// if err != nil {
-	//     return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
-	// }
-
-	// persistence, err := NewSQLiteReasoningPersistence(database.DB)
-	// if err != nil {
-	//     return nil, fmt.Errorf("failed to create MySQL persistence: %w", err)
-	// }
+	database, err := db.New(db.Config{
+		Type:         db.MySQL,
+		Host:         config.Host,
+		Port:         config.Port,
+		User:         config.User,
+		Password:     config.Password,
+		Database:     config.Database,
+		MaxOpenConns: config.MaxConnections,
+		MaxIdleConns: config.MaxIdleConnections,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to MySQL: %w", err)
+	}
 
-	// return persistence, nil
+	persistence, err := NewMySQLReasoningPersistence(database.DB)
+	if err != nil {
+		database.Close()
+		return nil, fmt.Errorf("failed to create MySQL persistence: %w", err)
+	}
 
-	return nil, fmt.Errorf("MySQL persistence requires agno/db package")
+	return persistence, nil
 }
 
 // newMariaDBPersistence cria uma nova instância de MariaDB persistence usando agno/db
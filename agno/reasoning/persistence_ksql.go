@@ -121,6 +121,16 @@ func (krp *KsqlReasoningPersistence) GetReasoningStats(ctx context.Context, runI
 	return nil, fmt.Errorf("not implemented: use SQLiteReasoningPersistence or implement ksql integration")
 }
 
+// ListRuns lista os históricos de um agente
+func (krp *KsqlReasoningPersistence) ListRuns(ctx context.Context, agentID string, limit, offset int) ([]ReasoningHistory, error) {
+	return nil, fmt.Errorf("not implemented: use SQLiteReasoningPersistence or implement ksql integration")
+}
+
+// CountRuns conta os históricos de um agente
+func (krp *KsqlReasoningPersistence) CountRuns(ctx context.Context, agentID string) (int, error) {
+	return 0, fmt.Errorf("not implemented: use SQLiteReasoningPersistence or implement ksql integration")
+}
+
 // KsqlImplementationGuide fornece um guia para implementar ksql
 const KsqlImplementationGuide = `
 # Guia de Implementação com ksql
@@ -0,0 +1,381 @@
+package reasoning
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// MySQLReasoningPersistence implementação MySQL de ReasoningPersistence
+type MySQLReasoningPersistence struct {
+	db *sql.DB
+}
+
+// NewMySQLReasoningPersistence cria uma nova instância de MySQLReasoningPersistence.
+// A conexão precisa de parseTime=true no DSN para ler as colunas DATETIME.
+func NewMySQLReasoningPersistence(db *sql.DB) (*MySQLReasoningPersistence, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	mrp := &MySQLReasoningPersistence{db: db}
+
+	// Criar tabelas se não existirem
+	if err := mrp.createTables(); err != nil {
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+
+	return mrp, nil
+}
+
+// createTables cria as tabelas necessárias. O driver MySQL não aceita vários
+// comandos em um Exec, então cada tabela é criada separadamente, com os
+// índices na própria definição.
+func (mrp *MySQLReasoningPersistence) createTables() error {
+	schema := []string{`
+	CREATE TABLE IF NOT EXISTS reasoning_steps (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		run_id VARCHAR(255) NOT NULL,
+		agent_id VARCHAR(255) NOT NULL,
+		step_number INT NOT NULL,
+		title TEXT,
+		reasoning TEXT,
+		action TEXT,
+		result TEXT,
+		confidence DOUBLE,
+		next_action TEXT,
+		reasoning_tokens INT,
+		input_tokens INT,
+		output_tokens INT,
+		duration BIGINT,
+		` + "`timestamp`" + ` DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+		metadata JSON,
+		UNIQUE KEY uq_reasoning_steps_run_step (run_id, step_number),
+		KEY idx_reasoning_steps_run_id (run_id),
+		KEY idx_reasoning_steps_agent_id (agent_id)
+	)`, `
+	CREATE TABLE IF NOT EXISTS reasoning_history (
+		id VARCHAR(255) PRIMARY KEY,
+		run_id VARCHAR(255) NOT NULL,
+		agent_id VARCHAR(255) NOT NULL,
+		total_tokens INT,
+		reasoning_tokens INT,
+		input_tokens INT,
+		output_tokens INT,
+		total_duration BIGINT,
+		start_time DATETIME(6) NULL,
+		end_time DATETIME(6) NULL,
+		status VARCHAR(32),
+		error TEXT,
+		created_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+		updated_at DATETIME(6) DEFAULT CURRENT_TIMESTAMP(6),
+		UNIQUE KEY uq_reasoning_history_run_id (run_id),
+		KEY idx_reasoning_history_agent_id (agent_id)
+	)`}
+
+	for _, statement := range schema {
+		if _, err := mrp.db.Exec(statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SaveReasoningStep salva um reasoning step
+func (mrp *MySQLReasoningPersistence) SaveReasoningStep(ctx context.Context, step ReasoningStepRecord) error {
+	metadataJSON, err := json.Marshal(step.Metadata)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata: %w", err)
+	}
+
+	query := `
+	INSERT INTO reasoning_steps (
+		run_id, agent_id, step_number, title, reasoning, action, result,
+		confidence, next_action, reasoning_tokens, input_tokens, output_tokens,
+		duration, metadata
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		title = VALUES(title),
+		reasoning = VALUES(reasoning),
+		action = VALUES(action),
+		result = VALUES(result),
+		confidence = VALUES(confidence),
+		next_action = VALUES(next_action),
+		reasoning_tokens = VALUES(reasoning_tokens),
+		input_tokens = VALUES(input_tokens),
+		output_tokens = VALUES(output_tokens),
+		duration = VALUES(duration),
+		metadata = VALUES(metadata)
+	`
+
+	_, err = mrp.db.ExecContext(ctx, query,
+		step.RunID, step.AgentID, step.StepNumber, step.Title, step.Reasoning,
+		step.Action, step.Result, step.Confidence, step.NextAction,
+		step.ReasoningTokens, step.InputTokens, step.OutputTokens,
+		step.Duration, string(metadataJSON),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save reasoning step: %w", err)
+	}
+
+	return nil
+}
+
+// GetReasoningHistory obtém o histórico de reasoning de uma execução
+func (mrp *MySQLReasoningPersistence) GetReasoningHistory(ctx context.Context, runID string) (*ReasoningHistory, error) {
+	query := `
+	SELECT id, run_id, agent_id, total_tokens, reasoning_tokens, input_tokens,
+	       output_tokens, total_duration, start_time, end_time, status, error
+	FROM reasoning_history
+	WHERE run_id = ?
+	`
+
+	history := &ReasoningHistory{}
+	var startTime, endTime sql.NullTime
+	var status, errorText sql.NullString
+	err := mrp.db.QueryRowContext(ctx, query, runID).Scan(
+		&history.ID, &history.RunID, &history.AgentID, &history.TotalTokens,
+		&history.ReasoningTokens, &history.InputTokens, &history.OutputTokens,
+		&history.TotalDuration, &startTime, &endTime,
+		&status, &errorText,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("reasoning history not found for run %s", runID)
+		}
+		return nil, fmt.Errorf("failed to get reasoning history: %w", err)
+	}
+
+	history.StartTime = startTime.Time
+	history.EndTime = endTime.Time
+	history.Status = status.String
+	history.Error = errorText.String
+
+	// Obter os steps
+	steps, err := mrp.ListReasoningSteps(ctx, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reasoning steps: %w", err)
+	}
+
+	history.Steps = steps
+	return history, nil
+}
+
+// GetReasoningStep obtém um reasoning step específico
+func (mrp *MySQLReasoningPersistence) GetReasoningStep(ctx context.Context, id int64) (*ReasoningStepRecord, error) {
+	query := `
+	SELECT id, run_id, agent_id, step_number, title, reasoning, action, result,
+	       confidence, next_action, reasoning_tokens, input_tokens, output_tokens,
+	       duration, ` + "`timestamp`" + `, metadata
+	FROM reasoning_steps
+	WHERE id = ?
+	`
+
+	step, err := scanMySQLReasoningStep(mrp.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("reasoning step not found")
+		}
+		return nil, fmt.Errorf("failed to get reasoning step: %w", err)
+	}
+
+	return step, nil
+}
+
+// ListReasoningSteps lista todos os reasoning steps de uma execução
+func (mrp *MySQLReasoningPersistence) ListReasoningSteps(ctx context.Context, runID string) ([]ReasoningStepRecord, error) {
+	query := `
+	SELECT id, run_id, agent_id, step_number, title, reasoning, action, result,
+	       confidence, next_action, reasoning_tokens, input_tokens, output_tokens,
+	       duration, ` + "`timestamp`" + `, metadata
+	FROM reasoning_steps
+	WHERE run_id = ?
+	ORDER BY step_number ASC
+	`
+
+	rows, err := mrp.db.QueryContext(ctx, query, runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reasoning steps: %w", err)
+	}
+	defer rows.Close()
+
+	var steps []ReasoningStepRecord
+
+	for rows.Next() {
+		step, err := scanMySQLReasoningStep(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reasoning step: %w", err)
+		}
+		steps = append(steps, *step)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reasoning steps: %w", err)
+	}
+
+	return steps, nil
+}
+
+// scanMySQLReasoningStep lê um reasoning step de uma linha, decodificando o
+// metadata JSON
+func scanMySQLReasoningStep(row interface{ Scan(dest ...any) error }) (*ReasoningStepRecord, error) {
+	step := &ReasoningStepRecord{}
+	var title, reasoning, action, result, nextAction, metadataJSON sql.NullString
+	var timestamp sql.NullTime
+
+	err := row.Scan(
+		&step.ID, &step.RunID, &step.AgentID, &step.StepNumber, &title,
+		&reasoning, &action, &result, &step.Confidence,
+		&nextAction, &step.ReasoningTokens, &step.InputTokens,
+		&step.OutputTokens, &step.Duration, &timestamp, &metadataJSON,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	step.Title = title.String
+	step.Reasoning = reasoning.String
+	step.Action = action.String
+	step.Result = result.String
+	step.NextAction = nextAction.String
+	step.Timestamp = timestamp.Time
+
+	if metadataJSON.String != "" {
+		if err := json.Unmarshal([]byte(metadataJSON.String), &step.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+
+	return step, nil
+}
+
+// UpdateReasoningHistory atualiza o histórico de reasoning
+func (mrp *MySQLReasoningPersistence) UpdateReasoningHistory(ctx context.Context, history ReasoningHistory) error {
+	query := `
+	INSERT INTO reasoning_history (
+		id, run_id, agent_id, total_tokens, reasoning_tokens, input_tokens,
+		output_tokens, total_duration, start_time, end_time, status, error
+	) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	ON DUPLICATE KEY UPDATE
+		total_tokens = VALUES(total_tokens),
+		reasoning_tokens = VALUES(reasoning_tokens),
+		input_tokens = VALUES(input_tokens),
+		output_tokens = VALUES(output_tokens),
+		total_duration = VALUES(total_duration),
+		end_time = VALUES(end_time),
+		status = VALUES(status),
+		error = VALUES(error),
+		updated_at = CURRENT_TIMESTAMP(6)
+	`
+
+	_, err := mrp.db.ExecContext(ctx, query,
+		history.ID, history.RunID, history.AgentID, history.TotalTokens,
+		history.ReasoningTokens, history.InputTokens, history.OutputTokens,
+		history.TotalDuration, nullTime(history.StartTime), nullTime(history.EndTime),
+		history.Status, history.Error,
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update reasoning history: %w", err)
+	}
+
+	return nil
+}
+
+// nullTime converte o tempo zero em NULL, já que o modo estrito do MySQL
+// rejeita datas zeradas
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
+
+// DeleteReasoningHistory deleta o histórico de reasoning
+func (mrp *MySQLReasoningPersistence) DeleteReasoningHistory(ctx context.Context, runID string) error {
+	// Deletar steps primeiro
+	_, err := mrp.db.ExecContext(ctx, "DELETE FROM reasoning_steps WHERE run_id = ?", runID)
+	if err != nil {
+		return fmt.Errorf("failed to delete reasoning steps: %w", err)
+	}
+
+	// Deletar history
+	_, err = mrp.db.ExecContext(ctx, "DELETE FROM reasoning_history WHERE run_id = ?", runID)
+	if err != nil {
+		return fmt.Errorf("failed to delete reasoning history: %w", err)
+	}
+
+	return nil
+}
+
+// GetReasoningStats obtém estatísticas de reasoning
+func (mrp *MySQLReasoningPersistence) GetReasoningStats(ctx context.Context, runID string) (map[string]interface{}, error) {
+	query := `
+	SELECT
+		COUNT(*) AS total_steps,
+		SUM(reasoning_tokens) AS total_reasoning_tokens,
+		SUM(input_tokens) AS total_input_tokens,
+		SUM(output_tokens) AS total_output_tokens,
+		SUM(duration) AS total_duration,
+		AVG(confidence) AS avg_confidence
+	FROM reasoning_steps
+	WHERE run_id = ?
+	`
+
+	stats := make(map[string]interface{})
+	var totalSteps int
+	var reasoningTokens, inputTokens, outputTokens, totalDuration sql.NullInt64
+	var avgConfidence sql.NullFloat64
+
+	err := mrp.db.QueryRowContext(ctx, query, runID).Scan(
+		&totalSteps, &reasoningTokens, &inputTokens, &outputTokens,
+		&totalDuration, &avgConfidence,
+	)
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reasoning stats: %w", err)
+	}
+
+	stats["total_steps"] = totalSteps
+	if reasoningTokens.Valid {
+		stats["total_reasoning_tokens"] = reasoningTokens.Int64
+	}
+	if inputTokens.Valid {
+		stats["total_input_tokens"] = inputTokens.Int64
+	}
+	if outputTokens.Valid {
+		stats["total_output_tokens"] = outputTokens.Int64
+	}
+	if totalDuration.Valid {
+		stats["total_duration_ms"] = totalDuration.Int64
+	}
+	if avgConfidence.Valid {
+		stats["avg_confidence"] = avgConfidence.Float64
+	}
+
+	return stats, nil
+}
+
+// ListRuns lista os históricos de um agente, ordenados por start_time decrescente
+func (mrp *MySQLReasoningPersistence) ListRuns(ctx context.Context, agentID string, limit, offset int) ([]ReasoningHistory, error) {
+	query, args := listRunsQuery(agentID, limit, offset)
+
+	rows, err := mrp.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reasoning runs: %w", err)
+	}
+	defer rows.Close()
+
+	return scanReasoningRuns(rows)
+}
+
+// CountRuns conta os históricos de um agente
+func (mrp *MySQLReasoningPersistence) CountRuns(ctx context.Context, agentID string) (int, error) {
+	var count int
+	err := mrp.db.QueryRowContext(ctx, "SELECT COUNT(*) FROM reasoning_history WHERE agent_id = ?", agentID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count reasoning runs: %w", err)
+	}
+	return count, nil
+}
@@ -0,0 +1,212 @@
+package reasoning
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+func setupMySQLContainer(tb testing.TB) (*DatabaseConfig, func()) {
+	ctx := context.Background()
+
+	container, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: testcontainers.ContainerRequest{
+			Image:        "mysql:8.0",
+			ExposedPorts: []string{"3306/tcp"},
+			Env: map[string]string{
+				"MYSQL_ROOT_PASSWORD": "testpass",
+				"MYSQL_DATABASE":      "testdb",
+				"MYSQL_USER":          "testuser",
+				"MYSQL_PASSWORD":      "testpass",
+			},
+			WaitingFor: wait.ForLog("port: 3306  MySQL Community Server").
+				WithStartupTimeout(120 * time.Second),
+		},
+		Started: true,
+	})
+	if err != nil {
+		tb.Fatalf("Failed to start MySQL container: %v", err)
+	}
+
+	host, err := container.Host(ctx)
+	if err != nil {
+		tb.Fatalf("Failed to get container host: %v", err)
+	}
+	port, err := container.MappedPort(ctx, "3306")
+	if err != nil {
+		tb.Fatalf("Failed to get container port: %v", err)
+	}
+
+	config := &DatabaseConfig{
+		Type:     DatabaseTypeMySQL,
+		Host:     host,
+		Port:     port.Int(),
+		User:     "testuser",
+		Password: "testpass",
+		Database: "testdb",
+	}
+
+	cleanup := func() {
+		if err := container.Terminate(ctx); err != nil {
+			tb.Logf("Failed to terminate container: %v", err)
+		}
+	}
+
+	return config, cleanup
+}
+
+func TestMySQLReasoningPersistenceWithTestcontainers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	config, cleanup := setupMySQLContainer(t)
+	defer cleanup()
+
+	persistence, err := NewReasoningPersistence(config)
+	if err != nil {
+		t.Fatalf("Failed to create MySQL persistence: %v", err)
+	}
+
+	ctx := context.Background()
+	runID := "run-mysql"
+
+	t.Run("Save and List Steps", func(t *testing.T) {
+		for i := 1; i <= 3; i++ {
+			err := persistence.SaveReasoningStep(ctx, ReasoningStepRecord{
+				RunID:           runID,
+				AgentID:         "agent-1",
+				StepNumber:      i,
+				Title:           fmt.Sprintf("Step %d", i),
+				Reasoning:       "thinking",
+				Confidence:      0.5 + float64(i)/10,
+				ReasoningTokens: 10,
+				InputTokens:     20,
+				OutputTokens:    30,
+				Duration:        100,
+				Metadata:        map[string]interface{}{"source": "test", "index": i},
+			})
+			if err != nil {
+				t.Fatalf("Failed to save step %d: %v", i, err)
+			}
+		}
+
+		// Saving the same step number again updates it
+		err := persistence.SaveReasoningStep(ctx, ReasoningStepRecord{
+			RunID:      runID,
+			AgentID:    "agent-1",
+			StepNumber: 3,
+			Title:      "Step 3 revised",
+			Confidence: 0.9,
+			Duration:   100,
+			Metadata:   map[string]interface{}{"source": "revision"},
+		})
+		if err != nil {
+			t.Fatalf("Failed to update step: %v", err)
+		}
+
+		steps, err := persistence.ListReasoningSteps(ctx, runID)
+		if err != nil {
+			t.Fatalf("Failed to list steps: %v", err)
+		}
+		if len(steps) != 3 {
+			t.Fatalf("Expected 3 steps, got %d", len(steps))
+		}
+		if steps[0].Title != "Step 1" || steps[0].Metadata["source"] != "test" {
+			t.Errorf("Unexpected first step: %+v", steps[0])
+		}
+		if steps[2].Title != "Step 3 revised" || steps[2].Metadata["source"] != "revision" {
+			t.Errorf("Expected the revised step, got %+v", steps[2])
+		}
+		if steps[0].Timestamp.IsZero() {
+			t.Error("Expected a timestamp")
+		}
+
+		step, err := persistence.GetReasoningStep(ctx, steps[1].ID)
+		if err != nil {
+			t.Fatalf("Failed to get step: %v", err)
+		}
+		if step.StepNumber != 2 {
+			t.Errorf("Expected step 2, got %d", step.StepNumber)
+		}
+	})
+
+	t.Run("History and Stats", func(t *testing.T) {
+		start := time.Now().Add(-time.Minute).UTC().Truncate(time.Second)
+		err := persistence.UpdateReasoningHistory(ctx, ReasoningHistory{
+			ID:        "history-1",
+			RunID:     runID,
+			AgentID:   "agent-1",
+			StartTime: start,
+			Status:    "running",
+		})
+		if err != nil {
+			t.Fatalf("Failed to save history: %v", err)
+		}
+
+		err = persistence.UpdateReasoningHistory(ctx, ReasoningHistory{
+			ID:            "history-1",
+			RunID:         runID,
+			AgentID:       "agent-1",
+			TotalTokens:   150,
+			TotalDuration: 300,
+			StartTime:     start,
+			EndTime:       start.Add(time.Minute),
+			Status:        "completed",
+		})
+		if err != nil {
+			t.Fatalf("Failed to update history: %v", err)
+		}
+
+		history, err := persistence.GetReasoningHistory(ctx, runID)
+		if err != nil {
+			t.Fatalf("Failed to get history: %v", err)
+		}
+		if history.Status != "completed" || history.TotalTokens != 150 || len(history.Steps) != 3 {
+			t.Errorf("Unexpected history: %+v", history)
+		}
+		if !history.StartTime.Equal(start) || history.EndTime.IsZero() {
+			t.Errorf("Unexpected history times: %v - %v", history.StartTime, history.EndTime)
+		}
+
+		runs, err := persistence.ListRuns(ctx, "agent-1", 10, 0)
+		if err != nil {
+			t.Fatalf("Failed to list runs: %v", err)
+		}
+		count, err := persistence.CountRuns(ctx, "agent-1")
+		if err != nil {
+			t.Fatalf("Failed to count runs: %v", err)
+		}
+		if len(runs) != 1 || count != 1 || runs[0].RunID != runID {
+			t.Errorf("Expected the single run, got %d runs (count %d)", len(runs), count)
+		}
+
+		stats, err := persistence.GetReasoningStats(ctx, runID)
+		if err != nil {
+			t.Fatalf("Failed to get stats: %v", err)
+		}
+		if stats["total_steps"] != 3 || stats["total_duration_ms"] != int64(300) {
+			t.Errorf("Unexpected stats: %v", stats)
+		}
+	})
+
+	t.Run("Delete History", func(t *testing.T) {
+		if err := persistence.DeleteReasoningHistory(ctx, runID); err != nil {
+			t.Fatalf("Failed to delete history: %v", err)
+		}
+		if _, err := persistence.GetReasoningHistory(ctx, runID); err == nil {
+			t.Error("Expected the history to be deleted")
+		}
+		steps, err := persistence.ListReasoningSteps(ctx, runID)
+		if err != nil {
+			t.Fatalf("Failed to list steps: %v", err)
+		}
+		if len(steps) != 0 {
+			t.Errorf("Expected no steps, got %d", len(steps))
+		}
+	})
+}
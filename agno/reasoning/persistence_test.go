@@ -3,6 +3,7 @@ package reasoning
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"testing"
 	"time"
 
@@ -10,7 +11,7 @@ import (
 )
 
 func setupTestDB(t *testing.T) *sql.DB {
-	db, err := sql.Open("sqlite3", ":memory:")
+	db, err := sql.Open("sqlite", ":memory:")
 	if err != nil {
 		t.Fatalf("Failed to open test database: %v", err)
 	}
@@ -232,3 +233,72 @@ func TestDeleteReasoningHistory(t *testing.T) {
 		t.Error("Expected error when getting deleted history")
 	}
 }
+
+func TestListRuns(t *testing.T) {
+	db := setupTestDB(t)
+	defer db.Close()
+
+	persistence, err := NewSQLiteReasoningPersistence(db)
+	if err != nil {
+		t.Fatalf("Failed to create persistence: %v", err)
+	}
+
+	ctx := context.Background()
+	start := time.Now().Add(-time.Hour)
+	for i := 0; i < 5; i++ {
+		err := persistence.UpdateReasoningHistory(ctx, ReasoningHistory{
+			ID:        fmt.Sprintf("history-%d", i),
+			RunID:     fmt.Sprintf("run-%d", i),
+			AgentID:   "agent-001",
+			StartTime: start.Add(time.Duration(i) * time.Minute),
+			Status:    "completed",
+		})
+		if err != nil {
+			t.Fatalf("Failed to save history %d: %v", i, err)
+		}
+	}
+	err = persistence.UpdateReasoningHistory(ctx, ReasoningHistory{
+		ID:        "history-other",
+		RunID:     "run-other",
+		AgentID:   "agent-002",
+		StartTime: start,
+	})
+	if err != nil {
+		t.Fatalf("Failed to save history: %v", err)
+	}
+
+	count, err := persistence.CountRuns(ctx, "agent-001")
+	if err != nil {
+		t.Fatalf("Failed to count runs: %v", err)
+	}
+	if count != 5 {
+		t.Errorf("Expected 5 runs, got %d", count)
+	}
+
+	page, err := persistence.ListRuns(ctx, "agent-001", 2, 1)
+	if err != nil {
+		t.Fatalf("Failed to list runs: %v", err)
+	}
+	if len(page) != 2 || page[0].RunID != "run-3" || page[1].RunID != "run-2" {
+		t.Errorf("Expected run-3 and run-2, got %+v", page)
+	}
+	if page[0].Status != "completed" || page[0].StartTime.IsZero() {
+		t.Errorf("Unexpected run: %+v", page[0])
+	}
+
+	all, err := persistence.ListRuns(ctx, "agent-001", 0, 0)
+	if err != nil {
+		t.Fatalf("Failed to list runs: %v", err)
+	}
+	if len(all) != 5 || all[0].RunID != "run-4" {
+		t.Errorf("Expected all 5 runs newest first, got %d", len(all))
+	}
+
+	none, err := persistence.ListRuns(ctx, "agent-003", 10, 0)
+	if err != nil {
+		t.Fatalf("Failed to list runs: %v", err)
+	}
+	if len(none) != 0 {
+		t.Errorf("Expected no runs, got %d", len(none))
+	}
+}
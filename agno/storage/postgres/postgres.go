@@ -1,6 +1,7 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -174,6 +175,11 @@ func (s *PostgresStorage) Drop() error {
 	return err
 }
 
+// Ping checks that the database is reachable
+func (s *PostgresStorage) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
 func (s *PostgresStorage) Close() error {
 	return s.db.Close()
 }
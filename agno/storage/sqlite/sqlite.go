@@ -1,6 +1,7 @@
 package sqlite
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
 	"fmt"
@@ -91,7 +92,7 @@ func (s *SqliteStorage) initDB(providedDB *sql.DB) error {
 	}
 
 	if s.dbURL != nil {
-		s.db, err = sql.Open("sqlite3", *s.dbURL)
+		s.db, err = sql.Open("sqlite", *s.dbURL)
 		if err != nil {
 			return fmt.Errorf("failed to open database with URL: %w", err)
 		}
@@ -102,13 +103,13 @@ func (s *SqliteStorage) initDB(providedDB *sql.DB) error {
 			return fmt.Errorf("failed to create directory: %w", err)
 		}
 
-		s.db, err = sql.Open("sqlite3", *s.dbFile)
+		s.db, err = sql.Open("sqlite", *s.dbFile)
 		if err != nil {
 			return fmt.Errorf("failed to open database file: %w", err)
 		}
 	} else {
 		// Create in-memory database
-		s.db, err = sql.Open("sqlite3", ":memory:")
+		s.db, err = sql.Open("sqlite", ":memory:")
 		if err != nil {
 			return fmt.Errorf("failed to create in-memory database: %w", err)
 		}
@@ -738,6 +739,14 @@ func (s *SqliteStorage) Drop() error {
 	return nil
 }
 
+// Ping checks that the database is reachable
+func (s *SqliteStorage) Ping(ctx context.Context) error {
+	if s.db == nil {
+		return fmt.Errorf("database not initialized")
+	}
+	return s.db.PingContext(ctx)
+}
+
 // Close closes the database connection
 func (s *SqliteStorage) Close() error {
 	if s.db != nil {
@@ -0,0 +1,152 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/storage"
+)
+
+// TaskStore Implementation
+// These methods implement storage.TaskStore on the agno_scheduled_tasks table
+
+// SaveTask inserts or replaces a scheduled task
+func (s *SqliteStorage) SaveTask(ctx context.Context, task *storage.ScheduledTask) error {
+	if err := s.createTasksTableIfNotExists(ctx); err != nil {
+		return err
+	}
+
+	query := `
+		INSERT OR REPLACE INTO agno_scheduled_tasks (id, payload, run_at, status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err := s.db.ExecContext(ctx, query,
+		task.ID,
+		task.Payload,
+		task.RunAt.Unix(),
+		string(task.Status),
+		task.CreatedAt.Unix(),
+		task.UpdatedAt.Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save task: %w", err)
+	}
+	return nil
+}
+
+// GetTask returns a scheduled task by ID
+func (s *SqliteStorage) GetTask(ctx context.Context, id string) (*storage.ScheduledTask, error) {
+	if err := s.createTasksTableIfNotExists(ctx); err != nil {
+		return nil, err
+	}
+
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, payload, run_at, status, created_at, updated_at
+		FROM agno_scheduled_tasks WHERE id = ?
+	`, id)
+
+	task, err := scanTask(row)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("task not found: %s", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task: %w", err)
+	}
+	return task, nil
+}
+
+// ListTasks returns scheduled tasks ordered by run time; an empty status returns all tasks
+func (s *SqliteStorage) ListTasks(ctx context.Context, status storage.TaskStatus) ([]*storage.ScheduledTask, error) {
+	if err := s.createTasksTableIfNotExists(ctx); err != nil {
+		return nil, err
+	}
+
+	query := `SELECT id, payload, run_at, status, created_at, updated_at FROM agno_scheduled_tasks`
+	var args []interface{}
+	if status != "" {
+		query += ` WHERE status = ?`
+		args = append(args, string(status))
+	}
+	query += ` ORDER BY run_at ASC`
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var tasks []*storage.ScheduledTask
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan task: %w", err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, rows.Err()
+}
+
+// UpdateTaskStatus moves a scheduled task from one status to another. The
+// status check is part of the UPDATE so concurrent transitions cannot both win.
+func (s *SqliteStorage) UpdateTaskStatus(ctx context.Context, id string, from, to storage.TaskStatus) error {
+	if err := s.createTasksTableIfNotExists(ctx); err != nil {
+		return err
+	}
+
+	result, err := s.db.ExecContext(ctx,
+		`UPDATE agno_scheduled_tasks SET status = ?, updated_at = ? WHERE id = ? AND status = ?`,
+		string(to), time.Now().Unix(), id, string(from),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+	if affected == 0 {
+		task, err := s.GetTask(ctx, id)
+		if err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: task %s is %s", storage.ErrTaskStatusChanged, id, task.Status)
+	}
+	return nil
+}
+
+// scanTask reads a task from a row with the columns selected above
+func scanTask(row interface{ Scan(...interface{}) error }) (*storage.ScheduledTask, error) {
+	var task storage.ScheduledTask
+	var status string
+	var runAt, createdAt, updatedAt int64
+	if err := row.Scan(&task.ID, &task.Payload, &runAt, &status, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+	task.Status = storage.TaskStatus(status)
+	task.RunAt = time.Unix(runAt, 0)
+	task.CreatedAt = time.Unix(createdAt, 0)
+	task.UpdatedAt = time.Unix(updatedAt, 0)
+	return &task, nil
+}
+
+// createTasksTableIfNotExists creates the agno_scheduled_tasks table if it doesn't exist
+func (s *SqliteStorage) createTasksTableIfNotExists(ctx context.Context) error {
+	query := `
+		CREATE TABLE IF NOT EXISTS agno_scheduled_tasks (
+			id TEXT PRIMARY KEY,
+			payload TEXT NOT NULL DEFAULT '',
+			run_at INTEGER NOT NULL,
+			status TEXT NOT NULL,
+			created_at INTEGER,
+			updated_at INTEGER
+		)
+	`
+
+	if _, err := s.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to create scheduled tasks table: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,111 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TaskStatus is the lifecycle state of a scheduled task
+type TaskStatus string
+
+const (
+	TaskPending   TaskStatus = "pending"
+	TaskFired     TaskStatus = "fired"
+	TaskCancelled TaskStatus = "cancelled"
+)
+
+// ErrTaskStatusChanged is returned by UpdateTaskStatus when the task is no
+// longer in the expected status, e.g. it was cancelled while being fired
+var ErrTaskStatusChanged = errors.New("task status changed")
+
+// ScheduledTask is a deferred task persisted until it is due
+type ScheduledTask struct {
+	ID        string     `json:"id" db:"id"`
+	Payload   string     `json:"payload" db:"payload"`
+	RunAt     time.Time  `json:"run_at" db:"run_at"`
+	Status    TaskStatus `json:"status" db:"status"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// TaskStore persists scheduled tasks
+type TaskStore interface {
+	// SaveTask inserts or replaces a task
+	SaveTask(ctx context.Context, task *ScheduledTask) error
+	// GetTask returns a task by ID
+	GetTask(ctx context.Context, id string) (*ScheduledTask, error)
+	// ListTasks returns tasks ordered by RunAt; an empty status returns all tasks
+	ListTasks(ctx context.Context, status TaskStatus) ([]*ScheduledTask, error)
+	// UpdateTaskStatus moves a task from one status to another; it returns
+	// ErrTaskStatusChanged if the task is not currently in the from status
+	UpdateTaskStatus(ctx context.Context, id string, from, to TaskStatus) error
+}
+
+// MemoryTaskStore is an in-memory TaskStore, useful for tests and short-lived processes
+type MemoryTaskStore struct {
+	mu    sync.RWMutex
+	tasks map[string]*ScheduledTask
+}
+
+// NewMemoryTaskStore creates an empty in-memory task store
+func NewMemoryTaskStore() *MemoryTaskStore {
+	return &MemoryTaskStore{tasks: make(map[string]*ScheduledTask)}
+}
+
+// SaveTask inserts or replaces a task
+func (s *MemoryTaskStore) SaveTask(ctx context.Context, task *ScheduledTask) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copied := *task
+	s.tasks[task.ID] = &copied
+	return nil
+}
+
+// GetTask returns a task by ID
+func (s *MemoryTaskStore) GetTask(ctx context.Context, id string) (*ScheduledTask, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	task, ok := s.tasks[id]
+	if !ok {
+		return nil, fmt.Errorf("task not found: %s", id)
+	}
+	copied := *task
+	return &copied, nil
+}
+
+// ListTasks returns tasks ordered by RunAt
+func (s *MemoryTaskStore) ListTasks(ctx context.Context, status TaskStatus) ([]*ScheduledTask, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	tasks := make([]*ScheduledTask, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		if status == "" || task.Status == status {
+			copied := *task
+			tasks = append(tasks, &copied)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].RunAt.Before(tasks[j].RunAt)
+	})
+	return tasks, nil
+}
+
+// UpdateTaskStatus moves a task from one status to another
+func (s *MemoryTaskStore) UpdateTaskStatus(ctx context.Context, id string, from, to TaskStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	task, ok := s.tasks[id]
+	if !ok {
+		return fmt.Errorf("task not found: %s", id)
+	}
+	if task.Status != from {
+		return fmt.Errorf("%w: task %s is %s", ErrTaskStatusChanged, id, task.Status)
+	}
+	task.Status = to
+	task.UpdatedAt = time.Now()
+	return nil
+}
@@ -0,0 +1,138 @@
+package team
+
+import (
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// MemberMetrics holds timing and token usage for one member run
+type MemberMetrics struct {
+	Name         string        `json:"name"`
+	Duration     time.Duration `json:"duration"`
+	DurationMs   int64         `json:"duration_ms"`
+	InputTokens  int           `json:"input_tokens,omitempty"`
+	OutputTokens int           `json:"output_tokens,omitempty"`
+	TotalTokens  int           `json:"total_tokens,omitempty"`
+	Success      bool          `json:"success"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// TeamRunMetrics holds the metrics of the last team run
+type TeamRunMetrics struct {
+	Mode        TeamMode         `json:"mode"`
+	StartTime   time.Time        `json:"start_time"`
+	EndTime     time.Time        `json:"end_time"`
+	DurationMs  int64            `json:"duration_ms"`
+	TotalTokens int              `json:"total_tokens,omitempty"`
+	Success     bool             `json:"success"`
+	Members     []*MemberMetrics `json:"members,omitempty"`
+	Routing     *RoutingDecision `json:"routing,omitempty"` // Member chosen in RouteMode
+}
+
+// SlowestMember returns the member run that took the longest, or nil if no member ran
+func (m *TeamRunMetrics) SlowestMember() *MemberMetrics {
+	var slowest *MemberMetrics
+	for _, member := range m.Members {
+		if slowest == nil || member.Duration > slowest.Duration {
+			slowest = member
+		}
+	}
+	return slowest
+}
+
+// GetMetrics returns a copy of the metrics of the last run, or nil before the
+// first run. The copy is safe to read while the team runs again.
+func (t *Team) GetMetrics() *TeamRunMetrics {
+	t.metricsMu.RLock()
+	defer t.metricsMu.RUnlock()
+	if t.metrics == nil {
+		return nil
+	}
+	metrics := *t.metrics
+	metrics.Members = make([]*MemberMetrics, len(t.metrics.Members))
+	for i, member := range t.metrics.Members {
+		memberCopy := *member
+		metrics.Members[i] = &memberCopy
+	}
+	if t.metrics.Routing != nil {
+		routing := *t.metrics.Routing
+		metrics.Routing = &routing
+	}
+	return &metrics
+}
+
+// startMetrics begins collecting metrics for a new run
+func (t *Team) startMetrics() {
+	t.metricsMu.Lock()
+	defer t.metricsMu.Unlock()
+	t.metrics = &TeamRunMetrics{
+		Mode:      t.mode,
+		StartTime: time.Now(),
+	}
+}
+
+// finishMetrics records the end of the current run
+func (t *Team) finishMetrics(err error) {
+	t.metricsMu.Lock()
+	defer t.metricsMu.Unlock()
+	if t.metrics == nil {
+		return
+	}
+	t.metrics.EndTime = time.Now()
+	t.metrics.DurationMs = t.metrics.EndTime.Sub(t.metrics.StartTime).Milliseconds()
+	t.metrics.Success = err == nil
+}
+
+// runMember runs a member and records its duration, token usage and outcome.
+// Token counts are read from the input_tokens and output_tokens entries of the
+// member's RunResponse.Metrics, which agents fill from the usage their model
+// reports, also when the member run is streamed.
+func (t *Team) runMember(member TeamMember, prompt string, stream *teamStream) (models.RunResponse, error) {
+	if err := stream.stopped(); err != nil {
+		return models.RunResponse{}, err
+	}
+
+	start := time.Now()
+	resp, err := callMember(member, prompt, stream)
+	duration := time.Since(start)
+
+	memberMetrics := &MemberMetrics{
+		Name:         member.GetName(),
+		Duration:     duration,
+		DurationMs:   duration.Milliseconds(),
+		InputTokens:  metricInt(resp.Metrics, "input_tokens"),
+		OutputTokens: metricInt(resp.Metrics, "output_tokens"),
+		TotalTokens:  metricInt(resp.Metrics, "total_tokens"),
+		Success:      err == nil,
+	}
+	if memberMetrics.TotalTokens == 0 {
+		memberMetrics.TotalTokens = memberMetrics.InputTokens + memberMetrics.OutputTokens
+	}
+	if err != nil {
+		memberMetrics.Error = err.Error()
+	}
+
+	t.metricsMu.Lock()
+	if t.metrics != nil {
+		t.metrics.Members = append(t.metrics.Members, memberMetrics)
+		t.metrics.TotalTokens += memberMetrics.TotalTokens
+	}
+	t.metricsMu.Unlock()
+
+	return resp, err
+}
+
+// metricInt reads a numeric entry from a RunResponse metrics map
+func metricInt(metrics map[string]interface{}, key string) int {
+	switch v := metrics[key].(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(v)
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,94 @@
+package team
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+func TestTeamMetricsTokenUsage(t *testing.T) {
+	newMember := func(name string, usage models.Usage) *agent.Agent {
+		ag, err := agent.NewAgent(agent.AgentConfig{
+			Context: context.Background(),
+			Name:    name,
+			Model:   &replyModel{content: name + " answer", usage: usage},
+		})
+		if err != nil {
+			t.Fatalf("NewAgent failed: %v", err)
+		}
+		return ag
+	}
+	tm := NewTeam(TeamConfig{
+		Context: context.Background(),
+		Name:    "Newsroom",
+		Model:   &replyModel{content: "final article"},
+		Members: []*agent.Agent{
+			newMember("Researcher", models.Usage{InputTokens: 10, OutputTokens: 4}),
+			newMember("Writer", models.Usage{InputTokens: 20, OutputTokens: 8}),
+		},
+		Mode: CoordinateMode,
+	})
+
+	check := func(t *testing.T) {
+		metrics := tm.GetMetrics()
+		if metrics == nil || len(metrics.Members) != 2 {
+			t.Fatalf("expected metrics of both members, got %+v", metrics)
+		}
+		want := map[string][3]int{"Researcher": {10, 4, 14}, "Writer": {20, 8, 28}}
+		for _, member := range metrics.Members {
+			got := [3]int{member.InputTokens, member.OutputTokens, member.TotalTokens}
+			if got != want[member.Name] {
+				t.Errorf("%s: expected tokens %v, got %v", member.Name, want[member.Name], got)
+			}
+		}
+		if metrics.TotalTokens != 42 {
+			t.Errorf("expected 42 total tokens, got %d", metrics.TotalTokens)
+		}
+	}
+
+	t.Run("run", func(t *testing.T) {
+		if _, err := tm.Run("Write about Go"); err != nil {
+			t.Fatalf("Run failed: %v", err)
+		}
+		check(t)
+	})
+
+	t.Run("stream", func(t *testing.T) {
+		err := tm.RunStream("Write about Go", func(string, []byte) error { return nil })
+		if err != nil {
+			t.Fatalf("RunStream failed: %v", err)
+		}
+		check(t)
+	})
+
+	t.Run("metrics are a copy", func(t *testing.T) {
+		metrics := tm.GetMetrics()
+		metrics.TotalTokens = 0
+		metrics.Members[0].InputTokens = 0
+		metrics.Members = nil
+		check(t)
+	})
+
+	t.Run("metrics can be read during a run", func(t *testing.T) {
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			tm.Run("Write about Go")
+		}()
+		for {
+			select {
+			case <-done:
+				check(t)
+				return
+			default:
+				if metrics := tm.GetMetrics(); metrics != nil {
+					for _, member := range metrics.Members {
+						_ = member.TotalTokens
+					}
+				}
+			}
+		}
+	})
+}
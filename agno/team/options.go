@@ -0,0 +1,24 @@
+package team
+
+// TeamOption applies configuration to TeamConfig before creating a Team.
+type TeamOption func(*TeamConfig)
+
+// NewTeamWithOptions creates a Team after applying TeamOption functions.
+func NewTeamWithOptions(config TeamConfig, opts ...TeamOption) *Team {
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		opt(&config)
+	}
+	return NewTeam(config)
+}
+
+// WithSharedMemory makes the outputs of the members that already ran part of
+// the context of the next members in CoordinateMode, and records them in the
+// transcript returned by Team.GetTranscript.
+func WithSharedMemory(enabled bool) TeamOption {
+	return func(cfg *TeamConfig) {
+		cfg.SharedMemory = enabled
+	}
+}
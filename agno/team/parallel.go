@@ -0,0 +1,85 @@
+package team
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// MemberResponse is the answer of one member in ParallelMode
+type MemberResponse struct {
+	Name     string             `json:"name"`
+	Content  string             `json:"content,omitempty"`
+	Response models.RunResponse `json:"response"`
+	Error    string             `json:"error,omitempty"`
+}
+
+// runParallelMode runs every member on the same prompt concurrently, at most
+// maxConcurrency at a time, and returns all answers without synthesis. The
+// combined text lists each member's name and answer in member order, and
+// Output holds the []MemberResponse. A failing member does not stop the
+// others; the run fails only when every member fails.
+func (t *Team) runParallelMode(prompt string, stream *teamStream) (models.RunResponse, error) {
+	if len(t.members) == 0 {
+		return models.RunResponse{}, fmt.Errorf("team %s has no members", t.name)
+	}
+
+	limit := t.maxConcurrency
+	if limit <= 0 || limit > len(t.members) {
+		limit = len(t.members)
+	}
+
+	responses := make([]MemberResponse, len(t.members))
+	errs := make([]error, len(t.members))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i, member := range t.members {
+		wg.Add(1)
+		go func(i int, m TeamMember) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			resp, err := t.runMember(m, prompt, stream)
+			responses[i] = MemberResponse{Name: m.GetName(), Content: resp.TextContent, Response: resp}
+			if err != nil {
+				responses[i].Error = err.Error()
+				errs[i] = fmt.Errorf("%s: %w", m.GetName(), err)
+			}
+		}(i, member)
+	}
+	wg.Wait()
+
+	sections := make([]string, 0, len(responses))
+	failed := 0
+	for _, r := range responses {
+		if r.Error != "" {
+			failed++
+			sections = append(sections, fmt.Sprintf("**%s Error:**\n%s", r.Name, r.Error))
+			continue
+		}
+		sections = append(sections, fmt.Sprintf("**%s Response:**\n%s", r.Name, r.Content))
+	}
+	if failed == len(responses) {
+		return models.RunResponse{}, fmt.Errorf("all team members failed: %w", errors.Join(errs...))
+	}
+	content := strings.Join(sections, "\n\n---\n\n")
+
+	return models.RunResponse{
+		TextContent: content,
+		ContentType: "text",
+		Event:       "TeamParallelResponse",
+		Messages: []models.Message{
+			{
+				Role:    models.TypeAssistantRole,
+				Content: content,
+			},
+		},
+		Output:    responses,
+		CreatedAt: time.Now().Unix(),
+	}, nil
+}
@@ -0,0 +1,140 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// gaugeModel answers after a short delay and tracks how many calls overlap
+type gaugeModel struct {
+	replyModel
+	err   error
+	gauge *gauge
+}
+
+type gauge struct {
+	mu       sync.Mutex
+	inFlight int
+	max      int
+}
+
+func (g *gauge) enter() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.inFlight++
+	if g.inFlight > g.max {
+		g.max = g.inFlight
+	}
+}
+
+func (g *gauge) leave() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.inFlight--
+}
+
+func (m *gaugeModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	m.gauge.enter()
+	defer m.gauge.leave()
+	time.Sleep(20 * time.Millisecond)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.replyModel.Invoke(ctx, messages, options...)
+}
+
+func (m *gaugeModel) InvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) error {
+	return fmt.Errorf("not supported")
+}
+
+func TestParallelMode(t *testing.T) {
+	g := &gauge{}
+	var members []*agent.Agent
+	for i := 1; i <= 4; i++ {
+		model := &gaugeModel{replyModel: replyModel{content: fmt.Sprintf("answer %d", i)}, gauge: g}
+		if i == 2 {
+			model.err = errors.New("model unavailable")
+		}
+		ag, err := agent.NewAgent(agent.AgentConfig{
+			Context: context.Background(),
+			Name:    fmt.Sprintf("Member %d", i),
+			Model:   model,
+		})
+		if err != nil {
+			t.Fatalf("NewAgent failed: %v", err)
+		}
+		members = append(members, ag)
+	}
+
+	leader := &replyModel{content: "unused"}
+	tm := NewTeam(TeamConfig{
+		Context:        context.Background(),
+		Name:           "Panel",
+		Model:          leader,
+		Members:        members,
+		Mode:           ParallelMode,
+		MaxConcurrency: 2,
+	})
+
+	resp, err := tm.Run("Compare Go and Rust")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if leader.calls != 0 {
+		t.Errorf("expected no synthesis by the leader, got %d calls", leader.calls)
+	}
+	if g.max != 2 {
+		t.Errorf("expected at most 2 members at once, got %d", g.max)
+	}
+
+	responses, ok := resp.Output.([]MemberResponse)
+	if !ok || len(responses) != 4 {
+		t.Fatalf("expected 4 member responses, got %#v", resp.Output)
+	}
+	for i, r := range responses {
+		if r.Name != fmt.Sprintf("Member %d", i+1) {
+			t.Errorf("expected responses in member order, got %s at %d", r.Name, i)
+		}
+		if i == 1 {
+			if r.Error == "" || r.Content != "" {
+				t.Errorf("expected Member 2 to fail, got %+v", r)
+			}
+			continue
+		}
+		if r.Error != "" || r.Content != fmt.Sprintf("answer %d", i+1) {
+			t.Errorf("unexpected response: %+v", r)
+		}
+		if !strings.Contains(resp.TextContent, fmt.Sprintf("**Member %d Response:**\nanswer %d", i+1, i+1)) {
+			t.Errorf("combined text misses Member %d: %q", i+1, resp.TextContent)
+		}
+	}
+}
+
+func TestParallelModeAllMembersFail(t *testing.T) {
+	ag, err := agent.NewAgent(agent.AgentConfig{
+		Context: context.Background(),
+		Name:    "Broken",
+		Model:   &gaugeModel{err: errors.New("model unavailable"), gauge: &gauge{}},
+	})
+	if err != nil {
+		t.Fatalf("NewAgent failed: %v", err)
+	}
+
+	tm := NewTeam(TeamConfig{
+		Context: context.Background(),
+		Model:   &replyModel{},
+		Members: []*agent.Agent{ag},
+		Mode:    ParallelMode,
+	})
+	if _, err := tm.Run("hello"); err == nil || !strings.Contains(err.Error(), "Broken") {
+		t.Errorf("expected an error naming the failed member, got %v", err)
+	}
+}
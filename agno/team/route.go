@@ -0,0 +1,91 @@
+package team
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// RoutingDecision is the team leader's choice of member in RouteMode
+type RoutingDecision struct {
+	Member   string `json:"member,omitempty"` // Name of the member that handled the request, empty on fallback
+	Reason   string `json:"reason,omitempty"` // Explanation given by the team leader
+	Fallback bool   `json:"fallback"`         // True when no member matched and the team leader answered
+}
+
+// parseRoutingDecision reads the member chosen by the team leader. The leader
+// is asked for {"member": ..., "reason": ...}; when the reply is not JSON, the
+// member named first in it is used. A nil member means no member matched.
+func (t *Team) parseRoutingDecision(content string) (TeamMember, RoutingDecision) {
+	var reply struct {
+		Member string `json:"member"`
+		Reason string `json:"reason"`
+	}
+	start := strings.Index(content, "{")
+	end := strings.LastIndex(content, "}")
+	if start != -1 && end > start && json.Unmarshal([]byte(content[start:end+1]), &reply) == nil {
+		if member := t.findMember(reply.Member); member != nil {
+			return member, RoutingDecision{Member: member.GetName(), Reason: reply.Reason}
+		}
+		return nil, RoutingDecision{Reason: reply.Reason, Fallback: true}
+	}
+
+	// Free-form reply: pick the member mentioned first, preferring the longest
+	// name when several start at the same position
+	lower := strings.ToLower(content)
+	var chosen TeamMember
+	chosenAt := -1
+	for _, member := range t.members {
+		name := strings.ToLower(member.GetName())
+		if name == "" {
+			continue
+		}
+		at := strings.Index(lower, name)
+		if at == -1 {
+			continue
+		}
+		if chosen == nil || at < chosenAt || (at == chosenAt && len(name) > len(chosen.GetName())) {
+			chosen, chosenAt = member, at
+		}
+	}
+	reason := strings.TrimSpace(content)
+	if chosen == nil {
+		return nil, RoutingDecision{Reason: reason, Fallback: true}
+	}
+	return chosen, RoutingDecision{Member: chosen.GetName(), Reason: reason}
+}
+
+// findMember returns the member with the given name, ignoring case, or nil
+func (t *Team) findMember(name string) TeamMember {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil
+	}
+	for _, member := range t.members {
+		if strings.EqualFold(member.GetName(), name) {
+			return member
+		}
+	}
+	return nil
+}
+
+// recordRouting stores the routing decision in the metrics of the current run
+func (t *Team) recordRouting(decision RoutingDecision) {
+	t.metricsMu.Lock()
+	defer t.metricsMu.Unlock()
+	if t.metrics != nil {
+		t.metrics.Routing = &decision
+	}
+}
+
+// metrics returns the member's response metrics with the routing decision
+// added under routed_to, routing_reason and routing_fallback
+func (d RoutingDecision) metrics(memberMetrics map[string]interface{}) map[string]interface{} {
+	metrics := make(map[string]interface{}, len(memberMetrics)+3)
+	for k, v := range memberMetrics {
+		metrics[k] = v
+	}
+	metrics["routed_to"] = d.Member
+	metrics["routing_reason"] = d.Reason
+	metrics["routing_fallback"] = d.Fallback
+	return metrics
+}
@@ -0,0 +1,140 @@
+package team
+
+import (
+	"context"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// replyModel answers every call with the same content
+type replyModel struct {
+	content  string
+	calls    int
+	messages []models.Message // Messages of the last call
+	usage    models.Usage     // Reported for every call
+}
+
+func (m *replyModel) Invoke(ctx context.Context, messages []models.Message, options ...models.Option) (*models.MessageResponse, error) {
+	m.calls++
+	m.messages = messages
+	models.ReportUsage(ctx, m.usage)
+	return &models.MessageResponse{Role: models.TypeAssistantRole, Content: m.content, Model: "reply"}, nil
+}
+
+func (m *replyModel) AInvoke(ctx context.Context, messages []models.Message, options ...models.Option) (<-chan *models.MessageResponse, <-chan error) {
+	respCh := make(chan *models.MessageResponse, 1)
+	errCh := make(chan error, 1)
+	resp, _ := m.Invoke(ctx, messages, options...)
+	respCh <- resp
+	close(respCh)
+	close(errCh)
+	return respCh, errCh
+}
+
+func (m *replyModel) InvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) error {
+	m.calls++
+	m.messages = messages
+	models.ReportUsage(ctx, m.usage)
+	callOpts := models.DefaultCallOptions()
+	for _, opt := range options {
+		opt(callOpts)
+	}
+	if callOpts.StreamingFunc != nil {
+		return callOpts.StreamingFunc(ctx, []byte(m.content))
+	}
+	return nil
+}
+
+func (m *replyModel) AInvokeStream(ctx context.Context, messages []models.Message, options ...models.Option) (<-chan *models.MessageResponse, <-chan error) {
+	return m.AInvoke(ctx, messages, options...)
+}
+
+func (m *replyModel) GetID() string { return "reply" }
+
+func TestRouteMode(t *testing.T) {
+	newMember := func(name, role, answer string) (*agent.Agent, *replyModel) {
+		model := &replyModel{content: answer}
+		ag, err := agent.NewAgent(agent.AgentConfig{
+			Context: context.Background(),
+			Name:    name,
+			Role:    role,
+			Model:   model,
+		})
+		if err != nil {
+			t.Fatalf("NewAgent failed: %v", err)
+		}
+		return ag, model
+	}
+
+	tests := []struct {
+		name     string
+		decision string
+		want     string
+		routedTo string
+		fallback bool
+	}{
+		{
+			name:     "json decision",
+			decision: "```json\n{\"member\": \"Billing Agent\", \"reason\": \"invoice question\"}\n```",
+			want:     "billing answer",
+			routedTo: "Billing Agent",
+		},
+		{
+			name:     "free-form decision",
+			decision: "The support agent should handle this.",
+			want:     "support answer",
+			routedTo: "Support Agent",
+		},
+		{
+			name:     "no member matches",
+			decision: `{"member": "none", "reason": "off topic"}`,
+			want:     `{"member": "none", "reason": "off topic"}`,
+			fallback: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			billing, billingModel := newMember("Billing Agent", "Handles invoices and payments", "billing answer")
+			support, supportModel := newMember("Support Agent", "Troubleshoots technical problems", "support answer")
+			leader := &replyModel{content: tt.decision}
+
+			tm := NewTeam(TeamConfig{
+				Context: context.Background(),
+				Name:    "Help Desk",
+				Model:   leader,
+				Members: []*agent.Agent{billing, support},
+				Mode:    RouteMode,
+			})
+
+			resp, err := tm.Run("Why was I charged twice?")
+			if err != nil {
+				t.Fatalf("Run failed: %v", err)
+			}
+			if resp.TextContent != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, resp.TextContent)
+			}
+			if resp.Metrics["routed_to"] != tt.routedTo || resp.Metrics["routing_fallback"] != tt.fallback {
+				t.Errorf("unexpected routing metadata: %v", resp.Metrics)
+			}
+
+			// Only the chosen member runs
+			ran := map[string]int{"Billing Agent": billingModel.calls, "Support Agent": supportModel.calls}
+			for name, calls := range ran {
+				if (name == tt.routedTo) != (calls > 0) {
+					t.Errorf("member %s ran %d times", name, calls)
+				}
+			}
+			if tt.fallback && leader.calls != 2 {
+				t.Errorf("expected the leader to answer after routing, got %d calls", leader.calls)
+			}
+
+			routing := tm.GetMetrics().Routing
+			if routing == nil || routing.Member != tt.routedTo || routing.Fallback != tt.fallback {
+				t.Errorf("unexpected routing decision: %+v", routing)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package team
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+	"github.com/devalexandre/agno-golang/agno/models"
+)
+
+// teamStream forwards the chunks of a streamed team run to the caller, one
+// call at a time, and remembers the first error the caller returns so the rest
+// of the run is skipped. A nil teamStream means the run is not streamed.
+type teamStream struct {
+	mu  sync.Mutex
+	fn  func(memberName string, chunk []byte) error
+	err error
+}
+
+// send passes a chunk produced by the named member to the caller
+func (s *teamStream) send(memberName string, chunk []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return s.err
+	}
+	if err := s.fn(memberName, chunk); err != nil {
+		s.err = err
+	}
+	return s.err
+}
+
+// stopped returns the error the caller stopped the stream with, if any
+func (s *teamStream) stopped() error {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// RunStream executes a task using the team and streams the output as it is
+// produced, tagged with the name of the member that produced it: the output of
+// each member that runs, and the team leader's final answer, such as the
+// synthesis in coordinate mode, tagged with the team's name. Chunks of members
+// running concurrently are interleaved, but calls to fn are serialized.
+// Returning an error from fn stops the run and RunStream returns that error.
+func (t *Team) RunStream(prompt string, fn func(memberName string, chunk []byte) error) error {
+	stream := &teamStream{fn: fn}
+	_, err := t.run(prompt, stream)
+	if stopErr := stream.stopped(); stopErr != nil {
+		return stopErr
+	}
+	return err
+}
+
+// eventStreamer is a member that streams RunEvents, like *agent.Agent
+type eventStreamer interface {
+	RunStreamEvents(prompt string, fn func(agent.RunEvent) error) error
+}
+
+// callMember runs a member, streaming its output when stream is set
+func callMember(member TeamMember, prompt string, stream *teamStream) (models.RunResponse, error) {
+	if stream == nil {
+		return member.Run(prompt)
+	}
+
+	var content strings.Builder
+	name := member.GetName()
+	send := func(chunk []byte) error {
+		content.Write(chunk)
+		return stream.send(name, chunk)
+	}
+
+	// Members streaming events, like agents, report the metrics of the run
+	var metrics map[string]interface{}
+	var err error
+	if streamer, ok := member.(eventStreamer); ok {
+		err = streamer.RunStreamEvents(prompt, func(event agent.RunEvent) error {
+			switch event.Event {
+			case agent.RunEventContent:
+				return send([]byte(event.Content))
+			case agent.RunEventCompleted:
+				metrics = event.Metrics
+			}
+			return nil
+		})
+	} else {
+		err = member.RunStream(prompt, send)
+	}
+	return models.RunResponse{
+		TextContent: content.String(),
+		ContentType: "text",
+		Messages: []models.Message{
+			{
+				Role:    models.TypeAssistantRole,
+				Content: content.String(),
+			},
+		},
+		Metrics:   metrics,
+		CreatedAt: time.Now().Unix(),
+	}, err
+}
+
+// invokeLeader invokes the team leader model, streaming its answer under the
+// team's name when stream is set
+func (t *Team) invokeLeader(messages []models.Message, stream *teamStream) (*models.MessageResponse, error) {
+	if stream == nil {
+		return t.model.Invoke(t.ctx, messages)
+	}
+	if err := stream.stopped(); err != nil {
+		return nil, err
+	}
+
+	var content strings.Builder
+	err := t.model.InvokeStream(t.ctx, messages, models.WithStreamingFunc(func(ctx context.Context, chunk []byte) error {
+		content.Write(chunk)
+		return stream.send(t.name, chunk)
+	}))
+	if err != nil {
+		return nil, err
+	}
+	return &models.MessageResponse{
+		Role:    models.TypeAssistantRole,
+		Content: content.String(),
+		Model:   t.model.GetID(),
+	}, nil
+}
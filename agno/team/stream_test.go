@@ -0,0 +1,110 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+)
+
+func TestRunStream(t *testing.T) {
+	newMember := func(name, answer string) (*agent.Agent, *replyModel) {
+		model := &replyModel{content: answer}
+		ag, err := agent.NewAgent(agent.AgentConfig{
+			Context: context.Background(),
+			Name:    name,
+			Model:   model,
+		})
+		if err != nil {
+			t.Fatalf("NewAgent failed: %v", err)
+		}
+		return ag, model
+	}
+
+	type chunk struct{ member, text string }
+
+	t.Run("coordinate mode streams members and synthesis", func(t *testing.T) {
+		researcher, _ := newMember("Researcher", "facts")
+		writer, _ := newMember("Writer", "draft")
+		tm := NewTeam(TeamConfig{
+			Context: context.Background(),
+			Name:    "Newsroom",
+			Model:   &replyModel{content: "final article"},
+			Members: []*agent.Agent{researcher, writer},
+			Mode:    CoordinateMode,
+		})
+
+		var chunks []chunk
+		err := tm.RunStream("Write about Go", func(memberName string, data []byte) error {
+			chunks = append(chunks, chunk{memberName, string(data)})
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("RunStream failed: %v", err)
+		}
+
+		want := []chunk{{"Researcher", "facts"}, {"Writer", "draft"}, {"Newsroom", "final article"}}
+		if len(chunks) != len(want) {
+			t.Fatalf("expected %v, got %v", want, chunks)
+		}
+		for i := range want {
+			if chunks[i] != want[i] {
+				t.Errorf("chunk %d: expected %v, got %v", i, want[i], chunks[i])
+			}
+		}
+		if metrics := tm.GetMetrics(); metrics == nil || len(metrics.Members) != 2 || !metrics.Success {
+			t.Errorf("expected metrics of both members, got %+v", metrics)
+		}
+	})
+
+	t.Run("route mode streams the chosen member", func(t *testing.T) {
+		billing, _ := newMember("Billing", "refund issued")
+		support, _ := newMember("Support", "restart it")
+		tm := NewTeam(TeamConfig{
+			Context: context.Background(),
+			Name:    "Help Desk",
+			Model:   &replyModel{content: `{"member": "Billing", "reason": "refund"}`},
+			Members: []*agent.Agent{billing, support},
+			Mode:    RouteMode,
+		})
+
+		var chunks []chunk
+		err := tm.RunStream("I want a refund", func(memberName string, data []byte) error {
+			chunks = append(chunks, chunk{memberName, string(data)})
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("RunStream failed: %v", err)
+		}
+		if len(chunks) != 1 || chunks[0] != (chunk{"Billing", "refund issued"}) {
+			t.Errorf("expected only the billing answer, got %v", chunks)
+		}
+	})
+
+	t.Run("an error from fn stops the run", func(t *testing.T) {
+		researcher, _ := newMember("Researcher", "facts")
+		writer, writerModel := newMember("Writer", "draft")
+		leader := &replyModel{content: "final article"}
+		tm := NewTeam(TeamConfig{
+			Context: context.Background(),
+			Model:   leader,
+			Members: []*agent.Agent{researcher, writer},
+			Mode:    CoordinateMode,
+		})
+
+		errClosed := errors.New("client went away")
+		err := tm.RunStream("Write about Go", func(memberName string, data []byte) error {
+			return errClosed
+		})
+		if !errors.Is(err, errClosed) {
+			t.Fatalf("expected the callback error, got %v", err)
+		}
+		if writerModel.calls != 0 {
+			t.Errorf("expected the writer to be skipped, got %d calls", writerModel.calls)
+		}
+		if leader.calls != 1 {
+			t.Errorf("expected only the planning call to the leader, got %d", leader.calls)
+		}
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/devalexandre/agno-golang/agno/agent"
@@ -25,9 +26,13 @@ const (
 
 	// CollaborateMode: All members work on same task, leader synthesizes
 	CollaborateMode TeamMode = "collaborate"
+
+	// ParallelMode: All members answer the same prompt concurrently, responses are returned side by side
+	ParallelMode TeamMode = "parallel"
 )
 
-// TeamMember represents a member that can be either an Agent or another Team
+// TeamMember represents a member of a team, such as an Agent. RunStream
+// streams the member's output when the team run is streamed.
 type TeamMember interface {
 	GetName() string
 	GetRole() string
@@ -68,6 +73,11 @@ type TeamConfig struct {
 	Debug                bool
 	Stream               bool
 	Async                bool // Execute members concurrently when possible
+	MaxConcurrency       int  // Members run at once in ParallelMode (0 runs all members at once)
+
+	// SharedMemory passes the outputs of earlier members to the next ones in
+	// CoordinateMode and records them in the transcript (see WithSharedMemory)
+	SharedMemory bool
 }
 
 // Team represents a multi-agent system
@@ -103,9 +113,19 @@ type Team struct {
 	debug                bool
 	stream               bool
 	async                bool
+	maxConcurrency       int
+
+	// Shared memory
+	sharedMemory bool
+	transcriptMu sync.Mutex
+	transcript   []TranscriptEntry
 
 	// Session state
 	messages []models.Message
+
+	// Metrics of the last run
+	metricsMu sync.RWMutex
+	metrics   *TeamRunMetrics
 }
 
 // AgentWrapper wraps an agent to implement the TeamMember interface
@@ -121,6 +141,10 @@ func (aw *AgentWrapper) GetRole() string {
 	return aw.agent.GetRole()
 }
 
+func (aw *AgentWrapper) GetDescription() string {
+	return aw.agent.GetDescription()
+}
+
 func (aw *AgentWrapper) Run(prompt string) (models.RunResponse, error) {
 	return aw.agent.Run(prompt)
 }
@@ -129,6 +153,10 @@ func (aw *AgentWrapper) RunStream(prompt string, fn func([]byte) error) error {
 	return aw.agent.RunStream(prompt, fn)
 }
 
+func (aw *AgentWrapper) RunStreamEvents(prompt string, fn func(agent.RunEvent) error) error {
+	return aw.agent.RunStreamEvents(prompt, fn)
+}
+
 // NewTeam creates a new Team instance
 func NewTeam(config TeamConfig) *Team {
 	var members []TeamMember
@@ -179,6 +207,10 @@ func NewTeam(config TeamConfig) *Team {
 		debug:                config.Debug,
 		stream:               config.Stream,
 		async:                config.Async,
+		maxConcurrency:       config.MaxConcurrency,
+
+		// Shared memory
+		sharedMemory: config.SharedMemory,
 
 		// Initialize session state
 		messages: []models.Message{},
@@ -202,21 +234,50 @@ func (t *Team) GetRole() string {
 	return t.role
 }
 
+// GetDescription returns the team description
+func (t *Team) GetDescription() string {
+	return t.description
+}
+
+// GetModel returns the team leader model
+func (t *Team) GetModel() models.AgnoModelInterface {
+	return t.model
+}
+
+// GetStorage returns the team storage
+// GetMembers returns the team's members
+func (t *Team) GetMembers() []TeamMember {
+	return t.members
+}
+
+func (t *Team) GetStorage() storage.Storage {
+	return t.storage
+}
+
 // Run executes a task using the team
 func (t *Team) Run(prompt string) (models.RunResponse, error) {
+	return t.run(prompt, nil)
+}
+
+// run executes a task in the team's mode, streaming the output when stream is set
+func (t *Team) run(prompt string, stream *teamStream) (models.RunResponse, error) {
 	var response models.RunResponse
 	var err error
 
+	t.startMetrics()
 	switch t.mode {
 	case RouteMode:
-		response, err = t.runRouteMode(prompt)
+		response, err = t.runRouteMode(prompt, stream)
 	case CoordinateMode:
-		response, err = t.runCoordinateMode(prompt)
+		response, err = t.runCoordinateMode(prompt, stream)
 	case CollaborateMode:
-		response, err = t.runCollaborateMode(prompt)
+		response, err = t.runCollaborateMode(prompt, stream)
+	case ParallelMode:
+		response, err = t.runParallelMode(prompt, stream)
 	default:
-		response, err = t.runCoordinateMode(prompt) // Default to coordinate
+		response, err = t.runCoordinateMode(prompt, stream) // Default to coordinate
 	}
+	t.finishMetrics(err)
 
 	// Save to memory and/or storage if successful
 	if err == nil && (t.memory != nil || t.storage != nil) {
@@ -226,8 +287,10 @@ func (t *Team) Run(prompt string) (models.RunResponse, error) {
 	return response, err
 }
 
-// runRouteMode routes the request to the most appropriate team member
-func (t *Team) runRouteMode(prompt string) (models.RunResponse, error) {
+// runRouteMode routes the request to the single member best suited to handle
+// it and returns that member's response as is, without synthesis. When no
+// member fits, the team leader answers the request itself.
+func (t *Team) runRouteMode(prompt string, stream *teamStream) (models.RunResponse, error) {
 	// Step 1: Use team leader to decide which member should handle the request
 	routingPrompt := t.buildRoutingPrompt(prompt)
 
@@ -248,34 +311,79 @@ func (t *Team) runRouteMode(prompt string) (models.RunResponse, error) {
 		return models.RunResponse{}, err
 	}
 
-	// Step 2: Execute the selected member(s)
-	// For now, let's route to the first member (simplified implementation)
-	if len(t.members) > 0 {
-		memberResponse, err := t.members[0].Run(prompt)
-		if err != nil {
-			return models.RunResponse{}, err
+	member, decision := t.parseRoutingDecision(resp.Content)
+	t.recordRouting(decision)
+	if t.debug {
+		if member != nil {
+			fmt.Printf("Routing to %s: %s\n", decision.Member, decision.Reason)
+		} else {
+			fmt.Printf("No member matched, team leader answers: %s\n", decision.Reason)
 		}
+	}
 
-		return models.RunResponse{
-			TextContent: memberResponse.TextContent,
-			ContentType: memberResponse.ContentType,
-			Event:       "TeamRouteResponse",
-			Messages:    memberResponse.Messages,
-			Model:       resp.Model,
-			CreatedAt:   time.Now().Unix(),
-		}, nil
+	// Step 2: Execute the selected member, or answer as the coordinator
+	if member == nil {
+		return t.runRouteFallback(prompt, decision, stream)
+	}
+
+	memberResponse, err := t.runMember(member, prompt, stream)
+	if err != nil {
+		return models.RunResponse{}, err
 	}
 
 	return models.RunResponse{
-		TextContent: "No team members available",
-		ContentType: "text",
-		Event:       "TeamError",
+		TextContent: memberResponse.TextContent,
+		ContentType: memberResponse.ContentType,
+		Event:       "TeamRouteResponse",
+		Messages:    memberResponse.Messages,
+		Metrics:     decision.metrics(memberResponse.Metrics),
+		Model:       resp.Model,
+		AgentID:     memberResponse.AgentID,
+		Output:      memberResponse.Output,
 		CreatedAt:   time.Now().Unix(),
 	}, nil
 }
 
+// runRouteFallback answers a request no member was routed to with the team
+// leader model
+func (t *Team) runRouteFallback(prompt string, decision RoutingDecision, stream *teamStream) (models.RunResponse, error) {
+	messages := []models.Message{
+		{
+			Role: models.TypeSystemRole,
+			Content: fmt.Sprintf(`You are the leader of a team. None of the team members is suited to this request, so answer it yourself.
+
+Team Description: %s
+Team Instructions: %s`, t.description, strings.Join(t.instructions, "\n")),
+		},
+		{
+			Role:    models.TypeUserRole,
+			Content: prompt,
+		},
+	}
+
+	resp, err := t.invokeLeader(messages, stream)
+	if err != nil {
+		return models.RunResponse{}, err
+	}
+
+	return models.RunResponse{
+		TextContent: resp.Content,
+		ContentType: "text",
+		Event:       "TeamRouteResponse",
+		Messages: []models.Message{
+			{
+				Role:    models.Role(resp.Role),
+				Content: resp.Content,
+			},
+		},
+		Metrics:   decision.metrics(nil),
+		Model:     resp.Model,
+		CreatedAt: time.Now().Unix(),
+	}, nil
+}
+
 // runCoordinateMode delegates tasks to members and synthesizes their outputs
-func (t *Team) runCoordinateMode(prompt string) (models.RunResponse, error) {
+func (t *Team) runCoordinateMode(prompt string, stream *teamStream) (models.RunResponse, error) {
 	// Step 1: Plan the delegation
 	planPrompt := t.buildCoordinationPrompt(prompt)
 
@@ -299,8 +407,19 @@ func (t *Team) runCoordinateMode(prompt string) (models.RunResponse, error) {
 	// Step 2: Execute members (simplified - execute all for now)
 	memberResponses := []string{}
 
+	// With shared memory each member sees the outputs of the members before it
+	var shared []TranscriptEntry
+	if t.sharedMemory {
+		t.appendTranscript(TranscriptEntry{Role: string(models.TypeUserRole), Content: prompt})
+	}
+
 	for i, member := range t.members {
-		memberResp, err := member.Run(prompt)
+		memberPrompt := prompt
+		if t.sharedMemory {
+			memberPrompt = sharedPrompt(prompt, shared)
+		}
+
+		memberResp, err := t.runMember(member, memberPrompt, stream)
 		if err != nil {
 			if t.debug {
 				memberResponses = append(memberResponses, fmt.Sprintf("Member %d (%s) error: %v", i+1, member.GetName(), err))
@@ -308,6 +427,12 @@ func (t *Team) runCoordinateMode(prompt string) (models.RunResponse, error) {
 			continue
 		}
 
+		if t.sharedMemory {
+			entry := TranscriptEntry{Member: member.GetName(), Role: member.GetRole(), Content: memberResp.TextContent}
+			shared = append(shared, entry)
+			t.appendTranscript(entry)
+		}
+
 		if t.showMembersResponses {
 			memberResponses = append(memberResponses, fmt.Sprintf("**%s Response:**\n%s", member.GetName(), memberResp.TextContent))
 		} else {
@@ -329,7 +454,7 @@ func (t *Team) runCoordinateMode(prompt string) (models.RunResponse, error) {
 		},
 	}
 
-	finalResp, err := t.model.Invoke(t.ctx, synthesisMessages)
+	finalResp, err := t.invokeLeader(synthesisMessages, stream)
 	if err != nil {
 		return models.RunResponse{}, err
 	}
@@ -350,18 +475,18 @@ func (t *Team) runCoordinateMode(prompt string) (models.RunResponse, error) {
 }
 
 // runCollaborateMode gives all members the same task and synthesizes their outputs
-func (t *Team) runCollaborateMode(prompt string) (models.RunResponse, error) {
+func (t *Team) runCollaborateMode(prompt string, stream *teamStream) (models.RunResponse, error) {
 	// Step 1: Execute all members with the same task
 	memberResponses := []string{}
 
 	// Execute members concurrently if async is enabled
 	if t.async {
-		return t.runCollaborateModeAsync(prompt)
+		return t.runCollaborateModeAsync(prompt, stream)
 	}
 
 	// Sequential execution
 	for i, member := range t.members {
-		memberResp, err := member.Run(prompt)
+		memberResp, err := t.runMember(member, prompt, stream)
 		if err != nil {
 			if t.debug {
 				memberResponses = append(memberResponses, fmt.Sprintf("Member %d (%s) error: %v", i+1, member.GetName(), err))
@@ -382,13 +507,13 @@ func (t *Team) runCollaborateMode(prompt string) (models.RunResponse, error) {
 	var finalContent string
 	if hasConflicts {
 		// Resolve conflicts before synthesis
-		resolvedContent, err := t.resolveConflicts(prompt, memberResponses, conflictAnalysis)
+		resolvedContent, err := t.resolveConflicts(prompt, memberResponses, conflictAnalysis, stream)
 		if err != nil {
 			if t.debug {
 				fmt.Printf("Conflict resolution failed, proceeding with standard synthesis: %v\n", err)
 			}
 			// Fall back to standard synthesis
-			finalContent, err = t.synthesizeResponses(prompt, memberResponses, t.buildCollaborationSynthesisPrompt(prompt))
+			finalContent, err = t.synthesizeResponses(prompt, memberResponses, t.buildCollaborationSynthesisPrompt(prompt), stream)
 			if err != nil {
 				return models.RunResponse{}, err
 			}
@@ -398,7 +523,7 @@ func (t *Team) runCollaborateMode(prompt string) (models.RunResponse, error) {
 	} else {
 		// No conflicts, proceed with standard synthesis
 		var err error
-		finalContent, err = t.synthesizeResponses(prompt, memberResponses, t.buildCollaborationSynthesisPrompt(prompt))
+		finalContent, err = t.synthesizeResponses(prompt, memberResponses, t.buildCollaborationSynthesisPrompt(prompt), stream)
 		if err != nil {
 			return models.RunResponse{}, err
 		}
@@ -419,36 +544,6 @@ func (t *Team) runCollaborateMode(prompt string) (models.RunResponse, error) {
 	}, nil
 }
 
-// RunStream executes a task using the team with streaming response
-func (t *Team) RunStream(prompt string, fn func([]byte) error) error {
-	// For now, convert to regular Run and stream the final result
-	response, err := t.Run(prompt)
-	if err != nil {
-		return err
-	}
-
-	// Stream the response content in chunks
-	content := response.TextContent
-	chunkSize := 50 // characters per chunk
-
-	for i := 0; i < len(content); i += chunkSize {
-		end := i + chunkSize
-		if end > len(content) {
-			end = len(content)
-		}
-
-		chunk := content[i:end]
-		if err := fn([]byte(chunk)); err != nil {
-			return err
-		}
-
-		// Small delay to simulate streaming
-		time.Sleep(10 * time.Millisecond)
-	}
-
-	return nil
-}
-
 // Helper methods for building prompts
 
 // buildRoutingPrompt creates a prompt for routing decisions
@@ -456,6 +551,9 @@ func (t *Team) buildRoutingPrompt(prompt string) string {
 	membersInfo := ""
 	for i, member := range t.members {
 		membersInfo += fmt.Sprintf("%d. %s - %s\n", i+1, member.GetName(), member.GetRole())
+		if d, ok := member.(interface{ GetDescription() string }); ok && d.GetDescription() != "" {
+			membersInfo += fmt.Sprintf("   %s\n", d.GetDescription())
+		}
 	}
 
 	routingPrompt := fmt.Sprintf(`You are a team leader responsible for routing user requests to the most appropriate team member.
@@ -470,7 +568,9 @@ Instructions:
 - Consider each team member's role and expertise
 - Route the request to the member who can best address the user's needs
 - If multiple members could help, choose the most specialized one
-- Respond with the member's name and a brief explanation of why they were chosen
+- If no member is suited to the request, use "none" as the member
+- Respond only with a JSON object with the member's exact name and a brief explanation of why they were chosen:
+  {"member": "<member name>", "reason": "<why>"}
 
 Team Description: %s
 Team Instructions: %s`, membersInfo, t.description, strings.Join(t.instructions, "\n"))
@@ -558,7 +658,7 @@ Guidelines:
 }
 
 // runCollaborateModeAsync executes collaboration mode with concurrent member execution
-func (t *Team) runCollaborateModeAsync(prompt string) (models.RunResponse, error) {
+func (t *Team) runCollaborateModeAsync(prompt string, stream *teamStream) (models.RunResponse, error) {
 	// Channel to collect member responses
 	type memberResult struct {
 		response string
@@ -571,7 +671,7 @@ func (t *Team) runCollaborateModeAsync(prompt string) (models.RunResponse, error
 	// Execute all members concurrently
 	for _, member := range t.members {
 		go func(m TeamMember) {
-			resp, err := m.Run(prompt)
+			resp, err := t.runMember(m, prompt, stream)
 			if err != nil {
 				results <- memberResult{err: err, name: m.GetName()}
 				return
@@ -618,7 +718,7 @@ func (t *Team) runCollaborateModeAsync(prompt string) (models.RunResponse, error
 		},
 	}
 
-	finalResp, err := t.model.Invoke(t.ctx, synthesisMessages)
+	finalResp, err := t.invokeLeader(synthesisMessages, stream)
 	if err != nil {
 		return models.RunResponse{}, err
 	}
@@ -736,7 +836,7 @@ If no significant conflicts exist, respond with "NO CONFLICTS".`, strings.Join(r
 }
 
 // resolveConflicts uses AI to resolve conflicts between member responses
-func (t *Team) resolveConflicts(prompt string, responses []string, conflictAnalysis string) (string, error) {
+func (t *Team) resolveConflicts(prompt string, responses []string, conflictAnalysis string, stream *teamStream) (string, error) {
 	resolutionPrompt := fmt.Sprintf(`You are a conflict resolution specialist for a team of AI agents.
 
 Original Request: %s
@@ -770,7 +870,7 @@ Provide a clear, unified response that addresses the original request while reso
 		},
 	}
 
-	resp, err := t.model.Invoke(t.ctx, messages)
+	resp, err := t.invokeLeader(messages, stream)
 	if err != nil {
 		return "", fmt.Errorf("conflict resolution failed: %w", err)
 	}
@@ -779,7 +879,7 @@ Provide a clear, unified response that addresses the original request while reso
 }
 
 // synthesizeResponses performs standard synthesis without conflict resolution
-func (t *Team) synthesizeResponses(prompt string, responses []string, synthesisPrompt string) (string, error) {
+func (t *Team) synthesizeResponses(prompt string, responses []string, synthesisPrompt string, stream *teamStream) (string, error) {
 	synthesisMessages := []models.Message{
 		{
 			Role:    models.TypeSystemRole,
@@ -791,7 +891,7 @@ func (t *Team) synthesizeResponses(prompt string, responses []string, synthesisP
 		},
 	}
 
-	finalResp, err := t.model.Invoke(t.ctx, synthesisMessages)
+	finalResp, err := t.invokeLeader(synthesisMessages, stream)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,49 @@
+package team
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// TranscriptEntry is one message of the shared team transcript: the task given
+// to the team or the output of a member
+type TranscriptEntry struct {
+	Member    string    `json:"member,omitempty"` // Member name, empty for the task
+	Role      string    `json:"role"`             // "user" for the task, otherwise the member's role
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// GetTranscript returns a copy of the shared transcript of all runs so far.
+// It is only recorded when SharedMemory is enabled.
+func (t *Team) GetTranscript() []TranscriptEntry {
+	t.transcriptMu.Lock()
+	defer t.transcriptMu.Unlock()
+	return append([]TranscriptEntry(nil), t.transcript...)
+}
+
+// appendTranscript adds an entry to the shared transcript
+func (t *Team) appendTranscript(entry TranscriptEntry) {
+	entry.CreatedAt = time.Now()
+	t.transcriptMu.Lock()
+	defer t.transcriptMu.Unlock()
+	t.transcript = append(t.transcript, entry)
+}
+
+// sharedPrompt adds the outputs of the members that already worked on the
+// task to the prompt of the next member
+func sharedPrompt(prompt string, outputs []TranscriptEntry) string {
+	if len(outputs) == 0 {
+		return prompt
+	}
+
+	var b strings.Builder
+	b.WriteString(prompt)
+	b.WriteString("\n\n<team_context>\nOutputs of the team members who worked on this task before you:\n")
+	for _, out := range outputs {
+		fmt.Fprintf(&b, "\n**%s (%s):**\n%s\n", out.Member, out.Role, out.Content)
+	}
+	b.WriteString("</team_context>")
+	return b.String()
+}
@@ -0,0 +1,90 @@
+package team
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/agent"
+)
+
+func TestSharedMemory(t *testing.T) {
+	newMember := func(name, role, answer string) (*agent.Agent, *replyModel) {
+		model := &replyModel{content: answer}
+		ag, err := agent.NewAgent(agent.AgentConfig{
+			Context: context.Background(),
+			Name:    name,
+			Role:    role,
+			Model:   model,
+		})
+		if err != nil {
+			t.Fatalf("NewAgent failed: %v", err)
+		}
+		return ag, model
+	}
+	lastUserMessage := func(m *replyModel) string {
+		if len(m.messages) == 0 {
+			return ""
+		}
+		return m.messages[len(m.messages)-1].Content
+	}
+
+	researcher, researcherModel := newMember("Researcher", "researcher", "Go has goroutines")
+	writer, writerModel := newMember("Writer", "writer", "Draft about goroutines")
+	editor, editorModel := newMember("Editor", "editor", "Final article")
+
+	tm := NewTeamWithOptions(TeamConfig{
+		Context: context.Background(),
+		Model:   &replyModel{content: "summary"},
+		Members: []*agent.Agent{researcher, writer, editor},
+		Mode:    CoordinateMode,
+	}, WithSharedMemory(true))
+
+	if _, err := tm.Run("Write about Go concurrency"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+
+	if got := lastUserMessage(researcherModel); strings.Contains(got, "<team_context>") {
+		t.Errorf("first member should get the plain prompt, got %q", got)
+	}
+	if got := lastUserMessage(writerModel); !strings.Contains(got, "**Researcher (researcher):**\nGo has goroutines") {
+		t.Errorf("writer did not receive the research, got %q", got)
+	}
+	got := lastUserMessage(editorModel)
+	if !strings.Contains(got, "Go has goroutines") || !strings.Contains(got, "Draft about goroutines") {
+		t.Errorf("editor did not receive both earlier outputs, got %q", got)
+	}
+
+	transcript := tm.GetTranscript()
+	want := []TranscriptEntry{
+		{Role: "user", Content: "Write about Go concurrency"},
+		{Member: "Researcher", Role: "researcher", Content: "Go has goroutines"},
+		{Member: "Writer", Role: "writer", Content: "Draft about goroutines"},
+		{Member: "Editor", Role: "editor", Content: "Final article"},
+	}
+	if len(transcript) != len(want) {
+		t.Fatalf("expected %d transcript entries, got %+v", len(want), transcript)
+	}
+	for i, entry := range transcript {
+		if entry.Member != want[i].Member || entry.Role != want[i].Role || entry.Content != want[i].Content || entry.CreatedAt.IsZero() {
+			t.Errorf("entry %d: expected %+v, got %+v", i, want[i], entry)
+		}
+	}
+
+	// Without shared memory members run in isolation
+	isolated := NewTeam(TeamConfig{
+		Context: context.Background(),
+		Model:   &replyModel{content: "summary"},
+		Members: []*agent.Agent{researcher, writer},
+		Mode:    CoordinateMode,
+	})
+	if _, err := isolated.Run("Write about Go concurrency"); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if got := lastUserMessage(writerModel); strings.Contains(got, "<team_context>") {
+		t.Errorf("writer should not see other outputs without shared memory, got %q", got)
+	}
+	if len(isolated.GetTranscript()) != 0 {
+		t.Errorf("expected no transcript without shared memory")
+	}
+}
@@ -3,23 +3,22 @@ package tools
 import (
 	"encoding/json"
 	"fmt"
-	"go/ast"
-	"go/parser"
-	"go/token"
 	"math"
 	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
 )
 
-// CalculatorTool provides simple mathematical expression evaluation.
+// CalculatorTool provides mathematical expression evaluation.
 type CalculatorTool struct {
 	toolkit.Toolkit
 }
 
-// CalculatorParams defines the parameters for the Calculate method.
+// CalculatorParams defines the parameters for the Evaluate method.
 type CalculatorParams struct {
-	Expression string `json:"expression" description:"A mathematical expression to evaluate (e.g., '2 + 3 * 4', '(10 - 2) / 4')." required:"true"`
+	Expression string `json:"expression" description:"A mathematical expression to evaluate (e.g., '2 + 3 * 4', '(10 - 2) / 4', '2^10', 'sqrt(16) + log(e)')." required:"true"`
 }
 
 // NewCalculatorTool creates a new Calculator tool.
@@ -28,17 +27,20 @@ func NewCalculatorTool() *CalculatorTool {
 
 	tk := toolkit.NewToolkit()
 	tk.Name = "CalculatorTool"
-	tk.Description = "Evaluate mathematical expressions. Supports +, -, *, /, parentheses."
+	tk.Description = "Evaluate mathematical expressions exactly. Use it for any arithmetic instead of computing by hand. " +
+		"Supports + - * / %, ^ or ** for power, parentheses, the constants pi and e, and the functions " +
+		"sqrt, cbrt, abs, exp, log (natural), ln, log10, log2, sin, cos, tan, asin, acos, atan, atan2, " +
+		"sinh, cosh, tanh, floor, ceil, round, trunc, pow, hypot, min and max. Trigonometric functions use radians."
 
 	t.Toolkit = tk
-	t.Toolkit.Register("Calculate", "Evaluate a mathematical expression and return the result.", t, t.Calculate, CalculatorParams{})
+	t.Toolkit.Register("Evaluate", "Evaluate a mathematical expression and return the result.", t, t.Evaluate, CalculatorParams{})
 
 	return t
 }
 
-// Calculate evaluates a mathematical expression.
-func (t *CalculatorTool) Calculate(params CalculatorParams) (interface{}, error) {
-	if params.Expression == "" {
+// Evaluate evaluates a mathematical expression.
+func (t *CalculatorTool) Evaluate(params CalculatorParams) (interface{}, error) {
+	if strings.TrimSpace(params.Expression) == "" {
 		return nil, fmt.Errorf("expression is required")
 	}
 
@@ -53,73 +55,333 @@ func (t *CalculatorTool) Calculate(params CalculatorParams) (interface{}, error)
 	}, nil
 }
 
-// evalExpr evaluates a simple mathematical expression using Go's AST parser.
+// Calculate evaluates a mathematical expression.
+//
+// Deprecated: use Evaluate.
+func (t *CalculatorTool) Calculate(params CalculatorParams) (interface{}, error) {
+	return t.Evaluate(params)
+}
+
+// Execute implements the toolkit.Tool interface.
+func (t *CalculatorTool) Execute(methodName string, input json.RawMessage) (interface{}, error) {
+	return t.Toolkit.Execute(methodName, input)
+}
+
+// calcConstants are the named values an expression may use
+var calcConstants = map[string]float64{
+	"pi": math.Pi,
+	"e":  math.E,
+}
+
+// calcFunc is a function an expression may call. arity -1 accepts one or
+// more arguments.
+type calcFunc struct {
+	arity int
+	fn    func(args []float64) float64
+}
+
+func unaryCalcFunc(fn func(float64) float64) calcFunc {
+	return calcFunc{arity: 1, fn: func(args []float64) float64 { return fn(args[0]) }}
+}
+
+func binaryCalcFunc(fn func(float64, float64) float64) calcFunc {
+	return calcFunc{arity: 2, fn: func(args []float64) float64 { return fn(args[0], args[1]) }}
+}
+
+var calcFunctions = map[string]calcFunc{
+	"sqrt":  unaryCalcFunc(math.Sqrt),
+	"cbrt":  unaryCalcFunc(math.Cbrt),
+	"abs":   unaryCalcFunc(math.Abs),
+	"exp":   unaryCalcFunc(math.Exp),
+	"log":   unaryCalcFunc(math.Log),
+	"ln":    unaryCalcFunc(math.Log),
+	"log10": unaryCalcFunc(math.Log10),
+	"log2":  unaryCalcFunc(math.Log2),
+	"sin":   unaryCalcFunc(math.Sin),
+	"cos":   unaryCalcFunc(math.Cos),
+	"tan":   unaryCalcFunc(math.Tan),
+	"asin":  unaryCalcFunc(math.Asin),
+	"acos":  unaryCalcFunc(math.Acos),
+	"atan":  unaryCalcFunc(math.Atan),
+	"sinh":  unaryCalcFunc(math.Sinh),
+	"cosh":  unaryCalcFunc(math.Cosh),
+	"tanh":  unaryCalcFunc(math.Tanh),
+	"floor": unaryCalcFunc(math.Floor),
+	"ceil":  unaryCalcFunc(math.Ceil),
+	"round": unaryCalcFunc(math.Round),
+	"trunc": unaryCalcFunc(math.Trunc),
+	"atan2": binaryCalcFunc(math.Atan2),
+	"pow":   binaryCalcFunc(math.Pow),
+	"hypot": binaryCalcFunc(math.Hypot),
+	"min": {arity: -1, fn: func(args []float64) float64 {
+		result := args[0]
+		for _, v := range args[1:] {
+			result = math.Min(result, v)
+		}
+		return result
+	}},
+	"max": {arity: -1, fn: func(args []float64) float64 {
+		result := args[0]
+		for _, v := range args[1:] {
+			result = math.Max(result, v)
+		}
+		return result
+	}},
+}
+
+// calcParser is a recursive descent parser that evaluates while parsing:
+//
+//	expr    = term { ("+" | "-") term }
+//	term    = unary { ("*" | "/" | "%") unary }
+//	unary   = ("+" | "-") unary | power
+//	power   = primary [ ("^" | "**") unary ]
+//	primary = number | name | name "(" expr { "," expr } ")" | "(" expr ")"
+//
+// Power binds tighter than unary minus and is right associative, so -2^2 is
+// -4 and 2^3^2 is 512.
+type calcParser struct {
+	input string
+	pos   int
+}
+
+// evalExpr evaluates a mathematical expression.
 func evalExpr(expr string) (float64, error) {
-	node, err := parser.ParseExpr(expr)
+	p := &calcParser{input: expr}
+	result, err := p.parseExpr()
 	if err != nil {
-		return 0, fmt.Errorf("invalid expression: %w", err)
+		return 0, err
+	}
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return 0, fmt.Errorf("unexpected %q at position %d", p.input[p.pos:], p.pos+1)
+	}
+	if math.IsNaN(result) {
+		return 0, fmt.Errorf("result is not a number")
+	}
+	if math.IsInf(result, 0) {
+		return 0, fmt.Errorf("result is infinite")
+	}
+	return result, nil
+}
+
+func (p *calcParser) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// accept consumes op if it is next in the input
+func (p *calcParser) accept(op string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.input[p.pos:], op) {
+		p.pos += len(op)
+		return true
+	}
+	return false
+}
+
+func (p *calcParser) parseExpr() (float64, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		switch {
+		case p.accept("+"):
+			right, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			left += right
+		case p.accept("-"):
+			right, err := p.parseTerm()
+			if err != nil {
+				return 0, err
+			}
+			left -= right
+		default:
+			return left, nil
+		}
 	}
-	return evalNode(node)
 }
 
-func evalNode(node ast.Expr) (float64, error) {
-	switch n := node.(type) {
-	case *ast.BasicLit:
-		if n.Kind == token.INT || n.Kind == token.FLOAT {
-			return strconv.ParseFloat(n.Value, 64)
+func (p *calcParser) parseTerm() (float64, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		p.skipSpaces()
+		// "**" is power, not multiplication
+		if strings.HasPrefix(p.input[p.pos:], "**") {
+			return left, nil
 		}
-		return 0, fmt.Errorf("unsupported literal: %s", n.Value)
 
-	case *ast.BinaryExpr:
-		left, err := evalNode(n.X)
-		if err != nil {
-			return 0, err
+		var op string
+		switch {
+		case p.accept("*"):
+			op = "*"
+		case p.accept("/"):
+			op = "/"
+		case p.accept("%"):
+			op = "%"
+		default:
+			return left, nil
 		}
-		right, err := evalNode(n.Y)
+
+		right, err := p.parseUnary()
 		if err != nil {
 			return 0, err
 		}
-
-		switch n.Op {
-		case token.ADD:
-			return left + right, nil
-		case token.SUB:
-			return left - right, nil
-		case token.MUL:
-			return left * right, nil
-		case token.QUO:
+		switch op {
+		case "*":
+			left *= right
+		case "/":
 			if right == 0 {
 				return 0, fmt.Errorf("division by zero")
 			}
-			return left / right, nil
-		case token.REM:
+			left /= right
+		case "%":
 			if right == 0 {
 				return 0, fmt.Errorf("division by zero")
 			}
-			return math.Mod(left, right), nil
-		default:
-			return 0, fmt.Errorf("unsupported operator: %s", n.Op)
+			left = math.Mod(left, right)
+		}
+	}
+}
+
+func (p *calcParser) parseUnary() (float64, error) {
+	switch {
+	case p.accept("-"):
+		val, err := p.parseUnary()
+		return -val, err
+	case p.accept("+"):
+		return p.parseUnary()
+	default:
+		return p.parsePower()
+	}
+}
+
+func (p *calcParser) parsePower() (float64, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return 0, err
+	}
+	if p.accept("^") || p.accept("**") {
+		exp, err := p.parseUnary()
+		if err != nil {
+			return 0, err
 		}
+		return math.Pow(base, exp), nil
+	}
+	return base, nil
+}
 
-	case *ast.ParenExpr:
-		return evalNode(n.X)
+func (p *calcParser) parsePrimary() (float64, error) {
+	p.skipSpaces()
+	if p.pos >= len(p.input) {
+		return 0, fmt.Errorf("unexpected end of expression")
+	}
 
-	case *ast.UnaryExpr:
-		val, err := evalNode(n.X)
+	c := p.input[p.pos]
+	switch {
+	case c == '(':
+		p.pos++
+		val, err := p.parseExpr()
 		if err != nil {
 			return 0, err
 		}
-		if n.Op == token.SUB {
-			return -val, nil
+		if !p.accept(")") {
+			return 0, fmt.Errorf("missing closing parenthesis at position %d", p.pos+1)
 		}
 		return val, nil
 
+	case c >= '0' && c <= '9' || c == '.':
+		return p.parseNumber()
+
+	case unicode.IsLetter(rune(c)) || c == '_':
+		start := p.pos
+		for p.pos < len(p.input) && (unicode.IsLetter(rune(p.input[p.pos])) || unicode.IsDigit(rune(p.input[p.pos])) || p.input[p.pos] == '_') {
+			p.pos++
+		}
+		name := strings.ToLower(p.input[start:p.pos])
+
+		if p.accept("(") {
+			return p.parseCall(name)
+		}
+		if val, ok := calcConstants[name]; ok {
+			return val, nil
+		}
+		if _, ok := calcFunctions[name]; ok {
+			return 0, fmt.Errorf("function %s must be called with parentheses", name)
+		}
+		return 0, fmt.Errorf("unknown name %q", name)
+
 	default:
-		return 0, fmt.Errorf("unsupported expression type: %T", node)
+		return 0, fmt.Errorf("unexpected %q at position %d", string(c), p.pos+1)
 	}
 }
 
-// Execute implements the toolkit.Tool interface.
-func (t *CalculatorTool) Execute(methodName string, input json.RawMessage) (interface{}, error) {
-	return t.Toolkit.Execute(methodName, input)
+func (p *calcParser) parseNumber() (float64, error) {
+	start := p.pos
+	for p.pos < len(p.input) && (p.input[p.pos] >= '0' && p.input[p.pos] <= '9' || p.input[p.pos] == '.') {
+		p.pos++
+	}
+	// Exponent: 1e3, 2.5E-4
+	if p.pos < len(p.input) && (p.input[p.pos] == 'e' || p.input[p.pos] == 'E') {
+		end := p.pos + 1
+		if end < len(p.input) && (p.input[end] == '+' || p.input[end] == '-') {
+			end++
+		}
+		if end < len(p.input) && p.input[end] >= '0' && p.input[end] <= '9' {
+			for end < len(p.input) && p.input[end] >= '0' && p.input[end] <= '9' {
+				end++
+			}
+			p.pos = end
+		}
+	}
+
+	val, err := strconv.ParseFloat(p.input[start:p.pos], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", p.input[start:p.pos])
+	}
+	return val, nil
+}
+
+func (p *calcParser) parseCall(name string) (float64, error) {
+	f, ok := calcFunctions[name]
+	if !ok {
+		return 0, fmt.Errorf("unknown function %q", name)
+	}
+
+	var args []float64
+	if !p.accept(")") {
+		for {
+			arg, err := p.parseExpr()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, arg)
+			if p.accept(",") {
+				continue
+			}
+			if !p.accept(")") {
+				return 0, fmt.Errorf("missing closing parenthesis for %s at position %d", name, p.pos+1)
+			}
+			break
+		}
+	}
+
+	switch {
+	case f.arity < 0 && len(args) == 0:
+		return 0, fmt.Errorf("%s needs at least one argument", name)
+	case f.arity >= 0 && len(args) != f.arity:
+		return 0, fmt.Errorf("%s takes %d argument(s), got %d", name, f.arity, len(args))
+	}
+
+	result := f.fn(args)
+	if math.IsNaN(result) {
+		return 0, fmt.Errorf("%s is undefined for the given arguments", name)
+	}
+	return result, nil
 }
@@ -0,0 +1,75 @@
+package tools
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestCalculatorToolEvaluate(t *testing.T) {
+	tests := map[string]float64{
+		"2 + 3 * 4":                14,
+		"(10 - 2) / 4":             2,
+		"7 % 3":                    1,
+		"2^10":                     1024,
+		"2 ** 3 ** 2":              512,
+		"-2^2":                     -4,
+		"2^-1":                     0.5,
+		"2 * -3":                   -6,
+		"sqrt(16) + abs(-2)":       6,
+		"log(e)":                   1,
+		"log10(1000)":              3,
+		"sin(pi / 2)":              1,
+		"max(1, 5, 3) - min(4, 2)": 3,
+		"1.5e3 + .5":               1500.5,
+		"pow(2, 0.5)^2":            2,
+	}
+	for expr, want := range tests {
+		got, err := evalExpr(expr)
+		if err != nil {
+			t.Errorf("%s: %v", expr, err)
+			continue
+		}
+		if math.Abs(got-want) > 1e-9 {
+			t.Errorf("%s = %v, want %v", expr, got, want)
+		}
+	}
+}
+
+func TestCalculatorToolRejectsMalformedInput(t *testing.T) {
+	for _, expr := range []string{
+		"",
+		"2 +",
+		"(1 + 2",
+		"1 + 2)",
+		"2 3",
+		"1.2.3",
+		"sqrt",
+		"sqrt(-1)",
+		"foo(1)",
+		"x + 1",
+		"pow(2)",
+		"1 / 0",
+		"10 ^ 400",
+		"os.Exit(1)",
+	} {
+		if _, err := evalExpr(expr); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}
+
+func TestCalculatorToolExecute(t *testing.T) {
+	tool := NewCalculatorTool()
+	out, err := tool.Execute("CalculatorTool_Evaluate", json.RawMessage(`{"expression": "3 * (4 + 5)"}`))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+	if result := out.(map[string]interface{})["result"]; result != 27.0 {
+		t.Errorf("expected 27, got %v", result)
+	}
+
+	if _, err := tool.Execute("CalculatorTool_Evaluate", json.RawMessage(`{"expression": "3 *"}`)); err == nil {
+		t.Error("expected an error for a malformed expression")
+	}
+}
@@ -12,18 +12,22 @@ import (
 // DatabaseConfig holds configuration for database operations
 type DatabaseConfig struct {
 	Type         string // postgres, mysql, sqlite3
-	ReadOnly     bool   // If true, only SELECT queries are allowed
+	ReadOnly     bool   // If true, only single SELECT statements are allowed, run in read-only transactions
 	MaxRows      int    // Maximum number of rows to return (default: 1000)
 	MaxOpenConns int    // Maximum number of open connections (default: 10)
 	MaxIdleConns int    // Maximum number of idle connections (default: 5)
+	// AllowedTables restricts every query, including SELECTs, to these
+	// tables. Empty means all tables are allowed.
+	AllowedTables []string
 }
 
 // DatabaseTool is the Tool wrapper to use in Agent
 type DatabaseTool struct {
-	db       *sql.DB
-	dbType   string
-	readOnly bool
-	maxRows  int
+	db            *sql.DB
+	dbType        string
+	readOnly      bool
+	maxRows       int
+	allowedTables map[string]bool
 	toolkit.Toolkit
 }
 
@@ -58,6 +62,12 @@ func NewDatabaseTool(sqlDB *sql.DB, config DatabaseConfig) toolkit.Tool {
 		readOnly: config.ReadOnly,
 		maxRows:  maxRows,
 	}
+	if len(config.AllowedTables) > 0 {
+		dbTool.allowedTables = make(map[string]bool, len(config.AllowedTables))
+		for _, table := range config.AllowedTables {
+			dbTool.allowedTables[strings.ToLower(table)] = true
+		}
+	}
 	dbTool.Toolkit = toolkit.NewToolkit()
 	dbTool.Toolkit.Name = "DatabaseTool"
 	dbTool.Toolkit.Description = "Toolkit for safe database operations: list tables, describe schema, execute queries,select(find) insert, update  and run transactions."
@@ -87,7 +97,8 @@ type DescribeTableInput struct {
 type ExecuteSelectInput struct {
 	Query  string        `json:"query" description:"SELECT query to retrieve data from database" required:"true"`
 	Params []interface{} `json:"params,omitempty" description:"Query parameters for prepared statements (optional)"`
-	Limit  int           `json:"limit,omitempty" description:"Maximum number of rows to return (optional, default 1000)"`
+	Limit  int           `json:"limit,omitempty" description:"Maximum number of rows to return (optional, default and maximum is MaxRows)"`
+	Offset int           `json:"offset,omitempty" description:"Number of rows to skip, use next_offset from the previous page to get the next one (optional)"`
 }
 
 type ExecuteQueryInput struct {
@@ -139,6 +150,9 @@ func (t *DatabaseTool) ListTables(params ListTablesInput) (interface{}, error) {
 		if err := rows.Scan(&table); err != nil {
 			continue
 		}
+		if !t.tableAllowed(table) {
+			continue
+		}
 		tables = append(tables, table)
 	}
 
@@ -150,6 +164,10 @@ func (t *DatabaseTool) ListTables(params ListTablesInput) (interface{}, error) {
 }
 
 func (t *DatabaseTool) DescribeTable(params DescribeTableInput) (interface{}, error) {
+	if !t.tableAllowed(params.Table) {
+		return nil, fmt.Errorf("table %q is not in the allowed tables", params.Table)
+	}
+
 	ctx := context.Background()
 	var query string
 	var args []interface{}
@@ -218,21 +236,47 @@ func (t *DatabaseTool) DescribeTable(params DescribeTableInput) (interface{}, er
 }
 
 func (t *DatabaseTool) ExecuteSelect(params ExecuteSelectInput) (interface{}, error) {
-	if !t.isSelectQuery(params.Query) {
-		return nil, fmt.Errorf("only SELECT queries allowed in ExecuteSelect")
+	if err := checkReadOnlySQL(params.Query); err != nil {
+		if t.readOnly {
+			return nil, err
+		}
+		return nil, fmt.Errorf("only single SELECT queries allowed in ExecuteSelect")
+	}
+	if err := t.checkTables(params.Query); err != nil {
+		return nil, err
 	}
 
 	limit := t.maxRows
 	if params.Limit > 0 && params.Limit < limit {
 		limit = params.Limit
 	}
+	offset := params.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	// Fetch one row past the page to know whether there are more. A query
+	// with its own LIMIT is paged as a subquery so its limit still applies.
+	// The newlines end any trailing line comment.
+	query := strings.TrimRight(strings.TrimSpace(params.Query), "; \t\n")
+	tokens, _ := singleSQLStatement(query)
+	if hasTopLevelLimit(tokens) {
+		query = fmt.Sprintf("SELECT * FROM (%s\n) AS agno_page LIMIT %d OFFSET %d", query, limit+1, offset)
+	} else {
+		query = fmt.Sprintf("%s\nLIMIT %d OFFSET %d", query, limit+1, offset)
+	}
 
-	query := params.Query
-	if !strings.Contains(strings.ToUpper(query), "LIMIT") {
-		query = fmt.Sprintf("%s LIMIT %d", query, limit)
+	// Run in a read-only transaction that is always rolled back, so a query
+	// that passes the keyword check but calls a function with side effects,
+	// e.g. setval or dblink_exec, still cannot change the database
+	ctx := context.Background()
+	tx, err := t.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin read-only transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	rows, err := t.db.QueryContext(context.Background(), query, params.Params...)
+	rows, err := tx.QueryContext(ctx, query, params.Params...)
 	if err != nil {
 		return nil, fmt.Errorf("query execution failed: %w", err)
 	}
@@ -244,7 +288,13 @@ func (t *DatabaseTool) ExecuteSelect(params ExecuteSelectInput) (interface{}, er
 	}
 
 	var results []map[string]interface{}
+	hasMore := false
 	for rows.Next() {
+		if len(results) == limit {
+			hasMore = true
+			break
+		}
+
 		values := make([]interface{}, len(cols))
 		valuePtrs := make([]interface{}, len(cols))
 		for i := range values {
@@ -265,21 +315,45 @@ func (t *DatabaseTool) ExecuteSelect(params ExecuteSelectInput) (interface{}, er
 		}
 		results = append(results, row)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read rows: %w", err)
+	}
+	rows.Close()
+
+	result := map[string]interface{}{
+		"success":  true,
+		"columns":  cols,
+		"rows":     results,
+		"count":    len(results),
+		"offset":   offset,
+		"limit":    limit,
+		"has_more": hasMore,
+	}
+	if hasMore {
+		result["next_offset"] = offset + len(results)
+		// Counting is only needed when the page did not reach the end
+		if total, err := t.countRows(ctx, tx, params.Query, params.Params); err == nil {
+			result["total_rows"] = total
+		}
+	} else {
+		result["total_rows"] = int64(offset + len(results))
+	}
 
-	return map[string]interface{}{
-		"success": true,
-		"columns": cols,
-		"rows":    results,
-		"count":   len(results),
-	}, nil
+	return result, nil
 }
 
 func (t *DatabaseTool) ExecuteQuery(params ExecuteQueryInput) (interface{}, error) {
-	if t.readOnly && !t.isSelectQuery(params.Query) {
-		return nil, fmt.Errorf("only SELECT queries allowed in read-only mode")
+	if t.readOnly {
+		if err := checkReadOnlySQL(params.Query); err != nil {
+			return nil, err
+		}
+	}
+	if err := t.checkTables(params.Query); err != nil {
+		return nil, err
 	}
 
-	if t.isSelectQuery(params.Query) {
+	// In read-only mode every query that passed the check is a SELECT
+	if t.readOnly || t.isSelectQuery(params.Query) {
 		return t.ExecuteSelect(ExecuteSelectInput{
 			Query:  params.Query,
 			Params: params.Params,
@@ -333,6 +407,11 @@ func (t *DatabaseTool) ExecuteTransaction(params ExecuteTransactionInput) (inter
 	if len(params.Queries) == 0 {
 		return nil, fmt.Errorf("no queries provided")
 	}
+	for _, q := range params.Queries {
+		if err := t.checkTables(q); err != nil {
+			return nil, err
+		}
+	}
 
 	tx, err := t.db.BeginTx(context.Background(), nil)
 	if err != nil {
@@ -369,6 +448,49 @@ func (t *DatabaseTool) isSelectQuery(q string) bool {
 	return strings.HasPrefix(trimmed, "SELECT") || strings.HasPrefix(trimmed, "WITH")
 }
 
+// countRows counts the rows a SELECT returns, within tx
+func (t *DatabaseTool) countRows(ctx context.Context, tx *sql.Tx, query string, params []interface{}) (int64, error) {
+	query = strings.TrimRight(strings.TrimSpace(query), "; \t\n")
+	var total int64
+	err := tx.QueryRowContext(ctx,
+		fmt.Sprintf("SELECT COUNT(*) FROM (%s\n) AS agno_count", query), params...).Scan(&total)
+	return total, err
+}
+
+// tableAllowed reports whether table is in the allowlist. Schema-qualified
+// names match on the table part.
+func (t *DatabaseTool) tableAllowed(table string) bool {
+	if t.allowedTables == nil {
+		return true
+	}
+	table = strings.ToLower(table)
+	if t.allowedTables[table] {
+		return true
+	}
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		return t.allowedTables[table[i+1:]]
+	}
+	return false
+}
+
+// checkTables fails if query is not a single statement touching only
+// allowed tables
+func (t *DatabaseTool) checkTables(query string) error {
+	if t.allowedTables == nil {
+		return nil
+	}
+	tokens, err := singleSQLStatement(query)
+	if err != nil {
+		return err
+	}
+	for _, table := range sqlTables(tokens) {
+		if !t.tableAllowed(table) {
+			return fmt.Errorf("table %q is not in the allowed tables", table)
+		}
+	}
+	return nil
+}
+
 func (t *DatabaseTool) quoteIdentifier(name string) string {
 	switch t.dbType {
 	case "mysql":
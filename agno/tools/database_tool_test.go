@@ -0,0 +1,283 @@
+package tools
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func newTestDatabaseTool(t *testing.T, config DatabaseConfig) (*DatabaseTool, *sql.DB) {
+	t.Helper()
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	// Each connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	for _, stmt := range []string{
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)",
+		"CREATE TABLE secrets (id INTEGER PRIMARY KEY, token TEXT)",
+		"INSERT INTO users (name) VALUES ('ana'), ('bob')",
+		"INSERT INTO secrets (token) VALUES ('s3cr3t')",
+	} {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+
+	config.Type = "sqlite3"
+	return NewDatabaseTool(db, config).(*DatabaseTool), db
+}
+
+func TestDatabaseToolReadOnly(t *testing.T) {
+	tool, db := newTestDatabaseTool(t, DatabaseConfig{ReadOnly: true})
+
+	rejected := []string{
+		"INSERT INTO users (name) VALUES ('eve')",
+		"UPDATE users SET name = 'eve'",
+		"DELETE FROM users",
+		"DROP TABLE users",
+		"  drop table users",
+		"/* comment */ DELETE FROM users",
+		"SELECT * FROM users; DROP TABLE users",
+		"SELECT * FROM users;DELETE FROM users;",
+		"SELECT 1 -- harmless\n; DROP TABLE users",
+		"WITH gone AS (DELETE FROM users RETURNING *) SELECT * FROM gone",
+		"SELECT * INTO backup FROM users",
+	}
+	for _, query := range rejected {
+		if _, err := tool.ExecuteQuery(ExecuteQueryInput{Query: query}); err == nil {
+			t.Errorf("ExecuteQuery(%q): expected an error", query)
+		} else if !strings.Contains(err.Error(), "read-only mode") && !strings.Contains(err.Error(), "multiple statements") {
+			t.Errorf("ExecuteQuery(%q): unexpected error %v", query, err)
+		}
+		if _, err := tool.ExecuteSelect(ExecuteSelectInput{Query: query}); err == nil {
+			t.Errorf("ExecuteSelect(%q): expected an error", query)
+		}
+	}
+
+	if _, err := tool.ExecuteQuery(ExecuteQueryInput{Query: "DELETE FROM users"}); err == nil ||
+		!strings.Contains(err.Error(), "write operations disabled in read-only mode") {
+		t.Errorf("expected a read-only error, got %v", err)
+	}
+	if _, err := tool.ExecuteTransaction(ExecuteTransactionInput{Queries: []string{"DELETE FROM users"}}); err == nil {
+		t.Error("expected transactions to be rejected")
+	}
+
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count); err != nil || count != 2 {
+		t.Fatalf("users table was modified: count=%d err=%v", count, err)
+	}
+
+	allowed := []string{
+		"SELECT * FROM users",
+		"select name from users where name = 'drop table; delete'",
+		"SELECT replace(name, 'a', 'b') AS name FROM users;",
+		`SELECT "update" FROM (SELECT name AS "update" FROM users)`,
+		"WITH u AS (SELECT * FROM users) SELECT * FROM u",
+	}
+	for _, query := range allowed {
+		if _, err := tool.ExecuteQuery(ExecuteQueryInput{Query: query}); err != nil {
+			t.Errorf("ExecuteQuery(%q): %v", query, err)
+		}
+	}
+}
+
+func TestDatabaseToolAllowedTables(t *testing.T) {
+	tool, _ := newTestDatabaseTool(t, DatabaseConfig{ReadOnly: true, AllowedTables: []string{"users"}})
+
+	out, err := tool.ExecuteSelect(ExecuteSelectInput{Query: "SELECT u.name FROM main.users u ORDER BY u.id"})
+	if err != nil {
+		t.Fatalf("ExecuteSelect: %v", err)
+	}
+	if count := out.(map[string]interface{})["count"]; count != 2 {
+		t.Errorf("expected 2 rows, got %v", count)
+	}
+
+	for _, query := range []string{
+		"SELECT * FROM secrets",
+		"SELECT * FROM users, secrets",
+		"SELECT * FROM users JOIN secrets ON secrets.id = users.id",
+		"SELECT * FROM users WHERE id IN (SELECT id FROM secrets)",
+		`SELECT * FROM "secrets"`,
+	} {
+		if _, err := tool.ExecuteSelect(ExecuteSelectInput{Query: query}); err == nil ||
+			!strings.Contains(err.Error(), `"secrets" is not in the allowed tables`) {
+			t.Errorf("ExecuteSelect(%q): expected an allowlist error, got %v", query, err)
+		}
+	}
+
+	if _, err := tool.DescribeTable(DescribeTableInput{Table: "secrets"}); err == nil {
+		t.Error("expected DescribeTable to reject secrets")
+	}
+	if _, err := tool.GetTableInfo(GetTableInfoInput{Table: "secrets"}); err == nil {
+		t.Error("expected GetTableInfo to reject secrets")
+	}
+
+	out, err = tool.ListTables(ListTablesInput{})
+	if err != nil {
+		t.Fatalf("ListTables: %v", err)
+	}
+	if tables := out.(map[string]interface{})["tables"].([]string); len(tables) != 1 || tables[0] != "users" {
+		t.Errorf("expected only users to be listed, got %v", tables)
+	}
+}
+
+func TestSQLTables(t *testing.T) {
+	tests := []struct {
+		query  string
+		tables []string
+	}{
+		{"SELECT * FROM users u, secrets s", []string{"users", "secrets"}},
+		{"SELECT * FROM (SELECT 1) AS x, secrets", []string{"secrets"}},
+		{"SELECT * FROM (SELECT id FROM users) x(id), secrets", []string{"secrets", "users"}},
+		{"SELECT * FROM users, LATERAL (SELECT 1) y, secrets", []string{"users", "secrets"}},
+		{"SELECT * FROM users JOIN orders ON orders.user_id = users.id, secrets", []string{"users", "orders", "secrets"}},
+		{"SELECT * FROM (users, secrets)", []string{"users", "secrets"}},
+		{"SELECT * FROM generate_series(1, 3) g, secrets", []string{"generate_series", "secrets"}},
+		{"INSERT INTO logs (id, msg) VALUES (1, 'a')", []string{"logs"}},
+		{"WITH u AS (SELECT * FROM users) SELECT * FROM u, secrets", []string{"users", "secrets"}},
+	}
+	for _, tt := range tests {
+		tokens, err := singleSQLStatement(tt.query)
+		if err != nil {
+			t.Fatalf("singleSQLStatement(%q): %v", tt.query, err)
+		}
+		if got := sqlTables(tokens); !reflect.DeepEqual(got, tt.tables) {
+			t.Errorf("sqlTables(%q) = %v, want %v", tt.query, got, tt.tables)
+		}
+	}
+
+	// MySQL reads \' as an escaped quote, so the rest of the query would be
+	// hidden inside a string
+	for _, query := range []string{
+		`SELECT * FROM users WHERE name = '\'' UNION SELECT * FROM secrets -- '`,
+		`SELECT * FROM users WHERE name = "\"" UNION SELECT * FROM secrets -- "`,
+	} {
+		if _, err := singleSQLStatement(query); err == nil {
+			t.Errorf("singleSQLStatement(%q): expected an error", query)
+		}
+	}
+	if _, err := singleSQLStatement(`SELECT * FROM users WHERE name = 'a\\'`); err != nil {
+		t.Errorf("expected an escaped backslash to be allowed, got %v", err)
+	}
+}
+
+func TestDatabaseToolExecuteSelectPagination(t *testing.T) {
+	tool, db := newTestDatabaseTool(t, DatabaseConfig{MaxRows: 2})
+	if _, err := db.Exec("INSERT INTO users (name) VALUES ('carl'), ('dora'), ('eli')"); err != nil {
+		t.Fatalf("insert: %v", err)
+	}
+
+	var names []string
+	offset := 0
+	for page := 0; page < 5; page++ {
+		out, err := tool.ExecuteSelect(ExecuteSelectInput{Query: "SELECT name FROM users ORDER BY id -- by id", Offset: offset})
+		if err != nil {
+			t.Fatalf("ExecuteSelect: %v", err)
+		}
+		result := out.(map[string]interface{})
+		for _, row := range result["rows"].([]map[string]interface{}) {
+			names = append(names, row["name"].(string))
+		}
+		if result["total_rows"] != int64(5) {
+			t.Errorf("page %d: expected total_rows 5, got %v", page, result["total_rows"])
+		}
+		if !result["has_more"].(bool) {
+			if _, ok := result["next_offset"]; ok {
+				t.Errorf("page %d: next_offset set on the last page", page)
+			}
+			break
+		}
+		offset = result["next_offset"].(int)
+	}
+	if got := strings.Join(names, ","); got != "ana,bob,carl,dora,eli" {
+		t.Errorf("unexpected rows across pages: %s", got)
+	}
+
+	// A query's own LIMIT is kept and paged within
+	out, err := tool.ExecuteSelect(ExecuteSelectInput{Query: "SELECT name FROM users ORDER BY id LIMIT 3;", Limit: 1, Offset: 2})
+	if err != nil {
+		t.Fatalf("ExecuteSelect: %v", err)
+	}
+	result := out.(map[string]interface{})
+	rows := result["rows"].([]map[string]interface{})
+	if len(rows) != 1 || rows[0]["name"] != "carl" || result["has_more"].(bool) {
+		t.Errorf("unexpected page of limited query: %+v", result)
+	}
+}
+
+// txRecorder is a database driver that records the transactions queries run
+// in, answering every query with no rows
+type txRecorder struct {
+	readOnly   []bool
+	committed  int
+	rolledBack int
+	queries    []string
+}
+
+func (r *txRecorder) Open(name string) (driver.Conn, error) { return &txRecorderConn{r}, nil }
+
+type txRecorderConn struct{ r *txRecorder }
+
+func (c *txRecorderConn) Prepare(query string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (c *txRecorderConn) Close() error                              { return nil }
+func (c *txRecorderConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *txRecorderConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	c.r.readOnly = append(c.r.readOnly, opts.ReadOnly)
+	return c, nil
+}
+
+func (c *txRecorderConn) Commit() error   { c.r.committed++; return nil }
+func (c *txRecorderConn) Rollback() error { c.r.rolledBack++; return nil }
+
+func (c *txRecorderConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.r.queries = append(c.r.queries, query)
+	return txRecorderRows{}, nil
+}
+
+type txRecorderRows struct{}
+
+func (txRecorderRows) Columns() []string              { return []string{"n"} }
+func (txRecorderRows) Close() error                   { return nil }
+func (txRecorderRows) Next(dest []driver.Value) error { return io.EOF }
+
+func TestDatabaseToolQueriesRunInReadOnlyTransaction(t *testing.T) {
+	recorder := &txRecorder{}
+	sql.Register("txrecorder", recorder)
+	db, err := sql.Open("txrecorder", "")
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	defer db.Close()
+	tool := NewDatabaseTool(db, DatabaseConfig{ReadOnly: true}).(*DatabaseTool)
+
+	// Passes the keyword check, but would change a sequence on PostgreSQL
+	if _, err := tool.ExecuteQuery(ExecuteQueryInput{Query: "SELECT setval('users_id_seq', 1)"}); err != nil {
+		t.Fatalf("ExecuteQuery: %v", err)
+	}
+	if _, err := tool.ExecuteSelect(ExecuteSelectInput{Query: "(SELECT 1)"}); err != nil {
+		t.Fatalf("ExecuteSelect: %v", err)
+	}
+
+	if len(recorder.queries) != 2 {
+		t.Fatalf("expected 2 queries, got %q", recorder.queries)
+	}
+	if len(recorder.readOnly) != 2 || !recorder.readOnly[0] || !recorder.readOnly[1] {
+		t.Errorf("expected each query in a read-only transaction, got %v", recorder.readOnly)
+	}
+	if recorder.committed != 0 || recorder.rolledBack != 2 {
+		t.Errorf("expected the transactions to be rolled back, got %d commits and %d rollbacks", recorder.committed, recorder.rolledBack)
+	}
+}
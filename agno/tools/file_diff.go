@@ -0,0 +1,213 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffContextLines is the number of unchanged lines shown around each change
+const diffContextLines = 3
+
+// maxDiffEdits caps the number of line edits a diff may hold, which bounds its
+// time and memory; contents further apart are only reported as different
+const maxDiffEdits = 1000
+
+// diffOp is a line of an edit script: ' ' kept, '-' removed or '+' added
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// UnifiedDiff returns the unified diff from oldContent to newContent, with the
+// file names fromName and toName in the header (e.g. "a/main.go" and
+// "b/main.go", or "/dev/null" for a new file), or "" when they are equal.
+// Contents that need more than maxDiffEdits line edits only get a note that
+// they differ.
+func UnifiedDiff(fromName, toName, oldContent, newContent string) string {
+	if oldContent == newContent {
+		return ""
+	}
+
+	ops, ok := diffLines(splitLines(oldContent), splitLines(newContent))
+	if !ok {
+		return fmt.Sprintf("--- %s\n+++ %s\nFiles differ in more than %d lines, diff omitted\n", fromName, toName, maxDiffEdits)
+	}
+
+	// Line counts consumed before each op, for the hunk headers
+	oldLines := make([]int, len(ops)+1)
+	newLines := make([]int, len(ops)+1)
+	for i, op := range ops {
+		oldLines[i+1], newLines[i+1] = oldLines[i], newLines[i]
+		if op.kind != '+' {
+			oldLines[i+1]++
+		}
+		if op.kind != '-' {
+			newLines[i+1]++
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "--- %s\n+++ %s\n", fromName, toName)
+
+	for i := 0; i < len(ops); {
+		if ops[i].kind == ' ' {
+			i++
+			continue
+		}
+
+		// Extend the hunk while the next change is close enough to share context
+		start := max(i-diffContextLines, 0)
+		end := i + 1
+		for j := i + 1; j < len(ops); j++ {
+			if ops[j].kind != ' ' {
+				end = j + 1
+			} else if j-end >= 2*diffContextLines {
+				break
+			}
+		}
+		stop := min(end+diffContextLines, len(ops))
+
+		fmt.Fprintf(&b, "@@ -%s +%s @@\n",
+			hunkRange(oldLines[start], oldLines[stop]-oldLines[start]),
+			hunkRange(newLines[start], newLines[stop]-newLines[start]))
+		for _, op := range ops[start:stop] {
+			b.WriteByte(op.kind)
+			b.WriteString(op.line)
+			if !strings.HasSuffix(op.line, "\n") {
+				b.WriteString("\n\\ No newline at end of file\n")
+			}
+		}
+		i = stop
+	}
+
+	return b.String()
+}
+
+// hunkRange formats the start and length of a hunk side, where before is the
+// number of lines preceding it
+func hunkRange(before, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", before)
+	case 1:
+		return fmt.Sprintf("%d", before+1)
+	}
+	return fmt.Sprintf("%d,%d", before+1, count)
+}
+
+// splitLines splits s into lines, keeping their line terminators
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(s, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// diffLines returns the edit script from a to b, trimming the common prefix
+// and suffix before running the Myers algorithm on the rest, or false when it
+// needs more than maxDiffEdits edits
+func diffLines(a, b []string) ([]diffOp, bool) {
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	edits, ok := myersDiff(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])
+	if !ok {
+		return nil, false
+	}
+
+	ops := make([]diffOp, 0, prefix+len(edits)+suffix)
+	for _, line := range a[:prefix] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	ops = append(ops, edits...)
+	for _, line := range a[len(a)-suffix:] {
+		ops = append(ops, diffOp{' ', line})
+	}
+	return ops, true
+}
+
+// myersDiff returns a shortest edit script from a to b, or false when it needs
+// more than maxDiffEdits edits. Only the live window v[-d..d] of each step is
+// kept for the walk back, so memory is O(D²) with D <= maxDiffEdits.
+func myersDiff(a, b []string) ([]diffOp, bool) {
+	n, m := len(a), len(b)
+	maxD := min(n+m, maxDiffEdits)
+	offset := maxD + 1
+	v := make([]int, 2*offset+1)
+
+	// trace[d] holds the furthest reaching paths v[-d..d] before step d
+	var trace [][]int
+	found := false
+search:
+	for d := 0; d <= maxD; d++ {
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+			if x >= n && y >= m {
+				found = true
+				break search
+			}
+		}
+	}
+	if !found {
+		return nil, false
+	}
+
+	// Walk back from the end, collecting ops in reverse
+	var ops []diffOp
+	x, y := n, m
+	for d := len(trace) - 1; d >= 0; d-- {
+		prevX, prevY := 0, 0
+		if d > 0 {
+			// trace[d][i] is v[i-d]
+			v := trace[d]
+			k := x - y
+			prevK := k - 1
+			if k == -d || (k != d && v[d+k-1] < v[d+k+1]) {
+				prevK = k + 1
+			}
+			prevX = v[d+prevK]
+			prevY = prevX - prevK
+		}
+		for x > prevX && y > prevY {
+			ops = append(ops, diffOp{' ', a[x-1]})
+			x--
+			y--
+		}
+		if d > 0 {
+			if x == prevX {
+				ops = append(ops, diffOp{'+', b[y-1]})
+				y--
+			} else {
+				ops = append(ops, diffOp{'-', a[x-1]})
+				x--
+			}
+		}
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops, true
+}
@@ -0,0 +1,126 @@
+package tools
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	oldContent := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	newContent := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk"
+
+	want := `--- a/x.txt
++++ b/x.txt
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -8,3 +8,4 @@
+ h
+ i
+ j
++k
+\ No newline at end of file
+`
+	if got := UnifiedDiff("a/x.txt", "b/x.txt", oldContent, newContent); got != want {
+		t.Errorf("unexpected diff:\n%s", got)
+	}
+
+	if got := UnifiedDiff("a/x.txt", "b/x.txt", oldContent, oldContent); got != "" {
+		t.Errorf("expected no diff for equal content, got:\n%s", got)
+	}
+
+	want = "--- /dev/null\n+++ b/x.txt\n@@ -0,0 +1 @@\n+new\n"
+	if got := UnifiedDiff("/dev/null", "b/x.txt", "", "new\n"); got != want {
+		t.Errorf("unexpected diff for a new file:\n%s", got)
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	// Every third line changed and every fifth removed
+	var a, b []string
+	for i := 0; i < 300; i++ {
+		line := fmt.Sprintf("line %d\n", i)
+		a = append(a, line)
+		switch {
+		case i%5 == 0:
+		case i%3 == 0:
+			b = append(b, "changed "+line)
+		default:
+			b = append(b, line)
+		}
+	}
+
+	ops, ok := diffLines(a, b)
+	if !ok {
+		t.Fatal("expected a diff within the edit limit")
+	}
+	var gotA, gotB []string
+	edits := 0
+	for _, op := range ops {
+		if op.kind != '+' {
+			gotA = append(gotA, op.line)
+		}
+		if op.kind != '-' {
+			gotB = append(gotB, op.line)
+		}
+		if op.kind != ' ' {
+			edits++
+		}
+	}
+	if strings.Join(gotA, "") != strings.Join(a, "") || strings.Join(gotB, "") != strings.Join(b, "") {
+		t.Fatal("edit script does not turn a into b")
+	}
+	// 60 removed lines plus 80 changed lines, each a removal and an addition
+	if edits != 60+2*80 {
+		t.Errorf("expected a shortest edit script of 220 edits, got %d", edits)
+	}
+}
+
+func TestUnifiedDiffTooManyChanges(t *testing.T) {
+	var oldContent, newContent strings.Builder
+	for i := 0; i < maxDiffEdits; i++ {
+		fmt.Fprintf(&oldContent, "old %d\n", i)
+		fmt.Fprintf(&newContent, "new %d\n", i)
+	}
+
+	want := fmt.Sprintf("--- a/x.txt\n+++ b/x.txt\nFiles differ in more than %d lines, diff omitted\n", maxDiffEdits)
+	if got := UnifiedDiff("a/x.txt", "b/x.txt", oldContent.String(), newContent.String()); got != want {
+		t.Errorf("unexpected diff:\n%s", got)
+	}
+}
+
+func TestFileToolWriteDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "main.txt")
+	if err := os.WriteFile(path, []byte("hello\nworld\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	ft := NewFileTool(false)
+	out, err := ft.PreviewWrite(WriteFileParams{Path: path, Content: "hello\ngophers\n"})
+	if err != nil {
+		t.Fatalf("PreviewWrite: %v", err)
+	}
+	preview := out.(FileOperationResult)
+	if !preview.Success || preview.Diff == "" {
+		t.Fatalf("unexpected preview: %+v", preview)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "hello\nworld\n" {
+		t.Errorf("PreviewWrite changed the file: %q", data)
+	}
+
+	ft.EnableWrite()
+	out, err = ft.WriteFile(WriteFileParams{Path: path, Content: "hello\ngophers\n"})
+	if err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if written := out.(FileOperationResult); written.Diff != preview.Diff {
+		t.Errorf("expected the write diff to match the preview, got:\n%s", written.Diff)
+	}
+}
@@ -26,6 +26,7 @@ type FileOperationResult struct {
 	Size      int64  `json:"size,omitempty"`
 	IsDir     bool   `json:"is_dir,omitempty"`
 	Exists    bool   `json:"exists,omitempty"`
+	Diff      string `json:"diff,omitempty"`
 	Operation string `json:"operation"`
 }
 
@@ -78,7 +79,8 @@ func NewFileTool(write bool) *FileTool {
 
 	// Register methods
 	ft.Toolkit.Register("ReadFile", "Read content from a file", ft, ft.ReadFile, ReadFileParams{})
-	ft.Toolkit.Register("WriteFile", "Write content to a file", ft, ft.WriteFile, WriteFileParams{})
+	ft.Toolkit.Register("WriteFile", "Write content to a file. Returns a unified diff of the changes", ft, ft.WriteFile, WriteFileParams{})
+	ft.Toolkit.Register("PreviewWrite", "Preview a write: returns the unified diff WriteFile would produce, without changing the file", ft, ft.PreviewWrite, WriteFileParams{})
 	ft.Toolkit.Register("GetFileInfo", "Get information about a file or directory", ft, ft.GetFileInfo, FileInfoParams{})
 	ft.Toolkit.Register("ListDirectory", "List contents of a directory", ft, ft.ListDirectory, ListDirParams{})
 	ft.Toolkit.Register("SearchFiles", "Search for files matching patterns", ft, ft.SearchFiles, SearchFileParams{})
@@ -206,6 +208,17 @@ func (ft *FileTool) WriteFile(params WriteFileParams) (interface{}, error) {
 		return nil, fmt.Errorf("content is required")
 	}
 
+	// Keep the prior content to report what changed
+	oldContent, existed, err := readPriorContent(params.Path)
+	if err != nil {
+		return FileOperationResult{
+			Path:      params.Path,
+			Success:   false,
+			Error:     err.Error(),
+			Operation: "WriteFile",
+		}, nil
+	}
+
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(params.Path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -265,10 +278,73 @@ func (ft *FileTool) WriteFile(params WriteFileParams) (interface{}, error) {
 		Path:      params.Path,
 		Success:   true,
 		Size:      int64(bytesWritten),
+		Diff:      writeDiff(params, oldContent, existed),
 		Operation: "WriteFile",
 	}, nil
 }
 
+// PreviewWrite returns the unified diff WriteFile would produce for the same
+// parameters, without changing the file. It is allowed when writes are
+// disabled, so an agent can propose changes for review.
+func (ft *FileTool) PreviewWrite(params WriteFileParams) (interface{}, error) {
+	if params.Path == "" {
+		return nil, fmt.Errorf("file path is required")
+	}
+
+	if len(params.Content) == 0 {
+		return nil, fmt.Errorf("content is required")
+	}
+
+	oldContent, existed, err := readPriorContent(params.Path)
+	if err != nil {
+		return FileOperationResult{
+			Path:      params.Path,
+			Success:   false,
+			Error:     err.Error(),
+			Operation: "PreviewWrite",
+		}, nil
+	}
+
+	return FileOperationResult{
+		Path:      params.Path,
+		Success:   true,
+		Size:      int64(len(writtenContent(params, oldContent))),
+		Exists:    existed,
+		Diff:      writeDiff(params, oldContent, existed),
+		Operation: "PreviewWrite",
+	}, nil
+}
+
+// readPriorContent returns the current content of the file at path, and
+// whether it exists
+func readPriorContent(path string) (string, bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to read current content: %v", err)
+	}
+	return string(data), true, nil
+}
+
+// writtenContent returns the file content after a write
+func writtenContent(params WriteFileParams, oldContent string) string {
+	if params.Append {
+		return oldContent + params.Content
+	}
+	return params.Content
+}
+
+// writeDiff returns the unified diff of a write from the prior file content
+func writeDiff(params WriteFileParams, oldContent string, existed bool) string {
+	fromName := "a/" + filepath.ToSlash(params.Path)
+	if !existed {
+		fromName = "/dev/null"
+	}
+	return UnifiedDiff(fromName, "b/"+filepath.ToSlash(params.Path), oldContent, writtenContent(params, oldContent))
+}
+
 // GetFileInfo gets information about a file or directory
 func (ft *FileTool) GetFileInfo(params FileInfoParams) (interface{}, error) {
 	if params.Path == "" {
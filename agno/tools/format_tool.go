@@ -0,0 +1,352 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// FormatTool converts data between JSON, CSV and YAML and extracts values with JSONPath.
+// All methods are pure and deterministic, so results are safe to cache.
+type FormatTool struct {
+	toolkit.Toolkit
+}
+
+// FormatInputParams represents parameters for format conversions
+type FormatInputParams struct {
+	Data string `json:"data" description:"Input data to convert" required:"true"`
+}
+
+// JSONQueryParams represents parameters for JSONPath queries
+type JSONQueryParams struct {
+	Data     string `json:"data" description:"JSON document to query" required:"true"`
+	JSONPath string `json:"jsonpath" description:"JSONPath expression, e.g. $.items[0].name or $.items[*].id" required:"true"`
+}
+
+// NewFormatTool creates a new FormatTool instance
+func NewFormatTool() *FormatTool {
+	tk := toolkit.NewToolkit()
+	tk.Name = "FormatTool"
+	tk.Description = "Convert data between JSON, CSV and YAML, and extract values from JSON with JSONPath."
+
+	ft := &FormatTool{tk}
+
+	ft.Toolkit.Register("json_to_csv", "Convert a JSON array of objects to CSV with a header row", ft, ft.JSONToCSV, FormatInputParams{})
+	ft.Toolkit.Register("csv_to_json", "Convert CSV with a header row to a JSON array of objects", ft, ft.CSVToJSON, FormatInputParams{})
+	ft.Toolkit.Register("json_to_yaml", "Convert a JSON document to YAML", ft, ft.JSONToYAML, FormatInputParams{})
+	ft.Toolkit.Register("yaml_to_json", "Convert a YAML document to JSON", ft, ft.YAMLToJSON, FormatInputParams{})
+	ft.Toolkit.Register("json_query", "Extract a value from a JSON document using a JSONPath expression", ft, ft.JSONQuery, JSONQueryParams{})
+
+	return ft
+}
+
+// JSONToCSV converts a JSON array of objects (or a single object) to CSV.
+// Columns are the sorted union of all keys; nested values are written as JSON.
+func (ft *FormatTool) JSONToCSV(params FormatInputParams) (interface{}, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(params.Data), &data); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	var rows []map[string]interface{}
+	switch v := data.(type) {
+	case []interface{}:
+		for i, item := range v {
+			row, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("element %d is not an object", i)
+			}
+			rows = append(rows, row)
+		}
+	case map[string]interface{}:
+		rows = append(rows, v)
+	default:
+		return nil, fmt.Errorf("JSON must be an array of objects or an object")
+	}
+
+	keySet := make(map[string]bool)
+	for _, row := range rows {
+		for key := range row {
+			keySet[key] = true
+		}
+	}
+	headers := make([]string, 0, len(keySet))
+	for key := range keySet {
+		headers = append(headers, key)
+	}
+	sort.Strings(headers)
+
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(headers); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+	for _, row := range rows {
+		record := make([]string, len(headers))
+		for i, key := range headers {
+			value, ok := row[key]
+			if !ok {
+				continue
+			}
+			cell, err := formatScalar(value)
+			if err != nil {
+				return nil, err
+			}
+			record[i] = cell
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, fmt.Errorf("failed to write CSV: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV: %w", err)
+	}
+
+	return buf.String(), nil
+}
+
+// CSVToJSON converts CSV with a header row to a JSON array of objects with string values
+func (ft *FormatTool) CSVToJSON(params FormatInputParams) (interface{}, error) {
+	reader := csv.NewReader(strings.NewReader(params.Data))
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV: %w", err)
+	}
+	if len(records) == 0 {
+		return "[]", nil
+	}
+
+	headers := records[0]
+	rows := make([]map[string]string, 0, len(records)-1)
+	for _, record := range records[1:] {
+		row := make(map[string]string, len(headers))
+		for i, header := range headers {
+			if i < len(record) {
+				row[header] = record[i]
+			}
+		}
+		rows = append(rows, row)
+	}
+
+	out, err := json.Marshal(rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return string(out), nil
+}
+
+// JSONToYAML converts a JSON document to YAML
+func (ft *FormatTool) JSONToYAML(params FormatInputParams) (interface{}, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(params.Data), &data); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	out, err := yaml.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode YAML: %w", err)
+	}
+	return string(out), nil
+}
+
+// YAMLToJSON converts a YAML document to JSON
+func (ft *FormatTool) YAMLToJSON(params FormatInputParams) (interface{}, error) {
+	var data interface{}
+	if err := yaml.Unmarshal([]byte(params.Data), &data); err != nil {
+		return nil, fmt.Errorf("invalid YAML: %w", err)
+	}
+
+	out, err := json.Marshal(normalizeYAML(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return string(out), nil
+}
+
+// JSONQuery extracts a value from a JSON document. Supported syntax: $, .key, ['key'],
+// [index] (negative indexes count from the end), [*] and .* wildcards.
+// Strings are returned as-is; other values are returned as JSON.
+func (ft *FormatTool) JSONQuery(params JSONQueryParams) (interface{}, error) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(params.Data), &data); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	value, err := evalJSONPath(data, params.JSONPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	out, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode JSON: %w", err)
+	}
+	return string(out), nil
+}
+
+// formatScalar renders a JSON value as a CSV cell
+func formatScalar(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	default:
+		out, err := json.Marshal(v)
+		if err != nil {
+			return "", fmt.Errorf("failed to encode value: %w", err)
+		}
+		return string(out), nil
+	}
+}
+
+// normalizeYAML converts YAML maps with non-string keys into JSON-compatible maps
+func normalizeYAML(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = normalizeYAML(item)
+		}
+		return v
+	case map[interface{}]interface{}:
+		out := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			out[fmt.Sprint(key)] = normalizeYAML(item)
+		}
+		return out
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalizeYAML(item)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// evalJSONPath evaluates a JSONPath expression. Once a wildcard is applied the
+// result is the list of all matches.
+func evalJSONPath(data interface{}, path string) (interface{}, error) {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "$") {
+		return nil, fmt.Errorf("JSONPath must start with $")
+	}
+
+	nodes := []interface{}{data}
+	wildcard := false
+	rest := path[1:]
+
+	for len(rest) > 0 {
+		var selector string
+		switch {
+		case strings.HasPrefix(rest, "."):
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end == -1 {
+				end = len(rest)
+			}
+			selector = rest[:end]
+			rest = rest[end:]
+			if selector == "" {
+				return nil, fmt.Errorf("empty key in JSONPath %q", path)
+			}
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end == -1 {
+				return nil, fmt.Errorf("unclosed bracket in JSONPath %q", path)
+			}
+			selector = strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("unexpected character %q in JSONPath %q", rest[0], path)
+		}
+
+		var next []interface{}
+		for _, node := range nodes {
+			matches, err := selectJSONPath(node, selector)
+			if err != nil {
+				if wildcard {
+					continue
+				}
+				return nil, err
+			}
+			next = append(next, matches...)
+		}
+		if selector == "*" {
+			wildcard = true
+		}
+		nodes = next
+	}
+
+	if wildcard {
+		if nodes == nil {
+			return []interface{}{}, nil
+		}
+		return nodes, nil
+	}
+	return nodes[0], nil
+}
+
+// selectJSONPath applies a single key, index or wildcard selector to a node
+func selectJSONPath(node interface{}, selector string) ([]interface{}, error) {
+	if selector == "*" {
+		switch v := node.(type) {
+		case []interface{}:
+			return v, nil
+		case map[string]interface{}:
+			keys := make([]string, 0, len(v))
+			for key := range v {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			values := make([]interface{}, 0, len(keys))
+			for _, key := range keys {
+				values = append(values, v[key])
+			}
+			return values, nil
+		default:
+			return nil, fmt.Errorf("wildcard applied to a scalar value")
+		}
+	}
+
+	if index, err := strconv.Atoi(selector); err == nil {
+		arr, ok := node.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("index [%d] applied to a non-array value", index)
+		}
+		if index < 0 {
+			index += len(arr)
+		}
+		if index < 0 || index >= len(arr) {
+			return nil, fmt.Errorf("index [%s] out of range", selector)
+		}
+		return []interface{}{arr[index]}, nil
+	}
+
+	key := strings.Trim(selector, `'"`)
+	obj, ok := node.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("key %q applied to a non-object value", key)
+	}
+	value, ok := obj[key]
+	if !ok {
+		return nil, fmt.Errorf("key %q not found", key)
+	}
+	return []interface{}{value}, nil
+}
@@ -0,0 +1,67 @@
+package tools
+
+import (
+	"testing"
+)
+
+func TestFormatToolConversions(t *testing.T) {
+	tool := NewFormatTool()
+
+	csvOut, err := tool.JSONToCSV(FormatInputParams{Data: `[{"name":"ana","age":30},{"name":"bob","tags":["x"]}]`})
+	if err != nil {
+		t.Fatalf("JSONToCSV failed: %v", err)
+	}
+	expectedCSV := "age,name,tags\n30,ana,\n,bob,\"[\"\"x\"\"]\"\n"
+	if csvOut != expectedCSV {
+		t.Errorf("Unexpected CSV:\n%q\nwant\n%q", csvOut, expectedCSV)
+	}
+
+	jsonOut, err := tool.CSVToJSON(FormatInputParams{Data: "name,age\nana,30\n"})
+	if err != nil {
+		t.Fatalf("CSVToJSON failed: %v", err)
+	}
+	if jsonOut != `[{"age":"30","name":"ana"}]` {
+		t.Errorf("Unexpected JSON: %s", jsonOut)
+	}
+
+	yamlOut, err := tool.JSONToYAML(FormatInputParams{Data: `{"b":1,"a":{"c":true}}`})
+	if err != nil {
+		t.Fatalf("JSONToYAML failed: %v", err)
+	}
+	roundTrip, err := tool.YAMLToJSON(FormatInputParams{Data: yamlOut.(string)})
+	if err != nil {
+		t.Fatalf("YAMLToJSON failed: %v", err)
+	}
+	if roundTrip != `{"a":{"c":true},"b":1}` {
+		t.Errorf("Unexpected round trip: %s", roundTrip)
+	}
+}
+
+func TestFormatToolJSONQuery(t *testing.T) {
+	tool := NewFormatTool()
+	data := `{"items":[{"id":1,"name":"a"},{"id":2,"name":"b"}]}`
+
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "$.items[0].name", want: "a"},
+		{path: "$.items[-1].id", want: "2"},
+		{path: "$['items'][*].id", want: "[1,2]"},
+		{path: "$.items", want: `[{"id":1,"name":"a"},{"id":2,"name":"b"}]`},
+		{path: "$.missing", wantErr: true},
+		{path: "items", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := tool.JSONQuery(JSONQueryParams{Data: data, JSONPath: tt.path})
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: unexpected error state: %v", tt.path, err)
+			continue
+		}
+		if !tt.wantErr && got != tt.want {
+			t.Errorf("%s: got %v, want %s", tt.path, got, tt.want)
+		}
+	}
+}
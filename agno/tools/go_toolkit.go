@@ -0,0 +1,243 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// maxGoOutput caps the raw toolchain output returned to the model
+const maxGoOutput = 8000
+
+var (
+	// goDiagnosticPattern matches "file.go:line[:col]: message", optionally indented (go test)
+	goDiagnosticPattern = regexp.MustCompile(`^\s*(\S+\.go):(\d+)(?::(\d+))?:\s*(.+)$`)
+	// goFailedTestPattern matches "--- FAIL: TestName (0.00s)"
+	goFailedTestPattern = regexp.MustCompile(`^\s*--- FAIL: (\S+)`)
+)
+
+// GoDiagnostic is a compiler, vet, test or gofmt message tied to a source position
+type GoDiagnostic struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column,omitempty"`
+	Message string `json:"message"`
+}
+
+// GoCheckResult is the structured outcome of a Go toolchain command
+type GoCheckResult struct {
+	Command     string         `json:"command"`
+	Success     bool           `json:"success"`
+	Diagnostics []GoDiagnostic `json:"diagnostics,omitempty"`
+	FailedTests []string       `json:"failed_tests,omitempty"`
+	Output      string         `json:"output,omitempty"`
+	Duration    string         `json:"duration"`
+}
+
+// GoToolkit runs the Go toolchain (build, test, vet, gofmt) on generated code and
+// reports pass/fail with parsed diagnostics, so agents get a reliable success signal.
+type GoToolkit struct {
+	toolkit.Toolkit
+	timeout time.Duration
+}
+
+// GoToolkitOption configures a GoToolkit
+type GoToolkitOption func(*GoToolkit)
+
+// WithGoCommandTimeout sets the timeout of each toolchain command (default 5m)
+func WithGoCommandTimeout(timeout time.Duration) GoToolkitOption {
+	return func(g *GoToolkit) {
+		g.timeout = timeout
+	}
+}
+
+// GoPathParams represents parameters for go build and go vet
+type GoPathParams struct {
+	Path string `json:"path" description:"Module directory (all packages are checked) or package pattern such as ./... or ./cmd/app" required:"true"`
+}
+
+// GoTestParams represents parameters for go test
+type GoTestParams struct {
+	Path string `json:"path" description:"Module directory (all packages are tested) or package pattern such as ./..." required:"true"`
+	Run  string `json:"run,omitempty" description:"Only run tests matching this regular expression"`
+}
+
+// GofmtCheckParams represents parameters for gofmt_check
+type GofmtCheckParams struct {
+	File string `json:"file" description:"Go source file to check" required:"true"`
+}
+
+// NewGoToolkit creates a new GoToolkit
+func NewGoToolkit(options ...GoToolkitOption) *GoToolkit {
+	g := &GoToolkit{timeout: 5 * time.Minute}
+	for _, opt := range options {
+		opt(g)
+	}
+
+	tk := toolkit.NewToolkit()
+	tk.Name = "GoToolkit"
+	tk.Description = "Build, test, vet and gofmt-check Go code. Every method returns success plus diagnostics as file, line and message."
+
+	g.Toolkit = tk
+	g.Toolkit.Register("go_build", "Compile Go packages and report compile errors", g, g.GoBuild, GoPathParams{})
+	g.Toolkit.Register("go_test", "Run Go tests and report failing tests and their messages", g, g.GoTest, GoTestParams{})
+	g.Toolkit.Register("go_vet", "Run go vet and report suspicious constructs", g, g.GoVet, GoPathParams{})
+	g.Toolkit.Register("gofmt_check", "Check that a Go file is gofmt-formatted and syntactically valid", g, g.GofmtCheck, GofmtCheckParams{})
+
+	return g
+}
+
+// GoBuild runs go build, discarding the binaries
+func (g *GoToolkit) GoBuild(params GoPathParams) (interface{}, error) {
+	dir, target, err := goTarget(params.Path)
+	if err != nil {
+		return nil, err
+	}
+	return g.run(dir, "go", "build", "-o", os.DevNull, target)
+}
+
+// GoTest runs go test
+func (g *GoToolkit) GoTest(params GoTestParams) (interface{}, error) {
+	dir, target, err := goTarget(params.Path)
+	if err != nil {
+		return nil, err
+	}
+	args := []string{"test"}
+	if params.Run != "" {
+		args = append(args, "-run", params.Run)
+	}
+	args = append(args, target)
+	return g.run(dir, "go", args...)
+}
+
+// GoVet runs go vet
+func (g *GoToolkit) GoVet(params GoPathParams) (interface{}, error) {
+	dir, target, err := goTarget(params.Path)
+	if err != nil {
+		return nil, err
+	}
+	return g.run(dir, "go", "vet", target)
+}
+
+// GofmtCheck reports syntax errors and whether the file needs formatting
+func (g *GoToolkit) GofmtCheck(params GofmtCheckParams) (interface{}, error) {
+	if params.File == "" {
+		return nil, fmt.Errorf("file is required")
+	}
+	if strings.HasPrefix(params.File, "-") {
+		return nil, fmt.Errorf("invalid file %q: must not start with '-'", params.File)
+	}
+	if _, err := os.Stat(params.File); err != nil {
+		return nil, fmt.Errorf("file not found: %s", params.File)
+	}
+
+	result, err := g.run("", "gofmt", "-d", "-e", params.File)
+	if err != nil {
+		return nil, err
+	}
+	// gofmt exits 0 with a diff when the file only needs formatting
+	if result.Success && strings.TrimSpace(result.Output) != "" {
+		result.Success = false
+		result.Diagnostics = append(result.Diagnostics, GoDiagnostic{
+			File:    params.File,
+			Line:    1,
+			Message: "file is not gofmt-formatted (see diff in output)",
+		})
+	}
+	return result, nil
+}
+
+// run executes a toolchain command and parses its output. A failing command is
+// reported in the result; only a toolchain that cannot be started is an error.
+func (g *GoToolkit) run(dir, name string, args ...string) (*GoCheckResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), g.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	start := time.Now()
+	runErr := cmd.Run()
+
+	result := &GoCheckResult{
+		Command:  strings.Join(append([]string{name}, args...), " "),
+		Success:  runErr == nil,
+		Duration: time.Since(start).Round(time.Millisecond).String(),
+	}
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return nil, fmt.Errorf("failed to run %s: %w", name, runErr)
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		result.Diagnostics = append(result.Diagnostics, GoDiagnostic{Message: fmt.Sprintf("timed out after %s", g.timeout)})
+	}
+
+	output := out.String()
+	result.Diagnostics = append(result.Diagnostics, parseGoDiagnostics(output)...)
+	result.FailedTests = parseFailedTests(output)
+	if len(output) > maxGoOutput {
+		output = output[:maxGoOutput] + "\n... (output truncated)"
+	}
+	result.Output = output
+
+	return result, nil
+}
+
+// goTarget resolves a directory or package pattern into the command directory and package argument
+func goTarget(path string) (string, string, error) {
+	if path == "" {
+		return "", "", fmt.Errorf("path is required")
+	}
+	// The path comes from the model; a leading dash would be parsed as a go flag
+	if strings.HasPrefix(path, "-") {
+		return "", "", fmt.Errorf("invalid path %q: must not start with '-'", path)
+	}
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		return path, "./...", nil
+	}
+	return "", path, nil
+}
+
+// parseGoDiagnostics extracts file:line[:col]: message lines from toolchain output
+func parseGoDiagnostics(output string) []GoDiagnostic {
+	var diagnostics []GoDiagnostic
+	for _, line := range strings.Split(output, "\n") {
+		match := goDiagnosticPattern.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		lineNum, _ := strconv.Atoi(match[2])
+		column, _ := strconv.Atoi(match[3])
+		diagnostics = append(diagnostics, GoDiagnostic{
+			File:    match[1],
+			Line:    lineNum,
+			Column:  column,
+			Message: strings.TrimSpace(match[4]),
+		})
+	}
+	return diagnostics
+}
+
+// parseFailedTests extracts the names of failed tests from go test output
+func parseFailedTests(output string) []string {
+	var failed []string
+	for _, line := range strings.Split(output, "\n") {
+		if match := goFailedTestPattern.FindStringSubmatch(line); match != nil {
+			failed = append(failed, match[1])
+		}
+	}
+	return failed
+}
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+func writeGoModule(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	files["go.mod"] = "module example.com/gen\n\ngo 1.21\n"
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestGoToolkit(t *testing.T) {
+	if _, err := exec.LookPath("go"); err != nil {
+		t.Skip("go toolchain not available")
+	}
+	g := NewGoToolkit()
+
+	broken := writeGoModule(t, map[string]string{
+		"main.go": "package main\n\nfunc main() {\n\tundefinedCall()\n}\n",
+	})
+	out, err := g.GoBuild(GoPathParams{Path: broken})
+	if err != nil {
+		t.Fatalf("GoBuild: %v", err)
+	}
+	result := out.(*GoCheckResult)
+	if result.Success || len(result.Diagnostics) == 0 {
+		t.Fatalf("expected a compile error, got %+v", result)
+	}
+	if d := result.Diagnostics[0]; d.File != "./main.go" && d.File != "main.go" || d.Line != 4 {
+		t.Errorf("unexpected diagnostic: %+v", d)
+	}
+
+	vet := writeGoModule(t, map[string]string{
+		"show.go": "package gen\n\nimport \"fmt\"\n\nfunc Show() { fmt.Printf(\"%d\\n\", \"x\") }\n",
+	})
+	out, _ = g.GoVet(GoPathParams{Path: vet})
+	if result := out.(*GoCheckResult); result.Success || len(result.Diagnostics) == 0 {
+		t.Errorf("expected a vet diagnostic, got %+v", result)
+	}
+
+	pkg := writeGoModule(t, map[string]string{
+		"calc.go":      "package gen\n\nfunc Add(a,b int) int {\nreturn a+b\n}\n",
+		"calc_test.go": "package gen\n\nimport \"testing\"\n\nfunc TestAdd(t *testing.T) {\n\tif Add(1, 1) != 3 {\n\t\tt.Error(\"wrong sum\")\n\t}\n}\n",
+	})
+
+	out, _ = g.GoBuild(GoPathParams{Path: pkg})
+	if result := out.(*GoCheckResult); !result.Success {
+		t.Errorf("expected build to pass, got %+v", result)
+	}
+
+	out, _ = g.GoTest(GoTestParams{Path: pkg, Run: "TestAdd"})
+	result = out.(*GoCheckResult)
+	if result.Success || len(result.FailedTests) != 1 || result.FailedTests[0] != "TestAdd" {
+		t.Errorf("expected TestAdd to fail, got %+v", result)
+	}
+
+	out, _ = g.GofmtCheck(GofmtCheckParams{File: filepath.Join(pkg, "calc.go")})
+	if result := out.(*GoCheckResult); result.Success {
+		t.Errorf("expected calc.go to need formatting, got %+v", result)
+	}
+	out, _ = g.GofmtCheck(GofmtCheckParams{File: filepath.Join(pkg, "calc_test.go")})
+	if result := out.(*GoCheckResult); !result.Success {
+		t.Errorf("expected calc_test.go to be formatted, got %+v", result)
+	}
+}
+
+func TestGoToolkitRejectsFlagPaths(t *testing.T) {
+	g := NewGoToolkit()
+	for _, path := range []string{"-toolexec=/bin/sh", "-exec=rm"} {
+		if _, err := g.GoBuild(GoPathParams{Path: path}); err == nil {
+			t.Errorf("GoBuild(%q): expected an error", path)
+		}
+		if _, err := g.GoTest(GoTestParams{Path: path}); err == nil {
+			t.Errorf("GoTest(%q): expected an error", path)
+		}
+		if _, err := g.GoVet(GoPathParams{Path: path}); err == nil {
+			t.Errorf("GoVet(%q): expected an error", path)
+		}
+		if _, err := g.GofmtCheck(GofmtCheckParams{File: path}); err == nil {
+			t.Errorf("GofmtCheck(%q): expected an error", path)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package tools
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// HTTPToolConfig holds configuration for HTTP requests
+type HTTPToolConfig struct {
+	// AllowedHosts lists the hosts the tool may call: "api.github.com",
+	// "localhost:8080" (that port only), "*.example.com" (subdomains) or "*"
+	// (any host). Requests to any other host, including redirects, are
+	// rejected, so an empty list blocks every request.
+	AllowedHosts []string
+	// AllowedSchemes lists the URL schemes the tool may use (default: https)
+	AllowedSchemes []string
+	// MaxResponseBytes caps the body returned to the model (default: 100000)
+	MaxResponseBytes int64
+	// Timeout bounds each request, including redirects (default: 30s)
+	Timeout time.Duration
+}
+
+// HTTPTool makes HTTP requests to an allowlist of hosts
+type HTTPTool struct {
+	toolkit.Toolkit
+	client           *http.Client
+	allowlist        urlAllowlist
+	maxResponseBytes int64
+}
+
+// HTTPToolResponse is the structured result of a request
+type HTTPToolResponse struct {
+	URL        string            `json:"url"`
+	StatusCode int               `json:"status_code"`
+	Status     string            `json:"status"`
+	Headers    map[string]string `json:"headers"`
+	Body       string            `json:"body"`
+	Truncated  bool              `json:"truncated,omitempty"`
+	Note       string            `json:"note,omitempty"`
+}
+
+// HTTPGetParams represents parameters for GET requests
+type HTTPGetParams struct {
+	URL     string            `json:"url" description:"The URL to request" required:"true"`
+	Headers map[string]string `json:"headers,omitempty" description:"Request headers (optional)"`
+}
+
+// HTTPPostParams represents parameters for POST requests
+type HTTPPostParams struct {
+	URL     string            `json:"url" description:"The URL to request" required:"true"`
+	Body    string            `json:"body,omitempty" description:"Request body. Sent as application/json when it is valid JSON and no Content-Type header is given"`
+	Headers map[string]string `json:"headers,omitempty" description:"Request headers (optional)"`
+}
+
+// NewHTTPTool initializes the tool and registers the methods
+func NewHTTPTool(config HTTPToolConfig) toolkit.Tool {
+	maxBytes := config.MaxResponseBytes
+	if maxBytes <= 0 {
+		maxBytes = 100000
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	httpTool := &HTTPTool{
+		allowlist:        newURLAllowlist(config.AllowedHosts, config.AllowedSchemes),
+		maxResponseBytes: maxBytes,
+	}
+	httpTool.client = httpTool.allowlist.client(timeout)
+
+	httpTool.Toolkit = toolkit.NewToolkit()
+	httpTool.Toolkit.Name = "HTTPTool"
+	httpTool.Toolkit.Description = "Make HTTP GET and POST requests to allowed hosts. Returns the status code, headers and body of the response."
+
+	httpTool.Toolkit.Register("Get", "Send an HTTP GET request", httpTool, httpTool.Get, HTTPGetParams{})
+	httpTool.Toolkit.Register("Post", "Send an HTTP POST request with a body", httpTool, httpTool.Post, HTTPPostParams{})
+
+	return httpTool
+}
+
+// Get sends a GET request
+func (t *HTTPTool) Get(params HTTPGetParams) (interface{}, error) {
+	return t.do(http.MethodGet, params.URL, "", params.Headers)
+}
+
+// Post sends a POST request
+func (t *HTTPTool) Post(params HTTPPostParams) (interface{}, error) {
+	return t.do(http.MethodPost, params.URL, params.Body, params.Headers)
+}
+
+func (t *HTTPTool) do(method, rawURL, body string, headers map[string]string) (interface{}, error) {
+	if rawURL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if err := t.allowlist.check(target); err != nil {
+		return nil, err
+	}
+
+	var reqBody io.Reader
+	if body != "" {
+		reqBody = strings.NewReader(body)
+	}
+	req, err := http.NewRequest(method, target.String(), reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+	if body != "" && req.Header.Get("Content-Type") == "" {
+		if json.Valid([]byte(body)) {
+			req.Header.Set("Content-Type", "application/json")
+		} else {
+			req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+		}
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read one byte past the cap to know whether the body was cut
+	data, err := io.ReadAll(io.LimitReader(resp.Body, t.maxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	result := HTTPToolResponse{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Headers:    make(map[string]string, len(resp.Header)),
+	}
+	for key, values := range resp.Header {
+		result.Headers[key] = strings.Join(values, ", ")
+	}
+	if int64(len(data)) > t.maxResponseBytes {
+		data = data[:t.maxResponseBytes]
+		result.Truncated = true
+		result.Note = fmt.Sprintf("response body truncated to the first %d bytes", t.maxResponseBytes)
+	}
+	result.Body = string(data)
+
+	return result, nil
+}
+
+// urlAllowlist limits the schemes and hosts a tool may request
+type urlAllowlist struct {
+	hosts   []string
+	schemes map[string]bool
+}
+
+// newURLAllowlist builds an allowlist; schemes default to https
+func newURLAllowlist(hosts, schemes []string) urlAllowlist {
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+
+	a := urlAllowlist{schemes: make(map[string]bool, len(schemes))}
+	for _, scheme := range schemes {
+		a.schemes[strings.ToLower(scheme)] = true
+	}
+	for _, host := range hosts {
+		a.hosts = append(a.hosts, strings.ToLower(host))
+	}
+	return a
+}
+
+// client returns an HTTP client that also checks every redirect
+func (a urlAllowlist) client(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= 10 {
+				return errors.New("stopped after 10 redirects")
+			}
+			return a.check(req.URL)
+		},
+	}
+}
+
+// check rejects URLs whose scheme or host is not allowed
+func (a urlAllowlist) check(u *url.URL) error {
+	scheme := strings.ToLower(u.Scheme)
+	if !a.schemes[scheme] {
+		return fmt.Errorf("scheme %q is not allowed", u.Scheme)
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return fmt.Errorf("url has no host")
+	}
+	hostPort := host
+	if port := u.Port(); port != "" {
+		hostPort = net.JoinHostPort(host, port)
+	}
+
+	for _, allowed := range a.hosts {
+		switch {
+		case allowed == "*":
+			return nil
+		case allowed == host || allowed == hostPort:
+			return nil
+		case strings.HasPrefix(allowed, "*.") && strings.HasSuffix(host, allowed[1:]):
+			return nil
+		}
+	}
+	return fmt.Errorf("host %q is not in the allowed hosts", u.Host)
+}
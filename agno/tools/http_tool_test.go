@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestHTTPTool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/status":
+			w.Header().Set("X-Request-Id", "abc")
+			fmt.Fprint(w, `{"ok": true}`)
+		case "/echo":
+			body, _ := io.ReadAll(r.Body)
+			fmt.Fprintf(w, "%s %s", r.Header.Get("Content-Type"), body)
+		case "/large":
+			fmt.Fprint(w, strings.Repeat("x", 50))
+		case "/redirect":
+			http.Redirect(w, r, "http://example.com/", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewHTTPTool(HTTPToolConfig{
+		AllowedHosts:     []string{"127.0.0.1"},
+		AllowedSchemes:   []string{"http"},
+		MaxResponseBytes: 20,
+	})
+
+	out, err := tool.Execute("HTTPTool_Get", json.RawMessage(fmt.Sprintf(`{"url": %q}`, server.URL+"/status")))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	resp := out.(HTTPToolResponse)
+	if resp.StatusCode != 200 || resp.Body != `{"ok": true}` || resp.Headers["X-Request-Id"] != "abc" || resp.Truncated {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	out, err = tool.Execute("HTTPTool_Post", json.RawMessage(fmt.Sprintf(`{"url": %q, "body": "[1]"}`, server.URL+"/echo")))
+	if err != nil {
+		t.Fatalf("Post: %v", err)
+	}
+	if body := out.(HTTPToolResponse).Body; body != "application/json [1]" {
+		t.Errorf("unexpected echo: %q", body)
+	}
+
+	out, err = tool.Execute("HTTPTool_Get", json.RawMessage(fmt.Sprintf(`{"url": %q}`, server.URL+"/large")))
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if resp := out.(HTTPToolResponse); len(resp.Body) != 20 || !resp.Truncated || resp.Note == "" {
+		t.Errorf("expected a truncated body, got %+v", resp)
+	}
+
+	for name, target := range map[string]string{
+		"host":     "http://example.com/",
+		"scheme":   strings.Replace(server.URL, "http://", "ftp://", 1),
+		"redirect": server.URL + "/redirect",
+	} {
+		if _, err := tool.Execute("HTTPTool_Get", json.RawMessage(fmt.Sprintf(`{"url": %q}`, target))); err == nil {
+			t.Errorf("expected the %s check to reject %s", name, target)
+		}
+	}
+}
+
+func TestHTTPToolHostMatching(t *testing.T) {
+	tool := NewHTTPTool(HTTPToolConfig{AllowedHosts: []string{"*.example.com", "localhost:8080"}}).(*HTTPTool)
+
+	for target, allowed := range map[string]bool{
+		"https://api.example.com/v1":  true,
+		"https://example.com/":        false,
+		"https://evilexample.com/":    false,
+		"https://localhost:8080/":     true,
+		"https://localhost:9090/":     false,
+		"http://api.example.com/":     false,
+		"https://api.example.com.io/": false,
+	} {
+		u, _ := url.Parse(target)
+		if err := tool.allowlist.check(u); (err == nil) != allowed {
+			t.Errorf("%s: expected allowed=%v, got %v", target, allowed, err)
+		}
+	}
+
+	wildcard := newURLAllowlist([]string{"*"}, nil)
+	if u, _ := url.Parse("https://anything.test/"); wildcard.check(u) != nil {
+		t.Error("expected * to allow any host")
+	}
+}
@@ -0,0 +1,623 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// openAPIMaxResponseBytes caps the response body returned to the model
+const openAPIMaxResponseBytes = 100000
+
+// openAPIMethods are the HTTP methods an OpenAPI path item may describe
+var openAPIMethods = []string{"get", "put", "post", "delete", "patch", "head", "options"}
+
+// OpenAPITool exposes each operation of an OpenAPI 3 spec as a tool method
+type OpenAPITool struct {
+	name        string
+	description string
+	baseURL     string
+	authHeader  string
+	client      *http.Client
+	methods     map[string]toolkit.Method
+	operations  map[string]*openAPIOperation
+}
+
+// openAPIOperation is an operation of the spec and where each of its tool
+// arguments goes in the request
+type openAPIOperation struct {
+	method string
+	path   string
+	// in maps an argument to "path", "query", "header" or "body", the
+	// latter for a field of a flattened JSON object body
+	in    map[string]string
+	names map[string]string // argument -> parameter name in the request
+	// bodyArg is the argument holding the whole body when it is not flattened
+	bodyArg string
+}
+
+// NewToolkitFromOpenAPI builds a tool from an OpenAPI 3 spec in JSON or YAML,
+// with one method per operation named after its operationId. Path, query and
+// header parameters and the JSON request body become the method's
+// parameters; a body that is an object is flattened into its fields.
+//
+// baseURL overrides the first server of the spec. authHeader is sent with
+// every request, either as the Authorization value ("Bearer <token>") or as a
+// whole header ("X-API-Key: <key>"); leave it empty for public APIs.
+func NewToolkitFromOpenAPI(specBytes []byte, baseURL string, authHeader string) (toolkit.Tool, error) {
+	var spec map[string]interface{}
+	if err := yaml.Unmarshal(specBytes, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+	if version, _ := spec["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported spec: expected OpenAPI 3.x, got %q", version)
+	}
+
+	if baseURL == "" {
+		if servers, ok := spec["servers"].([]interface{}); ok && len(servers) > 0 {
+			if server, ok := servers[0].(map[string]interface{}); ok {
+				baseURL, _ = server["url"].(string)
+			}
+		}
+	}
+	if u, err := url.Parse(baseURL); err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("a base URL is required: the spec has no absolute server URL")
+	}
+
+	info, _ := spec["info"].(map[string]interface{})
+	title, _ := info["title"].(string)
+	description, _ := info["description"].(string)
+
+	// Tool names can't have underscores: they separate the tool and method
+	name := openAPIIdentifier(title, true)
+	if name == "" {
+		name = "OpenAPI"
+	}
+	if description == "" {
+		description = fmt.Sprintf("Call the %s API", strings.TrimSpace(title+" "))
+	}
+
+	t := &OpenAPITool{
+		name:        name,
+		description: description,
+		baseURL:     strings.TrimRight(baseURL, "/"),
+		authHeader:  authHeader,
+		methods:     make(map[string]toolkit.Method),
+		operations:  make(map[string]*openAPIOperation),
+	}
+	t.client = &http.Client{Timeout: 30 * time.Second, CheckRedirect: t.checkRedirect}
+
+	paths, _ := spec["paths"].(map[string]interface{})
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	r := &openAPIResolver{spec: spec}
+	for _, path := range pathNames {
+		item, _ := r.resolve(paths[path]).(map[string]interface{})
+		for _, method := range openAPIMethods {
+			op, ok := item[method].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if err := t.addOperation(r, path, method, item, op); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(t.methods) == 0 {
+		return nil, fmt.Errorf("the OpenAPI spec has no operations")
+	}
+
+	return t, nil
+}
+
+// authHeaderField splits the configured auth header into the header name and
+// value, using Authorization when it is a bare value
+func (t *OpenAPITool) authHeaderField() (string, string) {
+	if name, value, ok := strings.Cut(t.authHeader, ":"); ok && !strings.ContainsAny(name, " \t") {
+		return name, strings.TrimSpace(value)
+	}
+	return "Authorization", t.authHeader
+}
+
+// checkRedirect follows up to 10 redirects like the default client, but drops
+// the auth header when a redirect leaves the host of the original request, so
+// the credentials are never sent to another server
+func (t *OpenAPITool) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return fmt.Errorf("stopped after 10 redirects")
+	}
+	if t.authHeader != "" && req.URL.Host != via[0].URL.Host {
+		name, _ := t.authHeaderField()
+		req.Header.Del(name)
+	}
+	return nil
+}
+
+// addOperation registers an operation as a method
+func (t *OpenAPITool) addOperation(r *openAPIResolver, path, method string, item, op map[string]interface{}) error {
+	opID, _ := op["operationId"].(string)
+	methodName := t.name + "_" + openAPIIdentifier(opID, false)
+	if opID == "" {
+		methodName = t.name + "_" + openAPIIdentifier(method+" "+path, false)
+	}
+	if _, exists := t.methods[methodName]; exists {
+		return fmt.Errorf("duplicate operation %s (%s %s)", methodName, strings.ToUpper(method), path)
+	}
+
+	operation := &openAPIOperation{
+		method: strings.ToUpper(method),
+		path:   path,
+		in:     make(map[string]string),
+		names:  make(map[string]string),
+	}
+	properties := make(map[string]interface{})
+	required := []string{}
+
+	// Operation parameters override path-level ones with the same name and location
+	params := make(map[string]map[string]interface{})
+	var order []string
+	for _, list := range []interface{}{item["parameters"], op["parameters"]} {
+		entries, _ := list.([]interface{})
+		for _, entry := range entries {
+			param, ok := r.resolve(entry).(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := param["name"].(string)
+			in, _ := param["in"].(string)
+			if name == "" || (in != "path" && in != "query" && in != "header") {
+				continue
+			}
+			key := in + ":" + name
+			if _, seen := params[key]; !seen {
+				order = append(order, key)
+			}
+			params[key] = param
+		}
+	}
+
+	for _, key := range order {
+		param := params[key]
+		name, _ := param["name"].(string)
+		in, _ := param["in"].(string)
+
+		arg := name
+		if _, taken := operation.in[arg]; taken {
+			arg = in + "_" + name
+		}
+		operation.in[arg] = in
+		operation.names[arg] = name
+
+		schema := r.schema(param["schema"], 0)
+		if desc, _ := param["description"].(string); desc != "" {
+			schema["description"] = desc
+		}
+		properties[arg] = schema
+		if isRequired, _ := param["required"].(bool); isRequired || in == "path" {
+			required = append(required, arg)
+		}
+	}
+
+	if body, ok := r.resolve(op["requestBody"]).(map[string]interface{}); ok {
+		content, _ := body["content"].(map[string]interface{})
+		media, ok := content["application/json"].(map[string]interface{})
+		if !ok {
+			// Take any JSON-like media type, e.g. application/merge-patch+json
+			for mediaType, value := range content {
+				if strings.Contains(mediaType, "json") {
+					media, _ = value.(map[string]interface{})
+					break
+				}
+			}
+		}
+		if media != nil {
+			bodyRequired, _ := body["required"].(bool)
+			schema := r.schema(media["schema"], 0)
+			fields, _ := schema["properties"].(map[string]interface{})
+
+			// Flatten an object body when none of its fields clash with a parameter
+			flatten := len(fields) > 0
+			for field := range fields {
+				if _, taken := operation.in[field]; taken {
+					flatten = false
+				}
+			}
+			if flatten {
+				requiredFields, _ := schema["required"].([]string)
+				for field, fieldSchema := range fields {
+					operation.in[field] = "body"
+					operation.names[field] = field
+					properties[field] = fieldSchema
+				}
+				if bodyRequired {
+					required = append(required, requiredFields...)
+				}
+			} else {
+				operation.bodyArg = "body"
+				if _, taken := operation.in["body"]; taken {
+					operation.bodyArg = "request_body"
+				}
+				if desc, _ := body["description"].(string); desc != "" {
+					schema["description"] = desc
+				} else if schema["description"] == nil {
+					schema["description"] = "JSON request body"
+				}
+				properties[operation.bodyArg] = schema
+				if bodyRequired {
+					required = append(required, operation.bodyArg)
+				}
+			}
+		}
+	}
+	sort.Strings(required)
+
+	summary, _ := op["summary"].(string)
+	description, _ := op["description"].(string)
+	parts := []string{}
+	for _, text := range []string{summary, description} {
+		if text = strings.TrimSpace(text); text != "" {
+			parts = append(parts, text)
+		}
+	}
+	parts = append(parts, fmt.Sprintf("(%s %s)", operation.method, path))
+
+	t.operations[methodName] = operation
+	t.methods[methodName] = toolkit.Method{
+		Receiver:    t,
+		Description: strings.Join(parts, " "),
+		Function: func(args map[string]interface{}) (interface{}, error) {
+			return t.call(context.Background(), operation, args)
+		},
+		Schema: map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+			"required":   required,
+		},
+		ParamType: reflect.TypeOf(map[string]interface{}{}),
+	}
+	return nil
+}
+
+// GetName returns the tool name, taken from the spec title
+func (t *OpenAPITool) GetName() string {
+	return t.name
+}
+
+// GetDescription returns the API description
+func (t *OpenAPITool) GetDescription() string {
+	return t.description
+}
+
+// GetMethods returns one method per operation
+func (t *OpenAPITool) GetMethods() map[string]toolkit.Method {
+	return t.methods
+}
+
+// GetParameterStruct returns the JSON schema of an operation's parameters
+func (t *OpenAPITool) GetParameterStruct(methodName string) map[string]interface{} {
+	return t.methods[methodName].Schema
+}
+
+// GetFunction returns the function calling an operation
+func (t *OpenAPITool) GetFunction(methodName string) interface{} {
+	return t.methods[methodName].Function
+}
+
+// GetDescriptionOfMethod returns an operation's summary and route
+func (t *OpenAPITool) GetDescriptionOfMethod(methodName string) string {
+	return t.methods[methodName].Description
+}
+
+// Execute calls an operation
+func (t *OpenAPITool) Execute(methodName string, input json.RawMessage) (interface{}, error) {
+	return t.ExecuteContext(context.Background(), methodName, input)
+}
+
+// ExecuteContext calls an operation, cancelling the request with ctx
+func (t *OpenAPITool) ExecuteContext(ctx context.Context, methodName string, input json.RawMessage) (interface{}, error) {
+	operation, ok := t.operations[methodName]
+	if !ok {
+		return nil, fmt.Errorf("operation %s not found", methodName)
+	}
+
+	args := make(map[string]interface{})
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &args); err != nil {
+			return nil, fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+	return t.call(ctx, operation, args)
+}
+
+// call sends the request of an operation and returns the response
+func (t *OpenAPITool) call(ctx context.Context, operation *openAPIOperation, args map[string]interface{}) (interface{}, error) {
+	path := operation.path
+	query := url.Values{}
+	headers := http.Header{}
+	var body interface{}
+	fields := make(map[string]interface{})
+
+	for arg, value := range args {
+		if value == nil {
+			continue
+		}
+		name := operation.names[arg]
+		switch operation.in[arg] {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+name+"}", url.PathEscape(openAPIString(value)))
+		case "query":
+			if values, ok := value.([]interface{}); ok {
+				for _, v := range values {
+					query.Add(name, openAPIString(v))
+				}
+			} else {
+				query.Set(name, openAPIString(value))
+			}
+		case "header":
+			headers.Set(name, openAPIString(value))
+		case "body":
+			fields[name] = value
+		default:
+			if arg == operation.bodyArg && operation.bodyArg != "" {
+				body = value
+			}
+		}
+	}
+	if len(fields) > 0 {
+		body = fields
+	}
+	if strings.Contains(path, "{") {
+		return nil, fmt.Errorf("missing path parameter in %s", path)
+	}
+
+	target := t.baseURL + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode request body: %w", err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, operation.method, target, reqBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	for key, values := range headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if t.authHeader != "" {
+		name, value := t.authHeaderField()
+		req.Header.Set(name, value)
+	}
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Read one byte past the cap to know whether the body was cut
+	data, err := io.ReadAll(io.LimitReader(resp.Body, openAPIMaxResponseBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	result := HTTPToolResponse{
+		URL:        resp.Request.URL.String(),
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Headers:    map[string]string{"Content-Type": resp.Header.Get("Content-Type")},
+	}
+	if len(data) > openAPIMaxResponseBytes {
+		data = data[:openAPIMaxResponseBytes]
+		result.Truncated = true
+		result.Note = fmt.Sprintf("response body truncated to the first %d bytes", openAPIMaxResponseBytes)
+	}
+	result.Body = string(data)
+	return result, nil
+}
+
+// openAPIString formats a parameter value for a URL or header
+func openAPIString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strings.TrimSuffix(strings.TrimRight(fmt.Sprintf("%f", v), "0"), ".")
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = openAPIString(item)
+		}
+		return strings.Join(parts, ",")
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// openAPIIdentifier turns text into a name usable for a tool or method:
+// letters and digits, words joined in camel case. Method names keep
+// underscores.
+func openAPIIdentifier(text string, upper bool) string {
+	var sb strings.Builder
+	nextUpper := upper
+	for _, r := range text {
+		switch {
+		case r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)):
+			if nextUpper {
+				r = unicode.ToUpper(r)
+			}
+			sb.WriteRune(r)
+			nextUpper = false
+		case r == '_' && !upper:
+			sb.WriteRune(r)
+		default:
+			nextUpper = sb.Len() > 0 || upper
+		}
+	}
+	return sb.String()
+}
+
+// openAPIResolver follows local $ref pointers of a spec
+type openAPIResolver struct {
+	spec map[string]interface{}
+}
+
+// resolve follows $ref until it reaches a value that is not a reference
+func (r *openAPIResolver) resolve(value interface{}) interface{} {
+	for i := 0; i < 10; i++ {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		ref, ok := m["$ref"].(string)
+		if !ok {
+			return value
+		}
+		value = r.lookup(ref)
+	}
+	return nil
+}
+
+// lookup returns the value of a "#/a/b" JSON pointer
+func (r *openAPIResolver) lookup(ref string) interface{} {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil
+	}
+	var current interface{} = r.spec
+	for _, part := range strings.Split(ref[2:], "/") {
+		part = strings.ReplaceAll(strings.ReplaceAll(part, "~1", "/"), "~0", "~")
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[part]
+	}
+	return current
+}
+
+// schema converts an OpenAPI schema into the JSON schema of a tool
+// parameter, resolving references. Nesting is cut at a few levels to stop
+// recursive schemas.
+func (r *openAPIResolver) schema(value interface{}, depth int) map[string]interface{} {
+	source, _ := r.resolve(value).(map[string]interface{})
+
+	// allOf merges the fields of its schemas
+	if allOf, ok := source["allOf"].([]interface{}); ok {
+		merged := map[string]interface{}{"type": "object"}
+		properties := map[string]interface{}{}
+		var required []string
+		for _, part := range allOf {
+			sub := r.schema(part, depth)
+			if props, ok := sub["properties"].(map[string]interface{}); ok {
+				for k, v := range props {
+					properties[k] = v
+				}
+			}
+			if req, ok := sub["required"].([]string); ok {
+				required = append(required, req...)
+			}
+			if desc, ok := sub["description"]; ok {
+				merged["description"] = desc
+			}
+		}
+		merged["properties"] = properties
+		if len(required) > 0 {
+			merged["required"] = required
+		}
+		return merged
+	}
+	// oneOf/anyOf: describe the first alternative
+	for _, key := range []string{"oneOf", "anyOf"} {
+		if alternatives, ok := source[key].([]interface{}); ok && len(alternatives) > 0 {
+			return r.schema(alternatives[0], depth)
+		}
+	}
+
+	schema := make(map[string]interface{})
+	typ, _ := source["type"].(string)
+	if typ == "" {
+		// OpenAPI 3.1 allows a list of types, e.g. ["string", "null"]
+		if types, ok := source["type"].([]interface{}); ok {
+			for _, t := range types {
+				if s, _ := t.(string); s != "null" && s != "" {
+					typ = s
+					break
+				}
+			}
+		}
+	}
+	if typ == "" {
+		if _, ok := source["properties"]; ok {
+			typ = "object"
+		} else {
+			typ = "string"
+		}
+	}
+	schema["type"] = typ
+
+	description, _ := source["description"].(string)
+	if format, _ := source["format"].(string); format != "" {
+		description = strings.TrimSpace(description + " (format: " + format + ")")
+	}
+	schema["description"] = description
+	if enum, ok := source["enum"].([]interface{}); ok {
+		schema["enum"] = enum
+	}
+	if def, ok := source["default"]; ok {
+		schema["default"] = def
+	}
+
+	if depth >= 4 {
+		return schema
+	}
+	switch typ {
+	case "array":
+		schema["items"] = r.schema(source["items"], depth+1)
+	case "object":
+		if props, ok := source["properties"].(map[string]interface{}); ok {
+			properties := make(map[string]interface{}, len(props))
+			for name, prop := range props {
+				properties[name] = r.schema(prop, depth+1)
+			}
+			schema["properties"] = properties
+		}
+		if req, ok := source["required"].([]interface{}); ok {
+			required := make([]string, 0, len(req))
+			for _, field := range req {
+				if s, ok := field.(string); ok {
+					required = append(required, s)
+				}
+			}
+			schema["required"] = required
+		}
+	}
+	return schema
+}
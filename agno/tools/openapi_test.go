@@ -0,0 +1,264 @@
+package tools
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const petStoreSpec = `
+openapi: 3.0.3
+info:
+  title: Pet Store
+  description: Manage the pets of the store
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List pets
+      parameters:
+        - name: tags
+          in: query
+          schema:
+            type: array
+            items:
+              type: string
+        - name: limit
+          in: query
+          schema:
+            type: integer
+    post:
+      operationId: createPet
+      summary: Create a pet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/NewPet'
+  /pets/{petId}:
+    parameters:
+      - $ref: '#/components/parameters/PetId'
+    get:
+      operationId: get-pet
+      summary: Get a pet
+    put:
+      summary: Replace a pet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                petId:
+                  type: string
+                name:
+                  type: string
+components:
+  parameters:
+    PetId:
+      name: petId
+      in: path
+      description: The pet id
+      schema:
+        type: string
+  schemas:
+    NewPet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        tag:
+          type: string
+`
+
+type recordedRequest struct {
+	method string
+	path   string
+	query  string
+	auth   string
+	body   map[string]interface{}
+}
+
+func newPetStoreServer(t *testing.T, requests chan<- recordedRequest) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := recordedRequest{method: r.Method, path: r.URL.EscapedPath(), query: r.URL.RawQuery, auth: r.Header.Get("Authorization")}
+		if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+			if err := json.Unmarshal(data, &rec.body); err != nil {
+				t.Errorf("Invalid JSON body %q: %v", data, err)
+			}
+		}
+		requests <- rec
+		w.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(r.URL.Path, "/missing") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"not found"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewToolkitFromOpenAPI(t *testing.T) {
+	requests := make(chan recordedRequest, 1)
+	server := newPetStoreServer(t, requests)
+
+	tool, err := NewToolkitFromOpenAPI([]byte(petStoreSpec), server.URL, "Bearer secret")
+	if err != nil {
+		t.Fatalf("NewToolkitFromOpenAPI failed: %v", err)
+	}
+	if tool.GetName() != "PetStore" {
+		t.Errorf("Expected tool name PetStore, got %q", tool.GetName())
+	}
+
+	methods := tool.GetMethods()
+	for _, name := range []string{"PetStore_listPets", "PetStore_createPet", "PetStore_getPet", "PetStore_putPetsPetId"} {
+		if _, ok := methods[name]; !ok {
+			t.Errorf("Expected method %s, got %v", name, methods)
+		}
+	}
+
+	schema := tool.GetParameterStruct("PetStore_createPet")
+	properties := schema["properties"].(map[string]interface{})
+	if _, ok := properties["name"]; !ok {
+		t.Errorf("Expected the object body to be flattened, got %v", properties)
+	}
+	if required := schema["required"].([]string); len(required) != 1 || required[0] != "name" {
+		t.Errorf("Expected name to be required, got %v", required)
+	}
+
+	// A body field clashing with a parameter keeps the body whole
+	putProperties := tool.GetParameterStruct("PetStore_putPetsPetId")["properties"].(map[string]interface{})
+	if _, ok := putProperties["body"]; !ok {
+		t.Errorf("Expected a body property, got %v", putProperties)
+	}
+
+	tests := []struct {
+		method string
+		input  string
+		want   recordedRequest
+	}{
+		{"PetStore_listPets", `{"tags": ["a", "b"], "limit": 5}`, recordedRequest{method: "GET", path: "/pets", query: "limit=5&tags=a&tags=b"}},
+		{"PetStore_getPet", `{"petId": "a b"}`, recordedRequest{method: "GET", path: "/pets/a%20b"}},
+		{"PetStore_createPet", `{"name": "Rex", "tag": "dog"}`, recordedRequest{method: "POST", path: "/pets", body: map[string]interface{}{"name": "Rex", "tag": "dog"}}},
+		{"PetStore_putPetsPetId", `{"petId": "7", "body": {"petId": "7", "name": "Rex"}}`, recordedRequest{method: "PUT", path: "/pets/7", body: map[string]interface{}{"petId": "7", "name": "Rex"}}},
+	}
+	for _, tt := range tests {
+		result, err := tool.Execute(tt.method, json.RawMessage(tt.input))
+		if err != nil {
+			t.Fatalf("%s failed: %v", tt.method, err)
+		}
+		got := <-requests
+		if got.method != tt.want.method || got.path != tt.want.path || got.query != tt.want.query {
+			t.Errorf("%s: expected %s %s?%s, got %s %s?%s", tt.method, tt.want.method, tt.want.path, tt.want.query, got.method, got.path, got.query)
+		}
+		if got.auth != "Bearer secret" {
+			t.Errorf("%s: expected the auth header, got %q", tt.method, got.auth)
+		}
+		gotBody, _ := json.Marshal(got.body)
+		wantBody, _ := json.Marshal(tt.want.body)
+		if string(gotBody) != string(wantBody) {
+			t.Errorf("%s: expected body %s, got %s", tt.method, wantBody, gotBody)
+		}
+		if response := result.(HTTPToolResponse); response.Body != `{"ok":true}` {
+			t.Errorf("%s: expected the response body, got %q", tt.method, response.Body)
+		}
+	}
+
+	// Error statuses are returned to the model, not raised
+	result, err := tool.Execute("PetStore_getPet", json.RawMessage(`{"petId": "missing"}`))
+	<-requests
+	if err != nil {
+		t.Fatalf("Expected the 404 response as a result, got %v", err)
+	}
+	if response := result.(HTTPToolResponse); response.StatusCode != http.StatusNotFound || !strings.Contains(response.Body, "not found") {
+		t.Errorf("Expected the 404 response, got %+v", response)
+	}
+
+	if _, err := tool.Execute("PetStore_getPet", json.RawMessage(`{}`)); err == nil {
+		t.Error("Expected an error for a missing path parameter")
+	}
+}
+
+func TestNewToolkitFromOpenAPIAuthHeader(t *testing.T) {
+	var apiKey string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKey = r.Header.Get("X-API-Key")
+	}))
+	defer server.Close()
+
+	// JSON specs work too, and the server URL comes from the spec
+	spec := `{"openapi": "3.1.0", "info": {"title": "ping"}, "servers": [{"url": "` + server.URL + `"}],
+		"paths": {"/ping": {"get": {"operationId": "ping"}}}}`
+	tool, err := NewToolkitFromOpenAPI([]byte(spec), "", "X-API-Key: key123")
+	if err != nil {
+		t.Fatalf("NewToolkitFromOpenAPI failed: %v", err)
+	}
+	if _, err := tool.Execute("Ping_ping", nil); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if apiKey != "key123" {
+		t.Errorf("Expected the X-API-Key header, got %q", apiKey)
+	}
+}
+
+func TestNewToolkitFromOpenAPIRedirectDropsAuthHeader(t *testing.T) {
+	leaked := "not called"
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked = r.Header.Get("X-API-Key")
+	}))
+	defer other.Close()
+
+	var apiKeys []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		apiKeys = append(apiKeys, r.Header.Get("X-API-Key"))
+		switch r.URL.Path {
+		case "/moved":
+			http.Redirect(w, r, "/ping", http.StatusFound)
+		case "/away":
+			http.Redirect(w, r, other.URL+"/ping", http.StatusFound)
+		}
+	}))
+	defer server.Close()
+
+	spec := `{"openapi": "3.1.0", "info": {"title": "ping"},
+		"paths": {"/moved": {"get": {"operationId": "moved"}}, "/away": {"get": {"operationId": "away"}}}}`
+	tool, err := NewToolkitFromOpenAPI([]byte(spec), server.URL, "X-API-Key: key123")
+	if err != nil {
+		t.Fatalf("NewToolkitFromOpenAPI failed: %v", err)
+	}
+
+	// Redirects within the API host keep the header
+	if _, err := tool.Execute("Ping_moved", nil); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if len(apiKeys) != 2 || apiKeys[1] != "key123" {
+		t.Errorf("Expected the X-API-Key header after a same-host redirect, got %q", apiKeys)
+	}
+
+	if _, err := tool.Execute("Ping_away", nil); err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if leaked != "" {
+		t.Errorf("Expected the X-API-Key header to be dropped on a cross-host redirect, got %q", leaked)
+	}
+}
+
+func TestNewToolkitFromOpenAPIInvalidSpec(t *testing.T) {
+	for name, spec := range map[string]string{
+		"swagger 2":     `{"swagger": "2.0", "paths": {}}`,
+		"no base url":   `{"openapi": "3.0.0", "info": {"title": "x"}, "paths": {"/a": {"get": {}}}}`,
+		"no operations": `{"openapi": "3.0.0", "servers": [{"url": "https://example.com"}], "paths": {}}`,
+	} {
+		if _, err := NewToolkitFromOpenAPI([]byte(spec), "", ""); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
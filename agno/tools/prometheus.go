@@ -0,0 +1,338 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// PrometheusTool queries a Prometheus-compatible HTTP API (Prometheus, Thanos,
+// Mimir, VictoriaMetrics) so ops agents can answer questions with live metrics.
+type PrometheusTool struct {
+	toolkit.Toolkit
+	baseURL     string
+	bearerToken string
+	timeout     time.Duration
+	maxSeries   int
+	maxPoints   int
+	httpClient  *http.Client
+}
+
+// PrometheusOption configures a PrometheusTool
+type PrometheusOption func(*PrometheusTool)
+
+// WithPrometheusTimeout sets the query timeout, also sent to Prometheus (default 30s)
+func WithPrometheusTimeout(timeout time.Duration) PrometheusOption {
+	return func(p *PrometheusTool) {
+		p.timeout = timeout
+	}
+}
+
+// WithPrometheusMaxSeries caps the number of series returned to the model (default 50)
+func WithPrometheusMaxSeries(maxSeries int) PrometheusOption {
+	return func(p *PrometheusTool) {
+		p.maxSeries = maxSeries
+	}
+}
+
+// WithPrometheusMaxPoints caps the number of samples per series in range queries (default 200)
+func WithPrometheusMaxPoints(maxPoints int) PrometheusOption {
+	return func(p *PrometheusTool) {
+		p.maxPoints = maxPoints
+	}
+}
+
+// WithPrometheusBearerToken authenticates requests with a bearer token
+func WithPrometheusBearerToken(token string) PrometheusOption {
+	return func(p *PrometheusTool) {
+		p.bearerToken = token
+	}
+}
+
+// PromQueryParams represents parameters for an instant query
+type PromQueryParams struct {
+	Expr string `json:"expr" description:"PromQL expression, e.g. histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))" required:"true"`
+	Time string `json:"time,omitempty" description:"Evaluation time: RFC3339, unix timestamp, 'now' or 'now-1h'. Default: now."`
+}
+
+// PromRangeQueryParams represents parameters for a range query
+type PromRangeQueryParams struct {
+	Expr  string `json:"expr" description:"PromQL expression" required:"true"`
+	Start string `json:"start" description:"Range start: RFC3339, unix timestamp, 'now' or 'now-1h'" required:"true"`
+	End   string `json:"end,omitempty" description:"Range end, same formats as start. Default: now."`
+	Step  string `json:"step,omitempty" description:"Resolution step as a duration (e.g. 30s, 5m) or seconds. Default: 60s."`
+}
+
+// PromSample is a single sample; Value is kept as returned by Prometheus
+// because it may be NaN or +Inf, which JSON numbers cannot represent
+type PromSample struct {
+	Timestamp time.Time `json:"timestamp"`
+	Value     string    `json:"value"`
+}
+
+// PromSeries is one series of a vector or matrix result
+type PromSeries struct {
+	Metric map[string]string `json:"metric"`
+	Value  *PromSample       `json:"value,omitempty"`
+	Values []PromSample      `json:"values,omitempty"`
+}
+
+// PromQueryResult is the parsed result of a Prometheus query
+type PromQueryResult struct {
+	ResultType  string       `json:"result_type"`
+	Series      []PromSeries `json:"series,omitempty"`
+	Scalar      *PromSample  `json:"scalar,omitempty"`
+	TotalSeries int          `json:"total_series"`
+	Truncated   bool         `json:"truncated,omitempty"`
+	Warnings    []string     `json:"warnings,omitempty"`
+}
+
+// promResponse is the envelope of the Prometheus HTTP API
+type promResponse struct {
+	Status    string   `json:"status"`
+	ErrorType string   `json:"errorType"`
+	Error     string   `json:"error"`
+	Warnings  []string `json:"warnings"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+// NewPrometheusTool creates a new Prometheus tool.
+// If baseURL is empty, it is read from the PROMETHEUS_URL environment variable.
+func NewPrometheusTool(baseURL string, options ...PrometheusOption) *PrometheusTool {
+	if baseURL == "" {
+		baseURL = os.Getenv("PROMETHEUS_URL")
+	}
+
+	p := &PrometheusTool{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		timeout:    30 * time.Second,
+		maxSeries:  50,
+		maxPoints:  200,
+		httpClient: &http.Client{},
+	}
+	for _, opt := range options {
+		opt(p)
+	}
+
+	tk := toolkit.NewToolkit()
+	tk.Name = "PrometheusTool"
+	tk.Description = "Query Prometheus metrics with PromQL: current values (instant queries) and trends over time (range queries)."
+
+	p.Toolkit = tk
+	p.Toolkit.Register("prom_query", "Evaluate a PromQL expression at a single point in time, e.g. the current p99 latency or error rate.", p, p.Query, PromQueryParams{})
+	p.Toolkit.Register("prom_range_query", "Evaluate a PromQL expression over a time range, returning samples at each step.", p, p.RangeQuery, PromRangeQueryParams{})
+
+	return p
+}
+
+// Query runs an instant query
+func (p *PrometheusTool) Query(params PromQueryParams) (interface{}, error) {
+	if params.Expr == "" {
+		return nil, fmt.Errorf("expr is required")
+	}
+
+	values := url.Values{}
+	values.Set("query", params.Expr)
+	if params.Time != "" {
+		t, err := parsePromTime(params.Time)
+		if err != nil {
+			return nil, err
+		}
+		values.Set("time", t)
+	}
+
+	return p.query("/api/v1/query", values)
+}
+
+// RangeQuery runs a range query
+func (p *PrometheusTool) RangeQuery(params PromRangeQueryParams) (interface{}, error) {
+	if params.Expr == "" {
+		return nil, fmt.Errorf("expr is required")
+	}
+	if params.Start == "" {
+		return nil, fmt.Errorf("start is required")
+	}
+
+	start, err := parsePromTime(params.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parsePromTime(params.End)
+	if err != nil {
+		return nil, err
+	}
+	step := params.Step
+	if step == "" {
+		step = "60s"
+	}
+
+	values := url.Values{}
+	values.Set("query", params.Expr)
+	values.Set("start", start)
+	values.Set("end", end)
+	values.Set("step", step)
+
+	return p.query("/api/v1/query_range", values)
+}
+
+// query calls the Prometheus API and parses the result
+func (p *PrometheusTool) query(path string, values url.Values) (*PromQueryResult, error) {
+	if p.baseURL == "" {
+		return nil, fmt.Errorf("PROMETHEUS_URL must be set")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.timeout)
+	defer cancel()
+	values.Set("timeout", p.timeout.String())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if p.bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+p.bearerToken)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("prometheus request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var envelope promResponse
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, fmt.Errorf("prometheus API error (status %d): %s", resp.StatusCode, string(body))
+	}
+	if envelope.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed (%s): %s", envelope.ErrorType, envelope.Error)
+	}
+
+	return p.parseResult(envelope)
+}
+
+// parseResult converts the raw result into series, applying the size caps
+func (p *PrometheusTool) parseResult(envelope promResponse) (*PromQueryResult, error) {
+	result := &PromQueryResult{
+		ResultType: envelope.Data.ResultType,
+		Warnings:   envelope.Warnings,
+	}
+
+	switch envelope.Data.ResultType {
+	case "scalar", "string":
+		var raw []interface{}
+		if err := json.Unmarshal(envelope.Data.Result, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse %s result: %w", envelope.Data.ResultType, err)
+		}
+		sample, err := parsePromSample(raw)
+		if err != nil {
+			return nil, err
+		}
+		result.Scalar = &sample
+		return result, nil
+
+	case "vector", "matrix":
+		var raw []struct {
+			Metric map[string]string `json:"metric"`
+			Value  []interface{}     `json:"value"`
+			Values [][]interface{}   `json:"values"`
+		}
+		if err := json.Unmarshal(envelope.Data.Result, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse %s result: %w", envelope.Data.ResultType, err)
+		}
+
+		result.TotalSeries = len(raw)
+		if p.maxSeries > 0 && len(raw) > p.maxSeries {
+			raw = raw[:p.maxSeries]
+			result.Truncated = true
+		}
+
+		result.Series = make([]PromSeries, 0, len(raw))
+		for _, r := range raw {
+			series := PromSeries{Metric: r.Metric}
+			if r.Value != nil {
+				sample, err := parsePromSample(r.Value)
+				if err != nil {
+					return nil, err
+				}
+				series.Value = &sample
+			}
+			values := r.Values
+			if p.maxPoints > 0 && len(values) > p.maxPoints {
+				// Keep the most recent samples
+				values = values[len(values)-p.maxPoints:]
+				result.Truncated = true
+			}
+			for _, v := range values {
+				sample, err := parsePromSample(v)
+				if err != nil {
+					return nil, err
+				}
+				series.Values = append(series.Values, sample)
+			}
+			result.Series = append(result.Series, series)
+		}
+		return result, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported result type: %s", envelope.Data.ResultType)
+	}
+}
+
+// parsePromSample parses a [unix_seconds, "value"] pair
+func parsePromSample(raw []interface{}) (PromSample, error) {
+	if len(raw) != 2 {
+		return PromSample{}, fmt.Errorf("invalid sample: %v", raw)
+	}
+	ts, ok := raw[0].(float64)
+	if !ok {
+		return PromSample{}, fmt.Errorf("invalid sample timestamp: %v", raw[0])
+	}
+	value, ok := raw[1].(string)
+	if !ok {
+		return PromSample{}, fmt.Errorf("invalid sample value: %v", raw[1])
+	}
+	sec := int64(ts)
+	nsec := int64((ts - float64(sec)) * 1e9)
+	return PromSample{Timestamp: time.Unix(sec, nsec).UTC(), Value: value}, nil
+}
+
+// parsePromTime resolves "now" and "now-<duration>" into a unix timestamp and
+// passes RFC3339 and unix timestamps through unchanged
+func parsePromTime(value string) (string, error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "now" {
+		return strconv.FormatInt(time.Now().Unix(), 10), nil
+	}
+	if offset, ok := strings.CutPrefix(value, "now-"); ok {
+		d, err := time.ParseDuration(offset)
+		if err != nil {
+			return "", fmt.Errorf("invalid relative time %q: %w", value, err)
+		}
+		return strconv.FormatInt(time.Now().Add(-d).Unix(), 10), nil
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value, nil
+	}
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		return "", fmt.Errorf("invalid time %q: use RFC3339, a unix timestamp, 'now' or 'now-<duration>'", value)
+	}
+	return value, nil
+}
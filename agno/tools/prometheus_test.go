@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestPrometheusTool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		switch {
+		case r.Form.Get("query") == "bad(":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":"error","errorType":"bad_data","error":"parse error"}`))
+		case r.URL.Path == "/api/v1/query":
+			w.Write([]byte(`{"status":"success","data":{"resultType":"vector","result":[
+				{"metric":{"job":"api"},"value":[1700000000.5,"0.25"]},
+				{"metric":{"job":"web"},"value":[1700000000.5,"NaN"]}]}}`))
+		case r.URL.Path == "/api/v1/query_range":
+			if r.Form.Get("step") != "60s" || r.Form.Get("start") == "" || r.Form.Get("end") == "" {
+				t.Errorf("unexpected range params: %v", r.Form)
+			}
+			w.Write([]byte(`{"status":"success","data":{"resultType":"matrix","result":[
+				{"metric":{"job":"api"},"values":[[1,"1"],[61,"2"],[121,"3"]]}]}}`))
+		}
+	}))
+	defer server.Close()
+
+	p := NewPrometheusTool(server.URL, WithPrometheusMaxSeries(1), WithPrometheusMaxPoints(2))
+
+	out, err := p.Query(PromQueryParams{Expr: "up"})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	result := out.(*PromQueryResult)
+	if result.TotalSeries != 2 || len(result.Series) != 1 || !result.Truncated {
+		t.Fatalf("expected the series cap to apply, got %+v", result)
+	}
+	if v := result.Series[0].Value; v == nil || v.Value != "0.25" || v.Timestamp.Unix() != 1700000000 {
+		t.Errorf("unexpected sample: %+v", v)
+	}
+
+	out, err = p.RangeQuery(PromRangeQueryParams{Expr: "up", Start: "now-1h"})
+	if err != nil {
+		t.Fatalf("RangeQuery: %v", err)
+	}
+	values := out.(*PromQueryResult).Series[0].Values
+	if len(values) != 2 || values[0].Value != "2" {
+		t.Errorf("expected the 2 most recent samples, got %+v", values)
+	}
+
+	if _, err := p.Query(PromQueryParams{Expr: "bad("}); err == nil || !strings.Contains(err.Error(), "parse error") {
+		t.Errorf("expected the Prometheus error, got %v", err)
+	}
+}
@@ -0,0 +1,236 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/devalexandre/agno-golang/agno/storage"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// SchedulerTool lets an agent schedule deferred tasks such as reminders.
+// Tasks are persisted in a storage.TaskStore (e.g. the SQLite storage) and the
+// due handler is called once for each task when its run time arrives.
+// Implements ConnectableTool: Connect starts the scheduler loop and Close stops it.
+type SchedulerTool struct {
+	toolkit.Toolkit
+	store        storage.TaskStore
+	onDue        func(task storage.ScheduledTask)
+	pollInterval time.Duration
+	now          func() time.Time
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// SchedulerOption configures a SchedulerTool
+type SchedulerOption func(*SchedulerTool)
+
+// WithTaskDueHandler sets the callback fired when a task is due
+func WithTaskDueHandler(fn func(task storage.ScheduledTask)) SchedulerOption {
+	return func(s *SchedulerTool) {
+		s.onDue = fn
+	}
+}
+
+// WithSchedulerPollInterval sets how often due tasks are checked (default 1s)
+func WithSchedulerPollInterval(interval time.Duration) SchedulerOption {
+	return func(s *SchedulerTool) {
+		s.pollInterval = interval
+	}
+}
+
+// SchedulerScheduleParams represents parameters for scheduling a task
+type SchedulerScheduleParams struct {
+	RunAt   string `json:"run_at" description:"When to run the task: an RFC3339 time (2025-01-02T15:04:05Z) or a delay from now such as 30m, 1h or 2h30m" required:"true"`
+	Payload string `json:"payload" description:"What the task is about, e.g. the reminder text" required:"true"`
+}
+
+// SchedulerListParams represents parameters for listing tasks
+type SchedulerListParams struct {
+	Status string `json:"status,omitempty" description:"Filter by status: pending, fired or cancelled. Empty lists all tasks."`
+}
+
+// SchedulerCancelParams represents parameters for cancelling a task
+type SchedulerCancelParams struct {
+	TaskID string `json:"task_id" description:"ID of the task to cancel" required:"true"`
+}
+
+// NewSchedulerTool creates a new SchedulerTool backed by store
+func NewSchedulerTool(store storage.TaskStore, options ...SchedulerOption) *SchedulerTool {
+	s := &SchedulerTool{
+		store:        store,
+		pollInterval: time.Second,
+		now:          time.Now,
+	}
+	for _, opt := range options {
+		opt(s)
+	}
+
+	tk := toolkit.NewToolkit()
+	tk.Name = "SchedulerTool"
+	tk.Description = "Schedule deferred tasks and reminders, list scheduled tasks and cancel them."
+
+	s.Toolkit = tk
+	s.Toolkit.Register("schedule_task", "Schedule a task to run at a given time or after a delay", s, s.ScheduleTask, SchedulerScheduleParams{})
+	s.Toolkit.Register("list_tasks", "List scheduled tasks", s, s.ListTasks, SchedulerListParams{})
+	s.Toolkit.Register("cancel_task", "Cancel a pending task", s, s.CancelTask, SchedulerCancelParams{})
+
+	return s
+}
+
+// ScheduleTask stores a new pending task
+func (s *SchedulerTool) ScheduleTask(params SchedulerScheduleParams) (interface{}, error) {
+	now := s.now()
+	runAt, err := parseRunAt(params.RunAt, now)
+	if err != nil {
+		return nil, err
+	}
+
+	task := &storage.ScheduledTask{
+		ID:        uuid.New().String(),
+		Payload:   params.Payload,
+		RunAt:     runAt,
+		Status:    storage.TaskPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if err := s.store.SaveTask(context.Background(), task); err != nil {
+		return nil, fmt.Errorf("failed to schedule task: %w", err)
+	}
+
+	return map[string]interface{}{
+		"task_id": task.ID,
+		"run_at":  task.RunAt.Format(time.RFC3339),
+		"status":  task.Status,
+	}, nil
+}
+
+// ListTasks returns scheduled tasks, optionally filtered by status
+func (s *SchedulerTool) ListTasks(params SchedulerListParams) (interface{}, error) {
+	tasks, err := s.store.ListTasks(context.Background(), storage.TaskStatus(strings.ToLower(params.Status)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", err)
+	}
+	if tasks == nil {
+		tasks = []*storage.ScheduledTask{}
+	}
+	return tasks, nil
+}
+
+// CancelTask cancels a pending task
+func (s *SchedulerTool) CancelTask(params SchedulerCancelParams) (interface{}, error) {
+	ctx := context.Background()
+	task, err := s.store.GetTask(ctx, params.TaskID)
+	if err != nil {
+		return nil, err
+	}
+	if task.Status != storage.TaskPending {
+		return nil, fmt.Errorf("task %s is already %s", task.ID, task.Status)
+	}
+	// The store only cancels the task if it is still pending, so a task that
+	// fired after the check above is reported as such rather than cancelled
+	if err := s.store.UpdateTaskStatus(ctx, task.ID, storage.TaskPending, storage.TaskCancelled); err != nil {
+		return nil, fmt.Errorf("failed to cancel task: %w", err)
+	}
+	return fmt.Sprintf("Task %s cancelled", task.ID), nil
+}
+
+// Connect starts the loop that fires due tasks
+func (s *SchedulerTool) Connect() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.cancel != nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.pollInterval)
+		defer ticker.Stop()
+		for {
+			s.FireDueTasks(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops the scheduler loop and waits for it to exit
+func (s *SchedulerTool) Close() error {
+	s.mu.Lock()
+	cancel, done := s.cancel, s.done
+	s.cancel, s.done = nil, nil
+	s.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+		<-done
+	}
+	return nil
+}
+
+// FireDueTasks marks every pending task whose run time has passed as fired and
+// calls the due handler for it. It is called by the scheduler loop and can be
+// called directly when the loop is not running.
+func (s *SchedulerTool) FireDueTasks(ctx context.Context) {
+	tasks, err := s.store.ListTasks(ctx, storage.TaskPending)
+	if err != nil {
+		log.Printf("Warning: Failed to list scheduled tasks: %v", err)
+		return
+	}
+
+	now := s.now()
+	for _, task := range tasks {
+		if task.RunAt.After(now) {
+			break
+		}
+		// Mark as fired first and only deliver if this call moved the task out
+		// of pending, so a task cancelled or fired elsewhere is not delivered
+		if err := s.store.UpdateTaskStatus(ctx, task.ID, storage.TaskPending, storage.TaskFired); err != nil {
+			if !errors.Is(err, storage.ErrTaskStatusChanged) {
+				log.Printf("Warning: Failed to mark task %s as fired: %v", task.ID, err)
+			}
+			continue
+		}
+		task.Status = storage.TaskFired
+		if s.onDue != nil {
+			s.onDue(*task)
+		}
+	}
+}
+
+// parseRunAt accepts an RFC3339 time or a delay relative to now
+func parseRunAt(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("run_at is required")
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	delay, err := time.ParseDuration(strings.TrimPrefix(value, "+"))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid run_at %q: use an RFC3339 time or a delay such as 1h30m", value)
+	}
+	if delay < 0 {
+		return time.Time{}, fmt.Errorf("run_at must not be in the past")
+	}
+	return now.Add(delay), nil
+}
@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/storage"
+)
+
+func TestSchedulerTool(t *testing.T) {
+	store := storage.NewMemoryTaskStore()
+	var fired []storage.ScheduledTask
+	scheduler := NewSchedulerTool(store, WithTaskDueHandler(func(task storage.ScheduledTask) {
+		fired = append(fired, task)
+	}))
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheduler.now = func() time.Time { return now }
+
+	reminder, err := scheduler.ScheduleTask(SchedulerScheduleParams{RunAt: "1h", Payload: "stand up"})
+	if err != nil {
+		t.Fatalf("ScheduleTask failed: %v", err)
+	}
+	later, err := scheduler.ScheduleTask(SchedulerScheduleParams{RunAt: "2025-01-02T09:00:00Z", Payload: "call mom"})
+	if err != nil {
+		t.Fatalf("ScheduleTask failed: %v", err)
+	}
+	if _, err := scheduler.ScheduleTask(SchedulerScheduleParams{RunAt: "tomorrow", Payload: "x"}); err == nil {
+		t.Error("Expected invalid run_at to fail")
+	}
+
+	laterID := later.(map[string]interface{})["task_id"].(string)
+	if _, err := scheduler.CancelTask(SchedulerCancelParams{TaskID: laterID}); err != nil {
+		t.Fatalf("CancelTask failed: %v", err)
+	}
+
+	scheduler.FireDueTasks(context.Background())
+	if len(fired) != 0 {
+		t.Fatalf("Expected no due tasks yet, got %d", len(fired))
+	}
+
+	now = now.Add(2 * time.Hour)
+	scheduler.FireDueTasks(context.Background())
+	scheduler.FireDueTasks(context.Background())
+	if len(fired) != 1 {
+		t.Fatalf("Expected 1 fired task, got %d", len(fired))
+	}
+	if fired[0].ID != reminder.(map[string]interface{})["task_id"] || fired[0].Payload != "stand up" {
+		t.Errorf("Unexpected fired task: %+v", fired[0])
+	}
+
+	pending, err := scheduler.ListTasks(SchedulerListParams{Status: "pending"})
+	if err != nil {
+		t.Fatalf("ListTasks failed: %v", err)
+	}
+	if len(pending.([]*storage.ScheduledTask)) != 0 {
+		t.Errorf("Expected no pending tasks, got %v", pending)
+	}
+	all, _ := scheduler.ListTasks(SchedulerListParams{})
+	if len(all.([]*storage.ScheduledTask)) != 2 {
+		t.Errorf("Expected 2 tasks, got %v", all)
+	}
+}
+
+// cancelOnListStore cancels every listed task right after ListTasks returns,
+// simulating a cancel that lands while FireDueTasks is running
+type cancelOnListStore struct {
+	*storage.MemoryTaskStore
+	scheduler *SchedulerTool
+}
+
+func (s *cancelOnListStore) ListTasks(ctx context.Context, status storage.TaskStatus) ([]*storage.ScheduledTask, error) {
+	tasks, err := s.MemoryTaskStore.ListTasks(ctx, status)
+	for _, task := range tasks {
+		if _, err := s.scheduler.CancelTask(SchedulerCancelParams{TaskID: task.ID}); err != nil {
+			return nil, err
+		}
+	}
+	return tasks, err
+}
+
+func TestSchedulerToolCancelDuringFire(t *testing.T) {
+	store := &cancelOnListStore{MemoryTaskStore: storage.NewMemoryTaskStore()}
+	var fired []storage.ScheduledTask
+	scheduler := NewSchedulerTool(store, WithTaskDueHandler(func(task storage.ScheduledTask) {
+		fired = append(fired, task)
+	}))
+	store.scheduler = scheduler
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	scheduler.now = func() time.Time { return now }
+
+	result, err := scheduler.ScheduleTask(SchedulerScheduleParams{RunAt: "1m", Payload: "stand up"})
+	if err != nil {
+		t.Fatalf("ScheduleTask failed: %v", err)
+	}
+	id := result.(map[string]interface{})["task_id"].(string)
+
+	now = now.Add(time.Hour)
+	scheduler.FireDueTasks(context.Background())
+	if len(fired) != 0 {
+		t.Fatalf("Expected cancelled task not to be delivered, got %+v", fired)
+	}
+
+	task, err := store.GetTask(context.Background(), id)
+	if err != nil {
+		t.Fatalf("GetTask failed: %v", err)
+	}
+	if task.Status != storage.TaskCancelled {
+		t.Errorf("Expected task to stay cancelled, got %s", task.Status)
+	}
+	if err := store.UpdateTaskStatus(context.Background(), id, storage.TaskPending, storage.TaskFired); !errors.Is(err, storage.ErrTaskStatusChanged) {
+		t.Errorf("Expected ErrTaskStatusChanged, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package tools
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -9,6 +10,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
@@ -108,16 +110,54 @@ func (st *ShellTool) Execute(action string, params json.RawMessage) (interface{}
 	}
 }
 
+// ShellOutputFunc receives the output of a running command line by line.
+// stream is "stdout" or "stderr"; calls never overlap.
+type ShellOutputFunc func(stream, line string)
+
+// ExecuteContext implements toolkit.ContextTool. Commands run like
+// ExecuteStream: they are killed when ctx is done, and their lines go to the
+// toolkit.ToolOutputFunc of ctx, if any, so agents can show them live.
+func (st *ShellTool) ExecuteContext(ctx context.Context, action string, params json.RawMessage) (interface{}, error) {
+	if strings.TrimPrefix(action, st.Name+"_") != "Execute" {
+		return st.Execute(action, params)
+	}
+
+	var executeParams ExecuteParams
+	if err := json.Unmarshal(params, &executeParams); err != nil {
+		return nil, fmt.Errorf("failed to parse parameters: %w", err)
+	}
+
+	var onLine ShellOutputFunc
+	if output := toolkit.ToolOutputFromContext(ctx); output != nil {
+		onLine = func(stream, line string) {
+			output(toolkit.ToolOutput{Tool: st.Name + "_Execute", Stream: stream, Text: line})
+		}
+	}
+	return st.ExecuteStream(ctx, executeParams, onLine)
+}
+
 // ExecuteCommand runs a command in the system shell
 func (st *ShellTool) executeCommand(params ExecuteParams) (interface{}, error) {
+	return st.ExecuteStream(context.Background(), params, nil)
+}
+
+// ExecuteStream runs a command like Execute, calling onLine with each line of
+// stdout and stderr as the command prints it, so long builds and test runs
+// show progress. onLine may be nil.
+//
+// The command, and any process it started, is killed when params.Timeout
+// (default 30s) passes or ctx is done. A timeout is reported in the result;
+// when ctx is done, the output so far is returned with ctx's error.
+func (st *ShellTool) ExecuteStream(ctx context.Context, params ExecuteParams, onLine ShellOutputFunc) (ShellResult, error) {
 	if params.Command == "" {
-		return nil, fmt.Errorf("command is required")
+		return ShellResult{}, fmt.Errorf("command is required")
 	}
 
 	// Set default timeout
 	if params.Timeout <= 0 {
 		params.Timeout = 30
 	}
+	timeout := time.Duration(params.Timeout) * time.Second
 
 	// Set working directory
 	workingDir := params.WorkingDir
@@ -130,81 +170,141 @@ func (st *ShellTool) executeCommand(params ExecuteParams) (interface{}, error) {
 	}
 
 	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(params.Timeout)*time.Second)
+	runCtx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
-	start := time.Now()
-	var cmd *exec.Cmd
-
-	// Prepare command based on shell flag and platform
-	if params.Shell {
-		// Execute in shell
-		switch runtime.GOOS {
-		case "windows":
-			fullCommand := params.Command
-			if len(params.Args) > 0 {
-				fullCommand += " " + strings.Join(params.Args, " ")
-			}
-			cmd = exec.CommandContext(ctx, "cmd", "/C", fullCommand)
-		default:
-			fullCommand := params.Command
-			if len(params.Args) > 0 {
-				fullCommand += " " + strings.Join(params.Args, " ")
-			}
-			cmd = exec.CommandContext(ctx, "sh", "-c", fullCommand)
-		}
-	} else {
-		// Execute directly
-		if len(params.Args) > 0 {
-			cmd = exec.CommandContext(ctx, params.Command, params.Args...)
-		} else {
-			cmd = exec.CommandContext(ctx, params.Command)
-		}
-	}
-
-	// Set working directory
+	cmd := buildShellCommand(runCtx, params)
 	if params.WorkingDir != "" {
 		cmd.Dir = params.WorkingDir
 	}
+	killProcessGroup(cmd)
+	// Don't wait forever for output pipes held open by orphaned processes
+	cmd.WaitDelay = time.Second
 
-	// Execute command
-	stdout, err := cmd.Output()
-	duration := time.Since(start)
+	// Truncate output if too long to avoid token overflow
+	var mu sync.Mutex
+	stdout := &shellOutput{stream: "stdout", limit: 5000, marker: "\n[... output truncated ...]", onLine: onLine, mu: &mu}
+	stderr := &shellOutput{stream: "stderr", limit: 2000, marker: "\n[... error output truncated ...]", onLine: onLine, mu: &mu}
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+
+	start := time.Now()
+	err := cmd.Run()
+	stdout.flush()
+	stderr.flush()
 
 	result := ShellResult{
 		Command:    params.Command,
 		WorkingDir: workingDir,
-		Duration:   duration.String(),
+		Duration:   time.Since(start).String(),
 		Operation:  "Execute",
+		Stdout:     stdout.String(),
+		Stderr:     stderr.String(),
 	}
 
-	if err != nil {
+	switch {
+	case ctx.Err() != nil:
+		result.ExitCode = -1
+		result.Error = fmt.Sprintf("command cancelled: %v", ctx.Err())
+		return result, ctx.Err()
+	case runCtx.Err() != nil:
+		result.ExitCode = -1
+		result.Error = fmt.Sprintf("command timed out after %s and was killed", timeout)
+	case err != nil:
 		// Handle different types of errors
 		if exitError, ok := err.(*exec.ExitError); ok {
 			result.ExitCode = exitError.ExitCode()
-			result.Stderr = string(exitError.Stderr)
-			result.Success = false
 		} else {
 			result.Error = fmt.Sprintf("execution failed: %v", err)
-			result.Success = false
 			result.ExitCode = -1
 		}
-	} else {
+	default:
 		result.ExitCode = 0
 		result.Success = true
 	}
 
-	result.Stdout = string(stdout)
+	return result, nil
+}
 
-	// Truncate output if too long to avoid token overflow
-	if len(result.Stdout) > 5000 {
-		result.Stdout = result.Stdout[:5000] + "\n[... output truncated ...]"
+// buildShellCommand prepares the command based on the shell flag and platform
+func buildShellCommand(ctx context.Context, params ExecuteParams) *exec.Cmd {
+	if !params.Shell {
+		// Execute directly
+		return exec.CommandContext(ctx, params.Command, params.Args...)
+	}
+
+	fullCommand := params.Command
+	if len(params.Args) > 0 {
+		fullCommand += " " + strings.Join(params.Args, " ")
 	}
-	if len(result.Stderr) > 2000 {
-		result.Stderr = result.Stderr[:2000] + "\n[... error output truncated ...]"
+	if runtime.GOOS == "windows" {
+		return exec.CommandContext(ctx, "cmd", "/C", fullCommand)
 	}
+	return exec.CommandContext(ctx, "sh", "-c", fullCommand)
+}
 
-	return result, nil
+// shellOutput keeps the first limit bytes of a command's output and reports
+// it line by line as it is written
+type shellOutput struct {
+	stream string
+	limit  int
+	marker string // Appended when the output was truncated
+	onLine ShellOutputFunc
+	mu     *sync.Mutex // Shared by stdout and stderr so onLine calls don't overlap
+
+	text      strings.Builder
+	truncated bool
+	partial   []byte // Last line, until its newline arrives
+}
+
+// shellMaxLine caps the partial line kept for onLine, for output that never
+// prints a newline, such as progress bars
+const shellMaxLine = 64 * 1024
+
+func (o *shellOutput) Write(p []byte) (int, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if room := o.limit - o.text.Len(); len(p) > room {
+		o.text.Write(p[:max(room, 0)])
+		o.truncated = true
+	} else {
+		o.text.Write(p)
+	}
+
+	if o.onLine != nil {
+		o.partial = append(o.partial, p...)
+		for {
+			i := bytes.IndexByte(o.partial, '\n')
+			if i < 0 {
+				break
+			}
+			o.onLine(o.stream, strings.TrimSuffix(string(o.partial[:i]), "\r"))
+			o.partial = o.partial[i+1:]
+		}
+		if len(o.partial) > shellMaxLine {
+			o.onLine(o.stream, string(o.partial))
+			o.partial = nil
+		}
+	}
+	return len(p), nil
+}
+
+// flush reports the last line when the output doesn't end with a newline
+func (o *shellOutput) flush() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.onLine != nil && len(o.partial) > 0 {
+		o.onLine(o.stream, strings.TrimSuffix(string(o.partial), "\r"))
+	}
+	o.partial = nil
+}
+
+func (o *shellOutput) String() string {
+	if o.truncated {
+		return o.text.String() + o.marker
+	}
+	return o.text.String()
 }
 
 // GetSystemInfo retrieves various system information
@@ -0,0 +1,112 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func skipWithoutShell(t *testing.T) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("requires sh")
+	}
+}
+
+func TestShellToolExecuteStream(t *testing.T) {
+	skipWithoutShell(t)
+	st := NewShellTool()
+
+	var mu sync.Mutex
+	var lines []string
+	result, err := st.ExecuteStream(context.Background(), ExecuteParams{
+		Command: "echo one; echo oops >&2; printf two",
+		Shell:   true,
+	}, func(stream, line string) {
+		mu.Lock()
+		defer mu.Unlock()
+		lines = append(lines, stream+":"+line)
+	})
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+	if !result.Success || result.Stdout != "one\ntwo" || result.Stderr != "oops\n" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	// stdout and stderr are read separately, so only the order within a stream is fixed
+	var stdout []string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "stdout:") {
+			stdout = append(stdout, line)
+		}
+	}
+	if len(lines) != 3 || !reflect.DeepEqual(stdout, []string{"stdout:one", "stdout:two"}) {
+		t.Errorf("unexpected lines: %v", lines)
+	}
+
+	// The blocking Execute returns the same result
+	out, err := st.Execute("ShellTool_Execute", json.RawMessage(`{"command": "exit 3", "shell": true}`))
+	if err != nil {
+		t.Fatalf("Execute failed: %v", err)
+	}
+	if r := out.(ShellResult); r.Success || r.ExitCode != 3 {
+		t.Errorf("expected exit code 3, got %+v", r)
+	}
+}
+
+func TestShellToolExecuteStreamTimeoutKillsChildren(t *testing.T) {
+	skipWithoutShell(t)
+	st := NewShellTool()
+
+	// The background sleep keeps the output open unless the whole group is killed
+	start := time.Now()
+	result, err := st.ExecuteStream(context.Background(), ExecuteParams{
+		Command: "echo started; sleep 30 & sleep 30",
+		Shell:   true,
+		Timeout: 1,
+	}, nil)
+	if err != nil {
+		t.Fatalf("ExecuteStream failed: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the command to be killed at the timeout, took %s", elapsed)
+	}
+	if result.Success || !strings.Contains(result.Error, "timed out") || result.Stdout != "started\n" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+}
+
+func TestShellToolExecuteContextCancel(t *testing.T) {
+	skipWithoutShell(t)
+	st := NewShellTool()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var once sync.Once
+	ctx = toolkit.ContextWithToolOutput(ctx, func(out toolkit.ToolOutput) {
+		if out.Tool != "ShellTool_Execute" || out.Text != "ready" {
+			t.Errorf("unexpected output: %+v", out)
+		}
+		// Cancel once the command is running
+		once.Do(cancel)
+	})
+
+	start := time.Now()
+	out, err := st.ExecuteContext(ctx, "ShellTool_Execute", json.RawMessage(`{"command": "echo ready; sleep 30", "shell": true, "timeout": 60}`))
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("expected the command to be killed on cancel, took %s", elapsed)
+	}
+	if r := out.(ShellResult); r.Stdout != "ready\n" || r.ExitCode != -1 {
+		t.Errorf("unexpected result: %+v", r)
+	}
+}
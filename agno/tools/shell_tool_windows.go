@@ -0,0 +1,13 @@
+//go:build windows
+
+package tools
+
+import "os/exec"
+
+// killProcessGroup kills the command when cancelled. Windows has no process
+// groups to signal, so only the command itself is killed.
+func killProcessGroup(cmd *exec.Cmd) {
+	cmd.Cancel = func() error {
+		return cmd.Process.Kill()
+	}
+}
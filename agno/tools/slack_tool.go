@@ -3,7 +3,9 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
 	"github.com/slack-go/slack"
@@ -15,20 +17,58 @@ type SlackTool struct {
 	toolkit.Toolkit
 	client *slack.Client
 	token  string
+	// allowedChannels restricts the channels the tool can use; empty allows all
+	allowedChannels map[string]bool
+	clientOptions   []slack.Option
+	// handlers maps the full method names to their implementations
+	handlers map[string]slackHandler
+}
+
+// slackHandler implements a Slack method from the tool call arguments
+type slackHandler func(ctx context.Context, args map[string]interface{}) (string, error)
+
+// SlackToolOption configures a SlackTool
+type SlackToolOption func(*SlackTool)
+
+// WithSlackAllowedChannels restricts the tool to the given channels, by ID
+// (e.g. "C0123456") or name (e.g. "#alerts"). Calls with any other channel
+// fail, and listChannels, the searches and listFiles only return results from
+// these channels. Files are matched by the IDs of the channels they are shared in.
+func WithSlackAllowedChannels(channels ...string) SlackToolOption {
+	return func(t *SlackTool) {
+		if t.allowedChannels == nil {
+			t.allowedChannels = make(map[string]bool)
+		}
+		for _, channel := range channels {
+			t.allowedChannels[strings.TrimPrefix(channel, "#")] = true
+		}
+	}
+}
+
+// WithSlackAPIURL sets the Web API base URL, e.g. for a proxy or tests.
+// It must end with a slash, like the default "https://slack.com/api/".
+func WithSlackAPIURL(url string) SlackToolOption {
+	return func(t *SlackTool) {
+		t.clientOptions = append(t.clientOptions, slack.OptionAPIURL(url))
+	}
 }
 
 // NewSlackTool creates a new Slack tool instance with the provided bot token
 // The token should have appropriate OAuth scopes for the operations you want to perform
-func NewSlackTool(token string) *SlackTool {
+func NewSlackTool(token string, opts ...SlackToolOption) *SlackTool {
 	if token == "" {
 		panic("slack token is required")
 	}
 
 	tool := &SlackTool{
-		Toolkit: toolkit.NewToolkit(),
-		client:  slack.New(token),
-		token:   token,
+		Toolkit:  toolkit.NewToolkit(),
+		token:    token,
+		handlers: make(map[string]slackHandler),
 	}
+	for _, opt := range opts {
+		opt(tool)
+	}
+	tool.client = slack.New(token, tool.clientOptions...)
 
 	tool.Name = "slack"
 	tool.Description = "Comprehensive Slack workspace integration - send messages, manage channels, handle threads, upload files, and more"
@@ -39,52 +79,163 @@ func NewSlackTool(token string) *SlackTool {
 	return tool
 }
 
-// registerMethods registers all available Slack operations
 // registerMethods registers all available Slack operations
 func (t *SlackTool) registerMethods() {
 	// Message operations
-	t.Register("sendMessage", "Send a new message to a Slack channel", t, t.sendMessage, SendMessageParams{})
-	t.Register("sendThreadReply", "Send a reply in an existing message thread", t, t.sendThreadReply, SendThreadReplyParams{})
-	t.Register("updateMessage", "Update the text of an existing message", t, t.updateMessage, UpdateMessageParams{})
-	t.Register("deleteMessage", "Delete a message from a channel", t, t.deleteMessage, DeleteMessageParams{})
+	t.register("postMessage", "Post a new message to a Slack channel", t.postMessage, SendMessageParams{})
+	t.register("sendThreadReply", "Send a reply in an existing message thread", t.sendThreadReply, SendThreadReplyParams{})
+	t.register("updateMessage", "Update the text of an existing message", t.updateMessage, UpdateMessageParams{})
+	t.register("deleteMessage", "Delete a message from a channel", t.deleteMessage, DeleteMessageParams{})
 
 	// Channel operations
-	t.Register("listChannels", "List all public and private channels in the workspace", t, t.listChannels, ListChannelsParams{})
-	t.Register("getChannelInfo", "Get detailed information about a specific channel", t, t.getChannelInfo, GetChannelInfoParams{})
-	t.Register("getChannelHistory", "Retrieve recent message history from a channel", t, t.getChannelHistory, GetChannelHistoryParams{})
-	t.Register("createChannel", "Create a new public or private channel", t, t.createChannel, CreateChannelParams{})
-	t.Register("archiveChannel", "Archive (deactivate) a channel", t, t.archiveChannel, ArchiveChannelParams{})
-	t.Register("setChannelTopic", "Update the topic of a channel", t, t.setChannelTopic, SetChannelTopicParams{})
-	t.Register("setChannelPurpose", "Update the purpose/description of a channel", t, t.setChannelPurpose, SetChannelPurposeParams{})
+	t.register("listChannels", "List all public and private channels in the workspace", t.listChannels, ListChannelsParams{})
+	t.register("getChannelInfo", "Get detailed information about a specific channel", t.getChannelInfo, GetChannelInfoParams{})
+	t.register("readChannel", "Read the most recent messages of a channel", t.readChannel, GetChannelHistoryParams{})
+	t.register("createChannel", "Create a new public or private channel", t.createChannel, CreateChannelParams{})
+	t.register("archiveChannel", "Archive (deactivate) a channel", t.archiveChannel, ArchiveChannelParams{})
+	t.register("setChannelTopic", "Update the topic of a channel", t.setChannelTopic, SetChannelTopicParams{})
+	t.register("setChannelPurpose", "Update the purpose/description of a channel", t.setChannelPurpose, SetChannelPurposeParams{})
 
 	// User operations
-	t.Register("inviteToChannel", "Invite one or more users to a channel", t, t.inviteToChannel, InviteToChannelParams{})
-	t.Register("removeFromChannel", "Remove a user from a channel", t, t.removeFromChannel, RemoveFromChannelParams{})
-	t.Register("getUserInfo", "Get detailed profile information for a user", t, t.getUserInfo, GetUserInfoParams{})
-	t.Register("listUsers", "List all active users in the workspace", t, t.listUsers, ListUsersParams{})
-	t.Register("getUserPresence", "Check if a user is currently online or away", t, t.getUserPresence, GetUserPresenceParams{})
+	t.register("inviteToChannel", "Invite one or more users to a channel", t.inviteToChannel, InviteToChannelParams{})
+	t.register("removeFromChannel", "Remove a user from a channel", t.removeFromChannel, RemoveFromChannelParams{})
+	t.register("getUserInfo", "Get detailed profile information for a user", t.getUserInfo, GetUserInfoParams{})
+	t.register("listUsers", "List all active users in the workspace", t.listUsers, ListUsersParams{})
+	t.register("getUserPresence", "Check if a user is currently online or away", t.getUserPresence, GetUserPresenceParams{})
 
 	// File operations
-	t.Register("uploadFile", "Upload a file (text or binary) to Slack", t, t.uploadFile, UploadFileParams{})
-	t.Register("listFiles", "List files shared in the workspace or a specific channel/user", t, t.listFiles, ListFilesParams{})
-	t.Register("deleteFile", "Permanently delete a file by its ID", t, t.deleteFile, DeleteFileParams{})
+	t.register("uploadFile", "Upload a file (text or binary) to Slack", t.uploadFile, UploadFileParams{})
+	t.register("listFiles", "List files shared in the workspace or a specific channel/user", t.listFiles, ListFilesParams{})
+	t.register("deleteFile", "Permanently delete a file by its ID", t.deleteFile, DeleteFileParams{})
 
 	// Reaction operations
-	t.Register("addReaction", "Add an emoji reaction to a message", t, t.addReaction, AddReactionParams{})
-	t.Register("removeReaction", "Remove an emoji reaction from a message", t, t.removeReaction, RemoveReactionParams{})
-	t.Register("getReactions", "List all reactions on a specific message", t, t.getReactions, GetReactionsParams{})
+	t.register("addReaction", "Add an emoji reaction to a message", t.addReaction, AddReactionParams{})
+	t.register("removeReaction", "Remove an emoji reaction from a message", t.removeReaction, RemoveReactionParams{})
+	t.register("getReactions", "List all reactions on a specific message", t.getReactions, GetReactionsParams{})
 
 	// Search operations
-	t.Register("searchMessages", "Search for messages matching a query across the workspace", t, t.searchMessages, SearchMessagesParams{})
-	t.Register("searchFiles", "Search for files matching a query", t, t.searchFiles, SearchFilesParams{})
+	t.register("searchMessages", "Search for messages matching a query across the workspace", t.searchMessages, SearchMessagesParams{})
+	t.register("searchFiles", "Search for files matching a query", t.searchFiles, SearchFilesParams{})
 
 	// Thread operations
-	t.Register("getThreadReplies", "Fetch all replies in a specific message thread", t, t.getThreadReplies, GetThreadRepliesParams{})
+	t.register("getThreadReplies", "Fetch all replies in a specific message thread", t.getThreadReplies, GetThreadRepliesParams{})
 
 	// Pin operations
-	t.Register("pinMessage", "Pin a message to the top of a channel", t, t.pinMessage, PinMessageParams{})
-	t.Register("unpinMessage", "Unpin a previously pinned message", t, t.unpinMessage, UnpinMessageParams{})
-	t.Register("listPins", "List all pinned items in a channel", t, t.listPins, ListPinsParams{})
+	t.register("pinMessage", "Pin a message to the top of a channel", t.pinMessage, PinMessageParams{})
+	t.register("unpinMessage", "Unpin a previously pinned message", t.unpinMessage, UnpinMessageParams{})
+	t.register("listPins", "List all pinned items in a channel", t.listPins, ListPinsParams{})
+}
+
+// register registers a method schema and the handler that implements it
+func (t *SlackTool) register(methodName, description string, handler slackHandler, paramExample interface{}) {
+	t.Register(methodName, description, t, handler, paramExample)
+	t.handlers[t.Name+"_"+methodName] = handler
+}
+
+// Execute runs a Slack method
+func (t *SlackTool) Execute(methodName string, input json.RawMessage) (interface{}, error) {
+	return t.ExecuteContext(context.Background(), methodName, input)
+}
+
+// ExecuteContext runs a Slack method with the run context, after checking its
+// channel against the allowlist. Slack Web API errors are explained so the
+// agent can act on them, and the token is redacted from errors.
+func (t *SlackTool) ExecuteContext(ctx context.Context, methodName string, input json.RawMessage) (interface{}, error) {
+	handler, ok := t.handlers[methodName]
+	if !ok {
+		return nil, fmt.Errorf("method %s not found", methodName)
+	}
+
+	args := make(map[string]interface{})
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &args); err != nil {
+			return nil, fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+
+	for _, key := range []string{"channel", "channels"} {
+		if channel, ok := args[key].(string); ok && channel != "" && !t.channelAllowed(channel) {
+			return nil, fmt.Errorf("channel %s is not in the allowed channels", channel)
+		}
+	}
+
+	result, err := handler(ctx, args)
+	if err != nil {
+		return nil, t.redact(slackToolError(err))
+	}
+	return result, nil
+}
+
+// slackErrorHints explains the Web API error codes an agent can act on
+var slackErrorHints = map[string]string{
+	"channel_not_found":   "the channel does not exist or the bot cannot see it; use listChannels to find its ID",
+	"not_in_channel":      "the bot is not a member of the channel and must be invited first",
+	"is_archived":         "the channel is archived",
+	"user_not_found":      "the user does not exist",
+	"message_not_found":   "no message has that timestamp in the channel",
+	"cant_update_message": "the bot can only update its own messages",
+	"cant_delete_message": "the bot can only delete its own messages",
+	"msg_too_long":        "the message text is too long",
+	"no_text":             "the message has no text",
+	"already_reacted":     "the reaction is already on the message",
+	"missing_scope":       "the bot token lacks the OAuth scope this method needs",
+	"not_authed":          "no bot token was sent",
+	"invalid_auth":        "the bot token is invalid",
+	"token_revoked":       "the bot token was revoked",
+	"account_inactive":    "the bot token belongs to a deactivated account",
+}
+
+// slackToolError adds an explanation to known Slack Web API errors. The
+// original error is kept in the chain.
+func slackToolError(err error) error {
+	var apiErr slack.SlackErrorResponse
+	if errors.As(err, &apiErr) {
+		if hint, ok := slackErrorHints[apiErr.Err]; ok {
+			return fmt.Errorf("%w (%s)", err, hint)
+		}
+	}
+	return err
+}
+
+// channelAllowed reports whether channel, an ID or a name, may be used
+func (t *SlackTool) channelAllowed(channel string) bool {
+	return len(t.allowedChannels) == 0 || t.allowedChannels[strings.TrimPrefix(channel, "#")]
+}
+
+// fileAllowed reports whether file is shared in an allowed channel
+func (t *SlackTool) fileAllowed(file slack.File) bool {
+	if len(t.allowedChannels) == 0 {
+		return true
+	}
+	for _, ids := range [][]string{file.Channels, file.Groups} {
+		for _, id := range ids {
+			if t.allowedChannels[id] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// redact removes the token from an error message
+func (t *SlackTool) redact(err error) error {
+	if !strings.Contains(err.Error(), t.token) {
+		return err
+	}
+	return errors.New(strings.ReplaceAll(err.Error(), t.token, redactedSlackToken))
+}
+
+// redactedSlackToken replaces the token wherever the tool could print it
+const redactedSlackToken = "[REDACTED]"
+
+// String describes the tool without its token, so logging it is safe
+func (t *SlackTool) String() string {
+	return fmt.Sprintf("SlackTool{token: %s, allowed_channels: %d}", redactedSlackToken, len(t.allowedChannels))
+}
+
+// GoString is String for %#v
+func (t *SlackTool) GoString() string {
+	return t.String()
 }
 
 // Parameter structs
@@ -226,8 +377,8 @@ type ListPinsParams struct {
 	Channel string `json:"channel" description:"Channel ID"`
 }
 
-// sendMessage sends a message to a Slack channel
-func (t *SlackTool) sendMessage(ctx context.Context, args map[string]interface{}) (string, error) {
+// postMessage posts a message to a Slack channel
+func (t *SlackTool) postMessage(ctx context.Context, args map[string]interface{}) (string, error) {
 	channel, ok := args["channel"].(string)
 	if !ok {
 		return "", fmt.Errorf("channel is required")
@@ -303,6 +454,9 @@ func (t *SlackTool) listChannels(ctx context.Context, args map[string]interface{
 
 	var channelList []map[string]interface{}
 	for _, channel := range channels {
+		if !t.channelAllowed(channel.ID) && !t.channelAllowed(channel.Name) {
+			continue
+		}
 		channelList = append(channelList, map[string]interface{}{
 			"id":          channel.ID,
 			"name":        channel.Name,
@@ -324,8 +478,8 @@ func (t *SlackTool) listChannels(ctx context.Context, args map[string]interface{
 	return string(resultJSON), nil
 }
 
-// getChannelHistory retrieves message history from a channel
-func (t *SlackTool) getChannelHistory(ctx context.Context, args map[string]interface{}) (string, error) {
+// readChannel retrieves the recent message history of a channel
+func (t *SlackTool) readChannel(ctx context.Context, args map[string]interface{}) (string, error) {
 	channel, ok := args["channel"].(string)
 	if !ok {
 		return "", fmt.Errorf("channel is required")
@@ -651,6 +805,9 @@ func (t *SlackTool) searchMessages(ctx context.Context, args map[string]interfac
 
 	var messages []map[string]interface{}
 	for _, match := range searchResult.Matches {
+		if !t.channelAllowed(match.Channel.ID) && !t.channelAllowed(match.Channel.Name) {
+			continue
+		}
 		messages = append(messages, map[string]interface{}{
 			"text":      match.Text,
 			"user":      match.Username,
@@ -659,11 +816,17 @@ func (t *SlackTool) searchMessages(ctx context.Context, args map[string]interfac
 		})
 	}
 
+	// The workspace total would count matches outside the allowlist
+	total := searchResult.Total
+	if len(t.allowedChannels) > 0 {
+		total = len(messages)
+	}
+
 	result := map[string]interface{}{
 		"success":  true,
 		"messages": messages,
 		"count":    len(messages),
-		"total":    searchResult.Total,
+		"total":    total,
 	}
 
 	resultJSON, _ := json.Marshal(result)
@@ -907,6 +1070,9 @@ func (t *SlackTool) listFiles(ctx context.Context, args map[string]interface{})
 
 	var fileList []map[string]interface{}
 	for _, file := range files {
+		if !t.fileAllowed(file) {
+			continue
+		}
 		fileList = append(fileList, map[string]interface{}{
 			"id":       file.ID,
 			"name":     file.Name,
@@ -1049,6 +1215,9 @@ func (t *SlackTool) searchFiles(ctx context.Context, args map[string]interface{}
 
 	var files []map[string]interface{}
 	for _, match := range searchResult.Matches {
+		if !t.fileAllowed(match) {
+			continue
+		}
 		files = append(files, map[string]interface{}{
 			"id":       match.ID,
 			"name":     match.Name,
@@ -1059,11 +1228,17 @@ func (t *SlackTool) searchFiles(ctx context.Context, args map[string]interface{}
 		})
 	}
 
+	// The workspace total would count matches outside the allowlist
+	total := searchResult.Total
+	if len(t.allowedChannels) > 0 {
+		total = len(files)
+	}
+
 	result := map[string]interface{}{
 		"success": true,
 		"files":   files,
 		"count":   len(files),
-		"total":   searchResult.Total,
+		"total":   total,
 	}
 
 	resultJSON, _ := json.Marshal(result)
@@ -1216,14 +1391,14 @@ func (t *SlackTool) GetJSONSchema() interface{} {
 				"type": "string",
 				"enum": []string{
 					// Message operations
-					"send_message",
+					"post_message",
 					"send_thread_reply",
 					"update_message",
 					"delete_message",
 					// Channel operations
 					"list_channels",
 					"get_channel_info",
-					"get_channel_history",
+					"read_channel",
 					"create_channel",
 					"archive_channel",
 					"set_channel_topic",
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestSlackTool(t *testing.T) {
+	var posted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/chat.postMessage":
+			posted = r.Form.Get("channel") + ": " + r.Form.Get("text")
+			fmt.Fprint(w, `{"ok": true, "channel": "C1", "ts": "1700000000.000100"}`)
+		case "/conversations.list":
+			fmt.Fprint(w, `{"ok": true, "channels": [{"id": "C1", "name": "alerts"}, {"id": "C2", "name": "random"}]}`)
+		case "/chat.update":
+			fmt.Fprint(w, `{"ok": false, "error": "not_in_channel"}`)
+		case "/search.messages":
+			fmt.Fprint(w, `{"ok": true, "messages": {"total": 2, "matches": [{"text": "deploy done", "channel": {"id": "C1", "name": "alerts"}}, {"text": "salary talk", "channel": {"id": "C2", "name": "random"}}]}}`)
+		case "/search.files":
+			fmt.Fprint(w, `{"ok": true, "files": {"total": 2, "matches": [{"id": "F1", "name": "report.txt", "channels": ["C1"]}, {"id": "F2", "name": "secret.txt", "channels": ["C2"]}]}}`)
+		case "/conversations.history":
+			fmt.Fprint(w, `{"ok": true, "messages": [{"user": "U1", "text": "deploy done", "ts": "1700000000.000100"}]}`)
+		default:
+			fmt.Fprint(w, `{"ok": false, "error": "not_authed xoxb-secret"}`)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewSlackTool("xoxb-secret", WithSlackAPIURL(server.URL+"/"), WithSlackAllowedChannels("#alerts", "C1"))
+
+	if _, err := tool.Execute("slack_postMessage", json.RawMessage(`{"channel": "alerts", "text": "build passed"}`)); err != nil {
+		t.Fatalf("postMessage: %v", err)
+	}
+	if posted != "alerts: build passed" {
+		t.Errorf("unexpected post: %q", posted)
+	}
+	if _, err := tool.Execute("slack_postMessage", json.RawMessage(`{"channel": "#random", "text": "hi"}`)); err == nil {
+		t.Error("expected a channel outside the allowlist to be rejected")
+	}
+
+	out, err := tool.Execute("slack_listChannels", json.RawMessage(`{}`))
+	if err != nil {
+		t.Fatalf("listChannels: %v", err)
+	}
+	if s := out.(string); !strings.Contains(s, "alerts") || strings.Contains(s, "random") {
+		t.Errorf("expected only allowed channels, got %s", s)
+	}
+
+	out, err = tool.Execute("slack_searchMessages", json.RawMessage(`{"query": "deploy"}`))
+	if err != nil {
+		t.Fatalf("searchMessages: %v", err)
+	}
+	if s := out.(string); !strings.Contains(s, "deploy done") || strings.Contains(s, "salary") || !strings.Contains(s, `"total":1`) {
+		t.Errorf("expected only matches from allowed channels, got %s", s)
+	}
+
+	out, err = tool.Execute("slack_searchFiles", json.RawMessage(`{"query": "txt"}`))
+	if err != nil {
+		t.Fatalf("searchFiles: %v", err)
+	}
+	if s := out.(string); !strings.Contains(s, "report.txt") || strings.Contains(s, "secret.txt") {
+		t.Errorf("expected only files shared in allowed channels, got %s", s)
+	}
+
+	out, err = tool.Execute("slack_readChannel", json.RawMessage(`{"channel": "C1", "limit": 5}`))
+	if err != nil {
+		t.Fatalf("readChannel: %v", err)
+	}
+	if !strings.Contains(out.(string), "deploy done") {
+		t.Errorf("unexpected history: %v", out)
+	}
+
+	_, err = tool.Execute("slack_updateMessage", json.RawMessage(`{"channel": "C1", "timestamp": "1700000000.000100", "text": "fixed"}`))
+	if err == nil || !strings.Contains(err.Error(), "not_in_channel") || !strings.Contains(err.Error(), "must be invited") {
+		t.Errorf("expected the Slack error to be explained, got %v", err)
+	}
+
+	_, err = tool.Execute("slack_getUserInfo", json.RawMessage(`{"user": "U1"}`))
+	if err == nil || strings.Contains(err.Error(), "xoxb-secret") {
+		t.Errorf("expected an error with the token redacted, got %v", err)
+	}
+	if strings.Contains(fmt.Sprintf("%v %+v %#v", tool, tool, tool), "xoxb-secret") {
+		t.Error("expected the token to be redacted when printing the tool")
+	}
+}
@@ -0,0 +1,319 @@
+package tools
+
+import (
+	"fmt"
+	"strings"
+)
+
+// sqlToken is a word, quoted identifier, string literal or punctuation of a
+// SQL statement. Comments are dropped while tokenizing.
+type sqlToken struct {
+	text   string
+	quoted bool // quoted identifier, never a keyword
+	str    bool // string literal
+}
+
+// keyword returns the upper-cased token if it can be a keyword
+func (tok sqlToken) keyword() string {
+	if tok.quoted || tok.str {
+		return ""
+	}
+	return strings.ToUpper(tok.text)
+}
+
+// sqlWriteKeywords are the keywords that make a statement change data or
+// schema. SELECT ... INTO creates a table in some databases.
+var sqlWriteKeywords = map[string]bool{
+	"INSERT": true, "UPDATE": true, "DELETE": true, "MERGE": true, "UPSERT": true,
+	"REPLACE": true, "DROP": true, "ALTER": true, "CREATE": true, "TRUNCATE": true,
+	"GRANT": true, "REVOKE": true, "ATTACH": true, "DETACH": true, "PRAGMA": true,
+	"VACUUM": true, "REINDEX": true, "COPY": true, "CALL": true, "EXEC": true,
+	"EXECUTE": true, "LOCK": true, "SET": true, "INTO": true,
+}
+
+// sqlClauseKeywords end a table list
+var sqlClauseKeywords = map[string]bool{
+	"WHERE": true, "GROUP": true, "ORDER": true, "HAVING": true, "LIMIT": true,
+	"OFFSET": true, "UNION": true, "INTERSECT": true, "EXCEPT": true, "JOIN": true,
+	"INNER": true, "LEFT": true, "RIGHT": true, "FULL": true, "CROSS": true,
+	"NATURAL": true, "ON": true, "USING": true, "WINDOW": true, "FOR": true,
+	"SET": true, "VALUES": true, "RETURNING": true, "SELECT": true, "AS": true,
+}
+
+// tokenizeSQL splits a query into tokens, dropping comments
+func tokenizeSQL(query string) ([]sqlToken, error) {
+	var tokens []sqlToken
+	runes := []rune(query)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			j := i + 2
+			for j+1 < len(runes) && !(runes[j] == '*' && runes[j+1] == '/') {
+				j++
+			}
+			if j+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i = j + 2
+		case r == '\'' || r == '"' || r == '`':
+			// Quotes are escaped by doubling them. MySQL also escapes them with a
+			// backslash where other databases end the string, so a quote after an
+			// odd number of backslashes is rejected rather than guessed.
+			var text strings.Builder
+			j := i + 1
+			for {
+				if j >= len(runes) {
+					return nil, fmt.Errorf("unterminated quoted string")
+				}
+				if runes[j] == r && r != '`' {
+					backslashes := 0
+					for k := j - 1; k > i && runes[k] == '\\'; k-- {
+						backslashes++
+					}
+					if backslashes%2 == 1 {
+						return nil, fmt.Errorf("backslash-escaped quotes are not allowed")
+					}
+				}
+				if runes[j] == r {
+					if j+1 < len(runes) && runes[j+1] == r {
+						text.WriteRune(r)
+						j += 2
+						continue
+					}
+					break
+				}
+				text.WriteRune(runes[j])
+				j++
+			}
+			tokens = append(tokens, sqlToken{text: text.String(), quoted: r != '\'', str: r == '\''})
+			i = j + 1
+		case isSQLWordRune(r):
+			j := i
+			for j < len(runes) && isSQLWordRune(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, sqlToken{text: string(runes[i:j])})
+			i = j
+		default:
+			tokens = append(tokens, sqlToken{text: string(r)})
+			i++
+		}
+	}
+
+	return tokens, nil
+}
+
+func isSQLWordRune(r rune) bool {
+	return r == '_' || r == '$' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r > 127
+}
+
+// singleSQLStatement returns the tokens of query, failing if it holds more
+// than one statement. Trailing semicolons are allowed.
+func singleSQLStatement(query string) ([]sqlToken, error) {
+	tokens, err := tokenizeSQL(query)
+	if err != nil {
+		return nil, err
+	}
+	for len(tokens) > 0 && tokens[len(tokens)-1].text == ";" && !tokens[len(tokens)-1].str {
+		tokens = tokens[:len(tokens)-1]
+	}
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("empty query")
+	}
+	for _, tok := range tokens {
+		if tok.text == ";" && !tok.str && !tok.quoted {
+			return nil, fmt.Errorf("multiple statements are not allowed")
+		}
+	}
+	return tokens, nil
+}
+
+// checkReadOnlySQL fails unless query is a single SELECT (optionally with a
+// WITH clause) that contains no write keyword
+func checkReadOnlySQL(query string) error {
+	tokens, err := singleSQLStatement(query)
+	if err != nil {
+		return err
+	}
+
+	if first := tokens[0].keyword(); first != "SELECT" && first != "WITH" && first != "(" {
+		return fmt.Errorf("write operations disabled in read-only mode: only SELECT queries are allowed")
+	}
+	for i, tok := range tokens {
+		if !sqlWriteKeywords[tok.keyword()] {
+			continue
+		}
+		// A name followed by "(" is a function call, e.g. replace(name, 'a', 'b')
+		if i+1 < len(tokens) && tokens[i+1].text == "(" && !tokens[i+1].str {
+			continue
+		}
+		return fmt.Errorf("write operations disabled in read-only mode: %s is not allowed", tok.keyword())
+	}
+	return nil
+}
+
+// sqlTables returns the tables a statement reads or writes: the names after
+// FROM, JOIN, INTO, UPDATE and TABLE, skipping CTE names. The tables of a
+// subquery are found from its own FROM; the list after it is read on, so
+// "FROM (SELECT 1) x, t" still returns t.
+func sqlTables(tokens []sqlToken) []string {
+	ctes := make(map[string]bool)
+	for i := 0; i+2 < len(tokens); i++ {
+		// name AS ( ... ) in a WITH clause
+		if tokens[i+1].keyword() == "AS" && tokens[i+2].text == "(" && !tokens[i].str {
+			ctes[strings.ToLower(tokens[i].text)] = true
+		}
+	}
+
+	var tables []string
+	for i := 0; i < len(tokens); i++ {
+		list := false
+		switch tokens[i].keyword() {
+		case "FROM", "JOIN", "UPDATE", "TABLE":
+			list = true
+		case "INTO":
+		default:
+			continue
+		}
+
+		// Read a comma separated list of table references. nested counts the
+		// parentheses of a table list like "FROM (a, b)".
+		nested := 0
+		for j := i + 1; j < len(tokens); {
+			if tokens[j].str {
+				break
+			}
+			kw := tokens[j].keyword()
+			switch {
+			case kw == "IF" || kw == "EXISTS" || kw == "ONLY" || kw == "NOT" || kw == "LATERAL":
+				j++
+				continue
+			case tokens[j].text == "(":
+				if next := j + 1; next < len(tokens) && tokens[next].keyword() != "SELECT" &&
+					tokens[next].keyword() != "WITH" && tokens[next].keyword() != "VALUES" && tokens[next].text != "(" {
+					nested++
+					j++
+					continue
+				}
+				// A subquery, read from its own FROM
+				j = skipSQLParens(tokens, j)
+			case tokens[j].text == "," || tokens[j].text == ")" || sqlClauseKeywords[kw]:
+				j = len(tokens)
+				continue
+			default:
+				// Qualified name: schema.table
+				name := tokens[j].text
+				j++
+				for j+1 < len(tokens) && tokens[j].text == "." && !tokens[j].str {
+					name += "." + tokens[j+1].text
+					j += 2
+				}
+				if !ctes[strings.ToLower(name)] {
+					tables = append(tables, name)
+				}
+				// The arguments of a table function or the columns of INSERT INTO
+				if j < len(tokens) && tokens[j].text == "(" {
+					j = skipSQLParens(tokens, j)
+				}
+			}
+
+			j = skipSQLAlias(tokens, j)
+			for nested > 0 && j < len(tokens) && tokens[j].text == ")" {
+				nested--
+				j = skipSQLAlias(tokens, j+1)
+			}
+			if !list {
+				break
+			}
+
+			// Skip a join condition up to the next reference
+			if j < len(tokens) && (tokens[j].keyword() == "ON" || tokens[j].keyword() == "USING") {
+				for j++; j < len(tokens); j++ {
+					if tokens[j].str || tokens[j].quoted {
+						continue
+					}
+					if tokens[j].text == "(" {
+						j = skipSQLParens(tokens, j) - 1
+						continue
+					}
+					if tokens[j].text == "," || tokens[j].text == ")" || sqlClauseKeywords[tokens[j].keyword()] {
+						break
+					}
+				}
+			}
+			if j < len(tokens) && tokens[j].text == "," && !tokens[j].str {
+				j++
+				continue
+			}
+			break
+		}
+	}
+
+	return tables
+}
+
+// skipSQLParens returns the index after the parenthesis that closes the one
+// at tokens[i]
+func skipSQLParens(tokens []sqlToken, i int) int {
+	depth := 0
+	for ; i < len(tokens); i++ {
+		if tokens[i].str || tokens[i].quoted {
+			continue
+		}
+		switch tokens[i].text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return i
+}
+
+// skipSQLAlias returns the index after an optional alias at tokens[i], with
+// its column list as in "AS x(a, b)"
+func skipSQLAlias(tokens []sqlToken, i int) int {
+	if i < len(tokens) && tokens[i].keyword() == "AS" {
+		i++
+	}
+	if i < len(tokens) && !tokens[i].str && tokens[i].text != "," && tokens[i].text != "(" &&
+		tokens[i].text != ")" && tokens[i].text != "." && !sqlClauseKeywords[tokens[i].keyword()] {
+		i++
+		if i < len(tokens) && tokens[i].text == "(" {
+			i = skipSQLParens(tokens, i)
+		}
+	}
+	return i
+}
+
+// hasTopLevelLimit reports whether a statement limits its own result with
+// LIMIT or FETCH outside any subquery
+func hasTopLevelLimit(tokens []sqlToken) bool {
+	depth := 0
+	for _, tok := range tokens {
+		if tok.str || tok.quoted {
+			continue
+		}
+		switch tok.text {
+		case "(":
+			depth++
+		case ")":
+			depth--
+		}
+		if kw := tok.keyword(); depth == 0 && (kw == "LIMIT" || kw == "FETCH") {
+			return true
+		}
+	}
+	return false
+}
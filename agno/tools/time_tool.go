@@ -0,0 +1,340 @@
+package tools
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// namedTimeLayouts maps layout names accepted by parse to Go layouts
+var namedTimeLayouts = map[string]string{
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC822":      time.RFC822,
+	"RFC850":      time.RFC850,
+	"ANSIC":       time.ANSIC,
+	"Kitchen":     time.Kitchen,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// commonTimeLayouts are tried, in order, when no layout is given
+var commonTimeLayouts = []string{
+	time.RFC3339Nano,
+	time.DateTime,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	time.DateOnly,
+	time.RFC1123Z,
+	time.RFC1123,
+	time.RFC850,
+	time.ANSIC,
+}
+
+// TimeTool gives agents a reliable clock and timezone-aware date arithmetic
+type TimeTool struct {
+	toolkit.Toolkit
+	clock    func() time.Time
+	location *time.Location
+}
+
+// TimeToolOption configures a TimeTool
+type TimeToolOption func(*TimeTool)
+
+// WithTimeToolClock sets the clock used by now, e.g. a fixed time in tests
+func WithTimeToolClock(clock func() time.Time) TimeToolOption {
+	return func(t *TimeTool) {
+		t.clock = clock
+	}
+}
+
+// WithTimeToolLocation sets the timezone used when a method gets none (default UTC)
+func WithTimeToolLocation(location *time.Location) TimeToolOption {
+	return func(t *TimeTool) {
+		t.location = location
+	}
+}
+
+// TimeNowParams represents parameters for now
+type TimeNowParams struct {
+	Timezone string `json:"timezone,omitempty" description:"IANA timezone such as America/Sao_Paulo or Europe/Berlin. Default: UTC."`
+}
+
+// TimeAddDurationParams represents parameters for add_duration
+type TimeAddDurationParams struct {
+	Time     string `json:"time" description:"Start time: RFC3339, YYYY-MM-DD, YYYY-MM-DD HH:MM:SS, unix seconds or 'now'" required:"true"`
+	Duration string `json:"duration" description:"Duration to add, negative to subtract: e.g. 90m, 2h30m, 3d, 2w, -1d12h" required:"true"`
+	Timezone string `json:"timezone,omitempty" description:"IANA timezone for the result and for times without an offset. Default: UTC."`
+}
+
+// TimeDiffParams represents parameters for diff
+type TimeDiffParams struct {
+	TimeA    string `json:"time_a" description:"Earlier time, same formats as add_duration" required:"true"`
+	TimeB    string `json:"time_b" description:"Later time, same formats as add_duration" required:"true"`
+	Timezone string `json:"timezone,omitempty" description:"IANA timezone for times without an offset. Default: UTC."`
+}
+
+// TimeParseParams represents parameters for parse
+type TimeParseParams struct {
+	Value    string `json:"value" description:"Text to parse, e.g. 'Mon, 02 Jan 2006 15:04:05 MST'" required:"true"`
+	Layout   string `json:"layout,omitempty" description:"Go reference layout (e.g. 02/01/2006 15:04) or a name: RFC3339, RFC1123, DateTime, DateOnly, Kitchen. Default: detect common formats."`
+	Timezone string `json:"timezone,omitempty" description:"IANA timezone for values without an offset. Default: UTC."`
+}
+
+// TimeInfo describes a point in time
+type TimeInfo struct {
+	Time      string `json:"time"`
+	Unix      int64  `json:"unix"`
+	Timezone  string `json:"timezone"`
+	UTCOffset string `json:"utc_offset"`
+	Date      string `json:"date"`
+	Clock     string `json:"clock"`
+	Weekday   string `json:"weekday"`
+	DayOfYear int    `json:"day_of_year"`
+	ISOWeek   int    `json:"iso_week"`
+}
+
+// TimeDiff is the difference time_b - time_a
+type TimeDiff struct {
+	TimeA    TimeInfo `json:"time_a"`
+	TimeB    TimeInfo `json:"time_b"`
+	Duration string   `json:"duration"`
+	Seconds  float64  `json:"seconds"`
+	Hours    float64  `json:"hours"`
+	Days     float64  `json:"days"`
+	Human    string   `json:"human"`
+}
+
+// NewTimeTool creates a new TimeTool
+func NewTimeTool(options ...TimeToolOption) *TimeTool {
+	t := &TimeTool{
+		clock:    time.Now,
+		location: time.UTC,
+	}
+	for _, opt := range options {
+		opt(t)
+	}
+
+	tk := toolkit.NewToolkit()
+	tk.Name = "TimeTool"
+	tk.Description = "Current time, timezone conversion and date arithmetic. Use it instead of guessing dates."
+
+	t.Toolkit = tk
+	t.Toolkit.Register("now", "Get the current date and time in a timezone.", t, t.Now, TimeNowParams{})
+	t.Toolkit.Register("add_duration", "Add or subtract a duration (minutes, hours, days, weeks) to a time.", t, t.AddDuration, TimeAddDurationParams{})
+	t.Toolkit.Register("diff", "Compute the time elapsed from time_a to time_b.", t, t.Diff, TimeDiffParams{})
+	t.Toolkit.Register("parse", "Parse a date/time string into a normalized time.", t, t.Parse, TimeParseParams{})
+
+	return t
+}
+
+// Now returns the current time in the requested timezone
+func (t *TimeTool) Now(params TimeNowParams) (interface{}, error) {
+	loc, err := t.loadLocation(params.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	return newTimeInfo(t.clock().In(loc)), nil
+}
+
+// AddDuration adds a duration to a time
+func (t *TimeTool) AddDuration(params TimeAddDurationParams) (interface{}, error) {
+	loc, err := t.loadLocation(params.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	start, err := t.parseTime(params.Time, loc)
+	if err != nil {
+		return nil, err
+	}
+	d, err := parseExtendedDuration(params.Duration)
+	if err != nil {
+		return nil, err
+	}
+	return newTimeInfo(start.Add(d).In(loc)), nil
+}
+
+// Diff returns time_b - time_a
+func (t *TimeTool) Diff(params TimeDiffParams) (interface{}, error) {
+	loc, err := t.loadLocation(params.Timezone)
+	if err != nil {
+		return nil, err
+	}
+	a, err := t.parseTime(params.TimeA, loc)
+	if err != nil {
+		return nil, fmt.Errorf("time_a: %w", err)
+	}
+	b, err := t.parseTime(params.TimeB, loc)
+	if err != nil {
+		return nil, fmt.Errorf("time_b: %w", err)
+	}
+
+	d := b.Sub(a)
+	return TimeDiff{
+		TimeA:    newTimeInfo(a),
+		TimeB:    newTimeInfo(b),
+		Duration: d.String(),
+		Seconds:  d.Seconds(),
+		Hours:    d.Hours(),
+		Days:     d.Hours() / 24,
+		Human:    humanizeDuration(d),
+	}, nil
+}
+
+// Parse parses a value with a layout, or detects common formats without one
+func (t *TimeTool) Parse(params TimeParseParams) (interface{}, error) {
+	if params.Value == "" {
+		return nil, fmt.Errorf("value is required")
+	}
+	loc, err := t.loadLocation(params.Timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.Layout == "" {
+		parsed, err := t.parseTime(params.Value, loc)
+		if err != nil {
+			return nil, err
+		}
+		return newTimeInfo(parsed), nil
+	}
+
+	layout := params.Layout
+	if named, ok := namedTimeLayouts[layout]; ok {
+		layout = named
+	}
+	parsed, err := time.ParseInLocation(layout, strings.TrimSpace(params.Value), loc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q with layout %q: %w", params.Value, params.Layout, err)
+	}
+	return newTimeInfo(parsed), nil
+}
+
+// loadLocation resolves an IANA timezone, defaulting to the tool location
+func (t *TimeTool) loadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return t.location, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q: use an IANA name such as America/New_York", name)
+	}
+	return loc, nil
+}
+
+// parseTime parses 'now', unix seconds or one of the common layouts in loc
+func (t *TimeTool) parseTime(value string, loc *time.Location) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, fmt.Errorf("time is required")
+	}
+	if strings.EqualFold(value, "now") {
+		return t.clock().In(loc), nil
+	}
+	if secs, err := strconv.ParseInt(value, 10, 64); err == nil && len(value) > 8 {
+		return time.Unix(secs, 0).In(loc), nil
+	}
+	for _, layout := range commonTimeLayouts {
+		if parsed, err := time.ParseInLocation(layout, value, loc); err == nil {
+			return parsed, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized time %q: use RFC3339 (2006-01-02T15:04:05Z07:00), YYYY-MM-DD, unix seconds or 'now'", value)
+}
+
+// parseExtendedDuration parses Go durations plus d (24h) and w (7d) units, e.g. "1w2d" or "-3d12h"
+func parseExtendedDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, fmt.Errorf("duration is required")
+	}
+
+	sign := time.Duration(1)
+	rest := value
+	if strings.HasPrefix(rest, "-") {
+		sign = -1
+		rest = rest[1:]
+	} else {
+		rest = strings.TrimPrefix(rest, "+")
+	}
+
+	var total time.Duration
+	for _, unit := range []struct {
+		suffix string
+		size   time.Duration
+	}{{"w", 7 * 24 * time.Hour}, {"d", 24 * time.Hour}} {
+		i := strings.Index(rest, unit.suffix)
+		if i < 0 {
+			continue
+		}
+		n, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		total += time.Duration(n * float64(unit.size))
+		rest = rest[i+1:]
+	}
+	if rest != "" {
+		d, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q: use units w, d, h, m, s (e.g. 2d3h)", value)
+		}
+		total += d
+	}
+	return sign * total, nil
+}
+
+// newTimeInfo describes t
+func newTimeInfo(t time.Time) TimeInfo {
+	_, week := t.ISOWeek()
+	return TimeInfo{
+		Time:      t.Format(time.RFC3339),
+		Unix:      t.Unix(),
+		Timezone:  t.Location().String(),
+		UTCOffset: t.Format("-07:00"),
+		Date:      t.Format(time.DateOnly),
+		Clock:     t.Format(time.TimeOnly),
+		Weekday:   t.Weekday().String(),
+		DayOfYear: t.YearDay(),
+		ISOWeek:   week,
+	}
+}
+
+// humanizeDuration renders d as days, hours and minutes, e.g. "2 days 3 hours"
+func humanizeDuration(d time.Duration) string {
+	prefix := ""
+	if d < 0 {
+		prefix = "-"
+		d = -d
+	}
+	days := int(d.Hours()) / 24
+	hours := int(d.Hours()) % 24
+	minutes := int(math.Mod(d.Minutes(), 60))
+
+	var parts []string
+	for _, p := range []struct {
+		n    int
+		unit string
+	}{{days, "day"}, {hours, "hour"}, {minutes, "minute"}} {
+		if p.n == 0 {
+			continue
+		}
+		if p.n == 1 {
+			parts = append(parts, fmt.Sprintf("1 %s", p.unit))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d %ss", p.n, p.unit))
+		}
+	}
+	if len(parts) == 0 {
+		return fmt.Sprintf("%s%d seconds", prefix, int(d.Seconds()))
+	}
+	return prefix + strings.Join(parts, " ")
+}
@@ -0,0 +1,46 @@
+package tools
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeTool(t *testing.T) {
+	fixed := time.Date(2025, 3, 30, 0, 30, 0, 0, time.UTC)
+	tool := NewTimeTool(WithTimeToolClock(func() time.Time { return fixed }))
+
+	out, err := tool.Now(TimeNowParams{Timezone: "America/Sao_Paulo"})
+	if err != nil {
+		t.Fatalf("Now: %v", err)
+	}
+	if info := out.(TimeInfo); info.Time != "2025-03-29T21:30:00-03:00" || info.Weekday != "Saturday" {
+		t.Errorf("unexpected now: %+v", info)
+	}
+	if _, err := tool.Now(TimeNowParams{Timezone: "Mars/Olympus"}); err == nil {
+		t.Error("expected an unknown timezone error")
+	}
+
+	out, err = tool.AddDuration(TimeAddDurationParams{Time: "2025-01-31", Duration: "1w1d12h"})
+	if err != nil {
+		t.Fatalf("AddDuration: %v", err)
+	}
+	if info := out.(TimeInfo); info.Time != "2025-02-08T12:00:00Z" {
+		t.Errorf("unexpected sum: %+v", info)
+	}
+
+	out, err = tool.Diff(TimeDiffParams{TimeA: "2025-01-01T08:00:00Z", TimeB: "now"})
+	if err != nil {
+		t.Fatalf("Diff: %v", err)
+	}
+	if diff := out.(TimeDiff); diff.Human != "87 days 16 hours 30 minutes" || diff.Days < 87 || diff.Days > 88 {
+		t.Errorf("unexpected diff: %+v", diff)
+	}
+
+	out, err = tool.Parse(TimeParseParams{Value: "31/12/2024 23:59", Layout: "02/01/2006 15:04", Timezone: "Europe/Berlin"})
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if info := out.(TimeInfo); info.Time != "2024-12-31T23:59:00+01:00" {
+		t.Errorf("unexpected parse: %+v", info)
+	}
+}
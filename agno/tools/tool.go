@@ -72,6 +72,25 @@ type Tool struct {
 //	}
 //	tool := NewToolFromFunction(add, "Add two numbers")
 //	// Now use tool with Agent!
+//
+// With an empty description, the tool is named after the function and described
+// by its doc comment, as registered by the tooldoc generator.
+//
+// Functions may also return a toolkit.ToolResult (or *toolkit.ToolResult) to
+// hand structured data to downstream code while the model only sees Content:
+//
+//	func chart(query string) (toolkit.ToolResult, error) {
+//	    png := render(query)
+//	    return toolkit.NewToolResult("Chart rendered", png, "image/png"), nil
+//	}
+//
+// Functions whose first parameter is a context.Context receive the run context
+// when called by an agent, carrying the metadata passed with agent.WithMetadata:
+//
+//	func lookupOrder(ctx context.Context, id string) (string, error) {
+//	    tenant := agent.MetadataFromContext(ctx)["tenant_id"]
+//	    ...
+//	}
 func NewToolFromFunction(fn interface{}, description string) *Tool {
 	fnValue := reflect.ValueOf(fn)
 	fnType := fnValue.Type()
@@ -83,6 +102,12 @@ func NewToolFromFunction(fn interface{}, description string) *Tool {
 	// Use description as name: convert to camelCase for Ollama compatibility
 	name := toCamelCase(description)
 
+	// Without a description, use the function name and its doc comment (see tooldoc)
+	if description == "" {
+		description, _ = toolkit.FuncDoc(fn)
+		name = toolkit.FuncName(fn)
+	}
+
 	// Generate schema from function signature
 	schema := generateSchemaFromFunction(fnType)
 
@@ -176,11 +201,19 @@ func typeToJSONSchema(t reflect.Type) map[string]interface{} {
 		return map[string]interface{}{
 			"type": "object",
 		}
+	case reflect.Struct:
+		// Fields are described by their json, description, required and jsonschema tags
+		return toolkit.GenerateSchemaFromType(t)
+	case reflect.Ptr:
+		return typeToJSONSchema(t.Elem())
 	default:
 		return map[string]interface{}{"type": "string"}
 	}
 }
 
+// toolResultPtrType is the reflect type of *toolkit.ToolResult
+var toolResultPtrType = reflect.TypeOf((*toolkit.ToolResult)(nil))
+
 // createFunctionWrapper creates a wrapper that accepts map[string]interface{}
 func createFunctionWrapper(fnValue reflect.Value, fnType reflect.Type) func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
 	return func(ctx context.Context, args map[string]interface{}) (interface{}, error) {
@@ -242,6 +275,14 @@ func createFunctionWrapper(fnValue reflect.Value, fnType reflect.Type) func(ctx
 
 		// Return the first result
 		if lastResultIdx >= 0 {
+			// Structured results are passed on as a ToolResult value so the agent
+			// sends Content to the model and keeps Data for downstream consumers
+			if results[0].Type() == toolResultPtrType {
+				if results[0].IsNil() {
+					return nil, nil
+				}
+				return results[0].Elem().Interface(), nil
+			}
 			return results[0].Interface(), nil
 		}
 
@@ -263,6 +304,28 @@ func convertValue(value interface{}, targetType reflect.Type) (reflect.Value, er
 		return sourceValue, nil
 	}
 
+	// Objects are decoded into structs, and pointers to structs, through JSON
+	if _, ok := value.(map[string]interface{}); ok {
+		structType := targetType
+		if structType.Kind() == reflect.Ptr {
+			structType = structType.Elem()
+		}
+		if structType.Kind() == reflect.Struct {
+			data, err := json.Marshal(value)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			ptr := reflect.New(structType)
+			if err := json.Unmarshal(data, ptr.Interface()); err != nil {
+				return reflect.Value{}, err
+			}
+			if targetType.Kind() == reflect.Ptr {
+				return ptr, nil
+			}
+			return ptr.Elem(), nil
+		}
+	}
+
 	// Try conversion
 	switch targetType.Kind() {
 	case reflect.String:
@@ -330,13 +393,22 @@ func (t *Tool) GetDescriptionOfMethod(methodName string) string {
 
 // Execute executes the tool with the given arguments
 func (t *Tool) Execute(methodName string, input json.RawMessage) (interface{}, error) {
+	return t.ExecuteContext(context.Background(), methodName, input)
+}
+
+// ExecuteContext executes the tool, passing ctx to functions that take a context.Context
+func (t *Tool) ExecuteContext(ctx context.Context, methodName string, input json.RawMessage) (interface{}, error) {
 	// Parse input
 	var args map[string]interface{}
 	if err := json.Unmarshal(input, &args); err != nil {
 		return nil, fmt.Errorf("failed to parse arguments: %v", err)
 	}
 
+	// Reject values outside the enum or bounds of the schema before running
+	if err := toolkit.CheckConstraints(t.Parameters, args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
 	// Execute the tool
-	ctx := context.Background()
 	return t.Entrypoint(ctx, args)
 }
@@ -0,0 +1,43 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func TestNewToolFromFunctionToolResult(t *testing.T) {
+	tool := NewToolFromFunction(func(query string) (*toolkit.ToolResult, error) {
+		return &toolkit.ToolResult{
+			Content:  "2 rows for " + query,
+			Data:     []map[string]interface{}{{"id": 1}, {"id": 2}},
+			MimeType: "application/json",
+		}, nil
+	}, "query table")
+
+	out, err := tool.Execute(tool.Name, json.RawMessage(`{"arg0":"users"}`))
+	if err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	result, ok := out.(toolkit.ToolResult)
+	if !ok {
+		t.Fatalf("expected toolkit.ToolResult, got %T", out)
+	}
+	if result.MimeType != "application/json" || len(result.Data.([]map[string]interface{})) != 2 {
+		t.Errorf("structured data lost: %+v", result)
+	}
+	if got := toolkit.ModelContent(out); got != "2 rows for users" {
+		t.Errorf("ModelContent = %v", got)
+	}
+	if got := fmt.Sprintf("%v", out); got != "2 rows for users" {
+		t.Errorf("formatted result = %q", got)
+	}
+
+	nilTool := NewToolFromFunction(func() (*toolkit.ToolResult, error) { return nil, nil }, "nothing")
+	if out, err := nilTool.Execute(nilTool.Name, json.RawMessage(`{}`)); err != nil || out != nil {
+		t.Errorf("nil *ToolResult should give (nil, nil), got (%v, %v)", out, err)
+	}
+}
@@ -0,0 +1,57 @@
+package tools
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+type roomBooking struct {
+	RoomType string `json:"room_type" description:"Type of room" jsonschema:"enum=standard|deluxe|suite"`
+	Nights   int    `json:"nights" required:"true" jsonschema:"min=1,max=14"`
+}
+
+func TestNewToolFromFunctionStructSchema(t *testing.T) {
+	var booked roomBooking
+	tool := NewToolFromFunction(func(booking roomBooking) (string, error) {
+		booked = booking
+		return "booked", nil
+	}, "Book a room")
+
+	arg := tool.Parameters["properties"].(map[string]interface{})["arg0"].(map[string]interface{})
+	if arg["type"] != "object" {
+		t.Fatalf("expected the struct to be an object, got %v", arg)
+	}
+	fields := arg["properties"].(map[string]interface{})
+	roomType := fields["room_type"].(map[string]interface{})
+	if roomType["description"] != "Type of room" || !reflect.DeepEqual(roomType["enum"], []string{"standard", "deluxe", "suite"}) {
+		t.Errorf("unexpected room_type schema: %v", roomType)
+	}
+	if nights := fields["nights"].(map[string]interface{}); nights["minimum"] != 1.0 || nights["maximum"] != 14.0 {
+		t.Errorf("unexpected nights schema: %v", nights)
+	}
+	if required := arg["required"].([]string); !reflect.DeepEqual(required, []string{"nights"}) {
+		t.Errorf("unexpected required fields: %v", required)
+	}
+
+	out, err := tool.Execute(tool.Name, json.RawMessage(`{"arg0": {"room_type": "suite", "nights": 2}}`))
+	if err != nil || out != "booked" {
+		t.Fatalf("Execute = %v, %v", out, err)
+	}
+	if booked != (roomBooking{RoomType: "suite", Nights: 2}) {
+		t.Errorf("struct argument not decoded: %+v", booked)
+	}
+
+	for _, input := range []string{
+		`{"arg0": {"room_type": "penthouse", "nights": 2}}`,
+		`{"arg0": {"room_type": "suite", "nights": 15}}`,
+	} {
+		booked = roomBooking{}
+		if _, err := tool.Execute(tool.Name, json.RawMessage(input)); err == nil {
+			t.Errorf("expected %s to be rejected", input)
+		}
+		if booked != (roomBooking{}) {
+			t.Errorf("function ran for invalid input %s", input)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+// Command tooldoc extracts the doc comments of exported functions and methods
+// in a package and generates a file registering them as tool descriptions, so
+// toolkit.Register can be called with an empty description and
+// toolkit.RegisterFunc can be used without repeating the comment:
+//
+//	//go:generate go run github.com/devalexandre/agno-golang/agno/tools/toolkit/cmd/tooldoc -types MathToolkit
+//
+// A comment that starts with the function name has it removed, so
+// "// Add adds two numbers." becomes "Adds two numbers.".
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+func main() {
+	dir := flag.String("dir", ".", "package directory")
+	output := flag.String("output", "tooldocs_gen.go", "generated file name, relative to dir")
+	types := flag.String("types", "", "comma-separated receiver types to include (default: all exported functions and methods)")
+	flag.Parse()
+
+	var include map[string]bool
+	if *types != "" {
+		include = make(map[string]bool)
+		for _, t := range strings.Split(*types, ",") {
+			include[strings.TrimSpace(t)] = true
+		}
+	}
+
+	pkgName, descriptions, err := extractDocs(*dir, *output, include)
+	if err != nil {
+		log.Fatalf("tooldoc: %v", err)
+	}
+
+	src, err := render(pkgName, descriptions)
+	if err != nil {
+		log.Fatalf("tooldoc: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(*dir, *output), src, 0o644); err != nil {
+		log.Fatalf("tooldoc: %v", err)
+	}
+}
+
+// extractDocs collects the doc comments of the package in dir, keyed as
+// toolkit.FuncDoc expects: "package.Func" or "package.Type.Method"
+func extractDocs(dir, output string, include map[string]bool) (string, map[string]string, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return "", nil, err
+	}
+
+	fset := token.NewFileSet()
+	pkgName := ""
+	descriptions := make(map[string]string)
+	for _, path := range files {
+		if strings.HasSuffix(path, "_test.go") || filepath.Base(path) == output {
+			continue
+		}
+		file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+		if err != nil {
+			return "", nil, err
+		}
+		pkgName = file.Name.Name
+
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Doc == nil || !fn.Name.IsExported() {
+				continue
+			}
+			key := fn.Name.Name
+			if fn.Recv != nil && len(fn.Recv.List) == 1 {
+				recv := receiverName(fn.Recv.List[0].Type)
+				if recv == "" {
+					continue
+				}
+				key = recv + "." + key
+				if include != nil && !include[recv] {
+					continue
+				}
+			} else if include != nil {
+				continue
+			}
+			if description := describe(fn.Name.Name, fn.Doc.Text()); description != "" {
+				descriptions[pkgName+"."+key] = description
+			}
+		}
+	}
+	if pkgName == "" {
+		return "", nil, fmt.Errorf("no Go files in %s", dir)
+	}
+	return pkgName, descriptions, nil
+}
+
+// receiverName returns the type name of a method receiver
+func receiverName(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return receiverName(t.X)
+	case *ast.Ident:
+		return t.Name
+	case *ast.IndexExpr:
+		return receiverName(t.X)
+	case *ast.IndexListExpr:
+		return receiverName(t.X)
+	}
+	return ""
+}
+
+// describe joins a doc comment into one line and drops the leading function name
+func describe(name, doc string) string {
+	text := strings.Join(strings.Fields(doc), " ")
+	if rest, ok := strings.CutPrefix(text, name+" "); ok {
+		r, size := utf8.DecodeRuneInString(rest)
+		text = string(unicode.ToUpper(r)) + rest[size:]
+	}
+	return text
+}
+
+// render generates the Go source registering the descriptions
+func render(pkgName string, descriptions map[string]string) ([]byte, error) {
+	keys := make([]string, 0, len(descriptions))
+	for key := range descriptions {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by tooldoc; DO NOT EDIT.\n\n")
+	fmt.Fprintf(&buf, "package %s\n\n", pkgName)
+	buf.WriteString("import \"github.com/devalexandre/agno-golang/agno/tools/toolkit\"\n\n")
+	buf.WriteString("func init() {\n\ttoolkit.RegisterDocs(map[string]string{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&buf, "\t\t%s: %s,\n", strconv.Quote(key), strconv.Quote(descriptions[key]))
+	}
+	buf.WriteString("\t})\n}\n")
+
+	return format.Source(buf.Bytes())
+}
@@ -1,6 +1,7 @@
 package toolkit
 
 import (
+	"context"
 	"encoding/json"
 	"reflect"
 	"sync"
@@ -59,6 +60,9 @@ type Method struct {
 	// Per-method hooks (in addition to toolkit-level hooks)
 	PreHooks  []HookFunc
 	PostHooks []PostHookFunc
+	// RateLimiter limits how often the method runs (see WithRateLimit).
+	// Cached results are returned without taking a call.
+	RateLimiter *RateLimiter
 }
 
 // Tool is the interface that defines the basic operations for any tool.
@@ -79,3 +83,11 @@ type ConnectableTool interface {
 	Connect() error
 	Close() error
 }
+
+// ContextTool is an optional interface for tools that accept the run context.
+// Agents call ExecuteContext instead of Execute, so the tool can read the run
+// metadata (agent.MetadataFromContext) and stop when the run is cancelled.
+type ContextTool interface {
+	Tool
+	ExecuteContext(ctx context.Context, methodName string, input json.RawMessage) (interface{}, error)
+}
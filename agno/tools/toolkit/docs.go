@@ -0,0 +1,67 @@
+package toolkit
+
+import (
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// Doc comments are not available through reflection, so the tooldoc generator
+// extracts them at build time and registers them here from an init function:
+//
+//	//go:generate go run github.com/devalexandre/agno-golang/agno/tools/toolkit/cmd/tooldoc
+//
+// Register and RegisterFunc fall back to these descriptions when none is given.
+var (
+	docsMu sync.RWMutex
+	docs   = make(map[string]string)
+)
+
+// RegisterDocs registers tool descriptions keyed by "package.Func" or
+// "package.Type.Method" (package name, not import path). It is called by
+// files generated by tooldoc.
+func RegisterDocs(descriptions map[string]string) {
+	docsMu.Lock()
+	defer docsMu.Unlock()
+	for key, description := range descriptions {
+		docs[key] = description
+	}
+}
+
+// FuncDoc returns the registered description of a function or method value
+func FuncDoc(fn interface{}) (string, bool) {
+	key := funcKey(fn)
+	if key == "" {
+		return "", false
+	}
+	docsMu.RLock()
+	defer docsMu.RUnlock()
+	description, ok := docs[key]
+	return description, ok
+}
+
+// FuncName returns the name of a function or method value, e.g. "Add" for
+// mathToolkit.Add, or "" when it cannot be determined
+func FuncName(fn interface{}) string {
+	key := funcKey(fn)
+	return key[strings.LastIndex(key, ".")+1:]
+}
+
+// funcKey converts the runtime name of fn into a docs key: the import path
+// directory, pointer receiver markers and the method value suffix are dropped,
+// so "github.com/acme/calc.(*MathToolkit).Add-fm" becomes "calc.MathToolkit.Add"
+func funcKey(fn interface{}) string {
+	v := reflect.ValueOf(fn)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+	f := runtime.FuncForPC(v.Pointer())
+	if f == nil {
+		return ""
+	}
+	name := strings.TrimSuffix(f.Name(), "-fm")
+	name = name[strings.LastIndex(name, "/")+1:]
+	name = strings.NewReplacer("(*", "", "(", "", ")", "").Replace(name)
+	return name
+}
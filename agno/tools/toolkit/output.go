@@ -0,0 +1,31 @@
+package toolkit
+
+import "context"
+
+// ToolOutput is a piece of output a tool reports while it runs, such as a
+// line printed by a shell command, before it returns its result.
+type ToolOutput struct {
+	Tool   string `json:"tool"`   // Method reporting the output, e.g. "ShellTool_Execute"
+	Stream string `json:"stream"` // "stdout" or "stderr"
+	Text   string `json:"text"`   // One line, without the trailing newline
+}
+
+// ToolOutputFunc receives the output of a running tool. It may be called from
+// several goroutines, one call at a time, and must not block for long.
+type ToolOutputFunc func(output ToolOutput)
+
+type toolOutputKey struct{}
+
+// ContextWithToolOutput returns a context whose tools report their output to
+// fn while they run. Agents set it for streamed runs; tools read it with
+// ToolOutputFromContext in ExecuteContext.
+func ContextWithToolOutput(ctx context.Context, fn ToolOutputFunc) context.Context {
+	return context.WithValue(ctx, toolOutputKey{}, fn)
+}
+
+// ToolOutputFromContext returns the function tools report their output to,
+// or nil when nobody is listening.
+func ToolOutputFromContext(ctx context.Context) ToolOutputFunc {
+	fn, _ := ctx.Value(toolOutputKey{}).(ToolOutputFunc)
+	return fn
+}
@@ -0,0 +1,121 @@
+package toolkit
+
+import (
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultRateLimitWait is how long a rate limited call waits for its turn
+// before failing with a RateLimitError, for limits set with WithRateLimit or
+// RegisterWithRateLimit.
+const DefaultRateLimitWait = 10 * time.Second
+
+// RateLimitError is returned when a method is called more often than its
+// rate limit allows and the wait for a free slot would exceed the limiter's
+// MaxWait.
+type RateLimitError struct {
+	Method     string
+	RetryAfter time.Duration // Time until the call would be allowed
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded for %s, retry after %s", e.Method, e.RetryAfter.Round(time.Millisecond))
+}
+
+// RateLimiter is a token bucket: it allows Burst calls at once and refills
+// at RPS calls per second. Calls beyond that wait up to MaxWait for a token.
+// One RateLimiter can be shared by several methods, e.g. all the methods of
+// a toolkit calling the same API (see WithRateLimiter).
+//
+// It limits individual tool calls, independently of the agent's
+// RateLimitGuardrail, which limits whole runs.
+type RateLimiter struct {
+	rps     float64
+	burst   float64
+	maxWait time.Duration
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter allowing rps calls per second with bursts
+// of up to burst calls. A call waits up to maxWait for its turn; zero fails
+// at once when no call is available.
+func NewRateLimiter(rps float64, burst int, maxWait time.Duration) *RateLimiter {
+	if rps <= 0 {
+		panic("NewRateLimiter: rps must be positive")
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rps:     rps,
+		burst:   float64(burst),
+		maxWait: maxWait,
+		tokens:  float64(burst),
+		last:    time.Now(),
+	}
+}
+
+// reserve takes a token, possibly one that is not available yet, and returns
+// how long to wait until it is. When that wait exceeds maxWait no token is
+// taken and ok is false.
+func (l *RateLimiter) reserve(now time.Time) (wait time.Duration, ok bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+elapsed.Seconds()*l.rps)
+		l.last = now
+	}
+
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0, true
+	}
+
+	wait = time.Duration((1 - l.tokens) / l.rps * float64(time.Second))
+	if wait > l.maxWait {
+		return wait, false
+	}
+	// Tokens go negative so later callers queue behind this one
+	l.tokens--
+	return wait, true
+}
+
+// Wait blocks until a call is allowed, or returns a *RateLimitError at once
+// if that would take longer than the limiter's maxWait.
+func (l *RateLimiter) Wait(methodName string) error {
+	wait, ok := l.reserve(time.Now())
+	if !ok {
+		return &RateLimitError{Method: methodName, RetryAfter: wait}
+	}
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+	return nil
+}
+
+// WithRateLimit limits the method to rps calls per second with bursts of up
+// to burst calls. Calls over the limit wait up to DefaultRateLimitWait, then
+// fail with a *RateLimitError the model can see.
+func WithRateLimit(rps float64, burst int) MethodOption {
+	return WithRateLimiter(NewRateLimiter(rps, burst, DefaultRateLimitWait))
+}
+
+// WithRateLimiter limits the method with limiter, which may be shared with
+// other methods to give them a common limit.
+func WithRateLimiter(limiter *RateLimiter) MethodOption {
+	return func(m *Method) {
+		m.RateLimiter = limiter
+	}
+}
+
+// RegisterWithRateLimit registers a method limited to rps calls per second
+// with bursts of up to burst calls (see WithRateLimit).
+func (tk *Toolkit) RegisterWithRateLimit(methodName, description string, receiver interface{}, fn interface{}, paramExample interface{}, rps float64, burst int, opts ...MethodOption) {
+	tk.RegisterWithOptions(methodName, description, receiver, fn, paramExample, append(opts, WithRateLimit(rps, burst))...)
+}
@@ -0,0 +1,43 @@
+package toolkit
+
+// ToolResult is a structured value a tool can return instead of a plain string.
+// Content is what the model sees; Data and MimeType carry the raw payload
+// (an image, a table, a JSON document...) for downstream code such as event
+// handlers, hooks or the caller inspecting tool results.
+type ToolResult struct {
+	Content  string      `json:"content"`
+	Data     interface{} `json:"data,omitempty"`
+	MimeType string      `json:"mime_type,omitempty"`
+}
+
+// NewToolResult creates a ToolResult with the given model-facing content and payload
+func NewToolResult(content string, data interface{}, mimeType string) ToolResult {
+	return ToolResult{Content: content, Data: data, MimeType: mimeType}
+}
+
+// String returns the model-facing content
+func (r ToolResult) String() string {
+	return r.Content
+}
+
+// AsToolResult reports whether v is a ToolResult or a non-nil *ToolResult
+func AsToolResult(v interface{}) (ToolResult, bool) {
+	switch r := v.(type) {
+	case ToolResult:
+		return r, true
+	case *ToolResult:
+		if r != nil {
+			return *r, true
+		}
+	}
+	return ToolResult{}, false
+}
+
+// ModelContent returns the value that should be sent to the model for a tool
+// result: the Content of a ToolResult, or v unchanged for any other value.
+func ModelContent(v interface{}) interface{} {
+	if r, ok := AsToolResult(v); ok {
+		return r.Content
+	}
+	return v
+}
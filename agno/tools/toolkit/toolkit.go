@@ -67,6 +67,7 @@ func (tk *Toolkit) ExcludeTools(names ...string) {
 
 // Register registers a method in the toolkit.
 // methodName = Function name
+// description = What the method does; when empty, the doc comment registered by tooldoc is used
 // fn = Execution function
 // paramExample = Example struct that represents the parameters for schema generation
 func (tk *Toolkit) Register(methodName, description string, receiver interface{}, fn interface{}, paramExample interface{}) {
@@ -79,7 +80,10 @@ func (tk *Toolkit) Register(methodName, description string, receiver interface{}
 	}
 
 	if description == "" {
-		panic("Register: description cannot be empty")
+		description, _ = FuncDoc(fn)
+	}
+	if description == "" {
+		panic(fmt.Sprintf("Register: description cannot be empty (no doc comment registered for %s, run tooldoc)", methodName))
 	}
 
 	if receiver == nil {
@@ -119,6 +123,16 @@ func (tk *Toolkit) Register(methodName, description string, receiver interface{}
 	}
 }
 
+// RegisterFunc registers a method named after fn (e.g. "Add" for mt.Add) and
+// described by its doc comment, as extracted by tooldoc.
+func (tk *Toolkit) RegisterFunc(receiver interface{}, fn interface{}, paramExample interface{}) {
+	name := FuncName(fn)
+	if name == "" {
+		panic("RegisterFunc: cannot determine the function name")
+	}
+	tk.Register(name, "", receiver, fn, paramExample)
+}
+
 // MethodOption configures optional properties on a registered method.
 type MethodOption func(*Method)
 
@@ -287,6 +301,13 @@ func (tk *Toolkit) Execute(methodName string, input json.RawMessage) (interface{
 		}
 	}
 
+	// Wait for the method's rate limit
+	if method.RateLimiter != nil {
+		if err := method.RateLimiter.Wait(methodName); err != nil {
+			return nil, err
+		}
+	}
+
 	// Parse JSON to intermediate map
 	var argsMap map[string]interface{}
 	if err := json.Unmarshal(input, &argsMap); err != nil {
@@ -330,6 +351,11 @@ func (tk *Toolkit) Execute(methodName string, input json.RawMessage) (interface{
 		}
 	}
 
+	// Reject values outside the enum or bounds of the schema before running
+	if err := CheckConstraints(method.Schema, argsMap); err != nil {
+		return nil, fmt.Errorf("Execute: invalid arguments: %w", err)
+	}
+
 	// Re-marshal corrected data
 	cleanJSON, err := json.Marshal(argsMap)
 	if err != nil {
@@ -402,13 +428,32 @@ func GenerateSchemaFromType(paramType reflect.Type) map[string]interface{} {
 		// Map to JSON Schema type
 		typeStr := mapGoTypeToJSONType(field.Type.Kind())
 
-		// Tag description
+		// Tag description, or the description in a jsonschema tag
 		description := field.Tag.Get("description")
+		jsonSchema := parseJSONSchemaTag(field.Tag.Get("jsonschema"))
+		if description == "" {
+			description = jsonSchema.description
+		}
 
 		prop := map[string]interface{}{
 			"type":        typeStr,
 			"description": description,
 		}
+		if len(jsonSchema.enum) > 0 {
+			prop["enum"] = enumValues(jsonSchema.enum, typeStr)
+		}
+		if jsonSchema.minimum != nil {
+			prop["minimum"] = *jsonSchema.minimum
+		}
+		if jsonSchema.maximum != nil {
+			prop["maximum"] = *jsonSchema.maximum
+		}
+		if jsonSchema.minLength != nil {
+			prop["minLength"] = *jsonSchema.minLength
+		}
+		if jsonSchema.maxLength != nil {
+			prop["maxLength"] = *jsonSchema.maxLength
+		}
 		// If it's an array or slice, define items automatically
 		if field.Type.Kind() == reflect.Slice || field.Type.Kind() == reflect.Array {
 			elemType := field.Type.Elem().Kind()
@@ -420,7 +465,7 @@ func GenerateSchemaFromType(paramType reflect.Type) map[string]interface{} {
 		properties[fieldName] = prop
 
 		// If the tag is required, add it
-		if field.Tag.Get("required") == "true" {
+		if field.Tag.Get("required") == "true" || jsonSchema.required {
 			requiredFields = append(requiredFields, fieldName)
 		}
 	}
@@ -431,6 +476,90 @@ func GenerateSchemaFromType(paramType reflect.Type) map[string]interface{} {
 	return schema
 }
 
+// jsonSchemaTag holds the options of a
+// `jsonschema:"required,description=...,enum=a|b,min=1,max=10,minLength=2,maxLength=50"` tag
+type jsonSchemaTag struct {
+	required    bool
+	description string
+	enum        []string
+	minimum     *float64
+	maximum     *float64
+	minLength   *int
+	maxLength   *int
+}
+
+// jsonSchemaTagKeys are the options a jsonschema tag may hold
+var jsonSchemaTagKeys = map[string]bool{
+	"required": true, "description": true, "enum": true,
+	"min": true, "minimum": true, "max": true, "maximum": true,
+	"minLength": true, "maxLength": true,
+}
+
+// parseJSONSchemaTag parses a jsonschema struct tag. The description runs to
+// the next known option, so it may contain commas. Enum values are given as
+// enum=a|b|c or as repeated enum= options; min and max are aliases of
+// minimum and maximum. Malformed numbers are ignored.
+func parseJSONSchemaTag(tag string) jsonSchemaTag {
+	var parsed jsonSchemaTag
+	if tag == "" {
+		return parsed
+	}
+
+	var parts []string
+	for _, part := range strings.Split(tag, ",") {
+		key := strings.SplitN(part, "=", 2)[0]
+		if !jsonSchemaTagKeys[key] && len(parts) > 0 && strings.HasPrefix(parts[len(parts)-1], "description=") {
+			parts[len(parts)-1] += "," + part
+			continue
+		}
+		parts = append(parts, part)
+	}
+
+	for _, part := range parts {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "required":
+			parsed.required = true
+		case "description":
+			parsed.description = value
+		case "enum":
+			parsed.enum = append(parsed.enum, strings.Split(value, "|")...)
+		case "min", "minimum":
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				parsed.minimum = &f
+			}
+		case "max", "maximum":
+			if f, err := strconv.ParseFloat(value, 64); err == nil {
+				parsed.maximum = &f
+			}
+		case "minLength":
+			if n, err := strconv.Atoi(value); err == nil {
+				parsed.minLength = &n
+			}
+		case "maxLength":
+			if n, err := strconv.Atoi(value); err == nil {
+				parsed.maxLength = &n
+			}
+		}
+	}
+	return parsed
+}
+
+// enumValues types the enum of a jsonschema tag after its field: numbers for
+// number fields, strings otherwise.
+func enumValues(values []string, typeStr string) interface{} {
+	if typeStr != "number" {
+		return values
+	}
+	numbers := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			numbers = append(numbers, f)
+		}
+	}
+	return numbers
+}
+
 // mapGoTypeToJSONType converts Go types to JSON Schema types.
 func mapGoTypeToJSONType(kind reflect.Kind) string {
 	switch kind {
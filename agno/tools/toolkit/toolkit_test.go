@@ -3,6 +3,7 @@ package toolkit
 import (
 	"encoding/json"
 	"errors"
+	"reflect"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -326,3 +327,171 @@ func TestSchemaGeneration(t *testing.T) {
 		t.Fatalf("expected 2 required fields, got %d", len(required))
 	}
 }
+
+type searchParams struct {
+	Query string `json:"query" jsonschema:"required,description=Search query, e.g. golang agents"`
+	Mode  string `json:"mode,omitempty" jsonschema:"description=Search mode,enum=fast,enum=deep"`
+}
+
+func TestSchemaFromJSONSchemaTag(t *testing.T) {
+	schema := GenerateSchemaFromType(reflect.TypeOf(searchParams{}))
+	props := schema["properties"].(map[string]interface{})
+
+	query := props["query"].(map[string]interface{})
+	if query["description"] != "Search query, e.g. golang agents" {
+		t.Fatalf("unexpected description: %v", query["description"])
+	}
+	mode := props["mode"].(map[string]interface{})
+	if enum, _ := mode["enum"].([]string); len(enum) != 2 || enum[1] != "deep" {
+		t.Fatalf("unexpected enum: %v", mode["enum"])
+	}
+	if required := schema["required"].([]string); len(required) != 1 || required[0] != "query" {
+		t.Fatalf("unexpected required fields: %v", required)
+	}
+}
+
+type bookingParams struct {
+	RoomType string `json:"room_type" jsonschema:"required,description=Room type,enum=standard|deluxe|suite"`
+	Nights   int    `json:"nights" jsonschema:"min=1,max=30"`
+	Guest    string `json:"guest" required:"true" jsonschema:"minLength=2,maxLength=40"`
+	Floor    int    `json:"floor" jsonschema:"enum=1|2|3"`
+}
+
+func TestSchemaConstraintTags(t *testing.T) {
+	schema := GenerateSchemaFromType(reflect.TypeOf(bookingParams{}))
+	props := schema["properties"].(map[string]interface{})
+
+	roomType := props["room_type"].(map[string]interface{})
+	if enum, _ := roomType["enum"].([]string); !reflect.DeepEqual(enum, []string{"standard", "deluxe", "suite"}) {
+		t.Fatalf("unexpected enum: %v", roomType["enum"])
+	}
+	nights := props["nights"].(map[string]interface{})
+	if nights["minimum"] != 1.0 || nights["maximum"] != 30.0 {
+		t.Fatalf("unexpected bounds: %v", nights)
+	}
+	guest := props["guest"].(map[string]interface{})
+	if guest["minLength"] != 2 || guest["maxLength"] != 40 {
+		t.Fatalf("unexpected length bounds: %v", guest)
+	}
+	if floor := props["floor"].(map[string]interface{}); !reflect.DeepEqual(floor["enum"], []interface{}{1.0, 2.0, 3.0}) {
+		t.Fatalf("expected a numeric enum, got %v", floor["enum"])
+	}
+	if required := schema["required"].([]string); !reflect.DeepEqual(required, []string{"room_type", "guest"}) {
+		t.Fatalf("unexpected required fields: %v", required)
+	}
+}
+
+func TestExecuteRejectsConstraintViolations(t *testing.T) {
+	var calls int
+	tk := NewToolkit()
+	tk.Name = "Hotel"
+	tk.Register("Book", "Books a room", &tk, func(p bookingParams) (interface{}, error) {
+		calls++
+		return "booked", nil
+	}, bookingParams{})
+
+	for _, input := range []string{
+		`{"room_type": "penthouse", "guest": "Ana"}`,
+		`{"room_type": "suite", "guest": "Ana", "nights": 0}`,
+		`{"room_type": "suite", "guest": "Ana", "nights": "31"}`,
+		`{"room_type": "suite", "guest": "A"}`,
+		`{"room_type": "suite", "guest": "Ana", "floor": 4}`,
+	} {
+		if _, err := tk.Execute("Hotel_Book", json.RawMessage(input)); err == nil {
+			t.Errorf("expected %s to be rejected", input)
+		}
+	}
+	if calls != 0 {
+		t.Fatalf("expected the function not to run, ran %d times", calls)
+	}
+
+	result, err := tk.Execute("Hotel_Book", json.RawMessage(`{"room_type": "deluxe", "guest": "Ana", "nights": 3, "floor": 2}`))
+	if err != nil || result != "booked" {
+		t.Fatalf("expected a valid booking, got %v, %v", result, err)
+	}
+}
+
+// --- Doc comment descriptions ---
+
+func TestRegisterFuncUsesDocs(t *testing.T) {
+	RegisterDocs(map[string]string{"toolkit.addFunc": "Adds two numbers."})
+
+	tk := NewToolkit()
+	tk.Name = "DocTool"
+	tk.RegisterFunc(&tk, addFunc, addParams{})
+
+	if got := tk.GetDescriptionOfMethod("DocTool_addFunc"); got != "Adds two numbers." {
+		t.Fatalf("expected the registered doc, got %q", got)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for a function without description")
+		}
+	}()
+	tk.Register("Fail", "", &tk, failFunc, failParams{})
+}
+
+// --- Rate Limiting ---
+
+func TestRateLimitWaitsForToken(t *testing.T) {
+	tk := NewToolkit()
+	tk.Name = "TestTool"
+	tk.RegisterWithRateLimit("Add", "Adds two numbers", &tk, addFunc, addParams{}, 20, 1)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := tk.Execute("TestTool_Add", makeInput(addParams{A: 1, B: 2})); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	// The first call uses the burst, the next two wait 50ms each
+	if elapsed := time.Since(start); elapsed < 90*time.Millisecond {
+		t.Errorf("expected calls to be spaced by the rate limit, took %s", elapsed)
+	}
+}
+
+func TestRateLimitErrorWhenWaitTooLong(t *testing.T) {
+	limiter := NewRateLimiter(1, 2, 0)
+	tk := NewToolkit()
+	tk.Name = "TestTool"
+	tk.RegisterWithOptions("Add", "Adds two numbers", &tk, addFunc, addParams{}, WithRateLimiter(limiter))
+	tk.RegisterWithOptions("Fail", "Always fails", &tk, failFunc, failParams{}, WithRateLimiter(limiter))
+
+	if _, err := tk.Execute("TestTool_Add", makeInput(addParams{A: 1, B: 2})); err != nil {
+		t.Fatalf("first call: %v", err)
+	}
+	if _, err := tk.Execute("TestTool_Fail", makeInput(failParams{Msg: "x"})); err == nil || err.Error() != "x" {
+		t.Fatalf("second call should reach the method, got %v", err)
+	}
+
+	// Both methods share the limiter, so the burst is used up
+	_, err := tk.Execute("TestTool_Add", makeInput(addParams{A: 1, B: 2}))
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("expected a RateLimitError, got %v", err)
+	}
+	if rateErr.Method != "TestTool_Add" || rateErr.RetryAfter <= 0 || rateErr.RetryAfter > time.Second {
+		t.Errorf("unexpected rate limit error: %+v", rateErr)
+	}
+}
+
+func TestRateLimitSkipsCachedResults(t *testing.T) {
+	var calls int32
+	tk := NewToolkit()
+	tk.Name = "TestTool"
+	tk.Cache = CacheConfig{Enabled: true, TTL: time.Minute}
+	tk.RegisterWithOptions("Add", "Adds two numbers", &tk, func(p addParams) (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return p.A + p.B, nil
+	}, addParams{}, WithRateLimiter(NewRateLimiter(1, 1, 0)))
+
+	for i := 0; i < 3; i++ {
+		if _, err := tk.Execute("TestTool_Add", makeInput(addParams{A: 1, B: 2})); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 call, got %d", calls)
+	}
+}
@@ -0,0 +1,120 @@
+package toolkit
+
+import (
+	"fmt"
+	"strconv"
+	"unicode/utf8"
+)
+
+// CheckConstraints checks the arguments against the enum, minimum, maximum,
+// minLength and maxLength of their properties in a method's JSON schema,
+// including nested objects and array items. Arguments the schema doesn't
+// describe, and constraints it doesn't set, are not checked; types and
+// required arguments are left to the caller.
+func CheckConstraints(schema map[string]interface{}, args map[string]interface{}) error {
+	properties, _ := schema["properties"].(map[string]interface{})
+	for name, value := range args {
+		prop, ok := properties[name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if err := checkValue(name, prop, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkValue checks one value against its property schema
+func checkValue(path string, prop map[string]interface{}, value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	if enum := schemaEnum(prop["enum"]); len(enum) > 0 {
+		found := false
+		for _, allowed := range enum {
+			if fmt.Sprint(allowed) == fmt.Sprint(value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("argument '%s' must be one of %v, got %v", path, enum, value)
+		}
+	}
+
+	if number, ok := schemaNumber(value); ok {
+		if minimum, ok := schemaNumber(prop["minimum"]); ok && number < minimum {
+			return fmt.Errorf("argument '%s' must be at least %v, got %v", path, minimum, value)
+		}
+		if maximum, ok := schemaNumber(prop["maximum"]); ok && number > maximum {
+			return fmt.Errorf("argument '%s' must be at most %v, got %v", path, maximum, value)
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(v)
+		if minLength, ok := schemaNumber(prop["minLength"]); ok && float64(length) < minLength {
+			return fmt.Errorf("argument '%s' must have at least %v characters, got %d", path, minLength, length)
+		}
+		if maxLength, ok := schemaNumber(prop["maxLength"]); ok && float64(length) > maxLength {
+			return fmt.Errorf("argument '%s' must have at most %v characters, got %d", path, maxLength, length)
+		}
+	case map[string]interface{}:
+		properties, _ := prop["properties"].(map[string]interface{})
+		for name, fieldValue := range v {
+			if fieldProp, ok := properties[name].(map[string]interface{}); ok {
+				if err := checkValue(path+"."+name, fieldProp, fieldValue); err != nil {
+					return err
+				}
+			}
+		}
+	case []interface{}:
+		if items, ok := prop["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				if err := checkValue(fmt.Sprintf("%s[%d]", path, i), items, item); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// schemaEnum returns the values of an enum given as []string or []interface{}
+func schemaEnum(enum interface{}) []interface{} {
+	switch values := enum.(type) {
+	case []interface{}:
+		return values
+	case []string:
+		result := make([]interface{}, len(values))
+		for i, v := range values {
+			result[i] = v
+		}
+		return result
+	}
+	return nil
+}
+
+// schemaNumber returns a numeric value as float64. Numeric strings count, as
+// models often send numbers quoted.
+func schemaNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,179 @@
+package tools
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+
+	"github.com/devalexandre/agno-golang/agno/document/htmltext"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+// WebFetchToolConfig holds configuration for fetching pages
+type WebFetchToolConfig struct {
+	// AllowedHosts lists the hosts pages may be fetched from, with the same
+	// syntax as HTTPToolConfig.AllowedHosts. Use "*" to allow any host; an
+	// empty list blocks every request.
+	AllowedHosts []string
+	// AllowedSchemes lists the URL schemes the tool may use (default: https)
+	AllowedSchemes []string
+	// MaxContentChars caps the text returned to the model (default: 20000)
+	MaxContentChars int
+	// MaxPageBytes caps how much of a page is downloaded (default: 5MB)
+	MaxPageBytes int64
+	// Timeout bounds each fetch, including redirects (default: 30s)
+	Timeout time.Duration
+	// UserAgent is sent with every request (default: "Agno-Framework/1.0 (WebFetchTool)")
+	UserAgent string
+}
+
+// WebFetchTool downloads pages and returns their readable main text
+type WebFetchTool struct {
+	toolkit.Toolkit
+	client          *http.Client
+	allowlist       urlAllowlist
+	maxContentChars int
+	maxPageBytes    int64
+	userAgent       string
+}
+
+// WebFetchResult is the text extracted from a page
+type WebFetchResult struct {
+	URL         string `json:"url"`
+	Title       string `json:"title,omitempty"`
+	ContentType string `json:"content_type"`
+	Content     string `json:"content"`
+	Truncated   bool   `json:"truncated,omitempty"`
+	Note        string `json:"note,omitempty"`
+}
+
+// WebFetchParams represents parameters for fetching a page
+type WebFetchParams struct {
+	URL string `json:"url" description:"The URL of the page to read" required:"true"`
+}
+
+// NewWebFetchTool initializes the tool and registers the methods
+func NewWebFetchTool(config WebFetchToolConfig) toolkit.Tool {
+	maxChars := config.MaxContentChars
+	if maxChars <= 0 {
+		maxChars = 20000
+	}
+
+	maxBytes := config.MaxPageBytes
+	if maxBytes <= 0 {
+		maxBytes = 5 << 20
+	}
+
+	timeout := config.Timeout
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+
+	userAgent := config.UserAgent
+	if userAgent == "" {
+		userAgent = "Agno-Framework/1.0 (WebFetchTool)"
+	}
+
+	fetchTool := &WebFetchTool{
+		allowlist:       newURLAllowlist(config.AllowedHosts, config.AllowedSchemes),
+		maxContentChars: maxChars,
+		maxPageBytes:    maxBytes,
+		userAgent:       userAgent,
+	}
+	fetchTool.client = fetchTool.allowlist.client(timeout)
+
+	fetchTool.Toolkit = toolkit.NewToolkit()
+	fetchTool.Toolkit.Name = "WebFetchTool"
+	fetchTool.Toolkit.Description = "Read web pages. Downloads a page and returns its title and main text, without scripts, menus or footers. Use it to read the links found by a search tool."
+
+	fetchTool.Toolkit.Register("Fetch", "Download a web page and return its readable main text", fetchTool, fetchTool.Fetch, WebFetchParams{})
+
+	return fetchTool
+}
+
+// Fetch downloads a page and extracts its main text
+func (t *WebFetchTool) Fetch(params WebFetchParams) (interface{}, error) {
+	if params.URL == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+
+	target, err := url.Parse(params.URL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	if err := t.allowlist.check(target); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodGet, target.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("User-Agent", t.userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,text/plain;q=0.9")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to fetch page: %s", resp.Status)
+	}
+
+	contentType := "text/html"
+	if header := resp.Header.Get("Content-Type"); header != "" {
+		if mediaType, _, err := mime.ParseMediaType(header); err == nil {
+			contentType = mediaType
+		}
+	}
+	if contentType != "text/html" && contentType != "application/xhtml+xml" && contentType != "text/plain" {
+		return nil, fmt.Errorf("unsupported content type %q: only HTML and plain text pages can be read", contentType)
+	}
+
+	// Read one byte past the cap to know whether the page was cut
+	body, err := io.ReadAll(io.LimitReader(resp.Body, t.maxPageBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read page: %w", err)
+	}
+	pageCut := int64(len(body)) > t.maxPageBytes
+	if pageCut {
+		body = body[:t.maxPageBytes]
+	}
+
+	result := WebFetchResult{
+		URL:         resp.Request.URL.String(),
+		ContentType: contentType,
+	}
+	if contentType == "text/plain" {
+		result.Content = strings.TrimSpace(string(body))
+	} else {
+		root, err := html.Parse(bytes.NewReader(body))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse HTML: %w", err)
+		}
+		result.Title, result.Content = htmltext.Extract(root)
+	}
+	if result.Content == "" {
+		return nil, fmt.Errorf("no readable text found at %s", result.URL)
+	}
+
+	if content := []rune(result.Content); len(content) > t.maxContentChars {
+		result.Content = string(content[:t.maxContentChars])
+		result.Truncated = true
+		result.Note = fmt.Sprintf("content truncated to the first %d characters", t.maxContentChars)
+	} else if pageCut {
+		result.Truncated = true
+		result.Note = fmt.Sprintf("page larger than %d bytes, only the beginning was read", t.maxPageBytes)
+	}
+
+	return result, nil
+}
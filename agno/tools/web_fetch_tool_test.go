@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestWebFetchTool(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/article":
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			fmt.Fprintf(w, `<html><head><title>Go Release</title><script>var x = 1;</script></head>
+<body><nav>Home | Blog</nav><main><h1>Go 1.30</h1><p>Released today by %s.</p></main><footer>Copyright</footer></body></html>`,
+				r.Header.Get("User-Agent"))
+		case "/long":
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, strings.Repeat("a", 100))
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+			fmt.Fprint(w, "\x89PNG")
+		case "/missing":
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	tool := NewWebFetchTool(WebFetchToolConfig{
+		AllowedHosts:    []string{"127.0.0.1"},
+		AllowedSchemes:  []string{"http"},
+		MaxContentChars: 70,
+	})
+
+	out, err := tool.Execute("WebFetchTool_Fetch", json.RawMessage(fmt.Sprintf(`{"url": %q}`, server.URL+"/article")))
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	result := out.(WebFetchResult)
+	if result.Title != "Go Release" || result.Content != "Go 1.30\n\nReleased today by Agno-Framework/1.0 (WebFetchTool)." || result.Truncated {
+		t.Errorf("unexpected result: %+v", result)
+	}
+	if strings.Contains(result.Content, "Home") || strings.Contains(result.Content, "var x") {
+		t.Errorf("navigation or scripts leaked into the content: %q", result.Content)
+	}
+
+	out, err = tool.Execute("WebFetchTool_Fetch", json.RawMessage(fmt.Sprintf(`{"url": %q}`, server.URL+"/long")))
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if result := out.(WebFetchResult); len(result.Content) != 70 || !result.Truncated || result.Note == "" {
+		t.Errorf("expected truncated plain text, got %+v", result)
+	}
+
+	for _, target := range []string{
+		server.URL + "/image",
+		server.URL + "/missing",
+		"http://example.com/",
+		strings.Replace(server.URL, "http://", "https://", 1) + "/article",
+	} {
+		if _, err := tool.Execute("WebFetchTool_Fetch", json.RawMessage(fmt.Sprintf(`{"url": %q}`, target))); err == nil {
+			t.Errorf("expected an error for %s", target)
+		}
+	}
+}
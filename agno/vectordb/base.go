@@ -2,7 +2,9 @@ package vectordb
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/devalexandre/agno-golang/agno/document"
 	"github.com/devalexandre/agno-golang/agno/embedder"
@@ -62,6 +64,127 @@ type VectorDB interface {
 	IDExists(ctx context.Context, id string) (bool, error)
 }
 
+// DocumentLister is implemented by vector databases that can enumerate stored documents.
+// It is kept separate from VectorDB so existing backends remain compatible.
+type DocumentLister interface {
+	// ListDocuments returns stored documents matching filters, without embeddings
+	ListDocuments(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*document.Document, error)
+	// GetDocument returns a single stored document by ID, or nil if it does not exist
+	GetDocument(ctx context.Context, id string) (*document.Document, error)
+}
+
+// DocumentBatchGetter is implemented by vector databases that can fetch many stored
+// documents by ID in one request.
+type DocumentBatchGetter interface {
+	// GetDocuments returns the stored documents among ids, without embeddings.
+	// IDs that do not exist are left out.
+	GetDocuments(ctx context.Context, ids []string) ([]*document.Document, error)
+}
+
+// DocumentScroller is implemented by vector databases that can page through every
+// stored document together with its embeddings, e.g. to copy a collection.
+type DocumentScroller interface {
+	// ScrollDocuments returns up to limit documents, with embeddings, starting at
+	// cursor ("" for the first page), and the cursor of the next page ("" when done)
+	ScrollDocuments(ctx context.Context, cursor string, limit int) ([]*document.Document, string, error)
+}
+
+// DocumentDeleter is implemented by vector databases that can remove individual documents.
+type DocumentDeleter interface {
+	// DeleteByIDs removes the documents with the given IDs and returns how many were deleted
+	DeleteByIDs(ctx context.Context, ids []string) (int, error)
+}
+
+// FilterDeleter is implemented by vector databases that can remove the documents matching metadata filters.
+type FilterDeleter interface {
+	// DeleteByFilter removes the documents whose metadata matches every filter.
+	// Empty filters are rejected so a mistake cannot clear the collection.
+	DeleteByFilter(ctx context.Context, filters map[string]interface{}) error
+}
+
+// VectorSearcher is implemented by vector databases that can search with a query
+// embedding computed by the caller, e.g. by a knowledge base with its own embedder.
+type VectorSearcher interface {
+	// SearchByVector returns the documents closest to embedding
+	SearchByVector(ctx context.Context, embedding []float64, limit int, filters map[string]interface{}) ([]*SearchResult, error)
+}
+
+// EmbedderInfo records the embedder used to index a collection
+type EmbedderInfo struct {
+	Model      string `json:"model,omitempty"`
+	Dimensions int    `json:"dimensions,omitempty"`
+}
+
+// NewEmbedderInfo describes emb as EmbedderInfo
+func NewEmbedderInfo(emb embedder.Embedder) EmbedderInfo {
+	return EmbedderInfo{Model: emb.GetID(), Dimensions: emb.GetDimensions()}
+}
+
+// EmbedderInfoStore is implemented by vector databases that can record the embedder
+// of a collection in its metadata.
+type EmbedderInfoStore interface {
+	// GetEmbedderInfo returns the recorded embedder, or nil if none was recorded
+	GetEmbedderInfo(ctx context.Context) (*EmbedderInfo, error)
+	// SetEmbedderInfo records the embedder used to index the collection
+	SetEmbedderInfo(ctx context.Context, info EmbedderInfo) error
+}
+
+// ErrEmbedderMismatch is returned when a query embedder differs from the embedder
+// that indexed the collection. Mixing embedders produces meaningless scores.
+var ErrEmbedderMismatch = errors.New("embedder does not match the collection embedder")
+
+// ValidateEmbedder checks emb against the embedder recorded for a collection.
+// Fields that were not recorded are not compared.
+func ValidateEmbedder(indexed *EmbedderInfo, emb embedder.Embedder) error {
+	if indexed == nil || emb == nil {
+		return nil
+	}
+	current := NewEmbedderInfo(emb)
+	if indexed.Dimensions > 0 && current.Dimensions > 0 && indexed.Dimensions != current.Dimensions {
+		return fmt.Errorf("%w: collection has %d dimensions, embedder %q has %d", ErrEmbedderMismatch, indexed.Dimensions, current.Model, current.Dimensions)
+	}
+	if indexed.Model != "" && current.Model != "" && indexed.Model != current.Model {
+		return fmt.Errorf("%w: collection was indexed with %q, query uses %q", ErrEmbedderMismatch, indexed.Model, current.Model)
+	}
+	return nil
+}
+
+// DistanceSearcher is implemented by vector databases that can run a one-off
+// vector search with a distance other than the configured one.
+type DistanceSearcher interface {
+	// IndexDistance returns the distance the collection index was built with,
+	// or "" if the collection has no vector index
+	IndexDistance(ctx context.Context) (Distance, error)
+	// SearchWithDistance performs a vector search using distance. It returns
+	// ErrDistanceMismatch when the collection index was built for another distance.
+	SearchWithDistance(ctx context.Context, query string, distance Distance, limit int, filters map[string]interface{}) ([]*SearchResult, error)
+}
+
+// ErrDistanceMismatch is returned when a search asks for a distance other than
+// the one the collection index was built with
+var ErrDistanceMismatch = errors.New("distance does not match the collection index")
+
+// NormalizeDistance maps the aliases of a distance to a single name:
+// euclidean to l2, and dot and ip to max_inner_product
+func NormalizeDistance(distance Distance) Distance {
+	switch distance {
+	case DistanceEuclidean:
+		return DistanceL2
+	case DistanceDot, DistanceIP:
+		return DistanceMaxInnerProduct
+	}
+	return distance
+}
+
+// ValidateDistance checks that distance matches the distance the collection
+// index was built with. An unknown index distance ("") is not compared.
+func ValidateDistance(indexed, distance Distance) error {
+	if indexed == "" || NormalizeDistance(indexed) == NormalizeDistance(distance) {
+		return nil
+	}
+	return fmt.Errorf("%w: collection index uses %q, search asked for %q", ErrDistanceMismatch, indexed, distance)
+}
+
 // BaseVectorDB provides common functionality for VectorDB implementations
 type BaseVectorDB struct {
 	Embedder   embedder.Embedder `json:"embedder"`
@@ -90,6 +213,12 @@ func (b *BaseVectorDB) GetEmbedder() embedder.Embedder {
 	return b.Embedder
 }
 
+// SetSearchType changes the search type used by Search, e.g. to compare vector
+// and hybrid results on the same collection without creating a new instance
+func (b *BaseVectorDB) SetSearchType(searchType SearchType) {
+	b.SearchType = searchType
+}
+
 // EmbedDocuments generates embeddings for a list of documents
 func (b *BaseVectorDB) EmbedDocuments(docs []*document.Document) error {
 	if b.Embedder == nil {
@@ -122,6 +251,25 @@ func (b *BaseVectorDB) EmbedQuery(query string) ([]float64, error) {
 	return b.Embedder.GetEmbedding(query)
 }
 
+var tenantPattern = regexp.MustCompile(`^[a-z0-9]+$`)
+
+// TenantCollectionName returns the physical collection/table name for a tenant.
+// Each tenant gets its own prefixed collection, so filters can never reach another
+// tenant's data. An empty tenant returns name unchanged.
+// Tenants may not contain the "_" separator, so the tenant is everything before
+// the first "_" and two tenants never share a collection: "a_b" + "c" and
+// "a" + "b_c" would both be "a_b_c". Upper case is rejected too, since
+// Postgres folds unquoted names and "Acme" would share "acme"'s table.
+func TenantCollectionName(tenant, name string) (string, error) {
+	if tenant == "" {
+		return name, nil
+	}
+	if !tenantPattern.MatchString(tenant) {
+		return "", fmt.Errorf("invalid tenant %q: only lowercase letters and digits are allowed", tenant)
+	}
+	return fmt.Sprintf("%s_%s", tenant, name), nil
+}
+
 // CalculateCosineSimilarity calculates cosine similarity between two vectors
 func CalculateCosineSimilarity(a, b []float64) float64 {
 	if len(a) != len(b) {
@@ -0,0 +1,74 @@
+package vectordb
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidateDistance(t *testing.T) {
+	tests := []struct {
+		indexed, distance Distance
+		wantErr           bool
+	}{
+		{DistanceCosine, DistanceCosine, false},
+		{DistanceL2, DistanceEuclidean, false},
+		{DistanceMaxInnerProduct, DistanceDot, false},
+		{DistanceDot, DistanceIP, false},
+		{"", DistanceL2, false},
+		{DistanceCosine, DistanceL2, true},
+		{DistanceL2, DistanceDot, true},
+	}
+
+	for _, tt := range tests {
+		err := ValidateDistance(tt.indexed, tt.distance)
+		if tt.wantErr != errors.Is(err, ErrDistanceMismatch) {
+			t.Errorf("ValidateDistance(%q, %q) = %v, want mismatch %v", tt.indexed, tt.distance, err, tt.wantErr)
+		}
+	}
+}
+
+func TestTenantCollectionName(t *testing.T) {
+	tests := []struct {
+		tenant, name, want string
+		wantErr            bool
+	}{
+		{"", "docs", "docs", false},
+		{"acme", "docs", "acme_docs", false},
+		{"acme42", "my_docs", "acme42_my_docs", false},
+		{"Acme", "docs", "", true},
+		{"a_b", "c", "", true},
+		{"acme-corp", "docs", "", true},
+		{"acme; DROP TABLE docs", "docs", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := TenantCollectionName(tt.tenant, tt.name)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("TenantCollectionName(%q, %q) = %q, %v, want %q, error %v", tt.tenant, tt.name, got, err, tt.want, tt.wantErr)
+		}
+	}
+
+	// Different tenants never map to the same collection
+	names := map[string]string{}
+	for _, pair := range [][2]string{{"a", "b_c"}, {"ab", "c"}, {"a", "bc"}, {"b", "a_c"}} {
+		name, err := TenantCollectionName(pair[0], pair[1])
+		if err != nil {
+			t.Fatalf("TenantCollectionName(%q, %q): %v", pair[0], pair[1], err)
+		}
+		if tenant, ok := names[name]; ok && tenant != pair[0] {
+			t.Errorf("tenants %q and %q share the collection %q", tenant, pair[0], name)
+		}
+		names[name] = pair[0]
+	}
+
+	// Postgres folds unquoted table names, so tenants differing only by case
+	// would share one table; only the lower case one is accepted
+	if _, err := TenantCollectionName("acme", "docs"); err != nil {
+		t.Errorf("TenantCollectionName(%q): %v", "acme", err)
+	}
+	for _, tenant := range []string{"Acme", "ACME"} {
+		if name, err := TenantCollectionName(tenant, "docs"); err == nil {
+			t.Errorf("TenantCollectionName(%q) = %q, expected upper case to be rejected", tenant, name)
+		}
+	}
+}
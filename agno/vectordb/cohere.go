@@ -0,0 +1,116 @@
+package vectordb
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// CohereReranker reranks results with the Cohere Rerank API
+type CohereReranker struct {
+	APIKey     string
+	Model      string
+	BaseURL    string
+	HTTPClient *http.Client
+}
+
+// CohereRerankRequest request structure for Cohere Rerank
+type CohereRerankRequest struct {
+	Model     string   `json:"model"`
+	Query     string   `json:"query"`
+	Documents []string `json:"documents"`
+}
+
+// CohereRerankResponse Cohere Rerank response structure
+type CohereRerankResponse struct {
+	Results []struct {
+		Index          int     `json:"index"`
+		RelevanceScore float64 `json:"relevance_score"`
+	} `json:"results"`
+}
+
+// NewCohereReranker creates a Cohere reranker. An empty apiKey falls back to
+// COHERE_API_KEY and an empty model to rerank-v3.5.
+func NewCohereReranker(apiKey, model string) *CohereReranker {
+	if apiKey == "" {
+		apiKey = os.Getenv("COHERE_API_KEY")
+	}
+	if model == "" {
+		model = "rerank-v3.5"
+	}
+	return &CohereReranker{
+		APIKey:     apiKey,
+		Model:      model,
+		BaseURL:    "https://api.cohere.com/v2",
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// Rerank sends the result contents to Cohere and returns the results ordered
+// by relevance, with Score set to the relevance score
+func (c *CohereReranker) Rerank(ctx context.Context, query string, results []*SearchResult) ([]*SearchResult, error) {
+	if len(results) == 0 {
+		return results, nil
+	}
+	if c.APIKey == "" {
+		return nil, fmt.Errorf("cohere API key is required")
+	}
+
+	request := CohereRerankRequest{
+		Model:     c.Model,
+		Query:     query,
+		Documents: make([]string, len(results)),
+	}
+	for i, result := range results {
+		request.Documents[i] = resultContent(result)
+	}
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/rerank", bytes.NewBuffer(requestBody))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.APIKey)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response CohereRerankResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+
+	// Cohere returns the results sorted by relevance
+	reranked := make([]*SearchResult, 0, len(response.Results))
+	for _, r := range response.Results {
+		if r.Index < 0 || r.Index >= len(results) {
+			return nil, fmt.Errorf("rerank result index %d out of range", r.Index)
+		}
+		result := *results[r.Index]
+		result.Score = r.RelevanceScore
+		reranked = append(reranked, &result)
+	}
+	return reranked, nil
+}
@@ -0,0 +1,64 @@
+package vectordb
+
+import "context"
+
+// AdvancedFilter combines metadata conditions: every Must condition has to
+// match, at least one Should condition has to match (when there are any),
+// and no MustNot condition may match
+type AdvancedFilter struct {
+	Must    []FilterCondition
+	Should  []FilterCondition
+	MustNot []FilterCondition
+}
+
+// FilterCondition represents a single filter condition on a metadata field
+type FilterCondition struct {
+	Field    string
+	Operator FilterOperator
+	Value    interface{}
+}
+
+// FilterOperator represents filter operators
+type FilterOperator string
+
+const (
+	FilterOpEqual              FilterOperator = "eq"
+	FilterOpNotEqual           FilterOperator = "ne"
+	FilterOpGreaterThan        FilterOperator = "gt"
+	FilterOpGreaterThanOrEqual FilterOperator = "gte"
+	FilterOpLessThan           FilterOperator = "lt"
+	FilterOpLessThanOrEqual    FilterOperator = "lte"
+	FilterOpIn                 FilterOperator = "in"  // Value is a list
+	FilterOpNotIn              FilterOperator = "nin" // Value is a list
+	FilterOpContains           FilterOperator = "contains"
+	FilterOpRange              FilterOperator = "range" // Value is a map with "gt", "gte", "lt" and/or "lte" keys
+)
+
+// AdvancedSearcher is implemented by vector databases that support AdvancedFilter
+type AdvancedSearcher interface {
+	// SearchWithAdvancedFilters performs a vector search restricted by filter
+	SearchWithAdvancedFilters(ctx context.Context, query string, limit int, filter *AdvancedFilter) ([]*SearchResult, error)
+}
+
+// FilterNumber converts a numeric filter value to float64
+func FilterNumber(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
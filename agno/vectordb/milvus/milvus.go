@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/devalexandre/agno-golang/agno/document"
 	"github.com/devalexandre/agno-golang/agno/embedder"
@@ -12,6 +15,12 @@ import (
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 )
 
+// Document fields stored in the metadata JSON field next to the document
+// metadata, so filters can match them like any other metadata key
+var documentFields = []string{"name", "content_type", "source", "chunk_index", "chunk_total", "parent_id"}
+
+// Milvus implements VectorDB on a Milvus collection with the fields id,
+// vector, content and metadata (JSON)
 type Milvus struct {
 	*vectordb.BaseVectorDB
 	client         client.Client
@@ -19,20 +28,63 @@ type Milvus struct {
 	dbName         string
 }
 
+// MilvusConfig holds configuration for Milvus
 type MilvusConfig struct {
-	Address        string
-	Username       string
-	Password       string
+	Host       string // Default "localhost"
+	Port       int    // Default 19530
+	Collection string // Default "documents"
+	Embedder   embedder.Embedder
+	SearchType vectordb.SearchType // Only vector search is supported
+	Distance   vectordb.Distance   // Default DistanceL2
+
+	Address  string // "host:port", overrides Host and Port
+	Username string
+	Password string
+	DBName   string
+
+	// Deprecated: use Collection
 	CollectionName string
-	DBName         string
-	Embedder       embedder.Embedder
-	Distance       vectordb.Distance
 }
 
+// NewMilvus creates a new Milvus instance
 func NewMilvus(config MilvusConfig) (*Milvus, error) {
+	address := config.Address
+	if address == "" {
+		host := config.Host
+		if host == "" {
+			host = "localhost"
+		}
+		port := config.Port
+		if port == 0 {
+			port = 19530
+		}
+		address = fmt.Sprintf("%s:%d", host, port)
+	}
+
+	collection := config.Collection
+	if collection == "" {
+		collection = config.CollectionName
+	}
+	if collection == "" {
+		collection = "documents"
+	}
+
+	searchType := config.SearchType
+	if searchType == "" {
+		searchType = vectordb.SearchTypeVector
+	}
+
+	distance := config.Distance
+	if distance == "" {
+		distance = vectordb.DistanceL2
+	}
+	if _, err := metricType(distance); err != nil {
+		return nil, err
+	}
+
 	ctx := context.Background()
 	c, err := client.NewClient(ctx, client.Config{
-		Address:  config.Address,
+		Address:  address,
 		Username: config.Username,
 		Password: config.Password,
 		DBName:   config.DBName,
@@ -41,19 +93,38 @@ func NewMilvus(config MilvusConfig) (*Milvus, error) {
 		return nil, fmt.Errorf("failed to connect to milvus: %w", err)
 	}
 
-	if config.Distance == "" {
-		config.Distance = vectordb.DistanceL2
-	}
-
 	return &Milvus{
-		BaseVectorDB:   vectordb.NewBaseVectorDB(config.Embedder, vectordb.SearchTypeVector, config.Distance),
+		BaseVectorDB:   vectordb.NewBaseVectorDB(config.Embedder, searchType, distance),
 		client:         c,
-		collectionName: config.CollectionName,
+		collectionName: collection,
 		dbName:         config.DBName,
 	}, nil
 }
 
+// metricType maps a distance to the Milvus metric type
+func metricType(distance vectordb.Distance) (entity.MetricType, error) {
+	switch vectordb.NormalizeDistance(distance) {
+	case vectordb.DistanceL2:
+		return entity.L2, nil
+	case vectordb.DistanceCosine:
+		return entity.COSINE, nil
+	case vectordb.DistanceMaxInnerProduct:
+		return entity.IP, nil
+	default:
+		return "", fmt.Errorf("distance %q is not supported by milvus", distance)
+	}
+}
+
+// Create creates the collection with an IVF_FLAT index and loads it
 func (m *Milvus) Create(ctx context.Context) error {
+	exists, err := m.Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check collection existence: %w", err)
+	}
+	if exists {
+		return nil // Collection already exists
+	}
+
 	schema := &entity.Schema{
 		CollectionName: m.collectionName,
 		AutoID:         false,
@@ -63,7 +134,7 @@ func (m *Milvus) Create(ctx context.Context) error {
 				DataType:   entity.FieldTypeVarChar,
 				PrimaryKey: true,
 				AutoID:     false,
-				TypeParams: map[string]string{"max_length": "36"},
+				TypeParams: map[string]string{"max_length": "512"},
 			},
 			{
 				Name:     "vector",
@@ -86,24 +157,17 @@ func (m *Milvus) Create(ctx context.Context) error {
 		},
 	}
 
-	var metricType entity.MetricType
-	switch m.Distance {
-	case vectordb.DistanceL2:
-		metricType = entity.L2
-	case vectordb.DistanceCosine:
-		metricType = entity.COSINE
-	case vectordb.DistanceIP:
-		metricType = entity.IP
-	default:
-		metricType = entity.L2
+	metric, err := metricType(m.Distance)
+	if err != nil {
+		return err
 	}
 
-	err := m.client.CreateCollection(ctx, schema, 1)
+	err = m.client.CreateCollection(ctx, schema, 1)
 	if err != nil {
 		return fmt.Errorf("failed to create collection: %w", err)
 	}
 
-	idx, err := entity.NewIndexIvfFlat(metricType, 1024)
+	idx, err := entity.NewIndexIvfFlat(metric, 1024)
 	if err != nil {
 		return fmt.Errorf("failed to create index description: %w", err)
 	}
@@ -116,125 +180,155 @@ func (m *Milvus) Create(ctx context.Context) error {
 	return m.client.LoadCollection(ctx, m.collectionName, false)
 }
 
+// Exists checks if the collection exists
 func (m *Milvus) Exists(ctx context.Context) (bool, error) {
 	return m.client.HasCollection(ctx, m.collectionName)
 }
 
+// Drop drops the collection
 func (m *Milvus) Drop(ctx context.Context) error {
+	exists, err := m.Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check collection existence: %w", err)
+	}
+	if !exists {
+		return nil // Collection doesn't exist
+	}
 	return m.client.DropCollection(ctx, m.collectionName)
 }
 
+// Optimize flushes inserted data to sealed segments
 func (m *Milvus) Optimize(ctx context.Context) error {
 	return m.client.Flush(ctx, m.collectionName, false)
 }
 
+// Insert adds documents, creating the collection if needed. Filters are
+// stored as metadata of every document.
 func (m *Milvus) Insert(ctx context.Context, documents []*document.Document, filters map[string]interface{}) error {
-	if err := m.EmbedDocuments(documents); err != nil {
+	columns, err := m.prepareColumns(ctx, documents, filters)
+	if err != nil || columns == nil {
 		return err
 	}
 
-	ids := make([]string, 0, len(documents))
-	vectors := make([][]float32, 0, len(documents))
-	contents := make([]string, 0, len(documents))
-	metadatas := make([]string, 0, len(documents))
-
-	for _, doc := range documents {
-		ids = append(ids, doc.ID)
-		v32 := make([]float32, len(doc.Embeddings))
-		for i, v := range doc.Embeddings {
-			v32[i] = float32(v)
-		}
-		vectors = append(vectors, v32)
-		contents = append(contents, doc.Content)
-		metaJSON, _ := json.Marshal(doc.Metadata)
-		metadatas = append(metadatas, string(metaJSON))
+	if _, err := m.client.Insert(ctx, m.collectionName, "", columns...); err != nil {
+		return fmt.Errorf("failed to insert documents: %w", err)
 	}
+	return nil
+}
 
-	idCol := entity.NewColumnVarChar("id", ids)
-	vectorCol := entity.NewColumnFloatVector("vector", m.Dimensions, vectors)
-	contentCol := entity.NewColumnVarChar("content", contents)
-	metadataCol := entity.NewColumnVarChar("metadata", metadatas)
+// Upsert inserts or replaces documents by ID, creating the collection if needed
+func (m *Milvus) Upsert(ctx context.Context, documents []*document.Document, filters map[string]interface{}) error {
+	columns, err := m.prepareColumns(ctx, documents, filters)
+	if err != nil || columns == nil {
+		return err
+	}
 
-	_, err := m.client.Insert(ctx, m.collectionName, "", idCol, vectorCol, contentCol, metadataCol)
-	return err
+	if _, err := m.client.Upsert(ctx, m.collectionName, "", columns...); err != nil {
+		return fmt.Errorf("failed to upsert documents: %w", err)
+	}
+	return nil
 }
 
-func (m *Milvus) Upsert(ctx context.Context, documents []*document.Document, filters map[string]interface{}) error {
-	// Milvus upsert requires a primary key, but since we handle IDs manually, we can use it.
+// prepareColumns embeds documents and converts them to collection columns.
+// It returns nil columns when there is nothing to write.
+func (m *Milvus) prepareColumns(ctx context.Context, documents []*document.Document, filters map[string]interface{}) ([]entity.Column, error) {
+	if len(documents) == 0 {
+		return nil, nil
+	}
+
+	if err := m.Create(ctx); err != nil {
+		return nil, fmt.Errorf("failed to create collection: %w", err)
+	}
+
 	if err := m.EmbedDocuments(documents); err != nil {
-		return err
+		return nil, fmt.Errorf("failed to embed documents: %w", err)
 	}
 
 	ids := make([]string, 0, len(documents))
 	vectors := make([][]float32, 0, len(documents))
 	contents := make([]string, 0, len(documents))
-	metadatas := make([]string, 0, len(documents))
+	metadatas := make([][]byte, 0, len(documents))
 
 	for _, doc := range documents {
-		ids = append(ids, doc.ID)
-		v32 := make([]float32, len(doc.Embeddings))
-		for i, v := range doc.Embeddings {
-			v32[i] = float32(v)
+		metaJSON, err := json.Marshal(documentMetadata(doc, filters))
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal metadata of document %s: %w", doc.ID, err)
 		}
-		vectors = append(vectors, v32)
+
+		ids = append(ids, doc.ID)
+		vectors = append(vectors, toFloat32(doc.Embeddings))
 		contents = append(contents, doc.Content)
-		metaJSON, _ := json.Marshal(doc.Metadata)
-		metadatas = append(metadatas, string(metaJSON))
+		metadatas = append(metadatas, metaJSON)
 	}
 
-	idCol := entity.NewColumnVarChar("id", ids)
-	vectorCol := entity.NewColumnFloatVector("vector", m.Dimensions, vectors)
-	contentCol := entity.NewColumnVarChar("content", contents)
-	metadataCol := entity.NewColumnVarChar("metadata", metadatas)
-
-	_, err := m.client.Upsert(ctx, m.collectionName, "", idCol, vectorCol, contentCol, metadataCol)
-	return err
+	return []entity.Column{
+		entity.NewColumnVarChar("id", ids),
+		entity.NewColumnFloatVector("vector", m.Dimensions, vectors),
+		entity.NewColumnVarChar("content", contents),
+		entity.NewColumnJSONBytes("metadata", metadatas),
+	}, nil
 }
 
+// Search performs search based on the configured search type
 func (m *Milvus) Search(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
-	return m.VectorSearch(ctx, query, limit, filters)
+	switch m.SearchType {
+	case vectordb.SearchTypeKeyword:
+		return m.KeywordSearch(ctx, query, limit, filters)
+	case vectordb.SearchTypeHybrid:
+		return m.HybridSearch(ctx, query, limit, filters)
+	default:
+		return m.VectorSearch(ctx, query, limit, filters)
+	}
 }
 
+// VectorSearch performs vector similarity search
 func (m *Milvus) VectorSearch(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
 	vector, err := m.EmbedQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+
+	return m.SearchByVector(ctx, vector, limit, filters)
+}
+
+// SearchByVector returns the documents closest to queryEmbedding
+func (m *Milvus) SearchByVector(ctx context.Context, queryEmbedding []float64, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	metric, err := metricType(m.Distance)
 	if err != nil {
 		return nil, err
 	}
 
-	v32 := make([]float32, len(vector))
-	for i, v := range vector {
-		v32[i] = float32(v)
+	expr, err := buildFilterExpr(filters)
+	if err != nil {
+		return nil, err
 	}
 
-	var metricType entity.MetricType
-	switch m.Distance {
-	case vectordb.DistanceL2:
-		metricType = entity.L2
-	case vectordb.DistanceCosine:
-		metricType = entity.COSINE
-	default:
-		metricType = entity.L2
+	searchParam, err := entity.NewIndexIvfFlatSearchParam(10)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create search params: %w", err)
 	}
 
-	searchParam, _ := entity.NewIndexIvfFlatSearchParam(10)
-	res, err := m.client.Search(ctx, m.collectionName, nil, "", []string{"content", "metadata"}, []entity.Vector{entity.FloatVector(v32)}, "vector", metricType, limit, searchParam)
+	res, err := m.client.Search(ctx, m.collectionName, nil, expr, []string{"content", "metadata"},
+		[]entity.Vector{entity.FloatVector(toFloat32(queryEmbedding))}, "vector", metric, limit, searchParam)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to search collection: %w", err)
 	}
 
 	results := make([]*vectordb.SearchResult, 0)
 	for _, sr := range res {
+		if sr.Err != nil {
+			return nil, fmt.Errorf("failed to search collection: %w", sr.Err)
+		}
 		for i := 0; i < sr.ResultCount; i++ {
-			id, _ := sr.IDs.GetAsString(i)
-			content, _ := sr.Fields.GetColumn("content").GetAsString(i)
-			// metadata handling would be more complex depending on how it's stored
-
+			doc, err := resultDocument(sr.IDs, sr.Fields, i)
+			if err != nil {
+				return nil, err
+			}
+			score, distance := scoreAndDistance(metric, sr.Scores[i])
 			results = append(results, &vectordb.SearchResult{
-				Document: &document.Document{
-					ID:      id,
-					Content: content,
-				},
-				Score: float64(sr.Scores[i]),
+				Document: doc,
+				Score:    score,
+				Distance: distance,
 			})
 		}
 	}
@@ -242,36 +336,233 @@ func (m *Milvus) VectorSearch(ctx context.Context, query string, limit int, filt
 	return results, nil
 }
 
+// KeywordSearch is not supported by this backend
 func (m *Milvus) KeywordSearch(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
 	return nil, fmt.Errorf("keyword search not implemented for milvus")
 }
 
+// HybridSearch is not supported by this backend
 func (m *Milvus) HybridSearch(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
 	return nil, fmt.Errorf("hybrid search not implemented for milvus")
 }
 
+// GetCount returns the number of documents in the collection
 func (m *Milvus) GetCount(ctx context.Context) (int64, error) {
-	// Milvus doesn't have a direct "count" in describe, usually requires query or statistics
-	return 0, fmt.Errorf("get count not directly supported in milvus without query")
+	res, err := m.client.Query(ctx, m.collectionName, nil, "", []string{"count(*)"},
+		client.WithSearchQueryConsistencyLevel(entity.ClStrong))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+
+	column, ok := res.GetColumn("count(*)").(*entity.ColumnInt64)
+	if !ok || column.Len() == 0 {
+		return 0, fmt.Errorf("failed to count documents: unexpected count result")
+	}
+	return column.Data()[0], nil
 }
 
+// DocExists checks if a document exists by ID
 func (m *Milvus) DocExists(ctx context.Context, doc *document.Document) (bool, error) {
 	return m.IDExists(ctx, doc.ID)
 }
 
+// NameExists checks if a document with the given name exists
 func (m *Milvus) NameExists(ctx context.Context, name string) (bool, error) {
-	// In Milvus we'd query by a 'name' metadata field if it exists
-	return false, nil
+	expr, err := buildFilterExpr(map[string]interface{}{"name": name})
+	if err != nil {
+		return false, err
+	}
+	return m.exists(ctx, expr)
 }
 
+// IDExists checks if a document with the given ID exists
 func (m *Milvus) IDExists(ctx context.Context, id string) (bool, error) {
-	res, err := m.client.Query(ctx, m.collectionName, nil, fmt.Sprintf("id == '%s'", id), []string{"id"})
+	return m.exists(ctx, "id == "+quoteExprString(id))
+}
+
+func (m *Milvus) exists(ctx context.Context, expr string) (bool, error) {
+	res, err := m.client.Query(ctx, m.collectionName, nil, expr, []string{"id"},
+		client.WithLimit(1), client.WithSearchQueryConsistencyLevel(entity.ClStrong))
 	if err != nil {
-		return false, err
+		return false, fmt.Errorf("failed to query collection: %w", err)
 	}
-	return res.GetColumn("id").Len() > 0, nil
+	return res.Len() > 0, nil
 }
 
+// Close closes the connection to Milvus
 func (m *Milvus) Close() error {
 	return m.client.Close()
 }
+
+// buildFilterExpr translates metadata filters into a Milvus boolean
+// expression on the metadata JSON field. Slices match any of their values.
+func buildFilterExpr(filters map[string]interface{}) (string, error) {
+	keys := make([]string, 0, len(filters))
+	for key := range filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	conditions := make([]string, 0, len(keys))
+	for _, key := range keys {
+		field := "metadata[" + quoteExprString(key) + "]"
+		value := filters[key]
+
+		if values, ok := filterValues(value); ok {
+			literals := make([]string, 0, len(values))
+			for _, v := range values {
+				literal, err := exprLiteral(v)
+				if err != nil {
+					return "", fmt.Errorf("invalid filter %q: %w", key, err)
+				}
+				literals = append(literals, literal)
+			}
+			conditions = append(conditions, field+" in ["+strings.Join(literals, ", ")+"]")
+			continue
+		}
+
+		literal, err := exprLiteral(value)
+		if err != nil {
+			return "", fmt.Errorf("invalid filter %q: %w", key, err)
+		}
+		conditions = append(conditions, field+" == "+literal)
+	}
+
+	return strings.Join(conditions, " && "), nil
+}
+
+// filterValues returns the elements of a slice filter value
+func filterValues(value interface{}) ([]interface{}, bool) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, true
+	case []string:
+		values := make([]interface{}, len(v))
+		for i, s := range v {
+			values[i] = s
+		}
+		return values, true
+	case []int:
+		values := make([]interface{}, len(v))
+		for i, n := range v {
+			values[i] = n
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// exprStringEscaper escapes the only characters that are special inside a
+// Milvus expression string literal
+var exprStringEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// quoteExprString formats s as a Milvus expression string literal. Unlike
+// strconv.Quote it keeps non-ASCII and control characters as they are, since
+// Milvus does not decode Go escapes such as \u00e9.
+func quoteExprString(s string) string {
+	return `"` + exprStringEscaper.Replace(s) + `"`
+}
+
+// exprLiteral formats a filter value as a Milvus expression literal
+func exprLiteral(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return quoteExprString(v), nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v), nil
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported value type %T", value)
+	}
+}
+
+// documentMetadata returns the metadata stored for doc: its metadata, the
+// insert filters and its document fields
+func documentMetadata(doc *document.Document, filters map[string]interface{}) map[string]interface{} {
+	metadata := make(map[string]interface{}, len(doc.Metadata)+len(filters)+len(documentFields))
+	for k, v := range doc.Metadata {
+		metadata[k] = v
+	}
+	for k, v := range filters {
+		metadata[k] = v
+	}
+	metadata["name"] = doc.Name
+	metadata["content_type"] = doc.ContentType
+	metadata["source"] = doc.Source
+	metadata["chunk_index"] = doc.ChunkIndex
+	metadata["chunk_total"] = doc.ChunkTotal
+	metadata["parent_id"] = doc.ParentID
+	return metadata
+}
+
+// resultDocument rebuilds the document at row i of a search result
+func resultDocument(ids entity.Column, fields client.ResultSet, i int) (*document.Document, error) {
+	id, err := ids.GetAsString(i)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read document id: %w", err)
+	}
+	doc := &document.Document{ID: id}
+
+	if column := fields.GetColumn("content"); column != nil {
+		doc.Content, _ = column.GetAsString(i)
+	}
+
+	column, ok := fields.GetColumn("metadata").(*entity.ColumnJSONBytes)
+	if !ok {
+		return doc, nil
+	}
+	raw, err := column.ValueByIdx(i)
+	if err != nil || len(raw) == 0 {
+		return doc, nil
+	}
+
+	var metadata map[string]interface{}
+	if err := json.Unmarshal(raw, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal metadata of document %s: %w", id, err)
+	}
+
+	doc.Name, _ = metadata["name"].(string)
+	doc.ContentType, _ = metadata["content_type"].(string)
+	doc.Source, _ = metadata["source"].(string)
+	doc.ParentID, _ = metadata["parent_id"].(string)
+	if n, ok := metadata["chunk_index"].(float64); ok {
+		doc.ChunkIndex = int(n)
+	}
+	if n, ok := metadata["chunk_total"].(float64); ok {
+		doc.ChunkTotal = int(n)
+	}
+	for _, field := range documentFields {
+		delete(metadata, field)
+	}
+	doc.Metadata = metadata
+
+	return doc, nil
+}
+
+// scoreAndDistance converts a Milvus score, a distance for L2 and a
+// similarity otherwise, to a score where higher is better and a distance
+// where lower is better
+func scoreAndDistance(metric entity.MetricType, value float32) (float64, float64) {
+	v := float64(value)
+	switch metric {
+	case entity.L2:
+		return 1.0 / (1.0 + v), v
+	case entity.IP:
+		return v, -v
+	default:
+		return v, 1.0 - v
+	}
+}
+
+func toFloat32(vector []float64) []float32 {
+	v32 := make([]float32, len(vector))
+	for i, v := range vector {
+		v32[i] = float32(v)
+	}
+	return v32
+}
@@ -0,0 +1,65 @@
+package milvus
+
+import (
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+)
+
+func TestBuildFilterExpr(t *testing.T) {
+	expr, err := buildFilterExpr(map[string]interface{}{
+		"category": `say "hi"`,
+		"year":     2024,
+		"draft":    false,
+		"tags":     []string{"go", "ai"},
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	want := `metadata["category"] == "say \"hi\"" && metadata["draft"] == false && metadata["tags"] in ["go", "ai"] && metadata["year"] == 2024`
+	if expr != want {
+		t.Fatalf("Unexpected expression:\n got: %s\nwant: %s", expr, want)
+	}
+
+	if expr, _ := buildFilterExpr(nil); expr != "" {
+		t.Errorf("Expected an empty expression without filters, got: %s", expr)
+	}
+	if _, err := buildFilterExpr(map[string]interface{}{"nested": map[string]interface{}{}}); err == nil {
+		t.Errorf("Expected an error for an unsupported filter value")
+	}
+}
+
+func TestBuildFilterExprNonASCII(t *testing.T) {
+	expr, err := buildFilterExpr(map[string]interface{}{
+		"título": "São Paulo \\ 東京 🚀",
+		"path":   `C:\docs\"q"`,
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	want := `metadata["path"] == "C:\\docs\\\"q\"" && metadata["título"] == "São Paulo \\ 東京 🚀"`
+	if expr != want {
+		t.Fatalf("Unexpected expression:\n got: %s\nwant: %s", expr, want)
+	}
+}
+
+func TestMetricType(t *testing.T) {
+	tests := map[vectordb.Distance]entity.MetricType{
+		vectordb.DistanceCosine:    entity.COSINE,
+		vectordb.DistanceL2:        entity.L2,
+		vectordb.DistanceEuclidean: entity.L2,
+		vectordb.DistanceDot:       entity.IP,
+	}
+	for distance, want := range tests {
+		if got, err := metricType(distance); err != nil || got != want {
+			t.Errorf("%s: expected %s, got %s (%v)", distance, want, got, err)
+		}
+	}
+
+	if _, err := metricType("hamming"); err == nil {
+		t.Errorf("Expected an error for an unsupported distance")
+	}
+}
@@ -0,0 +1,200 @@
+package pgvector
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+	"github.com/lib/pq"
+)
+
+// SearchWithAdvancedFilters performs a vector search restricted by filter,
+// translated into a parameterized WHERE clause over the JSONB metadata column
+func (p *PgVector) SearchWithAdvancedFilters(ctx context.Context, query string, limit int, filter *vectordb.AdvancedFilter) ([]*vectordb.SearchResult, error) {
+	whereClause, args, err := buildAdvancedWhereClause(filter, 2) // Start from $2 since $1 is the embedding
+	if err != nil {
+		return nil, err
+	}
+
+	queryEmbedding, err := p.EmbedQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if queryEmbedding == nil {
+		return nil, fmt.Errorf("no query embedding generated")
+	}
+
+	return p.searchByVectorWhere(ctx, queryEmbedding, p.Distance, limit, whereClause, args)
+}
+
+// SearchWithReranking fetches TopK candidates (default 3x limit) and reorders
+// them with the same boosted scoring as the other backends
+func (p *PgVector) SearchWithReranking(ctx context.Context, query string, limit int, filters map[string]interface{}, config *vectordb.RerankingConfig) ([]*vectordb.SearchResult, error) {
+	return vectordb.SearchWithReranking(ctx, p, query, limit, filters, config)
+}
+
+// advancedWhere accumulates SQL conditions and their arguments
+type advancedWhere struct {
+	args      []interface{}
+	nextIndex int
+}
+
+// buildAdvancedWhereClause translates filter into a WHERE clause whose
+// placeholders start at $startIndex
+func buildAdvancedWhereClause(filter *vectordb.AdvancedFilter, startIndex int) (string, []interface{}, error) {
+	if filter == nil {
+		return "", nil, nil
+	}
+
+	w := &advancedWhere{nextIndex: startIndex}
+	var clauses []string
+
+	for _, cond := range filter.Must {
+		sql, err := w.condition(cond)
+		if err != nil {
+			return "", nil, err
+		}
+		clauses = append(clauses, sql)
+	}
+
+	if len(filter.Should) > 0 {
+		should := make([]string, 0, len(filter.Should))
+		for _, cond := range filter.Should {
+			sql, err := w.condition(cond)
+			if err != nil {
+				return "", nil, err
+			}
+			should = append(should, sql)
+		}
+		clauses = append(clauses, "("+strings.Join(should, " OR ")+")")
+	}
+
+	for _, cond := range filter.MustNot {
+		sql, err := w.condition(cond)
+		if err != nil {
+			return "", nil, err
+		}
+		// A missing field does not match the condition, so it passes MustNot
+		clauses = append(clauses, "NOT COALESCE("+sql+", false)")
+	}
+
+	if len(clauses) == 0 {
+		return "", nil, nil
+	}
+	return "WHERE " + strings.Join(clauses, " AND "), w.args, nil
+}
+
+// param adds an argument and returns its placeholder
+func (w *advancedWhere) param(value interface{}) string {
+	w.args = append(w.args, value)
+	placeholder := fmt.Sprintf("$%d", w.nextIndex)
+	w.nextIndex++
+	return placeholder
+}
+
+// condition translates a single condition into SQL
+func (w *advancedWhere) condition(cond vectordb.FilterCondition) (string, error) {
+	if cond.Field == "" {
+		return "", fmt.Errorf("filter condition without a field")
+	}
+	field := w.param(cond.Field)
+	text := fmt.Sprintf("(metadata ->> %s::text)", field)
+
+	switch cond.Operator {
+	case vectordb.FilterOpEqual:
+		return fmt.Sprintf("%s = %s", text, w.param(filterText(cond.Value))), nil
+
+	case vectordb.FilterOpNotEqual:
+		return fmt.Sprintf("%s IS DISTINCT FROM %s", text, w.param(filterText(cond.Value))), nil
+
+	case vectordb.FilterOpGreaterThan, vectordb.FilterOpGreaterThanOrEqual, vectordb.FilterOpLessThan, vectordb.FilterOpLessThanOrEqual:
+		return w.comparison(field, text, cond.Operator, cond.Value)
+
+	case vectordb.FilterOpRange:
+		bounds, ok := cond.Value.(map[string]interface{})
+		if !ok || len(bounds) == 0 {
+			return "", fmt.Errorf("range filter on %q needs a map of gt/gte/lt/lte bounds", cond.Field)
+		}
+		comparisons := make([]string, 0, len(bounds))
+		for _, op := range []vectordb.FilterOperator{vectordb.FilterOpGreaterThan, vectordb.FilterOpGreaterThanOrEqual, vectordb.FilterOpLessThan, vectordb.FilterOpLessThanOrEqual} {
+			bound, ok := bounds[string(op)]
+			if !ok {
+				continue
+			}
+			sql, err := w.comparison(field, text, op, bound)
+			if err != nil {
+				return "", err
+			}
+			comparisons = append(comparisons, sql)
+		}
+		if len(comparisons) != len(bounds) {
+			return "", fmt.Errorf("range filter on %q only supports gt/gte/lt/lte bounds", cond.Field)
+		}
+		return "(" + strings.Join(comparisons, " AND ") + ")", nil
+
+	case vectordb.FilterOpIn, vectordb.FilterOpNotIn:
+		values, ok := filterList(cond.Value)
+		if !ok {
+			return "", fmt.Errorf("%s filter on %q needs a list value", cond.Operator, cond.Field)
+		}
+		sql := fmt.Sprintf("%s = ANY(%s::text[])", text, w.param(pq.Array(values)))
+		if cond.Operator == vectordb.FilterOpNotIn {
+			sql = fmt.Sprintf("NOT COALESCE(%s, false)", sql)
+		}
+		return sql, nil
+
+	case vectordb.FilterOpContains:
+		// Arrays contain the value as an element, strings as a substring
+		value := filterText(cond.Value)
+		return fmt.Sprintf(`CASE jsonb_typeof(metadata -> %[1]s::text)
+			WHEN 'array' THEN EXISTS (SELECT 1 FROM jsonb_array_elements_text(metadata -> %[1]s::text) AS e WHERE e = %[2]s)
+			WHEN 'string' THEN strpos(%[3]s, %[2]s) > 0
+			ELSE false END`, field, w.param(value), text), nil
+
+	default:
+		return "", fmt.Errorf("unsupported filter operator %q", cond.Operator)
+	}
+}
+
+// comparison compares a field with a bound: numerically for numbers, only
+// matching numeric fields, and as text otherwise (e.g. ISO dates)
+func (w *advancedWhere) comparison(field, text string, op vectordb.FilterOperator, value interface{}) (string, error) {
+	sqlOps := map[vectordb.FilterOperator]string{
+		vectordb.FilterOpGreaterThan:        ">",
+		vectordb.FilterOpGreaterThanOrEqual: ">=",
+		vectordb.FilterOpLessThan:           "<",
+		vectordb.FilterOpLessThanOrEqual:    "<=",
+	}
+
+	if number, ok := vectordb.FilterNumber(value); ok {
+		return fmt.Sprintf("(CASE WHEN jsonb_typeof(metadata -> %s::text) = 'number' THEN %s::numeric END) %s %s",
+			field, text, sqlOps[op], w.param(number)), nil
+	}
+	if s, ok := value.(string); ok {
+		return fmt.Sprintf("%s %s %s", text, sqlOps[op], w.param(s)), nil
+	}
+	return "", fmt.Errorf("%s filter needs a number or string value, got %T", op, value)
+}
+
+// filterText formats a value like the ->> operator returns it
+func filterText(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// filterList returns the elements of a slice value as text
+func filterList(value interface{}) ([]string, bool) {
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, false
+	}
+	values := make([]string, v.Len())
+	for i := range values {
+		values[i] = filterText(v.Index(i).Interface())
+	}
+	return values, true
+}
@@ -0,0 +1,61 @@
+package pgvector
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+)
+
+func TestBuildAdvancedWhereClause(t *testing.T) {
+	where, args, err := buildAdvancedWhereClause(&vectordb.AdvancedFilter{
+		Must: []vectordb.FilterCondition{
+			{Field: "year", Operator: vectordb.FilterOpGreaterThan, Value: 2015},
+			{Field: "category", Operator: vectordb.FilterOpIn, Value: []string{"AI", "ML"}},
+		},
+		Should: []vectordb.FilterCondition{
+			{Field: "lang", Operator: vectordb.FilterOpEqual, Value: "go"},
+			{Field: "published", Operator: vectordb.FilterOpRange, Value: map[string]interface{}{"gte": "2020-01-01", "lt": "2021-01-01"}},
+		},
+		MustNot: []vectordb.FilterCondition{
+			{Field: "status'; DROP TABLE documents; --", Operator: vectordb.FilterOpEqual, Value: "draft"},
+		},
+	}, 2)
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	for _, want := range []string{
+		"WHERE (CASE WHEN jsonb_typeof(metadata -> $2::text) = 'number' THEN (metadata ->> $2::text)::numeric END) > $3",
+		"AND (metadata ->> $4::text) = ANY($5::text[])",
+		"AND ((metadata ->> $6::text) = $7 OR ((metadata ->> $8::text) >= $9 AND (metadata ->> $8::text) < $10))",
+		"AND NOT COALESCE((metadata ->> $11::text) = $12, false)",
+	} {
+		if !strings.Contains(where, want) {
+			t.Errorf("Expected WHERE clause to contain %q, got:\n%s", want, where)
+		}
+	}
+	if strings.Contains(where, "DROP TABLE") {
+		t.Errorf("Expected field names to be passed as parameters, got:\n%s", where)
+	}
+	if len(args) != 11 || args[0] != "year" || args[1] != 2015.0 || args[10] != "draft" {
+		t.Errorf("Unexpected args: %v", args)
+	}
+}
+
+func TestBuildAdvancedWhereClauseErrors(t *testing.T) {
+	for name, cond := range map[string]vectordb.FilterCondition{
+		"unknown operator": {Field: "a", Operator: "like", Value: "x"},
+		"in without list":  {Field: "a", Operator: vectordb.FilterOpIn, Value: "x"},
+		"bad range bound":  {Field: "a", Operator: vectordb.FilterOpRange, Value: map[string]interface{}{"between": 1}},
+		"bool comparison":  {Field: "a", Operator: vectordb.FilterOpLessThan, Value: true},
+	} {
+		if _, _, err := buildAdvancedWhereClause(&vectordb.AdvancedFilter{Must: []vectordb.FilterCondition{cond}}, 1); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if where, args, err := buildAdvancedWhereClause(nil, 2); where != "" || args != nil || err != nil {
+		t.Errorf("Expected no WHERE clause without a filter, got %q %v %v", where, args, err)
+	}
+}
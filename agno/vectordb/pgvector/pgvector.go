@@ -11,7 +11,7 @@ import (
 	"github.com/devalexandre/agno-golang/agno/document"
 	"github.com/devalexandre/agno-golang/agno/embedder"
 	"github.com/devalexandre/agno-golang/agno/vectordb"
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/pgvector/pgvector-go"
 )
 
@@ -21,6 +21,7 @@ type PgVector struct {
 	db         *sql.DB
 	tableName  string
 	schema     string
+	tenant     string
 	dimensions int
 }
 
@@ -32,6 +33,9 @@ type PgVectorConfig struct {
 	Embedder         embedder.Embedder
 	SearchType       vectordb.SearchType
 	Distance         vectordb.Distance
+	// Tenant isolates data per customer by storing it in a tenant-prefixed table
+	// (e.g. "acme_documents"). Only lowercase letters and digits are allowed.
+	Tenant string
 }
 
 // NewPgVector creates a new PgVector instance
@@ -50,6 +54,10 @@ func NewPgVector(config PgVectorConfig) (*PgVector, error) {
 	if tableName == "" {
 		tableName = "documents"
 	}
+	tableName, err = vectordb.TenantCollectionName(config.Tenant, tableName)
+	if err != nil {
+		return nil, err
+	}
 
 	schema := config.Schema
 	if schema == "" {
@@ -78,6 +86,7 @@ func NewPgVector(config PgVectorConfig) (*PgVector, error) {
 		db:           db,
 		tableName:    tableName,
 		schema:       schema,
+		tenant:       config.Tenant,
 		dimensions:   dimensions,
 	}, nil
 }
@@ -398,13 +407,87 @@ func (p *PgVector) VectorSearch(ctx context.Context, query string, limit int, fi
 		return nil, fmt.Errorf("no query embedding generated")
 	}
 
+	return p.SearchByVector(ctx, queryEmbedding, limit, filters)
+}
+
+// SearchByVector performs vector similarity search with a precomputed query embedding
+func (p *PgVector) SearchByVector(ctx context.Context, queryEmbedding []float64, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	return p.searchByVector(ctx, queryEmbedding, p.Distance, limit, filters)
+}
+
+// IndexDistance returns the distance the HNSW index of the table was built
+// with, read from its operator class, or "" if the table has no vector index
+func (p *PgVector) IndexDistance(ctx context.Context) (vectordb.Distance, error) {
+	rows, err := p.db.QueryContext(ctx, "SELECT indexdef FROM pg_indexes WHERE schemaname = $1 AND tablename = $2", p.schema, p.tableName)
+	if err != nil {
+		return "", fmt.Errorf("failed to read table indexes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var indexDef string
+		if err := rows.Scan(&indexDef); err != nil {
+			return "", fmt.Errorf("failed to scan index definition: %w", err)
+		}
+		switch {
+		case strings.Contains(indexDef, "vector_cosine_ops"):
+			return vectordb.DistanceCosine, nil
+		case strings.Contains(indexDef, "vector_l2_ops"):
+			return vectordb.DistanceL2, nil
+		case strings.Contains(indexDef, "vector_ip_ops"):
+			return vectordb.DistanceMaxInnerProduct, nil
+		}
+	}
+	return "", rows.Err()
+}
+
+// SearchWithDistance performs a vector search ordered by distance instead of
+// the configured distance. distance must match the operator class of the
+// table's vector index, otherwise the index cannot serve the search and
+// ErrDistanceMismatch is returned; tables without a vector index accept any
+// supported distance.
+func (p *PgVector) SearchWithDistance(ctx context.Context, query string, distance vectordb.Distance, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	distance = vectordb.NormalizeDistance(distance)
+	switch distance {
+	case vectordb.DistanceCosine, vectordb.DistanceL2, vectordb.DistanceMaxInnerProduct:
+	default:
+		return nil, fmt.Errorf("unsupported distance %q", distance)
+	}
+
+	indexed, err := p.IndexDistance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := vectordb.ValidateDistance(indexed, distance); err != nil {
+		return nil, err
+	}
+
+	queryEmbedding, err := p.EmbedQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if queryEmbedding == nil {
+		return nil, fmt.Errorf("no query embedding generated")
+	}
+
+	return p.searchByVector(ctx, queryEmbedding, distance, limit, filters)
+}
+
+// searchByVector performs vector similarity search ordered by distanceType
+func (p *PgVector) searchByVector(ctx context.Context, queryEmbedding []float64, distanceType vectordb.Distance, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
 	// Build WHERE clause for filters
 	whereClause, args := p.buildWhereClause(filters, 2) // Start from $2 since $1 is the embedding
 
+	return p.searchByVectorWhere(ctx, queryEmbedding, distanceType, limit, whereClause, args)
+}
+
+// searchByVectorWhere performs vector similarity search on the rows matching
+// whereClause, whose placeholders start at $2
+func (p *PgVector) searchByVectorWhere(ctx context.Context, queryEmbedding []float64, distanceType vectordb.Distance, limit int, whereClause string, args []interface{}) ([]*vectordb.SearchResult, error) {
 	// Choose distance operator based on distance type
 	var distanceOp string
 	var orderBy string
-	switch p.Distance {
+	switch distanceType {
 	case vectordb.DistanceCosine:
 		distanceOp = "<=>"
 		orderBy = "embeddings <=> $1"
@@ -440,7 +523,7 @@ func (p *PgVector) VectorSearch(ctx context.Context, query string, limit int, fi
 	}
 	defer rows.Close()
 
-	return p.scanSearchResults(rows)
+	return p.scanSearchResults(rows, distanceType)
 }
 
 // KeywordSearch performs full-text search
@@ -476,7 +559,7 @@ func (p *PgVector) KeywordSearch(ctx context.Context, query string, limit int, f
 	}
 	defer rows.Close()
 
-	return p.scanSearchResults(rows)
+	return p.scanSearchResults(rows, p.Distance)
 }
 
 // HybridSearch performs hybrid vector + keyword search
@@ -570,8 +653,252 @@ func (p *PgVector) IDExists(ctx context.Context, id string) (bool, error) {
 	return exists, err
 }
 
+// ListDocuments returns stored documents matching filters, ordered by creation time.
+// Embeddings are not loaded.
+func (p *PgVector) ListDocuments(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*document.Document, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	whereClause, args := p.buildWhereClause(filters, 1)
+
+	listSQL := fmt.Sprintf(`
+		SELECT id, name, content, content_type, metadata, source, created_at, updated_at,
+			   chunk_index, chunk_total, parent_id
+		FROM %s.%s
+		%s
+		ORDER BY created_at, id
+		LIMIT $%d OFFSET $%d
+	`, p.schema, p.tableName, whereClause, len(args)+1, len(args)+2)
+
+	queryArgs := append(args, limit, offset)
+
+	rows, err := p.db.QueryContext(ctx, listSQL, queryArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []*document.Document
+	for rows.Next() {
+		doc, err := scanDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, rows.Err()
+}
+
+// GetDocument returns a stored document by ID without its embeddings.
+// It returns nil when the document does not exist.
+func (p *PgVector) GetDocument(ctx context.Context, id string) (*document.Document, error) {
+	getSQL := fmt.Sprintf(`
+		SELECT id, name, content, content_type, metadata, source, created_at, updated_at,
+			   chunk_index, chunk_total, parent_id
+		FROM %s.%s
+		WHERE id = $1
+	`, p.schema, p.tableName)
+
+	rows, err := p.db.QueryContext(ctx, getSQL, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document %s: %w", id, err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+
+	return scanDocument(rows)
+}
+
+// GetDocuments returns the stored documents among ids without their embeddings
+func (p *PgVector) GetDocuments(ctx context.Context, ids []string) ([]*document.Document, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	getSQL := fmt.Sprintf(`
+		SELECT id, name, content, content_type, metadata, source, created_at, updated_at,
+			   chunk_index, chunk_total, parent_id
+		FROM %s.%s
+		WHERE id = ANY($1)
+	`, p.schema, p.tableName)
+
+	rows, err := p.db.QueryContext(ctx, getSQL, pq.Array(ids))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []*document.Document
+	for rows.Next() {
+		doc, err := scanDocument(rows)
+		if err != nil {
+			return nil, err
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, rows.Err()
+}
+
+// ScrollDocuments returns up to limit documents with their embeddings, ordered by ID.
+// The cursor is the last ID of the previous page.
+func (p *PgVector) ScrollDocuments(ctx context.Context, cursor string, limit int) ([]*document.Document, string, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	scrollSQL := fmt.Sprintf(`
+		SELECT id, name, content, content_type, metadata, source, created_at, updated_at,
+			   chunk_index, chunk_total, parent_id, embeddings
+		FROM %s.%s
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`, p.schema, p.tableName)
+
+	rows, err := p.db.QueryContext(ctx, scrollSQL, cursor, limit)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to scroll documents: %w", err)
+	}
+	defer rows.Close()
+
+	var docs []*document.Document
+	for rows.Next() {
+		var doc document.Document
+		var name, contentType, source, parentID, metadataJSON, embeddings sql.NullString
+		err := rows.Scan(
+			&doc.ID,
+			&name,
+			&doc.Content,
+			&contentType,
+			&metadataJSON,
+			&source,
+			&doc.CreatedAt,
+			&doc.UpdatedAt,
+			&doc.ChunkIndex,
+			&doc.ChunkTotal,
+			&parentID,
+			&embeddings,
+		)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		doc.Name = name.String
+		doc.ContentType = contentType.String
+		doc.Source = source.String
+		doc.ParentID = parentID.String
+
+		if metadataJSON.Valid && metadataJSON.String != "" {
+			if err := json.Unmarshal([]byte(metadataJSON.String), &doc.Metadata); err != nil {
+				return nil, "", fmt.Errorf("failed to unmarshal metadata: %w", err)
+			}
+		}
+
+		if embeddings.Valid {
+			var vector pgvector.Vector
+			if err := vector.Parse(embeddings.String); err != nil {
+				return nil, "", fmt.Errorf("failed to parse embeddings of %s: %w", doc.ID, err)
+			}
+			doc.Embeddings = convertFloat32ToFloat64(vector.Slice())
+		}
+
+		docs = append(docs, &doc)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if len(docs) == limit {
+		next = docs[len(docs)-1].ID
+	}
+	return docs, next, nil
+}
+
+// DeleteByIDs removes the documents with the given IDs
+func (p *PgVector) DeleteByIDs(ctx context.Context, ids []string) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	result, err := p.db.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM %s.%s WHERE id = ANY($1)", p.schema, p.tableName),
+		pq.Array(ids))
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get deleted count: %w", err)
+	}
+	return int(affected), nil
+}
+
+// DeleteByFilter removes the documents whose metadata matches filters
+func (p *PgVector) DeleteByFilter(ctx context.Context, filters map[string]interface{}) error {
+	if len(filters) == 0 {
+		return fmt.Errorf("filters cannot be empty for delete operation")
+	}
+
+	whereClause, args := p.buildWhereClause(filters, 1)
+	_, err := p.db.ExecContext(ctx,
+		fmt.Sprintf("DELETE FROM %s.%s %s", p.schema, p.tableName, whereClause),
+		args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete documents: %w", err)
+	}
+	return nil
+}
+
 // Helper methods
 
+// scanDocument scans a row without embeddings or distance into a Document
+func scanDocument(rows *sql.Rows) (*document.Document, error) {
+	var doc document.Document
+	var name, contentType, source, parentID sql.NullString
+	var metadataJSON sql.NullString
+
+	err := rows.Scan(
+		&doc.ID,
+		&name,
+		&doc.Content,
+		&contentType,
+		&metadataJSON,
+		&source,
+		&doc.CreatedAt,
+		&doc.UpdatedAt,
+		&doc.ChunkIndex,
+		&doc.ChunkTotal,
+		&parentID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan row: %w", err)
+	}
+
+	doc.Name = name.String
+	doc.ContentType = contentType.String
+	doc.Source = source.String
+	doc.ParentID = parentID.String
+
+	if metadataJSON.Valid && metadataJSON.String != "" {
+		if err := json.Unmarshal([]byte(metadataJSON.String), &doc.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata: %w", err)
+		}
+	}
+
+	return &doc, nil
+}
+
 // buildWhereClause builds WHERE clause for filters
 func (p *PgVector) buildWhereClause(filters map[string]interface{}, startIndex int) (string, []interface{}) {
 	if len(filters) == 0 {
@@ -600,8 +927,9 @@ func (p *PgVector) buildWhereClause(filters map[string]interface{}, startIndex i
 	return whereClause, args
 }
 
-// scanSearchResults scans database rows into SearchResult slice
-func (p *PgVector) scanSearchResults(rows *sql.Rows) ([]*vectordb.SearchResult, error) {
+// scanSearchResults scans database rows into SearchResult slice, scoring them
+// by distanceType
+func (p *PgVector) scanSearchResults(rows *sql.Rows, distanceType vectordb.Distance) ([]*vectordb.SearchResult, error) {
 	var results []*vectordb.SearchResult
 
 	for rows.Next() {
@@ -644,7 +972,7 @@ func (p *PgVector) scanSearchResults(rows *sql.Rows) ([]*vectordb.SearchResult,
 
 		// Calculate score based on distance metric
 		var score float64
-		switch p.Distance {
+		switch distanceType {
 		case vectordb.DistanceCosine:
 			// For cosine distance, 0 = identical, 2 = opposite
 			// Convert to similarity: 1 - (distance / 2)
@@ -673,7 +1001,45 @@ func (p *PgVector) scanSearchResults(rows *sql.Rows) ([]*vectordb.SearchResult,
 	return results, rows.Err()
 }
 
+// GetTenant returns the tenant this instance is scoped to, if any
+func (p *PgVector) GetTenant() string {
+	return p.tenant
+}
+
 // Close closes the database connection
 func (p *PgVector) Close() error {
 	return p.db.Close()
 }
+
+// GetEmbedderInfo returns the embedder recorded in the table comment, or nil if none was recorded
+func (p *PgVector) GetEmbedderInfo(ctx context.Context) (*vectordb.EmbedderInfo, error) {
+	var comment sql.NullString
+	query := "SELECT obj_description(to_regclass($1), 'pg_class')"
+	if err := p.db.QueryRowContext(ctx, query, fmt.Sprintf("%s.%s", p.schema, p.tableName)).Scan(&comment); err != nil {
+		return nil, fmt.Errorf("failed to read table comment: %w", err)
+	}
+	if !comment.Valid || comment.String == "" {
+		return nil, nil
+	}
+
+	var info vectordb.EmbedderInfo
+	if err := json.Unmarshal([]byte(comment.String), &info); err != nil {
+		// Comment not written by us
+		return nil, nil
+	}
+	return &info, nil
+}
+
+// SetEmbedderInfo records the embedder in the table comment
+func (p *PgVector) SetEmbedderInfo(ctx context.Context, info vectordb.EmbedderInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return fmt.Errorf("failed to encode embedder info: %w", err)
+	}
+
+	query := fmt.Sprintf("COMMENT ON TABLE %s.%s IS %s", p.schema, p.tableName, pq.QuoteLiteral(string(data)))
+	if _, err := p.db.ExecContext(ctx, query); err != nil {
+		return fmt.Errorf("failed to record embedder info: %w", err)
+	}
+	return nil
+}
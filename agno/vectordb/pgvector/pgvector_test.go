@@ -2,6 +2,7 @@ package pgvector
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -231,6 +232,24 @@ func TestPgVectorWithTestcontainers(t *testing.T) {
 		}
 	})
 
+	t.Run("Search with Advanced Filters", func(t *testing.T) {
+		results, err := pgVector.SearchWithAdvancedFilters(ctx, "intelligence", 5, &vectordb.AdvancedFilter{
+			Must: []vectordb.FilterCondition{
+				{Field: "category", Operator: vectordb.FilterOpIn, Value: []string{"AI", "ML"}},
+			},
+			MustNot: []vectordb.FilterCondition{
+				{Field: "priority", Operator: vectordb.FilterOpEqual, Value: "medium"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("Failed to search with advanced filters: %v", err)
+		}
+
+		if len(results) != 1 || results[0].Document.ID != "doc1" {
+			t.Fatalf("Expected only doc1, got %d results", len(results))
+		}
+	})
+
 	t.Run("Keyword Search", func(t *testing.T) {
 		results, err := pgVector.KeywordSearch(ctx, "neural networks", 5, nil)
 		if err != nil {
@@ -436,6 +455,22 @@ func TestPgVectorDifferentDistanceMetrics(t *testing.T) {
 
 			t.Logf("Distance %s: Score=%.4f, Distance=%.4f",
 				distance, results[0].Score, results[0].Distance)
+
+			// One-off searches must match the distance the index was built with
+			indexed, err := pgVector.IndexDistance(ctx)
+			if err != nil || indexed != distance {
+				t.Fatalf("Expected index distance %s, got %q (%v)", distance, indexed, err)
+			}
+			if _, err := pgVector.SearchWithDistance(ctx, "test document", distance, 1, nil); err != nil {
+				t.Errorf("Failed to search with the index distance %s: %v", distance, err)
+			}
+			other := vectordb.DistanceCosine
+			if distance == vectordb.DistanceCosine {
+				other = vectordb.DistanceL2
+			}
+			if _, err := pgVector.SearchWithDistance(ctx, "test document", other, 1, nil); !errors.Is(err, vectordb.ErrDistanceMismatch) {
+				t.Errorf("Expected ErrDistanceMismatch searching a %s index with %s, got %v", distance, other, err)
+			}
 		})
 	}
 }
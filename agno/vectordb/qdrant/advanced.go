@@ -11,43 +11,30 @@ import (
 	"github.com/qdrant/go-client/qdrant"
 )
 
-// AdvancedFilter represents advanced filtering options for Qdrant
-type AdvancedFilter struct {
-	Must    []FilterCondition
-	Should  []FilterCondition
-	MustNot []FilterCondition
-}
+// AdvancedFilter represents advanced filtering options, shared with the other backends
+type AdvancedFilter = vectordb.AdvancedFilter
 
 // FilterCondition represents a single filter condition
-type FilterCondition struct {
-	Field    string
-	Operator FilterOperator
-	Value    interface{}
-}
+type FilterCondition = vectordb.FilterCondition
 
 // FilterOperator represents filter operators
-type FilterOperator string
+type FilterOperator = vectordb.FilterOperator
 
 const (
-	FilterOpEqual              FilterOperator = "eq"
-	FilterOpNotEqual           FilterOperator = "ne"
-	FilterOpGreaterThan        FilterOperator = "gt"
-	FilterOpGreaterThanOrEqual FilterOperator = "gte"
-	FilterOpLessThan           FilterOperator = "lt"
-	FilterOpLessThanOrEqual    FilterOperator = "lte"
-	FilterOpIn                 FilterOperator = "in"
-	FilterOpNotIn              FilterOperator = "nin"
-	FilterOpContains           FilterOperator = "contains"
-	FilterOpRange              FilterOperator = "range"
+	FilterOpEqual              = vectordb.FilterOpEqual
+	FilterOpNotEqual           = vectordb.FilterOpNotEqual
+	FilterOpGreaterThan        = vectordb.FilterOpGreaterThan
+	FilterOpGreaterThanOrEqual = vectordb.FilterOpGreaterThanOrEqual
+	FilterOpLessThan           = vectordb.FilterOpLessThan
+	FilterOpLessThanOrEqual    = vectordb.FilterOpLessThanOrEqual
+	FilterOpIn                 = vectordb.FilterOpIn
+	FilterOpNotIn              = vectordb.FilterOpNotIn
+	FilterOpContains           = vectordb.FilterOpContains
+	FilterOpRange              = vectordb.FilterOpRange
 )
 
-// RerankingConfig represents reranking configuration
-type RerankingConfig struct {
-	Enabled    bool
-	Model      string // "cross-encoder" or "colbert"
-	TopK       int    // Number of results to rerank
-	ScoreBoost float64
-}
+// RerankingConfig represents reranking configuration, shared with the other backends
+type RerankingConfig = vectordb.RerankingConfig
 
 // SearchWithAdvancedFilters performs search with advanced filtering
 func (q *Qdrant) SearchWithAdvancedFilters(ctx context.Context, query string, limit int, advFilter *AdvancedFilter) ([]*vectordb.SearchResult, error) {
@@ -115,33 +102,9 @@ func (q *Qdrant) SearchWithAdvancedFilters(ctx context.Context, query string, li
 	return results, nil
 }
 
-// SearchWithReranking performs search with reranking
+// SearchWithReranking performs search with reranking (see vectordb.SearchWithReranking)
 func (q *Qdrant) SearchWithReranking(ctx context.Context, query string, limit int, filters map[string]interface{}, config *RerankingConfig) ([]*vectordb.SearchResult, error) {
-	if config == nil || !config.Enabled {
-		return q.Search(ctx, query, limit, filters)
-	}
-
-	// Get more results than needed for reranking
-	fetchLimit := limit * 3
-	if config.TopK > 0 && config.TopK > limit {
-		fetchLimit = config.TopK
-	}
-
-	// Perform initial search
-	results, err := q.Search(ctx, query, fetchLimit, filters)
-	if err != nil {
-		return nil, err
-	}
-
-	// Rerank results
-	rerankedResults := q.rerankResults(query, results, config)
-
-	// Limit to requested number
-	if len(rerankedResults) > limit {
-		rerankedResults = rerankedResults[:limit]
-	}
-
-	return rerankedResults, nil
+	return vectordb.SearchWithReranking(ctx, q, query, limit, filters, config)
 }
 
 // BatchSearch performs batch search operations
@@ -325,25 +288,17 @@ func (q *Qdrant) convertCondition(cond FilterCondition) *qdrant.Condition {
 
 	case FilterOpRange:
 		if rangeVal, ok := cond.Value.(map[string]interface{}); ok {
-			var gte, lte *float64
-			if v, ok := rangeVal["gte"].(float64); ok {
-				gte = &v
-			}
-			if v, ok := rangeVal["lte"].(float64); ok {
-				lte = &v
+			qdrantRange := &qdrant.Range{}
+			for op, bound := range rangeVal {
+				setRangeBound(qdrantRange, FilterOperator(op), bound)
 			}
+			return rangeCondition(cond.Field, qdrantRange)
+		}
 
-			return &qdrant.Condition{
-				ConditionOneOf: &qdrant.Condition_Field{
-					Field: &qdrant.FieldCondition{
-						Key: cond.Field,
-						Range: &qdrant.Range{
-							Gte: gte,
-							Lte: lte,
-						},
-					},
-				},
-			}
+	case FilterOpGreaterThan, FilterOpGreaterThanOrEqual, FilterOpLessThan, FilterOpLessThanOrEqual:
+		qdrantRange := &qdrant.Range{}
+		if setRangeBound(qdrantRange, cond.Operator, cond.Value) {
+			return rangeCondition(cond.Field, qdrantRange)
 		}
 
 	case FilterOpIn:
@@ -374,89 +329,37 @@ func (q *Qdrant) convertCondition(cond FilterCondition) *qdrant.Condition {
 	return nil
 }
 
-// rerankResults reranks search results based on configuration
-func (q *Qdrant) rerankResults(query string, results []*vectordb.SearchResult, config *RerankingConfig) []*vectordb.SearchResult {
-	if len(results) == 0 {
-		return results
-	}
-
-	// Simple reranking based on content similarity
-	// In production, you would use a cross-encoder model here
-	for _, result := range results {
-		// Calculate additional score based on query-content similarity
-		contentScore := q.calculateContentSimilarity(query, result.Document.Content)
-
-		// Boost the score
-		boost := config.ScoreBoost
-		if boost == 0 {
-			boost = 1.2
-		}
-
-		result.Score = result.Score*0.7 + contentScore*0.3*boost
-	}
-
-	// Sort by new score
-	sort.Slice(results, func(i, j int) bool {
-		return results[i].Score > results[j].Score
-	})
-
-	return results
-}
-
-// calculateContentSimilarity calculates simple content similarity
-func (q *Qdrant) calculateContentSimilarity(query, content string) float64 {
-	// Simple word overlap similarity
-	queryWords := q.tokenize(query)
-	contentWords := q.tokenize(content)
-
-	if len(queryWords) == 0 || len(contentWords) == 0 {
-		return 0.0
-	}
-
-	// Count overlapping words
-	overlap := 0
-	querySet := make(map[string]bool)
-	for _, word := range queryWords {
-		querySet[word] = true
-	}
-
-	for _, word := range contentWords {
-		if querySet[word] {
-			overlap++
-		}
-	}
-
-	// Calculate Jaccard similarity
-	union := len(queryWords) + len(contentWords) - overlap
-	if union == 0 {
-		return 0.0
-	}
-
-	return float64(overlap) / float64(union)
+// setRangeBound sets the bound of qdrantRange for a range operator and
+// reports whether value was numeric
+func setRangeBound(qdrantRange *qdrant.Range, op FilterOperator, value interface{}) bool {
+	v, ok := vectordb.FilterNumber(value)
+	if !ok {
+		return false
+	}
+	switch op {
+	case FilterOpGreaterThan:
+		qdrantRange.Gt = &v
+	case FilterOpGreaterThanOrEqual:
+		qdrantRange.Gte = &v
+	case FilterOpLessThan:
+		qdrantRange.Lt = &v
+	case FilterOpLessThanOrEqual:
+		qdrantRange.Lte = &v
+	default:
+		return false
+	}
+	return true
 }
 
-// tokenize splits text into words
-func (q *Qdrant) tokenize(text string) []string {
-	// Simple tokenization - split by spaces and convert to lowercase
-	words := []string{}
-	current := ""
-
-	for _, char := range text {
-		if char == ' ' || char == '\n' || char == '\t' {
-			if current != "" {
-				words = append(words, current)
-				current = ""
-			}
-		} else {
-			current += string(char)
-		}
-	}
-
-	if current != "" {
-		words = append(words, current)
+func rangeCondition(field string, qdrantRange *qdrant.Range) *qdrant.Condition {
+	return &qdrant.Condition{
+		ConditionOneOf: &qdrant.Condition_Field{
+			Field: &qdrant.FieldCondition{
+				Key:   field,
+				Range: qdrantRange,
+			},
+		},
 	}
-
-	return words
 }
 
 // SparseVector represents a sparse vector for hybrid search
@@ -586,12 +489,12 @@ func (q *Qdrant) CalculateRelevanceScore(query string, doc *document.Document) f
 	}
 
 	// Calculate multiple relevance signals
-	contentSim := q.calculateContentSimilarity(query, doc.Content)
+	contentSim := vectordb.ContentSimilarity(query, doc.Content)
 
 	// Title/name similarity (if available)
 	nameSim := 0.0
 	if doc.Name != "" {
-		nameSim = q.calculateContentSimilarity(query, doc.Name)
+		nameSim = vectordb.ContentSimilarity(query, doc.Name)
 	}
 
 	// Combine scores
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	"github.com/devalexandre/agno-golang/agno/document"
 	"github.com/devalexandre/agno-golang/agno/embedder"
@@ -16,6 +17,7 @@ type Qdrant struct {
 	*vectordb.BaseVectorDB
 	client     *qdrant.Client
 	collection string
+	tenant     string
 }
 
 // QdrantConfig holds configuration for Qdrant
@@ -26,6 +28,9 @@ type QdrantConfig struct {
 	Embedder   embedder.Embedder
 	SearchType vectordb.SearchType
 	Distance   vectordb.Distance
+	// Tenant isolates data per customer by storing it in a tenant-prefixed collection
+	// (e.g. "acme_documents"). Only lowercase letters and digits are allowed.
+	Tenant string
 }
 
 // NewQdrant creates a new Qdrant instance
@@ -42,6 +47,10 @@ func NewQdrant(config QdrantConfig) (*Qdrant, error) {
 	if collection == "" {
 		collection = "documents"
 	}
+	collection, err = vectordb.TenantCollectionName(config.Tenant, collection)
+	if err != nil {
+		return nil, err
+	}
 
 	searchType := config.SearchType
 	if searchType == "" {
@@ -59,6 +68,7 @@ func NewQdrant(config QdrantConfig) (*Qdrant, error) {
 		BaseVectorDB: baseVectorDB,
 		client:       client,
 		collection:   collection,
+		tenant:       config.Tenant,
 	}, nil
 }
 
@@ -238,6 +248,72 @@ func (q *Qdrant) VectorSearch(ctx context.Context, query string, limit int, filt
 		return nil, fmt.Errorf("no query embedding generated")
 	}
 
+	return q.SearchByVector(ctx, queryEmbedding, limit, filters)
+}
+
+// SearchByVector performs vector similarity search with a precomputed query embedding
+func (q *Qdrant) SearchByVector(ctx context.Context, queryEmbedding []float64, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	return q.searchByVector(ctx, queryEmbedding, q.Distance, limit, filters)
+}
+
+// IndexDistance returns the distance of the collection vectors, or "" if the
+// collection does not exist or uses named vectors
+func (q *Qdrant) IndexDistance(ctx context.Context) (vectordb.Distance, error) {
+	exists, err := q.collectionExists(ctx, q.collection)
+	if err != nil {
+		return "", fmt.Errorf("failed to check collection existence: %w", err)
+	}
+	if !exists {
+		return "", nil
+	}
+
+	info, err := q.client.GetCollectionInfo(ctx, q.collection)
+	if err != nil {
+		return "", fmt.Errorf("failed to get collection info: %w", err)
+	}
+
+	params := info.GetConfig().GetParams().GetVectorsConfig().GetParams()
+	if params == nil {
+		return "", nil
+	}
+	switch params.GetDistance() {
+	case qdrant.Distance_Cosine:
+		return vectordb.DistanceCosine, nil
+	case qdrant.Distance_Euclid:
+		return vectordb.DistanceL2, nil
+	case qdrant.Distance_Dot:
+		return vectordb.DistanceMaxInnerProduct, nil
+	default:
+		return vectordb.Distance(strings.ToLower(params.GetDistance().String())), nil
+	}
+}
+
+// SearchWithDistance performs a vector search scored with distance. Qdrant
+// fixes the distance of a collection when it is created, so distance must
+// match it; otherwise ErrDistanceMismatch is returned.
+func (q *Qdrant) SearchWithDistance(ctx context.Context, query string, distance vectordb.Distance, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	indexed, err := q.IndexDistance(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := vectordb.ValidateDistance(indexed, distance); err != nil {
+		return nil, err
+	}
+
+	queryEmbedding, err := q.EmbedQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
+	}
+	if queryEmbedding == nil {
+		return nil, fmt.Errorf("no query embedding generated")
+	}
+
+	return q.searchByVector(ctx, queryEmbedding, vectordb.NormalizeDistance(distance), limit, filters)
+}
+
+// searchByVector performs vector similarity search, deriving result distances
+// from the scores as distance defines them
+func (q *Qdrant) searchByVector(ctx context.Context, queryEmbedding []float64, distanceType vectordb.Distance, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
 	// Create filter if provided
 	var filter *qdrant.Filter
 	if filters != nil && len(filters) > 0 {
@@ -281,7 +357,7 @@ func (q *Qdrant) VectorSearch(ctx context.Context, query string, limit int, filt
 
 		// Calculate distance from score (Qdrant returns similarity score 0-1)
 		distance := 1.0 - float64(point.Score)
-		if q.Distance == vectordb.DistanceMaxInnerProduct || q.Distance == vectordb.DistanceDot {
+		if distanceType == vectordb.DistanceMaxInnerProduct || distanceType == vectordb.DistanceDot {
 			distance = -float64(point.Score) // For inner product, negate for distance
 		}
 
@@ -515,6 +591,201 @@ func (q *Qdrant) IDExists(ctx context.Context, id string) (bool, error) {
 	return len(points) > 0, nil
 }
 
+// ListDocuments returns stored documents matching filters, without vectors.
+// Qdrant paginates by point ID, so offset is applied by skipping scrolled points.
+func (q *Qdrant) ListDocuments(ctx context.Context, filters map[string]interface{}, limit, offset int) ([]*document.Document, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	if offset < 0 {
+		offset = 0
+	}
+
+	var filter *qdrant.Filter
+	if len(filters) > 0 {
+		filter = createQdrantFilter(filters)
+	}
+
+	scrollLimit := uint32(limit + offset)
+	points, err := q.client.Scroll(ctx, &qdrant.ScrollPoints{
+		CollectionName: q.collection,
+		Filter:         filter,
+		Limit:          &scrollLimit,
+		WithPayload:    qdrant.NewWithPayload(true),
+		WithVectors:    qdrant.NewWithVectors(false),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list documents: %w", err)
+	}
+
+	if offset >= len(points) {
+		return []*document.Document{}, nil
+	}
+	points = points[offset:]
+
+	docs := make([]*document.Document, 0, len(points))
+	for _, point := range points {
+		doc, err := q.payloadToDocument(point.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if doc.ID == "" {
+			doc.ID = pointIDToString(point.Id)
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, nil
+}
+
+// ScrollDocuments returns up to limit documents with their vectors. The cursor is
+// the point ID of the next page as returned by Qdrant.
+func (q *Qdrant) ScrollDocuments(ctx context.Context, cursor string, limit int) ([]*document.Document, string, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+
+	scrollLimit := uint32(limit)
+	request := &qdrant.ScrollPoints{
+		CollectionName: q.collection,
+		Limit:          &scrollLimit,
+		WithPayload:    qdrant.NewWithPayload(true),
+		WithVectors:    qdrant.NewWithVectors(true),
+	}
+	if cursor != "" {
+		request.Offset = stringToPointID(cursor)
+	}
+
+	points, nextOffset, err := q.client.ScrollAndOffset(ctx, request)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to scroll documents: %w", err)
+	}
+
+	docs := make([]*document.Document, 0, len(points))
+	for _, point := range points {
+		doc, err := q.payloadToDocument(point.Payload)
+		if err != nil {
+			return nil, "", err
+		}
+		if doc.ID == "" {
+			doc.ID = pointIDToString(point.Id)
+		}
+		if vector := point.GetVectors().GetVector(); vector != nil {
+			data := vector.GetData()
+			if len(data) == 0 {
+				data = vector.GetDense().GetData()
+			}
+			doc.Embeddings = convertToFloat64(data)
+		}
+		docs = append(docs, doc)
+	}
+
+	return docs, pointIDToString(nextOffset), nil
+}
+
+// GetDocument returns a stored document by ID without its vector.
+// It returns nil when the document does not exist.
+func (q *Qdrant) GetDocument(ctx context.Context, id string) (*document.Document, error) {
+	points, err := q.client.Get(ctx, &qdrant.GetPoints{
+		CollectionName: q.collection,
+		Ids: []*qdrant.PointId{
+			{PointIdOptions: &qdrant.PointId_Num{Num: stringToUint64(id)}},
+		},
+		WithPayload: qdrant.NewWithPayload(true),
+		WithVectors: qdrant.NewWithVectors(false),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get document %s: %w", id, err)
+	}
+	if len(points) == 0 {
+		return nil, nil
+	}
+
+	doc, err := q.payloadToDocument(points[0].Payload)
+	if err != nil {
+		return nil, err
+	}
+	if doc.ID == "" {
+		doc.ID = id
+	}
+	return doc, nil
+}
+
+// GetDocuments returns the stored documents among ids without their vectors
+func (q *Qdrant) GetDocuments(ctx context.Context, ids []string) ([]*document.Document, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	pointIDs := make([]*qdrant.PointId, len(ids))
+	for i, id := range ids {
+		pointIDs[i] = &qdrant.PointId{PointIdOptions: &qdrant.PointId_Num{Num: stringToUint64(id)}}
+	}
+
+	points, err := q.client.Get(ctx, &qdrant.GetPoints{
+		CollectionName: q.collection,
+		Ids:            pointIDs,
+		WithPayload:    qdrant.NewWithPayload(true),
+		WithVectors:    qdrant.NewWithVectors(false),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get documents: %w", err)
+	}
+
+	docs := make([]*document.Document, 0, len(points))
+	for _, point := range points {
+		doc, err := q.payloadToDocument(point.Payload)
+		if err != nil {
+			return nil, err
+		}
+		if doc.ID == "" {
+			doc.ID = pointIDToString(point.Id)
+		}
+		docs = append(docs, doc)
+	}
+	return docs, nil
+}
+
+// DeleteByIDs removes the documents with the given IDs
+func (q *Qdrant) DeleteByIDs(ctx context.Context, ids []string) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	pointIDs := make([]*qdrant.PointId, len(ids))
+	for i, id := range ids {
+		pointIDs[i] = &qdrant.PointId{PointIdOptions: &qdrant.PointId_Num{Num: stringToUint64(id)}}
+	}
+
+	// Qdrant does not report how many points a delete removed, so count them first
+	existing, err := q.client.Get(ctx, &qdrant.GetPoints{
+		CollectionName: q.collection,
+		Ids:            pointIDs,
+		WithPayload:    qdrant.NewWithPayload(false),
+		WithVectors:    qdrant.NewWithVectors(false),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to look up documents: %w", err)
+	}
+	if len(existing) == 0 {
+		return 0, nil
+	}
+
+	_, err = q.client.Delete(ctx, &qdrant.DeletePoints{
+		CollectionName: q.collection,
+		Points: &qdrant.PointsSelector{
+			PointsSelectorOneOf: &qdrant.PointsSelector_Points{
+				Points: &qdrant.PointsIdsList{Ids: pointIDs},
+			},
+		},
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete documents: %w", err)
+	}
+
+	return len(existing), nil
+}
+
 // Helper methods
 
 // collectionExists checks if the collection exists
@@ -656,6 +927,17 @@ func convertToFloat32(vector []float64) []float32 {
 	return result
 }
 
+func convertToFloat64(vector []float32) []float64 {
+	if len(vector) == 0 {
+		return nil
+	}
+	result := make([]float64, len(vector))
+	for i, v := range vector {
+		result[i] = float64(v)
+	}
+	return result
+}
+
 func convertToQdrantValue(v interface{}) *qdrant.Value {
 	switch val := v.(type) {
 	case string:
@@ -718,6 +1000,14 @@ func pointIDToString(id *qdrant.PointId) string {
 	}
 }
 
+// stringToPointID parses a point ID formatted by pointIDToString
+func stringToPointID(s string) *qdrant.PointId {
+	if num, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return &qdrant.PointId{PointIdOptions: &qdrant.PointId_Num{Num: num}}
+	}
+	return &qdrant.PointId{PointIdOptions: &qdrant.PointId_Uuid{Uuid: s}}
+}
+
 // stringToUint64 converts a string to uint64 using hash for non-numeric strings
 func stringToUint64(s string) uint64 {
 	// Try to parse as number first
@@ -737,9 +1027,42 @@ func stringToUint64(s string) uint64 {
 	return hash
 }
 
+// GetTenant returns the tenant this instance is scoped to, if any
+func (q *Qdrant) GetTenant() string {
+	return q.tenant
+}
+
 // Close closes the Qdrant client connection
 func (q *Qdrant) Close() error {
 	// Qdrant go client doesn't require explicit closing
 	// This method is here for interface compatibility
 	return nil
 }
+
+// GetEmbedderInfo returns the vector size from the collection config, or nil if the
+// collection does not exist. Qdrant has no collection metadata, so the model is not recorded.
+func (q *Qdrant) GetEmbedderInfo(ctx context.Context) (*vectordb.EmbedderInfo, error) {
+	exists, err := q.collectionExists(ctx, q.collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check collection existence: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	info, err := q.client.GetCollectionInfo(ctx, q.collection)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection info: %w", err)
+	}
+
+	params := info.GetConfig().GetParams().GetVectorsConfig().GetParams()
+	if params == nil {
+		return nil, nil
+	}
+	return &vectordb.EmbedderInfo{Dimensions: int(params.GetSize())}, nil
+}
+
+// SetEmbedderInfo is a no-op: the vector size is already part of the collection config
+func (q *Qdrant) SetEmbedderInfo(ctx context.Context, info vectordb.EmbedderInfo) error {
+	return nil
+}
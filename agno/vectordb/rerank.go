@@ -0,0 +1,109 @@
+package vectordb
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RerankingConfig represents reranking configuration
+type RerankingConfig struct {
+	Enabled    bool
+	Model      string   // "cross-encoder" or "colbert"
+	TopK       int      // Number of candidates to fetch and rerank (default 3x the limit)
+	ScoreBoost float64  // Weight of the query-content overlap (default 1.2)
+	Reranker   Reranker // Reorders candidates instead of score boosting when set
+}
+
+// RerankingSearcher is implemented by vector databases that can rerank the
+// candidates of a search before returning them.
+type RerankingSearcher interface {
+	// SearchWithReranking searches for more candidates than limit and returns the best limit after reranking
+	SearchWithReranking(ctx context.Context, query string, limit int, filters map[string]interface{}, config *RerankingConfig) ([]*SearchResult, error)
+}
+
+// Searcher is the part of VectorDB that SearchWithReranking needs
+type Searcher interface {
+	Search(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*SearchResult, error)
+}
+
+// SearchWithReranking searches db for more candidates than limit, reorders
+// them with config.Reranker, or BoostRerank when it is nil, and returns the
+// best limit results. Without an enabled config it is a plain Search.
+func SearchWithReranking(ctx context.Context, db Searcher, query string, limit int, filters map[string]interface{}, config *RerankingConfig) ([]*SearchResult, error) {
+	if config == nil || !config.Enabled {
+		return db.Search(ctx, query, limit, filters)
+	}
+
+	// Get more results than needed for reranking
+	fetchLimit := limit * 3
+	if config.TopK > limit {
+		fetchLimit = config.TopK
+	}
+
+	results, err := db.Search(ctx, query, fetchLimit, filters)
+	if err != nil {
+		return nil, err
+	}
+
+	if config.Reranker != nil {
+		results, err = config.Reranker.Rerank(ctx, query, results)
+		if err != nil {
+			return nil, fmt.Errorf("failed to rerank results: %w", err)
+		}
+	} else {
+		results = BoostRerank(query, results, config)
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// BoostRerank rescores results as 70% of their search score plus 30% of the
+// word overlap between query and content, weighted by config.ScoreBoost, and
+// sorts them by the new score
+func BoostRerank(query string, results []*SearchResult, config *RerankingConfig) []*SearchResult {
+	boost := 1.2
+	if config != nil && config.ScoreBoost != 0 {
+		boost = config.ScoreBoost
+	}
+
+	for _, result := range results {
+		contentScore := ContentSimilarity(query, result.Document.Content)
+		result.Score = result.Score*0.7 + contentScore*0.3*boost
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Score > results[j].Score
+	})
+	return results
+}
+
+// ContentSimilarity is the Jaccard similarity of the words of query and content
+func ContentSimilarity(query, content string) float64 {
+	queryWords := strings.Fields(query)
+	contentWords := strings.Fields(content)
+	if len(queryWords) == 0 || len(contentWords) == 0 {
+		return 0.0
+	}
+
+	querySet := make(map[string]bool, len(queryWords))
+	for _, word := range queryWords {
+		querySet[word] = true
+	}
+
+	overlap := 0
+	for _, word := range contentWords {
+		if querySet[word] {
+			overlap++
+		}
+	}
+
+	union := len(queryWords) + len(contentWords) - overlap
+	if union == 0 {
+		return 0.0
+	}
+	return float64(overlap) / float64(union)
+}
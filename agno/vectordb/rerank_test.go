@@ -0,0 +1,137 @@
+package vectordb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+)
+
+// staticSearcher returns fixed results, copied so reranking does not leak between calls
+type staticSearcher struct {
+	results   []SearchResult
+	lastLimit int
+}
+
+func (s *staticSearcher) Search(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*SearchResult, error) {
+	s.lastLimit = limit
+	var results []*SearchResult
+	for i := range s.results {
+		if i == limit {
+			break
+		}
+		result := s.results[i]
+		results = append(results, &result)
+	}
+	return results, nil
+}
+
+func TestSearchWithReranking(t *testing.T) {
+	db := &staticSearcher{results: []SearchResult{
+		{Document: &document.Document{ID: "a", Content: "unrelated text about cooking"}, Score: 0.80},
+		{Document: &document.Document{ID: "b", Content: "vector database search"}, Score: 0.75},
+		{Document: &document.Document{ID: "c", Content: "more cooking recipes"}, Score: 0.70},
+	}}
+	ctx := context.Background()
+	query := "vector database search"
+
+	ids := func(results []*SearchResult) []string {
+		var out []string
+		for _, r := range results {
+			out = append(out, r.Document.ID)
+		}
+		return out
+	}
+
+	plain, err := SearchWithReranking(ctx, db, query, 2, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(plain); len(got) != 2 || got[0] != "a" || got[1] != "b" {
+		t.Errorf("without reranking got %v, want [a b]", got)
+	}
+
+	reranked, err := SearchWithReranking(ctx, db, query, 2, nil, &RerankingConfig{Enabled: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if db.lastLimit != 6 {
+		t.Errorf("fetched %d candidates, want 3x the limit", db.lastLimit)
+	}
+	if got := ids(reranked); len(got) != 2 || got[0] != "b" || got[1] != "a" {
+		t.Errorf("with reranking got %v, want [b a]", got)
+	}
+	// 0.75*0.7 + 1.0*0.3*1.2
+	if want := 0.885; reranked[0].Score < want-1e-9 || reranked[0].Score > want+1e-9 {
+		t.Errorf("boosted score = %v, want %v", reranked[0].Score, want)
+	}
+
+	if _, err := SearchWithReranking(ctx, db, query, 1, nil, &RerankingConfig{Enabled: true, TopK: 10}); err != nil {
+		t.Fatal(err)
+	}
+	if db.lastLimit != 10 {
+		t.Errorf("fetched %d candidates, want TopK", db.lastLimit)
+	}
+}
+
+func TestSearchWithRerankerReplacesBoost(t *testing.T) {
+	db := &staticSearcher{results: []SearchResult{
+		{Document: &document.Document{ID: "a", Content: "vector database search"}, Score: 0.9},
+		{Document: &document.Document{ID: "b", Content: "short"}, Score: 0.1},
+	}}
+	// Prefer the shortest content, which score boosting would rank last
+	reranker := &MockReranker{ScoreFunc: func(query, content string) float64 {
+		return 1 / float64(len(content))
+	}}
+
+	results, err := SearchWithReranking(context.Background(), db, "vector database search", 1, nil, &RerankingConfig{Enabled: true, Reranker: reranker})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reranker.Calls != 1 {
+		t.Errorf("reranker called %d times, want 1", reranker.Calls)
+	}
+	if len(results) != 1 || results[0].Document.ID != "b" || results[0].Score != 0.2 {
+		t.Errorf("got %+v, want b with the reranker score", results[0])
+	}
+
+	reranker.Err = errors.New("rerank failed")
+	if _, err := SearchWithReranking(context.Background(), db, "q", 1, nil, &RerankingConfig{Enabled: true, Reranker: reranker}); !errors.Is(err, reranker.Err) {
+		t.Errorf("got error %v, want the reranker error", err)
+	}
+}
+
+func TestCohereReranker(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rerank" || r.Header.Get("Authorization") != "Bearer test-key" {
+			t.Errorf("unexpected request %s with auth %q", r.URL.Path, r.Header.Get("Authorization"))
+		}
+		var request CohereRerankRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Error(err)
+		}
+		if request.Model != "rerank-v3.5" || request.Query != "q" || len(request.Documents) != 2 || request.Documents[1] != "second" {
+			t.Errorf("unexpected request body %+v", request)
+		}
+		w.Write([]byte(`{"results":[{"index":1,"relevance_score":0.9},{"index":0,"relevance_score":0.2}]}`))
+	}))
+	defer server.Close()
+
+	reranker := NewCohereReranker("test-key", "")
+	reranker.BaseURL = server.URL
+
+	results, err := reranker.Rerank(context.Background(), "q", []*SearchResult{
+		{Document: &document.Document{ID: "a", Content: "first"}, Score: 0.8},
+		{Document: &document.Document{ID: "b", Content: "second"}, Score: 0.7},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 2 || results[0].Document.ID != "b" || results[0].Score != 0.9 || results[1].Score != 0.2 {
+		t.Errorf("unexpected results %+v, %+v", results[0], results[1])
+	}
+}
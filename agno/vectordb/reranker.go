@@ -0,0 +1,53 @@
+package vectordb
+
+import (
+	"context"
+	"sort"
+)
+
+// Reranker reorders search results by their relevance to the query, e.g. with
+// a cross-encoder model. Set it on RerankingConfig to replace score boosting.
+type Reranker interface {
+	// Rerank returns results sorted by relevance, with Score set to the relevance score
+	Rerank(ctx context.Context, query string, results []*SearchResult) ([]*SearchResult, error)
+}
+
+// MockReranker is a Reranker for tests that scores results locally
+type MockReranker struct {
+	ScoreFunc func(query, content string) float64 // Default: ContentSimilarity
+	Err       error                               // Returned by Rerank when set
+	Calls     int                                 // Number of Rerank calls
+}
+
+// Rerank scores each result with ScoreFunc and sorts them by that score
+func (m *MockReranker) Rerank(ctx context.Context, query string, results []*SearchResult) ([]*SearchResult, error) {
+	m.Calls++
+	if m.Err != nil {
+		return nil, m.Err
+	}
+
+	score := m.ScoreFunc
+	if score == nil {
+		score = ContentSimilarity
+	}
+
+	reranked := make([]*SearchResult, len(results))
+	for i, result := range results {
+		r := *result
+		r.Score = score(query, resultContent(result))
+		reranked[i] = &r
+	}
+
+	sort.SliceStable(reranked, func(i, j int) bool {
+		return reranked[i].Score > reranked[j].Score
+	})
+	return reranked, nil
+}
+
+// resultContent returns the text of a result, or "" when it has no document
+func resultContent(result *SearchResult) string {
+	if result == nil || result.Document == nil {
+		return ""
+	}
+	return result.Document.Content
+}
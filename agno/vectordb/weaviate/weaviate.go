@@ -2,230 +2,655 @@ package weaviate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/devalexandre/agno-golang/agno/document"
 	"github.com/devalexandre/agno-golang/agno/embedder"
 	"github.com/devalexandre/agno-golang/agno/vectordb"
+	"github.com/go-openapi/strfmt"
+	"github.com/google/uuid"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/auth"
-	"github.com/weaviate/weaviate-go-client/v4/weaviate/entities/models"
+	"github.com/weaviate/weaviate-go-client/v4/weaviate/filters"
 	"github.com/weaviate/weaviate-go-client/v4/weaviate/graphql"
+	"github.com/weaviate/weaviate/entities/models"
 )
 
+// hybridAlpha weights vector against BM25 scores in HybridSearch, matching
+// the 70/30 split of the Qdrant and PgVector hybrid search
+const hybridAlpha = 0.7
+
+// Properties declared on every class. Metadata keys are stored as extra
+// properties created by Weaviate's auto-schema, so they can be filtered on,
+// and as JSON in "metadata" so documents round-trip unchanged.
+var documentProperties = []*models.Property{
+	{Name: "content", DataType: []string{"text"}},
+	{Name: "doc_id", DataType: []string{"text"}, Tokenization: "field"},
+	{Name: "name", DataType: []string{"text"}, Tokenization: "field"},
+	{Name: "content_type", DataType: []string{"text"}, Tokenization: "field"},
+	{Name: "source", DataType: []string{"text"}, Tokenization: "field"},
+	{Name: "parent_id", DataType: []string{"text"}, Tokenization: "field"},
+	{Name: "chunk_index", DataType: []string{"int"}},
+	{Name: "chunk_total", DataType: []string{"int"}},
+	{Name: "metadata", DataType: []string{"text"}, IndexFilterable: boolPtr(false), IndexSearchable: boolPtr(false)},
+}
+
+// invalidNameChars matches characters not allowed in class and property names
+var invalidNameChars = regexp.MustCompile(`[^_0-9A-Za-z]`)
+
+// Weaviate implements VectorDB on a Weaviate class, one class per collection
 type Weaviate struct {
 	*vectordb.BaseVectorDB
 	client    *weaviate.Client
 	className string
 }
 
+// WeaviateConfig holds configuration for Weaviate
 type WeaviateConfig struct {
-	Host      string
-	Scheme    string
-	APIKey    string
+	Host       string // Default "localhost:8080"
+	Scheme     string // Default "http"
+	APIKey     string // Optional, for Weaviate Cloud
+	Collection string // Class name, capitalized as Weaviate requires (default "Documents")
+	Embedder   embedder.Embedder
+	SearchType vectordb.SearchType
+	Distance   vectordb.Distance // Default DistanceCosine
+
+	// Deprecated: use Collection
 	ClassName string
-	Embedder  embedder.Embedder
-	Distance  vectordb.Distance
 }
 
+// NewWeaviate creates a new Weaviate instance
 func NewWeaviate(config WeaviateConfig) (*Weaviate, error) {
 	cfg := weaviate.Config{
 		Host:   config.Host,
 		Scheme: config.Scheme,
 	}
+	if cfg.Host == "" {
+		cfg.Host = "localhost:8080"
+	}
+	if cfg.Scheme == "" {
+		cfg.Scheme = "http"
+	}
 
 	if config.APIKey != "" {
 		cfg.AuthConfig = auth.ApiKey{Value: config.APIKey}
 	}
 
+	collection := config.Collection
+	if collection == "" {
+		collection = config.ClassName
+	}
+	if collection == "" {
+		collection = "Documents"
+	}
+
+	searchType := config.SearchType
+	if searchType == "" {
+		searchType = vectordb.SearchTypeVector
+	}
+
+	distance := config.Distance
+	if distance == "" {
+		distance = vectordb.DistanceCosine
+	}
+	if _, err := weaviateDistance(distance); err != nil {
+		return nil, err
+	}
+
 	c, err := weaviate.NewClient(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to weaviate: %w", err)
 	}
 
-	if config.Distance == "" {
-		config.Distance = vectordb.DistanceCosine
-	}
-
 	return &Weaviate{
-		BaseVectorDB: vectordb.NewBaseVectorDB(config.Embedder, vectordb.SearchTypeVector, config.Distance),
+		BaseVectorDB: vectordb.NewBaseVectorDB(config.Embedder, searchType, distance),
 		client:       c,
-		className:    config.ClassName,
+		className:    className(collection),
 	}, nil
 }
 
-func (w *Weaviate) Create(ctx context.Context) error {
-	dist := "cosine"
-	switch w.Distance {
-	case vectordb.DistanceL2:
-		dist = "l2-squared"
+// weaviateDistance maps a distance to the Weaviate vector index distance
+func weaviateDistance(distance vectordb.Distance) (string, error) {
+	switch vectordb.NormalizeDistance(distance) {
 	case vectordb.DistanceCosine:
-		dist = "cosine"
-	case vectordb.DistanceDot:
-		dist = "dot"
+		return "cosine", nil
+	case vectordb.DistanceL2:
+		return "l2-squared", nil
+	case vectordb.DistanceMaxInnerProduct:
+		return "dot", nil
+	default:
+		return "", fmt.Errorf("distance %q is not supported by weaviate", distance)
+	}
+}
+
+// Create creates the class of the collection, storing vectors provided by
+// the embedder
+func (w *Weaviate) Create(ctx context.Context) error {
+	exists, err := w.Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check class existence: %w", err)
+	}
+	if exists {
+		return nil // Class already exists
+	}
+
+	dist, err := weaviateDistance(w.Distance)
+	if err != nil {
+		return err
 	}
 
 	classObj := &models.Class{
 		Class:      w.className,
 		Vectorizer: "none",
-		VectorConfig: map[string]interface{}{
+		VectorIndexConfig: map[string]interface{}{
 			"distance": dist,
 		},
-		Properties: []*models.Property{
-			{
-				Name:     "content",
-				DataType: []string{"text"},
-			},
-			{
-				Name:     "metadata",
-				DataType: []string{"text"}, // Storing as JSON string for now
-			},
-			{
-				Name:     "name",
-				DataType: []string{"string"},
-			},
-		},
+		Properties: documentProperties,
 	}
 
-	return w.client.Schema().ClassCreator().WithClass(classObj).Do(ctx)
+	if err := w.client.Schema().ClassCreator().WithClass(classObj).Do(ctx); err != nil {
+		return fmt.Errorf("failed to create class: %w", err)
+	}
+	return nil
 }
 
+// Exists checks if the class exists
 func (w *Weaviate) Exists(ctx context.Context) (bool, error) {
 	return w.client.Schema().ClassExistenceChecker().WithClassName(w.className).Do(ctx)
 }
 
+// Drop deletes the class and all its objects
 func (w *Weaviate) Drop(ctx context.Context) error {
+	exists, err := w.Exists(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to check class existence: %w", err)
+	}
+	if !exists {
+		return nil // Class doesn't exist
+	}
 	return w.client.Schema().ClassDeleter().WithClassName(w.className).Do(ctx)
 }
 
+// Optimize is a no-op; Weaviate maintains its indexes itself
 func (w *Weaviate) Optimize(ctx context.Context) error {
 	return nil
 }
 
+// Insert adds documents, creating the class if needed. Filters are stored as
+// metadata of every document.
 func (w *Weaviate) Insert(ctx context.Context, documents []*document.Document, filters map[string]interface{}) error {
-	if err := w.EmbedDocuments(documents); err != nil {
+	return w.Upsert(ctx, documents, filters)
+}
+
+// Upsert inserts or replaces documents by ID, creating the class if needed.
+// Weaviate replaces objects imported with an existing ID.
+func (w *Weaviate) Upsert(ctx context.Context, documents []*document.Document, filters map[string]interface{}) error {
+	if len(documents) == 0 {
+		return nil
+	}
+
+	if err := w.Create(ctx); err != nil {
 		return err
 	}
 
+	if err := w.EmbedDocuments(documents); err != nil {
+		return fmt.Errorf("failed to embed documents: %w", err)
+	}
+
 	objects := make([]*models.Object, len(documents))
 	for i, doc := range documents {
-		v32 := make([]float32, len(doc.Embeddings))
-		for j, v := range doc.Embeddings {
-			v32[j] = float32(v)
+		properties, err := documentToProperties(doc, filters)
+		if err != nil {
+			return err
 		}
-
 		objects[i] = &models.Object{
-			Class: w.className,
-			ID:    strToUUID(doc.ID),
-			Properties: map[string]interface{}{
-				"content":  doc.Content,
-				"metadata": doc.Metadata, // Weaviate client handles maps in properties
-				"name":     doc.Name,
-			},
-			Vector: v32,
+			Class:      w.className,
+			ID:         strfmt.UUID(documentUUID(doc.ID)),
+			Properties: properties,
+			Vector:     toFloat32(doc.Embeddings),
 		}
 	}
 
-	_, err := w.client.Batch().ObjectsBatcher().WithObjects(objects...).Do(ctx)
-	return err
-}
-
-func (w *Weaviate) Upsert(ctx context.Context, documents []*document.Document, filters map[string]interface{}) error {
-	// Simple implementation: Batch insert handles upsert if ID is provided in some cases,
-	// but strictly we should check or use the specific upsert logic if available.
-	return w.Insert(ctx, documents, filters)
+	responses, err := w.client.Batch().ObjectsBatcher().WithObjects(objects...).Do(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to import documents: %w", err)
+	}
+	for _, res := range responses {
+		if res.Result != nil && res.Result.Errors != nil && len(res.Result.Errors.Error) > 0 {
+			return fmt.Errorf("failed to import document %s: %s", res.ID, res.Result.Errors.Error[0].Message)
+		}
+	}
+	return nil
 }
 
+// Search performs search based on the configured search type
 func (w *Weaviate) Search(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
-	return w.VectorSearch(ctx, query, limit, filters)
+	switch w.SearchType {
+	case vectordb.SearchTypeKeyword:
+		return w.KeywordSearch(ctx, query, limit, filters)
+	case vectordb.SearchTypeHybrid:
+		return w.HybridSearch(ctx, query, limit, filters)
+	default:
+		return w.VectorSearch(ctx, query, limit, filters)
+	}
 }
 
+// VectorSearch performs vector similarity search
 func (w *Weaviate) VectorSearch(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
 	vector, err := w.EmbedQuery(query)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("failed to embed query: %w", err)
 	}
 
-	v32 := make([]float32, len(vector))
-	for i, v := range vector {
-		v32[i] = float32(v)
-	}
+	return w.SearchByVector(ctx, vector, limit, filters)
+}
 
-	result, err := w.client.GraphQL().Get().
+// SearchByVector returns the documents closest to queryEmbedding
+func (w *Weaviate) SearchByVector(ctx context.Context, queryEmbedding []float64, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	nearVector := w.client.GraphQL().NearVectorArgBuilder().WithVector(toFloat32(queryEmbedding))
+	get := w.client.GraphQL().Get().
 		WithClassName(w.className).
-		WithFields(graphql.Field{Name: "content"}, graphql.Field{Name: "metadata"}, graphql.Field{Name: "name"}, graphql.Field{Name: "_additional", Fields: []graphql.Field{{Name: "distance"}, {Name: "id"}}}).
-		WithNearVector(w.client.GraphQL().NearVectorArgBuilder().WithVector(v32)).
-		WithLimit(limit).
-		Do(ctx)
+		WithFields(resultFields("distance")...).
+		WithNearVector(nearVector).
+		WithLimit(limit)
 
-	if err != nil {
-		return nil, err
-	}
+	return w.runSearch(ctx, get, filters)
+}
 
-	return w.parseGraphQLResponse(result)
+// KeywordSearch performs BM25 search on the document content
+func (w *Weaviate) KeywordSearch(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	bm25 := w.client.GraphQL().Bm25ArgBuilder().WithQuery(query).WithProperties("content")
+	get := w.client.GraphQL().Get().
+		WithClassName(w.className).
+		WithFields(resultFields("score")...).
+		WithBM25(bm25).
+		WithLimit(limit)
+
+	return w.runSearch(ctx, get, filters)
 }
 
-func (w *Weaviate) parseGraphQLResponse(res *models.GraphQLResponse) ([]*vectordb.SearchResult, error) {
-	if res.Errors != nil {
-		return nil, fmt.Errorf("graphql error: %v", res.Errors)
+// HybridSearch combines vector and BM25 search with Weaviate's native hybrid
+// search, weighting vector results at 70% like the other backends
+func (w *Weaviate) HybridSearch(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	vector, err := w.EmbedQuery(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to embed query: %w", err)
 	}
 
-	data := res.Data["Get"].(map[string]interface{})[w.className].([]interface{})
-	results := make([]*vectordb.SearchResult, 0, len(data))
+	hybrid := w.client.GraphQL().HybridArgumentBuilder().
+		WithQuery(query).
+		WithVector(toFloat32(vector)).
+		WithAlpha(hybridAlpha).
+		WithProperties([]string{"content"})
+	get := w.client.GraphQL().Get().
+		WithClassName(w.className).
+		WithFields(resultFields("score")...).
+		WithHybrid(hybrid).
+		WithLimit(limit)
 
-	for _, item := range data {
-		m := item.(map[string]interface{})
-		additional := m["_additional"].(map[string]interface{})
+	return w.runSearch(ctx, get, filters)
+}
 
-		results = append(results, &vectordb.SearchResult{
-			Document: &document.Document{
-				ID:      additional["id"].(string),
-				Content: m["content"].(string),
-				Name:    m["name"].(string),
-			},
-			Score:    1.0 - additional["distance"].(float64), // Convert distance to score
-			Distance: additional["distance"].(float64),
-		})
+// runSearch applies filters to a Get query and converts its results
+func (w *Weaviate) runSearch(ctx context.Context, get *graphql.GetBuilder, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
+	where, err := buildWhereFilter(filters)
+	if err != nil {
+		return nil, err
+	}
+	if where != nil {
+		get = get.WithWhere(where)
 	}
 
-	return results, nil
-}
-
-func (w *Weaviate) KeywordSearch(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
-	return nil, fmt.Errorf("keyword search not implemented for weaviate")
-}
+	res, err := get.Do(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search class: %w", err)
+	}
 
-func (w *Weaviate) HybridSearch(ctx context.Context, query string, limit int, filters map[string]interface{}) ([]*vectordb.SearchResult, error) {
-	return nil, fmt.Errorf("hybrid search not implemented for weaviate")
+	items, err := w.getResults(res)
+	if err != nil {
+		return nil, err
+	}
+	return w.parseResults(items)
 }
 
+// GetCount returns the number of documents in the class
 func (w *Weaviate) GetCount(ctx context.Context) (int64, error) {
-	result, err := w.client.GraphQL().Aggregate().
+	res, err := w.client.GraphQL().Aggregate().
 		WithClassName(w.className).
 		WithFields(graphql.Field{Name: "meta", Fields: []graphql.Field{{Name: "count"}}}).
 		Do(ctx)
 	if err != nil {
-		return 0, err
+		return 0, fmt.Errorf("failed to count documents: %w", err)
+	}
+	if err := graphQLError(res); err != nil {
+		return 0, fmt.Errorf("failed to count documents: %w", err)
 	}
 
-	// Parsing aggregate response is nested
-	return 0, fmt.Errorf("aggregate parsing not implemented")
+	aggregate, _ := res.Data["Aggregate"].(map[string]interface{})
+	groups, _ := aggregate[w.className].([]interface{})
+	if len(groups) == 0 {
+		return 0, nil
+	}
+	group, _ := groups[0].(map[string]interface{})
+	meta, _ := group["meta"].(map[string]interface{})
+	count, _ := meta["count"].(float64)
+	return int64(count), nil
 }
 
+// DocExists checks if a document exists by ID
 func (w *Weaviate) DocExists(ctx context.Context, doc *document.Document) (bool, error) {
 	return w.IDExists(ctx, doc.ID)
 }
 
+// NameExists checks if a document with the given name exists
 func (w *Weaviate) NameExists(ctx context.Context, name string) (bool, error) {
-	return false, nil
+	where, err := buildWhereFilter(map[string]interface{}{"name": name})
+	if err != nil {
+		return false, err
+	}
+
+	res, err := w.client.GraphQL().Get().
+		WithClassName(w.className).
+		WithFields(graphql.Field{Name: "_additional", Fields: []graphql.Field{{Name: "id"}}}).
+		WithWhere(where).
+		WithLimit(1).
+		Do(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to query class: %w", err)
+	}
+
+	items, err := w.getResults(res)
+	if err != nil {
+		return false, err
+	}
+	return len(items) > 0, nil
 }
 
+// IDExists checks if a document with the given ID exists
 func (w *Weaviate) IDExists(ctx context.Context, id string) (bool, error) {
-	return w.client.Data().Checker().WithClassName(w.className).WithID(strToUUID(id)).Do(ctx)
+	return w.client.Data().Checker().WithClassName(w.className).WithID(documentUUID(id)).Do(ctx)
+}
+
+// getResults returns the objects of a Get query response
+func (w *Weaviate) getResults(res *models.GraphQLResponse) ([]interface{}, error) {
+	if err := graphQLError(res); err != nil {
+		return nil, err
+	}
+
+	get, _ := res.Data["Get"].(map[string]interface{})
+	items, _ := get[w.className].([]interface{})
+	return items, nil
+}
+
+// parseResults converts Get query objects to search results. Vector search
+// reports a distance, BM25 and hybrid search a score.
+func (w *Weaviate) parseResults(items []interface{}) ([]*vectordb.SearchResult, error) {
+	results := make([]*vectordb.SearchResult, 0, len(items))
+	for _, item := range items {
+		properties, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		doc, err := propertiesToDocument(properties)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &vectordb.SearchResult{Document: doc}
+		additional, _ := properties["_additional"].(map[string]interface{})
+		if distance, ok := additional["distance"].(float64); ok {
+			result.Score, result.Distance = w.scoreFromDistance(distance)
+		} else if score, ok := additional["score"].(string); ok {
+			result.Score, _ = strconv.ParseFloat(score, 64)
+			result.Distance = 1.0 - result.Score
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// scoreFromDistance converts a Weaviate distance to a score where higher is better
+func (w *Weaviate) scoreFromDistance(distance float64) (float64, float64) {
+	switch vectordb.NormalizeDistance(w.Distance) {
+	case vectordb.DistanceL2:
+		return 1.0 / (1.0 + distance), distance
+	case vectordb.DistanceMaxInnerProduct:
+		return -distance, distance // Weaviate reports the negative dot product
+	default:
+		return 1.0 - distance, distance
+	}
+}
+
+// graphQLError returns the errors of a GraphQL response as an error
+func graphQLError(res *models.GraphQLResponse) error {
+	if res == nil || len(res.Errors) == 0 {
+		return nil
+	}
+	messages := make([]string, 0, len(res.Errors))
+	for _, e := range res.Errors {
+		messages = append(messages, e.Message)
+	}
+	return fmt.Errorf("graphql error: %s", strings.Join(messages, "; "))
+}
+
+// resultFields returns the fields requested from search results, with the
+// given _additional relevance field
+func resultFields(relevance string) []graphql.Field {
+	fields := make([]graphql.Field, 0, len(documentProperties)+1)
+	for _, property := range documentProperties {
+		fields = append(fields, graphql.Field{Name: property.Name})
+	}
+	return append(fields, graphql.Field{Name: "_additional", Fields: []graphql.Field{{Name: "id"}, {Name: relevance}}})
+}
+
+// buildWhereFilter translates metadata filters into a Weaviate where filter.
+// Slices match any of their values. It returns nil without filters.
+func buildWhereFilter(metadataFilters map[string]interface{}) (*filters.WhereBuilder, error) {
+	keys := make([]string, 0, len(metadataFilters))
+	for key := range metadataFilters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	operands := make([]*filters.WhereBuilder, 0, len(keys))
+	for _, key := range keys {
+		property := propertyName(key)
+		if key == "id" {
+			property = "doc_id" // The object ID is a UUID derived from the document ID
+		}
+		condition, err := whereCondition(property, metadataFilters[key])
+		if err != nil {
+			return nil, fmt.Errorf("invalid filter %q: %w", key, err)
+		}
+		operands = append(operands, condition)
+	}
+
+	switch len(operands) {
+	case 0:
+		return nil, nil
+	case 1:
+		return operands[0], nil
+	default:
+		return filters.Where().WithOperator(filters.And).WithOperands(operands), nil
+	}
+}
+
+// whereCondition matches property against value, or any element of a slice value
+func whereCondition(property string, value interface{}) (*filters.WhereBuilder, error) {
+	where := filters.Where().WithPath([]string{property}).WithOperator(filters.Equal)
+
+	switch v := value.(type) {
+	case string:
+		return where.WithValueText(v), nil
+	case []string:
+		return where.WithOperator(filters.ContainsAny).WithValueText(v...), nil
+	case bool:
+		return where.WithValueBoolean(v), nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("only string lists are supported, got %T", item)
+			}
+			values = append(values, s)
+		}
+		return where.WithOperator(filters.ContainsAny).WithValueText(values...), nil
+	}
+
+	number, ok := toFloat64(value)
+	if !ok {
+		return nil, fmt.Errorf("unsupported value type %T", value)
+	}
+	if property == "chunk_index" || property == "chunk_total" {
+		return where.WithValueInt(int64(number)), nil
+	}
+	// Auto-schema stores JSON numbers as "number" properties
+	return where.WithValueNumber(number), nil
+}
+
+// documentToProperties returns the object properties stored for doc
+func documentToProperties(doc *document.Document, filters map[string]interface{}) (map[string]interface{}, error) {
+	metadata := make(map[string]interface{}, len(doc.Metadata)+len(filters))
+	for k, v := range doc.Metadata {
+		metadata[k] = v
+	}
+	for k, v := range filters {
+		metadata[k] = v
+	}
+
+	metaJSON, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal metadata of document %s: %w", doc.ID, err)
+	}
+
+	properties := make(map[string]interface{}, len(metadata)+len(documentProperties))
+	for k, v := range metadata {
+		if isFilterable(v) {
+			properties[propertyName(k)] = v
+		}
+	}
+	// Document fields take precedence over metadata with the same name
+	properties["content"] = doc.Content
+	properties["doc_id"] = doc.ID
+	properties["name"] = doc.Name
+	properties["content_type"] = doc.ContentType
+	properties["source"] = doc.Source
+	properties["parent_id"] = doc.ParentID
+	properties["chunk_index"] = doc.ChunkIndex
+	properties["chunk_total"] = doc.ChunkTotal
+	properties["metadata"] = string(metaJSON)
+
+	return properties, nil
+}
+
+// propertiesToDocument rebuilds a document from object properties
+func propertiesToDocument(properties map[string]interface{}) (*document.Document, error) {
+	doc := &document.Document{}
+	doc.Content, _ = properties["content"].(string)
+	doc.ID, _ = properties["doc_id"].(string)
+	doc.Name, _ = properties["name"].(string)
+	doc.ContentType, _ = properties["content_type"].(string)
+	doc.Source, _ = properties["source"].(string)
+	doc.ParentID, _ = properties["parent_id"].(string)
+	if n, ok := properties["chunk_index"].(float64); ok {
+		doc.ChunkIndex = int(n)
+	}
+	if n, ok := properties["chunk_total"].(float64); ok {
+		doc.ChunkTotal = int(n)
+	}
+
+	if doc.ID == "" {
+		additional, _ := properties["_additional"].(map[string]interface{})
+		doc.ID, _ = additional["id"].(string)
+	}
+
+	if metaJSON, _ := properties["metadata"].(string); metaJSON != "" {
+		if err := json.Unmarshal([]byte(metaJSON), &doc.Metadata); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal metadata of document %s: %w", doc.ID, err)
+		}
+	}
+
+	return doc, nil
+}
+
+// isFilterable reports whether a metadata value is stored as its own property
+func isFilterable(value interface{}) bool {
+	switch v := value.(type) {
+	case string, bool, []string:
+		return true
+	case []interface{}:
+		for _, item := range v {
+			if _, ok := item.(string); !ok {
+				return false
+			}
+		}
+		return true
+	}
+	_, ok := toFloat64(value)
+	return ok
+}
+
+func toFloat64(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// className converts a collection name to a valid Weaviate class name,
+// which must start with an uppercase letter
+func className(collection string) string {
+	name := invalidNameChars.ReplaceAllString(collection, "_")
+	if name == "" || !(name[0] >= 'A' && name[0] <= 'Z' || name[0] >= 'a' && name[0] <= 'z') {
+		name = "C" + name
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// propertyName converts a metadata key to a valid Weaviate property name
+func propertyName(key string) string {
+	name := invalidNameChars.ReplaceAllString(key, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "_" + name
+	}
+	if name == "id" || name == "_id" || name == "_additional" {
+		name = "meta_" + name
+	}
+	return name
+}
+
+// documentUUID returns id if it is a UUID, or a UUID derived from it, since
+// Weaviate object IDs must be UUIDs
+func documentUUID(id string) string {
+	if parsed, err := uuid.Parse(id); err == nil {
+		return parsed.String()
+	}
+	return uuid.NewSHA1(uuid.NameSpaceOID, []byte(id)).String()
+}
+
+func toFloat32(vector []float64) []float32 {
+	v32 := make([]float32, len(vector))
+	for i, v := range vector {
+		v32[i] = float32(v)
+	}
+	return v32
 }
 
-func strToUUID(id string) string {
-	// Weaviate requires UUIDs. If the provided ID is not a UUID, we should hash it or similar.
-	// For this implementation, we assume it's already a UUID or handled by the user.
-	return id
+func boolPtr(b bool) *bool {
+	return &b
 }
@@ -0,0 +1,100 @@
+package weaviate
+
+import (
+	"testing"
+
+	"github.com/devalexandre/agno-golang/agno/document"
+	"github.com/devalexandre/agno-golang/agno/vectordb"
+)
+
+func TestBuildWhereFilter(t *testing.T) {
+	where, err := buildWhereFilter(map[string]interface{}{
+		"category": "AI",
+		"year":     2024,
+		"tags":     []string{"go", "rag"},
+		"id":       "doc-1",
+	})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	filter := where.Build()
+	if filter.Operator != "And" || len(filter.Operands) != 4 {
+		t.Fatalf("Expected an And of 4 conditions, got: %+v", filter)
+	}
+	category, id, tags, year := filter.Operands[0], filter.Operands[1], filter.Operands[2], filter.Operands[3]
+	if category.Path[0] != "category" || category.Operator != "Equal" || *category.ValueText != "AI" {
+		t.Errorf("Unexpected category condition: %+v", category)
+	}
+	if id.Path[0] != "doc_id" || *id.ValueText != "doc-1" {
+		t.Errorf("Expected the id filter on doc_id, got: %+v", id)
+	}
+	if tags.Operator != "ContainsAny" || len(tags.ValueTextArray) != 2 {
+		t.Errorf("Expected a ContainsAny condition for a list, got: %+v", tags)
+	}
+	if year.Path[0] != "year" || *year.ValueNumber != 2024 {
+		t.Errorf("Unexpected year condition: %+v", year)
+	}
+
+	if where, _ := buildWhereFilter(nil); where != nil {
+		t.Errorf("Expected no where filter without filters")
+	}
+	if _, err := buildWhereFilter(map[string]interface{}{"nested": map[string]interface{}{}}); err == nil {
+		t.Errorf("Expected an error for an unsupported filter value")
+	}
+}
+
+func TestDocumentProperties(t *testing.T) {
+	doc := &document.Document{
+		ID:         "doc-1",
+		Name:       "guide",
+		Content:    "Weaviate guide",
+		Source:     "docs",
+		ChunkIndex: 2,
+		Metadata:   map[string]interface{}{"my-key": "value", "nested": map[string]interface{}{"a": 1.0}},
+	}
+
+	properties, err := documentToProperties(doc, map[string]interface{}{"tenant": "acme"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+	if properties["my_key"] != "value" || properties["tenant"] != "acme" || properties["nested"] != nil {
+		t.Fatalf("Expected scalar metadata and filters as properties, got: %v", properties)
+	}
+
+	// Weaviate returns numbers as float64
+	properties["chunk_index"] = 2.0
+	properties["_additional"] = map[string]interface{}{"id": documentUUID(doc.ID), "distance": 0.25}
+	w := &Weaviate{BaseVectorDB: vectordb.NewBaseVectorDB(nil, vectordb.SearchTypeVector, vectordb.DistanceCosine)}
+	results, err := w.parseResults([]interface{}{properties})
+	if err != nil || len(results) != 1 {
+		t.Fatalf("Expected one result, got %v (%v)", results, err)
+	}
+
+	got := results[0]
+	if got.Document.ID != "doc-1" || got.Document.Name != "guide" || got.Document.ChunkIndex != 2 ||
+		got.Document.Metadata["tenant"] != "acme" || got.Document.Metadata["nested"] == nil {
+		t.Errorf("Document did not round-trip: %+v", got.Document)
+	}
+	if got.Score != 0.75 || got.Distance != 0.25 {
+		t.Errorf("Expected score 0.75 and distance 0.25, got %f and %f", got.Score, got.Distance)
+	}
+}
+
+func TestNames(t *testing.T) {
+	if got := className("my-docs"); got != "My_docs" {
+		t.Errorf("Expected My_docs, got %s", got)
+	}
+	if got := className("2024"); got != "C2024" {
+		t.Errorf("Expected C2024, got %s", got)
+	}
+	if got := propertyName("id"); got != "meta_id" {
+		t.Errorf("Expected meta_id, got %s", got)
+	}
+	if documentUUID("doc-1") != documentUUID("doc-1") || documentUUID("doc-1") == documentUUID("doc-2") {
+		t.Errorf("Expected stable, distinct UUIDs for document IDs")
+	}
+	if id := "123e4567-e89b-12d3-a456-426614174000"; documentUUID(id) != id {
+		t.Errorf("Expected UUID document IDs to be kept")
+	}
+}
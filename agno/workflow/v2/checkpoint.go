@@ -0,0 +1,232 @@
+package v2
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// WorkflowState is the persisted progress of one workflow run. It is saved
+// after every completed step so the run can be resumed after a crash.
+// Message and Content values are stored as JSON, so custom types come back
+// as maps after a resume.
+type WorkflowState struct {
+	WorkflowID  string                  `json:"workflow_id"`
+	RunID       string                  `json:"run_id"`
+	Status      RunStatus               `json:"status"`
+	Input       *WorkflowExecutionInput `json:"input"`
+	NextStepIdx int                     `json:"next_step_idx"`
+	StepOutputs map[string]*StepOutput  `json:"step_outputs"`
+	LastOutput  *StepOutput             `json:"last_output,omitempty"`
+	CreatedAt   time.Time               `json:"created_at"`
+	UpdatedAt   time.Time               `json:"updated_at"`
+}
+
+// WorkflowStore persists workflow run state keyed by workflow ID and run ID
+type WorkflowStore interface {
+	SaveState(ctx context.Context, state *WorkflowState) error
+	// LoadState returns an error when the run has no saved state
+	LoadState(ctx context.Context, workflowID, runID string) (*WorkflowState, error)
+}
+
+// runCheckpoint is the store and state of a run started by RunWithCheckpoint
+// or ResumeWorkflow
+type runCheckpoint struct {
+	store WorkflowStore
+	state *WorkflowState
+}
+
+// RunWithCheckpoint runs the workflow like Run and saves its state to store
+// after each completed step. If the process dies, pass the response RunID
+// (also available as w.RunID once the run starts) to ResumeWorkflow.
+func (w *Workflow) RunWithCheckpoint(ctx context.Context, input interface{}, store WorkflowStore) (*WorkflowRunResponse, error) {
+	if store == nil {
+		return nil, fmt.Errorf("workflow store is nil")
+	}
+	if err := w.validateInput(input); err != nil {
+		return nil, fmt.Errorf("input validation failed: %w", err)
+	}
+
+	now := time.Now()
+	state := &WorkflowState{
+		WorkflowID:  w.WorkflowID,
+		RunID:       GenerateID(),
+		Status:      RunStatusRunning,
+		Input:       w.createExecutionInput(input),
+		StepOutputs: make(map[string]*StepOutput),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	if err := store.SaveState(ctx, state); err != nil {
+		return nil, fmt.Errorf("failed to save workflow state: %w", err)
+	}
+
+	return w.runCheckpointed(ctx, &runCheckpoint{store: store, state: state})
+}
+
+// ResumeWorkflow continues the run runID of this workflow from its first
+// incomplete step, reusing the saved outputs of the completed ones. Function
+// workflows have no steps to skip and run again from the start.
+func (w *Workflow) ResumeWorkflow(ctx context.Context, runID string, store WorkflowStore) (*WorkflowRunResponse, error) {
+	if store == nil {
+		return nil, fmt.Errorf("workflow store is nil")
+	}
+
+	state, err := store.LoadState(ctx, w.WorkflowID, runID)
+	if err != nil {
+		return nil, err
+	}
+	if state.Status == RunStatusCompleted {
+		return nil, fmt.Errorf("workflow run already completed: %s", runID)
+	}
+	if state.StepOutputs == nil {
+		state.StepOutputs = make(map[string]*StepOutput)
+	}
+	if state.Input == nil {
+		state.Input = &WorkflowExecutionInput{}
+	}
+
+	return w.runCheckpointed(ctx, &runCheckpoint{store: store, state: state})
+}
+
+// runCheckpointed executes the run described by checkpoint and records its
+// final status
+func (w *Workflow) runCheckpointed(ctx context.Context, checkpoint *runCheckpoint) (*WorkflowRunResponse, error) {
+	w.checkpoint = checkpoint
+	defer func() { w.checkpoint = nil }()
+
+	response, err := w.run(ctx, checkpoint.state.Input, checkpoint.state)
+
+	state := checkpoint.state
+	state.Status = response.Status
+	state.UpdatedAt = time.Now()
+	if saveErr := checkpoint.store.SaveState(ctx, state); saveErr != nil && err == nil {
+		err = fmt.Errorf("failed to save workflow state: %w", saveErr)
+	}
+	return response, err
+}
+
+// resumedOutput returns the output of the last step completed before a
+// resume, so the next step still receives it as PreviousStepContent
+func (w *Workflow) resumedOutput() *StepOutput {
+	if w.checkpoint == nil {
+		return nil
+	}
+	return w.checkpoint.state.LastOutput
+}
+
+// saveCheckpoint records that every step before nextStepIdx has completed,
+// output being the last one's. Durable workflows save a session checkpoint
+// to Storage; runs started by RunWithCheckpoint save their state, and a
+// failure to do so is returned.
+func (w *Workflow) saveCheckpoint(ctx context.Context, nextStepIdx int, output *StepOutput) error {
+	if w.Durable && w.Storage != nil && w.SessionID != "" {
+		w.mu.RLock()
+		checkpoint := &WorkflowCheckpoint{
+			SessionID:   w.SessionID,
+			RunID:       w.RunID,
+			NextStepIdx: nextStepIdx,
+			StepOutputs: make(map[string]*StepOutput),
+			UpdatedAt:   time.Now(),
+		}
+		for k, v := range w.stepOutputs {
+			checkpoint.StepOutputs[k] = v
+		}
+		w.mu.RUnlock()
+		w.Storage.SaveCheckpoint(ctx, w.SessionID, checkpoint)
+	}
+
+	if w.checkpoint == nil {
+		return nil
+	}
+	state := w.checkpoint.state
+	w.mu.RLock()
+	for k, v := range w.stepOutputs {
+		state.StepOutputs[k] = v
+	}
+	w.mu.RUnlock()
+	state.NextStepIdx = nextStepIdx
+	state.LastOutput = output
+	state.UpdatedAt = time.Now()
+	if err := w.checkpoint.store.SaveState(ctx, state); err != nil {
+		return fmt.Errorf("failed to save workflow state: %w", err)
+	}
+	return nil
+}
+
+// SQLiteWorkflowStore is a WorkflowStore backed by the workflow_states table
+// of a SQLite database
+type SQLiteWorkflowStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteWorkflowStore creates the workflow_states table if needed
+func NewSQLiteWorkflowStore(db *sql.DB) (*SQLiteWorkflowStore, error) {
+	if db == nil {
+		return nil, fmt.Errorf("database connection is nil")
+	}
+
+	schema := `
+	CREATE TABLE IF NOT EXISTS workflow_states (
+		workflow_id TEXT NOT NULL,
+		run_id TEXT NOT NULL,
+		status TEXT NOT NULL,
+		state TEXT NOT NULL,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL,
+		PRIMARY KEY (workflow_id, run_id)
+	)`
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("failed to create workflow_states table: %w", err)
+	}
+
+	return &SQLiteWorkflowStore{db: db}, nil
+}
+
+// SaveState inserts or replaces the state of a run
+func (s *SQLiteWorkflowStore) SaveState(ctx context.Context, state *WorkflowState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("failed to marshal workflow state: %w", err)
+	}
+
+	query := `
+		INSERT OR REPLACE INTO workflow_states (workflow_id, run_id, status, state, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	_, err = s.db.ExecContext(ctx, query,
+		state.WorkflowID,
+		state.RunID,
+		string(state.Status),
+		string(data),
+		state.CreatedAt,
+		state.UpdatedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save workflow state: %w", err)
+	}
+	return nil
+}
+
+// LoadState returns the saved state of a run
+func (s *SQLiteWorkflowStore) LoadState(ctx context.Context, workflowID, runID string) (*WorkflowState, error) {
+	var data string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT state FROM workflow_states WHERE workflow_id = ? AND run_id = ?`,
+		workflowID, runID,
+	).Scan(&data)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("workflow state not found: %s", runID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load workflow state: %w", err)
+	}
+
+	var state WorkflowState
+	if err := json.Unmarshal([]byte(data), &state); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal workflow state: %w", err)
+	}
+	return &state, nil
+}
@@ -0,0 +1,113 @@
+package v2
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func newTestWorkflowStore(t *testing.T) *SQLiteWorkflowStore {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatalf("Failed to open test database: %v", err)
+	}
+	// Every connection to :memory: is a separate database
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	store, err := NewSQLiteWorkflowStore(db)
+	if err != nil {
+		t.Fatalf("Failed to create workflow store: %v", err)
+	}
+	return store
+}
+
+func TestRunWithCheckpointAndResume(t *testing.T) {
+	store := newTestWorkflowStore(t)
+	ctx := context.Background()
+
+	step1 := func(input *StepInput) (*StepOutput, error) {
+		return &StepOutput{Content: "plan for " + input.GetMessageAsString(), StepName: "plan"}, nil
+	}
+	step3 := func(input *StepInput) (*StepOutput, error) {
+		return &StepOutput{Content: fmt.Sprintf("reviewed %v", input.PreviousStepContent), StepName: "review"}, nil
+	}
+
+	// First run: the code step fails, as if the process had died there
+	crashing := NewWorkflow(
+		WithWorkflowID("coder"),
+		WithWorkflowSteps([]interface{}{
+			step1,
+			func(input *StepInput) (*StepOutput, error) {
+				return nil, fmt.Errorf("process killed")
+			},
+			step3,
+		}),
+	)
+	resp, err := crashing.RunWithCheckpoint(ctx, "login page", store)
+	if err == nil {
+		t.Fatal("Expected the first run to fail")
+	}
+	runID := resp.RunID
+
+	state, err := store.LoadState(ctx, "coder", runID)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if state.Status != RunStatusFailed || state.NextStepIdx != 1 {
+		t.Errorf("Expected a failed run resuming at step 1, got %s at %d", state.Status, state.NextStepIdx)
+	}
+	if state.StepOutputs["plan"] == nil || state.StepOutputs["plan"].Content != "plan for login page" {
+		t.Errorf("Expected the plan output to be saved, got %v", state.StepOutputs["plan"])
+	}
+
+	// Resume in a fresh workflow: the completed step is skipped
+	resumed := NewWorkflow(
+		WithWorkflowID("coder"),
+		WithWorkflowSteps([]interface{}{
+			func(input *StepInput) (*StepOutput, error) {
+				t.Error("Completed step should not run again")
+				return nil, fmt.Errorf("plan re-executed")
+			},
+			func(input *StepInput) (*StepOutput, error) {
+				plan := input.GetStepOutput("plan")
+				if plan == nil {
+					return nil, fmt.Errorf("plan output missing")
+				}
+				if input.PreviousStepContent != "plan for login page" {
+					return nil, fmt.Errorf("unexpected previous content: %v", input.PreviousStepContent)
+				}
+				return &StepOutput{Content: "code for " + input.GetMessageAsString(), StepName: "code"}, nil
+			},
+			step3,
+		}),
+	)
+	resp, err = resumed.ResumeWorkflow(ctx, runID, store)
+	if err != nil {
+		t.Fatalf("ResumeWorkflow failed: %v", err)
+	}
+	if resp.RunID != runID {
+		t.Errorf("Expected run ID %s, got %s", runID, resp.RunID)
+	}
+	if resp.Content != "reviewed code for login page" {
+		t.Errorf("Unexpected content: %v", resp.Content)
+	}
+
+	state, err = store.LoadState(ctx, "coder", runID)
+	if err != nil {
+		t.Fatalf("LoadState failed: %v", err)
+	}
+	if state.Status != RunStatusCompleted || state.NextStepIdx != 3 {
+		t.Errorf("Expected a completed run at step 3, got %s at %d", state.Status, state.NextStepIdx)
+	}
+
+	if _, err := resumed.ResumeWorkflow(ctx, runID, store); err == nil {
+		t.Error("Expected an error resuming a completed run")
+	}
+	if _, err := resumed.ResumeWorkflow(ctx, "unknown", store); err == nil {
+		t.Error("Expected an error resuming an unknown run")
+	}
+}
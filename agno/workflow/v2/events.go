@@ -0,0 +1,142 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Event ordering guarantees
+//
+// Every event emitted by a workflow run carries a Sequence number that increases
+// by one per event. Handlers are called one event at a time, in Sequence order,
+// so a handler never sees an event before one with a lower Sequence.
+//
+// StepPath identifies the step an event belongs to, joining the names of the
+// enclosing constructs with "/", e.g. "review_loop/iteration_0/validator" or
+// "research/web_search". For every step, its StepStarted event is delivered before
+// any StepOutput or StepCompleted event with the same StepPath, even when sibling
+// branches of a Parallel interleave their events.
+//
+// Handlers must not block for long and must not emit events themselves.
+
+// eventScope carries the running workflow and the path of the enclosing construct
+// through the context, so loops and parallels can report their inner steps
+type eventScope struct {
+	workflow *Workflow
+	path     string
+}
+
+type eventScopeKey struct{}
+
+// withEventScope returns a context whose nested steps report to w under path
+func withEventScope(ctx context.Context, w *Workflow, path string) context.Context {
+	return context.WithValue(ctx, eventScopeKey{}, &eventScope{workflow: w, path: path})
+}
+
+// eventScopeFrom returns the event scope of ctx, or nil outside a workflow run
+func eventScopeFrom(ctx context.Context) *eventScope {
+	scope, _ := ctx.Value(eventScopeKey{}).(*eventScope)
+	return scope
+}
+
+// pathFor returns the StepPath of a child named name
+func (s *eventScope) pathFor(name string) string {
+	if s == nil {
+		return name
+	}
+	return joinStepPath(s.path, name)
+}
+
+// currentPath returns the StepPath of the enclosing construct
+func (s *eventScope) currentPath() string {
+	if s == nil {
+		return ""
+	}
+	return s.path
+}
+
+// enter returns a context for the children of the construct at path
+func (s *eventScope) enter(ctx context.Context, path string) context.Context {
+	if s == nil {
+		return ctx
+	}
+	return withEventScope(ctx, s.workflow, path)
+}
+
+// emit sends an event for the step at path, if ctx belongs to a workflow run
+func (s *eventScope) emit(event WorkflowRunEvent, path string, data interface{}, metadata map[string]interface{}) {
+	if s == nil || s.workflow == nil {
+		return
+	}
+	s.workflow.emitEvent(&WorkflowRunResponseEvent{
+		Event:     event,
+		Data:      data,
+		Timestamp: time.Now(),
+		StepPath:  path,
+		Metadata:  metadata,
+	})
+}
+
+// runStep emits StepStarted and StepCompleted for a nested step around run.
+// run receives a context scoped to the step, so its own children nest under it.
+func (s *eventScope) runStep(ctx context.Context, name string, index int, run func(context.Context) (*StepOutput, error)) (*StepOutput, error) {
+	path := s.pathFor(name)
+	s.emit(StepStartedEvent, path, nil, map[string]interface{}{
+		"step_name":  name,
+		"step_index": index,
+	})
+
+	output, err := run(s.enter(ctx, path))
+
+	metadata := map[string]interface{}{
+		"step_name":  name,
+		"step_index": index,
+	}
+	if err != nil {
+		metadata["error"] = err.Error()
+	}
+	s.emit(StepCompletedEvent, path, output, metadata)
+
+	return output, err
+}
+
+// joinStepPath appends name to a StepPath
+func joinStepPath(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
+// iterationName returns the StepPath segment of a loop iteration
+func iterationName(iteration int) string {
+	return fmt.Sprintf("iteration_%d", iteration)
+}
+
+// stepItemName returns the name of a workflow item, or a positional name
+func stepItemName(item interface{}, index int) string {
+	switch v := item.(type) {
+	case *Step:
+		if v.Name != "" {
+			return v.Name
+		}
+	case *Loop:
+		if v.Name != "" {
+			return v.Name
+		}
+	case *Parallel:
+		if v.Name != "" {
+			return v.Name
+		}
+	case *Condition:
+		if v.Name != "" {
+			return v.Name
+		}
+	case *Router:
+		if v.Name != "" {
+			return v.Name
+		}
+	}
+	return fmt.Sprintf("step_%d", index)
+}
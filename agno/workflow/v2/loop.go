@@ -18,6 +18,7 @@ type Loop struct {
 	// Loop control
 	MaxIterations int
 	Condition     LoopCondition
+	BreakIf       func(*StepInput) bool
 
 	// Configuration
 	BreakOnError   bool
@@ -88,6 +89,16 @@ func WithLoopCondition(condition LoopCondition) LoopOption {
 	}
 }
 
+// WithLoopBreakIf stops the loop early when breakIf returns true. It is evaluated
+// after each successful iteration with an input whose PreviousStepContent is the
+// iteration's output and whose PreviousStepOutputs include that iteration's step
+// outputs by step name, so GetStepContent("validator") reads the latest result.
+func WithLoopBreakIf(breakIf func(*StepInput) bool) LoopOption {
+	return func(l *Loop) {
+		l.BreakIf = breakIf
+	}
+}
+
 // WithBreakOnError enables breaking the loop on error
 func WithBreakOnError(breakOnError bool) LoopOption {
 	return func(l *Loop) {
@@ -125,6 +136,12 @@ func (l *Loop) Execute(ctx context.Context, input *StepInput) (*StepOutput, erro
 	}
 
 	startTime := time.Now()
+	brokeEarly := false
+	scope := eventScopeFrom(ctx)
+	scope.emit(LoopExecutionStartedEvent, scope.currentPath(), nil, map[string]interface{}{
+		"step_name":      l.Name,
+		"max_iterations": l.MaxIterations,
+	})
 
 	for l.currentIteration = 0; l.Condition(l.currentIteration, lastOutput); l.currentIteration++ {
 		// Check context cancellation
@@ -145,7 +162,20 @@ func (l *Loop) Execute(ctx context.Context, input *StepInput) (*StepOutput, erro
 		}
 
 		// Execute steps for this iteration
-		iterationOutput, err := l.executeIteration(ctx, stepInput, l.currentIteration)
+		iterationPath := joinStepPath(scope.currentPath(), iterationName(l.currentIteration))
+		scope.emit(LoopIterationStartedEvent, iterationPath, nil, map[string]interface{}{
+			"step_name": l.Name,
+			"iteration": l.currentIteration,
+		})
+		iterationOutput, iterationSteps, err := l.executeIteration(scope.enter(ctx, iterationPath), stepInput, l.currentIteration)
+		iterationMetadata := map[string]interface{}{
+			"step_name": l.Name,
+			"iteration": l.currentIteration,
+		}
+		if err != nil {
+			iterationMetadata["error"] = err.Error()
+		}
+		scope.emit(LoopIterationCompletedEvent, iterationPath, iterationOutput, iterationMetadata)
 		if err != nil {
 			if l.BreakOnError {
 				return nil, fmt.Errorf("loop '%s' failed at iteration %d: %w", l.Name, l.currentIteration, err)
@@ -167,6 +197,13 @@ func (l *Loop) Execute(ctx context.Context, input *StepInput) (*StepOutput, erro
 			iterationKey := fmt.Sprintf("%s_iteration_%d", l.Name, l.currentIteration)
 			stepInput.PreviousStepOutputs[iterationKey] = iterationOutput
 		}
+
+		// Stop early if the break condition is met
+		if l.BreakIf != nil && l.BreakIf(l.breakInput(stepInput, iterationOutput, iterationSteps)) {
+			l.currentIteration++
+			brokeEarly = true
+			break
+		}
 	}
 
 	endTime := time.Now()
@@ -179,6 +216,7 @@ func (l *Loop) Execute(ctx context.Context, input *StepInput) (*StepOutput, erro
 		Metadata: map[string]interface{}{
 			"iterations":  l.currentIteration,
 			"duration_ms": endTime.Sub(startTime).Milliseconds(),
+			"broke_early": brokeEarly,
 		},
 	}
 
@@ -192,12 +230,41 @@ func (l *Loop) Execute(ctx context.Context, input *StepInput) (*StepOutput, erro
 		output.Content = lastOutput.Content
 	}
 
+	scope.emit(LoopExecutionCompletedEvent, scope.currentPath(), output, map[string]interface{}{
+		"step_name":  l.Name,
+		"iterations": l.currentIteration,
+	})
+
 	return output, nil
 }
 
-// executeIteration executes all steps for a single iteration
-func (l *Loop) executeIteration(ctx context.Context, input *StepInput, iteration int) (*StepOutput, error) {
+// breakInput builds the input passed to BreakIf after an iteration
+func (l *Loop) breakInput(input *StepInput, iterationOutput *StepOutput, iterationSteps map[string]*StepOutput) *StepInput {
+	breakInput := &StepInput{
+		Message:             input.Message,
+		AdditionalData:      input.AdditionalData,
+		Images:              input.Images,
+		Videos:              input.Videos,
+		Audio:               input.Audio,
+		PreviousStepOutputs: make(map[string]*StepOutput),
+	}
+	if iterationOutput != nil {
+		breakInput.PreviousStepContent = iterationOutput.Content
+	}
+	for k, v := range input.PreviousStepOutputs {
+		breakInput.PreviousStepOutputs[k] = v
+	}
+	for k, v := range iterationSteps {
+		breakInput.PreviousStepOutputs[k] = v
+	}
+	return breakInput
+}
+
+// executeIteration executes all steps for a single iteration, returning the last
+// output and the outputs of the iteration's steps by step name
+func (l *Loop) executeIteration(ctx context.Context, input *StepInput, iteration int) (*StepOutput, map[string]*StepOutput, error) {
 	var lastOutput *StepOutput
+	stepOutputs := make(map[string]*StepOutput)
 
 	// 🔥 CRIAMOS UM NOVO input para preservar o Message original
 	iterInput := &StepInput{
@@ -218,7 +285,7 @@ func (l *Loop) executeIteration(ctx context.Context, input *StepInput, iteration
 	for i, item := range l.Steps {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return nil, nil, ctx.Err()
 		default:
 		}
 
@@ -227,30 +294,33 @@ func (l *Loop) executeIteration(ctx context.Context, input *StepInput, iteration
 			iterInput.PreviousStepContent = lastOutput.Content
 		}
 
-		var output *StepOutput
-		var err error
-
-		switch v := item.(type) {
-		case *Step:
-			output, err = v.Execute(ctx, iterInput)
-		case ExecutorFunc:
-			output, err = v(iterInput)
-		case func(*StepInput) (*StepOutput, error):
-			output, err = v(iterInput)
-		case *Loop:
-			output, err = v.Execute(ctx, iterInput)
-		case *Parallel:
-			output, err = v.Execute(ctx, iterInput)
-		case *Condition:
-			output, err = v.Execute(ctx, iterInput)
-		case *Router:
-			output, err = v.Execute(ctx, iterInput)
+		switch item.(type) {
+		case *Step, ExecutorFunc, func(*StepInput) (*StepOutput, error), *Loop, *Parallel, *Condition, *Router:
 		default:
-			return nil, fmt.Errorf("unsupported step type at index %d in loop '%s': %T", i, l.Name, v)
+			return nil, nil, fmt.Errorf("unsupported step type at index %d in loop '%s': %T", i, l.Name, item)
 		}
 
+		output, err := eventScopeFrom(ctx).runStep(ctx, stepItemName(item, i), i, func(stepCtx context.Context) (*StepOutput, error) {
+			switch v := item.(type) {
+			case *Step:
+				return v.Execute(stepCtx, iterInput)
+			case ExecutorFunc:
+				return v(iterInput)
+			case func(*StepInput) (*StepOutput, error):
+				return v(iterInput)
+			case *Loop:
+				return v.Execute(stepCtx, iterInput)
+			case *Parallel:
+				return v.Execute(stepCtx, iterInput)
+			case *Condition:
+				return v.Execute(stepCtx, iterInput)
+			default:
+				return item.(*Router).Execute(stepCtx, iterInput)
+			}
+		})
+
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		if output != nil {
@@ -259,11 +329,16 @@ func (l *Loop) executeIteration(ctx context.Context, input *StepInput, iteration
 				stepName = fmt.Sprintf("%s_iteration_%d", output.StepName, iteration)
 			}
 			iterInput.PreviousStepOutputs[stepName] = output
+			if output.StepName != "" {
+				stepOutputs[output.StepName] = output
+			} else {
+				stepOutputs[stepItemName(item, i)] = output
+			}
 			lastOutput = output
 		}
 	}
 
-	return lastOutput, nil
+	return lastOutput, stepOutputs, nil
 }
 
 // Common loop conditions
@@ -2,6 +2,7 @@ package v2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -138,6 +139,11 @@ func (p *Parallel) Execute(ctx context.Context, input *StepInput) (*StepOutput,
 	p.mu.Unlock()
 
 	startTime := time.Now()
+	scope := eventScopeFrom(ctx)
+	scope.emit(ParallelExecutionStartedEvent, scope.currentPath(), nil, map[string]interface{}{
+		"step_name":   p.Name,
+		"total_steps": len(p.Steps),
+	})
 
 	// Create channels for coordination
 	type result struct {
@@ -147,13 +153,16 @@ func (p *Parallel) Execute(ctx context.Context, input *StepInput) (*StepOutput,
 	}
 
 	resultChan := make(chan result, len(p.Steps))
-	errorChan := make(chan error, len(p.Steps))
 	semaphore := make(chan struct{}, p.MaxConcurrency)
 
 	// Create a context that can be cancelled if fail-fast is enabled
 	execCtx, cancelExec := context.WithCancel(ctx)
 	defer cancelExec()
 
+	// In fail-fast mode the first failure cancels the others and is reported
+	var failFastErr error
+	var failFastOnce sync.Once
+
 	// WaitGroup to track all goroutines
 	var wg sync.WaitGroup
 
@@ -199,7 +208,9 @@ func (p *Parallel) Execute(ctx context.Context, input *StepInput) (*StepOutput,
 			}
 
 			// Execute the step
-			output, err := p.executeStep(execCtx, stepItem, stepInput)
+			output, err := scope.runStep(execCtx, name, idx, func(stepCtx context.Context) (*StepOutput, error) {
+				return p.executeWithTimeout(stepCtx, stepItem, stepInput)
+			})
 
 			// Ensure output has the step name
 			if output != nil && output.StepName == "" {
@@ -207,12 +218,12 @@ func (p *Parallel) Execute(ctx context.Context, input *StepInput) (*StepOutput,
 			}
 
 			if err != nil {
+				err = fmt.Errorf("parallel step '%s' failed: %w", name, err)
 				if p.FailFast && !p.ContinueOnError {
-					cancelExec() // Cancel all other executions
-				}
-				errorChan <- fmt.Errorf("parallel step '%s' failed: %w", name, err)
-				if !p.ContinueOnError {
-					return
+					failFastOnce.Do(func() {
+						failFastErr = err
+						cancelExec() // Cancel all other executions
+					})
 				}
 			}
 
@@ -230,20 +241,17 @@ func (p *Parallel) Execute(ctx context.Context, input *StepInput) (*StepOutput,
 	go func() {
 		wg.Wait()
 		close(resultChan)
-		close(errorChan)
 	}()
 
 	// Collect results
-	var errors []error
+	var errs []error
 	successCount := 0
 	failureCount := 0
 
 	for r := range resultChan {
 		if r.err != nil {
 			failureCount++
-			if !p.ContinueOnError {
-				errors = append(errors, r.err)
-			}
+			errs = append(errs, r.err)
 		} else {
 			successCount++
 			p.mu.Lock()
@@ -252,9 +260,13 @@ func (p *Parallel) Execute(ctx context.Context, input *StepInput) (*StepOutput,
 		}
 	}
 
-	// Check for errors
-	if len(errors) > 0 && !p.ContinueOnError {
-		return nil, fmt.Errorf("parallel execution failed with %d errors: %v", len(errors), errors[0])
+	// Check for errors: fail-fast reports the first failure, otherwise all
+	// of them are collected
+	if len(errs) > 0 && !p.ContinueOnError {
+		if failFastErr != nil {
+			return nil, failFastErr
+		}
+		return nil, fmt.Errorf("parallel execution failed with %d errors: %w", len(errs), errors.Join(errs...))
 	}
 
 	endTime := time.Now()
@@ -290,9 +302,49 @@ func (p *Parallel) Execute(ctx context.Context, input *StepInput) (*StepOutput,
 		}
 	}
 
+	scope.emit(ParallelExecutionCompletedEvent, scope.currentPath(), output, map[string]interface{}{
+		"step_name":     p.Name,
+		"success_count": successCount,
+		"failure_count": failureCount,
+	})
+
 	return output, nil
 }
 
+// executeWithTimeout executes a single step, enforcing the TimeoutSeconds of
+// a *Step even when its executor ignores the context. A step that times out
+// is reported as failed; its goroutine is left to finish on its own.
+func (p *Parallel) executeWithTimeout(ctx context.Context, item interface{}, input *StepInput) (*StepOutput, error) {
+	step, ok := item.(*Step)
+	if !ok || step.TimeoutSeconds <= 0 {
+		return p.executeStep(ctx, item, input)
+	}
+
+	timeout := time.Duration(step.TimeoutSeconds) * time.Second
+	stepCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	type result struct {
+		output *StepOutput
+		err    error
+	}
+	done := make(chan result, 1)
+	go func() {
+		output, err := p.executeStep(stepCtx, item, input)
+		done <- result{output: output, err: err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.output, r.err
+	case <-stepCtx.Done():
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("step '%s' timed out after %s", step.Name, timeout)
+	}
+}
+
 // executeStep executes a single step
 func (p *Parallel) executeStep(ctx context.Context, item interface{}, input *StepInput) (*StepOutput, error) {
 	switch v := item.(type) {
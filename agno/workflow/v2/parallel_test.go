@@ -0,0 +1,170 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelOutputsInPreviousStepOutputs(t *testing.T) {
+	source := func(name string) func(*StepInput) (*StepOutput, error) {
+		return func(input *StepInput) (*StepOutput, error) {
+			return &StepOutput{Content: name + " results", StepName: name}, nil
+		}
+	}
+
+	var running, peak int32
+	limited := func(name string) *Step {
+		step, _ := NewStep(
+			WithName(name),
+			WithExecutor(func(input *StepInput) (*StepOutput, error) {
+				n := atomic.AddInt32(&running, 1)
+				defer atomic.AddInt32(&running, -1)
+				for {
+					p := atomic.LoadInt32(&peak)
+					if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+						break
+					}
+				}
+				time.Sleep(20 * time.Millisecond)
+				return &StepOutput{Content: name + " results"}, nil
+			}),
+		)
+		return step
+	}
+
+	research := NewParallel(
+		WithParallelName("research"),
+		WithParallelSteps(source("web"), source("papers"), limited("news"), limited("forums")),
+		WithMaxConcurrency(1),
+	)
+	summarize := func(input *StepInput) (*StepOutput, error) {
+		var parts []string
+		for _, name := range []string{"web", "papers", "news", "forums"} {
+			output := input.GetStepOutput(name)
+			if output == nil {
+				return nil, fmt.Errorf("missing output of %s", name)
+			}
+			parts = append(parts, fmt.Sprintf("%v", output.Content))
+		}
+		return &StepOutput{Content: strings.Join(parts, ", ")}, nil
+	}
+
+	workflow := NewWorkflow(WithWorkflowSteps([]interface{}{research, summarize}))
+	resp, err := workflow.Run(context.Background(), "agents")
+	if err != nil {
+		t.Fatalf("Workflow failed: %v", err)
+	}
+	if resp.Content != "web results, papers results, news results, forums results" {
+		t.Errorf("Unexpected content: %v", resp.Content)
+	}
+	if peak != 1 {
+		t.Errorf("Expected at most 1 concurrent step, got %d", peak)
+	}
+}
+
+func TestParallelFailureModes(t *testing.T) {
+	errWeb := errors.New("web unavailable")
+	errPapers := errors.New("papers unavailable")
+	failing := func(err error) func(*StepInput) (*StepOutput, error) {
+		return func(input *StepInput) (*StepOutput, error) { return nil, err }
+	}
+
+	t.Run("collect all", func(t *testing.T) {
+		var finished int32
+		slow := func(input *StepInput) (*StepOutput, error) {
+			time.Sleep(20 * time.Millisecond)
+			atomic.AddInt32(&finished, 1)
+			return &StepOutput{Content: "ok"}, nil
+		}
+		p := NewParallel(WithParallelSteps(failing(errWeb), failing(errPapers), slow))
+
+		_, err := p.Execute(context.Background(), &StepInput{Message: "q"})
+		if !errors.Is(err, errWeb) || !errors.Is(err, errPapers) {
+			t.Errorf("Expected both errors, got %v", err)
+		}
+		if finished != 1 {
+			t.Error("Expected the other steps to run to completion")
+		}
+	})
+
+	t.Run("fail fast", func(t *testing.T) {
+		release := make(chan struct{})
+		defer close(release)
+		waiting, _ := NewStep(
+			WithName("waiting"),
+			WithTimeout(10),
+			WithMaxRetries(0),
+			WithExecutor(func(input *StepInput) (*StepOutput, error) {
+				<-release
+				return &StepOutput{Content: "late"}, nil
+			}),
+		)
+		p := NewParallel(WithParallelSteps(failing(errWeb), waiting), WithFailFast(true))
+
+		start := time.Now()
+		_, err := p.Execute(context.Background(), &StepInput{Message: "q"})
+		if !errors.Is(err, errWeb) {
+			t.Errorf("Expected the first failure, got %v", err)
+		}
+		if elapsed := time.Since(start); elapsed > 5*time.Second {
+			t.Errorf("Expected the waiting step to be cancelled, took %s", elapsed)
+		}
+	})
+
+	t.Run("continue on error", func(t *testing.T) {
+		ok := func(input *StepInput) (*StepOutput, error) {
+			return &StepOutput{Content: "ok", StepName: "ok"}, nil
+		}
+		p := NewParallel(WithParallelSteps(failing(errWeb), ok), WithContinueOnError(true))
+
+		output, err := p.Execute(context.Background(), &StepInput{Message: "q"})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if output.Metadata["failure_count"] != 1 || output.Metadata["success_count"] != 1 {
+			t.Errorf("Unexpected counts: %v", output.Metadata)
+		}
+	})
+}
+
+func TestParallelStepTimeout(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	stuck, _ := NewStep(
+		WithName("stuck"),
+		WithTimeout(1),
+		WithMaxRetries(0),
+		WithExecutor(func(input *StepInput) (*StepOutput, error) {
+			<-release // Ignores the context
+			return &StepOutput{Content: "late"}, nil
+		}),
+	)
+	quick, _ := NewStep(
+		WithName("quick"),
+		WithExecutor(func(input *StepInput) (*StepOutput, error) {
+			return &StepOutput{Content: "done"}, nil
+		}),
+	)
+	p := NewParallel(WithParallelSteps(stuck, quick), WithContinueOnError(true))
+
+	start := time.Now()
+	output, err := p.Execute(context.Background(), &StepInput{Message: "q"})
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 3*time.Second {
+		t.Errorf("Expected the stuck step to time out after 1s, took %s", elapsed)
+	}
+	if output.ParallelStepOutputs["quick"] == nil || output.ParallelStepOutputs["stuck"] != nil {
+		t.Errorf("Expected only the quick output, got %v", output.ParallelStepOutputs)
+	}
+	if output.Metadata["failure_count"] != 1 {
+		t.Errorf("Expected the timeout to count as a failure, got %v", output.Metadata)
+	}
+}
@@ -0,0 +1,96 @@
+package v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// WorkflowResult is a serializable summary of the last workflow run: its
+// final content plus the output and metrics of every step
+type WorkflowResult struct {
+	WorkflowID string                 `json:"workflow_id"`
+	Name       string                 `json:"name,omitempty"`
+	RunID      string                 `json:"run_id"`
+	Status     RunStatus              `json:"status"`
+	Content    interface{}            `json:"content,omitempty"`
+	Error      string                 `json:"error,omitempty"`
+	StartTime  time.Time              `json:"start_time"`
+	EndTime    time.Time              `json:"end_time"`
+	DurationMs int64                  `json:"duration_ms"`
+	Steps      map[string]*StepResult `json:"steps"`
+}
+
+// StepResult is the outcome of one step in a WorkflowResult
+type StepResult struct {
+	Name         string                 `json:"name"`
+	ExecutorName string                 `json:"executor_name,omitempty"`
+	ExecutorType string                 `json:"executor_type,omitempty"`
+	Content      interface{}            `json:"content,omitempty"`
+	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+	Success      bool                   `json:"success"`
+	Error        string                 `json:"error,omitempty"`
+	DurationMs   int64                  `json:"duration_ms"`
+	RetryCount   int                    `json:"retry_count,omitempty"`
+}
+
+// Result returns the summary of the last run, or nil if the workflow has not
+// run yet. Steps are keyed by step name and include steps that failed.
+func (w *Workflow) Result() *WorkflowResult {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	if w.RunResponse == nil {
+		return nil
+	}
+
+	result := &WorkflowResult{
+		WorkflowID: w.WorkflowID,
+		Name:       w.Name,
+		RunID:      w.RunResponse.RunID,
+		Status:     w.RunResponse.Status,
+		Content:    w.RunResponse.Content,
+		Error:      w.metrics.Error,
+		StartTime:  w.metrics.StartTime,
+		EndTime:    w.metrics.EndTime,
+		DurationMs: w.metrics.DurationMs,
+		Steps:      make(map[string]*StepResult),
+	}
+
+	for name, output := range w.stepOutputs {
+		if output == nil {
+			continue
+		}
+		result.Steps[name] = &StepResult{
+			Name:         name,
+			ExecutorName: output.ExecutorName,
+			ExecutorType: output.ExecutorType,
+			Content:      output.Content,
+			Metadata:     output.Metadata,
+			Success:      true,
+		}
+	}
+
+	for name, metrics := range w.metrics.StepMetrics {
+		step, ok := result.Steps[name]
+		if !ok {
+			step = &StepResult{Name: name}
+			result.Steps[name] = step
+		}
+		step.Success = metrics.Success
+		step.Error = metrics.Error
+		step.DurationMs = metrics.DurationMs
+		step.RetryCount = metrics.RetryCount
+	}
+
+	return result
+}
+
+// ResultJSON returns Result encoded as indented JSON
+func (w *Workflow) ResultJSON() ([]byte, error) {
+	result := w.Result()
+	if result == nil {
+		return nil, fmt.Errorf("workflow has not run yet")
+	}
+	return json.MarshalIndent(result, "", "  ")
+}
@@ -0,0 +1,62 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestWorkflowResult(t *testing.T) {
+	draft, _ := NewStep(
+		WithName("draft"),
+		WithExecutor(func(input *StepInput) (*StepOutput, error) {
+			return &StepOutput{Content: "draft text", Metadata: map[string]interface{}{"words": 2}}, nil
+		}),
+	)
+	publish, _ := NewStep(
+		WithName("publish"),
+		WithMaxRetries(0),
+		WithExecutor(func(input *StepInput) (*StepOutput, error) {
+			return nil, errors.New("cms offline")
+		}),
+	)
+	workflow := NewWorkflow(
+		WithWorkflowName("Blog"),
+		WithWorkflowSteps([]*Step{draft, publish}),
+	)
+
+	if workflow.Result() != nil {
+		t.Error("Expected no result before the first run")
+	}
+	if _, err := workflow.ResultJSON(); err == nil {
+		t.Error("Expected an error before the first run")
+	}
+
+	if _, err := workflow.Run(context.Background(), "write"); err == nil {
+		t.Fatal("Expected the publish step to fail")
+	}
+
+	result := workflow.Result()
+	if result.Status != RunStatusFailed || result.Error == "" || result.RunID != workflow.RunID {
+		t.Errorf("Unexpected run summary: %+v", result)
+	}
+	if step := result.Steps["draft"]; step == nil || !step.Success || step.Content != "draft text" || step.Metadata["words"] != 2 {
+		t.Errorf("Unexpected draft result: %+v", step)
+	}
+	if step := result.Steps["publish"]; step == nil || step.Success || step.Error == "" {
+		t.Errorf("Unexpected publish result: %+v", step)
+	}
+
+	data, err := workflow.ResultJSON()
+	if err != nil {
+		t.Fatalf("ResultJSON failed: %v", err)
+	}
+	var decoded WorkflowResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode result: %v", err)
+	}
+	if decoded.Name != "Blog" || decoded.Steps["draft"].Content != "draft text" {
+		t.Errorf("Unexpected decoded result: %s", data)
+	}
+}
@@ -2,12 +2,14 @@ package v2
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"strings"
 	"time"
 
 	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
 )
 
 // StepExecutor represents any type that can execute a step
@@ -28,6 +30,7 @@ type Step struct {
 
 	// Step configuration
 	MaxRetries            int
+	RetryBackoff          time.Duration // Delay before the first retry, growing linearly
 	TimeoutSeconds        int
 	SkipOnFailure         bool
 	StrictInputValidation bool
@@ -36,7 +39,22 @@ type Step struct {
 	// Internal state
 	activeExecutor StepExecutor
 	executorType   string
-	retryCount     int
+}
+
+// stepRetryError is returned by Step.Execute when every attempt failed; it
+// carries the retry count so concurrent runs of a step don't share it
+type stepRetryError struct {
+	step    string
+	retries int
+	err     error
+}
+
+func (e *stepRetryError) Error() string {
+	return fmt.Sprintf("step '%s' failed after %d retries: %v", e.step, e.retries, e.err)
+}
+
+func (e *stepRetryError) Unwrap() error {
+	return e.err
 }
 
 // Agent interface (should be imported from agent package)
@@ -46,6 +64,23 @@ type Agent interface {
 	GetName() string
 }
 
+// toolOutputAgent is implemented by agents that report the output of their
+// running tools, such as the lines printed by ShellTool commands
+type toolOutputAgent interface {
+	RunStreamWithToolOutput(prompt string, fn func([]byte) error, onToolOutput toolkit.ToolOutputFunc) error
+}
+
+// runAgentStream streams an agent run, passing the output of its running
+// tools to onToolOutput when the agent can report it
+func runAgentStream(agent interface {
+	RunStream(prompt string, fn func([]byte) error) error
+}, prompt string, fn func([]byte) error, onToolOutput toolkit.ToolOutputFunc) error {
+	if a, ok := agent.(toolOutputAgent); ok {
+		return a.RunStreamWithToolOutput(prompt, fn, onToolOutput)
+	}
+	return agent.RunStream(prompt, fn)
+}
+
 // Team interface (should be imported from team package)
 type Team interface {
 	Run(prompt string) (models.RunResponse, error)
@@ -56,6 +91,7 @@ type Team interface {
 func NewStep(options ...StepOption) (*Step, error) {
 	s := &Step{
 		MaxRetries:            3,
+		RetryBackoff:          time.Second,
 		StrictInputValidation: false,
 	}
 
@@ -124,6 +160,16 @@ func WithMaxRetries(retries int) StepOption {
 	}
 }
 
+// WithStepRetries retries a failing executor up to maxRetries times, waiting
+// backoff before the first retry, 2*backoff before the second, and so on.
+// Only executor errors are retried, within the step timeout.
+func WithStepRetries(maxRetries int, backoff time.Duration) StepOption {
+	return func(s *Step) {
+		s.MaxRetries = maxRetries
+		s.RetryBackoff = backoff
+	}
+}
+
 // WithTimeout sets the timeout in seconds
 func WithTimeout(seconds int) StepOption {
 	return func(s *Step) {
@@ -237,33 +283,42 @@ func (s *Step) Execute(ctx context.Context, input *StepInput) (*StepOutput, erro
 
 	// Execute with retry logic
 	var lastErr error
+	retries := 0
+retry:
 	for attempt := 0; attempt <= s.MaxRetries; attempt++ {
 		if attempt > 0 {
 			// Log retry attempt
 			fmt.Printf("Retrying step '%s' (attempt %d/%d)\n", s.Name, attempt, s.MaxRetries)
+			retries = attempt
 		}
 
 		output, err := s.executeOnce(ctx, input)
 		if err == nil {
+			if output != nil && retries > 0 {
+				if output.Metrics == nil {
+					output.Metrics = &StepMetrics{Success: true}
+				}
+				output.Metrics.RetryCount = retries
+			}
 			return output, nil
 		}
 
 		lastErr = err
-		s.retryCount = attempt
 
 		// Don't retry if context is cancelled
 		if ctx.Err() != nil {
 			break
 		}
 
-		// Wait before retry (exponential backoff)
+		// Wait before retry (linear backoff)
 		if attempt < s.MaxRetries {
-			backoff := time.Duration(attempt+1) * time.Second
+			backoff := time.Duration(attempt+1) * s.RetryBackoff
 			select {
 			case <-time.After(backoff):
 				// Continue to next retry
 			case <-ctx.Done():
-				break
+				lastErr = fmt.Errorf("%w (retry cancelled: %v)", lastErr, ctx.Err())
+				break retry
 			}
 		}
 	}
@@ -279,10 +334,24 @@ func (s *Step) Execute(ctx context.Context, input *StepInput) (*StepOutput, erro
 				"error":  lastErr.Error(),
 				"reason": "skip_on_failure",
 			},
+			Metrics: &StepMetrics{Error: lastErr.Error(), RetryCount: retries},
 		}, nil
 	}
 
-	return nil, fmt.Errorf("step '%s' failed after %d retries: %w", s.Name, s.retryCount, lastErr)
+	return nil, &stepRetryError{step: s.Name, retries: retries, err: lastErr}
+}
+
+// stepRetryCount returns the retries made by the Step.Execute call that
+// returned output and err
+func stepRetryCount(output *StepOutput, err error) int {
+	var retryErr *stepRetryError
+	if errors.As(err, &retryErr) {
+		return retryErr.retries
+	}
+	if output != nil && output.Metrics != nil {
+		return output.Metrics.RetryCount
+	}
+	return 0
 }
 
 // executeOnce executes the step once without retry logic
@@ -399,16 +468,24 @@ func (e *agentExecutor) Execute(ctx context.Context, input *StepInput) (*StepOut
 		contentChan := make(chan string, 100)
 		errChan := make(chan error, 1)
 
+		// Report the output of running tools, e.g. shell commands, as it comes
+		scope := eventScopeFrom(ctx)
+		onToolOutput := func(out toolkit.ToolOutput) {
+			scope.emit(StepToolOutputEvent, scope.currentPath(), out, map[string]interface{}{
+				"agent_name": e.agent.GetName(),
+			})
+		}
+
 		// Start streaming in a goroutine
 		go func() {
-			err := e.agent.RunStream(message, func(chunk []byte) error {
+			err := runAgentStream(e.agent, message, func(chunk []byte) error {
 				select {
 				case contentChan <- string(chunk):
 				case <-ctx.Done():
 					return ctx.Err()
 				}
 				return nil
-			})
+			}, onToolOutput)
 			errChan <- err
 		}()
 
@@ -0,0 +1,205 @@
+package v2
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/devalexandre/agno-golang/agno/models"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
+)
+
+func TestStepRetries(t *testing.T) {
+	errTransient := errors.New("rate limited")
+
+	t.Run("retries until success", func(t *testing.T) {
+		calls := 0
+		step, _ := NewStep(
+			WithName("fetch"),
+			WithStepRetries(3, time.Millisecond),
+			WithExecutor(func(input *StepInput) (*StepOutput, error) {
+				calls++
+				if calls < 3 {
+					return nil, errTransient
+				}
+				return &StepOutput{Content: "fetched"}, nil
+			}),
+		)
+
+		workflow := NewWorkflow(WithWorkflowSteps([]*Step{step}))
+		resp, err := workflow.Run(context.Background(), "go")
+		if err != nil {
+			t.Fatalf("Workflow failed: %v", err)
+		}
+		if resp.Content != "fetched" || calls != 3 {
+			t.Errorf("Expected success on the third call, got %v after %d calls", resp.Content, calls)
+		}
+		if retries := workflow.GetMetrics().StepMetrics["fetch"].RetryCount; retries != 2 {
+			t.Errorf("Expected 2 retries in metrics, got %d", retries)
+		}
+	})
+
+	t.Run("fails after max retries", func(t *testing.T) {
+		calls := 0
+		step, _ := NewStep(
+			WithName("fetch"),
+			WithStepRetries(2, time.Millisecond),
+			WithExecutor(func(input *StepInput) (*StepOutput, error) {
+				calls++
+				return nil, errTransient
+			}),
+		)
+
+		workflow := NewWorkflow(WithWorkflowSteps([]*Step{step}))
+		if _, err := workflow.Run(context.Background(), "go"); !errors.Is(err, errTransient) {
+			t.Fatalf("Expected the executor error, got %v", err)
+		}
+		if calls != 3 {
+			t.Errorf("Expected 3 calls, got %d", calls)
+		}
+		if retries := workflow.GetMetrics().StepMetrics["fetch"].RetryCount; retries != 2 {
+			t.Errorf("Expected 2 retries in metrics, got %d", retries)
+		}
+	})
+
+	t.Run("does not retry unsuccessful output", func(t *testing.T) {
+		calls := 0
+		step, _ := NewStep(
+			WithStepRetries(3, time.Millisecond),
+			WithExecutor(func(input *StepInput) (*StepOutput, error) {
+				calls++
+				return &StepOutput{Metadata: map[string]interface{}{"success": false}}, nil
+			}),
+		)
+
+		output, err := step.Execute(context.Background(), &StepInput{})
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+		if retries := stepRetryCount(output, err); calls != 1 || retries != 0 {
+			t.Errorf("Expected a single call, got %d calls and %d retries", calls, retries)
+		}
+	})
+
+	t.Run("stops on cancellation", func(t *testing.T) {
+		step, _ := NewStep(
+			WithStepRetries(3, time.Hour),
+			WithExecutor(func(input *StepInput) (*StepOutput, error) {
+				return nil, errTransient
+			}),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		output, err := step.Execute(ctx, &StepInput{})
+		if !errors.Is(err, errTransient) {
+			t.Errorf("Expected the executor error, got %v", err)
+		}
+		if time.Since(start) > time.Second {
+			t.Error("Expected the backoff to stop on cancellation")
+		}
+		if retries := stepRetryCount(output, err); retries != 0 {
+			t.Errorf("Expected no retries, got %d", retries)
+		}
+	})
+
+	t.Run("counts retries per execution", func(t *testing.T) {
+		step, _ := NewStep(
+			WithStepRetries(2, time.Millisecond),
+			WithExecutor(func(input *StepInput) (*StepOutput, error) {
+				if input.Message == "flaky" {
+					return nil, errTransient
+				}
+				return &StepOutput{Content: "ok"}, nil
+			}),
+		)
+
+		var wg sync.WaitGroup
+		retries := make([]int, 2)
+		for i, message := range []string{"flaky", "steady"} {
+			wg.Add(1)
+			go func(i int, message string) {
+				defer wg.Done()
+				output, err := step.Execute(context.Background(), &StepInput{Message: message})
+				retries[i] = stepRetryCount(output, err)
+			}(i, message)
+		}
+		wg.Wait()
+
+		if retries[0] != 2 || retries[1] != 0 {
+			t.Errorf("Expected 2 and 0 retries, got %v", retries)
+		}
+	})
+}
+
+// shellAgent streams a reply after reporting the output of a running tool
+type shellAgent struct{}
+
+func (a *shellAgent) Run(input interface{}, opts ...interface{}) (models.RunResponse, error) {
+	return models.RunResponse{TextContent: "done"}, nil
+}
+
+func (a *shellAgent) RunStream(prompt string, fn func([]byte) error) error {
+	return fn([]byte("done"))
+}
+
+func (a *shellAgent) RunStreamWithToolOutput(prompt string, fn func([]byte) error, onToolOutput toolkit.ToolOutputFunc) error {
+	onToolOutput(toolkit.ToolOutput{Tool: "ShellTool_Execute", Stream: "stdout", Text: "ok 1 tests"})
+	return fn([]byte("done"))
+}
+
+func (a *shellAgent) GetName() string { return "shell" }
+
+func TestStepToolOutputEvents(t *testing.T) {
+	newStep := func(name string) *Step {
+		step, err := NewStep(
+			WithName(name),
+			WithAgent(&shellAgent{}),
+			WithStepStreaming(true),
+		)
+		if err != nil {
+			t.Fatalf("Failed to create step: %v", err)
+		}
+		return step
+	}
+
+	workflow := NewWorkflow(
+		WithWorkflowName("Tool Output Test"),
+		WithWorkflowSteps([]interface{}{
+			newStep("build"),
+			NewLoop(
+				WithLoopName("fix"),
+				WithLoopSteps(newStep("test")),
+				WithMaxIterations(1),
+			),
+		}),
+	)
+
+	var events []*WorkflowRunResponseEvent
+	workflow.OnEvent(StepToolOutputEvent, func(event *WorkflowRunResponseEvent) {
+		events = append(events, event)
+	})
+
+	if _, err := workflow.Run(context.Background(), "start"); err != nil {
+		t.Fatalf("Workflow failed: %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("Expected 2 tool output events, got %d", len(events))
+	}
+	for _, event := range events {
+		out, ok := event.Data.(toolkit.ToolOutput)
+		if !ok || out.Text != "ok 1 tests" || out.Stream != "stdout" {
+			t.Errorf("Unexpected tool output: %+v", event.Data)
+		}
+	}
+	if events[0].StepPath != "build" || events[1].StepPath != "fix/iteration_0/test" {
+		t.Errorf("Unexpected step paths: %q, %q", events[0].StepPath, events[1].StepPath)
+	}
+	if name := events[1].Metadata["agent_name"]; name != "shell" {
+		t.Errorf("Expected agent_name shell, got %v", name)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
 	"github.com/devalexandre/agno-golang/agno/utils"
 )
 
@@ -32,6 +33,7 @@ const (
 	StepStartedEvent                 WorkflowRunEvent = "StepStarted"
 	StepCompletedEvent               WorkflowRunEvent = "StepCompleted"
 	StepOutputEvent                  WorkflowRunEvent = "StepOutput"
+	StepToolOutputEvent              WorkflowRunEvent = "StepToolOutput"
 	StepsExecutionStartedEvent       WorkflowRunEvent = "StepsExecutionStarted"
 	StepsExecutionCompletedEvent     WorkflowRunEvent = "StepsExecutionCompleted"
 	LoopExecutionStartedEvent        WorkflowRunEvent = "LoopExecutionStarted"
@@ -59,11 +61,14 @@ type WorkflowRunResponse struct {
 	UpdatedAt  time.Time              `json:"updated_at"`
 }
 
-// WorkflowRunResponseEvent represents an event during workflow execution
+// WorkflowRunResponseEvent represents an event during workflow execution.
+// See events.go for the ordering guarantees of Sequence and StepPath.
 type WorkflowRunResponseEvent struct {
 	Event     WorkflowRunEvent       `json:"event"`
 	Data      interface{}            `json:"data,omitempty"`
 	Timestamp time.Time              `json:"timestamp"`
+	Sequence  uint64                 `json:"sequence"`
+	StepPath  string                 `json:"step_path,omitempty"`
 	Metadata  map[string]interface{} `json:"metadata,omitempty"`
 }
 
@@ -150,6 +155,9 @@ type Workflow struct {
 	stepOutputs   map[string]*StepOutput
 	metrics       *WorkflowMetrics
 	eventHandlers map[WorkflowRunEvent][]func(*WorkflowRunResponseEvent)
+	emitMu        sync.Mutex
+	eventSeq      uint64
+	checkpoint    *runCheckpoint
 }
 
 // NewWorkflow creates a new Workflow instance
@@ -298,6 +306,7 @@ func (w *Workflow) RunWithStream(ctx context.Context, input interface{}, stream
 	// Initialize run
 	w.RunID = GenerateID()
 	w.metrics.RunID = w.RunID
+	w.resetEventSequence()
 	w.metrics.StartTime = time.Now()
 
 	// Create workflow execution input
@@ -343,6 +352,7 @@ func (w *Workflow) RunWithStream(ctx context.Context, input interface{}, stream
 	})
 
 	// Execute workflow steps with streaming
+	ctx = withEventScope(ctx, w, "")
 	var finalOutput *StepOutput
 	var err error
 
@@ -431,17 +441,42 @@ func (w *Workflow) Run(ctx context.Context, input interface{}) (*WorkflowRunResp
 		return nil, fmt.Errorf("input validation failed: %w", err)
 	}
 
+	return w.run(ctx, w.createExecutionInput(input), nil)
+}
+
+// run executes the workflow steps, resuming the run saved in resume when it
+// is not nil
+func (w *Workflow) run(ctx context.Context, execInput *WorkflowExecutionInput, resume *WorkflowState) (*WorkflowRunResponse, error) {
 	// Initialize run
 	w.RunID = GenerateID()
 	w.metrics.RunID = w.RunID
+	w.resetEventSequence()
 	w.metrics.StartTime = time.Now()
 
-	// Create workflow execution input
-	execInput := w.createExecutionInput(input)
-
-	// Resume from checkpoint if durable
+	// Resume from the saved run state, or from checkpoint if durable
 	startStepIdx := 0
-	if w.Durable && w.Storage != nil && w.SessionID != "" {
+	if resume != nil {
+		w.RunID = resume.RunID
+		w.metrics.RunID = w.RunID
+		startStepIdx = resume.NextStepIdx
+		w.mu.Lock()
+		for k, v := range resume.StepOutputs {
+			w.stepOutputs[k] = v
+		}
+		w.mu.Unlock()
+
+		if startStepIdx > 0 {
+			w.emitEvent(&WorkflowRunResponseEvent{
+				Event:     "WorkflowResumed",
+				Timestamp: time.Now(),
+				Metadata: map[string]interface{}{
+					"workflow_id":   w.WorkflowID,
+					"run_id":        w.RunID,
+					"next_step_idx": startStepIdx,
+				},
+			})
+		}
+	} else if w.Durable && w.Storage != nil && w.SessionID != "" {
 		checkpoint, err := w.Storage.LoadCheckpoint(ctx, w.SessionID)
 		if err == nil && checkpoint != nil {
 			fmt.Printf("Resuming workflow %s from step index %d\n", w.WorkflowID, checkpoint.NextStepIdx)
@@ -479,6 +514,7 @@ func (w *Workflow) Run(ctx context.Context, input interface{}) (*WorkflowRunResp
 	})
 
 	// Execute workflow steps
+	ctx = withEventScope(ctx, w, "")
 	var finalOutput *StepOutput
 	var err error
 
@@ -562,7 +598,7 @@ func (w *Workflow) Run(ctx context.Context, input interface{}) (*WorkflowRunResp
 
 // executeStepSequenceWithStream executes a sequence of steps with streaming support
 func (w *Workflow) executeStepSequenceWithStream(ctx context.Context, steps []*Step, execInput *WorkflowExecutionInput, startIdx int) (*StepOutput, error) {
-	var lastOutput *StepOutput
+	lastOutput := w.resumedOutput()
 	stepInput := &StepInput{
 		Message:             execInput.Message,
 		AdditionalData:      execInput.AdditionalData,
@@ -627,16 +663,30 @@ func (w *Workflow) executeStepSequenceWithStream(ctx context.Context, steps []*S
 				contentChan := make(chan string, 100)
 				errChan := make(chan error, 1)
 
+				// Report the output of running tools, e.g. shell commands, as it comes
+				stepIndex := i
+				onToolOutput := func(out toolkit.ToolOutput) {
+					w.emitEvent(&WorkflowRunResponseEvent{
+						Event:     StepToolOutputEvent,
+						Timestamp: time.Now(),
+						Data:      out,
+						Metadata: map[string]interface{}{
+							"step_name":  stepName,
+							"step_index": stepIndex,
+						},
+					})
+				}
+
 				// Start streaming in goroutine
 				go func() {
-					err := streamingAgent.RunStream(stepInput.GetMessageAsString(), func(chunk []byte) error {
+					err := runAgentStream(streamingAgent, stepInput.GetMessageAsString(), func(chunk []byte) error {
 						select {
 						case contentChan <- string(chunk):
 						case <-ctx.Done():
 							return ctx.Err()
 						}
 						return nil
-					})
+					}, onToolOutput)
 					errChan <- err
 				}()
 
@@ -719,6 +769,7 @@ func (w *Workflow) executeStepSequenceWithStream(ctx context.Context, steps []*S
 
 		stepMetrics.EndTime = time.Now()
 		stepMetrics.DurationMs = stepMetrics.EndTime.Sub(stepMetrics.StartTime).Milliseconds()
+		stepMetrics.RetryCount = stepRetryCount(output, err)
 
 		// Store step output and metrics
 		w.mu.Lock()
@@ -750,7 +801,7 @@ func (w *Workflow) executeStepSequenceWithStream(ctx context.Context, steps []*S
 
 // executeInterfaceSequenceWithStream executes a sequence of mixed step types with streaming
 func (w *Workflow) executeInterfaceSequenceWithStream(ctx context.Context, steps []interface{}, execInput *WorkflowExecutionInput, startIdx int) (*StepOutput, error) {
-	var lastOutput *StepOutput
+	lastOutput := w.resumedOutput()
 	stepInput := &StepInput{
 		Message:             execInput.Message,
 		AdditionalData:      execInput.AdditionalData,
@@ -792,7 +843,23 @@ func (w *Workflow) executeInterfaceSequenceWithStream(ctx context.Context, steps
 		var output *StepOutput
 		var err error
 
+		// StepStarted is emitted before the step runs, so nested events follow it
+		scope := eventScopeFrom(ctx)
+		startedName := stepItemName(item, i)
+		stepPath := scope.pathFor(startedName)
+		stepCtx := scope.enter(ctx, stepPath)
+		w.emitEvent(&WorkflowRunResponseEvent{
+			Event:     StepStartedEvent,
+			Timestamp: time.Now(),
+			StepPath:  stepPath,
+			Metadata: map[string]interface{}{
+				"step_name":  startedName,
+				"step_index": i,
+			},
+		})
+
 		// Executa o passo com base no tipo
+		stepStart := time.Now()
 		switch v := item.(type) {
 		case *Step:
 			// Check if step agent supports streaming
@@ -835,6 +902,7 @@ func (w *Workflow) executeInterfaceSequenceWithStream(ctx context.Context, steps
 									ExecutorName: v.GetExecutorName(),
 									ExecutorType: v.GetExecutorType(),
 								},
+								StepPath: stepPath,
 								Metadata: map[string]interface{}{
 									"step_name":  v.Name,
 									"step_index": i,
@@ -861,11 +929,11 @@ func (w *Workflow) executeInterfaceSequenceWithStream(ctx context.Context, steps
 					}
 				} else {
 					// Fallback to regular execution
-					output, err = v.Execute(ctx, stepInput)
+					output, err = v.Execute(stepCtx, stepInput)
 				}
 			} else {
 				// Non-agent steps use regular execution
-				output, err = v.Execute(ctx, stepInput)
+				output, err = v.Execute(stepCtx, stepInput)
 			}
 		case ExecutorFunc:
 			output, err = v(stepInput)
@@ -873,7 +941,7 @@ func (w *Workflow) executeInterfaceSequenceWithStream(ctx context.Context, steps
 			output, err = v(stepInput)
 		case *Loop:
 			// Executa o loop
-			output, err = v.Execute(ctx, stepInput)
+			output, err = v.Execute(stepCtx, stepInput)
 			if err != nil {
 				return nil, err
 			}
@@ -894,15 +962,20 @@ func (w *Workflow) executeInterfaceSequenceWithStream(ctx context.Context, steps
 			// ✅ Atualiza lastOutput para o próximo passo
 			lastOutput = output
 		case *Parallel:
-			output, err = v.Execute(ctx, stepInput)
+			output, err = v.Execute(stepCtx, stepInput)
+			if err == nil {
+				w.storeParallelOutputs(v)
+			}
 		case *Condition:
-			output, err = v.Execute(ctx, stepInput)
+			output, err = v.Execute(stepCtx, stepInput)
 		case *Router:
-			output, err = v.Execute(ctx, stepInput)
+			output, err = v.Execute(stepCtx, stepInput)
 		default:
 			return nil, fmt.Errorf("unsupported step type at index %d: %T", i, v)
 		}
 
+		stepEnd := time.Now()
+
 		// Determina o nome do passo
 		stepName := fmt.Sprintf("step_%d", i)
 		if output != nil && output.StepName != "" {
@@ -920,19 +993,11 @@ func (w *Workflow) executeInterfaceSequenceWithStream(ctx context.Context, steps
 		w.metrics.StepsSucceeded++
 
 		// Emite eventos
-		w.emitEvent(&WorkflowRunResponseEvent{
-			Event:     StepStartedEvent,
-			Timestamp: time.Now(),
-			Metadata: map[string]interface{}{
-				"step_name":  stepName,
-				"step_index": i,
-			},
-		})
-
 		w.emitEvent(&WorkflowRunResponseEvent{
 			Event:     StepCompletedEvent,
 			Timestamp: time.Now(),
 			Data:      output,
+			StepPath:  stepPath,
 			Metadata: map[string]interface{}{
 				"step_name":  stepName,
 				"step_index": i,
@@ -947,25 +1012,17 @@ func (w *Workflow) executeInterfaceSequenceWithStream(ctx context.Context, steps
 				w.metrics.StepMetrics = make(map[string]*StepMetrics)
 			}
 			w.metrics.StepMetrics[stepName] = &StepMetrics{
-				Success: true,
+				StartTime:  stepStart,
+				EndTime:    stepEnd,
+				DurationMs: stepEnd.Sub(stepStart).Milliseconds(),
+				Success:    true,
+				RetryCount: stepRetryCount(output, nil),
 			}
 			w.mu.Unlock()
 
-			// Save checkpoint if durable
-			if w.Durable && w.Storage != nil && w.SessionID != "" {
-				w.mu.RLock()
-				checkpoint := &WorkflowCheckpoint{
-					SessionID:   w.SessionID,
-					RunID:       w.RunID,
-					NextStepIdx: i + 1,
-					StepOutputs: make(map[string]*StepOutput),
-					UpdatedAt:   time.Now(),
-				}
-				for k, v := range w.stepOutputs {
-					checkpoint.StepOutputs[k] = v
-				}
-				w.mu.RUnlock()
-				w.Storage.SaveCheckpoint(ctx, w.SessionID, checkpoint)
+			// Save checkpoint if durable or checkpointed
+			if err := w.saveCheckpoint(ctx, i+1, output); err != nil {
+				return nil, err
 			}
 
 			// Atualiza o PreviousStepOutputs para os próximos passos
@@ -1019,7 +1076,7 @@ func (w *Workflow) executeFunctionWorkflowWithStream(ctx context.Context, fn Exe
 
 // executeStepSequence executes a sequence of steps
 func (w *Workflow) executeStepSequence(ctx context.Context, steps []*Step, execInput *WorkflowExecutionInput, startIdx int) (*StepOutput, error) {
-	var lastOutput *StepOutput
+	lastOutput := w.resumedOutput()
 	stepInput := &StepInput{
 		Message:             execInput.Message,
 		AdditionalData:      execInput.AdditionalData,
@@ -1073,12 +1130,16 @@ func (w *Workflow) executeStepSequence(ctx context.Context, steps []*Step, execI
 		output, err := step.Execute(ctx, stepInput)
 		stepMetrics.EndTime = time.Now()
 		stepMetrics.DurationMs = stepMetrics.EndTime.Sub(stepMetrics.StartTime).Milliseconds()
+		stepMetrics.RetryCount = stepRetryCount(output, err)
 
 		if err != nil {
 			stepMetrics.Success = false
 			stepMetrics.Error = err.Error()
 			w.metrics.StepsFailed++
 			if !step.SkipOnFailure {
+				w.mu.Lock()
+				w.metrics.StepMetrics[stepName] = stepMetrics
+				w.mu.Unlock()
 				return nil, fmt.Errorf("step '%s' failed: %w", stepName, err)
 			}
 		} else {
@@ -1093,21 +1154,9 @@ func (w *Workflow) executeStepSequence(ctx context.Context, steps []*Step, execI
 		w.metrics.StepsExecuted++
 		w.mu.Unlock()
 
-		// Save checkpoint if durable
-		if w.Durable && w.Storage != nil && w.SessionID != "" {
-			w.mu.RLock()
-			checkpoint := &WorkflowCheckpoint{
-				SessionID:   w.SessionID,
-				RunID:       w.RunID,
-				NextStepIdx: i + 1,
-				StepOutputs: make(map[string]*StepOutput),
-				UpdatedAt:   time.Now(),
-			}
-			for k, v := range w.stepOutputs {
-				checkpoint.StepOutputs[k] = v
-			}
-			w.mu.RUnlock()
-			w.Storage.SaveCheckpoint(ctx, w.SessionID, checkpoint)
+		// Save checkpoint if durable or checkpointed
+		if err := w.saveCheckpoint(ctx, i+1, output); err != nil {
+			return nil, err
 		}
 
 		// Update step input for next iteration
@@ -1133,7 +1182,7 @@ func (w *Workflow) executeStepSequence(ctx context.Context, steps []*Step, execI
 
 // executeInterfaceSequence executes a sequence of mixed step types
 func (w *Workflow) executeInterfaceSequence(ctx context.Context, steps []interface{}, execInput *WorkflowExecutionInput, startIdx int) (*StepOutput, error) {
-	var lastOutput *StepOutput
+	lastOutput := w.resumedOutput()
 	stepInput := &StepInput{
 		Message:             execInput.Message,
 		AdditionalData:      execInput.AdditionalData,
@@ -1175,17 +1224,33 @@ func (w *Workflow) executeInterfaceSequence(ctx context.Context, steps []interfa
 		var output *StepOutput
 		var err error
 
+		// StepStarted is emitted before the step runs, so nested events follow it
+		scope := eventScopeFrom(ctx)
+		startedName := stepItemName(item, i)
+		stepPath := scope.pathFor(startedName)
+		stepCtx := scope.enter(ctx, stepPath)
+		w.emitEvent(&WorkflowRunResponseEvent{
+			Event:     StepStartedEvent,
+			Timestamp: time.Now(),
+			StepPath:  stepPath,
+			Metadata: map[string]interface{}{
+				"step_name":  startedName,
+				"step_index": i,
+			},
+		})
+
 		// Executa o passo com base no tipo
+		stepStart := time.Now()
 		switch v := item.(type) {
 		case *Step:
-			output, err = v.Execute(ctx, stepInput)
+			output, err = v.Execute(stepCtx, stepInput)
 		case ExecutorFunc:
 			output, err = v(stepInput)
 		case func(*StepInput) (*StepOutput, error):
 			output, err = v(stepInput)
 		case *Loop:
 			// Executa o loop
-			output, err = v.Execute(ctx, stepInput)
+			output, err = v.Execute(stepCtx, stepInput)
 			if err != nil {
 				return nil, err
 			}
@@ -1206,15 +1271,20 @@ func (w *Workflow) executeInterfaceSequence(ctx context.Context, steps []interfa
 			// ✅ Atualiza lastOutput para o próximo passo
 			lastOutput = output
 		case *Parallel:
-			output, err = v.Execute(ctx, stepInput)
+			output, err = v.Execute(stepCtx, stepInput)
+			if err == nil {
+				w.storeParallelOutputs(v)
+			}
 		case *Condition:
-			output, err = v.Execute(ctx, stepInput)
+			output, err = v.Execute(stepCtx, stepInput)
 		case *Router:
-			output, err = v.Execute(ctx, stepInput)
+			output, err = v.Execute(stepCtx, stepInput)
 		default:
 			return nil, fmt.Errorf("unsupported step type at index %d: %T", i, v)
 		}
 
+		stepEnd := time.Now()
+
 		// Determina o nome do passo
 		stepName := fmt.Sprintf("step_%d", i)
 		if output != nil && output.StepName != "" {
@@ -1232,19 +1302,11 @@ func (w *Workflow) executeInterfaceSequence(ctx context.Context, steps []interfa
 		w.metrics.StepsSucceeded++
 
 		// Emite eventos
-		w.emitEvent(&WorkflowRunResponseEvent{
-			Event:     StepStartedEvent,
-			Timestamp: time.Now(),
-			Metadata: map[string]interface{}{
-				"step_name":  stepName,
-				"step_index": i,
-			},
-		})
-
 		w.emitEvent(&WorkflowRunResponseEvent{
 			Event:     StepCompletedEvent,
 			Timestamp: time.Now(),
 			Data:      output,
+			StepPath:  stepPath,
 			Metadata: map[string]interface{}{
 				"step_name":  stepName,
 				"step_index": i,
@@ -1259,25 +1321,17 @@ func (w *Workflow) executeInterfaceSequence(ctx context.Context, steps []interfa
 				w.metrics.StepMetrics = make(map[string]*StepMetrics)
 			}
 			w.metrics.StepMetrics[stepName] = &StepMetrics{
-				Success: true,
+				StartTime:  stepStart,
+				EndTime:    stepEnd,
+				DurationMs: stepEnd.Sub(stepStart).Milliseconds(),
+				Success:    true,
+				RetryCount: stepRetryCount(output, nil),
 			}
 			w.mu.Unlock()
 
-			// Save checkpoint if durable
-			if w.Durable && w.Storage != nil && w.SessionID != "" {
-				w.mu.RLock()
-				checkpoint := &WorkflowCheckpoint{
-					SessionID:   w.SessionID,
-					RunID:       w.RunID,
-					NextStepIdx: i + 1,
-					StepOutputs: make(map[string]*StepOutput),
-					UpdatedAt:   time.Now(),
-				}
-				for k, v := range w.stepOutputs {
-					checkpoint.StepOutputs[k] = v
-				}
-				w.mu.RUnlock()
-				w.Storage.SaveCheckpoint(ctx, w.SessionID, checkpoint)
+			// Save checkpoint if durable or checkpointed
+			if err := w.saveCheckpoint(ctx, i+1, output); err != nil {
+				return nil, err
 			}
 
 			// Atualiza o PreviousStepOutputs para os próximos passos
@@ -1367,6 +1421,18 @@ func (w *Workflow) emitEvent(event *WorkflowRunResponseEvent) {
 		// TODO: Implement event storage
 	}
 
+	if event.StepPath == "" {
+		if name, ok := event.Metadata["step_name"].(string); ok {
+			event.StepPath = name
+		}
+	}
+
+	// Handlers and the WebSocket see events one at a time, in Sequence order
+	w.emitMu.Lock()
+	defer w.emitMu.Unlock()
+	w.eventSeq++
+	event.Sequence = w.eventSeq
+
 	// Call registered event handlers
 	w.mu.RLock()
 	handlers := w.eventHandlers[event.Event]
@@ -1384,6 +1450,13 @@ func (w *Workflow) emitEvent(event *WorkflowRunResponseEvent) {
 	}
 }
 
+// resetEventSequence restarts event numbering for a new run
+func (w *Workflow) resetEventSequence() {
+	w.emitMu.Lock()
+	defer w.emitMu.Unlock()
+	w.eventSeq = 0
+}
+
 // OnEvent registers an event handler for a specific event type
 func (w *Workflow) OnEvent(event WorkflowRunEvent, handler func(*WorkflowRunResponseEvent)) {
 	w.mu.Lock()
@@ -1439,6 +1512,24 @@ func (w *Workflow) loadSession(ctx context.Context) error {
 	return nil
 }
 
+// storeParallelOutputs stores the output of each step of p under its step
+// name, so later steps find them in PreviousStepOutputs
+func (w *Workflow) storeParallelOutputs(p *Parallel) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for name, output := range p.outputs {
+		if output == nil {
+			continue
+		}
+		if output.StepName != "" {
+			name = output.StepName
+		}
+		w.stepOutputs[name] = output
+	}
+}
+
 // GetStepOutput returns the output of a specific step
 func (w *Workflow) GetStepOutput(stepName string) *StepOutput {
 	w.mu.RLock()
@@ -204,6 +204,46 @@ func TestLoopWorkflow(t *testing.T) {
 	}
 }
 
+// TestLoopBreakIf tests stopping a loop early on a content condition
+func TestLoopBreakIf(t *testing.T) {
+	attempts := 0
+	validator := func(input *StepInput) (*StepOutput, error) {
+		attempts++
+		content := "retry"
+		if attempts == 2 {
+			content = "done"
+		}
+		return &StepOutput{Content: content, StepName: "validator"}, nil
+	}
+
+	loop := NewLoop(
+		WithLoopName("review"),
+		WithLoopSteps(validator),
+		WithMaxIterations(5),
+		WithLoopBreakIf(func(input *StepInput) bool {
+			return input.GetStepContent("validator") == "done"
+		}),
+	)
+
+	output, err := loop.Execute(context.Background(), &StepInput{Message: "start"})
+	if err != nil {
+		t.Fatalf("Loop failed: %v", err)
+	}
+
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts, got %d", attempts)
+	}
+	if output.Metadata["iterations"] != 2 {
+		t.Errorf("Expected 2 iterations, got %v", output.Metadata["iterations"])
+	}
+	if output.Metadata["broke_early"] != true {
+		t.Error("Expected loop to break early")
+	}
+	if output.Content != "done" {
+		t.Errorf("Expected content 'done', got %v", output.Content)
+	}
+}
+
 // TestRouterWorkflow tests routing logic
 func TestRouterWorkflow(t *testing.T) {
 	errorHandler := func(input *StepInput) (*StepOutput, error) {
@@ -497,3 +537,85 @@ func TestWorkflowEvents(t *testing.T) {
 		t.Error("No step completed events received")
 	}
 }
+
+// TestWorkflowEventOrdering tests event sequence numbers and step paths
+func TestWorkflowEventOrdering(t *testing.T) {
+	branch := func(name string) *Step {
+		step, err := NewStep(
+			WithName(name),
+			WithExecutor(func(input *StepInput) (*StepOutput, error) {
+				return &StepOutput{Content: name}, nil
+			}),
+		)
+		if err != nil {
+			t.Fatalf("Failed to create step: %v", err)
+		}
+		return step
+	}
+
+	parallel := NewParallel(
+		WithParallelName("fanout"),
+		WithParallelSteps(branch("a"), branch("b"), branch("c")),
+	)
+	loop := NewLoop(
+		WithLoopName("review"),
+		WithLoopSteps(parallel),
+		WithMaxIterations(2),
+		WithLoopCondition(ForN(2)),
+	)
+
+	workflow := NewWorkflow(
+		WithWorkflowName("Ordering Test"),
+		WithWorkflowSteps([]interface{}{loop}),
+	)
+
+	var events []*WorkflowRunResponseEvent
+	record := func(event *WorkflowRunResponseEvent) {
+		events = append(events, event)
+	}
+	for _, event := range []WorkflowRunEvent{
+		WorkflowStartedEvent, WorkflowCompletedEvent, StepStartedEvent, StepCompletedEvent,
+		LoopExecutionStartedEvent, LoopExecutionCompletedEvent, LoopIterationStartedEvent,
+		LoopIterationCompletedEvent, ParallelExecutionStartedEvent, ParallelExecutionCompletedEvent,
+	} {
+		workflow.OnEvent(event, record)
+	}
+
+	if _, err := workflow.Run(context.Background(), "start"); err != nil {
+		t.Fatalf("Workflow failed: %v", err)
+	}
+
+	started := make(map[string]bool)
+	completed := make(map[string]bool)
+	var last uint64
+	for _, event := range events {
+		if event.Sequence <= last {
+			t.Fatalf("Sequence not increasing: %d after %d", event.Sequence, last)
+		}
+		last = event.Sequence
+
+		switch event.Event {
+		case StepStartedEvent:
+			if started[event.StepPath] {
+				t.Errorf("Duplicate StepStarted for %s", event.StepPath)
+			}
+			started[event.StepPath] = true
+		case StepCompletedEvent:
+			if !started[event.StepPath] {
+				t.Errorf("StepCompleted before StepStarted for %s", event.StepPath)
+			}
+			completed[event.StepPath] = true
+		}
+	}
+
+	for _, path := range []string{
+		"review",
+		"review/iteration_0/fanout",
+		"review/iteration_0/fanout/a",
+		"review/iteration_1/fanout/c",
+	} {
+		if !started[path] || !completed[path] {
+			t.Errorf("Missing started/completed pair for %s", path)
+		}
+	}
+}
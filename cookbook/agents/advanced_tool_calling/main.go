@@ -13,6 +13,9 @@ import (
 	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
 )
 
+// Tool descriptions are generated from the method doc comments into tooldoc_gen.go
+//go:generate go run github.com/devalexandre/agno-golang/agno/tools/toolkit/cmd/tooldoc -types MathToolkit,StringToolkit -output tooldoc_gen.go
+
 // MathToolkit exemplo de toolkit com operações matemáticas
 type MathToolkit struct {
 	toolkit.Toolkit
@@ -24,22 +27,22 @@ type MathParams struct {
 	B float64 `json:"b" description:"Segundo número"`
 }
 
-// Add soma dois números
+// Add adds two numbers.
 func (mt *MathToolkit) Add(params MathParams) (float64, error) {
 	return params.A + params.B, nil
 }
 
-// Subtract subtrai dois números
+// Subtract subtracts the second number from the first.
 func (mt *MathToolkit) Subtract(params MathParams) (float64, error) {
 	return params.A - params.B, nil
 }
 
-// Multiply multiplica dois números
+// Multiply multiplies two numbers.
 func (mt *MathToolkit) Multiply(params MathParams) (float64, error) {
 	return params.A * params.B, nil
 }
 
-// Divide divide dois números
+// Divide divides the first number by the second (returns an error on division by zero).
 func (mt *MathToolkit) Divide(params MathParams) (float64, error) {
 	if params.B == 0 {
 		return 0, fmt.Errorf("divisão por zero")
@@ -57,17 +60,17 @@ type StringParams struct {
 	Text string `json:"text" description:"Texto para processar"`
 }
 
-// Uppercase converte texto para maiúsculas
+// Uppercase converts a string to uppercase.
 func (st *StringToolkit) Uppercase(params StringParams) (string, error) {
 	return fmt.Sprintf("UPPERCASE: %s", params.Text), nil
 }
 
-// Lowercase converte texto para minúsculas
+// Lowercase converts a string to lowercase.
 func (st *StringToolkit) Lowercase(params StringParams) (string, error) {
 	return fmt.Sprintf("lowercase: %s", params.Text), nil
 }
 
-// Reverse inverte o texto
+// Reverse reverses the characters in a string.
 func (st *StringToolkit) Reverse(params StringParams) (string, error) {
 	runes := []rune(params.Text)
 	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
@@ -106,19 +109,21 @@ func main() {
 	stringToolkit.Name = "string"
 	stringToolkit.Description = "Operações com strings"
 
-	// Register math methods using exact Go method names
-	mathToolkit.Register("Add", "Add two numbers", mathToolkit, mathToolkit.Add, MathParams{})
-	mathToolkit.Register("Subtract", "Subtract the second number from the first", mathToolkit, mathToolkit.Subtract, MathParams{})
-	mathToolkit.Register("Multiply", "Multiply two numbers", mathToolkit, mathToolkit.Multiply, MathParams{})
-	mathToolkit.Register("Divide", "Divide the first number by the second (returns error on division by zero)", mathToolkit, mathToolkit.Divide, MathParams{})
-
-	// Register string methods using exact Go method names
-	stringToolkit.Register("Uppercase", "Convert a string to uppercase", stringToolkit, stringToolkit.Uppercase, StringParams{})
-	stringToolkit.Register("Lowercase", "Convert a string to lowercase", stringToolkit, stringToolkit.Lowercase, StringParams{})
-	stringToolkit.Register("Reverse", "Reverse the characters in a string", stringToolkit, stringToolkit.Reverse, StringParams{})
-
-	// Criar agent com ferramentas
-	ag, err := agent.NewAgent(agent.AgentConfig{
+	// Register math methods: the name is the Go method name and the
+	// description its doc comment (see go:generate above)
+	mathToolkit.RegisterFunc(mathToolkit, mathToolkit.Add, MathParams{})
+	mathToolkit.RegisterFunc(mathToolkit, mathToolkit.Subtract, MathParams{})
+	mathToolkit.RegisterFunc(mathToolkit, mathToolkit.Multiply, MathParams{})
+	mathToolkit.RegisterFunc(mathToolkit, mathToolkit.Divide, MathParams{})
+
+	// Register string methods the same way
+	stringToolkit.RegisterFunc(stringToolkit, stringToolkit.Uppercase, StringParams{})
+	stringToolkit.RegisterFunc(stringToolkit, stringToolkit.Lowercase, StringParams{})
+	stringToolkit.RegisterFunc(stringToolkit, stringToolkit.Reverse, StringParams{})
+
+	// Criar agent com ferramentas; a ferramenta "math" executa uma chamada por
+	// vez, mesmo quando o batch permite mais chamadas em paralelo
+	ag, err := agent.NewAgentWithOptions(agent.AgentConfig{
 		Context:     ctx,
 		Model:       model,
 		Name:        "Advanced Tool Calling Agent",
@@ -128,7 +133,7 @@ func main() {
 			stringToolkit,
 		},
 		Debug: true,
-	})
+	}, agent.WithToolConcurrency("math", 1))
 	if err != nil {
 		log.Fatalf("Erro ao criar agent: %v", err)
 	}
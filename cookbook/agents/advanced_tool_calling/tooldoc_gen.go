@@ -0,0 +1,17 @@
+// Code generated by tooldoc; DO NOT EDIT.
+
+package main
+
+import "github.com/devalexandre/agno-golang/agno/tools/toolkit"
+
+func init() {
+	toolkit.RegisterDocs(map[string]string{
+		"main.MathToolkit.Add":         "Adds two numbers.",
+		"main.MathToolkit.Divide":      "Divides the first number by the second (returns an error on division by zero).",
+		"main.MathToolkit.Multiply":    "Multiplies two numbers.",
+		"main.MathToolkit.Subtract":    "Subtracts the second number from the first.",
+		"main.StringToolkit.Lowercase": "Converts a string to lowercase.",
+		"main.StringToolkit.Reverse":   "Reverses the characters in a string.",
+		"main.StringToolkit.Uppercase": "Converts a string to uppercase.",
+	})
+}
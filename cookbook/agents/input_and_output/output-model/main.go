@@ -50,14 +50,16 @@ func main() {
 	// Agent using OutputModel for two-stage processing:
 	// 1. Main model generates creative content freely (no schema constraints)
 	// 2. Output model formats the content into structured JSON
-	agentWithOutputModel, err := agent.NewAgent(agent.AgentConfig{
+	// WithJSONRepair fixes the code fences and trailing commas small local
+	// models often add to their JSON
+	agentWithOutputModel, err := agent.NewAgentWithOptions(agent.AgentConfig{
 		Context:       ctx,
 		Model:         mainModel,
 		OutputModel:   outputModel, // Separate model for JSON formatting
 		Description:   "You are a creative movie script writer. Focus on creating engaging content.",
 		OutputSchema:  movieScript,
 		ParseResponse: true,
-	})
+	}, agent.WithJSONRepair(true))
 	if err != nil {
 		log.Fatalf("Failed to create agent: %v", err)
 	}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -9,6 +10,8 @@ import (
 	"github.com/devalexandre/agno-golang/agno/agent"
 	"github.com/devalexandre/agno-golang/agno/models"
 	"github.com/devalexandre/agno-golang/agno/models/ollama"
+	"github.com/devalexandre/agno-golang/agno/tools"
+	"github.com/devalexandre/agno-golang/agno/tools/toolkit"
 )
 
 func main() {
@@ -209,6 +212,48 @@ func main() {
 		fmt.Printf("\n💡 Combine metadata with SessionID and UserID for complete tracking\n")
 	}
 
+	// Scenario 7: Metadata inside tools
+	fmt.Println("\n--- Scenario 7: Metadata in Tool Calls ---")
+
+	// Tools taking a context.Context receive the run metadata, e.g. to scope
+	// queries to the caller's tenant and tag their logs with the request ID
+	ordersTool := tools.NewToolFromFunction(func(ctx context.Context, orderID string) (string, error) {
+		metadata := agent.MetadataFromContext(ctx)
+		log.Printf("[%v] looking up order %s for tenant %v", metadata["request_id"], orderID, metadata["tenant_id"])
+		if metadata["tenant_id"] != "acme_corp" {
+			return "", fmt.Errorf("order %s not found for this tenant", orderID)
+		}
+		return fmt.Sprintf("Order %s: shipped, arriving tomorrow", orderID), nil
+	}, "Get order status")
+
+	orderAgent, err := agent.NewAgent(agent.AgentConfig{
+		Name:         "Order Assistant",
+		Model:        model,
+		Instructions: "Use the order status tool to answer questions about orders.",
+		Tools:        []toolkit.Tool{ordersTool},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create agent: %v", err)
+	}
+
+	metadata7 := map[string]interface{}{
+		"request_id": "tool_req_007",
+		"tenant_id":  "acme_corp",
+	}
+
+	response7, err := orderAgent.Run(
+		"Where is order 42?",
+		agent.WithMetadata(metadata7),
+	)
+	if err != nil {
+		log.Printf("Error: %v", err)
+	} else {
+		fmt.Printf("\n👤 User: Where is order 42?\n")
+		fmt.Printf("📊 Metadata: %s\n", formatMetadata(metadata7))
+		fmt.Printf("🤖 Assistant: %s\n", response7.TextContent)
+		fmt.Printf("\n💡 Tools read metadata with agent.MetadataFromContext(ctx)\n")
+	}
+
 	fmt.Println("\n=== Demo Complete ===")
 	fmt.Println("\n✨ Key Features Demonstrated:")
 	fmt.Println("   • WithMetadata - Attach custom tracking data to requests")
@@ -216,6 +261,7 @@ func main() {
 	fmt.Println("   • Metadata for analytics (user tracking, A/B testing)")
 	fmt.Println("   • Metadata for monitoring (SLA, cost centers, regions)")
 	fmt.Println("   • Combined with SessionID and UserID")
+	fmt.Println("   • Metadata in tools for authorization and logging")
 	fmt.Println("\n💡 Use Cases:")
 	fmt.Println("   • Request tracing across microservices")
 	fmt.Println("   • A/B testing and feature flag tracking")
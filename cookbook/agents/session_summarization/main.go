@@ -126,7 +126,18 @@ func main() {
 	fmt.Println("\n\n📊 Creating Session Summary")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
 
-	summary, err := memoryManager.CreateSessionSummary(ctx, userID, sessionID, conversationMessages)
+	// Stream the summary as it is generated instead of waiting for the whole text
+	summary, err := memoryManager.CreateSessionSummaryStream(ctx, userID, sessionID, conversationMessages, func(event memory.SummaryEvent) error {
+		switch event.Type {
+		case memory.SummaryEventStarted:
+			fmt.Printf("\n⏳ Summarizing %d messages (~%d prompt tokens)...\n\n", event.Messages, event.Usage.InputTokens)
+		case memory.SummaryEventChunk:
+			fmt.Print(event.Delta)
+		case memory.SummaryEventCompleted:
+			fmt.Printf("\n\n(%d tokens generated)\n", event.Usage.OutputTokens)
+		}
+		return nil
+	})
 	if err != nil {
 		log.Fatalf("Failed to create session summary: %v", err)
 	}
@@ -138,6 +149,10 @@ func main() {
 	fmt.Printf("Created: %s\n\n", summary.CreatedAt.Format("2006-01-02 15:04:05"))
 	fmt.Printf("Summary:\n%s\n", summary.Summary)
 
+	// Usage is estimated: the models do not report token counts yet
+	fmt.Printf("\n💰 Summarization cost: %d input + %d output = %d tokens (estimated)\n",
+		summary.Usage.InputTokens, summary.Usage.OutputTokens, summary.Usage.TotalTokens)
+
 	// 7. Retrieve session summary
 	fmt.Println("\n\n🔍 Retrieving Session Summary")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
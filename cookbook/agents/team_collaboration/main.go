@@ -106,8 +106,10 @@ Be thorough but supportive in your reviews.`,
 	// editorMember := &AgentWrapper{agent: editorAgent}
 
 	// 4. Create the team
+	// With shared memory each member receives the outputs of the members
+	// before it, so the writer sees the research and the editor the draft
 	fmt.Println("\n🎯 Creating collaborative team...")
-	contentTeam := team.NewTeam(team.TeamConfig{
+	contentTeam := team.NewTeamWithOptions(team.TeamConfig{
 		Context:     ctx,
 		Name:        "Content Creation Team",
 		Description: "A team specialized in creating high-quality content",
@@ -116,7 +118,7 @@ Be thorough but supportive in your reviews.`,
 		Mode:        team.CoordinateMode,
 		Debug:       false,
 		Markdown:    false,
-	})
+	}, team.WithSharedMemory(true))
 
 	fmt.Println("✅ Team created with 3 members")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -201,6 +203,15 @@ Provide:
 
 	fmt.Printf("\n👥 Team Response:\n%s\n", teamResponse.TextContent)
 
+	fmt.Println("\n📜 Shared Transcript:")
+	for _, entry := range contentTeam.GetTranscript() {
+		if entry.Member == "" {
+			fmt.Printf("  • task: %s\n", entry.Content)
+			continue
+		}
+		fmt.Printf("  • %s (%s): %d chars\n", entry.Member, entry.Role, len(entry.Content))
+	}
+
 	// 7. Show team statistics
 	fmt.Println("\n\n📊 Team Statistics")
 	fmt.Println("━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━━")
@@ -211,6 +222,16 @@ Provide:
 	fmt.Printf("  2. %s (%s)\n", writerAgent.GetName(), writerAgent.GetRole())
 	fmt.Printf("  3. %s (%s)\n", editorAgent.GetName(), editorAgent.GetRole())
 
+	if metrics := contentTeam.GetMetrics(); metrics != nil {
+		fmt.Printf("\nRun Duration: %dms (%d tokens)\n", metrics.DurationMs, metrics.TotalTokens)
+		for _, member := range metrics.Members {
+			fmt.Printf("  • %s: %dms, %d tokens, success=%t\n", member.Name, member.DurationMs, member.TotalTokens, member.Success)
+		}
+		if slowest := metrics.SlowestMember(); slowest != nil {
+			fmt.Printf("Bottleneck: %s\n", slowest.Name)
+		}
+	}
+
 	fmt.Println("\n\n✨ Team Collaboration example completed!")
 	fmt.Println("\n💡 Key Benefits of Team Collaboration:")
 	fmt.Println("   • Specialized expertise for each task")
@@ -141,6 +141,8 @@ DIR: %s
 		Memory:                  mem,
 		MaxToolCallsFromHistory: 5,
 		NumHistoryRuns:          4,
+		// Keep huge file reads and command outputs from flooding the context
+		MaxToolOutputBytes: 32 * 1024,
 	})
 	if err != nil {
 		pterm.FgRed.Printf("✗ Failed to create analysis agent: %v\n", err)
@@ -148,12 +150,9 @@ DIR: %s
 	}
 
 	// CodePlanner Agent - Creates implementation plans
-	planner, err := agent.NewAgent(agent.AgentConfig{
-		Context: ctx,
-		Model:   model,
-		Name:    "CodePlanner",
-		Stream:  true,
-		Instructions: fmt.Sprintf(`ROLE: Developer planner
+	planner, err := analyzer.Clone(func(cfg *agent.AgentConfig) {
+		cfg.Name = "CodePlanner"
+		cfg.Instructions = fmt.Sprintf(`ROLE: Developer planner
 DIR: %s
 
 Create step-by-step implementation plan.
@@ -161,70 +160,53 @@ Create step-by-step implementation plan.
 ## Output Format
 ### Files to Modify: [list]
 ### Steps: [numbered actions]
-### Verification: [test commands]`, cwd),
-		Tools:                   toolsList,
-		Memory:                  mem,
-		MaxToolCallsFromHistory: 5,
-		NumHistoryRuns:          4,
+### Verification: [test commands]`, cwd)
 	})
 	if err != nil {
 		pterm.FgRed.Printf("✗ Failed to create planning agent: %v\n", err)
 		os.Exit(1)
 	}
 
-	executor, err := agent.NewAgent(agent.AgentConfig{
-		Context: ctx,
-		Model:   model,
-		Name:    "CodeExecutor",
-		Stream:  true,
-		Instructions: fmt.Sprintf(`ROLE: Code executor
+	executor, err := analyzer.Clone(func(cfg *agent.AgentConfig) {
+		cfg.Name = "CodeExecutor"
+		cfg.Instructions = fmt.Sprintf(`ROLE: Code executor
 DIR: %s
 
 Modify files and run commands to implement the plan.
+Use PreviewWrite to check a change before WriteFile; both return a diff of the file.
 
 ## Output Format
 ### Modified Files: [list]
-### Status: ✅ Success | ❌ Failure`, cwd),
-		Tools:                   toolsList,
-		Memory:                  mem,
-		MaxToolCallsFromHistory: 5,
-		NumHistoryRuns:          4,
+### Status: ✅ Success | ❌ Failure`, cwd)
 	})
 	if err != nil {
 		pterm.FgRed.Printf("✗ Failed to create execution agent: %v\n", err)
 		os.Exit(1)
 	}
 
-	validator, err := agent.NewAgent(agent.AgentConfig{
-		Context: ctx,
-		Model:   model,
-		Name:    "CodeValidator",
-		Stream:  true,
-		Instructions: fmt.Sprintf(`ROLE: Code validator
+	validator, err := analyzer.Clone(func(cfg *agent.AgentConfig) {
+		cfg.Name = "CodeValidator"
+		// Copy toolsList so the append cannot write into the slice the other agents share
+		cfg.Tools = append(append([]toolkit.Tool{}, toolsList...), tools.NewGoToolkit())
+		cfg.Instructions = fmt.Sprintf(`ROLE: Code validator
 DIR: %s
 
-Run tests and build commands to verify implementation.
+Use go_build, go_vet, go_test and gofmt_check to verify the implementation.
+Report success only when every check returns success: true.
 
 ## Output Format
 ### Checks: [list]
 ### Success: Yes/No
-### Errors: [if any]`, cwd),
-		Tools:                   toolsList,
-		Memory:                  mem,
-		MaxToolCallsFromHistory: 5,
-		NumHistoryRuns:          4,
+### Errors: [if any]`, cwd)
 	})
 	if err != nil {
 		pterm.FgRed.Printf("✗ Failed to create validator agent: %v\n", err)
 		os.Exit(1)
 	}
 
-	debugger, err := agent.NewAgent(agent.AgentConfig{
-		Context: ctx,
-		Model:   model,
-		Name:    "CodeDebugger",
-		Stream:  true,
-		Instructions: fmt.Sprintf(`ROLE: Code debugger
+	debugger, err := analyzer.Clone(func(cfg *agent.AgentConfig) {
+		cfg.Name = "CodeDebugger"
+		cfg.Instructions = fmt.Sprintf(`ROLE: Code debugger
 DIR: %s
 
 Analyze failures and propose fixes.
@@ -232,11 +214,7 @@ Analyze failures and propose fixes.
 ## Output Format
 ### Error: [error description]
 ### Root Cause: [explanation]
-### Fix: [specific instructions]`, cwd),
-		Tools:                   toolsList,
-		Memory:                  mem,
-		MaxToolCallsFromHistory: 5,
-		NumHistoryRuns:          4,
+### Fix: [specific instructions]`, cwd)
 	})
 	if err != nil {
 		pterm.FgRed.Printf("✗ Failed to create debugger agent: %v\n", err)
@@ -323,6 +301,17 @@ Analyze failures and propose fixes.
 		}),
 	)
 
+	// Show the output of shell commands as they run, so long builds don't look frozen
+	workflow.OnEvent(v2.StepToolOutputEvent, func(event *v2.WorkflowRunResponseEvent) {
+		if out, ok := event.Data.(toolkit.ToolOutput); ok {
+			if out.Stream == "stderr" {
+				pterm.FgLightRed.Printf("  │ %s\n", out.Text)
+			} else {
+				pterm.FgGray.Printf("  │ %s\n", out.Text)
+			}
+		}
+	})
+
 	// Display the prompt
 	pterm.FgCyan.Printf("📝 Task: %s\n", prompt)
 	pterm.Println()
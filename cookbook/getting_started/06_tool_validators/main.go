@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"time"
 
 	"github.com/devalexandre/agno-golang/agno/agent"
 	"github.com/devalexandre/agno-golang/agno/models"
@@ -74,8 +75,8 @@ func createAccount(email string, age int) (string, error) {
 			"  Email: %s\n"+
 			"  Age: %d\n"+
 			"  Status: Active\n"+
-			"  Created: 2025-01-01T10:00:00Z",
-		email, age,
+			"  Created: %s",
+		email, age, time.Now().UTC().Format(time.RFC3339),
 	), nil
 }
 
@@ -97,8 +98,8 @@ func processPayment(email string, amount float64) (string, error) {
 			"  Amount: $%.2f\n"+
 			"  Status: Completed\n"+
 			"  TransactionID: TXN-%d\n"+
-			"  Timestamp: 2025-01-01T10:30:00Z",
-		amount, int(amount)%1000,
+			"  Timestamp: %s",
+		amount, int(amount)%1000, time.Now().UTC().Format(time.RFC3339),
 	), nil
 }
 
@@ -117,8 +118,8 @@ func getUserInfo(email string) (string, error) {
 			"  Email: %s\n"+
 			"  Account Status: Active\n"+
 			"  Joined: 2024-01-15\n"+
-			"  Last Login: 2025-01-01T09:00:00Z",
-		email,
+			"  Last Login: %s",
+		email, time.Now().UTC().Add(-time.Hour).Format(time.RFC3339),
 	), nil
 }
 
@@ -149,6 +149,8 @@ func main() {
 		Tools:         []toolkit.Tool{dbTool},
 		ShowToolsCall: true,
 		Debug:         false,
+		// Truncate large query results before they reach the model
+		MaxToolOutputBytes: 16 * 1024,
 		Instructions: `You are a helpful database assistant with access to structured database tools. 
 
 		Important guidelines: 
@@ -36,8 +36,8 @@ func main() {
 		log.Fatalf("Failed to create Ollama model: %v", err)
 	}
 
-	// Create Slack tool
-	slackTool := tools.NewSlackTool(slackToken)
+	// Create Slack tool, limited to the channels used in this demo
+	slackTool := tools.NewSlackTool(slackToken, tools.WithSlackAllowedChannels("#general"))
 
 	// Create agent with Slack tool
 	agentInstance, err := agent.NewAgent(agent.AgentConfig{
@@ -55,7 +55,7 @@ func main() {
 	fmt.Println("\n1️⃣ Demo: List Slack Channels")
 	fmt.Println("-" + string(make([]byte, 50)))
 
-	response, err := agentInstance.Run(ctx, "List all channels in the Slack workspace")
+	response, err := agentInstance.Run("List all channels in the Slack workspace")
 	if err != nil {
 		log.Printf("Error listing channels: %v", err)
 	} else {
@@ -66,7 +66,7 @@ func main() {
 	fmt.Println("\n2️⃣ Demo: Send Message to Channel")
 	fmt.Println("-" + string(make([]byte, 50)))
 
-	response, err = agentInstance.Run(ctx, "Send a message 'Hello from Agno!' to the #general channel")
+	response, err = agentInstance.Run("Send a message 'Hello from Agno!' to the #general channel")
 	if err != nil {
 		log.Printf("Error sending message: %v", err)
 	} else {
@@ -77,7 +77,7 @@ func main() {
 	fmt.Println("\n3️⃣ Demo: Get Channel History")
 	fmt.Println("-" + string(make([]byte, 50)))
 
-	response, err = agentInstance.Run(ctx, "Get the last 5 messages from the #general channel")
+	response, err = agentInstance.Run("Get the last 5 messages from the #general channel")
 	if err != nil {
 		log.Printf("Error getting history: %v", err)
 	} else {
@@ -88,7 +88,7 @@ func main() {
 	fmt.Println("\n4️⃣ Demo: Search Messages")
 	fmt.Println("-" + string(make([]byte, 50)))
 
-	response, err = agentInstance.Run(ctx, "Search for messages containing 'meeting' in the workspace")
+	response, err = agentInstance.Run("Search for messages containing 'meeting' in the workspace")
 	if err != nil {
 		log.Printf("Error searching messages: %v", err)
 	} else {
@@ -99,7 +99,7 @@ func main() {
 	fmt.Println("\n5️⃣ Demo: List Users")
 	fmt.Println("-" + string(make([]byte, 50)))
 
-	response, err = agentInstance.Run(ctx, "List all users in the workspace")
+	response, err = agentInstance.Run("List all users in the workspace")
 	if err != nil {
 		log.Printf("Error listing users: %v", err)
 	} else {
@@ -114,7 +114,7 @@ func main() {
 	paramsJSON, _ := json.Marshal(map[string]interface{}{
 		"limit": 10,
 	})
-	result, err := slackTool.Execute("list_channels", paramsJSON)
+	result, err := slackTool.Execute("slack_listChannels", paramsJSON)
 	if err != nil {
 		log.Printf("Error: %v", err)
 	} else {
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -238,14 +239,24 @@ func main() {
 	fmt.Println("\n7️⃣ Demo: Hybrid Search")
 	fmt.Println("-" + string(make([]byte, 50)))
 
-	hybridResults, err := qdrantDB.HybridSearch(ctx, "learning systems", 3, nil)
-	if err != nil {
-		log.Fatalf("Failed to perform hybrid search: %v", err)
+	// Switch the search type at runtime to compare both on the same collection
+	for _, searchType := range []vectordb.SearchType{vectordb.SearchTypeVector, vectordb.SearchTypeHybrid} {
+		qdrantDB.SetSearchType(searchType)
+		searchResults, err := qdrantDB.Search(ctx, "learning systems", 3, nil)
+		if err != nil {
+			log.Fatalf("Failed to perform %s search: %v", searchType, err)
+		}
+
+		fmt.Printf("Top 3 %s search results for 'learning systems':\n", searchType)
+		for i, result := range searchResults {
+			fmt.Printf("  %d. %s (score: %.4f)\n", i+1, result.Document.Name, result.Score)
+		}
 	}
+	qdrantDB.SetSearchType(vectordb.SearchTypeVector)
 
-	fmt.Printf("Top 3 hybrid search results for 'learning systems':\n")
-	for i, result := range hybridResults {
-		fmt.Printf("  %d. %s (score: %.4f)\n", i+1, result.Document.Name, result.Score)
+	// One-off searches must use the distance the collection was created with
+	if _, err := qdrantDB.SearchWithDistance(ctx, "learning systems", vectordb.DistanceEuclidean, 3, nil); errors.Is(err, vectordb.ErrDistanceMismatch) {
+		fmt.Printf("Euclidean search rejected: %v\n", err)
 	}
 
 	// Demo 8: Delete by Filter